@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/boreq/errors"
@@ -22,6 +23,7 @@ const (
 	FieldPeerId       = logging.PeerIdContextLabel
 	FieldConnectionId = logging.ConnectionIdContextLabel
 	FieldStreamId     = logging.StreamIdContextLabel
+	FieldProcedure    = "procedure"
 
 	MessageLogFieldRequestNumber = "header.number"
 	MessageLogFieldFlags         = "header.flags"
@@ -44,6 +46,10 @@ type Event struct {
 	ConnectionId string
 	StreamId     string
 
+	// Procedure is set if the event is a request or a log entry which
+	// specifies the procedure.
+	Procedure string
+
 	Message *Message
 	Entry   log.Entry
 }
@@ -71,6 +77,8 @@ func NewEvent(e log.Entry) (*Event, error) {
 		PeerId:       peerIdString,
 		ConnectionId: connectionIdString,
 
+		Procedure: e[FieldProcedure],
+
 		Entry: e,
 	}
 
@@ -87,6 +95,10 @@ func NewEvent(e log.Entry) (*Event, error) {
 
 		event.StreamId = strconv.Itoa(streamIdInt)
 		event.Message = &msg
+
+		if procedure, ok := procedureFromBody(e[MessageLogFieldBody]); ok {
+			event.Procedure = procedure
+		}
 	} else {
 		streamIdString := e[FieldStreamId]
 		if streamIdString == "" {
@@ -130,6 +142,20 @@ func NewMessage(entry log.Entry) (Message, error) {
 	}, nil
 }
 
+// procedureFromBody returns the name of the procedure if the body is a
+// request.
+func procedureFromBody(body string) (string, bool) {
+	var request struct {
+		Name []string `json:"name"`
+	}
+
+	if err := json.Unmarshal([]byte(body), &request); err != nil || len(request.Name) == 0 {
+		return "", false
+	}
+
+	return strings.Join(request.Name, "."), true
+}
+
 func prettifyBody(body string) (string, error) {
 	decoded, err := messages.NewEbtReplicateNotesFromBytes([]byte(body))
 	if err == nil {
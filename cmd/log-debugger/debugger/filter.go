@@ -0,0 +1,157 @@
+package debugger
+
+import (
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/boreq/errors"
+)
+
+const (
+	FilterParamPeerId       = "peer"
+	FilterParamConnectionId = "connection"
+	FilterParamProcedure    = "procedure"
+	FilterParamFrom         = "from"
+	FilterParamTo           = "to"
+	FilterParamSearch       = "search"
+
+	// FilterTimeFormat is the format of the time range parameters.
+	FilterTimeFormat = time.RFC3339
+)
+
+// Filter selects events. Zero values match everything.
+type Filter struct {
+	PeerId       string
+	ConnectionId string
+
+	// Procedure matches streams which were opened to call the procedure
+	// e.g. "createHistoryStream" or "blobs.get".
+	Procedure string
+
+	// From and To limit events to a time range, both ends are inclusive.
+	From time.Time
+	To   time.Time
+
+	// Search matches events whose message body or log fields contain the
+	// string, ignoring case.
+	Search string
+}
+
+func NewFilterFromQuery(query url.Values) (Filter, error) {
+	filter := Filter{
+		PeerId:       query.Get(FilterParamPeerId),
+		ConnectionId: query.Get(FilterParamConnectionId),
+		Procedure:    query.Get(FilterParamProcedure),
+		Search:       query.Get(FilterParamSearch),
+	}
+
+	if v := query.Get(FilterParamFrom); v != "" {
+		from, err := time.Parse(FilterTimeFormat, v)
+		if err != nil {
+			return Filter{}, errors.Wrap(err, "error parsing from")
+		}
+		filter.From = from
+	}
+
+	if v := query.Get(FilterParamTo); v != "" {
+		to, err := time.Parse(FilterTimeFormat, v)
+		if err != nil {
+			return Filter{}, errors.Wrap(err, "error parsing to")
+		}
+		filter.To = to
+	}
+
+	return filter, nil
+}
+
+func (f Filter) matchesStream(peerId, connectionId string, stream Stream) bool {
+	if f.PeerId != "" && f.PeerId != peerId {
+		return false
+	}
+
+	if f.ConnectionId != "" && f.ConnectionId != connectionId {
+		return false
+	}
+
+	if f.Procedure != "" && f.Procedure != stream.Procedure {
+		return false
+	}
+
+	return true
+}
+
+func (f Filter) matchesEvent(event Event) bool {
+	if !f.From.IsZero() && event.Timestamp.Before(f.From) {
+		return false
+	}
+
+	if !f.To.IsZero() && event.Timestamp.After(f.To) {
+		return false
+	}
+
+	if f.Search != "" && !eventContains(event, f.Search) {
+		return false
+	}
+
+	return true
+}
+
+func eventContains(event Event, s string) bool {
+	s = strings.ToLower(s)
+
+	if event.Message != nil && strings.Contains(strings.ToLower(event.Message.Body), s) {
+		return true
+	}
+
+	for _, value := range event.Entry {
+		if strings.Contains(strings.ToLower(value), s) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Filter returns events matching the filter. Streams, connections and peers
+// without matching events are omitted. The returned events are sorted by their
+// timestamps.
+func (p Peers) Filter(f Filter) Peers {
+	result := NewPeers()
+
+	for peerId, connections := range p {
+		for connectionId, connection := range connections {
+			for streamId, stream := range connection {
+				if !f.matchesStream(peerId, connectionId, stream) {
+					continue
+				}
+
+				filtered := NewStream()
+				filtered.Procedure = stream.Procedure
+
+				for _, event := range stream.Events {
+					if f.matchesEvent(event) {
+						filtered.Events = append(filtered.Events, event)
+					}
+				}
+
+				if len(filtered.Events) == 0 {
+					continue
+				}
+
+				if _, ok := result[peerId]; !ok {
+					result[peerId] = NewConnections()
+				}
+
+				if _, ok := result[peerId][connectionId]; !ok {
+					result[peerId][connectionId] = NewConnection()
+				}
+
+				result[peerId][connectionId][streamId] = filtered
+			}
+		}
+	}
+
+	result.SortEvents()
+	return result
+}
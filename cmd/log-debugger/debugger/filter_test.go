@@ -0,0 +1,132 @@
+package debugger_test
+
+import (
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/planetary-social/scuttlego/cmd/log-debugger/debugger"
+	"github.com/planetary-social/scuttlego/cmd/log-debugger/debugger/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPeers_Filter(t *testing.T) {
+	start := time.Date(2023, 1, 17, 22, 19, 42, 0, time.UTC)
+
+	peers := debugger.NewPeers()
+	for _, entry := range []log.Entry{
+		newSentMessageEntry(start, "peer1", "1", 1, `{"name":["createHistoryStream"],"type":"source","args":[]}`),
+		newReceivedMessageEntry(start.Add(1*time.Second), "peer1", "1", 1, `{"key":"%someMessage"}`),
+		newSentMessageEntry(start.Add(2*time.Second), "peer1", "1", 2, `{"name":["blobs","get"],"type":"source","args":[]}`),
+		newSentMessageEntry(start.Add(3*time.Second), "peer2", "2", 1, `{"name":["createHistoryStream"],"type":"source","args":[]}`),
+	} {
+		require.NoError(t, peers.Add(entry))
+	}
+
+	testCases := []struct {
+		Name            string
+		Query           url.Values
+		ExpectedStreams []string
+	}{
+		{
+			Name:            "no_filters",
+			Query:           url.Values{},
+			ExpectedStreams: []string{"peer1/1/1", "peer1/1/2", "peer2/2/1"},
+		},
+		{
+			Name:            "peer",
+			Query:           url.Values{"peer": {"peer2"}},
+			ExpectedStreams: []string{"peer2/2/1"},
+		},
+		{
+			Name:            "connection",
+			Query:           url.Values{"connection": {"1"}},
+			ExpectedStreams: []string{"peer1/1/1", "peer1/1/2"},
+		},
+		{
+			Name:            "procedure",
+			Query:           url.Values{"procedure": {"blobs.get"}},
+			ExpectedStreams: []string{"peer1/1/2"},
+		},
+		{
+			Name:            "time_range",
+			Query:           url.Values{"from": {"2023-01-17T22:19:43Z"}, "to": {"2023-01-17T22:19:44Z"}},
+			ExpectedStreams: []string{"peer1/1/1", "peer1/1/2"},
+		},
+		{
+			Name:            "search_is_case_insensitive",
+			Query:           url.Values{"search": {"SOMEMESSAGE"}},
+			ExpectedStreams: []string{"peer1/1/1"},
+		},
+		{
+			Name:            "search_matches_entry_fields_of_events_with_messages",
+			Query:           url.Values{"search": {debugger.MessageLogMessageReceived}},
+			ExpectedStreams: []string{"peer1/1/1"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			filter, err := debugger.NewFilterFromQuery(testCase.Query)
+			require.NoError(t, err)
+
+			var streams []string
+			for peerId, connections := range peers.Filter(filter) {
+				for connectionId, connection := range connections {
+					for streamId := range connection {
+						streams = append(streams, peerId+"/"+connectionId+"/"+streamId)
+					}
+				}
+			}
+
+			require.ElementsMatch(t, testCase.ExpectedStreams, streams)
+		})
+	}
+}
+
+func TestPeers_FilterKeepsOnlyMatchingEventsAndSortsThem(t *testing.T) {
+	start := time.Date(2023, 1, 17, 22, 19, 42, 0, time.UTC)
+
+	peers := debugger.NewPeers()
+	for _, entry := range []log.Entry{
+		newReceivedMessageEntry(start.Add(2*time.Second), "peer", "1", 1, `{"key":"%second"}`),
+		newSentMessageEntry(start, "peer", "1", 1, `{"name":["createHistoryStream"],"type":"source","args":[]}`),
+		newReceivedMessageEntry(start.Add(1*time.Second), "peer", "1", 1, `{"key":"%first"}`),
+	} {
+		require.NoError(t, peers.Add(entry))
+	}
+
+	filtered := peers.Filter(debugger.Filter{From: start.Add(1 * time.Second)})
+
+	stream := filtered["peer"]["1"]["1"]
+	require.Equal(t, "createHistoryStream", stream.Procedure)
+	require.Len(t, stream.Events, 2)
+	require.Equal(t, start.Add(1*time.Second), stream.Events[0].Timestamp)
+	require.Equal(t, start.Add(2*time.Second), stream.Events[1].Timestamp)
+}
+
+func TestNewFilterFromQuery_ReturnsErrorsForInvalidTimes(t *testing.T) {
+	_, err := debugger.NewFilterFromQuery(url.Values{"from": {"yesterday"}})
+	require.Error(t, err)
+}
+
+func newSentMessageEntry(timestamp time.Time, peerId, connectionId string, requestNumber int, body string) log.Entry {
+	return newMessageEntry(timestamp, peerId, connectionId, debugger.MessageLogMessageSent, requestNumber, body)
+}
+
+func newReceivedMessageEntry(timestamp time.Time, peerId, connectionId string, streamId int, body string) log.Entry {
+	return newMessageEntry(timestamp, peerId, connectionId, debugger.MessageLogMessageReceived, -streamId, body)
+}
+
+func newMessageEntry(timestamp time.Time, peerId, connectionId, msg string, requestNumber int, body string) log.Entry {
+	return log.Entry{
+		debugger.FieldTimestamp:               timestamp.Format(debugger.TimestampFormat),
+		debugger.FieldMessage:                 msg,
+		debugger.FieldPeerId:                  peerId,
+		debugger.FieldConnectionId:            connectionId,
+		debugger.MessageLogFieldRequestNumber: strconv.Itoa(requestNumber),
+		debugger.MessageLogFieldFlags:         "<stream=true endOrError=false bodyType={json}>",
+		debugger.MessageLogFieldBody:          body,
+	}
+}
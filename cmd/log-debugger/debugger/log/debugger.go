@@ -2,7 +2,11 @@ package log
 
 import (
 	"bufio"
+	"bytes"
+	"context"
+	"io"
 	"os"
+	"time"
 
 	"github.com/boreq/errors"
 )
@@ -17,10 +21,15 @@ func LoadLog(s string) (Log, error) {
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to open the file")
 	}
+	defer file.Close()
 
+	return ReadLog(file)
+}
+
+func ReadLog(r io.Reader) (Log, error) {
 	var log Log
 
-	scanner := bufio.NewScanner(file)
+	scanner := bufio.NewScanner(r)
 	scanner.Buffer(nil, maxTokenLength)
 
 	for scanner.Scan() {
@@ -39,6 +48,61 @@ func LoadLog(s string) (Log, error) {
 	return log, nil
 }
 
+// Follow passes entries read from the reader to the provided function. Once
+// the end of the reader is reached Follow waits for more data to be appended
+// to it, checking every poll interval, until the context is cancelled. Lines
+// are only parsed once they are terminated by a newline as the rest of the
+// line may not have been written yet. Empty lines are skipped. Lines which
+// can't be parsed are passed to onInvalidLine and skipped.
+func Follow(ctx context.Context, r io.Reader, pollInterval time.Duration, fn func(Entry) error, onInvalidLine func(err error)) error {
+	reader := bufio.NewReader(r)
+
+	var line []byte
+	var discardingLine bool
+	for {
+		b, err := reader.ReadBytes('\n')
+		if !discardingLine {
+			line = append(line, b...)
+
+			if len(line) > maxTokenLength {
+				onInvalidLine(errors.New("line too long"))
+				discardingLine = true
+				line = nil
+			}
+		}
+
+		if err != nil {
+			if !errors.Is(err, io.EOF) {
+				return errors.Wrap(err, "read error")
+			}
+
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(pollInterval):
+				continue
+			}
+		}
+
+		if discardingLine {
+			discardingLine = false
+			continue
+		}
+
+		line = bytes.TrimRight(line, "\r\n")
+		if len(line) > 0 {
+			entry, err := loadLine(line)
+			if err != nil {
+				onInvalidLine(errors.Wrapf(err, "failed to load the line '%s'", string(line)))
+			} else if err := fn(entry); err != nil {
+				return errors.Wrap(err, "function returned an error")
+			}
+		}
+
+		line = nil
+	}
+}
+
 func loadLine(b []byte) (Entry, error) {
 	l := newLexer(b)
 	if err := l.lex(); err != nil {
@@ -1,7 +1,12 @@
 package log
 
 import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/planetary-social/scuttlego/internal/fixtures"
 	"github.com/stretchr/testify/require"
@@ -114,3 +119,86 @@ func TestLoadLog_LoadsLogFileCorrectly(t *testing.T) {
 		log,
 	)
 }
+
+func TestFollow_ReadsLinesAppendedToTheFile(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "scuttlego.log")
+
+	w, err := os.Create(filename)
+	require.NoError(t, err)
+	defer w.Close()
+
+	r, err := os.Open(filename)
+	require.NoError(t, err)
+	defer r.Close()
+
+	_, err = w.WriteString("a=1\n\nb=")
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	entries := make(chan Entry)
+	errCh := make(chan error)
+
+	go func() {
+		errCh <- Follow(ctx, r, 10*time.Millisecond, func(entry Entry) error {
+			entries <- entry
+			return nil
+		}, func(err error) {
+			t.Errorf("unexpected invalid line: %s", err)
+		})
+	}()
+
+	require.Equal(t, Entry{"a": "1"}, <-entries)
+
+	select {
+	case entry := <-entries:
+		t.Fatalf("unterminated line shouldn't have been read: %v", entry)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	_, err = w.WriteString("\"2\"\n")
+	require.NoError(t, err)
+
+	require.Equal(t, Entry{"b": "2"}, <-entries)
+
+	cancel()
+	require.ErrorIs(t, <-errCh, context.Canceled)
+}
+
+func TestFollow_InvalidLinesAreReportedAndSkipped(t *testing.T) {
+	input := strings.Join(
+		[]string{
+			"a=1",
+			"malformed",
+			strings.Repeat("b", maxTokenLength+1),
+			"c=2",
+			"",
+		},
+		"\n",
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	entries := make(chan Entry)
+	invalidLines := make(chan error)
+	errCh := make(chan error)
+
+	go func() {
+		errCh <- Follow(ctx, strings.NewReader(input), 10*time.Millisecond, func(entry Entry) error {
+			entries <- entry
+			return nil
+		}, func(err error) {
+			invalidLines <- err
+		})
+	}()
+
+	require.Equal(t, Entry{"a": "1"}, <-entries)
+	require.ErrorContains(t, <-invalidLines, "failed to load the line 'malformed'")
+	require.ErrorContains(t, <-invalidLines, "line too long")
+	require.Equal(t, Entry{"c": "2"}, <-entries)
+
+	cancel()
+	require.ErrorIs(t, <-errCh, context.Canceled)
+}
@@ -1,6 +1,8 @@
 package debugger
 
 import (
+	"sort"
+
 	"github.com/boreq/errors"
 	"github.com/planetary-social/scuttlego/cmd/log-debugger/debugger/log"
 )
@@ -35,6 +37,21 @@ func (p Peers) Add(e log.Entry) error {
 	return nil
 }
 
+// SortEvents sorts events in each stream by their timestamps. This is needed
+// if entries weren't added in chronological order e.g. when spans are added
+// after log entries.
+func (p Peers) SortEvents() {
+	for _, connections := range p {
+		for _, connection := range connections {
+			for _, stream := range connection {
+				sort.SliceStable(stream.Events, func(i, j int) bool {
+					return stream.Events[i].Timestamp.Before(stream.Events[j].Timestamp)
+				})
+			}
+		}
+	}
+}
+
 // Connections uses connection ids as keys.
 type Connections map[string]Connection
 
@@ -67,12 +84,18 @@ func (s Connection) AddEvent(event Event) error {
 
 	stream.Events = append(stream.Events, event)
 
+	if stream.Procedure == "" {
+		stream.Procedure = event.Procedure
+	}
+
 	s[event.StreamId] = stream
 	return nil
 }
 
 type Stream struct {
-	Events []Event
+	// Procedure is determined using the first event which specifies it.
+	Procedure string
+	Events    []Event
 }
 
 func NewStream() Stream {
@@ -0,0 +1,48 @@
+package debugger
+
+import (
+	"fmt"
+
+	"github.com/planetary-social/scuttlego/cmd/log-debugger/debugger/log"
+	"github.com/planetary-social/scuttlego/logging"
+	"github.com/planetary-social/scuttlego/tracing"
+)
+
+const (
+	FieldTraceId      = logging.TraceIdContextLabel
+	FieldSpanId       = logging.SpanIdContextLabel
+	FieldSpanName     = "span.name"
+	FieldSpanDuration = "span.duration"
+	FieldSpanError    = "span.error"
+
+	spanMessagePrefix = "span "
+)
+
+// NewEntryFromSpan converts a span to a log entry so that it can be displayed
+// next to log entries produced by the same connection or stream. The entry is
+// timestamped with the end of the span. False is returned if the span doesn't
+// belong to a connection with a known peer.
+func NewEntryFromSpan(span tracing.SpanData) (log.Entry, bool) {
+	entry := make(log.Entry)
+
+	for key, value := range span.Attributes {
+		entry[key] = fmt.Sprint(value)
+	}
+
+	if entry[FieldPeerId] == "" || entry[FieldConnectionId] == "" {
+		return nil, false
+	}
+
+	entry[FieldTimestamp] = span.End.Format(TimestampFormat)
+	entry[FieldMessage] = spanMessagePrefix + span.Name
+	entry[FieldSpanName] = span.Name
+	entry[FieldSpanDuration] = span.Duration().String()
+	entry[FieldTraceId] = span.TraceId
+	entry[FieldSpanId] = span.SpanId
+
+	if span.Error != "" {
+		entry[FieldSpanError] = span.Error
+	}
+
+	return entry, true
+}
@@ -0,0 +1,66 @@
+package debugger_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/planetary-social/scuttlego/cmd/log-debugger/debugger"
+	"github.com/planetary-social/scuttlego/cmd/log-debugger/debugger/log"
+	"github.com/planetary-social/scuttlego/logging"
+	"github.com/planetary-social/scuttlego/tracing"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewEntryFromSpan_SpansAreAddedToTheirStreams(t *testing.T) {
+	start := time.Date(2023, 1, 17, 22, 19, 42, 0, time.UTC)
+
+	span := tracing.SpanData{
+		TraceId: "trace-id",
+		SpanId:  "span-id",
+		Name:    "rpc.request",
+		Start:   start,
+		End:     start.Add(2 * time.Second),
+		Attributes: map[string]any{
+			logging.PeerIdContextLabel:       "peer-id",
+			logging.ConnectionIdContextLabel: float64(1),
+			logging.StreamIdContextLabel:     float64(-5),
+			"procedure":                      "createHistoryStream",
+		},
+		Error: "some error",
+	}
+
+	entry, ok := debugger.NewEntryFromSpan(span)
+	require.True(t, ok)
+	require.Equal(t,
+		log.Entry{
+			"time":                           "2023-01-17 22:19:44 (UTC)",
+			"msg":                            "span rpc.request",
+			"span.name":                      "rpc.request",
+			"span.duration":                  "2s",
+			"span.error":                     "some error",
+			logging.TraceIdContextLabel:      "trace-id",
+			logging.SpanIdContextLabel:       "span-id",
+			logging.PeerIdContextLabel:       "peer-id",
+			logging.ConnectionIdContextLabel: "1",
+			logging.StreamIdContextLabel:     "-5",
+			"procedure":                      "createHistoryStream",
+		},
+		entry,
+	)
+
+	peers := debugger.NewPeers()
+	require.NoError(t, peers.Add(entry))
+	require.Len(t, peers["peer-id"]["1"]["-5"].Events, 1)
+}
+
+func TestNewEntryFromSpan_SpansWithoutPeerAreSkipped(t *testing.T) {
+	span := tracing.SpanData{
+		Name: "transport.handshake",
+		Attributes: map[string]any{
+			logging.ConnectionIdContextLabel: float64(1),
+		},
+	}
+
+	_, ok := debugger.NewEntryFromSpan(span)
+	require.False(t, ok)
+}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"bytes"
+	"context"
 	"embed"
 	_ "embed"
 	"fmt"
@@ -9,11 +10,14 @@ import (
 	"net/http"
 	"os"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/boreq/errors"
 	"github.com/boreq/guinea"
 	"github.com/planetary-social/scuttlego/cmd/log-debugger/debugger"
 	"github.com/planetary-social/scuttlego/cmd/log-debugger/debugger/log"
+	"github.com/planetary-social/scuttlego/tracing"
 )
 
 //go:embed output.tmpl
@@ -22,7 +26,18 @@ var outputTemplate string
 //go:embed assets/*
 var assets embed.FS
 
-const optionPort = "port"
+const (
+	optionPort   = "port"
+	optionSpans  = "spans"
+	optionFollow = "follow"
+
+	stdinFilename = "-"
+
+	followPollInterval = 500 * time.Millisecond
+
+	// How often the report is reloaded by the browser when following the log.
+	followRefreshSeconds = 5
+)
 
 var rootCommand = guinea.Command{
 	Options: []guinea.Option{
@@ -32,18 +47,38 @@ var rootCommand = guinea.Command{
 			Default:     8080,
 			Description: "HTTP port to listen on",
 		},
+		{
+			Name:        optionSpans,
+			Type:        guinea.String,
+			Description: "path to a file with spans saved by the JSON tracing exporter",
+		},
+		{
+			Name:        optionFollow,
+			Type:        guinea.Bool,
+			Description: "keep reading the log file as it grows, always enabled when reading from stdin",
+		},
 	},
 	Arguments: []guinea.Argument{
 		{
 			Name:        "log",
 			Multiple:    false,
 			Optional:    false,
-			Description: "path to the log file",
+			Description: `path to the log file, "-" reads from stdin`,
 		},
 	},
 	Run: func(c guinea.Context) error {
-		return run(c.Arguments[0], c.Options[optionPort].Int())
+		return run(
+			c.Arguments[0],
+			c.Options[optionSpans].Str(),
+			c.Options[optionFollow].Bool() || c.Arguments[0] == stdinFilename,
+			c.Options[optionPort].Int(),
+		)
 	},
+	ShortDescription: "displays RPC streams recorded in a log",
+	Description: `Displays log entries grouped by peers, connections and streams. The report
+can be filtered using the following query parameters: peer, connection,
+procedure (e.g. createHistoryStream), from and to (RFC3339) and search which
+matches message bodies and log fields.`,
 }
 
 func main() {
@@ -53,42 +88,163 @@ func main() {
 	}
 }
 
-func run(logFilename string, port int) error {
+func run(logFilename string, spansFilename string, follow bool, port int) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	peers := newSafePeers()
+
+	if spansFilename != "" {
+		if err := addSpans(peers, spansFilename); err != nil {
+			return errors.Wrap(err, "error adding spans")
+		}
+	}
+
+	errCh := make(chan error, 2)
+
+	if follow {
+		go func() {
+			errCh <- followLog(ctx, peers, logFilename)
+		}()
+	} else {
+		if err := loadLog(peers, logFilename); err != nil {
+			return errors.Wrap(err, "error loading the log")
+		}
+	}
+
+	fmt.Printf("http://localhost:%d\n", port)
+
+	go func() {
+		errCh <- http.ListenAndServe(fmt.Sprintf(":%d", port), newHandler(logFilename, follow, peers))
+	}()
+
+	return <-errCh
+}
+
+func loadLog(peers *safePeers, logFilename string) error {
 	log, err := log.LoadLog(logFilename)
 	if err != nil {
 		return errors.Wrap(err, "failed to load the log")
 	}
 
-	g := debugger.NewPeers()
 	for _, entry := range log {
-		if err := g.Add(entry); err != nil {
+		if err := peers.Add(entry); err != nil {
+			return errors.Wrapf(err, "error adding an entry '%+v'", entry)
+		}
+	}
+
+	return nil
+}
+
+func followLog(ctx context.Context, peers *safePeers, logFilename string) error {
+	r := os.Stdin
+	if logFilename != stdinFilename {
+		f, err := os.Open(logFilename)
+		if err != nil {
+			return errors.Wrap(err, "failed to open the file")
+		}
+		defer f.Close()
+		r = f
+	}
+
+	return log.Follow(ctx, r, followPollInterval, func(entry log.Entry) error {
+		if err := peers.Add(entry); err != nil {
 			return errors.Wrapf(err, "error adding an entry '%+v'", entry)
 		}
+		return nil
+	}, func(err error) {
+		fmt.Println("skipping an invalid line", err)
+	})
+}
+
+func addSpans(peers *safePeers, spansFilename string) error {
+	f, err := os.Open(spansFilename)
+	if err != nil {
+		return errors.Wrap(err, "failed to open the file")
 	}
+	defer f.Close()
 
-	b, err := createReport(logFilename, g)
+	spans, err := tracing.ReadSpans(f)
 	if err != nil {
-		return errors.Wrap(err, "error creating the report")
+		return errors.Wrap(err, "failed to read spans")
 	}
 
-	fmt.Printf("http://localhost:%d\n", port)
+	for _, span := range spans {
+		entry, ok := debugger.NewEntryFromSpan(span)
+		if !ok {
+			continue
+		}
 
-	return http.ListenAndServe(fmt.Sprintf(":%d", port), http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		if err := peers.Add(entry); err != nil {
+			return errors.Wrapf(err, "error adding a span '%+v'", span)
+		}
+	}
+
+	return nil
+}
+
+func newHandler(logFilename string, follow bool, peers *safePeers) http.Handler {
+	return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
 		if strings.HasPrefix(request.URL.Path, "/assets") {
 			http.FileServer(http.FS(assets)).ServeHTTP(writer, request)
 			return
 		}
 
-		_, err := writer.Write(b)
+		filter, err := debugger.NewFilterFromQuery(request.URL.Query())
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		b, err := createReport(logFilename, follow, filter, peers.Filter(filter))
 		if err != nil {
+			fmt.Println("error creating the report", err)
+			http.Error(writer, "error creating the report", http.StatusInternalServerError)
+			return
+		}
+
+		if _, err := writer.Write(b); err != nil {
 			fmt.Println("writer error", err)
 		}
-	}))
+	})
+}
+
+// safePeers lets the log be read while the report is being served.
+type safePeers struct {
+	peers debugger.Peers
+	lock  sync.Mutex
+}
+
+func newSafePeers() *safePeers {
+	return &safePeers{peers: debugger.NewPeers()}
 }
 
-func createReport(logFilename string, peers debugger.Peers) ([]byte, error) {
+func (s *safePeers) Add(entry log.Entry) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.peers.Add(entry)
+}
+
+func (s *safePeers) Filter(filter debugger.Filter) debugger.Peers {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.peers.Filter(filter)
+}
+
+func createReport(logFilename string, follow bool, filter debugger.Filter, peers debugger.Peers) ([]byte, error) {
 	var funcMap = template.FuncMap{
 		"MessageTypeSent": func() debugger.MessageType { return debugger.MessageTypeSent },
+		"FormatFilterTime": func(t time.Time) string {
+			if t.IsZero() {
+				return ""
+			}
+			return t.Format(debugger.FilterTimeFormat)
+		},
+	}
+
+	var refreshSeconds int
+	if follow {
+		refreshSeconds = followRefreshSeconds
 	}
 
 	tmpl, err := template.New("output").Funcs(funcMap).Parse(outputTemplate)
@@ -99,11 +255,15 @@ func createReport(logFilename string, peers debugger.Peers) ([]byte, error) {
 	buf := &bytes.Buffer{}
 
 	if err = tmpl.Execute(buf, struct {
-		LogFilename string
-		Peers       debugger.Peers
+		LogFilename    string
+		RefreshSeconds int
+		Filter         debugger.Filter
+		Peers          debugger.Peers
 	}{
-		LogFilename: logFilename,
-		Peers:       peers,
+		LogFilename:    logFilename,
+		RefreshSeconds: refreshSeconds,
+		Filter:         filter,
+		Peers:          peers,
 	}); err != nil {
 		return nil, errors.Wrap(err, "error executing the template")
 	}
@@ -0,0 +1,137 @@
+// Package client implements a client which talks to a running scuttlego node
+// over its local socket.
+package client
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	"github.com/boreq/errors"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/planetary-social/scuttlego/logging"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc/transport"
+)
+
+// Client performs requests over a connection with the local socket of a
+// running node. Connections with the local socket don't perform a handshake
+// and are considered privileged by the node.
+type Client struct {
+	conn   *rpc.Connection
+	cancel context.CancelFunc
+}
+
+// Dial connects to the node listening on the provided unix socket. Close must
+// be called to release the connection.
+func Dial(ctx context.Context, socketPath string) (*Client, error) {
+	var dialer net.Dialer
+
+	conn, err := dialer.DialContext(ctx, "unix", socketPath)
+	if err != nil {
+		return nil, errors.Wrap(err, "error dialing the socket")
+	}
+
+	return NewClient(conn), nil
+}
+
+// NewClient takes over managing the provided connection.
+func NewClient(conn net.Conn) *Client {
+	logger := logging.NewDevNullLogger()
+
+	raw := transport.NewRawConnection(conn, logger)
+
+	rpcConn, err := rpc.NewConnection(rpc.NewConnectionId(1), false, raw, rejectingRequestHandler{}, rpc.ResponseStreamTimeouts{}, logger)
+	if err != nil {
+		panic(err) // creating a connection never fails
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	go func() {
+		_ = rpcConn.Loop(ctx)
+	}()
+
+	return &Client{
+		conn:   rpcConn,
+		cancel: cancel,
+	}
+}
+
+// Call performs an async request and returns the body of the response.
+func (c *Client) Call(ctx context.Context, req *rpc.Request) ([]byte, error) {
+	var body []byte
+
+	if err := c.Stream(ctx, req, func(b []byte) error {
+		if body != nil {
+			return errors.New("received more than one response")
+		}
+		body = b
+		return nil
+	}); err != nil {
+		return nil, errors.Wrap(err, "request failed")
+	}
+
+	if body == nil {
+		return nil, errors.New("node didn't send a response")
+	}
+
+	return body, nil
+}
+
+// Stream performs a request and calls the provided function with the body of
+// each response until the node closes the stream.
+func (c *Client) Stream(ctx context.Context, req *rpc.Request, fn func(b []byte) error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream, err := c.conn.PerformRequest(ctx, req)
+	if err != nil {
+		return errors.Wrap(err, "error performing the request")
+	}
+
+	for response := range stream.Channel() {
+		if err := response.Err; err != nil {
+			if errors.Is(err, rpc.ErrRemoteEnd) {
+				return nil
+			}
+			return newRemoteError(err)
+		}
+
+		if err := fn(response.Value.Bytes()); err != nil {
+			return errors.Wrap(err, "function returned an error")
+		}
+	}
+
+	return errors.New("stream was closed")
+}
+
+func (c *Client) Close() error {
+	c.cancel()
+	return c.conn.Close()
+}
+
+func newRemoteError(err error) error {
+	var remoteErr rpc.RemoteError
+	if !errors.As(err, &remoteErr) {
+		return err
+	}
+
+	var transport remoteErrorTransport
+	if err := jsoniter.Unmarshal(remoteErr.Response(), &transport); err != nil || transport.Error == "" {
+		return fmt.Errorf("node returned an error: %s", string(remoteErr.Response()))
+	}
+
+	return fmt.Errorf("node returned an error: %s", transport.Error)
+}
+
+type remoteErrorTransport struct {
+	Error string `json:"error"`
+}
+
+type rejectingRequestHandler struct {
+}
+
+func (r rejectingRequestHandler) HandleRequest(ctx context.Context, s rpc.Stream, req *rpc.Request) {
+	_ = s.CloseWithError(errors.New("the client doesn't handle requests"))
+}
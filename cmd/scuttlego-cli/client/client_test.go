@@ -0,0 +1,97 @@
+package client_test
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/boreq/errors"
+	"github.com/planetary-social/scuttlego/cmd/scuttlego-cli/client"
+	"github.com/planetary-social/scuttlego/internal/fixtures"
+	"github.com/planetary-social/scuttlego/logging"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc/transport"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_CallReturnsTheResponse(t *testing.T) {
+	ctx := fixtures.TestContext(t)
+	c := newTestClient(t, newServerRequestHandler([]string{`{"key":"value"}`}, nil))
+
+	req := rpc.MustNewRequest(fixtures.SomeProcedureName(), rpc.ProcedureTypeAsync, []byte("[]"))
+
+	response, err := c.Call(ctx, req)
+	require.NoError(t, err)
+	require.Equal(t, `{"key":"value"}`, string(response))
+}
+
+func TestClient_StreamReturnsAllResponses(t *testing.T) {
+	ctx := fixtures.TestContext(t)
+	c := newTestClient(t, newServerRequestHandler([]string{`1`, `2`, `3`}, nil))
+
+	req := rpc.MustNewRequest(fixtures.SomeProcedureName(), rpc.ProcedureTypeSource, []byte("[]"))
+
+	var responses []string
+	err := c.Stream(ctx, req, func(b []byte) error {
+		responses = append(responses, string(b))
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{`1`, `2`, `3`}, responses)
+}
+
+func TestClient_RemoteErrorsAreReturned(t *testing.T) {
+	ctx := fixtures.TestContext(t)
+	c := newTestClient(t, newServerRequestHandler(nil, errors.New("some error")))
+
+	req := rpc.MustNewRequest(fixtures.SomeProcedureName(), rpc.ProcedureTypeAsync, []byte("[]"))
+
+	_, err := c.Call(ctx, req)
+	require.EqualError(t, err, "request failed: node returned an error: some error")
+}
+
+func newTestClient(t *testing.T, handler rpc.RequestHandler) *client.Client {
+	clientConn, serverConn := net.Pipe()
+
+	logger := logging.NewDevNullLogger()
+	serverRpcConn, err := rpc.NewConnection(
+		rpc.NewConnectionId(1),
+		true,
+		transport.NewRawConnection(serverConn, logger),
+		handler,
+		rpc.ResponseStreamTimeouts{},
+		logger,
+	)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	go func() {
+		_ = serverRpcConn.Loop(ctx)
+	}()
+
+	c := client.NewClient(clientConn)
+	t.Cleanup(func() {
+		_ = c.Close()
+	})
+	return c
+}
+
+type serverRequestHandler struct {
+	responses []string
+	err       error
+}
+
+func newServerRequestHandler(responses []string, err error) *serverRequestHandler {
+	return &serverRequestHandler{responses: responses, err: err}
+}
+
+func (s serverRequestHandler) HandleRequest(ctx context.Context, stream rpc.Stream, req *rpc.Request) {
+	for _, response := range s.responses {
+		if err := stream.WriteMessage([]byte(response), transport.MessageBodyTypeJSON); err != nil {
+			panic(err)
+		}
+	}
+	_ = stream.CloseWithError(s.err)
+}
@@ -0,0 +1,382 @@
+package main
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/boreq/errors"
+	"github.com/boreq/guinea"
+	"github.com/planetary-social/scuttlego/cmd/scuttlego-cli/client"
+	"github.com/planetary-social/scuttlego/service/domain/bans"
+	"github.com/planetary-social/scuttlego/service/domain/blobs"
+	"github.com/planetary-social/scuttlego/service/domain/messages"
+	"github.com/planetary-social/scuttlego/service/domain/refs"
+	"github.com/planetary-social/scuttlego/service/domain/rooms/aliases"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc"
+)
+
+const (
+	optionSocket = "socket"
+	optionStart  = "start"
+	optionLimit  = "limit"
+
+	defaultSocketPath = "/var/lib/scuttlego/socket"
+
+	// stdinArgument can be passed instead of content or a filename to read
+	// from the standard input.
+	stdinArgument = "-"
+)
+
+var socketOption = guinea.Option{
+	Name:        optionSocket,
+	Type:        guinea.String,
+	Default:     defaultSocketPath,
+	Description: "path to the local socket of the node",
+}
+
+var rootCommand = guinea.Command{
+	ShortDescription: "controls a running scuttlego node",
+	Description: `Controls a running scuttlego node by connecting to its local socket, see
+localSocketPath in the node config. Responses are printed to the standard
+output as JSON, streams are printed as one JSON value per line.`,
+	Subcommands: map[string]*guinea.Command{
+		"publish": {
+			Options:   []guinea.Option{socketOption},
+			Arguments: []guinea.Argument{{Name: "content", Description: "JSON object or - to read it from stdin"}},
+			Run: func(c guinea.Context) error {
+				content, err := readArgument(c.Arguments[0])
+				if err != nil {
+					return errors.Wrap(err, "error reading the content")
+				}
+
+				args, err := messages.NewScuttlegoPublishArguments(content)
+				if err != nil {
+					return errors.Wrap(err, "invalid content")
+				}
+
+				return call(c, func() (*rpc.Request, error) { return messages.NewScuttlegoPublish(args) })
+			},
+			ShortDescription: "publishes a message",
+		},
+		"follow": {
+			Options:   []guinea.Option{socketOption},
+			Arguments: []guinea.Argument{{Name: "feed", Description: "feed ref e.g. @...=.ed25519"}},
+			Run: func(c guinea.Context) error {
+				id, err := refs.NewIdentity(c.Arguments[0])
+				if err != nil {
+					return errors.Wrap(err, "invalid feed")
+				}
+
+				args, err := messages.NewScuttlegoIdentityArguments(id)
+				if err != nil {
+					return errors.Wrap(err, "error creating arguments")
+				}
+
+				return call(c, func() (*rpc.Request, error) { return messages.NewScuttlegoFollow(args) })
+			},
+			ShortDescription: "publishes a contact message following a feed",
+		},
+		"status": {
+			Options: []guinea.Option{socketOption},
+			Run: func(c guinea.Context) error {
+				return call(c, messages.NewScuttlegoStatus)
+			},
+			ShortDescription: "displays the status of the node",
+		},
+		"get-message": {
+			Options:   []guinea.Option{socketOption},
+			Arguments: []guinea.Argument{{Name: "id", Description: "message ref e.g. %...=.sha256"}},
+			Run: func(c guinea.Context) error {
+				id, err := refs.NewMessage(c.Arguments[0])
+				if err != nil {
+					return errors.Wrap(err, "invalid message id")
+				}
+
+				args, err := messages.NewGetArguments(id)
+				if err != nil {
+					return errors.Wrap(err, "error creating arguments")
+				}
+
+				return call(c, func() (*rpc.Request, error) { return messages.NewScuttlegoGetMessage(args) })
+			},
+			ShortDescription: "displays a message",
+		},
+		"receive-log": {
+			Options: []guinea.Option{
+				socketOption,
+				{Name: optionStart, Type: guinea.Int, Default: 0, Description: "receive log sequence of the first message"},
+				{Name: optionLimit, Type: guinea.Int, Default: 100, Description: "max number of messages"},
+			},
+			Run: func(c guinea.Context) error {
+				args, err := messages.NewScuttlegoReceiveLogArguments(c.Options[optionStart].Int(), c.Options[optionLimit].Int())
+				if err != nil {
+					return errors.Wrap(err, "error creating arguments")
+				}
+
+				req, err := messages.NewScuttlegoReceiveLog(args)
+				if err != nil {
+					return errors.Wrap(err, "error creating the request")
+				}
+
+				return withClient(c, func(ctx context.Context, cli *client.Client) error {
+					return cli.Stream(ctx, req, printJSON)
+				})
+			},
+			ShortDescription: "displays messages from the receive log",
+		},
+		"blob": {
+			Subcommands: map[string]*guinea.Command{
+				"add": {
+					Options:   []guinea.Option{socketOption},
+					Arguments: []guinea.Argument{{Name: "file", Description: "filename or - to read from stdin"}},
+					Run: func(c guinea.Context) error {
+						data, err := readFile(c.Arguments[0])
+						if err != nil {
+							return errors.Wrap(err, "error reading the blob")
+						}
+
+						args, err := messages.NewScuttlegoAddBlobArguments(data)
+						if err != nil {
+							return errors.Wrap(err, "error creating arguments")
+						}
+
+						return call(c, func() (*rpc.Request, error) { return messages.NewScuttlegoAddBlob(args) })
+					},
+					ShortDescription: "stores a blob",
+				},
+				"get": {
+					Options:   []guinea.Option{socketOption},
+					Arguments: []guinea.Argument{{Name: "id", Description: "blob ref e.g. &...=.sha256"}},
+					Run: func(c guinea.Context) error {
+						id, err := refs.NewBlob(c.Arguments[0])
+						if err != nil {
+							return errors.Wrap(err, "invalid blob id")
+						}
+
+						max := blobs.MaxBlobSize()
+
+						args, err := messages.NewBlobsGetArguments(id, nil, &max)
+						if err != nil {
+							return errors.Wrap(err, "error creating arguments")
+						}
+
+						req, err := messages.NewBlobsGet(args)
+						if err != nil {
+							return errors.Wrap(err, "error creating the request")
+						}
+
+						return withClient(c, func(ctx context.Context, cli *client.Client) error {
+							return cli.Stream(ctx, req, func(b []byte) error {
+								_, err := os.Stdout.Write(b)
+								return err
+							})
+						})
+					},
+					ShortDescription: "writes a blob to stdout",
+				},
+			},
+			ShortDescription: "manages blobs",
+		},
+		"ban": {
+			Subcommands: map[string]*guinea.Command{
+				"add": {
+					Options:   []guinea.Option{socketOption},
+					Arguments: []guinea.Argument{{Name: "hash", Description: "hex encoded ban list hash"}},
+					Run: func(c guinea.Context) error {
+						args, err := newBanListArguments(c.Arguments[0])
+						if err != nil {
+							return errors.Wrap(err, "error creating arguments")
+						}
+
+						return call(c, func() (*rpc.Request, error) { return messages.NewScuttlegoAddToBanList(args) })
+					},
+					ShortDescription: "adds a hash to the ban list",
+				},
+				"remove": {
+					Options:   []guinea.Option{socketOption},
+					Arguments: []guinea.Argument{{Name: "hash", Description: "hex encoded ban list hash"}},
+					Run: func(c guinea.Context) error {
+						args, err := newBanListArguments(c.Arguments[0])
+						if err != nil {
+							return errors.Wrap(err, "error creating arguments")
+						}
+
+						return call(c, func() (*rpc.Request, error) { return messages.NewScuttlegoRemoveFromBanList(args) })
+					},
+					ShortDescription: "removes a hash from the ban list",
+				},
+			},
+			ShortDescription: "manages the ban list",
+		},
+		"invite": {
+			Subcommands: map[string]*guinea.Command{
+				"redeem": {
+					Options:   []guinea.Option{socketOption},
+					Arguments: []guinea.Argument{{Name: "invite", Description: "pub invite"}},
+					Run: func(c guinea.Context) error {
+						args, err := messages.NewScuttlegoRedeemInviteArguments(c.Arguments[0])
+						if err != nil {
+							return errors.Wrap(err, "error creating arguments")
+						}
+
+						return call(c, func() (*rpc.Request, error) { return messages.NewScuttlegoRedeemInvite(args) })
+					},
+					ShortDescription: "redeems a pub invite",
+				},
+			},
+			ShortDescription: "manages invites",
+		},
+		"room": {
+			Subcommands: map[string]*guinea.Command{
+				"alias": {
+					Subcommands: map[string]*guinea.Command{
+						"register": {
+							Options:   []guinea.Option{socketOption},
+							Arguments: []guinea.Argument{{Name: "room", Description: "multiserver address of the room"}, {Name: "alias"}},
+							Run: func(c guinea.Context) error {
+								args, err := newAliasArguments(c.Arguments[0], c.Arguments[1])
+								if err != nil {
+									return errors.Wrap(err, "error creating arguments")
+								}
+
+								return call(c, func() (*rpc.Request, error) { return messages.NewScuttlegoRegisterAlias(args) })
+							},
+							ShortDescription: "registers an alias",
+						},
+						"revoke": {
+							Options:   []guinea.Option{socketOption},
+							Arguments: []guinea.Argument{{Name: "room", Description: "multiserver address of the room"}, {Name: "alias"}},
+							Run: func(c guinea.Context) error {
+								args, err := newAliasArguments(c.Arguments[0], c.Arguments[1])
+								if err != nil {
+									return errors.Wrap(err, "error creating arguments")
+								}
+
+								return call(c, func() (*rpc.Request, error) { return messages.NewScuttlegoRevokeAlias(args) })
+							},
+							ShortDescription: "revokes an alias",
+						},
+						"list": {
+							Options:   []guinea.Option{socketOption},
+							Arguments: []guinea.Argument{{Name: "room", Description: "multiserver address of the room"}},
+							Run: func(c guinea.Context) error {
+								args, err := messages.NewScuttlegoRoomArguments(c.Arguments[0])
+								if err != nil {
+									return errors.Wrap(err, "error creating arguments")
+								}
+
+								return call(c, func() (*rpc.Request, error) { return messages.NewScuttlegoListAliases(args) })
+							},
+							ShortDescription: "lists registered aliases",
+						},
+					},
+					ShortDescription: "manages room aliases",
+				},
+			},
+			ShortDescription: "manages rooms",
+		},
+		"connect": {
+			Options:   []guinea.Option{socketOption},
+			Arguments: []guinea.Argument{{Name: "address", Description: "multiserver address of the peer"}},
+			Run: func(c guinea.Context) error {
+				args, err := messages.NewScuttlegoConnectArguments(c.Arguments[0])
+				if err != nil {
+					return errors.Wrap(err, "error creating arguments")
+				}
+
+				return call(c, func() (*rpc.Request, error) { return messages.NewScuttlegoConnect(args) })
+			},
+			ShortDescription: "connects to a peer",
+		},
+	},
+}
+
+func main() {
+	if err := guinea.Run(&rootCommand); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func call(c guinea.Context, newRequest func() (*rpc.Request, error)) error {
+	req, err := newRequest()
+	if err != nil {
+		return errors.Wrap(err, "error creating the request")
+	}
+
+	return withClient(c, func(ctx context.Context, cli *client.Client) error {
+		response, err := cli.Call(ctx, req)
+		if err != nil {
+			return errors.Wrap(err, "call failed")
+		}
+
+		return printJSON(response)
+	})
+}
+
+func withClient(c guinea.Context, fn func(ctx context.Context, cli *client.Client) error) error {
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	cli, err := client.Dial(ctx, c.Options[optionSocket].Str())
+	if err != nil {
+		return errors.Wrap(err, "error connecting to the node")
+	}
+	defer cli.Close()
+
+	return fn(ctx, cli)
+}
+
+func printJSON(b []byte) error {
+	if _, err := fmt.Fprintln(os.Stdout, string(b)); err != nil {
+		return errors.Wrap(err, "error writing to stdout")
+	}
+	return nil
+}
+
+func readArgument(argument string) ([]byte, error) {
+	if argument == stdinArgument {
+		return io.ReadAll(os.Stdin)
+	}
+	return []byte(argument), nil
+}
+
+func readFile(filename string) ([]byte, error) {
+	if filename == stdinArgument {
+		return io.ReadAll(os.Stdin)
+	}
+	return os.ReadFile(filename)
+}
+
+func newBanListArguments(s string) (messages.ScuttlegoBanListArguments, error) {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		return messages.ScuttlegoBanListArguments{}, errors.Wrap(err, "error decoding hex")
+	}
+
+	hash, err := bans.NewHash(b)
+	if err != nil {
+		return messages.ScuttlegoBanListArguments{}, errors.Wrap(err, "invalid hash")
+	}
+
+	return messages.NewScuttlegoBanListArguments(hash)
+}
+
+func newAliasArguments(room, alias string) (messages.ScuttlegoAliasArguments, error) {
+	roomArgs, err := messages.NewScuttlegoRoomArguments(room)
+	if err != nil {
+		return messages.ScuttlegoAliasArguments{}, errors.Wrap(err, "invalid room")
+	}
+
+	a, err := aliases.NewAlias(alias)
+	if err != nil {
+		return messages.ScuttlegoAliasArguments{}, errors.Wrap(err, "invalid alias")
+	}
+
+	return messages.NewScuttlegoAliasArguments(roomArgs, a)
+}
@@ -0,0 +1,261 @@
+// Package config loads the configuration of the scuttlego daemon.
+package config
+
+import (
+	"encoding/base64"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/boreq/errors"
+	"github.com/dgraph-io/badger/v3/options"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/planetary-social/scuttlego/internal"
+	"github.com/planetary-social/scuttlego/service"
+	"github.com/planetary-social/scuttlego/service/domain"
+	"github.com/planetary-social/scuttlego/service/domain/feeds/formats"
+	"github.com/planetary-social/scuttlego/service/domain/graph"
+	"github.com/planetary-social/scuttlego/service/domain/network"
+	"github.com/planetary-social/scuttlego/service/domain/rooms/server"
+	"github.com/planetary-social/scuttlego/service/domain/transport/boxstream"
+)
+
+const (
+	defaultSecretFilename     = "secret"
+	defaultGoSSBDataDirectory = "gossb"
+)
+
+// Config is the configuration file of the daemon. It is stored as JSON, see
+// config.example.json.
+type Config struct {
+	// DataDirectory is where the database and, unless configured
+	// otherwise, the secret key are stored. Required.
+	DataDirectory string `json:"dataDirectory"`
+
+	// SecretFile is the path to the secret key. A new key is generated if
+	// the file doesn't exist. Optional, defaults to "secret" in the data
+	// directory.
+	SecretFile string `json:"secretFile"`
+
+	// GoSSBDataDirectory is the data directory of go-ssb. Blobs are stored
+	// in it. Optional, defaults to "gossb" in the data directory.
+	GoSSBDataDirectory string `json:"goSSBDataDirectory"`
+
+	ListenAddress           string `json:"listenAddress"`
+	WebSocketListenAddress  string `json:"webSocketListenAddress"`
+	LocalSocketPath         string `json:"localSocketPath"`
+	DisableLocalAdvertising bool   `json:"disableLocalAdvertising"`
+
+	// HTTPAPIListenAddress enables the HTTP API. HTTPAPIToken is required if
+	// it is set.
+	HTTPAPIListenAddress string `json:"httpApiListenAddress"`
+	HTTPAPIToken         string `json:"httpApiToken"`
+
+	// MetricsListenAddress enables the Prometheus metrics endpoint.
+	MetricsListenAddress string `json:"metricsListenAddress"`
+
+	// NetworkKey and MessageHMAC are base64 encoded. Optional, the main
+	// network is used by default.
+	NetworkKey  string `json:"networkKey"`
+	MessageHMAC string `json:"messageHMAC"`
+
+	Hops *int `json:"hops"`
+
+	// PreferredPubs are multiserver addresses of pubs to which the daemon
+	// will try to remain connected e.g. "net:example.com:8008~shs:<key>".
+	PreferredPubs []string `json:"preferredPubs"`
+
+	RoomServerPrivacyMode string `json:"roomServerPrivacyMode"`
+	RoomServerAliasDomain string `json:"roomServerAliasDomain"`
+
+	// TracingFile is a file to which spans are appended as JSON, one per
+	// line. They can be viewed using log-debugger. Optional, spans are
+	// discarded if this is not set.
+	TracingFile string `json:"tracingFile"`
+
+	// LogLevel is one of "error", "debug" or "trace". Optional, defaults to
+	// "error".
+	LogLevel string `json:"logLevel"`
+
+	Badger BadgerConfig `json:"badger"`
+}
+
+// BadgerConfig overrides Badger options. Zero values are ignored.
+type BadgerConfig struct {
+	NumGoroutines    int    `json:"numGoroutines"`
+	NumCompactors    int    `json:"numCompactors"`
+	Compression      string `json:"compression"`
+	ValueLogFileSize int64  `json:"valueLogFileSize"`
+	BlockCacheSize   int64  `json:"blockCacheSize"`
+	IndexCacheSize   int64  `json:"indexCacheSize"`
+	SyncWrites       *bool  `json:"syncWrites"`
+}
+
+// Load reads the configuration file. Unknown fields are treated as errors
+// to catch typos.
+func Load(filename string) (Config, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return Config{}, errors.Wrap(err, "error opening the file")
+	}
+	defer f.Close()
+
+	decoder := jsoniter.NewDecoder(f)
+	decoder.DisallowUnknownFields()
+
+	var config Config
+	if err := decoder.Decode(&config); err != nil {
+		return Config{}, errors.Wrap(err, "json decoding failed")
+	}
+
+	if config.DataDirectory == "" {
+		return Config{}, errors.New("data directory is required")
+	}
+
+	return config, nil
+}
+
+func (c Config) SecretFilename() string {
+	if c.SecretFile != "" {
+		return c.SecretFile
+	}
+	return filepath.Join(c.DataDirectory, defaultSecretFilename)
+}
+
+// ServiceConfig converts the configuration file to the config of the service.
+// The logging system and the tracing exporter aren't set as they are created
+// by the caller.
+func (c Config) ServiceConfig() (service.Config, error) {
+	config := service.Config{
+		DataDirectory:           c.DataDirectory,
+		GoSSBDataDirectory:      c.GoSSBDataDirectory,
+		ListenAddress:           c.ListenAddress,
+		WebSocketListenAddress:  c.WebSocketListenAddress,
+		LocalSocketPath:         c.LocalSocketPath,
+		DisableLocalAdvertising: c.DisableLocalAdvertising,
+		HTTPAPIListenAddress:    c.HTTPAPIListenAddress,
+		HTTPAPIToken:            c.HTTPAPIToken,
+		MetricsListenAddress:    c.MetricsListenAddress,
+		RoomServerAliasDomain:   c.RoomServerAliasDomain,
+	}
+
+	if c.HTTPAPIListenAddress != "" && c.HTTPAPIToken == "" {
+		return service.Config{}, errors.New("http api token is required if the http api is enabled")
+	}
+
+	if config.GoSSBDataDirectory == "" {
+		config.GoSSBDataDirectory = filepath.Join(c.DataDirectory, defaultGoSSBDataDirectory)
+	}
+
+	if c.NetworkKey != "" {
+		b, err := base64.StdEncoding.DecodeString(c.NetworkKey)
+		if err != nil {
+			return service.Config{}, errors.Wrap(err, "error decoding the network key")
+		}
+
+		networkKey, err := boxstream.NewNetworkKey(b)
+		if err != nil {
+			return service.Config{}, errors.Wrap(err, "error creating the network key")
+		}
+
+		config.NetworkKey = networkKey
+	}
+
+	if c.MessageHMAC != "" {
+		b, err := base64.StdEncoding.DecodeString(c.MessageHMAC)
+		if err != nil {
+			return service.Config{}, errors.Wrap(err, "error decoding the message hmac")
+		}
+
+		messageHMAC, err := formats.NewMessageHMAC(b)
+		if err != nil {
+			return service.Config{}, errors.Wrap(err, "error creating the message hmac")
+		}
+
+		config.MessageHMAC = messageHMAC
+	}
+
+	if c.Hops != nil {
+		hops, err := graph.NewHops(*c.Hops)
+		if err != nil {
+			return service.Config{}, errors.Wrap(err, "error creating hops")
+		}
+
+		config.Hops = internal.Ptr(hops)
+	}
+
+	for _, s := range c.PreferredPubs {
+		address, err := network.NewMultiserverAddress(s)
+		if err != nil {
+			return service.Config{}, errors.Wrapf(err, "error parsing pub address '%s'", s)
+		}
+
+		config.PeerManagerConfig.PreferredPubs = append(config.PeerManagerConfig.PreferredPubs, domain.Pub{
+			Identity: address.Remote(),
+			Address:  address.Address(),
+		})
+	}
+
+	if c.RoomServerPrivacyMode != "" {
+		privacyMode, err := server.NewPrivacyModeFromString(c.RoomServerPrivacyMode)
+		if err != nil {
+			return service.Config{}, errors.Wrap(err, "error parsing the room server privacy mode")
+		}
+
+		config.RoomServerPrivacyMode = privacyMode
+	}
+
+	modifyBadgerOptions, err := c.Badger.modifyBadgerOptions()
+	if err != nil {
+		return service.Config{}, errors.Wrap(err, "invalid badger config")
+	}
+	config.ModifyBadgerOptions = modifyBadgerOptions
+
+	return config, nil
+}
+
+func (b BadgerConfig) modifyBadgerOptions() (func(service.BadgerOptions), error) {
+	compression, err := newCompressionType(b.Compression)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid compression")
+	}
+
+	return func(o service.BadgerOptions) {
+		if b.NumGoroutines != 0 {
+			o.SetNumGoroutines(b.NumGoroutines)
+		}
+		if b.NumCompactors != 0 {
+			o.SetNumCompactors(b.NumCompactors)
+		}
+		if compression != nil {
+			o.SetCompression(*compression)
+		}
+		if b.ValueLogFileSize != 0 {
+			o.SetValueLogFileSize(b.ValueLogFileSize)
+		}
+		if b.BlockCacheSize != 0 {
+			o.SetBlockCacheSize(b.BlockCacheSize)
+		}
+		if b.IndexCacheSize != 0 {
+			o.SetIndexCacheSize(b.IndexCacheSize)
+		}
+		if b.SyncWrites != nil {
+			o.SetSyncWrites(*b.SyncWrites)
+		}
+	}, nil
+}
+
+func newCompressionType(s string) (*options.CompressionType, error) {
+	switch s {
+	case "":
+		return nil, nil
+	case "none":
+		return internal.Ptr(options.None), nil
+	case "snappy":
+		return internal.Ptr(options.Snappy), nil
+	case "zstd":
+		return internal.Ptr(options.ZSTD), nil
+	default:
+		return nil, fmt.Errorf("unknown compression type '%s'", s)
+	}
+}
@@ -0,0 +1,111 @@
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/planetary-social/scuttlego/cmd/scuttlego/config"
+	"github.com/planetary-social/scuttlego/service/domain/graph"
+	"github.com/planetary-social/scuttlego/service/domain/network"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad_ExampleConfig(t *testing.T) {
+	cfg, err := config.Load(filepath.Join("..", "config.example.json"))
+	require.NoError(t, err)
+
+	require.Equal(t, "/var/lib/scuttlego/secret", cfg.SecretFilename())
+
+	serviceConfig, err := cfg.ServiceConfig()
+	require.NoError(t, err)
+
+	require.Equal(t, "/var/lib/scuttlego", serviceConfig.DataDirectory)
+	require.Equal(t, "/var/lib/scuttlego/gossb", serviceConfig.GoSSBDataDirectory)
+	require.Equal(t, ":8008", serviceConfig.ListenAddress)
+	require.Equal(t, "127.0.0.1:8080", serviceConfig.HTTPAPIListenAddress)
+	require.Equal(t, "127.0.0.1:9090", serviceConfig.MetricsListenAddress)
+	require.Equal(t, graph.MustNewHops(2), *serviceConfig.Hops)
+	require.Len(t, serviceConfig.PeerManagerConfig.PreferredPubs, 1)
+	require.Equal(t, network.NewAddress("pub.example.com:8008"), serviceConfig.PeerManagerConfig.PreferredPubs[0].Address)
+	require.NotNil(t, serviceConfig.ModifyBadgerOptions)
+}
+
+func TestLoad_ReturnsErrorsForInvalidConfigs(t *testing.T) {
+	testCases := []struct {
+		Name   string
+		Config string
+	}{
+		{
+			Name:   "unknown_field",
+			Config: `{"dataDirectory": "/tmp", "unknown": true}`,
+		},
+		{
+			Name:   "missing_data_directory",
+			Config: `{"listenAddress": ":8008"}`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			filename := filepath.Join(t.TempDir(), "config.json")
+			err := os.WriteFile(filename, []byte(testCase.Config), 0600)
+			require.NoError(t, err)
+
+			_, err = config.Load(filename)
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestConfig_ServiceConfigReturnsErrorsForInvalidValues(t *testing.T) {
+	testCases := []struct {
+		Name   string
+		Config config.Config
+	}{
+		{
+			Name: "network_key",
+			Config: config.Config{
+				DataDirectory: "/tmp",
+				NetworkKey:    "invalid",
+			},
+		},
+		{
+			Name: "preferred_pub",
+			Config: config.Config{
+				DataDirectory: "/tmp",
+				PreferredPubs: []string{"invalid"},
+			},
+		},
+		{
+			Name: "http_api_without_token",
+			Config: config.Config{
+				DataDirectory:        "/tmp",
+				HTTPAPIListenAddress: ":8080",
+			},
+		},
+		{
+			Name: "privacy_mode",
+			Config: config.Config{
+				DataDirectory:         "/tmp",
+				RoomServerPrivacyMode: "invalid",
+			},
+		},
+		{
+			Name: "compression",
+			Config: config.Config{
+				DataDirectory: "/tmp",
+				Badger: config.BadgerConfig{
+					Compression: "invalid",
+				},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			_, err := testCase.Config.ServiceConfig()
+			require.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,147 @@
+package config
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/ed25519"
+	"encoding/base64"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/boreq/errors"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/planetary-social/scuttlego/service/domain/identity"
+	"github.com/planetary-social/scuttlego/service/domain/refs"
+)
+
+const (
+	secretCurve  = "ed25519"
+	secretSuffix = ".ed25519"
+)
+
+// LoadOrCreateSecret loads the secret key stored in the provided file. If
+// the file doesn't exist then a new secret key is generated and saved. The
+// file uses the same format as the secret files created by other Secure
+// Scuttlebutt implementations.
+func LoadOrCreateSecret(filename string) (identity.Private, error) {
+	private, err := LoadSecret(filename)
+	if err == nil {
+		return private, nil
+	}
+
+	if !errors.Is(err, os.ErrNotExist) {
+		return identity.Private{}, errors.Wrap(err, "error loading the secret")
+	}
+
+	private, err = identity.NewPrivate()
+	if err != nil {
+		return identity.Private{}, errors.Wrap(err, "error creating a new identity")
+	}
+
+	if err := SaveSecret(filename, private); err != nil {
+		return identity.Private{}, errors.Wrap(err, "error saving the secret")
+	}
+
+	return private, nil
+}
+
+// LoadSecret loads the secret key stored in the provided file. Lines starting
+// with a "#" are treated as comments.
+func LoadSecret(filename string) (identity.Private, error) {
+	b, err := os.ReadFile(filename)
+	if err != nil {
+		return identity.Private{}, errors.Wrap(err, "error reading the file")
+	}
+
+	var transport secretTransport
+	if err := jsoniter.Unmarshal(stripComments(b), &transport); err != nil {
+		return identity.Private{}, errors.Wrap(err, "json unmarshal failed")
+	}
+
+	if transport.Curve != secretCurve {
+		return identity.Private{}, fmt.Errorf("unsupported curve '%s'", transport.Curve)
+	}
+
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSuffix(transport.Private, secretSuffix))
+	if err != nil {
+		return identity.Private{}, errors.Wrap(err, "error decoding the private key")
+	}
+
+	private, err := identity.NewPrivateFromBytes(key)
+	if err != nil {
+		return identity.Private{}, errors.Wrap(err, "error creating the private identity")
+	}
+
+	if transport.Public != "" {
+		public, err := base64.StdEncoding.DecodeString(strings.TrimSuffix(transport.Public, secretSuffix))
+		if err != nil {
+			return identity.Private{}, errors.Wrap(err, "error decoding the public key")
+		}
+
+		if !private.Public().PublicKey().Equal(ed25519.PublicKey(public)) {
+			return identity.Private{}, errors.New("public key doesn't match the private key")
+		}
+	}
+
+	return private, nil
+}
+
+// SaveSecret saves the secret key in the provided file which must not
+// already exist.
+func SaveSecret(filename string, private identity.Private) error {
+	ref, err := refs.NewIdentityFromPublic(private.Public())
+	if err != nil {
+		return errors.Wrap(err, "error creating an identity ref")
+	}
+
+	b, err := jsoniter.MarshalIndent(secretTransport{
+		Curve:   secretCurve,
+		Public:  private.Public().String() + secretSuffix,
+		Private: base64.StdEncoding.EncodeToString(private.PrivateKey()) + secretSuffix,
+		Id:      ref.String(),
+	}, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "json marshal failed")
+	}
+
+	if err := os.MkdirAll(filepath.Dir(filename), 0700); err != nil {
+		return errors.Wrap(err, "error creating the directory")
+	}
+
+	f, err := os.OpenFile(filename, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return errors.Wrap(err, "error creating the file")
+	}
+	defer f.Close()
+
+	if _, err := f.Write(b); err != nil {
+		return errors.Wrap(err, "error writing the file")
+	}
+
+	return f.Close()
+}
+
+func stripComments(b []byte) []byte {
+	var buf bytes.Buffer
+
+	scanner := bufio.NewScanner(bytes.NewReader(b))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.HasPrefix(strings.TrimSpace(line), "#") {
+			continue
+		}
+		buf.WriteString(line)
+		buf.WriteString("\n")
+	}
+
+	return buf.Bytes()
+}
+
+type secretTransport struct {
+	Curve   string `json:"curve"`
+	Public  string `json:"public"`
+	Private string `json:"private"`
+	Id      string `json:"id"`
+}
@@ -0,0 +1,44 @@
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/planetary-social/scuttlego/cmd/scuttlego/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadOrCreateSecret_CreatesAndThenLoadsTheSameSecret(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "directory", "secret")
+
+	created, err := config.LoadOrCreateSecret(filename)
+	require.NoError(t, err)
+
+	info, err := os.Stat(filename)
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	loaded, err := config.LoadOrCreateSecret(filename)
+	require.NoError(t, err)
+	require.Equal(t, created, loaded)
+}
+
+func TestLoadSecret_IgnoresComments(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "secret")
+
+	err := os.WriteFile(filename, []byte(`# this is your SECRET name.
+# this name gives you magical powers.
+{
+  "curve": "ed25519",
+  "public": "TQ7DoNA/7/+TqbzOKiS0BtRvRK7/H3eH2u2xPKr6QCw=.ed25519",
+  "private": "AAcOFRwjKjE4P0ZNVFtiaXB3foWMk5qhqK+2vcTL0tlNDsOg0D/v/5OpvM4qJLQG1G9Erv8fd4fa7bE8qvpALA==.ed25519",
+  "id": "@TQ7DoNA/7/+TqbzOKiS0BtRvRK7/H3eH2u2xPKr6QCw=.ed25519"
+}
+`), 0600)
+	require.NoError(t, err)
+
+	private, err := config.LoadSecret(filename)
+	require.NoError(t, err)
+	require.Equal(t, "TQ7DoNA/7/+TqbzOKiS0BtRvRK7/H3eH2u2xPKr6QCw=", private.Public().String())
+}
@@ -0,0 +1,160 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/boreq/errors"
+	"github.com/boreq/guinea"
+	"github.com/planetary-social/scuttlego/cmd/scuttlego/config"
+	"github.com/planetary-social/scuttlego/logging"
+	"github.com/planetary-social/scuttlego/service/app/commands"
+	"github.com/planetary-social/scuttlego/service/di"
+	"github.com/planetary-social/scuttlego/service/domain/refs"
+	"github.com/planetary-social/scuttlego/tracing"
+	"github.com/sirupsen/logrus"
+)
+
+var rootCommand = guinea.Command{
+	Arguments: []guinea.Argument{
+		{
+			Name:        "config",
+			Multiple:    false,
+			Optional:    false,
+			Description: "path to the config file",
+		},
+	},
+	Run: func(c guinea.Context) error {
+		return run(c.Arguments[0])
+	},
+	ShortDescription: "runs a Secure Scuttlebutt node",
+	Description: `Runs a Secure Scuttlebutt node configured using a JSON config file, see
+config.example.json. A new secret key is generated on the first run if it
+doesn't exist. The node stops once it receives SIGINT or SIGTERM.`,
+}
+
+func main() {
+	if err := guinea.Run(&rootCommand); err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+}
+
+func run(configFilename string) error {
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	cfg, err := config.Load(configFilename)
+	if err != nil {
+		return errors.Wrap(err, "error loading the config")
+	}
+
+	serviceConfig, err := cfg.ServiceConfig()
+	if err != nil {
+		return errors.Wrap(err, "invalid config")
+	}
+
+	loggingSystem, err := newLoggingSystem(cfg.LogLevel)
+	if err != nil {
+		return errors.Wrap(err, "error creating the logging system")
+	}
+	serviceConfig.LoggingSystem = loggingSystem
+
+	if cfg.TracingFile != "" {
+		exporter, err := tracing.NewJSONFileExporter(cfg.TracingFile)
+		if err != nil {
+			return errors.Wrap(err, "error creating the tracing exporter")
+		}
+		defer exporter.Close()
+		serviceConfig.TracingExporter = exporter
+	}
+
+	serviceConfig.SetDefaults()
+
+	private, err := config.LoadOrCreateSecret(cfg.SecretFilename())
+	if err != nil {
+		return errors.Wrap(err, "error loading the secret")
+	}
+
+	ref, err := refs.NewIdentityFromPublic(private.Public())
+	if err != nil {
+		return errors.Wrap(err, "error creating the identity ref")
+	}
+
+	fmt.Printf("identity: %s\n", ref.String())
+
+	service, cleanup, err := di.BuildService(private, serviceConfig)
+	if err != nil {
+		return errors.Wrap(err, "error building the service")
+	}
+	defer cleanup()
+
+	logger := logging.NewContextLogger(loggingSystem, "main")
+
+	cmd, err := commands.NewRunMigrations(newMigrationsProgressCallback(logger))
+	if err != nil {
+		return errors.Wrap(err, "error creating the run migrations command")
+	}
+
+	if err := service.App.Commands.RunMigrations.Run(ctx, cmd); err != nil {
+		return errors.Wrap(err, "error running migrations")
+	}
+
+	// The service always returns an error once the context is cancelled.
+	if err := service.Run(ctx); err != nil && ctx.Err() == nil {
+		return errors.Wrap(err, "service terminated")
+	}
+
+	return nil
+}
+
+func newLoggingSystem(level string) (logging.LoggingSystem, error) {
+	logger := logrus.New()
+
+	switch level {
+	case "", "error":
+		logger.SetLevel(logrus.ErrorLevel)
+	case "debug":
+		logger.SetLevel(logrus.DebugLevel)
+	case "trace":
+		logger.SetLevel(logrus.TraceLevel)
+	default:
+		return nil, fmt.Errorf("unknown log level '%s'", level)
+	}
+
+	return logging.NewLogrusLoggingSystem(logger), nil
+}
+
+type migrationsProgressCallback struct {
+	logger logging.Logger
+}
+
+func newMigrationsProgressCallback(logger logging.Logger) migrationsProgressCallback {
+	return migrationsProgressCallback{
+		logger: logger.New("migrations"),
+	}
+}
+
+func (m migrationsProgressCallback) OnRunning(migrationIndex int, migrationsCount int) {
+	m.logger.Debug().
+		WithField("index", migrationIndex).
+		WithField("count", migrationsCount).
+		Message("running migration")
+}
+
+func (m migrationsProgressCallback) OnError(migrationIndex int, migrationsCount int, err error) {
+	m.logger.Error().
+		WithField("index", migrationIndex).
+		WithField("count", migrationsCount).
+		WithError(err).
+		Message("migration failed")
+}
+
+func (m migrationsProgressCallback) OnDone(migrationsCount int) {
+	m.logger.Debug().
+		WithField("count", migrationsCount).
+		Message("migrations done")
+}
@@ -2,11 +2,13 @@ package mocks
 
 import (
 	"fmt"
+	"sort"
 	"sync"
 
 	"github.com/boreq/errors"
 	"github.com/planetary-social/scuttlego/service/app/commands"
 	"github.com/planetary-social/scuttlego/service/app/common"
+	"github.com/planetary-social/scuttlego/service/app/queries"
 	"github.com/planetary-social/scuttlego/service/domain/feeds"
 	"github.com/planetary-social/scuttlego/service/domain/feeds/message"
 	"github.com/planetary-social/scuttlego/service/domain/refs"
@@ -18,6 +20,11 @@ type FeedRepositoryMockGetMessagesCall struct {
 	Limit *int
 }
 
+type FeedRepositoryMockListMessagesCall struct {
+	Id      refs.Feed
+	Options queries.FeedMessagesListOptions
+}
+
 type FeedRepositoryMockGetMessageCall struct {
 	Feed refs.Feed
 	Seq  message.Sequence
@@ -42,9 +49,15 @@ type FeedRepositoryMock struct {
 	GetMessagesReturnValue []message.Message
 	GetMessagesReturnErr   error
 
+	ListMessagesCalls       []FeedRepositoryMockListMessagesCall
+	ListMessagesReturnValue []message.Message
+	ListMessagesReturnErr   error
+
 	getMessageCalls        []FeedRepositoryMockGetMessageCall
 	getMessageReturnValues map[string]message.Message
 
+	getSequenceReturnValues map[string]feedRepositoryMockSequence
+
 	updateFeedCalls                   []FeedRepositoryMockUpdateFeedCall
 	updateFeedIgnoringReceiveLogCalls []FeedRepositoryMockUpdateFeedIgnoringReceiveLogCall
 
@@ -59,12 +72,58 @@ type FeedRepositoryMock struct {
 
 func NewFeedRepositoryMock() *FeedRepositoryMock {
 	return &FeedRepositoryMock{
-		getMessageReturnValues: make(map[string]message.Message),
+		getMessageReturnValues:  make(map[string]message.Message),
+		getSequenceReturnValues: make(map[string]feedRepositoryMockSequence),
+	}
+}
+
+func (m *FeedRepositoryMock) MockGetSequence(ref refs.Feed, sequence message.Sequence) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.getSequenceReturnValues[ref.String()] = feedRepositoryMockSequence{
+		Feed:     ref,
+		Sequence: sequence,
 	}
 }
 
 func (m *FeedRepositoryMock) GetSequence(ref refs.Feed) (message.Sequence, error) {
-	return message.Sequence{}, common.ErrFeedNotFound
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	v, ok := m.getSequenceReturnValues[ref.String()]
+	if !ok {
+		return message.Sequence{}, common.ErrFeedNotFound
+	}
+	return v.Sequence, nil
+}
+
+// ListFeeds returns feeds for which a sequence was mocked using
+// MockGetSequence sorted by their refs.
+func (m *FeedRepositoryMock) ListFeeds(after *refs.Feed, limit int) ([]refs.Feed, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	var all []refs.Feed
+	for _, v := range m.getSequenceReturnValues {
+		all = append(all, v.Feed)
+	}
+
+	sort.Slice(all, func(i, j int) bool {
+		return all[i].String() < all[j].String()
+	})
+
+	var result []refs.Feed
+	for _, feed := range all {
+		if after != nil && feed.String() <= after.String() {
+			continue
+		}
+		if len(result) >= limit {
+			break
+		}
+		result = append(result, feed)
+	}
+	return result, nil
 }
 
 func (m *FeedRepositoryMock) UpdateFeed(ref refs.Feed, f commands.UpdateFeedFn) error {
@@ -116,6 +175,14 @@ func (m *FeedRepositoryMock) GetMessages(id refs.Feed, seq *message.Sequence, li
 	return m.GetMessagesReturnValue, m.GetMessagesReturnErr
 }
 
+func (m *FeedRepositoryMock) ListMessages(id refs.Feed, options queries.FeedMessagesListOptions) ([]message.Message, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.ListMessagesCalls = append(m.ListMessagesCalls, FeedRepositoryMockListMessagesCall{Id: id, Options: options})
+	return m.ListMessagesReturnValue, m.ListMessagesReturnErr
+}
+
 func (m *FeedRepositoryMock) MockGetMessage(msg message.Message) {
 	m.getMessageReturnValues[fmt.Sprintf("%s-%d", msg.Feed().String(), msg.Sequence().Int())] = msg
 }
@@ -180,3 +247,8 @@ func (m *FeedRepositoryMock) UpdateFeedCalls() []FeedRepositoryMockUpdateFeedCal
 	copy(tmp, m.updateFeedCalls)
 	return tmp
 }
+
+type feedRepositoryMockSequence struct {
+	Feed     refs.Feed
+	Sequence message.Sequence
+}
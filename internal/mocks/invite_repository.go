@@ -0,0 +1,64 @@
+package mocks
+
+import (
+	"sync"
+
+	"github.com/boreq/errors"
+	"github.com/planetary-social/scuttlego/service/app/commands"
+	"github.com/planetary-social/scuttlego/service/domain/invites"
+	"github.com/planetary-social/scuttlego/service/domain/refs"
+)
+
+// InviteRepositoryMock stores invites in memory. Similarly to the real
+// repository invites are only saved if the update function succeeds.
+type InviteRepositoryMock struct {
+	lock    sync.Mutex
+	invites map[string]invites.PubInvite
+
+	UpdateInviteCalls []refs.Identity
+}
+
+func NewInviteRepositoryMock() *InviteRepositoryMock {
+	return &InviteRepositoryMock{
+		invites: make(map[string]invites.PubInvite),
+	}
+}
+
+func (m *InviteRepositoryMock) Put(invite *invites.PubInvite) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.invites[invite.Id().String()] = *invite
+	return nil
+}
+
+func (m *InviteRepositoryMock) UpdateInvite(id refs.Identity, f func(invite *invites.PubInvite) error) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.UpdateInviteCalls = append(m.UpdateInviteCalls, id)
+
+	invite, ok := m.invites[id.String()]
+	if !ok {
+		return commands.ErrInviteNotFound
+	}
+
+	if err := f(&invite); err != nil {
+		return errors.Wrap(err, "function returned an error")
+	}
+
+	m.invites[id.String()] = invite
+	return nil
+}
+
+// Invites returns all stored invites.
+func (m *InviteRepositoryMock) Invites() []invites.PubInvite {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	var result []invites.PubInvite
+	for _, invite := range m.invites {
+		result = append(result, invite)
+	}
+	return result
+}
@@ -1,7 +1,7 @@
 package mocks
 
 import (
-	"github.com/boreq/errors"
+	"github.com/planetary-social/scuttlego/service/app/common"
 	"github.com/planetary-social/scuttlego/service/domain/feeds/message"
 	"github.com/planetary-social/scuttlego/service/domain/refs"
 )
@@ -35,7 +35,7 @@ func (m *MessageRepositoryMock) Get(id refs.Message) (message.Message, error) {
 	m.getCalls = append(m.getCalls, MessageRepositoryMockGetCall{Id: id})
 	msg, ok := m.getReturnValues[id.String()]
 	if !ok {
-		return message.Message{}, errors.New("not mocked")
+		return message.Message{}, common.ErrMessageNotFound
 	}
 	return msg, nil
 }
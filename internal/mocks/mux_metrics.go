@@ -0,0 +1,41 @@
+package mocks
+
+import (
+	"sync"
+
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc"
+)
+
+type MuxMetricsMock struct {
+	rpcRequests        []rpc.ProcedureName
+	unknownRPCRequests int
+	lock               sync.Mutex
+}
+
+func NewMuxMetricsMock() *MuxMetricsMock {
+	return &MuxMetricsMock{}
+}
+
+func (m *MuxMetricsMock) ReportRPCRequest(procedure rpc.ProcedureName) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.rpcRequests = append(m.rpcRequests, procedure)
+}
+
+func (m *MuxMetricsMock) ReportUnknownRPCRequest() {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.unknownRPCRequests++
+}
+
+func (m *MuxMetricsMock) RPCRequests() []rpc.ProcedureName {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return m.rpcRequests
+}
+
+func (m *MuxMetricsMock) UnknownRPCRequests() int {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return m.unknownRPCRequests
+}
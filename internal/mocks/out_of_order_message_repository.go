@@ -0,0 +1,38 @@
+package mocks
+
+import (
+	"github.com/planetary-social/scuttlego/service/app/common"
+	"github.com/planetary-social/scuttlego/service/domain/feeds/message"
+	"github.com/planetary-social/scuttlego/service/domain/refs"
+)
+
+type OutOfOrderMessageRepositoryMock struct {
+	messages map[string]message.Message
+}
+
+func NewOutOfOrderMessageRepositoryMock() *OutOfOrderMessageRepositoryMock {
+	return &OutOfOrderMessageRepositoryMock{
+		messages: make(map[string]message.Message),
+	}
+}
+
+func (m *OutOfOrderMessageRepositoryMock) Put(msg message.Message) error {
+	m.messages[msg.Id().String()] = msg
+	return nil
+}
+
+func (m *OutOfOrderMessageRepositoryMock) Get(id refs.Message) (message.Message, error) {
+	msg, ok := m.messages[id.String()]
+	if !ok {
+		return message.Message{}, common.ErrMessageNotFound
+	}
+	return msg, nil
+}
+
+func (m *OutOfOrderMessageRepositoryMock) Messages() []message.Message {
+	var result []message.Message
+	for _, msg := range m.messages {
+		result = append(result, msg)
+	}
+	return result
+}
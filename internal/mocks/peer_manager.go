@@ -10,9 +10,10 @@ import (
 )
 
 type PeerManagerMock struct {
-	connectViaRoomCalls []PeerManagerConnectViaRoomCall
-	peersReturnValue    []transport.Peer
-	disconnectAllCalls  int
+	connectViaRoomCalls       []PeerManagerConnectViaRoomCall
+	connectViaRoomReturnValue transport.Peer
+	peersReturnValue          []transport.Peer
+	disconnectAllCalls        int
 }
 
 func NewPeerManagerMock() *PeerManagerMock {
@@ -32,7 +33,7 @@ func (p *PeerManagerMock) Connect(ctx context.Context, remote identity.Public, a
 	return errors.New("not implemented")
 }
 
-func (p *PeerManagerMock) ConnectViaRoom(ctx context.Context, portal transport.Peer, target identity.Public) error {
+func (p *PeerManagerMock) ConnectViaRoom(ctx context.Context, portal transport.Peer, target identity.Public) (transport.Peer, error) {
 	p.connectViaRoomCalls = append(
 		p.connectViaRoomCalls,
 		PeerManagerConnectViaRoomCall{
@@ -40,7 +41,11 @@ func (p *PeerManagerMock) ConnectViaRoom(ctx context.Context, portal transport.P
 			Target: target,
 		},
 	)
-	return nil
+	return p.connectViaRoomReturnValue, nil
+}
+
+func (p *PeerManagerMock) MockConnectViaRoom(tunnel transport.Peer) {
+	p.connectViaRoomReturnValue = tunnel
 }
 
 func (p *PeerManagerMock) ConnectViaRoomCalls() []PeerManagerConnectViaRoomCall {
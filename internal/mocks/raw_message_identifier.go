@@ -4,6 +4,7 @@ import (
 	"encoding/hex"
 
 	"github.com/planetary-social/scuttlego/internal/fixtures"
+	"github.com/planetary-social/scuttlego/service/domain/feeds"
 	"github.com/planetary-social/scuttlego/service/domain/feeds/message"
 )
 
@@ -25,6 +26,15 @@ func (r *RawMessageIdentifierMock) VerifyRawMessage(raw message.RawMessage) (mes
 	return fixtures.SomeMessage(fixtures.SomeSequence(), fixtures.SomeRefFeed()), nil
 }
 
+func (r *RawMessageIdentifierMock) PeekRawMessage(raw message.RawMessage) (feeds.PeekedMessage, error) {
+	msg, err := r.VerifyRawMessage(raw)
+	if err != nil {
+		return feeds.PeekedMessage{}, err
+	}
+
+	return feeds.NewPeekedMessage(msg.Feed(), msg.Sequence(), raw)
+}
+
 func (r *RawMessageIdentifierMock) LoadRawMessage(raw message.VerifiedRawMessage) (message.MessageWithoutId, error) {
 	if msg, ok := r.v[hex.EncodeToString(raw.Bytes())]; ok {
 		return r.convert(msg)
@@ -30,7 +30,7 @@ func (r ReceiveLogRepositoryMock) MockMessage(seq common.ReceiveLogSequence, msg
 	r.messagesToSequences[msg.Id().String()] = append(r.messagesToSequences[msg.Id().String()], seq)
 }
 
-func (r ReceiveLogRepositoryMock) List(startSeq common.ReceiveLogSequence, limit int) ([]queries.LogMessage, error) {
+func (r ReceiveLogRepositoryMock) List(options queries.ReceiveLogListOptions) ([]queries.LogMessage, error) {
 	return nil, nil
 }
 
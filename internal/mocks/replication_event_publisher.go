@@ -0,0 +1,59 @@
+package mocks
+
+import (
+	"sync"
+
+	"github.com/planetary-social/scuttlego/service/domain/identity"
+	"github.com/planetary-social/scuttlego/service/domain/refs"
+)
+
+type ReplicationEventPublisherMock struct {
+	started  []ReplicationEventPublisherMockEvent
+	finished []ReplicationEventPublisherMockFinishedEvent
+	lock     sync.Mutex
+}
+
+type ReplicationEventPublisherMockEvent struct {
+	Peer identity.Public
+	Feed refs.Feed
+}
+
+type ReplicationEventPublisherMockFinishedEvent struct {
+	Peer             identity.Public
+	Feed             refs.Feed
+	ReceivedMessages int
+	Err              error
+}
+
+func NewReplicationEventPublisherMock() *ReplicationEventPublisherMock {
+	return &ReplicationEventPublisherMock{}
+}
+
+func (m *ReplicationEventPublisherMock) PublishReplicationStarted(peer identity.Public, feed refs.Feed) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.started = append(m.started, ReplicationEventPublisherMockEvent{Peer: peer, Feed: feed})
+}
+
+func (m *ReplicationEventPublisherMock) PublishReplicationFinished(peer identity.Public, feed refs.Feed, receivedMessages int, err error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.finished = append(m.finished, ReplicationEventPublisherMockFinishedEvent{
+		Peer:             peer,
+		Feed:             feed,
+		ReceivedMessages: receivedMessages,
+		Err:              err,
+	})
+}
+
+func (m *ReplicationEventPublisherMock) Started() []ReplicationEventPublisherMockEvent {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return append([]ReplicationEventPublisherMockEvent(nil), m.started...)
+}
+
+func (m *ReplicationEventPublisherMock) Finished() []ReplicationEventPublisherMockFinishedEvent {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return append([]ReplicationEventPublisherMockFinishedEvent(nil), m.finished...)
+}
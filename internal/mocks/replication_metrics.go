@@ -0,0 +1,37 @@
+package mocks
+
+import (
+	"sync"
+
+	"github.com/planetary-social/scuttlego/service/domain/refs"
+)
+
+type ReplicationMetricsMock struct {
+	replicationLag map[string]int
+	lock           sync.Mutex
+}
+
+func NewReplicationMetricsMock() *ReplicationMetricsMock {
+	return &ReplicationMetricsMock{
+		replicationLag: make(map[string]int),
+	}
+}
+
+func (m *ReplicationMetricsMock) ReportReplicationLag(feed refs.Feed, lag int) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.replicationLag[feed.String()] = lag
+}
+
+func (m *ReplicationMetricsMock) ForgetReplicationLag(feed refs.Feed) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	delete(m.replicationLag, feed.String())
+}
+
+func (m *ReplicationMetricsMock) ReplicationLag(feed refs.Feed) (int, bool) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	lag, ok := m.replicationLag[feed.String()]
+	return lag, ok
+}
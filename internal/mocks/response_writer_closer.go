@@ -9,9 +9,10 @@ import (
 )
 
 type MockCloserStream struct {
-	writtenMessages []MockCloserStreamWriteMessageCall
-	writtenErrors   []error
-	lock            sync.Mutex // locks writtenMessages and writtenErrors
+	writtenMessages  []MockCloserStreamWriteMessageCall
+	writtenErrors    []error
+	incomingMessages chan rpc.IncomingMessage
+	lock             sync.Mutex // locks writtenMessages and writtenErrors
 }
 
 func NewMockCloserStream() *MockCloserStream {
@@ -58,7 +59,15 @@ func (m *MockCloserStream) WrittenErrors() []error {
 }
 
 func (m *MockCloserStream) IncomingMessages() (<-chan rpc.IncomingMessage, error) {
-	return nil, errors.New("not implemented")
+	if m.incomingMessages == nil {
+		return nil, errors.New("not implemented")
+	}
+	return m.incomingMessages, nil
+}
+
+// MockIncomingMessages makes IncomingMessages return the provided channel.
+func (m *MockCloserStream) MockIncomingMessages(ch chan rpc.IncomingMessage) {
+	m.incomingMessages = ch
 }
 
 type MockCloserStreamWriteMessageCall struct {
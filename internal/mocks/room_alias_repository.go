@@ -0,0 +1,45 @@
+package mocks
+
+import (
+	"github.com/planetary-social/scuttlego/service/app/common"
+	"github.com/planetary-social/scuttlego/service/domain/refs"
+	"github.com/planetary-social/scuttlego/service/domain/rooms/aliases"
+)
+
+type RoomAliasRepositoryMock struct {
+	registrations map[string]aliases.Registration
+}
+
+func NewRoomAliasRepositoryMock() *RoomAliasRepositoryMock {
+	return &RoomAliasRepositoryMock{
+		registrations: make(map[string]aliases.Registration),
+	}
+}
+
+func (r *RoomAliasRepositoryMock) Put(registration aliases.Registration) error {
+	r.registrations[registration.Alias().String()] = registration
+	return nil
+}
+
+func (r *RoomAliasRepositoryMock) Delete(alias aliases.Alias) error {
+	delete(r.registrations, alias.String())
+	return nil
+}
+
+func (r *RoomAliasRepositoryMock) Get(alias aliases.Alias) (aliases.Registration, error) {
+	registration, ok := r.registrations[alias.String()]
+	if !ok {
+		return aliases.Registration{}, common.ErrRoomAliasNotFound
+	}
+	return registration, nil
+}
+
+func (r *RoomAliasRepositoryMock) List(user refs.Identity) ([]aliases.Alias, error) {
+	var result []aliases.Alias
+	for _, registration := range r.registrations {
+		if registration.User().Equal(user) {
+			result = append(result, registration.Alias())
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,47 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/boreq/errors"
+	"github.com/planetary-social/scuttlego/service/domain/network"
+	"github.com/planetary-social/scuttlego/service/domain/refs"
+	"github.com/planetary-social/scuttlego/service/domain/rooms"
+	"github.com/planetary-social/scuttlego/service/domain/rooms/aliases"
+)
+
+type RoomHTTPClientMock struct {
+	resolvedAliases map[string]rooms.ResolvedAlias
+	invites         map[string]network.MultiserverAddress
+}
+
+func NewRoomHTTPClientMock() *RoomHTTPClientMock {
+	return &RoomHTTPClientMock{
+		resolvedAliases: make(map[string]rooms.ResolvedAlias),
+		invites:         make(map[string]network.MultiserverAddress),
+	}
+}
+
+func (m *RoomHTTPClientMock) MockResolveAlias(endpoint aliases.AliasEndpointURL, resolved rooms.ResolvedAlias) {
+	m.resolvedAliases[endpoint.String()] = resolved
+}
+
+func (m *RoomHTTPClientMock) MockInvite(invite rooms.HTTPInvite, address network.MultiserverAddress) {
+	m.invites[invite.Token()] = address
+}
+
+func (m *RoomHTTPClientMock) ResolveAlias(ctx context.Context, endpoint aliases.AliasEndpointURL) (rooms.ResolvedAlias, error) {
+	v, ok := m.resolvedAliases[endpoint.String()]
+	if !ok {
+		return rooms.ResolvedAlias{}, errors.New("alias not mocked")
+	}
+	return v, nil
+}
+
+func (m *RoomHTTPClientMock) ConsumeInvite(ctx context.Context, invite rooms.HTTPInvite, user refs.Identity) (network.MultiserverAddress, error) {
+	v, ok := m.invites[invite.Token()]
+	if !ok {
+		return network.MultiserverAddress{}, errors.New("invite not mocked")
+	}
+	return v, nil
+}
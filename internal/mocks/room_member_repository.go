@@ -0,0 +1,47 @@
+package mocks
+
+import (
+	"github.com/boreq/errors"
+	"github.com/planetary-social/scuttlego/internal"
+	"github.com/planetary-social/scuttlego/service/domain/refs"
+)
+
+type RoomMemberRepositoryMock struct {
+	members internal.Set[string]
+}
+
+func NewRoomMemberRepositoryMock() *RoomMemberRepositoryMock {
+	return &RoomMemberRepositoryMock{
+		members: internal.NewSet[string](),
+	}
+}
+
+func (r RoomMemberRepositoryMock) Mock(id refs.Identity) {
+	r.members.Put(id.String())
+}
+
+func (r RoomMemberRepositoryMock) Add(id refs.Identity) error {
+	r.members.Put(id.String())
+	return nil
+}
+
+func (r RoomMemberRepositoryMock) Remove(id refs.Identity) error {
+	r.members.Delete(id.String())
+	return nil
+}
+
+func (r RoomMemberRepositoryMock) Contains(id refs.Identity) (bool, error) {
+	return r.members.Contains(id.String()), nil
+}
+
+func (r RoomMemberRepositoryMock) List() ([]refs.Identity, error) {
+	var result []refs.Identity
+	for _, v := range r.members.List() {
+		ref, err := refs.NewIdentity(v)
+		if err != nil {
+			return nil, errors.Wrap(err, "error creating a ref")
+		}
+		result = append(result, ref)
+	}
+	return result, nil
+}
@@ -0,0 +1,26 @@
+package mocks
+
+import (
+	"time"
+
+	"github.com/planetary-social/scuttlego/service/domain/transport"
+)
+
+type RoundTripTimeProviderMock struct {
+	roundTripTimes map[transport.Connection]time.Duration
+}
+
+func NewRoundTripTimeProviderMock() *RoundTripTimeProviderMock {
+	return &RoundTripTimeProviderMock{
+		roundTripTimes: make(map[transport.Connection]time.Duration),
+	}
+}
+
+func (r *RoundTripTimeProviderMock) MockRoundTripTime(peer transport.Peer, rtt time.Duration) {
+	r.roundTripTimes[peer.Conn()] = rtt
+}
+
+func (r *RoundTripTimeProviderMock) RoundTripTime(peer transport.Peer) (time.Duration, bool) {
+	rtt, ok := r.roundTripTimes[peer.Conn()]
+	return rtt, ok
+}
@@ -1,11 +1,19 @@
 package mocks
 
 import (
+	"github.com/boreq/errors"
+	"github.com/planetary-social/scuttlego/service/domain/feeds"
 	"github.com/planetary-social/scuttlego/service/domain/graph"
+	"github.com/planetary-social/scuttlego/service/domain/refs"
 )
 
 type SocialGraphRepositoryMock struct {
 	GetSocialGraphReturnValue graph.SocialGraph
+
+	GetSocialGraphFromCalls       []SocialGraphRepositoryMockGetSocialGraphFromCall
+	GetSocialGraphFromReturnValue graph.SocialGraph
+
+	contacts []*feeds.Contact
 }
 
 func NewSocialGraphRepositoryMock() *SocialGraphRepositoryMock {
@@ -15,3 +23,62 @@ func NewSocialGraphRepositoryMock() *SocialGraphRepositoryMock {
 func (s *SocialGraphRepositoryMock) GetSocialGraph() (graph.SocialGraph, error) {
 	return s.GetSocialGraphReturnValue, nil
 }
+
+func (s *SocialGraphRepositoryMock) GetSocialGraphBuilder() (*graph.SocialGraphBuilder, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (s *SocialGraphRepositoryMock) GetSocialGraphFrom(root refs.Identity, hops graph.Hops) (graph.SocialGraph, error) {
+	s.GetSocialGraphFromCalls = append(s.GetSocialGraphFromCalls, SocialGraphRepositoryMockGetSocialGraphFromCall{
+		Root: root,
+		Hops: hops,
+	})
+	return s.GetSocialGraphFromReturnValue, nil
+}
+
+func (s *SocialGraphRepositoryMock) MockContacts(contacts []*feeds.Contact) {
+	s.contacts = contacts
+}
+
+func (s *SocialGraphRepositoryMock) GetContacts(node refs.Identity) ([]*feeds.Contact, error) {
+	var result []*feeds.Contact
+	for _, contact := range s.contacts {
+		if contact.Author().Equal(node) {
+			result = append(result, contact)
+		}
+	}
+	return result, nil
+}
+
+func (s *SocialGraphRepositoryMock) GetContact(author, target refs.Identity) (*feeds.Contact, error) {
+	for _, contact := range s.contacts {
+		if contact.Author().Equal(author) && contact.Target().Equal(target) {
+			return contact, nil
+		}
+	}
+	return feeds.NewContact(author, target)
+}
+
+func (s *SocialGraphRepositoryMock) ListContacts(after *feeds.Contact, limit int) ([]*feeds.Contact, error) {
+	start := 0
+	if after != nil {
+		for i, contact := range s.contacts {
+			if contact.Author().Equal(after.Author()) && contact.Target().Equal(after.Target()) {
+				start = i + 1
+				break
+			}
+		}
+	}
+
+	end := start + limit
+	if end > len(s.contacts) {
+		end = len(s.contacts)
+	}
+
+	return s.contacts[start:end], nil
+}
+
+type SocialGraphRepositoryMockGetSocialGraphFromCall struct {
+	Root refs.Identity
+	Hops graph.Hops
+}
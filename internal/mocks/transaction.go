@@ -1,12 +1,17 @@
 package mocks
 
 import (
+	"sync"
+
 	"github.com/planetary-social/scuttlego/service/app/commands"
 	"github.com/planetary-social/scuttlego/service/app/queries"
 )
 
 type MockCommandsTransactionProvider struct {
 	adapters commands.Adapters
+
+	lock          sync.Mutex
+	transactCalls int
 }
 
 func NewMockCommandsTransactionProvider(adapters commands.Adapters) *MockCommandsTransactionProvider {
@@ -14,9 +19,21 @@ func NewMockCommandsTransactionProvider(adapters commands.Adapters) *MockCommand
 }
 
 func (p *MockCommandsTransactionProvider) Transact(f func(adapters commands.Adapters) error) error {
+	p.lock.Lock()
+	p.transactCalls++
+	p.lock.Unlock()
+
 	return f(p.adapters)
 }
 
+// TransactCalls returns the number of started transactions.
+func (p *MockCommandsTransactionProvider) TransactCalls() int {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	return p.transactCalls
+}
+
 type MockQueriesTransactionProvider struct {
 	adapters queries.Adapters
 }
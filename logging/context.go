@@ -9,6 +9,11 @@ const (
 	// StreamIdContextLabel is used to store stream ids which are request numbers interpreted so that
 	// streams initiated by us have positive numbers and streams initiated by remote have negative numbers.
 	StreamIdContextLabel = "ctx.stream_id"
+
+	// TraceIdContextLabel and SpanIdContextLabel are used to store the ids of
+	// the current span so that log entries can be matched with spans.
+	TraceIdContextLabel = "ctx.trace_id"
+	SpanIdContextLabel  = "ctx.span_id"
 )
 
 type loggingContextKeyType string
@@ -1,15 +1,17 @@
 package badger
 
 import (
+	"bytes"
 	"encoding/binary"
 	"fmt"
+	"math"
 
 	"github.com/boreq/errors"
 	"github.com/dgraph-io/badger/v3"
-	"github.com/planetary-social/scuttlego/internal"
 	"github.com/planetary-social/scuttlego/service/adapters/badger/utils"
 	"github.com/planetary-social/scuttlego/service/app/commands"
 	"github.com/planetary-social/scuttlego/service/app/common"
+	"github.com/planetary-social/scuttlego/service/app/queries"
 	"github.com/planetary-social/scuttlego/service/domain/feeds"
 	"github.com/planetary-social/scuttlego/service/domain/feeds/formats"
 	"github.com/planetary-social/scuttlego/service/domain/feeds/message"
@@ -31,6 +33,7 @@ type FeedRepository struct {
 	blobRepository    *BlobRepository
 	banListRepository *BanListRepository
 	formatScuttlebutt *formats.Scuttlebutt
+	events            *TransactionEvents
 }
 
 func NewFeedRepository(
@@ -42,6 +45,7 @@ func NewFeedRepository(
 	blobRepository *BlobRepository,
 	banListRepository *BanListRepository,
 	formatScuttlebutt *formats.Scuttlebutt,
+	events *TransactionEvents,
 ) *FeedRepository {
 	return &FeedRepository{
 		tx:                tx,
@@ -52,6 +56,7 @@ func NewFeedRepository(
 		blobRepository:    blobRepository,
 		banListRepository: banListRepository,
 		formatScuttlebutt: formatScuttlebutt,
+		events:            events,
 	}
 }
 
@@ -110,17 +115,109 @@ func (b FeedRepository) GetSequence(ref refs.Feed) (message.Sequence, error) {
 	return seq, nil
 }
 
+// ListFeeds returns up to limit feeds for which at least one message is
+// stored starting after the provided feed. If after is nil feeds are returned
+// starting from the first one. Only the keys are read but each stored message
+// of the returned feeds is visited.
+func (b FeedRepository) ListFeeds(after *refs.Feed, limit int) ([]refs.Feed, error) {
+	bucket := b.getEntriesBucket()
+
+	it := bucket.IteratorWithModifiedOptions(func(options *badger.IteratorOptions) {
+		options.PrefetchValues = false
+	})
+	defer it.Close()
+
+	var result []refs.Feed
+	var previous []byte
+
+	if after != nil {
+		previous = []byte(after.String())
+		it.Seek(previous)
+	} else {
+		it.Rewind()
+	}
+
+	for ; it.ValidForBucket(); it.Next() {
+		key, err := utils.NewKeyFromBytes(it.Item().KeyCopy(nil))
+		if err != nil {
+			return nil, errors.Wrap(err, "error parsing the key")
+		}
+
+		components := key.Components()
+		if len(components) != bucket.Prefix().Len()+2 {
+			return nil, errors.New("invalid key length")
+		}
+
+		feedComponent := components[bucket.Prefix().Len()].Bytes()
+		if bytes.Equal(feedComponent, previous) {
+			continue
+		}
+		previous = feedComponent
+
+		if len(result) >= limit {
+			break
+		}
+
+		feed, err := refs.NewFeed(string(feedComponent))
+		if err != nil {
+			return nil, errors.Wrap(err, "error creating the feed ref")
+		}
+
+		result = append(result, feed)
+	}
+
+	return result, nil
+}
+
 func (b FeedRepository) GetMessages(id refs.Feed, seq *message.Sequence, limit *int) ([]message.Message, error) {
-	if seq == nil {
-		seq = internal.Ptr(message.NewFirstSequence())
+	return b.ListMessages(id, queries.FeedMessagesListOptions{
+		Gte:   seq,
+		Limit: limit,
+	})
+}
+
+func (b FeedRepository) ListMessages(id refs.Feed, options queries.FeedMessagesListOptions) ([]message.Message, error) {
+	gte := message.NewFirstSequence()
+	if options.Gte != nil {
+		gte = *options.Gte
+	}
+
+	lt := exclusiveUpperBound(sequencePointerToInt(options.Lt), sequencePointerToInt(options.Lte))
+
+	if lt != nil && *lt <= gte.Int() {
+		return nil, nil
+	}
+
+	if options.Limit != nil && *options.Limit <= 0 {
+		return nil, nil
 	}
 
 	bucket := b.getFeedBucket(id)
-	it := bucket.Iterator()
+	it := bucket.IteratorWithModifiedOptions(func(iteratorOptions *badger.IteratorOptions) {
+		iteratorOptions.Reverse = options.Reverse
+	})
 	defer it.Close()
 
 	var messages []message.Message
-	for it.Seek(b.marshalMessageKey(*seq)); it.ValidForBucket(); it.Next() {
+	for it.Seek(b.listMessagesSeekKey(gte, lt, options.Reverse)); it.ValidForBucket(); it.Next() {
+		keyInBucket, err := bucket.KeyInBucket(it.Item())
+		if err != nil {
+			return nil, errors.Wrap(err, "error checking key in bucket")
+		}
+
+		seq, err := b.unmarshalMessageKey(keyInBucket.Bytes())
+		if err != nil {
+			return nil, errors.Wrap(err, "error unmarshaling sequence")
+		}
+
+		if options.Reverse && seq.Int() < gte.Int() {
+			break
+		}
+
+		if !options.Reverse && lt != nil && seq.Int() >= *lt {
+			break
+		}
+
 		valueCopy, err := it.Item().ValueCopy(nil)
 		if err != nil {
 			return nil, errors.Wrap(err, "error getting value")
@@ -136,14 +233,35 @@ func (b FeedRepository) GetMessages(id refs.Feed, seq *message.Sequence, limit *
 			return nil, errors.Wrap(err, "failed to get the message")
 		}
 
-		if limit == nil || len(messages) < *limit {
-			messages = append(messages, msg)
+		if !options.Timestamps.Includes(msg.Timestamp()) {
+			continue
+		}
+
+		messages = append(messages, msg)
+
+		if options.Limit != nil && len(messages) >= *options.Limit {
+			break
 		}
 	}
 
 	return messages, nil
 }
 
+// listMessagesSeekKey returns the key at which the iteration should start.
+// Reverse iterators seek to the closest key which is lower than or equal to
+// the provided key.
+func (b FeedRepository) listMessagesSeekKey(gte message.Sequence, lt *int, reverse bool) []byte {
+	if !reverse {
+		return b.marshalMessageKey(gte)
+	}
+
+	if lt != nil {
+		return itob(uint64(*lt - 1))
+	}
+
+	return itob(math.MaxUint64)
+}
+
 func (b FeedRepository) Count() (int, error) {
 	c, err := b.getFeedCounter()
 	if err != nil {
@@ -479,6 +597,8 @@ func (b FeedRepository) saveMessageInRepositories(msg feeds.MessageToPersist, sa
 		}
 	}
 
+	b.events.MessageSaved(msg.Message())
+
 	return nil
 }
 
@@ -523,6 +643,10 @@ func (b FeedRepository) getFeedBucket(ref refs.Feed) utils.Bucket {
 	return utils.MustNewBucket(b.tx, b.feedBucketPath(ref))
 }
 
+func (b FeedRepository) getEntriesBucket() utils.Bucket {
+	return utils.MustNewBucket(b.tx, b.entriesBucketPath())
+}
+
 func (b FeedRepository) getMetaBucket() utils.Bucket {
 	return utils.MustNewBucket(b.tx, b.metaBucketPath())
 }
@@ -535,6 +659,13 @@ func (b FeedRepository) feedBucketPath(ref refs.Feed) utils.Key {
 	)
 }
 
+func (b FeedRepository) entriesBucketPath() utils.Key {
+	return utils.MustNewKey(
+		feedRepositoryBucketFeeds,
+		feedRepositoryBucketFeedsEntries,
+	)
+}
+
 func (b FeedRepository) metaBucketPath() utils.Key {
 	return utils.MustNewKey(
 		feedRepositoryBucketFeeds,
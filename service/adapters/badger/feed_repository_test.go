@@ -2,12 +2,14 @@ package badger_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/boreq/errors"
 	"github.com/planetary-social/scuttlego/internal"
 	"github.com/planetary-social/scuttlego/internal/fixtures"
 	"github.com/planetary-social/scuttlego/service/adapters/badger"
 	"github.com/planetary-social/scuttlego/service/app/common"
+	"github.com/planetary-social/scuttlego/service/app/queries"
 	"github.com/planetary-social/scuttlego/service/di"
 	"github.com/planetary-social/scuttlego/service/domain/feeds"
 	"github.com/planetary-social/scuttlego/service/domain/feeds/message"
@@ -364,7 +366,7 @@ func TestFeedRepository_DeleteRemovesDataFromChildRepositories(t *testing.T) {
 
 		for i, msg := range msgs {
 			_, err = adapters.MessageRepository.Get(msg.Id())
-			require.EqualError(t, err, "message not found: Key not found")
+			require.EqualError(t, err, "message not found")
 
 			_, err = adapters.ReceiveLogRepository.GetSequences(msg.Id())
 			require.ErrorIs(t, err, common.ErrReceiveLogEntryNotFound)
@@ -443,6 +445,84 @@ func TestFeedRepository_GetSequence(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestFeedRepository_ListFeedsReturnsFeedsWithMessages(t *testing.T) {
+	ts := di.BuildBadgerTestAdapters(t)
+
+	feedRef1 := fixtures.SomeRefFeed()
+	feedRef2 := fixtures.SomeRefFeed()
+	deletedFeedRef := fixtures.SomeRefFeed()
+
+	for _, feedRef := range []refs.Feed{feedRef1, feedRef2, deletedFeedRef} {
+		ts.Dependencies.BanListHasher.Mock(feedRef, fixtures.SomeBanListHash())
+	}
+
+	err := ts.TransactionProvider.View(func(adapters badger.TestAdapters) error {
+		feeds, err := adapters.FeedRepository.ListFeeds(nil, 10)
+		require.NoError(t, err)
+		require.Empty(t, feeds)
+
+		return nil
+	})
+	require.NoError(t, err)
+
+	insertMessages(t, ts, feedRef1, 3)
+	insertMessages(t, ts, feedRef2, 1)
+	insertMessages(t, ts, deletedFeedRef, 2)
+
+	err = ts.TransactionProvider.Update(func(adapters badger.TestAdapters) error {
+		return adapters.FeedRepository.DeleteFeed(deletedFeedRef)
+	})
+	require.NoError(t, err)
+
+	err = ts.TransactionProvider.View(func(adapters badger.TestAdapters) error {
+		feeds, err := adapters.FeedRepository.ListFeeds(nil, 10)
+		require.NoError(t, err)
+		require.ElementsMatch(t, []refs.Feed{feedRef1, feedRef2}, feeds)
+
+		return nil
+	})
+	require.NoError(t, err)
+}
+
+func TestFeedRepository_ListFeedsReturnsFeedsInPages(t *testing.T) {
+	ts := di.BuildBadgerTestAdapters(t)
+
+	const numberOfFeeds = 5
+	const limit = 2
+
+	var feedRefs []refs.Feed
+	for i := 0; i < numberOfFeeds; i++ {
+		feedRef := fixtures.SomeRefFeed()
+		ts.Dependencies.BanListHasher.Mock(feedRef, fixtures.SomeBanListHash())
+		insertMessages(t, ts, feedRef, 3)
+		feedRefs = append(feedRefs, feedRef)
+	}
+
+	var pageSizes []int
+	var result []refs.Feed
+	var after *refs.Feed
+	for {
+		var page []refs.Feed
+		err := ts.TransactionProvider.View(func(adapters badger.TestAdapters) error {
+			var err error
+			page, err = adapters.FeedRepository.ListFeeds(after, limit)
+			return err
+		})
+		require.NoError(t, err)
+
+		if len(page) == 0 {
+			break
+		}
+
+		pageSizes = append(pageSizes, len(page))
+		result = append(result, page...)
+		after = &page[len(page)-1]
+	}
+
+	require.Equal(t, []int{2, 2, 1}, pageSizes)
+	require.ElementsMatch(t, feedRefs, result)
+}
+
 func TestFeedRepository_GetMessagesReturnsEmptyListIfFeedIsEmpty(t *testing.T) {
 	ts := di.BuildBadgerTestAdapters(t)
 
@@ -536,9 +616,173 @@ func TestFeedRepository_GetMessagesListsCorrectMessages(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestFeedRepository_ListMessages(t *testing.T) {
+	ts := di.BuildBadgerTestAdapters(t)
+
+	feedRef := fixtures.SomeRefFeed()
+	ts.Dependencies.BanListHasher.Mock(feedRef, fixtures.SomeBanListHash())
+
+	var timestamps []time.Time
+	for i := 1; i <= 5; i++ {
+		timestamps = append(timestamps, time.UnixMilli(int64(i*10)))
+	}
+
+	msgs := insertMessagesWithTimestamps(t, ts, feedRef, timestamps)
+
+	testCases := []struct {
+		Name     string
+		Options  queries.FeedMessagesListOptions
+		Expected []message.Message
+	}{
+		{
+			Name:     "no_options",
+			Options:  queries.FeedMessagesListOptions{},
+			Expected: []message.Message{msgs[0], msgs[1], msgs[2], msgs[3], msgs[4]},
+		},
+		{
+			Name: "range",
+			Options: queries.FeedMessagesListOptions{
+				Gte: internal.Ptr(message.MustNewSequence(2)),
+				Lt:  internal.Ptr(message.MustNewSequence(4)),
+			},
+			Expected: []message.Message{msgs[1], msgs[2]},
+		},
+		{
+			Name: "empty_range",
+			Options: queries.FeedMessagesListOptions{
+				Gte: internal.Ptr(message.MustNewSequence(3)),
+				Lt:  internal.Ptr(message.MustNewSequence(3)),
+			},
+			Expected: nil,
+		},
+		{
+			Name: "reverse",
+			Options: queries.FeedMessagesListOptions{
+				Reverse: true,
+			},
+			Expected: []message.Message{msgs[4], msgs[3], msgs[2], msgs[1], msgs[0]},
+		},
+		{
+			Name: "lt",
+			Options: queries.FeedMessagesListOptions{
+				Lt: internal.Ptr(message.MustNewSequence(3)),
+			},
+			Expected: []message.Message{msgs[0], msgs[1]},
+		},
+		{
+			Name: "lt_reverse",
+			Options: queries.FeedMessagesListOptions{
+				Lt:      internal.Ptr(message.MustNewSequence(3)),
+				Reverse: true,
+			},
+			Expected: []message.Message{msgs[1], msgs[0]},
+		},
+		{
+			Name: "lte",
+			Options: queries.FeedMessagesListOptions{
+				Lte: internal.Ptr(message.MustNewSequence(3)),
+			},
+			Expected: []message.Message{msgs[0], msgs[1], msgs[2]},
+		},
+		{
+			Name: "lte_reverse",
+			Options: queries.FeedMessagesListOptions{
+				Lte:     internal.Ptr(message.MustNewSequence(3)),
+				Reverse: true,
+			},
+			Expected: []message.Message{msgs[2], msgs[1], msgs[0]},
+		},
+		{
+			Name: "lte_with_gte_reverse",
+			Options: queries.FeedMessagesListOptions{
+				Gte:     internal.Ptr(message.MustNewSequence(2)),
+				Lte:     internal.Ptr(message.MustNewSequence(4)),
+				Reverse: true,
+			},
+			Expected: []message.Message{msgs[3], msgs[2], msgs[1]},
+		},
+		{
+			Name: "lower_of_lt_and_lte_is_used",
+			Options: queries.FeedMessagesListOptions{
+				Lt:  internal.Ptr(message.MustNewSequence(5)),
+				Lte: internal.Ptr(message.MustNewSequence(2)),
+			},
+			Expected: []message.Message{msgs[0], msgs[1]},
+		},
+		{
+			Name: "empty_range_with_lte",
+			Options: queries.FeedMessagesListOptions{
+				Gte: internal.Ptr(message.MustNewSequence(3)),
+				Lte: internal.Ptr(message.MustNewSequence(2)),
+			},
+			Expected: nil,
+		},
+		{
+			Name: "reverse_with_range_and_limit",
+			Options: queries.FeedMessagesListOptions{
+				Gte:     internal.Ptr(message.MustNewSequence(2)),
+				Lt:      internal.Ptr(message.MustNewSequence(5)),
+				Reverse: true,
+				Limit:   internal.Ptr(2),
+			},
+			Expected: []message.Message{msgs[3], msgs[2]},
+		},
+		{
+			Name: "reverse_with_lt_above_last_sequence",
+			Options: queries.FeedMessagesListOptions{
+				Lt:      internal.Ptr(message.MustNewSequence(100)),
+				Reverse: true,
+				Limit:   internal.Ptr(1),
+			},
+			Expected: []message.Message{msgs[4]},
+		},
+		{
+			Name: "timestamps",
+			Options: queries.FeedMessagesListOptions{
+				Timestamps: queries.TimestampRange{
+					Gte: internal.Ptr(time.UnixMilli(20)),
+					Lt:  internal.Ptr(time.UnixMilli(40)),
+				},
+			},
+			Expected: []message.Message{msgs[1], msgs[2]},
+		},
+		{
+			Name: "skipped_messages_do_not_count_towards_limit",
+			Options: queries.FeedMessagesListOptions{
+				Reverse: true,
+				Timestamps: queries.TimestampRange{
+					Lt: internal.Ptr(time.UnixMilli(40)),
+				},
+				Limit: internal.Ptr(2),
+			},
+			Expected: []message.Message{msgs[2], msgs[1]},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			err := ts.TransactionProvider.View(func(adapters badger.TestAdapters) error {
+				result, err := adapters.FeedRepository.ListMessages(feedRef, testCase.Options)
+				require.NoError(t, err)
+				require.Equal(t, testCase.Expected, result)
+				return nil
+			})
+			require.NoError(t, err)
+		})
+	}
+}
+
 func insertMessages(t *testing.T, ts di.BadgerTestAdapters, feedRef refs.Feed, n int) []message.Message {
-	var messages []message.Message
+	var timestamps []time.Time
 	for i := 0; i < n; i++ {
+		timestamps = append(timestamps, fixtures.SomeTime())
+	}
+	return insertMessagesWithTimestamps(t, ts, feedRef, timestamps)
+}
+
+func insertMessagesWithTimestamps(t *testing.T, ts di.BadgerTestAdapters, feedRef refs.Feed, timestamps []time.Time) []message.Message {
+	var messages []message.Message
+	for i, timestamp := range timestamps {
 		seq := message.MustNewSequence(i + 1)
 
 		var previous *refs.Message
@@ -554,7 +798,7 @@ func insertMessages(t *testing.T, ts di.BadgerTestAdapters, feedRef refs.Feed, n
 			seq,
 			refs.MustNewIdentity(feedRef.String()),
 			feedRef,
-			fixtures.SomeTime(),
+			timestamp,
 			fixtures.SomeContent(),
 			rawMessage,
 		)
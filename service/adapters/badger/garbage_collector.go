@@ -11,13 +11,19 @@ import (
 
 const badgerGarbageCollectionErrorDelay = 1 * time.Minute
 
+type GarbageCollectorMetrics interface {
+	ReportBadgerSize(lsm, vlog int64)
+	ReportBadgerGarbageCollection(err error)
+}
+
 type GarbageCollector struct {
-	db     *badger.DB
-	logger logging.Logger
+	db      *badger.DB
+	metrics GarbageCollectorMetrics
+	logger  logging.Logger
 }
 
-func NewGarbageCollector(db *badger.DB, logger logging.Logger) *GarbageCollector {
-	return &GarbageCollector{db: db, logger: logger.New("badger_garbage_collector")}
+func NewGarbageCollector(db *badger.DB, metrics GarbageCollectorMetrics, logger logging.Logger) *GarbageCollector {
+	return &GarbageCollector{db: db, metrics: metrics, logger: logger.New("badger_garbage_collector")}
 }
 
 func (g *GarbageCollector) Run(ctx context.Context) error {
@@ -47,5 +53,8 @@ func (g *GarbageCollector) Run(ctx context.Context) error {
 }
 
 func (g *GarbageCollector) gc() error {
-	return g.db.RunValueLogGC(0.5)
+	err := g.db.RunValueLogGC(0.5)
+	g.metrics.ReportBadgerGarbageCollection(err)
+	g.metrics.ReportBadgerSize(g.db.Size())
+	return err
 }
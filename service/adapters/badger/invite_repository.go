@@ -0,0 +1,112 @@
+package badger
+
+import (
+	"time"
+
+	"github.com/boreq/errors"
+	"github.com/dgraph-io/badger/v3"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/planetary-social/scuttlego/service/adapters/badger/utils"
+	"github.com/planetary-social/scuttlego/service/app/commands"
+	"github.com/planetary-social/scuttlego/service/domain/invites"
+	"github.com/planetary-social/scuttlego/service/domain/refs"
+)
+
+var bucketInvitesKey = utils.MustNewKey(
+	utils.MustNewKeyComponent([]byte("invites")),
+)
+
+type InviteRepository struct {
+	tx *badger.Txn
+}
+
+func NewInviteRepository(
+	tx *badger.Txn,
+) *InviteRepository {
+	return &InviteRepository{
+		tx: tx,
+	}
+}
+
+func (r InviteRepository) Put(invite *invites.PubInvite) error {
+	v, err := jsoniter.Marshal(newStoredPubInvite(invite))
+	if err != nil {
+		return errors.Wrap(err, "json marshal failed")
+	}
+
+	if err := r.bucket().Set(r.key(invite.Id()), v); err != nil {
+		return errors.Wrap(err, "bucket set failed")
+	}
+
+	return nil
+}
+
+func (r InviteRepository) UpdateInvite(id refs.Identity, f func(invite *invites.PubInvite) error) error {
+	invite, err := r.Get(id)
+	if err != nil {
+		return errors.Wrap(err, "error getting the invite")
+	}
+
+	if err := f(invite); err != nil {
+		return errors.Wrap(err, "provided function returned an error")
+	}
+
+	if err := r.Put(invite); err != nil {
+		return errors.Wrap(err, "error saving the invite")
+	}
+
+	return nil
+}
+
+// Get returns commands.ErrInviteNotFound if the invite doesn't exist.
+func (r InviteRepository) Get(id refs.Identity) (*invites.PubInvite, error) {
+	item, err := r.bucket().Get(r.key(id))
+	if err != nil {
+		if errors.Is(err, badger.ErrKeyNotFound) {
+			return nil, commands.ErrInviteNotFound
+		}
+		return nil, errors.Wrap(err, "get failed")
+	}
+
+	value, err := item.ValueCopy(nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "error getting item value")
+	}
+
+	var stored storedPubInvite
+	if err := jsoniter.Unmarshal(value, &stored); err != nil {
+		return nil, errors.Wrap(err, "json unmarshal failed")
+	}
+
+	var expiresAt time.Time
+	if stored.ExpiresAt != 0 {
+		expiresAt = time.Unix(0, stored.ExpiresAt)
+	}
+
+	return invites.NewPubInvite(id, stored.RemainingUses, expiresAt)
+}
+
+func (r InviteRepository) bucket() utils.Bucket {
+	return utils.MustNewBucket(r.tx, bucketInvitesKey)
+}
+
+func (r InviteRepository) key(id refs.Identity) []byte {
+	return []byte(id.String())
+}
+
+type storedPubInvite struct {
+	RemainingUses int   `json:"remainingUses"`
+	ExpiresAt     int64 `json:"expiresAt,omitempty"`
+}
+
+func newStoredPubInvite(invite *invites.PubInvite) storedPubInvite {
+	var expiresAt int64
+	if !invite.ExpiresAt().IsZero() {
+		expiresAt = invite.ExpiresAt().UnixNano()
+	}
+
+	return storedPubInvite{
+		RemainingUses: invite.RemainingUses(),
+		ExpiresAt:     expiresAt,
+	}
+}
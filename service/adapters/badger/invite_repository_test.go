@@ -0,0 +1,90 @@
+package badger_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/planetary-social/scuttlego/internal/fixtures"
+	"github.com/planetary-social/scuttlego/service/adapters/badger"
+	"github.com/planetary-social/scuttlego/service/app/commands"
+	"github.com/planetary-social/scuttlego/service/di"
+	"github.com/planetary-social/scuttlego/service/domain/invites"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInviteRepository_PutAndGet(t *testing.T) {
+	testCases := []struct {
+		Name      string
+		ExpiresAt time.Time
+	}{
+		{
+			Name:      "never_expires",
+			ExpiresAt: time.Time{},
+		},
+		{
+			Name:      "expires",
+			ExpiresAt: time.Unix(1234, 5678),
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			ts := di.BuildBadgerTestAdapters(t)
+
+			invite := invites.MustNewPubInvite(fixtures.SomeRefIdentity(), 10, testCase.ExpiresAt)
+
+			err := ts.TransactionProvider.Update(func(adapters badger.TestAdapters) error {
+				return adapters.InviteRepository.Put(invite)
+			})
+			require.NoError(t, err)
+
+			err = ts.TransactionProvider.View(func(adapters badger.TestAdapters) error {
+				retrieved, err := adapters.InviteRepository.Get(invite.Id())
+				require.NoError(t, err)
+
+				require.Equal(t, invite.Id(), retrieved.Id())
+				require.Equal(t, invite.RemainingUses(), retrieved.RemainingUses())
+				require.True(t, invite.ExpiresAt().Equal(retrieved.ExpiresAt()))
+				return nil
+			})
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestInviteRepository_UpdateInviteSavesChanges(t *testing.T) {
+	ts := di.BuildBadgerTestAdapters(t)
+
+	invite := invites.MustNewPubInvite(fixtures.SomeRefIdentity(), 2, time.Time{})
+
+	err := ts.TransactionProvider.Update(func(adapters badger.TestAdapters) error {
+		return adapters.InviteRepository.Put(invite)
+	})
+	require.NoError(t, err)
+
+	err = ts.TransactionProvider.Update(func(adapters badger.TestAdapters) error {
+		return adapters.InviteRepository.UpdateInvite(invite.Id(), func(invite *invites.PubInvite) error {
+			return invite.Use(time.Now())
+		})
+	})
+	require.NoError(t, err)
+
+	err = ts.TransactionProvider.View(func(adapters badger.TestAdapters) error {
+		retrieved, err := adapters.InviteRepository.Get(invite.Id())
+		require.NoError(t, err)
+		require.Equal(t, 1, retrieved.RemainingUses())
+		return nil
+	})
+	require.NoError(t, err)
+}
+
+func TestInviteRepository_UpdateInviteReturnsErrInviteNotFound(t *testing.T) {
+	ts := di.BuildBadgerTestAdapters(t)
+
+	err := ts.TransactionProvider.Update(func(adapters badger.TestAdapters) error {
+		return adapters.InviteRepository.UpdateInvite(fixtures.SomeRefIdentity(), func(invite *invites.PubInvite) error {
+			return nil
+		})
+	})
+	require.ErrorIs(t, err, commands.ErrInviteNotFound)
+}
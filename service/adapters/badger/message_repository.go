@@ -4,6 +4,7 @@ import (
 	"github.com/boreq/errors"
 	"github.com/dgraph-io/badger/v3"
 	"github.com/planetary-social/scuttlego/service/adapters/badger/utils"
+	"github.com/planetary-social/scuttlego/service/app/common"
 	"github.com/planetary-social/scuttlego/service/domain/feeds/message"
 	"github.com/planetary-social/scuttlego/service/domain/refs"
 )
@@ -67,7 +68,7 @@ func (r MessageRepository) Get(id refs.Message) (message.Message, error) {
 	item, err := bucket.Get(r.messageKey(id))
 	if err != nil {
 		if errors.Is(err, badger.ErrKeyNotFound) {
-			return message.Message{}, errors.Wrap(err, "message not found")
+			return message.Message{}, common.ErrMessageNotFound
 		}
 
 		return message.Message{}, errors.Wrap(err, "error getting message")
@@ -27,7 +27,7 @@ func TestMessageRepository_GetNoMessage(t *testing.T) {
 
 	err := ts.TransactionProvider.View(func(adapters badger.TestAdapters) error {
 		_, err := adapters.MessageRepository.Get(fixtures.SomeRefMessage())
-		require.EqualError(t, err, "message not found: Key not found")
+		require.EqualError(t, err, "message not found")
 
 		return nil
 	})
@@ -83,7 +83,7 @@ func TestMessageRepository_Delete(t *testing.T) {
 
 	err = ts.TransactionProvider.View(func(adapters badger.TestAdapters) error {
 		_, err = adapters.MessageRepository.Get(msg.Id())
-		require.EqualError(t, err, "message not found: Key not found")
+		require.EqualError(t, err, "message not found")
 
 		return nil
 	})
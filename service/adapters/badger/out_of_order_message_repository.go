@@ -0,0 +1,94 @@
+package badger
+
+import (
+	"github.com/boreq/errors"
+	"github.com/dgraph-io/badger/v3"
+	"github.com/planetary-social/scuttlego/service/adapters/badger/utils"
+	"github.com/planetary-social/scuttlego/service/app/common"
+	"github.com/planetary-social/scuttlego/service/domain/feeds/message"
+	"github.com/planetary-social/scuttlego/service/domain/refs"
+)
+
+var (
+	outOfOrderMessageRepositoryBucket = utils.MustNewKey(
+		utils.MustNewKeyComponent([]byte("ooo")),
+		utils.MustNewKeyComponent([]byte("messages")),
+	)
+)
+
+// OutOfOrderMessageRepository stores messages which were retrieved by their
+// id instead of being replicated as part of their feeds. Those messages don't
+// belong to any feed and aren't placed in the receive log.
+type OutOfOrderMessageRepository struct {
+	tx         *badger.Txn
+	identifier RawMessageIdentifier
+}
+
+func NewOutOfOrderMessageRepository(
+	tx *badger.Txn,
+	identifier RawMessageIdentifier,
+) *OutOfOrderMessageRepository {
+	return &OutOfOrderMessageRepository{
+		tx:         tx,
+		identifier: identifier,
+	}
+}
+
+func (r OutOfOrderMessageRepository) Put(msg message.Message) error {
+	bucket, err := r.createBucket()
+	if err != nil {
+		return errors.Wrap(err, "could not create the bucket")
+	}
+
+	if err := bucket.Set(r.key(msg.Id()), msg.Raw().Bytes()); err != nil {
+		return errors.Wrap(err, "bucket put failed")
+	}
+
+	return nil
+}
+
+// Get returns common.ErrMessageNotFound if the message doesn't exist.
+func (r OutOfOrderMessageRepository) Get(id refs.Message) (message.Message, error) {
+	bucket, err := r.createBucket()
+	if err != nil {
+		return message.Message{}, errors.Wrap(err, "could not get the bucket")
+	}
+
+	item, err := bucket.Get(r.key(id))
+	if err != nil {
+		if errors.Is(err, badger.ErrKeyNotFound) {
+			return message.Message{}, common.ErrMessageNotFound
+		}
+		return message.Message{}, errors.Wrap(err, "error getting message")
+	}
+
+	value, err := item.ValueCopy(nil)
+	if err != nil {
+		return message.Message{}, errors.Wrap(err, "could not get the value")
+	}
+
+	rawMsg, err := message.NewVerifiedRawMessage(value)
+	if err != nil {
+		return message.Message{}, errors.Wrap(err, "could not create a raw message")
+	}
+
+	msgWithoutId, err := r.identifier.LoadRawMessage(rawMsg)
+	if err != nil {
+		return message.Message{}, errors.Wrap(err, "could not load the raw message")
+	}
+
+	msg, err := message.NewMessageFromMessageWithoutId(id, msgWithoutId)
+	if err != nil {
+		return message.Message{}, errors.Wrap(err, "could not create a message")
+	}
+
+	return msg, nil
+}
+
+func (r OutOfOrderMessageRepository) key(id refs.Message) []byte {
+	return []byte(id.String())
+}
+
+func (r OutOfOrderMessageRepository) createBucket() (utils.Bucket, error) {
+	return utils.NewBucket(r.tx, outOfOrderMessageRepositoryBucket)
+}
@@ -0,0 +1,46 @@
+package badger_test
+
+import (
+	"testing"
+
+	"github.com/planetary-social/scuttlego/internal/fixtures"
+	"github.com/planetary-social/scuttlego/service/adapters/badger"
+	"github.com/planetary-social/scuttlego/service/app/common"
+	"github.com/planetary-social/scuttlego/service/di"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutOfOrderMessageRepository_GetNoMessage(t *testing.T) {
+	ts := di.BuildBadgerTestAdapters(t)
+
+	err := ts.TransactionProvider.View(func(adapters badger.TestAdapters) error {
+		_, err := adapters.OutOfOrderMessageRepository.Get(fixtures.SomeRefMessage())
+		require.ErrorIs(t, err, common.ErrMessageNotFound)
+
+		return nil
+	})
+	require.NoError(t, err)
+}
+
+func TestOutOfOrderMessageRepository_Put_Get(t *testing.T) {
+	ts := di.BuildBadgerTestAdapters(t)
+
+	msg := fixtures.SomeMessage(fixtures.SomeSequence(), fixtures.SomeRefFeed())
+
+	err := ts.TransactionProvider.Update(func(adapters badger.TestAdapters) error {
+		return adapters.OutOfOrderMessageRepository.Put(msg)
+	})
+	require.NoError(t, err)
+
+	err = ts.TransactionProvider.View(func(adapters badger.TestAdapters) error {
+		retrievedMessage, err := adapters.OutOfOrderMessageRepository.Get(msg.Id())
+		require.NoError(t, err)
+		require.Equal(t, msg.Raw(), retrievedMessage.Raw())
+
+		_, err = adapters.MessageRepository.Get(msg.Id())
+		require.ErrorIs(t, err, common.ErrMessageNotFound)
+
+		return nil
+	})
+	require.NoError(t, err)
+}
@@ -2,6 +2,7 @@ package badger
 
 import (
 	"encoding/binary"
+	"math"
 
 	"github.com/boreq/errors"
 	"github.com/dgraph-io/badger/v3"
@@ -114,11 +115,17 @@ func (r ReceiveLogRepository) put(id refs.Message, receiveLogSeq common.ReceiveL
 	return nil
 }
 
-func (r ReceiveLogRepository) List(startSeq common.ReceiveLogSequence, limit int) ([]queries.LogMessage, error) {
-	if limit <= 0 {
+func (r ReceiveLogRepository) List(options queries.ReceiveLogListOptions) ([]queries.LogMessage, error) {
+	if options.Limit <= 0 {
 		return nil, errors.New("limit must be positive")
 	}
 
+	lt := exclusiveUpperBound(receiveLogSequencePointerToInt(options.Lt), receiveLogSequencePointerToInt(options.Lte))
+
+	if lt != nil && *lt <= options.Gte.Int() {
+		return nil, nil
+	}
+
 	bucket, err := r.createSequencesToMessagesBucket()
 	if err != nil {
 		return nil, errors.Wrap(err, "could not create a bucket")
@@ -126,10 +133,12 @@ func (r ReceiveLogRepository) List(startSeq common.ReceiveLogSequence, limit int
 
 	var result []queries.LogMessage
 
-	it := bucket.Iterator()
+	it := bucket.IteratorWithModifiedOptions(func(iteratorOptions *badger.IteratorOptions) {
+		iteratorOptions.Reverse = options.Reverse
+	})
 	defer it.Close()
 
-	for it.Seek(itob(uint64(startSeq.Int()))); it.ValidForBucket(); it.Next() {
+	for it.Seek(r.listSeekKey(options.Gte, lt, options.Reverse)); it.ValidForBucket(); it.Next() {
 		item := it.Item()
 
 		keyInBucket, err := bucket.KeyInBucket(item)
@@ -142,6 +151,14 @@ func (r ReceiveLogRepository) List(startSeq common.ReceiveLogSequence, limit int
 			return nil, errors.Wrap(err, "could not load the key")
 		}
 
+		if options.Reverse && receiveLogSequence.Int() < options.Gte.Int() {
+			break
+		}
+
+		if !options.Reverse && lt != nil && receiveLogSequence.Int() >= *lt {
+			break
+		}
+
 		val, err := item.ValueCopy(nil)
 		if err != nil {
 			return nil, errors.Wrap(err, "could not get the value")
@@ -152,12 +169,16 @@ func (r ReceiveLogRepository) List(startSeq common.ReceiveLogSequence, limit int
 			return nil, errors.Wrapf(err, "could not load the message '%d'", receiveLogSequence.Int())
 		}
 
+		if !options.Timestamps.Includes(msg.Timestamp()) {
+			continue
+		}
+
 		result = append(result, queries.LogMessage{
 			Message:  msg,
 			Sequence: receiveLogSequence,
 		})
 
-		if len(result) >= limit {
+		if len(result) >= options.Limit {
 			break
 		}
 	}
@@ -165,6 +186,21 @@ func (r ReceiveLogRepository) List(startSeq common.ReceiveLogSequence, limit int
 	return result, nil
 }
 
+// listSeekKey returns the key at which the iteration should start. Reverse
+// iterators seek to the closest key which is lower than or equal to the
+// provided key.
+func (r ReceiveLogRepository) listSeekKey(gte common.ReceiveLogSequence, lt *int, reverse bool) []byte {
+	if !reverse {
+		return r.marshalSequence(gte)
+	}
+
+	if lt != nil {
+		return itob(uint64(*lt - 1))
+	}
+
+	return itob(math.MaxUint64)
+}
+
 func (r ReceiveLogRepository) GetMessage(seq common.ReceiveLogSequence) (message.Message, error) {
 	bucket, err := r.createSequencesToMessagesBucket()
 	if err != nil {
@@ -339,6 +375,34 @@ func itob(v uint64) []byte {
 	return b
 }
 
+// exclusiveUpperBound combines the upper bounds used when listing messages
+// into a single exclusive bound. Nil means that there is no upper bound.
+func exclusiveUpperBound(lt, lte *int) *int {
+	if lte != nil {
+		v := *lte + 1
+		if lt == nil || v < *lt {
+			return &v
+		}
+	}
+	return lt
+}
+
+func sequencePointerToInt(seq *message.Sequence) *int {
+	if seq == nil {
+		return nil
+	}
+	v := seq.Int()
+	return &v
+}
+
+func receiveLogSequencePointerToInt(seq *common.ReceiveLogSequence) *int {
+	if seq == nil {
+		return nil
+	}
+	v := seq.Int()
+	return &v
+}
+
 func btoi(v []byte) uint64 {
 	return binary.BigEndian.Uint64(v)
 }
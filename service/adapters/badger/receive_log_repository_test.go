@@ -2,8 +2,10 @@ package badger_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/boreq/errors"
+	"github.com/planetary-social/scuttlego/internal"
 	"github.com/planetary-social/scuttlego/internal/fixtures"
 	"github.com/planetary-social/scuttlego/service/adapters/badger"
 	"github.com/planetary-social/scuttlego/service/app/common"
@@ -109,7 +111,7 @@ func TestReceiveLog_Get_ReturnsNoMessagesWhenEmpty(t *testing.T) {
 	ts := di.BuildBadgerTestAdapters(t)
 
 	err := ts.TransactionProvider.View(func(adapters badger.TestAdapters) error {
-		msgs, err := adapters.ReceiveLogRepository.List(common.MustNewReceiveLogSequence(0), 10)
+		msgs, err := adapters.ReceiveLogRepository.List(queries.ReceiveLogListOptions{Gte: common.MustNewReceiveLogSequence(0), Limit: 10})
 		require.NoError(t, err)
 		require.Empty(t, msgs)
 
@@ -122,7 +124,7 @@ func TestReceiveLog_Get_ReturnsErrorForInvalidLimit(t *testing.T) {
 	ts := di.BuildBadgerTestAdapters(t)
 
 	err := ts.TransactionProvider.View(func(adapters badger.TestAdapters) error {
-		_, err := adapters.ReceiveLogRepository.List(common.MustNewReceiveLogSequence(0), 0)
+		_, err := adapters.ReceiveLogRepository.List(queries.ReceiveLogListOptions{Gte: common.MustNewReceiveLogSequence(0), Limit: 0})
 		require.EqualError(t, err, "limit must be positive")
 
 		return nil
@@ -193,7 +195,7 @@ func TestReceiveLog_Get_ReturnsMessagesObeyingLimitAndStartSeq(t *testing.T) {
 
 	t.Run("seq_0", func(t *testing.T) {
 		err = ts.TransactionProvider.View(func(adapters badger.TestAdapters) error {
-			msgs, err := adapters.ReceiveLogRepository.List(common.MustNewReceiveLogSequence(0), 10)
+			msgs, err := adapters.ReceiveLogRepository.List(queries.ReceiveLogListOptions{Gte: common.MustNewReceiveLogSequence(0), Limit: 10})
 			require.NoError(t, err)
 			require.Len(t, msgs, 10)
 
@@ -204,7 +206,7 @@ func TestReceiveLog_Get_ReturnsMessagesObeyingLimitAndStartSeq(t *testing.T) {
 
 	t.Run("seq_5", func(t *testing.T) {
 		err = ts.TransactionProvider.View(func(adapters badger.TestAdapters) error {
-			msgs, err := adapters.ReceiveLogRepository.List(common.MustNewReceiveLogSequence(5), 10)
+			msgs, err := adapters.ReceiveLogRepository.List(queries.ReceiveLogListOptions{Gte: common.MustNewReceiveLogSequence(5), Limit: 10})
 			require.NoError(t, err)
 			require.Len(t, msgs, 5)
 
@@ -214,6 +216,166 @@ func TestReceiveLog_Get_ReturnsMessagesObeyingLimitAndStartSeq(t *testing.T) {
 	})
 }
 
+func TestReceiveLog_List_ReturnsMessagesObeyingRangeReverseAndTimestamps(t *testing.T) {
+	ts := di.BuildBadgerTestAdapters(t)
+
+	feedRef := fixtures.SomeRefFeed()
+	ts.Dependencies.BanListHasher.Mock(feedRef, fixtures.SomeBanListHash())
+
+	var timestamps []time.Time
+	for i := 0; i < 5; i++ {
+		timestamps = append(timestamps, time.UnixMilli(int64(i*10)))
+	}
+
+	msgs := insertMessagesWithTimestamps(t, ts, feedRef, timestamps)
+
+	testCases := []struct {
+		Name              string
+		Options           queries.ReceiveLogListOptions
+		ExpectedSequences []int
+	}{
+		{
+			Name: "range",
+			Options: queries.ReceiveLogListOptions{
+				Gte:   common.MustNewReceiveLogSequence(1),
+				Lt:    internal.Ptr(common.MustNewReceiveLogSequence(3)),
+				Limit: 10,
+			},
+			ExpectedSequences: []int{1, 2},
+		},
+		{
+			Name: "reverse",
+			Options: queries.ReceiveLogListOptions{
+				Reverse: true,
+				Limit:   10,
+			},
+			ExpectedSequences: []int{4, 3, 2, 1, 0},
+		},
+		{
+			Name: "reverse_with_range_and_limit",
+			Options: queries.ReceiveLogListOptions{
+				Gte:     common.MustNewReceiveLogSequence(1),
+				Lt:      internal.Ptr(common.MustNewReceiveLogSequence(4)),
+				Reverse: true,
+				Limit:   2,
+			},
+			ExpectedSequences: []int{3, 2},
+		},
+		{
+			Name: "reverse_with_gte",
+			Options: queries.ReceiveLogListOptions{
+				Gte:     common.MustNewReceiveLogSequence(3),
+				Reverse: true,
+				Limit:   10,
+			},
+			ExpectedSequences: []int{4, 3},
+		},
+		{
+			Name: "empty_range",
+			Options: queries.ReceiveLogListOptions{
+				Gte:   common.MustNewReceiveLogSequence(2),
+				Lt:    internal.Ptr(common.MustNewReceiveLogSequence(2)),
+				Limit: 10,
+			},
+			ExpectedSequences: nil,
+		},
+		{
+			Name: "lt",
+			Options: queries.ReceiveLogListOptions{
+				Lt:    internal.Ptr(common.MustNewReceiveLogSequence(2)),
+				Limit: 10,
+			},
+			ExpectedSequences: []int{0, 1},
+		},
+		{
+			Name: "lt_reverse",
+			Options: queries.ReceiveLogListOptions{
+				Lt:      internal.Ptr(common.MustNewReceiveLogSequence(2)),
+				Reverse: true,
+				Limit:   10,
+			},
+			ExpectedSequences: []int{1, 0},
+		},
+		{
+			Name: "lte",
+			Options: queries.ReceiveLogListOptions{
+				Lte:   internal.Ptr(common.MustNewReceiveLogSequence(2)),
+				Limit: 10,
+			},
+			ExpectedSequences: []int{0, 1, 2},
+		},
+		{
+			Name: "lte_reverse",
+			Options: queries.ReceiveLogListOptions{
+				Lte:     internal.Ptr(common.MustNewReceiveLogSequence(2)),
+				Reverse: true,
+				Limit:   10,
+			},
+			ExpectedSequences: []int{2, 1, 0},
+		},
+		{
+			Name: "lte_above_last_sequence_reverse",
+			Options: queries.ReceiveLogListOptions{
+				Lte:     internal.Ptr(common.MustNewReceiveLogSequence(100)),
+				Reverse: true,
+				Limit:   1,
+			},
+			ExpectedSequences: []int{4},
+		},
+		{
+			Name: "lower_of_lt_and_lte_is_used",
+			Options: queries.ReceiveLogListOptions{
+				Lt:    internal.Ptr(common.MustNewReceiveLogSequence(4)),
+				Lte:   internal.Ptr(common.MustNewReceiveLogSequence(1)),
+				Limit: 10,
+			},
+			ExpectedSequences: []int{0, 1},
+		},
+		{
+			Name: "empty_range_with_lte",
+			Options: queries.ReceiveLogListOptions{
+				Gte:   common.MustNewReceiveLogSequence(2),
+				Lte:   internal.Ptr(common.MustNewReceiveLogSequence(1)),
+				Limit: 10,
+			},
+			ExpectedSequences: nil,
+		},
+		{
+			Name: "timestamps",
+			Options: queries.ReceiveLogListOptions{
+				Reverse: true,
+				Timestamps: queries.TimestampRange{
+					Gte: internal.Ptr(time.UnixMilli(10)),
+					Lt:  internal.Ptr(time.UnixMilli(30)),
+				},
+				Limit: 10,
+			},
+			ExpectedSequences: []int{2, 1},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			err := ts.TransactionProvider.View(func(adapters badger.TestAdapters) error {
+				result, err := adapters.ReceiveLogRepository.List(testCase.Options)
+				require.NoError(t, err)
+
+				var expected []queries.LogMessage
+				for _, seq := range testCase.ExpectedSequences {
+					expected = append(expected, queries.LogMessage{
+						Message:  msgs[seq],
+						Sequence: common.MustNewReceiveLogSequence(seq),
+					})
+				}
+
+				require.Equal(t, expected, result)
+				return nil
+			})
+			require.NoError(t, err)
+		})
+	}
+}
+
 func TestReceiveLog_PutUnderSpecificSequence_InsertsCorrectMapping(t *testing.T) {
 	ts := di.BuildBadgerTestAdapters(t)
 
@@ -275,7 +437,7 @@ func TestReceiveLogRepository_PutUnderSpecificSequenceAdvancesInternalSequenceCo
 	require.NoError(t, err)
 
 	err = ts.TransactionProvider.View(func(adapters badger.TestAdapters) error {
-		msgs, err := adapters.ReceiveLogRepository.List(common.MustNewReceiveLogSequence(0), 100)
+		msgs, err := adapters.ReceiveLogRepository.List(queries.ReceiveLogListOptions{Gte: common.MustNewReceiveLogSequence(0), Limit: 100})
 		require.NoError(t, err)
 
 		require.Len(t, msgs, 1)
@@ -299,7 +461,7 @@ func TestReceiveLogRepository_PutUnderSpecificSequenceAdvancesInternalSequenceCo
 	require.NoError(t, err)
 
 	err = ts.TransactionProvider.View(func(adapters badger.TestAdapters) error {
-		msgs, err := adapters.ReceiveLogRepository.List(common.MustNewReceiveLogSequence(0), 100)
+		msgs, err := adapters.ReceiveLogRepository.List(queries.ReceiveLogListOptions{Gte: common.MustNewReceiveLogSequence(0), Limit: 100})
 		require.NoError(t, err)
 
 		require.Len(t, msgs, 2)
@@ -341,7 +503,7 @@ func TestReceiveLogRepository_PutUnderSpecificSequenceDoesNotAdvanceInternalSequ
 	require.NoError(t, err)
 
 	err = ts.TransactionProvider.View(func(adapters badger.TestAdapters) error {
-		msgs, err := adapters.ReceiveLogRepository.List(common.MustNewReceiveLogSequence(0), 100)
+		msgs, err := adapters.ReceiveLogRepository.List(queries.ReceiveLogListOptions{Gte: common.MustNewReceiveLogSequence(0), Limit: 100})
 		require.NoError(t, err)
 
 		require.Len(t, msgs, 2)
@@ -375,7 +537,7 @@ func TestReceiveLogRepository_PutUnderSpecificSequenceDoesNotAdvanceInternalSequ
 	require.NoError(t, err)
 
 	err = ts.TransactionProvider.View(func(adapters badger.TestAdapters) error {
-		msgs, err := adapters.ReceiveLogRepository.List(common.MustNewReceiveLogSequence(0), 100)
+		msgs, err := adapters.ReceiveLogRepository.List(queries.ReceiveLogListOptions{Gte: common.MustNewReceiveLogSequence(0), Limit: 100})
 		require.NoError(t, err)
 
 		require.Len(t, msgs, 3)
@@ -514,7 +676,7 @@ func TestReceiveLogRepository_ReserveSequencesUpToAdvancesInternalSequenceCounte
 	require.NoError(t, err)
 
 	err = ts.TransactionProvider.View(func(adapters badger.TestAdapters) error {
-		msgs, err := adapters.ReceiveLogRepository.List(common.MustNewReceiveLogSequence(0), 100)
+		msgs, err := adapters.ReceiveLogRepository.List(queries.ReceiveLogListOptions{Gte: common.MustNewReceiveLogSequence(0), Limit: 100})
 		require.NoError(t, err)
 
 		require.Equal(
@@ -555,7 +717,7 @@ func TestReceiveLogRepository_ReserveSequencesUpToAdvancesInternalSequenceCounte
 	require.NoError(t, err)
 
 	err = ts.TransactionProvider.View(func(adapters badger.TestAdapters) error {
-		msgs, err := adapters.ReceiveLogRepository.List(common.MustNewReceiveLogSequence(0), 100)
+		msgs, err := adapters.ReceiveLogRepository.List(queries.ReceiveLogListOptions{Gte: common.MustNewReceiveLogSequence(0), Limit: 100})
 		require.NoError(t, err)
 
 		require.Equal(
@@ -600,7 +762,7 @@ func TestReceiveLogRepository_ReserveSequencesUpToDoesNotAdvanceInternalSequence
 	require.NoError(t, err)
 
 	err = ts.TransactionProvider.View(func(adapters badger.TestAdapters) error {
-		msgs, err := adapters.ReceiveLogRepository.List(common.MustNewReceiveLogSequence(0), 100)
+		msgs, err := adapters.ReceiveLogRepository.List(queries.ReceiveLogListOptions{Gte: common.MustNewReceiveLogSequence(0), Limit: 100})
 		require.NoError(t, err)
 
 		require.Equal(
@@ -641,7 +803,7 @@ func TestReceiveLogRepository_ReserveSequencesUpToDoesNotAdvanceInternalSequence
 	require.NoError(t, err)
 
 	err = ts.TransactionProvider.View(func(adapters badger.TestAdapters) error {
-		msgs, err := adapters.ReceiveLogRepository.List(common.MustNewReceiveLogSequence(0), 100)
+		msgs, err := adapters.ReceiveLogRepository.List(queries.ReceiveLogListOptions{Gte: common.MustNewReceiveLogSequence(0), Limit: 100})
 		require.NoError(t, err)
 
 		require.Equal(
@@ -0,0 +1,159 @@
+package badger
+
+import (
+	"encoding/base64"
+
+	"github.com/boreq/errors"
+	"github.com/dgraph-io/badger/v3"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/planetary-social/scuttlego/service/adapters/badger/utils"
+	"github.com/planetary-social/scuttlego/service/app/common"
+	"github.com/planetary-social/scuttlego/service/domain/refs"
+	"github.com/planetary-social/scuttlego/service/domain/rooms/aliases"
+)
+
+var bucketRoomAliasesKey = utils.MustNewKey(
+	utils.MustNewKeyComponent([]byte("room_aliases")),
+)
+
+// RoomAliasRepository stores aliases registered in the room hosted by this
+// node.
+type RoomAliasRepository struct {
+	tx *badger.Txn
+}
+
+func NewRoomAliasRepository(
+	tx *badger.Txn,
+) *RoomAliasRepository {
+	return &RoomAliasRepository{
+		tx: tx,
+	}
+}
+
+// Put saves the registration overwriting any existing registration of the
+// same alias.
+func (r RoomAliasRepository) Put(registration aliases.Registration) error {
+	v, err := jsoniter.Marshal(storedRoomAlias{
+		User:      registration.User().String(),
+		Room:      registration.Room().String(),
+		Signature: base64.StdEncoding.EncodeToString(registration.Signature().Bytes()),
+	})
+	if err != nil {
+		return errors.Wrap(err, "json marshal failed")
+	}
+
+	if err := r.bucket().Set(r.key(registration.Alias()), v); err != nil {
+		return errors.Wrap(err, "bucket set failed")
+	}
+
+	return nil
+}
+
+func (r RoomAliasRepository) Delete(alias aliases.Alias) error {
+	if err := r.bucket().Delete(r.key(alias)); err != nil {
+		return errors.Wrap(err, "bucket delete failed")
+	}
+	return nil
+}
+
+// Get returns common.ErrRoomAliasNotFound if the alias isn't registered.
+func (r RoomAliasRepository) Get(alias aliases.Alias) (aliases.Registration, error) {
+	item, err := r.bucket().Get(r.key(alias))
+	if err != nil {
+		if errors.Is(err, badger.ErrKeyNotFound) {
+			return aliases.Registration{}, common.ErrRoomAliasNotFound
+		}
+		return aliases.Registration{}, errors.Wrap(err, "get failed")
+	}
+
+	value, err := item.ValueCopy(nil)
+	if err != nil {
+		return aliases.Registration{}, errors.Wrap(err, "error getting item value")
+	}
+
+	return r.load(alias, value)
+}
+
+// List returns aliases registered by the user.
+func (r RoomAliasRepository) List(user refs.Identity) ([]aliases.Alias, error) {
+	bucket := r.bucket()
+
+	var result []aliases.Alias
+	if err := bucket.ForEach(func(item utils.Item) error {
+		keyInBucket, err := bucket.KeyInBucket(item)
+		if err != nil {
+			return errors.Wrap(err, "error getting key in bucket")
+		}
+
+		alias, err := aliases.NewAlias(string(keyInBucket.Bytes()))
+		if err != nil {
+			return errors.Wrap(err, "error creating the alias")
+		}
+
+		value, err := item.ValueCopy(nil)
+		if err != nil {
+			return errors.Wrap(err, "error getting item value")
+		}
+
+		registration, err := r.load(alias, value)
+		if err != nil {
+			return errors.Wrap(err, "error loading the registration")
+		}
+
+		if registration.User().Equal(user) {
+			result = append(result, alias)
+		}
+		return nil
+	}); err != nil {
+		return nil, errors.Wrap(err, "foreach error")
+	}
+	return result, nil
+}
+
+func (r RoomAliasRepository) load(alias aliases.Alias, value []byte) (aliases.Registration, error) {
+	var stored storedRoomAlias
+	if err := jsoniter.Unmarshal(value, &stored); err != nil {
+		return aliases.Registration{}, errors.Wrap(err, "json unmarshal failed")
+	}
+
+	user, err := refs.NewIdentity(stored.User)
+	if err != nil {
+		return aliases.Registration{}, errors.Wrap(err, "error creating the user ref")
+	}
+
+	room, err := refs.NewIdentity(stored.Room)
+	if err != nil {
+		return aliases.Registration{}, errors.Wrap(err, "error creating the room ref")
+	}
+
+	signatureBytes, err := base64.StdEncoding.DecodeString(stored.Signature)
+	if err != nil {
+		return aliases.Registration{}, errors.Wrap(err, "error decoding the signature")
+	}
+
+	signature, err := aliases.NewRegistrationSignatureFromBytes(signatureBytes)
+	if err != nil {
+		return aliases.Registration{}, errors.Wrap(err, "error creating the signature")
+	}
+
+	msg, err := aliases.NewRegistrationMessage(alias, user, room)
+	if err != nil {
+		return aliases.Registration{}, errors.Wrap(err, "error creating the registration message")
+	}
+
+	return aliases.NewRegistration(msg, signature)
+}
+
+func (r RoomAliasRepository) bucket() utils.Bucket {
+	return utils.MustNewBucket(r.tx, bucketRoomAliasesKey)
+}
+
+func (r RoomAliasRepository) key(alias aliases.Alias) []byte {
+	return []byte(alias.String())
+}
+
+type storedRoomAlias struct {
+	User      string `json:"user"`
+	Room      string `json:"room"`
+	Signature string `json:"signature"`
+}
@@ -0,0 +1,82 @@
+package badger_test
+
+import (
+	"testing"
+
+	"github.com/planetary-social/scuttlego/internal/fixtures"
+	"github.com/planetary-social/scuttlego/service/adapters/badger"
+	"github.com/planetary-social/scuttlego/service/app/common"
+	"github.com/planetary-social/scuttlego/service/di"
+	"github.com/planetary-social/scuttlego/service/domain/identity"
+	"github.com/planetary-social/scuttlego/service/domain/refs"
+	"github.com/planetary-social/scuttlego/service/domain/rooms/aliases"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoomAliasRepository_PutGetListDelete(t *testing.T) {
+	ts := di.BuildBadgerTestAdapters(t)
+
+	user := fixtures.SomePrivateIdentity()
+	otherUser := fixtures.SomePrivateIdentity()
+
+	registration1 := someRoomAliasRegistration(t, user)
+	registration2 := someRoomAliasRegistration(t, user)
+	registration3 := someRoomAliasRegistration(t, otherUser)
+
+	err := ts.TransactionProvider.Update(func(adapters badger.TestAdapters) error {
+		for _, registration := range []aliases.Registration{registration1, registration2, registration3} {
+			if err := adapters.RoomAliasRepository.Put(registration); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	require.NoError(t, err)
+
+	err = ts.TransactionProvider.View(func(adapters badger.TestAdapters) error {
+		registration, err := adapters.RoomAliasRepository.Get(registration1.Alias())
+		require.NoError(t, err)
+		require.Equal(t, registration1.Alias(), registration.Alias())
+		require.Equal(t, registration1.User(), registration.User())
+		require.Equal(t, registration1.Room(), registration.Room())
+		require.Equal(t, registration1.Signature().Bytes(), registration.Signature().Bytes())
+
+		userAliases, err := adapters.RoomAliasRepository.List(registration1.User())
+		require.NoError(t, err)
+		require.ElementsMatch(t, []aliases.Alias{registration1.Alias(), registration2.Alias()}, userAliases)
+
+		return nil
+	})
+	require.NoError(t, err)
+
+	err = ts.TransactionProvider.Update(func(adapters badger.TestAdapters) error {
+		return adapters.RoomAliasRepository.Delete(registration1.Alias())
+	})
+	require.NoError(t, err)
+
+	err = ts.TransactionProvider.View(func(adapters badger.TestAdapters) error {
+		_, err := adapters.RoomAliasRepository.Get(registration1.Alias())
+		require.ErrorIs(t, err, common.ErrRoomAliasNotFound)
+
+		userAliases, err := adapters.RoomAliasRepository.List(registration1.User())
+		require.NoError(t, err)
+		require.Equal(t, []aliases.Alias{registration2.Alias()}, userAliases)
+
+		return nil
+	})
+	require.NoError(t, err)
+}
+
+func someRoomAliasRegistration(t *testing.T, user identity.Private) aliases.Registration {
+	msg, err := aliases.NewRegistrationMessage(
+		fixtures.SomeAlias(),
+		refs.MustNewIdentityFromPublic(user.Public()),
+		fixtures.SomeRefIdentity(),
+	)
+	require.NoError(t, err)
+
+	signature, err := aliases.NewRegistrationSignature(msg, user)
+	require.NoError(t, err)
+
+	return aliases.MustNewRegistration(msg, signature)
+}
@@ -0,0 +1,80 @@
+package badger
+
+import (
+	"github.com/boreq/errors"
+	"github.com/dgraph-io/badger/v3"
+	"github.com/planetary-social/scuttlego/service/adapters/badger/utils"
+	"github.com/planetary-social/scuttlego/service/domain/refs"
+)
+
+var bucketRoomMembersKey = utils.MustNewKey(
+	utils.MustNewKeyComponent([]byte("room_members")),
+)
+
+// RoomMemberRepository stores members of the room hosted by this node.
+type RoomMemberRepository struct {
+	tx *badger.Txn
+}
+
+func NewRoomMemberRepository(
+	tx *badger.Txn,
+) *RoomMemberRepository {
+	return &RoomMemberRepository{
+		tx: tx,
+	}
+}
+
+func (r RoomMemberRepository) Add(id refs.Identity) error {
+	if err := r.bucket().Set(r.key(id), nil); err != nil {
+		return errors.Wrap(err, "bucket set failed")
+	}
+	return nil
+}
+
+func (r RoomMemberRepository) Remove(id refs.Identity) error {
+	if err := r.bucket().Delete(r.key(id)); err != nil {
+		return errors.Wrap(err, "bucket delete failed")
+	}
+	return nil
+}
+
+func (r RoomMemberRepository) Contains(id refs.Identity) (bool, error) {
+	if _, err := r.bucket().Get(r.key(id)); err != nil {
+		if errors.Is(err, badger.ErrKeyNotFound) {
+			return false, nil
+		}
+		return false, errors.Wrap(err, "get failed")
+	}
+	return true, nil
+}
+
+func (r RoomMemberRepository) List() ([]refs.Identity, error) {
+	bucket := r.bucket()
+
+	var result []refs.Identity
+	if err := bucket.ForEach(func(item utils.Item) error {
+		keyInBucket, err := bucket.KeyInBucket(item)
+		if err != nil {
+			return errors.Wrap(err, "error getting key in bucket")
+		}
+
+		ref, err := refs.NewIdentity(string(keyInBucket.Bytes()))
+		if err != nil {
+			return errors.Wrap(err, "error creating a ref")
+		}
+
+		result = append(result, ref)
+		return nil
+	}); err != nil {
+		return nil, errors.Wrap(err, "foreach error")
+	}
+	return result, nil
+}
+
+func (r RoomMemberRepository) bucket() utils.Bucket {
+	return utils.MustNewBucket(r.tx, bucketRoomMembersKey)
+}
+
+func (r RoomMemberRepository) key(id refs.Identity) []byte {
+	return []byte(id.String())
+}
@@ -0,0 +1,58 @@
+package badger_test
+
+import (
+	"testing"
+
+	"github.com/planetary-social/scuttlego/internal/fixtures"
+	"github.com/planetary-social/scuttlego/service/adapters/badger"
+	"github.com/planetary-social/scuttlego/service/di"
+	"github.com/planetary-social/scuttlego/service/domain/refs"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoomMemberRepository_AddContainsListRemove(t *testing.T) {
+	ts := di.BuildBadgerTestAdapters(t)
+
+	member := fixtures.SomeRefIdentity()
+	notMember := fixtures.SomeRefIdentity()
+
+	err := ts.TransactionProvider.Update(func(adapters badger.TestAdapters) error {
+		return adapters.RoomMemberRepository.Add(member)
+	})
+	require.NoError(t, err)
+
+	err = ts.TransactionProvider.View(func(adapters badger.TestAdapters) error {
+		ok, err := adapters.RoomMemberRepository.Contains(member)
+		require.NoError(t, err)
+		require.True(t, ok)
+
+		ok, err = adapters.RoomMemberRepository.Contains(notMember)
+		require.NoError(t, err)
+		require.False(t, ok)
+
+		members, err := adapters.RoomMemberRepository.List()
+		require.NoError(t, err)
+		require.Equal(t, []refs.Identity{member}, members)
+
+		return nil
+	})
+	require.NoError(t, err)
+
+	err = ts.TransactionProvider.Update(func(adapters badger.TestAdapters) error {
+		return adapters.RoomMemberRepository.Remove(member)
+	})
+	require.NoError(t, err)
+
+	err = ts.TransactionProvider.View(func(adapters badger.TestAdapters) error {
+		ok, err := adapters.RoomMemberRepository.Contains(member)
+		require.NoError(t, err)
+		require.False(t, ok)
+
+		members, err := adapters.RoomMemberRepository.List()
+		require.NoError(t, err)
+		require.Empty(t, members)
+
+		return nil
+	})
+	require.NoError(t, err)
+}
@@ -1,6 +1,8 @@
 package badger
 
 import (
+	"bytes"
+
 	"github.com/boreq/errors"
 	"github.com/dgraph-io/badger/v3"
 	jsoniter "github.com/json-iterator/go"
@@ -23,6 +25,7 @@ type SocialGraphRepository struct {
 	hops          graph.Hops
 	banList       *BanListRepository
 	banListHasher BanListHasher
+	events        *TransactionEvents
 }
 
 func NewSocialGraphRepository(
@@ -31,6 +34,7 @@ func NewSocialGraphRepository(
 	hops graph.Hops,
 	banList *BanListRepository,
 	banListHasher BanListHasher,
+	events *TransactionEvents,
 ) *SocialGraphRepository {
 	return &SocialGraphRepository{
 		tx:            tx,
@@ -38,6 +42,7 @@ func NewSocialGraphRepository(
 		hops:          hops,
 		banList:       banList,
 		banListHasher: banListHasher,
+		events:        events,
 	}
 }
 
@@ -46,11 +51,17 @@ func (s *SocialGraphRepository) GetSocialGraph() (graph.SocialGraph, error) {
 	if err != nil {
 		return graph.SocialGraph{}, errors.Wrap(err, "could not create a local ref")
 	}
+	return s.GetSocialGraphFrom(localRef, s.hops)
+}
+
+// GetSocialGraphFrom builds a social graph starting from the provided root
+// instead of the local identity.
+func (s *SocialGraphRepository) GetSocialGraphFrom(root refs.Identity, hops graph.Hops) (graph.SocialGraph, error) {
 	banList, err := graph.NewCachedBanList(s.banListHasher, s.banList)
 	if err != nil {
 		return graph.SocialGraph{}, errors.Wrap(err, "could not create a cached ban list")
 	}
-	return graph.NewSocialGraphBuilder(s, banList, s.hops, localRef).Build()
+	return graph.NewSocialGraphBuilder(s, banList, hops, root).Build()
 }
 
 func (s *SocialGraphRepository) GetSocialGraphBuilder() (*graph.SocialGraphBuilder, error) {
@@ -82,12 +93,107 @@ func (s *SocialGraphRepository) UpdateContact(author, target refs.Identity, f Up
 		return errors.Wrap(err, "could not marshal contact")
 	}
 
-	return bucket.Set(s.key(target), b)
+	if err := bucket.Set(s.key(target), b); err != nil {
+		return errors.Wrap(err, "failed to save the contact")
+	}
+
+	s.events.ContactUpdated(contact)
+	return nil
 }
 
 func (s *SocialGraphRepository) Remove(author refs.Identity) error {
+	contacts, err := s.GetContacts(author)
+	if err != nil {
+		return errors.Wrap(err, "failed to get contacts")
+	}
+
 	bucket := s.getFeedBucket(author)
-	return bucket.DeleteBucket()
+	if err := bucket.DeleteBucket(); err != nil {
+		return errors.Wrap(err, "failed to delete the bucket")
+	}
+
+	for _, contact := range contacts {
+		removed, err := feeds.NewContact(author, contact.Target())
+		if err != nil {
+			return errors.Wrap(err, "failed to create a contact")
+		}
+		s.events.ContactUpdated(removed)
+	}
+
+	return nil
+}
+
+// GetContact returns the contact between two identities. If the author never
+// followed or blocked the target then a contact which is neither following nor
+// blocking is returned.
+func (s *SocialGraphRepository) GetContact(author, target refs.Identity) (*feeds.Contact, error) {
+	return s.loadOrCreateContact(s.getFeedBucket(author), author, target)
+}
+
+// ListContacts returns at most limit contacts stored in the database. If after
+// is not nil then only contacts which come after it are returned.
+func (s *SocialGraphRepository) ListContacts(after *feeds.Contact, limit int) ([]*feeds.Contact, error) {
+	bucket := utils.MustNewBucket(s.tx, utils.MustNewKey(socialGraphRepositoryBucketGraph))
+
+	it := bucket.Iterator()
+	defer it.Close()
+
+	var afterKey []byte
+	if after != nil {
+		afterKey = utils.MustNewKey(
+			socialGraphRepositoryBucketGraph,
+			utils.MustNewKeyComponent([]byte(after.Author().String())),
+			utils.MustNewKeyComponent(s.key(after.Target())),
+		).Bytes()
+		it.Seek([]byte(after.Author().String()))
+	} else {
+		it.Rewind()
+	}
+
+	var result []*feeds.Contact
+
+	for ; it.ValidForBucket() && len(result) < limit; it.Next() {
+		item := it.Item()
+
+		keyBytes := item.KeyCopy(nil)
+		if afterKey != nil && bytes.Compare(keyBytes, afterKey) <= 0 {
+			continue
+		}
+
+		key, err := utils.NewKeyFromBytes(keyBytes)
+		if err != nil {
+			return nil, errors.Wrap(err, "error creating the key")
+		}
+
+		components := key.Components()
+		if len(components) != 3 {
+			return nil, errors.New("invalid number of key components")
+		}
+
+		authorRef, err := refs.NewIdentity(string(components[1].Bytes()))
+		if err != nil {
+			return nil, errors.Wrap(err, "could not create author ref")
+		}
+
+		targetRef, err := refs.NewIdentity(string(components[2].Bytes()))
+		if err != nil {
+			return nil, errors.Wrap(err, "could not create target ref")
+		}
+
+		val, err := item.ValueCopy(nil)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not get the value")
+		}
+
+		contact, err := s.loadContact(authorRef, targetRef, val)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to load the contact")
+		}
+
+		result = append(result, contact)
+	}
+
+	return result, nil
 }
 
 func (s *SocialGraphRepository) GetContacts(node refs.Identity) ([]*feeds.Contact, error) {
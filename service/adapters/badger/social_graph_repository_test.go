@@ -123,6 +123,113 @@ func TestSocialGraphRepository_GetContacts(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestSocialGraphRepository_ListContactsReturnsContactsOfAllAuthors(t *testing.T) {
+	ts := di.BuildBadgerTestAdapters(t)
+
+	iden1 := fixtures.SomeRefIdentity()
+	iden2 := fixtures.SomeRefIdentity()
+
+	target1 := fixtures.SomeRefIdentity()
+	target2 := fixtures.SomeRefIdentity()
+
+	err := ts.TransactionProvider.Update(func(adapters badger.TestAdapters) error {
+		applyContactAction(t, adapters, iden1, target1, known.ContactActionFollow)
+		applyContactAction(t, adapters, iden2, target2, known.ContactActionBlock)
+
+		return nil
+	})
+	require.NoError(t, err)
+
+	err = ts.TransactionProvider.View(func(adapters badger.TestAdapters) error {
+		contacts, err := adapters.SocialGraphRepository.ListContacts(nil, 10)
+		require.NoError(t, err)
+		sortAndRequireEqualContacts(t,
+			[]*feeds.Contact{
+				feeds.MustNewContactFromHistory(iden1, target1, true, false),
+				feeds.MustNewContactFromHistory(iden2, target2, false, true),
+			},
+			contacts,
+		)
+
+		return nil
+	})
+	require.NoError(t, err)
+}
+
+func TestSocialGraphRepository_ListContactsReturnsContactsInPages(t *testing.T) {
+	ts := di.BuildBadgerTestAdapters(t)
+
+	const numberOfContacts = 10
+	const limit = 3
+
+	err := ts.TransactionProvider.Update(func(adapters badger.TestAdapters) error {
+		for i := 0; i < numberOfContacts; i++ {
+			applyContactAction(t, adapters, fixtures.SomeRefIdentity(), fixtures.SomeRefIdentity(), known.ContactActionFollow)
+		}
+		return nil
+	})
+	require.NoError(t, err)
+
+	var pages [][]*feeds.Contact
+	var after *feeds.Contact
+	for {
+		var page []*feeds.Contact
+		err = ts.TransactionProvider.View(func(adapters badger.TestAdapters) error {
+			page, err = adapters.SocialGraphRepository.ListContacts(after, limit)
+			return err
+		})
+		require.NoError(t, err)
+
+		if len(page) == 0 {
+			break
+		}
+
+		pages = append(pages, page)
+		after = page[len(page)-1]
+	}
+
+	var pageSizes []int
+	seen := make(map[string]struct{})
+	for _, page := range pages {
+		pageSizes = append(pageSizes, len(page))
+		for _, contact := range page {
+			key := contact.Author().String() + contact.Target().String()
+			require.NotContains(t, seen, key, "contact returned twice")
+			seen[key] = struct{}{}
+		}
+	}
+	require.Equal(t, []int{3, 3, 3, 1}, pageSizes)
+	require.Len(t, seen, numberOfContacts)
+}
+
+func TestSocialGraphRepository_GetContactReturnsEmptyContactIfItDoesNotExist(t *testing.T) {
+	ts := di.BuildBadgerTestAdapters(t)
+
+	iden := fixtures.SomeRefIdentity()
+	followed := fixtures.SomeRefIdentity()
+	unknown := fixtures.SomeRefIdentity()
+
+	err := ts.TransactionProvider.Update(func(adapters badger.TestAdapters) error {
+		applyContactAction(t, adapters, iden, followed, known.ContactActionFollow)
+
+		return nil
+	})
+	require.NoError(t, err)
+
+	err = ts.TransactionProvider.View(func(adapters badger.TestAdapters) error {
+		contact, err := adapters.SocialGraphRepository.GetContact(iden, followed)
+		require.NoError(t, err)
+		require.Equal(t, feeds.MustNewContactFromHistory(iden, followed, true, false), contact)
+
+		contact, err = adapters.SocialGraphRepository.GetContact(iden, unknown)
+		require.NoError(t, err)
+		require.Equal(t, feeds.MustNewContactFromHistory(iden, unknown, false, false), contact)
+
+		return nil
+	})
+	require.NoError(t, err)
+}
+
 func BenchmarkSocialGraphRepository_GetContacts(b *testing.B) {
 	for _, numberOfFollowees := range []int{0, 1, 10, 100, 1000} {
 		b.Run(fmt.Sprintf("followees=%d", numberOfFollowees), func(b *testing.B) {
@@ -6,29 +6,90 @@ import (
 	mocks2 "github.com/planetary-social/scuttlego/internal/mocks"
 	"github.com/planetary-social/scuttlego/service/app/commands"
 	"github.com/planetary-social/scuttlego/service/app/queries"
+	"github.com/planetary-social/scuttlego/service/domain/feeds"
+	"github.com/planetary-social/scuttlego/service/domain/feeds/message"
 	"github.com/planetary-social/scuttlego/service/domain/identity"
 )
 
-type CommandsAdaptersFactory func(tx *badger.Txn) (commands.Adapters, error)
+type ContactPublisher interface {
+	PublishContact(contact *feeds.Contact)
+}
+
+type MessagePublisher interface {
+	PublishNewMessage(msg message.Message)
+}
+
+// TransactionEvents collects events which occur during a transaction so that
+// they can be published once the transaction is committed.
+type TransactionEvents struct {
+	contacts []*feeds.Contact
+	messages []message.Message
+}
+
+func NewTransactionEvents() *TransactionEvents {
+	return &TransactionEvents{}
+}
+
+// ContactUpdated records the current state of the contact.
+func (e *TransactionEvents) ContactUpdated(contact *feeds.Contact) {
+	c := *contact
+	e.contacts = append(e.contacts, &c)
+}
+
+// MessageSaved records that a message was persisted.
+func (e *TransactionEvents) MessageSaved(msg message.Message) {
+	e.messages = append(e.messages, msg)
+}
+
+func (e *TransactionEvents) publish(contactPublisher ContactPublisher, messagePublisher MessagePublisher) {
+	for _, contact := range e.contacts {
+		contactPublisher.PublishContact(contact)
+	}
+
+	for _, msg := range e.messages {
+		messagePublisher.PublishNewMessage(msg)
+	}
+}
+
+type CommandsAdaptersFactory func(tx *badger.Txn, events *TransactionEvents) (commands.Adapters, error)
 
 type CommandsTransactionProvider struct {
-	db      *badger.DB
-	factory CommandsAdaptersFactory
+	db               *badger.DB
+	factory          CommandsAdaptersFactory
+	contactPublisher ContactPublisher
+	messagePublisher MessagePublisher
 }
 
-func NewCommandsTransactionProvider(db *badger.DB, factory CommandsAdaptersFactory) *CommandsTransactionProvider {
-	return &CommandsTransactionProvider{db: db, factory: factory}
+func NewCommandsTransactionProvider(
+	db *badger.DB,
+	factory CommandsAdaptersFactory,
+	contactPublisher ContactPublisher,
+	messagePublisher MessagePublisher,
+) *CommandsTransactionProvider {
+	return &CommandsTransactionProvider{
+		db:               db,
+		factory:          factory,
+		contactPublisher: contactPublisher,
+		messagePublisher: messagePublisher,
+	}
 }
 
 func (t CommandsTransactionProvider) Transact(f func(adapters commands.Adapters) error) error {
-	return t.db.Update(func(tx *badger.Txn) error {
-		adapters, err := t.factory(tx)
+	events := NewTransactionEvents()
+
+	if err := t.db.Update(func(tx *badger.Txn) error {
+		adapters, err := t.factory(tx, events)
 		if err != nil {
 			return errors.Wrap(err, "failed to build adapters")
 		}
 
 		return f(adapters)
-	})
+	}); err != nil {
+		return err
+	}
+
+	events.publish(t.contactPublisher, t.messagePublisher)
+	return nil
 }
 
 type QueriesAdaptersFactory func(tx *badger.Txn) (queries.Adapters, error)
@@ -56,15 +117,19 @@ func (t QueriesTransactionProvider) Transact(f func(adapters queries.Adapters) e
 type TestAdaptersFactory func(tx *badger.Txn, dependencies TestAdaptersDependencies) (TestAdapters, error)
 
 type TestAdapters struct {
-	BanListRepository      *BanListRepository
-	BlobRepository         *BlobRepository
-	BlobWantListRepository *BlobWantListRepository
-	FeedWantListRepository *FeedWantListRepository
-	MessageRepository      *MessageRepository
-	ReceiveLogRepository   *ReceiveLogRepository
-	SocialGraphRepository  *SocialGraphRepository
-	PubRepository          *PubRepository
-	FeedRepository         *FeedRepository
+	BanListRepository           *BanListRepository
+	BlobRepository              *BlobRepository
+	BlobWantListRepository      *BlobWantListRepository
+	FeedWantListRepository      *FeedWantListRepository
+	MessageRepository           *MessageRepository
+	ReceiveLogRepository        *ReceiveLogRepository
+	SocialGraphRepository       *SocialGraphRepository
+	PubRepository               *PubRepository
+	FeedRepository              *FeedRepository
+	InviteRepository            *InviteRepository
+	RoomMemberRepository        *RoomMemberRepository
+	RoomAliasRepository         *RoomAliasRepository
+	OutOfOrderMessageRepository *OutOfOrderMessageRepository
 }
 
 type TestAdaptersDependencies struct {
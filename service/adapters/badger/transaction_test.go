@@ -0,0 +1,58 @@
+package badger_test
+
+import (
+	"testing"
+	"time"
+
+	badgerdb "github.com/dgraph-io/badger/v3"
+	"github.com/planetary-social/scuttlego/internal/fixtures"
+	"github.com/planetary-social/scuttlego/service/adapters/badger"
+	"github.com/planetary-social/scuttlego/service/adapters/pubsub"
+	"github.com/planetary-social/scuttlego/service/app/commands"
+	"github.com/planetary-social/scuttlego/service/domain/feeds"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCommandsTransactionProvider_StalledSubscribersDoNotBlockTransactions(t *testing.T) {
+	ctx := fixtures.TestContext(t)
+
+	contactPubSub := pubsub.NewContactPubSub()
+	messagePubSub := pubsub.NewMessagePubSub()
+
+	// subscriptions which are never read from
+	_ = contactPubSub.SubscribeToContacts(ctx)
+	_ = messagePubSub.SubscribeToNewMessages(ctx)
+
+	var events *badger.TransactionEvents
+	provider := badger.NewCommandsTransactionProvider(
+		fixtures.Badger(t),
+		func(tx *badgerdb.Txn, e *badger.TransactionEvents) (commands.Adapters, error) {
+			events = e
+			return commands.Adapters{}, nil
+		},
+		contactPubSub,
+		messagePubSub,
+	)
+
+	done := make(chan error)
+	go func() {
+		for i := 0; i < 5000; i++ {
+			if err := provider.Transact(func(adapters commands.Adapters) error {
+				events.MessageSaved(fixtures.SomeMessage(fixtures.SomeSequence(), fixtures.SomeRefFeed()))
+				events.ContactUpdated(feeds.MustNewContactFromHistory(fixtures.SomeRefIdentity(), fixtures.SomeRefIdentity(), true, false))
+				return nil
+			}); err != nil {
+				done <- err
+				return
+			}
+		}
+		done <- nil
+	}()
+
+	select {
+	case err := <-done:
+		require.NoError(t, err)
+	case <-time.After(10 * time.Second):
+		t.Fatal("transactions blocked on subscribers which don't receive events")
+	}
+}
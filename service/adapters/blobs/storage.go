@@ -25,15 +25,21 @@ const filenameSeparator = "-"
 
 const charactersInDirName = 2
 
+type Metrics interface {
+	ReportBlobBytesStored(n int64)
+}
+
 type FilesystemStorage struct {
-	path   string
-	logger logging.Logger
+	path    string
+	metrics Metrics
+	logger  logging.Logger
 }
 
-func NewFilesystemStorage(path string, logger logging.Logger) (*FilesystemStorage, error) {
+func NewFilesystemStorage(path string, metrics Metrics, logger logging.Logger) (*FilesystemStorage, error) {
 	s := &FilesystemStorage{
-		path:   path,
-		logger: logger,
+		path:    path,
+		metrics: metrics,
+		logger:  logger,
 	}
 
 	if err := s.removeTemporaryFiles(); err != nil {
@@ -61,7 +67,8 @@ func (f FilesystemStorage) Store(id refs.Blob, r io.Reader) error {
 
 	h := blobs.NewHasher()
 
-	if _, err := io.Copy(io.MultiWriter(tmpFile, h), io.LimitReader(r, blobs.MaxBlobSize().InBytes())); err != nil {
+	n, err := io.Copy(io.MultiWriter(tmpFile, h), io.LimitReader(r, blobs.MaxBlobSize().InBytes()))
+	if err != nil {
 		return errors.Wrap(err, "failed to copy contents to a temporary file")
 	}
 
@@ -77,6 +84,8 @@ func (f FilesystemStorage) Store(id refs.Blob, r io.Reader) error {
 		return errors.Wrap(err, "failed to move the temporary file")
 	}
 
+	f.metrics.ReportBlobBytesStored(n)
+
 	return nil
 }
 
@@ -96,7 +105,8 @@ func (f FilesystemStorage) Create(r io.Reader) (refs.Blob, error) {
 
 	h := blobs.NewHasher()
 
-	if _, err := io.Copy(io.MultiWriter(tmpFile, h), io.LimitReader(r, blobs.MaxBlobSize().InBytes())); err != nil {
+	n, err := io.Copy(io.MultiWriter(tmpFile, h), io.LimitReader(r, blobs.MaxBlobSize().InBytes()))
+	if err != nil {
 		return refs.Blob{}, errors.Wrap(err, "failed to copy contents to a temporary file")
 	}
 
@@ -113,6 +123,8 @@ func (f FilesystemStorage) Create(r io.Reader) (refs.Blob, error) {
 		return refs.Blob{}, errors.Wrap(err, "failed to move the temporary file")
 	}
 
+	f.metrics.ReportBlobBytesStored(n)
+
 	return id, nil
 }
 
@@ -21,13 +21,16 @@ func TestStorageStore(t *testing.T) {
 	directory := fixtures.Directory(t)
 	logger := logging.NewDevNullLogger()
 
-	storage, err := blobs.NewFilesystemStorage(directory, logger)
+	metrics := newMetricsMock()
+
+	storage, err := blobs.NewFilesystemStorage(directory, metrics, logger)
 	require.NoError(t, err)
 
 	id, r, data := newFakeBlob(t)
 
 	err = storage.Store(id, r)
 	require.NoError(t, err)
+	require.EqualValues(t, len(data), metrics.BytesStored)
 
 	size, err := storage.Size(id)
 	require.NoError(t, err)
@@ -47,7 +50,7 @@ func TestStorage_StoreShouldNotFailIfDirectoryDisappearsAfterCreatingFilesystemS
 	directory := fixtures.Directory(t)
 	logger := logging.NewDevNullLogger()
 
-	storage, err := blobs.NewFilesystemStorage(directory, logger)
+	storage, err := blobs.NewFilesystemStorage(directory, newMetricsMock(), logger)
 	require.NoError(t, err)
 
 	err = os.RemoveAll(directory)
@@ -76,7 +79,7 @@ func TestSizeReturnsBlobNotFound(t *testing.T) {
 	directory := fixtures.Directory(t)
 	logger := logging.NewDevNullLogger()
 
-	storage, err := blobs.NewFilesystemStorage(directory, logger)
+	storage, err := blobs.NewFilesystemStorage(directory, newMetricsMock(), logger)
 	require.NoError(t, err)
 
 	_, err = storage.Size(fixtures.SomeRefBlob())
@@ -87,7 +90,9 @@ func TestStorageCreate(t *testing.T) {
 	directory := fixtures.Directory(t)
 	logger := logging.NewDevNullLogger()
 
-	storage, err := blobs.NewFilesystemStorage(directory, logger)
+	metrics := newMetricsMock()
+
+	storage, err := blobs.NewFilesystemStorage(directory, metrics, logger)
 	require.NoError(t, err)
 
 	bts := fixtures.SomeBytes()
@@ -95,6 +100,7 @@ func TestStorageCreate(t *testing.T) {
 	id, err := storage.Create(bytes.NewReader(bts))
 	require.NoError(t, err)
 	require.NotEmpty(t, id.String())
+	require.EqualValues(t, len(bts), metrics.BytesStored)
 
 	size, err := storage.Size(id)
 	require.NoError(t, err)
@@ -114,7 +120,7 @@ func TestStorage_CreateShouldNotFailIfDirectoryDisappearsAfterCreatingFilesystem
 	directory := fixtures.Directory(t)
 	logger := logging.NewDevNullLogger()
 
-	storage, err := blobs.NewFilesystemStorage(directory, logger)
+	storage, err := blobs.NewFilesystemStorage(directory, newMetricsMock(), logger)
 	require.NoError(t, err)
 
 	err = os.RemoveAll(directory)
@@ -144,7 +150,7 @@ func TestStorageStoreUsesSamePathsAsGoSSB(t *testing.T) {
 	directory := fixtures.Directory(t)
 	logger := logging.NewDevNullLogger()
 
-	storage, err := blobs.NewFilesystemStorage(directory, logger)
+	storage, err := blobs.NewFilesystemStorage(directory, newMetricsMock(), logger)
 	require.NoError(t, err)
 
 	data := []byte("testblobdata")
@@ -188,3 +194,15 @@ func newBlobFromData(t *testing.T, data []byte) (refs.Blob, io.Reader) {
 
 	return id, bytes.NewReader(data)
 }
+
+type metricsMock struct {
+	BytesStored int64
+}
+
+func newMetricsMock() *metricsMock {
+	return &metricsMock{}
+}
+
+func (m *metricsMock) ReportBlobBytesStored(n int64) {
+	m.BytesStored += n
+}
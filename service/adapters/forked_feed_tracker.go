@@ -0,0 +1,33 @@
+package adapters
+
+import (
+	"github.com/planetary-social/scuttlego/service/domain/identity"
+	"github.com/planetary-social/scuttlego/service/domain/refs"
+)
+
+type ForkedFeedTracker interface {
+	AddForkedFeed(replicatedFrom identity.Public, feed refs.Feed)
+}
+
+type ForkDetectedPublisher interface {
+	PublishForkDetected(replicatedFrom identity.Public, feed refs.Feed)
+}
+
+// PublishingForkedFeedTracker passes forked feeds to the underlying tracker
+// and publishes an event for each of them.
+type PublishingForkedFeedTracker struct {
+	tracker   ForkedFeedTracker
+	publisher ForkDetectedPublisher
+}
+
+func NewPublishingForkedFeedTracker(tracker ForkedFeedTracker, publisher ForkDetectedPublisher) *PublishingForkedFeedTracker {
+	return &PublishingForkedFeedTracker{
+		tracker:   tracker,
+		publisher: publisher,
+	}
+}
+
+func (t *PublishingForkedFeedTracker) AddForkedFeed(replicatedFrom identity.Public, feed refs.Feed) {
+	t.tracker.AddForkedFeed(replicatedFrom, feed)
+	t.publisher.PublishForkDetected(replicatedFrom, feed)
+}
@@ -0,0 +1,51 @@
+package adapters
+
+import (
+	"testing"
+
+	"github.com/planetary-social/scuttlego/internal/fixtures"
+	"github.com/planetary-social/scuttlego/service/domain/identity"
+	"github.com/planetary-social/scuttlego/service/domain/refs"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPublishingForkedFeedTracker_PassesFeedsToTrackerAndPublishesThem(t *testing.T) {
+	tracker := newForkedFeedsRecorder()
+	publisher := newForkedFeedsRecorder()
+	publishingTracker := NewPublishingForkedFeedTracker(tracker, publisher)
+
+	replicatedFrom := fixtures.SomePublicIdentity()
+	feed := fixtures.SomeRefFeed()
+
+	publishingTracker.AddForkedFeed(replicatedFrom, feed)
+
+	expected := []forkedFeed{
+		{
+			ReplicatedFrom: replicatedFrom,
+			Feed:           feed,
+		},
+	}
+	require.Equal(t, expected, tracker.feeds)
+	require.Equal(t, expected, publisher.feeds)
+}
+
+type forkedFeed struct {
+	ReplicatedFrom identity.Public
+	Feed           refs.Feed
+}
+
+type forkedFeedsRecorder struct {
+	feeds []forkedFeed
+}
+
+func newForkedFeedsRecorder() *forkedFeedsRecorder {
+	return &forkedFeedsRecorder{}
+}
+
+func (r *forkedFeedsRecorder) AddForkedFeed(replicatedFrom identity.Public, feed refs.Feed) {
+	r.feeds = append(r.feeds, forkedFeed{ReplicatedFrom: replicatedFrom, Feed: feed})
+}
+
+func (r *forkedFeedsRecorder) PublishForkDetected(replicatedFrom identity.Public, feed refs.Feed) {
+	r.feeds = append(r.feeds, forkedFeed{ReplicatedFrom: replicatedFrom, Feed: feed})
+}
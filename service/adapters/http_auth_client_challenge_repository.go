@@ -0,0 +1,51 @@
+package adapters
+
+import (
+	"sync"
+
+	"github.com/planetary-social/scuttlego/service/app/commands"
+	"github.com/planetary-social/scuttlego/service/domain/rooms/httpauth"
+)
+
+// HttpAuthClientChallengeRepository stores client challenges in memory as
+// they are only valid for a short period of time. Expired challenges are
+// removed when new challenges are added.
+type HttpAuthClientChallengeRepository struct {
+	challenges map[string]httpauth.ClientChallenge
+	lock       sync.Mutex
+}
+
+func NewHttpAuthClientChallengeRepository() *HttpAuthClientChallengeRepository {
+	return &HttpAuthClientChallengeRepository{
+		challenges: make(map[string]httpauth.ClientChallenge),
+	}
+}
+
+func (r *HttpAuthClientChallengeRepository) Put(challenge httpauth.ClientChallenge) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	for key, v := range r.challenges {
+		if v.Expired(challenge.CreatedAt()) {
+			delete(r.challenges, key)
+		}
+	}
+
+	r.challenges[challenge.Challenge().String()] = challenge
+	return nil
+}
+
+// Pop removes the challenge from the repository and returns it. Returns
+// commands.ErrClientChallengeNotFound if the challenge doesn't exist.
+func (r *HttpAuthClientChallengeRepository) Pop(cc httpauth.Challenge) (httpauth.ClientChallenge, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	challenge, ok := r.challenges[cc.String()]
+	if !ok {
+		return httpauth.ClientChallenge{}, commands.ErrClientChallengeNotFound
+	}
+
+	delete(r.challenges, cc.String())
+	return challenge, nil
+}
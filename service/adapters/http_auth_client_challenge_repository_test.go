@@ -0,0 +1,49 @@
+package adapters
+
+import (
+	"testing"
+
+	"github.com/planetary-social/scuttlego/internal/fixtures"
+	"github.com/planetary-social/scuttlego/service/app/commands"
+	"github.com/planetary-social/scuttlego/service/domain/rooms/httpauth"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHttpAuthClientChallengeRepository_ChallengesCanBePoppedOnlyOnce(t *testing.T) {
+	repository := NewHttpAuthClientChallengeRepository()
+
+	challenge := httpauth.MustNewClientChallenge(fixtures.SomeRefIdentity(), httpauth.MustNewChallenge(), fixtures.SomeTime())
+
+	_, err := repository.Pop(challenge.Challenge())
+	require.ErrorIs(t, err, commands.ErrClientChallengeNotFound)
+
+	err = repository.Put(challenge)
+	require.NoError(t, err)
+
+	popped, err := repository.Pop(challenge.Challenge())
+	require.NoError(t, err)
+	require.Equal(t, challenge, popped)
+
+	_, err = repository.Pop(challenge.Challenge())
+	require.ErrorIs(t, err, commands.ErrClientChallengeNotFound)
+}
+
+func TestHttpAuthClientChallengeRepository_ExpiredChallengesAreRemovedWhenNewChallengesArePut(t *testing.T) {
+	repository := NewHttpAuthClientChallengeRepository()
+
+	now := fixtures.SomeTime()
+
+	expiredChallenge := httpauth.MustNewClientChallenge(fixtures.SomeRefIdentity(), httpauth.MustNewChallenge(), now)
+	err := repository.Put(expiredChallenge)
+	require.NoError(t, err)
+
+	newChallenge := httpauth.MustNewClientChallenge(fixtures.SomeRefIdentity(), httpauth.MustNewChallenge(), now.Add(2*httpauth.ClientChallengeTTL))
+	err = repository.Put(newChallenge)
+	require.NoError(t, err)
+
+	_, err = repository.Pop(expiredChallenge.Challenge())
+	require.ErrorIs(t, err, commands.ErrClientChallengeNotFound)
+
+	_, err = repository.Pop(newChallenge.Challenge())
+	require.NoError(t, err)
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/planetary-social/scuttlego/service/domain/transport"
 	"github.com/planetary-social/scuttlego/service/domain/transport/boxstream"
 	"github.com/planetary-social/scuttlego/service/domain/transport/rpc"
+	"github.com/planetary-social/scuttlego/tracing"
 )
 
 type Dialer interface {
@@ -27,6 +28,10 @@ type InviteDialer struct {
 	requestHandler        rpc.RequestHandler
 	connectionIdGenerator *rpc.ConnectionIdGenerator
 	currentTimeProvider   CurrentTimeProvider
+	timeouts              rpc.ResponseStreamTimeouts
+	metrics               transport.Metrics
+	tracer                *tracing.Tracer
+	eventPublisher        transport.EventPublisher
 	logger                logging.Logger
 }
 
@@ -36,6 +41,10 @@ func NewInviteDialer(
 	requestHandler rpc.RequestHandler,
 	connectionIdGenerator *rpc.ConnectionIdGenerator,
 	currentTimeProvider CurrentTimeProvider,
+	timeouts rpc.ResponseStreamTimeouts,
+	metrics transport.Metrics,
+	tracer *tracing.Tracer,
+	eventPublisher transport.EventPublisher,
 	logger logging.Logger,
 ) *InviteDialer {
 	return &InviteDialer{
@@ -44,6 +53,10 @@ func NewInviteDialer(
 		requestHandler:        requestHandler,
 		connectionIdGenerator: connectionIdGenerator,
 		currentTimeProvider:   currentTimeProvider,
+		timeouts:              timeouts,
+		metrics:               metrics,
+		tracer:                tracer,
+		eventPublisher:        eventPublisher,
 		logger:                logger,
 	}
 }
@@ -54,7 +67,7 @@ func (h *InviteDialer) Dial(ctx context.Context, local identity.Private, remote
 		return transport.Peer{}, errors.Wrap(err, "could not create a handshaker")
 	}
 
-	initializer := transport.NewPeerInitializer(handshaker, h.requestHandler, h.connectionIdGenerator, newNoopPeerHandler(), h.logger)
+	initializer := transport.NewPeerInitializer(handshaker, h.requestHandler, h.connectionIdGenerator, newNoopPeerHandler(), h.timeouts, h.metrics, h.tracer, h.eventPublisher, h.logger)
 
 	peer, err := h.dialer.DialWithInitializer(ctx, initializer, remote, address)
 	if err != nil {
@@ -0,0 +1,114 @@
+package prometheus
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+type metricType string
+
+const (
+	metricTypeCounter metricType = "counter"
+	metricTypeGauge   metricType = "gauge"
+)
+
+// family is a group of metrics with the same name which are distinguished by
+// the value of a single label. Families without a label hold a single value
+// stored under an empty label value.
+type family struct {
+	name       string
+	help       string
+	metricType metricType
+	label      string
+
+	lock   sync.Mutex
+	values map[string]float64
+}
+
+func newFamily(name, help string, metricType metricType, label string) *family {
+	return &family{
+		name:       name,
+		help:       help,
+		metricType: metricType,
+		label:      label,
+		values:     make(map[string]float64),
+	}
+}
+
+func (f *family) Add(labelValue string, v float64) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.values[labelValue] += v
+}
+
+func (f *family) Set(labelValue string, v float64) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.values[labelValue] = v
+}
+
+func (f *family) Delete(labelValue string) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	delete(f.values, labelValue)
+}
+
+// WriteTo writes the family sorted by label values. Families without a label
+// are always written so that they are visible even if nothing was reported.
+func (f *family) WriteTo(w io.Writer) (int64, error) {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "# HELP %s %s\n", f.name, escapeHelp(f.help))
+	fmt.Fprintf(&b, "# TYPE %s %s\n", f.name, f.metricType)
+
+	for _, labelValue := range f.sortedLabelValues() {
+		b.WriteString(f.name)
+		if f.label != "" {
+			fmt.Fprintf(&b, "{%s=\"%s\"}", f.label, escapeLabelValue(labelValue))
+		}
+		b.WriteString(" ")
+		b.WriteString(strconv.FormatFloat(f.value(labelValue), 'g', -1, 64))
+		b.WriteString("\n")
+	}
+
+	n, err := io.WriteString(w, b.String())
+	return int64(n), err
+}
+
+func (f *family) sortedLabelValues() []string {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if f.label == "" {
+		return []string{""}
+	}
+
+	var labelValues []string
+	for labelValue := range f.values {
+		labelValues = append(labelValues, labelValue)
+	}
+	sort.Strings(labelValues)
+	return labelValues
+}
+
+func (f *family) value(labelValue string) float64 {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	return f.values[labelValue]
+}
+
+var helpReplacer = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+
+func escapeHelp(s string) string {
+	return helpReplacer.Replace(s)
+}
+
+var labelValueReplacer = strings.NewReplacer(`\`, `\\`, "\n", `\n`, `"`, `\"`)
+
+func escapeLabelValue(s string) string {
+	return labelValueReplacer.Replace(s)
+}
@@ -0,0 +1,60 @@
+package prometheus
+
+import (
+	"io"
+
+	"github.com/planetary-social/scuttlego/service/domain/refs"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc"
+)
+
+// NoopMetrics discards all metrics. It is used when the metrics endpoint is
+// disabled so that no memory is spent on metrics which can't be scraped.
+type NoopMetrics struct {
+}
+
+func NewNoopMetrics() *NoopMetrics {
+	return &NoopMetrics{}
+}
+
+func (n NoopMetrics) ReportPeerConnected() {
+}
+
+func (n NoopMetrics) ReportPeerDisconnected() {
+}
+
+func (n NoopMetrics) ReportBytesReceived(int) {
+}
+
+func (n NoopMetrics) ReportBytesSent(int) {
+}
+
+func (n NoopMetrics) ReportRPCRequest(rpc.ProcedureName) {
+}
+
+func (n NoopMetrics) ReportUnknownRPCRequest() {
+}
+
+func (n NoopMetrics) ReportMessagesPersisted(int) {
+}
+
+func (n NoopMetrics) ReportMessageBufferSize(int) {
+}
+
+func (n NoopMetrics) ReportReplicationLag(refs.Feed, int) {
+}
+
+func (n NoopMetrics) ForgetReplicationLag(refs.Feed) {
+}
+
+func (n NoopMetrics) ReportBlobBytesStored(int64) {
+}
+
+func (n NoopMetrics) ReportBadgerSize(int64, int64) {
+}
+
+func (n NoopMetrics) ReportBadgerGarbageCollection(error) {
+}
+
+func (n NoopMetrics) WriteTo(io.Writer) (int64, error) {
+	return 0, nil
+}
@@ -0,0 +1,202 @@
+// Package prometheus collects metrics describing the state of the node and
+// exposes them in the Prometheus text exposition format. The format is simple
+// enough to be written by hand which avoids pulling in the client library.
+package prometheus
+
+import (
+	"io"
+
+	"github.com/boreq/errors"
+	"github.com/dgraph-io/badger/v3"
+	"github.com/planetary-social/scuttlego/service/domain/refs"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc"
+)
+
+const (
+	labelDirection = "direction"
+	labelProcedure = "procedure"
+	labelFeed      = "feed"
+	labelType      = "type"
+	labelResult    = "result"
+
+	directionIn  = "in"
+	directionOut = "out"
+
+	procedureUnknown = "unknown"
+
+	badgerTypeLSM  = "lsm"
+	badgerTypeVlog = "vlog"
+
+	garbageCollectionResultRewrite   = "rewrite"
+	garbageCollectionResultNoRewrite = "no_rewrite"
+	garbageCollectionResultError     = "error"
+)
+
+// Prometheus is safe for concurrent use.
+type Prometheus struct {
+	connectedPeers    *family
+	transportBytes    *family
+	rpcRequests       *family
+	messagesPersisted *family
+	messageBufferSize *family
+	replicationLag    *family
+	blobBytesStored   *family
+	badgerSize        *family
+	badgerGCRuns      *family
+	families          []*family
+}
+
+func NewPrometheus() *Prometheus {
+	p := &Prometheus{
+		connectedPeers: newFamily(
+			"scuttlego_connected_peers",
+			"Number of peers which are currently connected.",
+			metricTypeGauge,
+			"",
+		),
+		transportBytes: newFamily(
+			"scuttlego_transport_bytes_total",
+			"Number of bytes received and sent over box streams.",
+			metricTypeCounter,
+			labelDirection,
+		),
+		rpcRequests: newFamily(
+			"scuttlego_rpc_requests_total",
+			"Number of RPC requests received from peers.",
+			metricTypeCounter,
+			labelProcedure,
+		),
+		messagesPersisted: newFamily(
+			"scuttlego_messages_persisted_total",
+			"Number of replicated messages persisted by the message buffer.",
+			metricTypeCounter,
+			"",
+		),
+		messageBufferSize: newFamily(
+			"scuttlego_message_buffer_messages",
+			"Number of messages waiting in the message buffer.",
+			metricTypeGauge,
+			"",
+		),
+		replicationLag: newFamily(
+			"scuttlego_replication_lag_messages",
+			"Number of messages by which the local copy of a feed was behind a peer when last checked.",
+			metricTypeGauge,
+			labelFeed,
+		),
+		blobBytesStored: newFamily(
+			"scuttlego_blob_bytes_stored_total",
+			"Number of bytes of blobs written to the blob storage.",
+			metricTypeCounter,
+			"",
+		),
+		badgerSize: newFamily(
+			"scuttlego_badger_size_bytes",
+			"Size of the Badger LSM tree and value log.",
+			metricTypeGauge,
+			labelType,
+		),
+		badgerGCRuns: newFamily(
+			"scuttlego_badger_gc_runs_total",
+			"Number of Badger value log garbage collection runs.",
+			metricTypeCounter,
+			labelResult,
+		),
+	}
+
+	p.families = []*family{
+		p.connectedPeers,
+		p.transportBytes,
+		p.rpcRequests,
+		p.messagesPersisted,
+		p.messageBufferSize,
+		p.replicationLag,
+		p.blobBytesStored,
+		p.badgerSize,
+		p.badgerGCRuns,
+	}
+
+	return p
+}
+
+func (p *Prometheus) ReportPeerConnected() {
+	p.connectedPeers.Add("", 1)
+}
+
+func (p *Prometheus) ReportPeerDisconnected() {
+	p.connectedPeers.Add("", -1)
+}
+
+func (p *Prometheus) ReportBytesReceived(n int) {
+	p.transportBytes.Add(directionIn, float64(n))
+}
+
+func (p *Prometheus) ReportBytesSent(n int) {
+	p.transportBytes.Add(directionOut, float64(n))
+}
+
+// ReportRPCRequest should only be called with names of registered procedures
+// as every name creates a new label value.
+func (p *Prometheus) ReportRPCRequest(procedure rpc.ProcedureName) {
+	p.rpcRequests.Add(procedure.String(), 1)
+}
+
+func (p *Prometheus) ReportUnknownRPCRequest() {
+	p.rpcRequests.Add(procedureUnknown, 1)
+}
+
+func (p *Prometheus) ReportMessagesPersisted(n int) {
+	p.messagesPersisted.Add("", float64(n))
+}
+
+func (p *Prometheus) ReportMessageBufferSize(n int) {
+	p.messageBufferSize.Set("", float64(n))
+}
+
+// ReportReplicationLag records by how many messages the local copy of a feed
+// is behind the latest message which a peer claims to have. If several peers
+// are replicating the feed then the last reported value is exposed.
+func (p *Prometheus) ReportReplicationLag(feed refs.Feed, lag int) {
+	p.replicationLag.Set(feed.String(), float64(lag))
+}
+
+// ForgetReplicationLag removes the replication lag of a feed which is no
+// longer replicated.
+func (p *Prometheus) ForgetReplicationLag(feed refs.Feed) {
+	p.replicationLag.Delete(feed.String())
+}
+
+func (p *Prometheus) ReportBlobBytesStored(n int64) {
+	p.blobBytesStored.Add("", float64(n))
+}
+
+func (p *Prometheus) ReportBadgerSize(lsm, vlog int64) {
+	p.badgerSize.Set(badgerTypeLSM, float64(lsm))
+	p.badgerSize.Set(badgerTypeVlog, float64(vlog))
+}
+
+// ReportBadgerGarbageCollection accepts the error returned by
+// badger.DB.RunValueLogGC.
+func (p *Prometheus) ReportBadgerGarbageCollection(err error) {
+	switch {
+	case err == nil:
+		p.badgerGCRuns.Add(garbageCollectionResultRewrite, 1)
+	case errors.Is(err, badger.ErrNoRewrite):
+		p.badgerGCRuns.Add(garbageCollectionResultNoRewrite, 1)
+	default:
+		p.badgerGCRuns.Add(garbageCollectionResultError, 1)
+	}
+}
+
+// WriteTo writes all metrics in the Prometheus text exposition format.
+func (p *Prometheus) WriteTo(w io.Writer) (int64, error) {
+	var written int64
+	for _, f := range p.families {
+		n, err := f.WriteTo(w)
+		written += n
+		if err != nil {
+			return written, errors.Wrapf(err, "error writing family '%s'", f.name)
+		}
+	}
+	return written, nil
+}
@@ -0,0 +1,112 @@
+package prometheus_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/boreq/errors"
+	"github.com/dgraph-io/badger/v3"
+	"github.com/planetary-social/scuttlego/service/adapters/prometheus"
+	"github.com/planetary-social/scuttlego/service/domain/refs"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrometheus_WriteToProducesTextExpositionFormat(t *testing.T) {
+	p := prometheus.NewPrometheus()
+
+	feed := refs.MustNewFeed("@qFtLJ6P5Eh9vKxnj7Rsh8SkE6B6Z36DVLP7ZOKNeQ/Y=.ed25519")
+
+	p.ReportPeerConnected()
+	p.ReportPeerConnected()
+	p.ReportPeerDisconnected()
+	p.ReportBytesReceived(10)
+	p.ReportBytesReceived(5)
+	p.ReportBytesSent(20)
+	p.ReportRPCRequest(rpc.MustNewProcedureName([]string{"createHistoryStream"}))
+	p.ReportRPCRequest(rpc.MustNewProcedureName([]string{"blobs", "get"}))
+	p.ReportRPCRequest(rpc.MustNewProcedureName([]string{"blobs", "get"}))
+	p.ReportUnknownRPCRequest()
+	p.ReportMessagesPersisted(3)
+	p.ReportMessagesPersisted(4)
+	p.ReportMessageBufferSize(100)
+	p.ReportMessageBufferSize(50)
+	p.ReportReplicationLag(feed, 12)
+	p.ReportBlobBytesStored(1024)
+	p.ReportBadgerSize(2048, 4096)
+	p.ReportBadgerGarbageCollection(nil)
+	p.ReportBadgerGarbageCollection(badger.ErrNoRewrite)
+	p.ReportBadgerGarbageCollection(errors.Wrap(badger.ErrNoRewrite, "wrapped"))
+	p.ReportBadgerGarbageCollection(errors.New("some error"))
+
+	buf := &bytes.Buffer{}
+	n, err := p.WriteTo(buf)
+	require.NoError(t, err)
+	require.Equal(t, int64(buf.Len()), n)
+
+	expected := `# HELP scuttlego_connected_peers Number of peers which are currently connected.
+# TYPE scuttlego_connected_peers gauge
+scuttlego_connected_peers 1
+# HELP scuttlego_transport_bytes_total Number of bytes received and sent over box streams.
+# TYPE scuttlego_transport_bytes_total counter
+scuttlego_transport_bytes_total{direction="in"} 15
+scuttlego_transport_bytes_total{direction="out"} 20
+# HELP scuttlego_rpc_requests_total Number of RPC requests received from peers.
+# TYPE scuttlego_rpc_requests_total counter
+scuttlego_rpc_requests_total{procedure="blobs.get"} 2
+scuttlego_rpc_requests_total{procedure="createHistoryStream"} 1
+scuttlego_rpc_requests_total{procedure="unknown"} 1
+# HELP scuttlego_messages_persisted_total Number of replicated messages persisted by the message buffer.
+# TYPE scuttlego_messages_persisted_total counter
+scuttlego_messages_persisted_total 7
+# HELP scuttlego_message_buffer_messages Number of messages waiting in the message buffer.
+# TYPE scuttlego_message_buffer_messages gauge
+scuttlego_message_buffer_messages 50
+# HELP scuttlego_replication_lag_messages Number of messages by which the local copy of a feed was behind a peer when last checked.
+# TYPE scuttlego_replication_lag_messages gauge
+scuttlego_replication_lag_messages{feed="@qFtLJ6P5Eh9vKxnj7Rsh8SkE6B6Z36DVLP7ZOKNeQ/Y=.ed25519"} 12
+# HELP scuttlego_blob_bytes_stored_total Number of bytes of blobs written to the blob storage.
+# TYPE scuttlego_blob_bytes_stored_total counter
+scuttlego_blob_bytes_stored_total 1024
+# HELP scuttlego_badger_size_bytes Size of the Badger LSM tree and value log.
+# TYPE scuttlego_badger_size_bytes gauge
+scuttlego_badger_size_bytes{type="lsm"} 2048
+scuttlego_badger_size_bytes{type="vlog"} 4096
+# HELP scuttlego_badger_gc_runs_total Number of Badger value log garbage collection runs.
+# TYPE scuttlego_badger_gc_runs_total counter
+scuttlego_badger_gc_runs_total{result="error"} 1
+scuttlego_badger_gc_runs_total{result="no_rewrite"} 2
+scuttlego_badger_gc_runs_total{result="rewrite"} 1
+`
+
+	require.Equal(t, expected, buf.String())
+}
+
+func TestPrometheus_UnlabelledMetricsAreWrittenBeforeAnythingIsReported(t *testing.T) {
+	p := prometheus.NewPrometheus()
+
+	buf := &bytes.Buffer{}
+	_, err := p.WriteTo(buf)
+	require.NoError(t, err)
+
+	require.Contains(t, buf.String(), "\nscuttlego_connected_peers 0\n")
+	require.NotContains(t, buf.String(), "scuttlego_transport_bytes_total{")
+}
+
+func TestPrometheus_ForgottenReplicationLagIsNoLongerWritten(t *testing.T) {
+	p := prometheus.NewPrometheus()
+
+	feed1 := refs.MustNewFeed("@qFtLJ6P5Eh9vKxnj7Rsh8SkE6B6Z36DVLP7ZOKNeQ/Y=.ed25519")
+	feed2 := refs.MustNewFeed("@JhVkUhOgcDZH4Bd2tY1Cp2U7LfWXfzpBsbp9K9aV04g=.ed25519")
+
+	p.ReportReplicationLag(feed1, 1)
+	p.ReportReplicationLag(feed2, 2)
+	p.ForgetReplicationLag(feed1)
+
+	buf := &bytes.Buffer{}
+	_, err := p.WriteTo(buf)
+	require.NoError(t, err)
+
+	require.NotContains(t, buf.String(), feed1.String())
+	require.Contains(t, buf.String(), feed2.String())
+}
@@ -0,0 +1,31 @@
+package pubsub
+
+import (
+	"context"
+
+	"github.com/planetary-social/scuttlego/service/domain/feeds"
+)
+
+// contactsBufferSize is the number of contacts which can be queued for a
+// subscriber. Contacts are published after transactions are committed so
+// publishing must not wait for slow subscribers, contacts which don't fit in
+// the buffer are dropped for that subscriber.
+const contactsBufferSize = 1000
+
+type ContactPubSub struct {
+	pubsub *NonBlockingGoChannelPubSub[*feeds.Contact]
+}
+
+func NewContactPubSub() *ContactPubSub {
+	return &ContactPubSub{
+		pubsub: NewNonBlockingGoChannelPubSub[*feeds.Contact](contactsBufferSize),
+	}
+}
+
+func (m *ContactPubSub) PublishContact(contact *feeds.Contact) {
+	m.pubsub.Publish(contact)
+}
+
+func (m *ContactPubSub) SubscribeToContacts(ctx context.Context) <-chan *feeds.Contact {
+	return m.pubsub.Subscribe(ctx)
+}
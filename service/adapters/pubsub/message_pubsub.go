@@ -6,13 +6,19 @@ import (
 	"github.com/planetary-social/scuttlego/service/domain/feeds/message"
 )
 
+// messagesBufferSize is the number of messages which can be queued for a
+// subscriber. Messages are published after transactions are committed so
+// publishing must not wait for slow subscribers, messages which don't fit in
+// the buffer are dropped for that subscriber.
+const messagesBufferSize = 1000
+
 type MessagePubSub struct {
-	pubsub *GoChannelPubSub[message.Message]
+	pubsub *NonBlockingGoChannelPubSub[message.Message]
 }
 
 func NewMessagePubSub() *MessagePubSub {
 	return &MessagePubSub{
-		pubsub: NewGoChannelPubSub[message.Message](),
+		pubsub: NewNonBlockingGoChannelPubSub[message.Message](messagesBufferSize),
 	}
 }
 
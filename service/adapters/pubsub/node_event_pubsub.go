@@ -0,0 +1,122 @@
+package pubsub
+
+import (
+	"context"
+
+	"github.com/planetary-social/scuttlego/service/app/queries"
+	"github.com/planetary-social/scuttlego/service/domain/identity"
+	"github.com/planetary-social/scuttlego/service/domain/refs"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc"
+)
+
+// nodeEventsBufferSize is the number of events which are buffered for each
+// subscriber before new events are dropped.
+const nodeEventsBufferSize = 1000
+
+// NodeEventPubSub is used in places such as the handshake code path where
+// publishing must never be slowed down by subscribers so events are dropped
+// if a subscriber doesn't keep up.
+type NodeEventPubSub struct {
+	pubsub *NonBlockingGoChannelPubSub[queries.NodeEvent]
+}
+
+func NewNodeEventPubSub() *NodeEventPubSub {
+	return &NodeEventPubSub{
+		pubsub: NewNonBlockingGoChannelPubSub[queries.NodeEvent](nodeEventsBufferSize),
+	}
+}
+
+func (m *NodeEventPubSub) PublishPeerConnected(remote identity.Public, connectionId rpc.ConnectionId, initiatedByRemote bool) {
+	m.pubsub.Publish(
+		queries.PeerConnected{
+			Remote:            remote,
+			ConnectionId:      connectionId,
+			InitiatedByRemote: initiatedByRemote,
+		},
+	)
+}
+
+func (m *NodeEventPubSub) PublishPeerDisconnected(remote identity.Public, connectionId rpc.ConnectionId) {
+	m.pubsub.Publish(
+		queries.PeerDisconnected{
+			Remote:       remote,
+			ConnectionId: connectionId,
+		},
+	)
+}
+
+func (m *NodeEventPubSub) PublishHandshakeFailed(connectionId rpc.ConnectionId, initiatedByRemote bool, remote identity.Public, err error) {
+	m.pubsub.Publish(
+		queries.HandshakeFailed{
+			ConnectionId:      connectionId,
+			InitiatedByRemote: initiatedByRemote,
+			Remote:            remote,
+			Err:               err,
+		},
+	)
+}
+
+func (m *NodeEventPubSub) PublishReplicationStarted(peer identity.Public, feed refs.Feed) {
+	m.pubsub.Publish(
+		queries.ReplicationStarted{
+			Peer: peer,
+			Feed: feed,
+		},
+	)
+}
+
+func (m *NodeEventPubSub) PublishReplicationFinished(peer identity.Public, feed refs.Feed, receivedMessages int, err error) {
+	m.pubsub.Publish(
+		queries.ReplicationFinished{
+			Peer:             peer,
+			Feed:             feed,
+			ReceivedMessages: receivedMessages,
+			Err:              err,
+		},
+	)
+}
+
+func (m *NodeEventPubSub) PublishForkDetected(replicatedFrom identity.Public, feed refs.Feed) {
+	m.pubsub.Publish(
+		queries.ForkDetected{
+			ReplicatedFrom: replicatedFrom,
+			Feed:           feed,
+		},
+	)
+}
+
+func (m *NodeEventPubSub) PublishMigrationRunning(migrationIndex int, migrationsCount int) {
+	m.pubsub.Publish(
+		queries.MigrationProgress{
+			MigrationIndex:  migrationIndex,
+			MigrationsCount: migrationsCount,
+		},
+	)
+}
+
+func (m *NodeEventPubSub) PublishMigrationFailed(migrationIndex int, migrationsCount int, err error) {
+	m.pubsub.Publish(
+		queries.MigrationProgress{
+			MigrationIndex:  migrationIndex,
+			MigrationsCount: migrationsCount,
+			Err:             err,
+		},
+	)
+}
+
+func (m *NodeEventPubSub) PublishMigrationsDone(migrationsCount int) {
+	m.pubsub.Publish(
+		queries.MigrationProgress{
+			MigrationsCount: migrationsCount,
+			Done:            true,
+		},
+	)
+}
+
+func (m *NodeEventPubSub) PublishBanListChanged() {
+	m.pubsub.Publish(queries.BanListChanged{})
+}
+
+func (m *NodeEventPubSub) Subscribe(ctx context.Context) <-chan queries.NodeEvent {
+	return m.pubsub.Subscribe(ctx)
+}
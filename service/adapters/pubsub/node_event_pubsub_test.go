@@ -0,0 +1,173 @@
+package pubsub_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/planetary-social/scuttlego/internal/fixtures"
+	"github.com/planetary-social/scuttlego/service/adapters/pubsub"
+	"github.com/planetary-social/scuttlego/service/app/queries"
+	"github.com/planetary-social/scuttlego/service/domain/identity"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNodeEventPubSub_EventsArePublished(t *testing.T) {
+	remote := fixtures.SomePublicIdentity()
+	feed := fixtures.SomeRefFeed()
+	connectionId := rpc.NewConnectionIdGenerator().Generate()
+	err := fixtures.SomeError()
+
+	testCases := []struct {
+		Name          string
+		Publish       func(p *pubsub.NodeEventPubSub)
+		ExpectedEvent queries.NodeEvent
+	}{
+		{
+			Name: "peer_connected",
+			Publish: func(p *pubsub.NodeEventPubSub) {
+				p.PublishPeerConnected(remote, connectionId, true)
+			},
+			ExpectedEvent: queries.PeerConnected{
+				Remote:            remote,
+				ConnectionId:      connectionId,
+				InitiatedByRemote: true,
+			},
+		},
+		{
+			Name: "peer_disconnected",
+			Publish: func(p *pubsub.NodeEventPubSub) {
+				p.PublishPeerDisconnected(remote, connectionId)
+			},
+			ExpectedEvent: queries.PeerDisconnected{
+				Remote:       remote,
+				ConnectionId: connectionId,
+			},
+		},
+		{
+			Name: "handshake_failed",
+			Publish: func(p *pubsub.NodeEventPubSub) {
+				p.PublishHandshakeFailed(connectionId, true, identity.Public{}, err)
+			},
+			ExpectedEvent: queries.HandshakeFailed{
+				ConnectionId:      connectionId,
+				InitiatedByRemote: true,
+				Remote:            identity.Public{},
+				Err:               err,
+			},
+		},
+		{
+			Name: "replication_started",
+			Publish: func(p *pubsub.NodeEventPubSub) {
+				p.PublishReplicationStarted(remote, feed)
+			},
+			ExpectedEvent: queries.ReplicationStarted{
+				Peer: remote,
+				Feed: feed,
+			},
+		},
+		{
+			Name: "replication_finished",
+			Publish: func(p *pubsub.NodeEventPubSub) {
+				p.PublishReplicationFinished(remote, feed, 10, err)
+			},
+			ExpectedEvent: queries.ReplicationFinished{
+				Peer:             remote,
+				Feed:             feed,
+				ReceivedMessages: 10,
+				Err:              err,
+			},
+		},
+		{
+			Name: "fork_detected",
+			Publish: func(p *pubsub.NodeEventPubSub) {
+				p.PublishForkDetected(remote, feed)
+			},
+			ExpectedEvent: queries.ForkDetected{
+				ReplicatedFrom: remote,
+				Feed:           feed,
+			},
+		},
+		{
+			Name: "migration_running",
+			Publish: func(p *pubsub.NodeEventPubSub) {
+				p.PublishMigrationRunning(1, 3)
+			},
+			ExpectedEvent: queries.MigrationProgress{
+				MigrationIndex:  1,
+				MigrationsCount: 3,
+			},
+		},
+		{
+			Name: "migration_failed",
+			Publish: func(p *pubsub.NodeEventPubSub) {
+				p.PublishMigrationFailed(1, 3, err)
+			},
+			ExpectedEvent: queries.MigrationProgress{
+				MigrationIndex:  1,
+				MigrationsCount: 3,
+				Err:             err,
+			},
+		},
+		{
+			Name: "migrations_done",
+			Publish: func(p *pubsub.NodeEventPubSub) {
+				p.PublishMigrationsDone(3)
+			},
+			ExpectedEvent: queries.MigrationProgress{
+				MigrationsCount: 3,
+				Done:            true,
+			},
+		},
+		{
+			Name: "ban_list_changed",
+			Publish: func(p *pubsub.NodeEventPubSub) {
+				p.PublishBanListChanged()
+			},
+			ExpectedEvent: queries.BanListChanged{},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			ctx := fixtures.TestContext(t)
+			p := pubsub.NewNodeEventPubSub()
+
+			events := p.Subscribe(ctx)
+			testCase.Publish(p)
+
+			select {
+			case v := <-events:
+				require.Equal(t, testCase.ExpectedEvent, v)
+			case <-time.After(1 * time.Second):
+				t.Fatal("timeout")
+			}
+		})
+	}
+}
+
+func TestNodeEventPubSub_PublishingDoesNotBlockIfSubscriberIsNotReceiving(t *testing.T) {
+	ctx := fixtures.TestContext(t)
+	p := pubsub.NewNodeEventPubSub()
+
+	events := p.Subscribe(ctx)
+
+	const numberOfEvents = 10000
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < numberOfEvents; i++ {
+			p.PublishBanListChanged()
+		}
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("publishing blocked")
+	}
+
+	require.NotEmpty(t, events)
+	require.Less(t, len(events), numberOfEvents)
+}
@@ -65,3 +65,65 @@ func (g *GoChannelPubSub[T]) removeSubscription(ch chan T) {
 
 	panic("somehow the subscription was already removed, this must be a bug")
 }
+
+// NonBlockingGoChannelPubSub never blocks publishers. Each subscription has a
+// buffer and values which don't fit in the buffer of a subscription are
+// dropped for that subscription.
+type NonBlockingGoChannelPubSub[T any] struct {
+	bufferSize    int
+	subscriptions []chan T
+	lock          sync.Mutex
+}
+
+func NewNonBlockingGoChannelPubSub[T any](bufferSize int) *NonBlockingGoChannelPubSub[T] {
+	return &NonBlockingGoChannelPubSub[T]{
+		bufferSize: bufferSize,
+	}
+}
+
+func (g *NonBlockingGoChannelPubSub[T]) Subscribe(ctx context.Context) <-chan T {
+	ch := make(chan T, g.bufferSize)
+
+	g.addSubscription(ch)
+
+	go func() {
+		<-ctx.Done()
+		g.removeSubscription(ch)
+		close(ch)
+	}()
+
+	return ch
+}
+
+func (g *NonBlockingGoChannelPubSub[T]) Publish(value T) {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	for _, ch := range g.subscriptions {
+		select {
+		case ch <- value:
+		default:
+		}
+	}
+}
+
+func (g *NonBlockingGoChannelPubSub[T]) addSubscription(ch chan T) {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	g.subscriptions = append(g.subscriptions, ch)
+}
+
+func (g *NonBlockingGoChannelPubSub[T]) removeSubscription(ch chan T) {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	for i := range g.subscriptions {
+		if g.subscriptions[i] == ch {
+			g.subscriptions = append(g.subscriptions[:i], g.subscriptions[i+1:]...)
+			return
+		}
+	}
+
+	panic("somehow the subscription was already removed, this must be a bug")
+}
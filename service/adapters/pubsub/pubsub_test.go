@@ -0,0 +1,61 @@
+package pubsub_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/planetary-social/scuttlego/internal/fixtures"
+	"github.com/planetary-social/scuttlego/service/adapters/pubsub"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNonBlockingGoChannelPubSub_ValuesWhichDoNotFitInBufferAreDropped(t *testing.T) {
+	ctx := fixtures.TestContext(t)
+	p := pubsub.NewNonBlockingGoChannelPubSub[int](2)
+
+	ch := p.Subscribe(ctx)
+
+	p.Publish(1)
+	p.Publish(2)
+	p.Publish(3)
+
+	require.Equal(t, 1, <-ch)
+	require.Equal(t, 2, <-ch)
+
+	p.Publish(4)
+	require.Equal(t, 4, <-ch)
+}
+
+func TestNonBlockingGoChannelPubSub_SlowSubscriberDoesNotAffectOtherSubscribers(t *testing.T) {
+	ctx := fixtures.TestContext(t)
+	p := pubsub.NewNonBlockingGoChannelPubSub[int](1)
+
+	slow := p.Subscribe(ctx)
+	fast := p.Subscribe(ctx)
+
+	p.Publish(1)
+	require.Equal(t, 1, <-fast)
+
+	p.Publish(2)
+	require.Equal(t, 2, <-fast)
+
+	require.Equal(t, 1, <-slow)
+}
+
+func TestNonBlockingGoChannelPubSub_ChannelIsClosedWhenContextIsCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(fixtures.TestContext(t))
+	p := pubsub.NewNonBlockingGoChannelPubSub[int](1)
+
+	ch := p.Subscribe(ctx)
+	cancel()
+
+	select {
+	case _, ok := <-ch:
+		require.False(t, ok)
+	case <-time.After(1 * time.Second):
+		t.Fatal("timeout")
+	}
+
+	p.Publish(1)
+}
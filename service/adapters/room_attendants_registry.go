@@ -0,0 +1,183 @@
+package adapters
+
+import (
+	"context"
+	"encoding/base64"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/planetary-social/scuttlego/service/adapters/pubsub"
+	"github.com/planetary-social/scuttlego/service/domain/refs"
+	"github.com/planetary-social/scuttlego/service/domain/rooms"
+	"github.com/planetary-social/scuttlego/service/domain/transport"
+)
+
+// RoomAttendantsRegistry keeps track of attendants of rooms that we are
+// connected to. Attendants of a room are forgotten when the connection to that
+// room ends.
+type RoomAttendantsRegistry struct {
+	portals map[string]*registeredPortal
+	lock    sync.Mutex
+
+	changes *pubsub.GoChannelPubSub[refs.Identity]
+}
+
+func NewRoomAttendantsRegistry() *RoomAttendantsRegistry {
+	return &RoomAttendantsRegistry{
+		portals: make(map[string]*registeredPortal),
+		changes: pubsub.NewGoChannelPubSub[refs.Identity](),
+	}
+}
+
+// Joined records that an attendant joined a room. The context should be
+// cancelled when the connection with the portal ends.
+func (r *RoomAttendantsRegistry) Joined(ctx context.Context, portal transport.Peer, attendant refs.Identity, at time.Time) {
+	r.modify(portal, func(p *registeredPortal) bool {
+		if _, ok := p.attendants[attendant.String()]; ok {
+			return false
+		}
+		p.attendants[attendant.String()] = rooms.MustNewAttendant(attendant, at, nil)
+		return true
+	}, func() {
+		go r.removePortalWhenDone(ctx, portal)
+	})
+}
+
+// Left records that an attendant left a room.
+func (r *RoomAttendantsRegistry) Left(portal transport.Peer, attendant refs.Identity) {
+	r.modify(portal, func(p *registeredPortal) bool {
+		if _, ok := p.attendants[attendant.String()]; !ok {
+			return false
+		}
+		delete(p.attendants, attendant.String())
+		return true
+	}, nil)
+}
+
+// TunnelEstablished records that a tunnel to an attendant was opened using a
+// room.
+func (r *RoomAttendantsRegistry) TunnelEstablished(portal transport.Peer, attendant refs.Identity, tunnel transport.Peer) {
+	r.modify(portal, func(p *registeredPortal) bool {
+		v, ok := p.attendants[attendant.String()]
+		if !ok {
+			return false
+		}
+		if conn, ok := v.Tunnel(); ok && conn == tunnel.Conn() {
+			return false
+		}
+		p.attendants[attendant.String()] = rooms.MustNewAttendant(v.Id(), v.Joined(), tunnel.Conn())
+		return true
+	}, nil)
+}
+
+// List returns attendants of all rooms sorted by portal identity and the time
+// at which they joined.
+func (r *RoomAttendantsRegistry) List() []rooms.PortalAttendants {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	var result []rooms.PortalAttendants
+	for _, p := range r.portals {
+		result = append(result, p.list())
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Portal().String() < result[j].Portal().String()
+	})
+
+	return result
+}
+
+// Subscribe returns a channel which receives the identity of a portal every
+// time attendants of that portal change.
+func (r *RoomAttendantsRegistry) Subscribe(ctx context.Context) <-chan refs.Identity {
+	return r.changes.Subscribe(ctx)
+}
+
+func (r *RoomAttendantsRegistry) modify(portal transport.Peer, fn func(p *registeredPortal) bool, onNewPortal func()) {
+	portalRef := refs.MustNewIdentityFromPublic(portal.Identity())
+
+	if r.modifyUnderLock(portal, portalRef, fn, onNewPortal) {
+		r.changes.Publish(portalRef)
+	}
+}
+
+func (r *RoomAttendantsRegistry) modifyUnderLock(portal transport.Peer, portalRef refs.Identity, fn func(p *registeredPortal) bool, onNewPortal func()) bool {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	key := r.portalKey(portal)
+
+	p, ok := r.portals[key]
+	if !ok || p.conn != portal.Conn() {
+		if onNewPortal == nil {
+			return false
+		}
+
+		// a new connection with a portal sends the full list of attendants
+		// again so previous state must be discarded
+		p = newRegisteredPortal(portalRef, portal.Conn())
+		r.portals[key] = p
+		onNewPortal()
+	}
+
+	return fn(p)
+}
+
+func (r *RoomAttendantsRegistry) removePortalWhenDone(ctx context.Context, portal transport.Peer) {
+	<-ctx.Done()
+
+	if r.removePortal(portal) {
+		r.changes.Publish(refs.MustNewIdentityFromPublic(portal.Identity()))
+	}
+}
+
+func (r *RoomAttendantsRegistry) removePortal(portal transport.Peer) bool {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	key := r.portalKey(portal)
+
+	p, ok := r.portals[key]
+	if ok && p.conn == portal.Conn() {
+		delete(r.portals, key)
+		return true
+	}
+
+	return false
+}
+
+func (r *RoomAttendantsRegistry) portalKey(portal transport.Peer) string {
+	return base64.StdEncoding.EncodeToString(portal.Identity().PublicKey())
+}
+
+type registeredPortal struct {
+	portal     refs.Identity
+	conn       transport.Connection
+	attendants map[string]rooms.Attendant
+}
+
+func newRegisteredPortal(portal refs.Identity, conn transport.Connection) *registeredPortal {
+	return &registeredPortal{
+		portal:     portal,
+		conn:       conn,
+		attendants: make(map[string]rooms.Attendant),
+	}
+}
+
+func (p *registeredPortal) list() rooms.PortalAttendants {
+	attendants := make([]rooms.Attendant, 0, len(p.attendants))
+	for _, attendant := range p.attendants {
+		attendants = append(attendants, attendant)
+	}
+
+	sort.Slice(attendants, func(i, j int) bool {
+		if !attendants[i].Joined().Equal(attendants[j].Joined()) {
+			return attendants[i].Joined().Before(attendants[j].Joined())
+		}
+		return attendants[i].Id().String() < attendants[j].Id().String()
+	})
+
+	return rooms.MustNewPortalAttendants(p.portal, attendants)
+}
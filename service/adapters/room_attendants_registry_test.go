@@ -0,0 +1,132 @@
+package adapters
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/planetary-social/scuttlego/internal/fixtures"
+	"github.com/planetary-social/scuttlego/internal/mocks"
+	"github.com/planetary-social/scuttlego/service/domain/refs"
+	"github.com/planetary-social/scuttlego/service/domain/rooms"
+	"github.com/planetary-social/scuttlego/service/domain/transport"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoomAttendantsRegistry_JoinedLeftAndTunnelEstablishedModifyAttendants(t *testing.T) {
+	ctx := fixtures.TestContext(t)
+	registry := NewRoomAttendantsRegistry()
+
+	portal := somePortal(ctx)
+	portalRef := refs.MustNewIdentityFromPublic(portal.Identity())
+
+	attendant1 := fixtures.SomeRefIdentity()
+	attendant2 := fixtures.SomeRefIdentity()
+
+	joined1 := fixtures.SomeTime()
+	joined2 := joined1.Add(time.Second)
+
+	registry.Joined(ctx, portal, attendant2, joined2)
+	registry.Joined(ctx, portal, attendant1, joined1)
+	tunnel := transport.MustNewPeer(attendant2.Identity(), mocks.NewConnectionMock(ctx))
+	registry.TunnelEstablished(portal, attendant2, tunnel)
+
+	require.Equal(t,
+		[]rooms.PortalAttendants{
+			rooms.MustNewPortalAttendants(portalRef, []rooms.Attendant{
+				rooms.MustNewAttendant(attendant1, joined1, nil),
+				rooms.MustNewAttendant(attendant2, joined2, tunnel.Conn()),
+			}),
+		},
+		registry.List(),
+	)
+
+	registry.Left(portal, attendant1)
+
+	require.Equal(t,
+		[]rooms.PortalAttendants{
+			rooms.MustNewPortalAttendants(portalRef, []rooms.Attendant{
+				rooms.MustNewAttendant(attendant2, joined2, tunnel.Conn()),
+			}),
+		},
+		registry.List(),
+	)
+}
+
+func TestRoomAttendantsRegistry_NewConnectionWithPortalDiscardsPreviousAttendants(t *testing.T) {
+	ctx := fixtures.TestContext(t)
+	registry := NewRoomAttendantsRegistry()
+
+	portal := somePortal(ctx)
+	reconnectedPortal := transport.MustNewPeer(portal.Identity(), mocks.NewConnectionMock(ctx))
+	portalRef := refs.MustNewIdentityFromPublic(portal.Identity())
+
+	attendant1 := fixtures.SomeRefIdentity()
+	attendant2 := fixtures.SomeRefIdentity()
+	joined := fixtures.SomeTime()
+
+	registry.Joined(ctx, portal, attendant1, joined)
+	registry.Joined(ctx, reconnectedPortal, attendant2, joined)
+
+	require.Equal(t,
+		[]rooms.PortalAttendants{
+			rooms.MustNewPortalAttendants(portalRef, []rooms.Attendant{
+				rooms.MustNewAttendant(attendant2, joined, nil),
+			}),
+		},
+		registry.List(),
+	)
+}
+
+func TestRoomAttendantsRegistry_PortalIsRemovedWhenContextIsCancelled(t *testing.T) {
+	ctx := fixtures.TestContext(t)
+	registry := NewRoomAttendantsRegistry()
+
+	portalCtx, cancel := context.WithCancel(ctx)
+	portal := somePortal(ctx)
+
+	registry.Joined(portalCtx, portal, fixtures.SomeRefIdentity(), fixtures.SomeTime())
+	require.Len(t, registry.List(), 1)
+
+	cancel()
+
+	require.Eventually(t, func() bool {
+		return len(registry.List()) == 0
+	}, 1*time.Second, 10*time.Millisecond)
+}
+
+func TestRoomAttendantsRegistry_SubscribersAreNotifiedAboutChanges(t *testing.T) {
+	ctx := fixtures.TestContext(t)
+	registry := NewRoomAttendantsRegistry()
+
+	portal := somePortal(ctx)
+	portalRef := refs.MustNewIdentityFromPublic(portal.Identity())
+	attendant := fixtures.SomeRefIdentity()
+
+	changes := registry.Subscribe(ctx)
+
+	go func() {
+		registry.Joined(ctx, portal, attendant, fixtures.SomeTime())
+		registry.Joined(ctx, portal, attendant, fixtures.SomeTime()) // no changes
+		registry.Left(portal, attendant)
+	}()
+
+	for i := 0; i < 2; i++ {
+		select {
+		case v := <-changes:
+			require.Equal(t, portalRef, v)
+		case <-time.After(time.Second):
+			t.Fatal("timeout")
+		}
+	}
+
+	select {
+	case <-changes:
+		t.Fatal("unexpected notification")
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func somePortal(ctx context.Context) transport.Peer {
+	return transport.MustNewPeer(fixtures.SomePublicIdentity(), mocks.NewConnectionMock(ctx))
+}
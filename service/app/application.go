@@ -12,11 +12,15 @@ type Application struct {
 
 type Commands struct {
 	RedeemInvite         *commands.RedeemInviteHandler
+	CreateInvite         *commands.CreateInviteHandler
+	UseInvite            *commands.UseInviteHandler
 	Follow               *commands.FollowHandler
 	PublishRaw           *commands.PublishRawHandler
 	PublishRawAsIdentity *commands.PublishRawAsIdentityHandler
 	DownloadFeed         *commands.DownloadFeedHandler
 
+	FetchOutOfOrderMessage *commands.FetchOutOfOrderMessageHandler
+
 	Connect       *commands.ConnectHandler
 	DisconnectAll *commands.DisconnectAllHandler
 
@@ -27,20 +31,39 @@ type Commands struct {
 	RemoveFromBanList *commands.RemoveFromBanListHandler
 	SetBanList        *commands.SetBanListHandler
 
-	RoomsAliasRegister *commands.RoomsAliasRegisterHandler
-	RoomsAliasRevoke   *commands.RoomsAliasRevokeHandler
+	RoomsAliasRegister                 *commands.RoomsAliasRegisterHandler
+	RoomsAliasRevoke                   *commands.RoomsAliasRevokeHandler
+	RoomsHttpAuthSignIn                *commands.RoomsHttpAuthSignInHandler
+	RoomsHttpAuthCreateClientChallenge *commands.RoomsHttpAuthCreateClientChallengeHandler
+	RoomsConsumeHttpInvite             *commands.RoomsConsumeHttpInviteHandler
+
+	AddRoomMember    *commands.AddRoomMemberHandler
+	RemoveRoomMember *commands.RemoveRoomMemberHandler
 
 	RunMigrations *commands.RunMigrationsHandler
 }
 
 type Queries struct {
-	CreateHistoryStream  *queries.CreateHistoryStreamHandler
-	ReceiveLog           *queries.ReceiveLogHandler
-	PublishedLog         *queries.PublishedLogHandler
-	Status               *queries.StatusHandler
-	GetBlob              *queries.GetBlobHandler
-	BlobDownloadedEvents *queries.BlobDownloadedEventsHandler
-	RoomsListAliases     *queries.RoomsListAliasesHandler
-	GetMessage           *queries.GetMessageHandler
-	GetMessageBySequence *queries.GetMessageBySequenceHandler
+	CreateHistoryStream     *queries.CreateHistoryStreamHandler
+	ReceiveLog              *queries.ReceiveLogHandler
+	FeedMessages            *queries.FeedMessagesHandler
+	PublishedLog            *queries.PublishedLogHandler
+	Status                  *queries.StatusHandler
+	GetBlob                 *queries.GetBlobHandler
+	BlobDownloadedEvents    *queries.BlobDownloadedEventsHandler
+	MessageSavedEvents      *queries.MessageSavedEventsHandler
+	NodeEvents              *queries.NodeEventsHandler
+	RoomsListAliases        *queries.RoomsListAliasesHandler
+	RoomsResolveAlias       *queries.RoomsResolveAliasHandler
+	GetMessage              *queries.GetMessageHandler
+	GetMessageBySequence    *queries.GetMessageBySequenceHandler
+	RoomMembers             *queries.RoomMembersHandler
+	RoomServerResolveAlias  *queries.RoomServerResolveAliasHandler
+	RoomAttendants          *queries.RoomAttendantsHandler
+	RoomAttendantsSubscribe *queries.RoomAttendantsSubscribeHandler
+	FriendsHops             *queries.FriendsHopsHandler
+	GetContact              *queries.GetContactHandler
+	ContactsSubscribe       *queries.ContactsSubscribeHandler
+	ReplicateUpto           *queries.ReplicateUptoHandler
+	LatestSequence          *queries.LatestSequenceHandler
 }
@@ -2,6 +2,7 @@ package commands
 
 import (
 	"context"
+	"io"
 	"time"
 
 	"github.com/boreq/errors"
@@ -11,13 +12,21 @@ import (
 	"github.com/planetary-social/scuttlego/service/domain/feeds/message"
 	"github.com/planetary-social/scuttlego/service/domain/graph"
 	"github.com/planetary-social/scuttlego/service/domain/identity"
+	"github.com/planetary-social/scuttlego/service/domain/invites"
 	"github.com/planetary-social/scuttlego/service/domain/network"
 	"github.com/planetary-social/scuttlego/service/domain/refs"
+	"github.com/planetary-social/scuttlego/service/domain/rooms"
+	"github.com/planetary-social/scuttlego/service/domain/rooms/aliases"
+	"github.com/planetary-social/scuttlego/service/domain/rooms/httpauth"
 	"github.com/planetary-social/scuttlego/service/domain/transport"
 )
 
 var ErrBanListMappingNotFound = errors.New("ban list mapping not found")
 
+var ErrInviteNotFound = errors.New("invite not found")
+
+var ErrClientChallengeNotFound = errors.New("client challenge not found")
+
 type UpdateFeedFn func(feed *feeds.Feed) error
 
 type PeerManager interface {
@@ -29,8 +38,9 @@ type PeerManager interface {
 
 	// ConnectViaRoom instructs the peer manager that it should establish
 	// communications with the specified node using a room as a relay. Behaves
-	// like Connect.
-	ConnectViaRoom(ctx context.Context, portal transport.Peer, target identity.Public) error
+	// like Connect. Returns the tunnelled peer or a zero value if a
+	// connection with the node already existed.
+	ConnectViaRoom(ctx context.Context, portal transport.Peer, target identity.Public) (transport.Peer, error)
 
 	// EstablishNewConnections instructs the peer manager that it is time to
 	// establish new connections so that the specific connections quotas are
@@ -45,12 +55,53 @@ type PeerManager interface {
 	DisconnectAll() error
 
 	TrackPeer(ctx context.Context, peer transport.Peer)
+
+	// Peers returns the currently connected peers.
+	Peers() []transport.Peer
+}
+
+// RoomServer is used when this node acts as a room.
+type RoomServer interface {
+	// Run keeps track of the connected peer until the context is cancelled.
+	Run(ctx context.Context, peer transport.Peer) error
+
+	Announce(remote identity.Public, member bool) error
+	Leave(remote identity.Public) error
+	Connect(ctx context.Context, origin identity.Public, member bool, target refs.Identity, stream io.ReadWriteCloser) error
+
+	RegisterAlias(member bool, user refs.Identity, alias aliases.Alias, signature aliases.RegistrationSignature) (aliases.Registration, aliases.AliasEndpointURL, error)
+	CanManageAliases(member bool) error
 }
 
 type CurrentTimeProvider interface {
 	Get() time.Time
 }
 
+// RoomAttendantsRegistry keeps track of attendants of rooms that we are
+// connected to.
+type RoomAttendantsRegistry interface {
+	// Joined records that an attendant joined a room. The context should be
+	// cancelled when the connection with the portal ends.
+	Joined(ctx context.Context, portal transport.Peer, attendant refs.Identity, at time.Time)
+
+	// Left records that an attendant left a room.
+	Left(portal transport.Peer, attendant refs.Identity)
+
+	// TunnelEstablished records that a tunnel to an attendant was opened
+	// using a room.
+	TunnelEstablished(portal transport.Peer, attendant refs.Identity, tunnel transport.Peer)
+}
+
+// HttpAuthClientChallengeRepository stores client challenges created when
+// the user initiates signing in to the website of a room.
+type HttpAuthClientChallengeRepository interface {
+	Put(challenge httpauth.ClientChallenge) error
+
+	// Pop removes the challenge from the repository and returns it. Returns
+	// ErrClientChallengeNotFound if the challenge doesn't exist.
+	Pop(cc httpauth.Challenge) (httpauth.ClientChallenge, error)
+}
+
 // BannableRef wraps a feed ref.
 type BannableRef struct {
 	v any
@@ -74,17 +125,25 @@ type Dialer interface {
 	Dial(ctx context.Context, remote identity.Public, address network.Address) (transport.Peer, error)
 }
 
+type RoomHTTPClient interface {
+	ConsumeInvite(ctx context.Context, invite rooms.HTTPInvite, user refs.Identity) (network.MultiserverAddress, error)
+}
+
 type TransactionProvider interface {
 	Transact(func(adapters Adapters) error) error
 }
 
 type Adapters struct {
-	Feed         FeedRepository
-	ReceiveLog   ReceiveLogRepository
-	SocialGraph  SocialGraphRepository
-	BlobWantList BlobWantListRepository
-	FeedWantList FeedWantListRepository
-	BanList      BanListRepository
+	Feed              FeedRepository
+	ReceiveLog        ReceiveLogRepository
+	SocialGraph       SocialGraphRepository
+	BlobWantList      BlobWantListRepository
+	FeedWantList      FeedWantListRepository
+	BanList           BanListRepository
+	Invite            InviteRepository
+	RoomMember        RoomMemberRepository
+	RoomAlias         RoomAliasRepository
+	OutOfOrderMessage OutOfOrderMessageRepository
 }
 
 type FeedRepository interface {
@@ -122,6 +181,9 @@ type ReceiveLogRepository interface {
 
 type SocialGraphRepository interface {
 	GetSocialGraphBuilder() (*graph.SocialGraphBuilder, error)
+
+	// GetContacts returns contacts of the specified identity.
+	GetContacts(node refs.Identity) ([]*feeds.Contact, error)
 }
 
 type BlobWantListRepository interface {
@@ -161,3 +223,60 @@ type BanListRepository interface {
 	// found.
 	LookupMapping(hash bans.Hash) (BannableRef, error)
 }
+
+type BanListChangedPublisher interface {
+	// PublishBanListChanged is called after a transaction modifying the ban
+	// list is committed.
+	PublishBanListChanged()
+}
+
+type InviteRepository interface {
+	// Put saves the invite overwriting an existing invite with the same id.
+	Put(invite *invites.PubInvite) error
+
+	// UpdateInvite updates the specified invite by calling the provided
+	// function on it. Returns ErrInviteNotFound if the invite doesn't exist.
+	UpdateInvite(id refs.Identity, f func(invite *invites.PubInvite) error) error
+}
+
+// RoomMemberRepository stores members of the room hosted by this node.
+type RoomMemberRepository interface {
+	// Add adds a member. Adding an existing member is not an error.
+	Add(id refs.Identity) error
+
+	// Remove removes a member. Removing a non-existent member is not an
+	// error.
+	Remove(id refs.Identity) error
+
+	Contains(id refs.Identity) (bool, error)
+}
+
+// RoomAliasRepository stores aliases registered in the room hosted by this
+// node.
+type RoomAliasRepository interface {
+	// Put saves the registration overwriting any existing registration of
+	// the same alias.
+	Put(registration aliases.Registration) error
+
+	// Delete removes the alias. Removing a non-existent alias is not an
+	// error.
+	Delete(alias aliases.Alias) error
+
+	// Get returns common.ErrRoomAliasNotFound if the alias isn't registered.
+	Get(alias aliases.Alias) (aliases.Registration, error)
+}
+
+// OutOfOrderMessageRepository stores messages which were retrieved by their id
+// and don't belong to any of the stored feeds.
+type OutOfOrderMessageRepository interface {
+	Put(msg message.Message) error
+}
+
+func isRoomMember(adapters Adapters, remote identity.Public) (bool, error) {
+	ref, err := refs.NewIdentityFromPublic(remote)
+	if err != nil {
+		return false, errors.Wrap(err, "error creating a ref")
+	}
+
+	return adapters.RoomMember.Contains(ref)
+}
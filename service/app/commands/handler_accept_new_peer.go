@@ -20,11 +20,17 @@ type RoomScanner interface {
 	Run(ctx context.Context, peer transport.Peer) error
 }
 
+type Pinger interface {
+	Run(ctx context.Context, peer transport.Peer) error
+}
+
 type AcceptNewPeerHandler struct {
 	peerManager       PeerManager
 	messageReplicator MessageReplicator
 	blobReplicator    BlobReplicator
 	roomScanner       RoomScanner
+	pinger            Pinger
+	roomServer        RoomServer
 	logger            logging.Logger
 }
 
@@ -33,6 +39,8 @@ func NewAcceptNewPeerHandler(
 	messageReplicator MessageReplicator,
 	blobReplicator BlobReplicator,
 	roomScanner RoomScanner,
+	pinger Pinger,
+	roomServer RoomServer,
 	logger logging.Logger,
 ) *AcceptNewPeerHandler {
 	return &AcceptNewPeerHandler{
@@ -40,6 +48,8 @@ func NewAcceptNewPeerHandler(
 		messageReplicator: messageReplicator,
 		blobReplicator:    blobReplicator,
 		roomScanner:       roomScanner,
+		pinger:            pinger,
+		roomServer:        roomServer,
 		logger:            logger,
 	}
 }
@@ -68,6 +78,8 @@ func (h *AcceptNewPeerHandler) runTasks(ctx context.Context, peer transport.Peer
 	h.startTask(&tasks, ctx, peer, ch, h.messageReplicator.Replicate, "message replication")
 	h.startTask(&tasks, ctx, peer, ch, h.blobReplicator.Replicate, "blob replication")
 	h.startTask(&tasks, ctx, peer, ch, h.roomScanner.Run, "room scanner")
+	h.startTask(&tasks, ctx, peer, ch, h.pinger.Run, "pinger")
+	h.startTask(&tasks, ctx, peer, ch, h.roomServer.Run, "room server")
 
 	var result error
 	for i := 0; i < tasks; i++ {
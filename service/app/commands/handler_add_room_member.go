@@ -0,0 +1,54 @@
+package commands
+
+import (
+	"github.com/boreq/errors"
+	"github.com/planetary-social/scuttlego/service/domain/refs"
+)
+
+type AddRoomMember struct {
+	member refs.Identity
+}
+
+func NewAddRoomMember(member refs.Identity) (AddRoomMember, error) {
+	if member.IsZero() {
+		return AddRoomMember{}, errors.New("zero value of member")
+	}
+	return AddRoomMember{member: member}, nil
+}
+
+func (c AddRoomMember) Member() refs.Identity {
+	return c.member
+}
+
+func (c AddRoomMember) IsZero() bool {
+	return c.member.IsZero()
+}
+
+type AddRoomMemberHandler struct {
+	transaction TransactionProvider
+}
+
+func NewAddRoomMemberHandler(
+	transaction TransactionProvider,
+) *AddRoomMemberHandler {
+	return &AddRoomMemberHandler{
+		transaction: transaction,
+	}
+}
+
+func (h *AddRoomMemberHandler) Handle(cmd AddRoomMember) error {
+	if cmd.IsZero() {
+		return errors.New("zero value of command")
+	}
+
+	if err := h.transaction.Transact(func(adapters Adapters) error {
+		if err := adapters.RoomMember.Add(cmd.member); err != nil {
+			return errors.Wrap(err, "could not add the member")
+		}
+		return nil
+	}); err != nil {
+		return errors.Wrap(err, "transaction failed")
+	}
+
+	return nil
+}
@@ -0,0 +1,79 @@
+package commands_test
+
+import (
+	"testing"
+
+	"github.com/planetary-social/scuttlego/internal/fixtures"
+	"github.com/planetary-social/scuttlego/service/app/commands"
+	"github.com/planetary-social/scuttlego/service/domain/refs"
+	"github.com/planetary-social/scuttlego/service/domain/rooms/server"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddRoomMemberHandler(t *testing.T) {
+	testCases := []struct {
+		Mode server.PrivacyMode
+	}{
+		{
+			Mode: server.PrivacyModeOpen,
+		},
+		{
+			Mode: server.PrivacyModeCommunity,
+		},
+		{
+			Mode: server.PrivacyModeRestricted,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Mode.String(), func(t *testing.T) {
+			ctx := fixtures.TestContext(t)
+			ts := newRoomServerTest(t, testCase.Mode)
+
+			peer := ts.RunPeer(ctx, false)
+			member := refs.MustNewIdentityFromPublic(peer.Identity())
+
+			announce := commands.NewRoomServerAnnounceHandler(ts.Transaction, ts.Room)
+
+			err := announce.Handle(mustNewRoomServerAnnounce(t, peer.Identity()))
+			if testCase.Mode.RequiresMembership() {
+				require.ErrorIs(t, err, server.ErrNotAMember)
+			} else {
+				require.NoError(t, err)
+			}
+
+			cmd, err := commands.NewAddRoomMember(member)
+			require.NoError(t, err)
+
+			handler := commands.NewAddRoomMemberHandler(ts.Transaction)
+
+			err = handler.Handle(cmd)
+			require.NoError(t, err)
+
+			members, err := ts.RoomMember.List()
+			require.NoError(t, err)
+			require.Equal(t, []refs.Identity{member}, members)
+
+			err = announce.Handle(mustNewRoomServerAnnounce(t, peer.Identity()))
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestAddRoomMemberHandler_AddingAnExistingMemberIsNotAnError(t *testing.T) {
+	ts := newRoomServerTest(t, server.PrivacyModeCommunity)
+	member := fixtures.SomeRefIdentity()
+	ts.RoomMember.Mock(member)
+
+	cmd, err := commands.NewAddRoomMember(member)
+	require.NoError(t, err)
+
+	handler := commands.NewAddRoomMemberHandler(ts.Transaction)
+
+	err = handler.Handle(cmd)
+	require.NoError(t, err)
+
+	members, err := ts.RoomMember.List()
+	require.NoError(t, err)
+	require.Equal(t, []refs.Identity{member}, members)
+}
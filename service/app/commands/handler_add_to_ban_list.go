@@ -22,13 +22,16 @@ func (c AddToBanList) IsZero() bool {
 
 type AddToBanListHandler struct {
 	transaction TransactionProvider
+	publisher   BanListChangedPublisher
 }
 
 func NewAddToBanListHandler(
 	transaction TransactionProvider,
+	publisher BanListChangedPublisher,
 ) *AddToBanListHandler {
 	return &AddToBanListHandler{
 		transaction: transaction,
+		publisher:   publisher,
 	}
 }
 
@@ -46,5 +49,6 @@ func (h *AddToBanListHandler) Handle(cmd AddToBanList) error {
 		return errors.Wrap(err, "transaction failed")
 	}
 
+	h.publisher.PublishBanListChanged()
 	return nil
 }
@@ -0,0 +1,26 @@
+package commands_test
+
+import (
+	"testing"
+
+	"github.com/planetary-social/scuttlego/service/app/commands"
+	"github.com/planetary-social/scuttlego/service/domain/bans"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddToBanListHandler_BanListChangedIsPublishedAfterTransaction(t *testing.T) {
+	transaction := newBanListTransactionProviderMock()
+	publisher := newBanListChangedPublisherMock(transaction)
+	handler := commands.NewAddToBanListHandler(transaction, publisher)
+
+	hash := someHash()
+	cmd, err := commands.NewAddToBanList(hash)
+	require.NoError(t, err)
+
+	err = handler.Handle(cmd)
+	require.NoError(t, err)
+
+	require.Equal(t, []bans.Hash{hash}, transaction.BanList.AddCalls)
+	require.Equal(t, 1, publisher.Calls)
+	require.True(t, publisher.CalledAfterTransaction)
+}
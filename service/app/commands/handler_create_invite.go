@@ -0,0 +1,124 @@
+package commands
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"time"
+
+	"github.com/boreq/errors"
+	"github.com/planetary-social/scuttlego/logging"
+	"github.com/planetary-social/scuttlego/service/domain/identity"
+	"github.com/planetary-social/scuttlego/service/domain/invites"
+	"github.com/planetary-social/scuttlego/service/domain/network"
+	"github.com/planetary-social/scuttlego/service/domain/refs"
+)
+
+type CreateInvite struct {
+	address   network.Address
+	uses      int
+	expiresAt time.Time
+}
+
+// NewCreateInvite creates a command which creates an invite code pointing to
+// this node reachable under the specified address. The invite can be used the
+// specified number of times. Zero value of expiresAt means that the invite
+// never expires.
+func NewCreateInvite(address network.Address, uses int, expiresAt time.Time) (CreateInvite, error) {
+	if address.IsZero() {
+		return CreateInvite{}, errors.New("zero value of address")
+	}
+	if uses <= 0 {
+		return CreateInvite{}, errors.New("uses must be positive")
+	}
+	return CreateInvite{
+		address:   address,
+		uses:      uses,
+		expiresAt: expiresAt,
+	}, nil
+}
+
+func MustNewCreateInvite(address network.Address, uses int, expiresAt time.Time) CreateInvite {
+	v, err := NewCreateInvite(address, uses, expiresAt)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+func (cmd CreateInvite) Address() network.Address {
+	return cmd.address
+}
+
+func (cmd CreateInvite) Uses() int {
+	return cmd.uses
+}
+
+func (cmd CreateInvite) ExpiresAt() time.Time {
+	return cmd.expiresAt
+}
+
+func (cmd CreateInvite) IsZero() bool {
+	return cmd.address.IsZero()
+}
+
+type CreateInviteHandler struct {
+	transaction TransactionProvider
+	local       identity.Public
+	logger      logging.Logger
+}
+
+func NewCreateInviteHandler(
+	transaction TransactionProvider,
+	local identity.Public,
+	logger logging.Logger,
+) *CreateInviteHandler {
+	return &CreateInviteHandler{
+		transaction: transaction,
+		local:       local,
+		logger:      logger.New("create_invite_handler"),
+	}
+}
+
+func (h *CreateInviteHandler) Handle(cmd CreateInvite) (invites.Invite, error) {
+	if cmd.IsZero() {
+		return invites.Invite{}, errors.New("zero value of command")
+	}
+
+	seed := make([]byte, ed25519.SeedSize)
+	if _, err := rand.Read(seed); err != nil {
+		return invites.Invite{}, errors.Wrap(err, "error generating the seed")
+	}
+
+	inviteIdentity, err := identity.NewPrivateFromSeed(seed)
+	if err != nil {
+		return invites.Invite{}, errors.Wrap(err, "error creating the invite identity")
+	}
+
+	inviteRef, err := refs.NewIdentityFromPublic(inviteIdentity.Public())
+	if err != nil {
+		return invites.Invite{}, errors.Wrap(err, "error creating the invite ref")
+	}
+
+	localRef, err := refs.NewIdentityFromPublic(h.local)
+	if err != nil {
+		return invites.Invite{}, errors.Wrap(err, "error creating the local ref")
+	}
+
+	pubInvite, err := invites.NewPubInvite(inviteRef, cmd.uses, cmd.expiresAt)
+	if err != nil {
+		return invites.Invite{}, errors.Wrap(err, "error creating the pub invite")
+	}
+
+	if err := h.transaction.Transact(func(adapters Adapters) error {
+		return adapters.Invite.Put(pubInvite)
+	}); err != nil {
+		return invites.Invite{}, errors.Wrap(err, "transaction failed")
+	}
+
+	h.logger.Debug().
+		WithField("invite", inviteRef.String()).
+		WithField("uses", cmd.uses).
+		Message("created an invite")
+
+	return invites.NewInvite(localRef, cmd.address, seed)
+}
@@ -0,0 +1,98 @@
+package commands_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/planetary-social/scuttlego/internal/fixtures"
+	"github.com/planetary-social/scuttlego/service/app/commands"
+	"github.com/planetary-social/scuttlego/service/di"
+	"github.com/planetary-social/scuttlego/service/domain/identity"
+	"github.com/planetary-social/scuttlego/service/domain/invites"
+	"github.com/planetary-social/scuttlego/service/domain/network"
+	"github.com/planetary-social/scuttlego/service/domain/refs"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateInviteHandler_InviteIsStoredAndPointsToThisNode(t *testing.T) {
+	tc, err := di.BuildTestCommands(t)
+	require.NoError(t, err)
+
+	address := network.NewAddress("127.0.0.1:8008")
+	uses := 3
+	expiresAt := fixtures.SomeTime()
+
+	cmd, err := commands.NewCreateInvite(address, uses, expiresAt)
+	require.NoError(t, err)
+
+	invite, err := tc.CreateInvite.Handle(cmd)
+	require.NoError(t, err)
+
+	require.Equal(t, refs.MustNewIdentityFromPublic(tc.Local), invite.Remote())
+	require.Equal(t, address, invite.Address())
+
+	inviteIdentity, err := identity.NewPrivateFromSeed(invite.SecretKeySeed())
+	require.NoError(t, err)
+
+	require.Equal(t,
+		[]invites.PubInvite{
+			*invites.MustNewPubInvite(refs.MustNewIdentityFromPublic(inviteIdentity.Public()), uses, expiresAt),
+		},
+		tc.InviteRepository.Invites(),
+	)
+}
+
+func TestCreateInviteHandler_EachInviteHasADifferentIdentity(t *testing.T) {
+	tc, err := di.BuildTestCommands(t)
+	require.NoError(t, err)
+
+	cmd, err := commands.NewCreateInvite(network.NewAddress("127.0.0.1:8008"), 1, time.Time{})
+	require.NoError(t, err)
+
+	invite1, err := tc.CreateInvite.Handle(cmd)
+	require.NoError(t, err)
+
+	invite2, err := tc.CreateInvite.Handle(cmd)
+	require.NoError(t, err)
+
+	require.NotEqual(t, invite1.SecretKeySeed(), invite2.SecretKeySeed())
+	require.Len(t, tc.InviteRepository.Invites(), 2)
+}
+
+func TestNewCreateInvite(t *testing.T) {
+	testCases := []struct {
+		Name          string
+		Address       network.Address
+		Uses          int
+		ExpectedError string
+	}{
+		{
+			Name:    "valid",
+			Address: network.NewAddress("127.0.0.1:8008"),
+			Uses:    1,
+		},
+		{
+			Name:          "zero_address",
+			Address:       network.Address{},
+			Uses:          1,
+			ExpectedError: "zero value of address",
+		},
+		{
+			Name:          "zero_uses",
+			Address:       network.NewAddress("127.0.0.1:8008"),
+			Uses:          0,
+			ExpectedError: "uses must be positive",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			_, err := commands.NewCreateInvite(testCase.Address, testCase.Uses, time.Time{})
+			if testCase.ExpectedError == "" {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, testCase.ExpectedError)
+			}
+		})
+	}
+}
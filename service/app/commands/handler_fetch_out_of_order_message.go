@@ -0,0 +1,139 @@
+package commands
+
+import (
+	"context"
+	"time"
+
+	"github.com/boreq/errors"
+	"github.com/planetary-social/scuttlego/logging"
+	"github.com/planetary-social/scuttlego/service/domain/feeds/message"
+	"github.com/planetary-social/scuttlego/service/domain/messages"
+	"github.com/planetary-social/scuttlego/service/domain/refs"
+	"github.com/planetary-social/scuttlego/service/domain/transport"
+)
+
+const fetchOutOfOrderMessageTimeout = 10 * time.Second
+
+var ErrOutOfOrderMessageNotFound = errors.New("none of the peers returned the message")
+
+type FetchOutOfOrderMessage struct {
+	id refs.Message
+}
+
+func NewFetchOutOfOrderMessage(id refs.Message) (FetchOutOfOrderMessage, error) {
+	if id.IsZero() {
+		return FetchOutOfOrderMessage{}, errors.New("zero value of id")
+	}
+	return FetchOutOfOrderMessage{id: id}, nil
+}
+
+func MustNewFetchOutOfOrderMessage(id refs.Message) FetchOutOfOrderMessage {
+	v, err := NewFetchOutOfOrderMessage(id)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+func (c FetchOutOfOrderMessage) Id() refs.Message {
+	return c.id
+}
+
+func (c FetchOutOfOrderMessage) IsZero() bool {
+	return c.id.IsZero()
+}
+
+// FetchOutOfOrderMessageHandler asks connected peers for a message which
+// doesn't belong to any of the replicated feeds using the ooo.get procedure. The first message which
+// passes verification and has the requested id is saved. Once saved the
+// message can be retrieved using the GetMessage query. Returns
+// ErrOutOfOrderMessageNotFound if none of the peers returned the message.
+type FetchOutOfOrderMessageHandler struct {
+	peerManager PeerManager
+	identifier  RawMessageIdentifier
+	transaction TransactionProvider
+	logger      logging.Logger
+}
+
+func NewFetchOutOfOrderMessageHandler(
+	peerManager PeerManager,
+	identifier RawMessageIdentifier,
+	transaction TransactionProvider,
+	logger logging.Logger,
+) *FetchOutOfOrderMessageHandler {
+	return &FetchOutOfOrderMessageHandler{
+		peerManager: peerManager,
+		identifier:  identifier,
+		transaction: transaction,
+		logger:      logger.New("fetch_out_of_order_message_handler"),
+	}
+}
+
+func (h *FetchOutOfOrderMessageHandler) Handle(ctx context.Context, cmd FetchOutOfOrderMessage) error {
+	if cmd.IsZero() {
+		return errors.New("zero value of command")
+	}
+
+	for _, peer := range h.peerManager.Peers() {
+		msg, err := h.fetch(ctx, peer, cmd.Id())
+		if err != nil {
+			h.logger.Debug().WithError(err).WithField("peer", peer).Message("peer didn't return the message")
+			continue
+		}
+
+		if err := h.transaction.Transact(func(adapters Adapters) error {
+			return adapters.OutOfOrderMessage.Put(msg)
+		}); err != nil {
+			return errors.Wrap(err, "transaction failed")
+		}
+
+		return nil
+	}
+
+	return ErrOutOfOrderMessageNotFound
+}
+
+func (h *FetchOutOfOrderMessageHandler) fetch(ctx context.Context, peer transport.Peer, id refs.Message) (message.Message, error) {
+	ctx, cancel := context.WithTimeout(ctx, fetchOutOfOrderMessageTimeout)
+	defer cancel()
+
+	args, err := messages.NewGetArguments(id)
+	if err != nil {
+		return message.Message{}, errors.Wrap(err, "could not create args")
+	}
+
+	req, err := messages.NewOooGet(args)
+	if err != nil {
+		return message.Message{}, errors.Wrap(err, "could not create the request")
+	}
+
+	rs, err := peer.Conn().PerformRequest(ctx, req)
+	if err != nil {
+		return message.Message{}, errors.Wrap(err, "failed to perform a request")
+	}
+
+	response, ok := <-rs.Channel()
+	if !ok {
+		return message.Message{}, errors.New("channel closed")
+	}
+
+	if err := response.Err; err != nil {
+		return message.Message{}, errors.Wrap(err, "received an error")
+	}
+
+	getResponse, err := messages.NewGetResponseFromBytes(response.Value.Bytes())
+	if err != nil {
+		return message.Message{}, errors.Wrap(err, "invalid response")
+	}
+
+	msg, err := h.identifier.VerifyRawMessage(getResponse.RawMessage())
+	if err != nil {
+		return message.Message{}, errors.Wrap(err, "message verification failed")
+	}
+
+	if !msg.Id().Equal(id) {
+		return message.Message{}, errors.New("peer returned a different message")
+	}
+
+	return msg, nil
+}
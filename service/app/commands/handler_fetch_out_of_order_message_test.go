@@ -0,0 +1,191 @@
+package commands_test
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/boreq/errors"
+	"github.com/planetary-social/scuttlego/internal/fixtures"
+	"github.com/planetary-social/scuttlego/internal/mocks"
+	"github.com/planetary-social/scuttlego/service/app/commands"
+	"github.com/planetary-social/scuttlego/service/di"
+	"github.com/planetary-social/scuttlego/service/domain/feeds/message"
+	"github.com/planetary-social/scuttlego/service/domain/messages"
+	"github.com/planetary-social/scuttlego/service/domain/transport"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc/mux"
+	rpctransport "github.com/planetary-social/scuttlego/service/domain/transport/rpc/transport"
+	portsrpc "github.com/planetary-social/scuttlego/service/ports/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFetchOutOfOrderMessageHandler_MessageIsSavedIfOneOfThePeersReturnsIt(t *testing.T) {
+	c, err := di.BuildTestCommands(t)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(fixtures.TestContext(t), 5*time.Second)
+	defer cancel()
+
+	msg := fixtures.SomeMessage(fixtures.SomeSequence(), fixtures.SomeRefFeed())
+	c.RawMessageIdentifier.Mock(msg)
+
+	c.PeerManager.MockPeers([]transport.Peer{
+		newPeerRespondingToGet(ctx, t, msg, []rpc.ResponseWithError{
+			{
+				Value: nil,
+				Err:   fixtures.SomeError(),
+			},
+		}),
+		newPeerRespondingToGet(ctx, t, msg, []rpc.ResponseWithError{
+			{
+				Value: rpc.NewResponse(msg.Raw().Bytes()),
+				Err:   nil,
+			},
+		}),
+	})
+
+	err = c.FetchOutOfOrderMessage.Handle(ctx, commands.MustNewFetchOutOfOrderMessage(msg.Id()))
+	require.NoError(t, err)
+
+	require.Equal(t, []message.Message{msg}, c.OutOfOrderMessage.Messages())
+}
+
+func TestFetchOutOfOrderMessageHandler_MessagesWithDifferentIdsAreRejected(t *testing.T) {
+	c, err := di.BuildTestCommands(t)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(fixtures.TestContext(t), 5*time.Second)
+	defer cancel()
+
+	msg := fixtures.SomeMessage(fixtures.SomeSequence(), fixtures.SomeRefFeed())
+	otherMsg := fixtures.SomeMessage(fixtures.SomeSequence(), fixtures.SomeRefFeed())
+	c.RawMessageIdentifier.Mock(otherMsg)
+
+	c.PeerManager.MockPeers([]transport.Peer{
+		newPeerRespondingToGet(ctx, t, msg, []rpc.ResponseWithError{
+			{
+				Value: rpc.NewResponse(otherMsg.Raw().Bytes()),
+				Err:   nil,
+			},
+		}),
+	})
+
+	err = c.FetchOutOfOrderMessage.Handle(ctx, commands.MustNewFetchOutOfOrderMessage(msg.Id()))
+	require.ErrorIs(t, err, commands.ErrOutOfOrderMessageNotFound)
+
+	require.Empty(t, c.OutOfOrderMessage.Messages())
+}
+
+func TestFetchOutOfOrderMessageHandler_NoPeers(t *testing.T) {
+	c, err := di.BuildTestCommands(t)
+	require.NoError(t, err)
+
+	ctx := fixtures.TestContext(t)
+
+	err = c.FetchOutOfOrderMessage.Handle(ctx, commands.MustNewFetchOutOfOrderMessage(fixtures.SomeRefMessage()))
+	require.ErrorIs(t, err, commands.ErrOutOfOrderMessageNotFound)
+}
+
+func TestFetchOutOfOrderMessageHandler_MessageIsFetchedFromScuttlegoPeer(t *testing.T) {
+	c, err := di.BuildTestCommands(t)
+	require.NoError(t, err)
+
+	remote, err := di.BuildTestQueries(t)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(fixtures.TestContext(t), 5*time.Second)
+	defer cancel()
+
+	msg := fixtures.SomeMessage(fixtures.SomeSequence(), fixtures.SomeRefFeed())
+	remote.MessageRepository.MockGet(msg)
+	c.RawMessageIdentifier.Mock(msg)
+
+	remoteMux, err := portsrpc.NewMux(
+		fixtures.TestLogger(t),
+		mocks.NewMuxMetricsMock(),
+		[]mux.Handler{portsrpc.NewHandlerOooGet(remote.Queries.GetMessage)},
+		nil,
+		nil,
+	)
+	require.NoError(t, err)
+
+	local, _ := newConnectedConnections(ctx, t, newMuxRequestHandler(remoteMux))
+
+	c.PeerManager.MockPeers([]transport.Peer{
+		transport.MustNewPeer(fixtures.SomePublicIdentity(), local),
+	})
+
+	err = c.FetchOutOfOrderMessage.Handle(ctx, commands.MustNewFetchOutOfOrderMessage(msg.Id()))
+	require.NoError(t, err)
+
+	require.Equal(t, []message.Message{msg}, c.OutOfOrderMessage.Messages())
+}
+
+func newConnectedConnections(ctx context.Context, t *testing.T, remoteHandler rpc.RequestHandler) (*rpc.Connection, *rpc.Connection) {
+	logger := fixtures.TestLogger(t)
+
+	localPipe, remotePipe := net.Pipe()
+
+	local, err := rpc.NewConnection(
+		fixtures.SomeConnectionId(),
+		false,
+		rpctransport.NewRawConnection(localPipe, logger),
+		newMuxRequestHandler(nil),
+		rpc.ResponseStreamTimeouts{},
+		logger,
+	)
+	require.NoError(t, err)
+
+	remote, err := rpc.NewConnection(
+		fixtures.SomeConnectionId(),
+		true,
+		rpctransport.NewRawConnection(remotePipe, logger),
+		remoteHandler,
+		rpc.ResponseStreamTimeouts{},
+		logger,
+	)
+	require.NoError(t, err)
+
+	for _, conn := range []*rpc.Connection{local, remote} {
+		conn := conn
+		go func() {
+			_ = conn.Loop(ctx)
+		}()
+		t.Cleanup(func() {
+			_ = conn.Close()
+		})
+	}
+
+	return local, remote
+}
+
+type muxRequestHandler struct {
+	mux *mux.Mux
+}
+
+func newMuxRequestHandler(m *mux.Mux) *muxRequestHandler {
+	return &muxRequestHandler{mux: m}
+}
+
+func (m muxRequestHandler) HandleRequest(ctx context.Context, s rpc.Stream, req *rpc.Request) {
+	if m.mux == nil {
+		_ = s.CloseWithError(errors.New("not supported"))
+		return
+	}
+	m.mux.HandleRequest(ctx, s, req)
+}
+
+func newPeerRespondingToGet(ctx context.Context, t *testing.T, msg message.Message, responses []rpc.ResponseWithError) transport.Peer {
+	connection := mocks.NewConnectionMock(ctx)
+	connection.Mock(
+		func(req *rpc.Request) []rpc.ResponseWithError {
+			require.Equal(t, messages.OooGetProcedure.Typ(), req.Type())
+			require.Equal(t, messages.OooGetProcedure.Name(), req.Name())
+			require.Contains(t, string(req.Arguments()), msg.Id().String())
+			return responses
+		},
+	)
+	return transport.MustNewPeer(fixtures.SomePublicIdentity(), connection)
+}
@@ -38,32 +38,45 @@ func NewFollowHandler(
 }
 
 func (h *FollowHandler) Handle(cmd Follow) error {
+	return h.transaction.Transact(func(adapters Adapters) error {
+		_, err := publishFollow(adapters, h.local, h.marshaler, cmd.Target)
+		return err
+	})
+}
+
+// publishFollow publishes a contact message which follows the target in the
+// feed of the local identity and returns the id of the published message.
+func publishFollow(adapters Adapters, local identity.Private, marshaler content.Marshaler, target refs.Identity) (refs.Message, error) {
 	contactActions, err := known.NewContactActions([]known.ContactAction{known.ContactActionFollow})
 	if err != nil {
-		return errors.Wrap(err, "failed to create contact actions")
+		return refs.Message{}, errors.Wrap(err, "failed to create contact actions")
 	}
 
-	contact, err := known.NewContact(cmd.Target, contactActions)
+	contact, err := known.NewContact(target, contactActions)
 	if err != nil {
-		return errors.Wrap(err, "failed to create a contact message")
+		return refs.Message{}, errors.Wrap(err, "failed to create a contact message")
 	}
 
-	content, err := h.marshaler.Marshal(contact)
+	content, err := marshaler.Marshal(contact)
 	if err != nil {
-		return errors.Wrap(err, "failed to create message content")
+		return refs.Message{}, errors.Wrap(err, "failed to create message content")
 	}
 
-	myRef, err := refs.NewIdentityFromPublic(h.local.Public())
+	myRef, err := refs.NewIdentityFromPublic(local.Public())
 	if err != nil {
-		return errors.Wrap(err, "could not create my own ref")
+		return refs.Message{}, errors.Wrap(err, "could not create my own ref")
 	}
 
-	return h.transaction.Transact(func(adapters Adapters) error {
-		return adapters.Feed.UpdateFeed(myRef.MainFeed(), func(feed *feeds.Feed) error {
-			if _, err := feed.CreateMessage(content, time.Now(), h.local); err != nil {
-				return errors.Wrap(err, "failed to create a message")
-			}
-			return nil
-		})
-	})
+	var id refs.Message
+	if err := adapters.Feed.UpdateFeed(myRef.MainFeed(), func(feed *feeds.Feed) error {
+		id, err = feed.CreateMessage(content, time.Now(), local)
+		if err != nil {
+			return errors.Wrap(err, "failed to create a message")
+		}
+		return nil
+	}); err != nil {
+		return refs.Message{}, errors.Wrap(err, "failed to update the feed")
+	}
+
+	return id, nil
 }
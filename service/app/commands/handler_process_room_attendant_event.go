@@ -42,11 +42,21 @@ func (e ProcessRoomAttendantEvent) IsZero() bool {
 }
 
 type ProcessRoomAttendantEventHandler struct {
-	peerManager PeerManager
+	peerManager         PeerManager
+	registry            RoomAttendantsRegistry
+	currentTimeProvider CurrentTimeProvider
 }
 
-func NewProcessRoomAttendantEventHandler(peerManager PeerManager) *ProcessRoomAttendantEventHandler {
-	return &ProcessRoomAttendantEventHandler{peerManager: peerManager}
+func NewProcessRoomAttendantEventHandler(
+	peerManager PeerManager,
+	registry RoomAttendantsRegistry,
+	currentTimeProvider CurrentTimeProvider,
+) *ProcessRoomAttendantEventHandler {
+	return &ProcessRoomAttendantEventHandler{
+		peerManager:         peerManager,
+		registry:            registry,
+		currentTimeProvider: currentTimeProvider,
+	}
 }
 
 func (h *ProcessRoomAttendantEventHandler) Handle(ctx context.Context, cmd ProcessRoomAttendantEvent) error {
@@ -54,10 +64,22 @@ func (h *ProcessRoomAttendantEventHandler) Handle(ctx context.Context, cmd Proce
 		return errors.New("zero value of command")
 	}
 
-	if cmd.Event().Typ() == rooms.RoomAttendantsEventTypeJoined {
-		if err := h.peerManager.ConnectViaRoom(ctx, cmd.Portal(), cmd.Event().Id().Identity()); err != nil {
+	switch cmd.Event().Typ() {
+	case rooms.RoomAttendantsEventTypeJoined:
+		h.registry.Joined(ctx, cmd.Portal(), cmd.Event().Id(), h.currentTimeProvider.Get())
+
+		tunnel, err := h.peerManager.ConnectViaRoom(ctx, cmd.Portal(), cmd.Event().Id().Identity())
+		if err != nil {
 			return errors.Wrap(err, "failed to connect")
 		}
+
+		if !tunnel.IsZero() {
+			h.registry.TunnelEstablished(cmd.Portal(), cmd.Event().Id(), tunnel)
+		}
+	case rooms.RoomAttendantsEventTypeLeft:
+		h.registry.Left(cmd.Portal(), cmd.Event().Id())
+	default:
+		return errors.New("unknown event type")
 	}
 
 	return nil
@@ -7,6 +7,7 @@ import (
 	mocks2 "github.com/planetary-social/scuttlego/internal/mocks"
 	"github.com/planetary-social/scuttlego/service/app/commands"
 	"github.com/planetary-social/scuttlego/service/di"
+	"github.com/planetary-social/scuttlego/service/domain/refs"
 	"github.com/planetary-social/scuttlego/service/domain/rooms"
 	"github.com/planetary-social/scuttlego/service/domain/transport"
 	"github.com/stretchr/testify/require"
@@ -96,3 +97,55 @@ func TestProcessRoomAttendantEventHandler_CallsPeerManagerConnectViaRoomForSpeci
 		})
 	}
 }
+
+func TestProcessRoomAttendantEventHandler_UpdatesRoomAttendantsRegistry(t *testing.T) {
+	tc, err := di.BuildTestCommands(t)
+	require.NoError(t, err)
+
+	ctx := fixtures.TestContext(t)
+	conn := mocks2.NewConnectionMock(ctx)
+
+	portal, err := transport.NewPeer(fixtures.SomePublicIdentity(), conn)
+	require.NoError(t, err)
+
+	target := fixtures.SomeRefIdentity()
+	now := fixtures.SomeTime()
+	tc.CurrentTimeProvider.CurrentTime = now
+
+	tunnel := transport.MustNewPeer(target.Identity(), mocks2.NewConnectionMock(ctx))
+	tc.PeerManager.MockConnectViaRoom(tunnel)
+
+	cmd, err := commands.NewProcessRoomAttendantEvent(portal, rooms.MustNewRoomAttendantsEvent(rooms.RoomAttendantsEventTypeJoined, target))
+	require.NoError(t, err)
+
+	err = tc.ProcessRoomAttendantEvent.Handle(ctx, cmd)
+	require.NoError(t, err)
+
+	require.Equal(t,
+		[]rooms.PortalAttendants{
+			rooms.MustNewPortalAttendants(
+				refs.MustNewIdentityFromPublic(portal.Identity()),
+				[]rooms.Attendant{
+					rooms.MustNewAttendant(target, now, tunnel.Conn()),
+				},
+			),
+		},
+		tc.RoomAttendantsRegistry.List(),
+	)
+
+	cmd, err = commands.NewProcessRoomAttendantEvent(portal, rooms.MustNewRoomAttendantsEvent(rooms.RoomAttendantsEventTypeLeft, target))
+	require.NoError(t, err)
+
+	err = tc.ProcessRoomAttendantEvent.Handle(ctx, cmd)
+	require.NoError(t, err)
+
+	require.Equal(t,
+		[]rooms.PortalAttendants{
+			rooms.MustNewPortalAttendants(
+				refs.MustNewIdentityFromPublic(portal.Identity()),
+				[]rooms.Attendant{},
+			),
+		},
+		tc.RoomAttendantsRegistry.List(),
+	)
+}
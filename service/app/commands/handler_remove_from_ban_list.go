@@ -22,11 +22,13 @@ func (c RemoveFromBanList) IsZero() bool {
 
 type RemoveFromBanListHandler struct {
 	transaction TransactionProvider
+	publisher   BanListChangedPublisher
 }
 
-func NewRemoveFromBanListHandler(transaction TransactionProvider) *RemoveFromBanListHandler {
+func NewRemoveFromBanListHandler(transaction TransactionProvider, publisher BanListChangedPublisher) *RemoveFromBanListHandler {
 	return &RemoveFromBanListHandler{
 		transaction: transaction,
+		publisher:   publisher,
 	}
 }
 
@@ -44,5 +46,6 @@ func (h *RemoveFromBanListHandler) Handle(cmd RemoveFromBanList) error {
 		return errors.Wrap(err, "transaction failed")
 	}
 
+	h.publisher.PublishBanListChanged()
 	return nil
 }
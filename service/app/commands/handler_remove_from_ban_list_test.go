@@ -0,0 +1,26 @@
+package commands_test
+
+import (
+	"testing"
+
+	"github.com/planetary-social/scuttlego/service/app/commands"
+	"github.com/planetary-social/scuttlego/service/domain/bans"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRemoveFromBanListHandler_BanListChangedIsPublishedAfterTransaction(t *testing.T) {
+	transaction := newBanListTransactionProviderMock()
+	publisher := newBanListChangedPublisherMock(transaction)
+	handler := commands.NewRemoveFromBanListHandler(transaction, publisher)
+
+	hash := someHash()
+	cmd, err := commands.NewRemoveFromBanList(hash)
+	require.NoError(t, err)
+
+	err = handler.Handle(cmd)
+	require.NoError(t, err)
+
+	require.Equal(t, []bans.Hash{hash}, transaction.BanList.RemoveCalls)
+	require.Equal(t, 1, publisher.Calls)
+	require.True(t, publisher.CalledAfterTransaction)
+}
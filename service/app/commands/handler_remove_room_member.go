@@ -0,0 +1,54 @@
+package commands
+
+import (
+	"github.com/boreq/errors"
+	"github.com/planetary-social/scuttlego/service/domain/refs"
+)
+
+type RemoveRoomMember struct {
+	member refs.Identity
+}
+
+func NewRemoveRoomMember(member refs.Identity) (RemoveRoomMember, error) {
+	if member.IsZero() {
+		return RemoveRoomMember{}, errors.New("zero value of member")
+	}
+	return RemoveRoomMember{member: member}, nil
+}
+
+func (c RemoveRoomMember) Member() refs.Identity {
+	return c.member
+}
+
+func (c RemoveRoomMember) IsZero() bool {
+	return c.member.IsZero()
+}
+
+type RemoveRoomMemberHandler struct {
+	transaction TransactionProvider
+}
+
+func NewRemoveRoomMemberHandler(
+	transaction TransactionProvider,
+) *RemoveRoomMemberHandler {
+	return &RemoveRoomMemberHandler{
+		transaction: transaction,
+	}
+}
+
+func (h *RemoveRoomMemberHandler) Handle(cmd RemoveRoomMember) error {
+	if cmd.IsZero() {
+		return errors.New("zero value of command")
+	}
+
+	if err := h.transaction.Transact(func(adapters Adapters) error {
+		if err := adapters.RoomMember.Remove(cmd.member); err != nil {
+			return errors.Wrap(err, "could not remove the member")
+		}
+		return nil
+	}); err != nil {
+		return errors.Wrap(err, "transaction failed")
+	}
+
+	return nil
+}
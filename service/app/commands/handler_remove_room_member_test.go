@@ -0,0 +1,73 @@
+package commands_test
+
+import (
+	"testing"
+
+	"github.com/planetary-social/scuttlego/internal/fixtures"
+	"github.com/planetary-social/scuttlego/service/app/commands"
+	"github.com/planetary-social/scuttlego/service/domain/refs"
+	"github.com/planetary-social/scuttlego/service/domain/rooms/server"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRemoveRoomMemberHandler(t *testing.T) {
+	testCases := []struct {
+		Mode server.PrivacyMode
+	}{
+		{
+			Mode: server.PrivacyModeOpen,
+		},
+		{
+			Mode: server.PrivacyModeCommunity,
+		},
+		{
+			Mode: server.PrivacyModeRestricted,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Mode.String(), func(t *testing.T) {
+			ctx := fixtures.TestContext(t)
+			ts := newRoomServerTest(t, testCase.Mode)
+
+			peer := ts.RunPeer(ctx, true)
+			member := refs.MustNewIdentityFromPublic(peer.Identity())
+
+			announce := commands.NewRoomServerAnnounceHandler(ts.Transaction, ts.Room)
+
+			err := announce.Handle(mustNewRoomServerAnnounce(t, peer.Identity()))
+			require.NoError(t, err)
+
+			cmd, err := commands.NewRemoveRoomMember(member)
+			require.NoError(t, err)
+
+			handler := commands.NewRemoveRoomMemberHandler(ts.Transaction)
+
+			err = handler.Handle(cmd)
+			require.NoError(t, err)
+
+			members, err := ts.RoomMember.List()
+			require.NoError(t, err)
+			require.Empty(t, members)
+
+			err = announce.Handle(mustNewRoomServerAnnounce(t, peer.Identity()))
+			if testCase.Mode.RequiresMembership() {
+				require.ErrorIs(t, err, server.ErrNotAMember)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestRemoveRoomMemberHandler_RemovingANonMemberIsNotAnError(t *testing.T) {
+	ts := newRoomServerTest(t, server.PrivacyModeCommunity)
+
+	cmd, err := commands.NewRemoveRoomMember(fixtures.SomeRefIdentity())
+	require.NoError(t, err)
+
+	handler := commands.NewRemoveRoomMemberHandler(ts.Transaction)
+
+	err = handler.Handle(cmd)
+	require.NoError(t, err)
+}
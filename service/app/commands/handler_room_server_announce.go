@@ -0,0 +1,61 @@
+package commands
+
+import (
+	"github.com/boreq/errors"
+	"github.com/planetary-social/scuttlego/service/domain/identity"
+)
+
+type RoomServerAnnounce struct {
+	remote identity.Public
+}
+
+func NewRoomServerAnnounce(remote identity.Public) (RoomServerAnnounce, error) {
+	if remote.IsZero() {
+		return RoomServerAnnounce{}, errors.New("zero value of remote")
+	}
+	return RoomServerAnnounce{remote: remote}, nil
+}
+
+func (c RoomServerAnnounce) Remote() identity.Public {
+	return c.remote
+}
+
+func (c RoomServerAnnounce) IsZero() bool {
+	return c.remote.IsZero()
+}
+
+type RoomServerAnnounceHandler struct {
+	transaction TransactionProvider
+	roomServer  RoomServer
+}
+
+func NewRoomServerAnnounceHandler(
+	transaction TransactionProvider,
+	roomServer RoomServer,
+) *RoomServerAnnounceHandler {
+	return &RoomServerAnnounceHandler{
+		transaction: transaction,
+		roomServer:  roomServer,
+	}
+}
+
+func (h *RoomServerAnnounceHandler) Handle(cmd RoomServerAnnounce) error {
+	if cmd.IsZero() {
+		return errors.New("zero value of command")
+	}
+
+	var member bool
+
+	if err := h.transaction.Transact(func(adapters Adapters) error {
+		tmp, err := isRoomMember(adapters, cmd.remote)
+		if err != nil {
+			return errors.Wrap(err, "error checking membership")
+		}
+		member = tmp
+		return nil
+	}); err != nil {
+		return errors.Wrap(err, "transaction failed")
+	}
+
+	return h.roomServer.Announce(cmd.remote, member)
+}
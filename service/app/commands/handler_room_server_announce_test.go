@@ -0,0 +1,168 @@
+package commands_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/planetary-social/scuttlego/internal/fixtures"
+	"github.com/planetary-social/scuttlego/internal/mocks"
+	"github.com/planetary-social/scuttlego/service/app/commands"
+	"github.com/planetary-social/scuttlego/service/domain/identity"
+	"github.com/planetary-social/scuttlego/service/domain/refs"
+	"github.com/planetary-social/scuttlego/service/domain/rooms/server"
+	"github.com/planetary-social/scuttlego/service/domain/transport"
+	"github.com/stretchr/testify/require"
+)
+
+// roomServerAccessTestCases describe who can use a room depending on its
+// privacy mode.
+var roomServerAccessTestCases = []struct {
+	Name          string
+	Mode          server.PrivacyMode
+	Member        bool
+	ExpectedError error
+}{
+	{
+		Name:          "disabled",
+		Mode:          server.PrivacyMode{},
+		Member:        true,
+		ExpectedError: server.ErrRoomDisabled,
+	},
+	{
+		Name:          "open_member",
+		Mode:          server.PrivacyModeOpen,
+		Member:        true,
+		ExpectedError: nil,
+	},
+	{
+		Name:          "open_non_member",
+		Mode:          server.PrivacyModeOpen,
+		Member:        false,
+		ExpectedError: nil,
+	},
+	{
+		Name:          "community_member",
+		Mode:          server.PrivacyModeCommunity,
+		Member:        true,
+		ExpectedError: nil,
+	},
+	{
+		Name:          "community_non_member",
+		Mode:          server.PrivacyModeCommunity,
+		Member:        false,
+		ExpectedError: server.ErrNotAMember,
+	},
+	{
+		Name:          "restricted_member",
+		Mode:          server.PrivacyModeRestricted,
+		Member:        true,
+		ExpectedError: nil,
+	},
+	{
+		Name:          "restricted_non_member",
+		Mode:          server.PrivacyModeRestricted,
+		Member:        false,
+		ExpectedError: server.ErrNotAMember,
+	},
+}
+
+func TestRoomServerAnnounceHandler(t *testing.T) {
+	for _, testCase := range roomServerAccessTestCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			ctx := fixtures.TestContext(t)
+			ts := newRoomServerTest(t, testCase.Mode)
+
+			peer := ts.RunPeer(ctx, testCase.Member)
+
+			cmd, err := commands.NewRoomServerAnnounce(peer.Identity())
+			require.NoError(t, err)
+
+			handler := commands.NewRoomServerAnnounceHandler(ts.Transaction, ts.Room)
+
+			err = handler.Handle(cmd)
+			if testCase.ExpectedError == nil {
+				require.NoError(t, err)
+				require.Equal(t, []refs.Identity{refs.MustNewIdentityFromPublic(peer.Identity())}, ts.Attendants(ctx))
+			} else {
+				require.ErrorIs(t, err, testCase.ExpectedError)
+			}
+		})
+	}
+}
+
+func TestRoomServerAnnounceHandler_PeerMustBeConnected(t *testing.T) {
+	ts := newRoomServerTest(t, server.PrivacyModeOpen)
+
+	cmd, err := commands.NewRoomServerAnnounce(fixtures.SomePublicIdentity())
+	require.NoError(t, err)
+
+	handler := commands.NewRoomServerAnnounceHandler(ts.Transaction, ts.Room)
+
+	err = handler.Handle(cmd)
+	require.ErrorIs(t, err, server.ErrNotConnected)
+}
+
+func mustNewRoomServerAnnounce(t *testing.T, remote identity.Public) commands.RoomServerAnnounce {
+	cmd, err := commands.NewRoomServerAnnounce(remote)
+	require.NoError(t, err)
+	return cmd
+}
+
+type roomServerTest struct {
+	t           *testing.T
+	Room        *server.Room
+	RoomMember  *mocks.RoomMemberRepositoryMock
+	Transaction *mocks.MockCommandsTransactionProvider
+}
+
+func newRoomServerTest(t *testing.T, mode server.PrivacyMode) roomServerTest {
+	roomMember := mocks.NewRoomMemberRepositoryMock()
+	return roomServerTest{
+		t:           t,
+		Room:        server.NewRoom(fixtures.SomePublicIdentity(), server.Config{PrivacyMode: mode}, fixtures.TestLogger(t)),
+		RoomMember:  roomMember,
+		Transaction: mocks.NewMockCommandsTransactionProvider(commands.Adapters{RoomMember: roomMember}),
+	}
+}
+
+// RunPeer connects a new peer to the room and optionally makes it a member.
+func (ts roomServerTest) RunPeer(ctx context.Context, member bool) transport.Peer {
+	peer := transport.MustNewPeer(fixtures.SomePublicIdentity(), mocks.NewConnectionMock(ctx))
+
+	if member {
+		ts.RoomMember.Mock(refs.MustNewIdentityFromPublic(peer.Identity()))
+	}
+
+	go func() {
+		_ = ts.Room.Run(ctx, peer)
+	}()
+
+	// A disabled room doesn't keep track of peers.
+	if ts.isDisabled() {
+		return peer
+	}
+
+	// there is no way to wait for the peer to be registered other than trying
+	// to use it
+	require.Eventually(ts.t, func() bool {
+		if err := ts.Room.Announce(peer.Identity(), true); err != nil {
+			return false
+		}
+		return ts.Room.Leave(peer.Identity()) == nil
+	}, 5*time.Second, 10*time.Millisecond)
+
+	return peer
+}
+
+// Attendants returns the current attendants of the room.
+func (ts roomServerTest) Attendants(ctx context.Context) []refs.Identity {
+	attendants, _, err := ts.Room.Attendants(ctx, true)
+	require.NoError(ts.t, err)
+	return attendants
+}
+
+func (ts roomServerTest) isDisabled() bool {
+	_, err := ts.Room.Metadata(true)
+	return err != nil
+}
@@ -0,0 +1,88 @@
+package commands
+
+import (
+	"context"
+	"io"
+
+	"github.com/boreq/errors"
+	"github.com/planetary-social/scuttlego/service/domain/identity"
+	"github.com/planetary-social/scuttlego/service/domain/refs"
+)
+
+type RoomServerConnect struct {
+	origin identity.Public
+	target refs.Identity
+	stream io.ReadWriteCloser
+}
+
+// NewRoomServerConnect creates a command which relays a tunnel.connect request
+// sent by origin to the target.
+func NewRoomServerConnect(origin identity.Public, target refs.Identity, stream io.ReadWriteCloser) (RoomServerConnect, error) {
+	if origin.IsZero() {
+		return RoomServerConnect{}, errors.New("zero value of origin")
+	}
+	if target.IsZero() {
+		return RoomServerConnect{}, errors.New("zero value of target")
+	}
+	if stream == nil {
+		return RoomServerConnect{}, errors.New("nil stream")
+	}
+	return RoomServerConnect{
+		origin: origin,
+		target: target,
+		stream: stream,
+	}, nil
+}
+
+func (c RoomServerConnect) Origin() identity.Public {
+	return c.origin
+}
+
+func (c RoomServerConnect) Target() refs.Identity {
+	return c.target
+}
+
+func (c RoomServerConnect) Stream() io.ReadWriteCloser {
+	return c.stream
+}
+
+func (c RoomServerConnect) IsZero() bool {
+	return c.origin.IsZero()
+}
+
+type RoomServerConnectHandler struct {
+	transaction TransactionProvider
+	roomServer  RoomServer
+}
+
+func NewRoomServerConnectHandler(
+	transaction TransactionProvider,
+	roomServer RoomServer,
+) *RoomServerConnectHandler {
+	return &RoomServerConnectHandler{
+		transaction: transaction,
+		roomServer:  roomServer,
+	}
+}
+
+// Handle blocks until the tunnel is closed.
+func (h *RoomServerConnectHandler) Handle(ctx context.Context, cmd RoomServerConnect) error {
+	if cmd.IsZero() {
+		return errors.New("zero value of command")
+	}
+
+	var member bool
+
+	if err := h.transaction.Transact(func(adapters Adapters) error {
+		tmp, err := isRoomMember(adapters, cmd.origin)
+		if err != nil {
+			return errors.Wrap(err, "error checking membership")
+		}
+		member = tmp
+		return nil
+	}); err != nil {
+		return errors.Wrap(err, "transaction failed")
+	}
+
+	return h.roomServer.Connect(ctx, cmd.origin, member, cmd.target, cmd.stream)
+}
@@ -0,0 +1,41 @@
+package commands_test
+
+import (
+	"net"
+	"testing"
+
+	"github.com/planetary-social/scuttlego/internal/fixtures"
+	"github.com/planetary-social/scuttlego/service/app/commands"
+	"github.com/planetary-social/scuttlego/service/domain/rooms/server"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoomServerConnectHandler(t *testing.T) {
+	for _, testCase := range roomServerAccessTestCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			ctx := fixtures.TestContext(t)
+			ts := newRoomServerTest(t, testCase.Mode)
+
+			origin := ts.RunPeer(ctx, testCase.Member)
+
+			stream, other := net.Pipe()
+			t.Cleanup(func() {
+				_ = stream.Close()
+				_ = other.Close()
+			})
+
+			cmd, err := commands.NewRoomServerConnect(origin.Identity(), fixtures.SomeRefIdentity(), stream)
+			require.NoError(t, err)
+
+			handler := commands.NewRoomServerConnectHandler(ts.Transaction, ts.Room)
+
+			err = handler.Handle(ctx, cmd)
+			if testCase.ExpectedError == nil {
+				// access was granted but the target is not in the room
+				require.ErrorIs(t, err, server.ErrTargetNotPresent)
+			} else {
+				require.ErrorIs(t, err, testCase.ExpectedError)
+			}
+		})
+	}
+}
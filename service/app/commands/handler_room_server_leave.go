@@ -0,0 +1,45 @@
+package commands
+
+import (
+	"github.com/boreq/errors"
+	"github.com/planetary-social/scuttlego/service/domain/identity"
+)
+
+type RoomServerLeave struct {
+	remote identity.Public
+}
+
+func NewRoomServerLeave(remote identity.Public) (RoomServerLeave, error) {
+	if remote.IsZero() {
+		return RoomServerLeave{}, errors.New("zero value of remote")
+	}
+	return RoomServerLeave{remote: remote}, nil
+}
+
+func (c RoomServerLeave) Remote() identity.Public {
+	return c.remote
+}
+
+func (c RoomServerLeave) IsZero() bool {
+	return c.remote.IsZero()
+}
+
+type RoomServerLeaveHandler struct {
+	roomServer RoomServer
+}
+
+func NewRoomServerLeaveHandler(
+	roomServer RoomServer,
+) *RoomServerLeaveHandler {
+	return &RoomServerLeaveHandler{
+		roomServer: roomServer,
+	}
+}
+
+func (h *RoomServerLeaveHandler) Handle(cmd RoomServerLeave) error {
+	if cmd.IsZero() {
+		return errors.New("zero value of command")
+	}
+
+	return h.roomServer.Leave(cmd.remote)
+}
@@ -0,0 +1,43 @@
+package commands_test
+
+import (
+	"testing"
+
+	"github.com/planetary-social/scuttlego/internal/fixtures"
+	"github.com/planetary-social/scuttlego/service/app/commands"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoomServerLeaveHandler(t *testing.T) {
+	for _, testCase := range roomServerAccessTestCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			ctx := fixtures.TestContext(t)
+			ts := newRoomServerTest(t, testCase.Mode)
+
+			peer := ts.RunPeer(ctx, testCase.Member)
+
+			announce := commands.NewRoomServerAnnounceHandler(ts.Transaction, ts.Room)
+			leave := commands.NewRoomServerLeaveHandler(ts.Room)
+
+			err := announce.Handle(mustNewRoomServerAnnounce(t, peer.Identity()))
+			if testCase.ExpectedError != nil {
+				require.ErrorIs(t, err, testCase.ExpectedError)
+			} else {
+				require.NoError(t, err)
+				require.Len(t, ts.Attendants(ctx), 1)
+			}
+
+			cmd, err := commands.NewRoomServerLeave(peer.Identity())
+			require.NoError(t, err)
+
+			// Leaving is always possible unless the room is disabled.
+			err = leave.Handle(cmd)
+			if testCase.Mode.IsZero() {
+				require.ErrorIs(t, err, testCase.ExpectedError)
+			} else {
+				require.NoError(t, err)
+				require.Empty(t, ts.Attendants(ctx))
+			}
+		})
+	}
+}
@@ -0,0 +1,130 @@
+package commands
+
+import (
+	"github.com/boreq/errors"
+	"github.com/planetary-social/scuttlego/service/app/common"
+	"github.com/planetary-social/scuttlego/service/domain/identity"
+	"github.com/planetary-social/scuttlego/service/domain/refs"
+	"github.com/planetary-social/scuttlego/service/domain/rooms/aliases"
+)
+
+type RoomServerRegisterAlias struct {
+	user      identity.Public
+	alias     aliases.Alias
+	signature aliases.RegistrationSignature
+}
+
+func NewRoomServerRegisterAlias(
+	user identity.Public,
+	alias aliases.Alias,
+	signature aliases.RegistrationSignature,
+) (RoomServerRegisterAlias, error) {
+	if user.IsZero() {
+		return RoomServerRegisterAlias{}, errors.New("zero value of user")
+	}
+
+	if alias.IsZero() {
+		return RoomServerRegisterAlias{}, errors.New("zero value of alias")
+	}
+
+	if signature.IsZero() {
+		return RoomServerRegisterAlias{}, errors.New("zero value of signature")
+	}
+
+	return RoomServerRegisterAlias{
+		user:      user,
+		alias:     alias,
+		signature: signature,
+	}, nil
+}
+
+func MustNewRoomServerRegisterAlias(
+	user identity.Public,
+	alias aliases.Alias,
+	signature aliases.RegistrationSignature,
+) RoomServerRegisterAlias {
+	v, err := NewRoomServerRegisterAlias(user, alias, signature)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+func (c RoomServerRegisterAlias) User() identity.Public {
+	return c.user
+}
+
+func (c RoomServerRegisterAlias) Alias() aliases.Alias {
+	return c.alias
+}
+
+func (c RoomServerRegisterAlias) Signature() aliases.RegistrationSignature {
+	return c.signature
+}
+
+func (c RoomServerRegisterAlias) IsZero() bool {
+	return c.user.IsZero()
+}
+
+type RoomServerRegisterAliasHandler struct {
+	transaction TransactionProvider
+	roomServer  RoomServer
+}
+
+func NewRoomServerRegisterAliasHandler(
+	transaction TransactionProvider,
+	roomServer RoomServer,
+) *RoomServerRegisterAliasHandler {
+	return &RoomServerRegisterAliasHandler{
+		transaction: transaction,
+		roomServer:  roomServer,
+	}
+}
+
+// Handle returns ErrRoomAliasAlreadyTaken if the alias was registered by a
+// different user. Registering an alias again by the same user is not an
+// error.
+func (h *RoomServerRegisterAliasHandler) Handle(cmd RoomServerRegisterAlias) (aliases.AliasEndpointURL, error) {
+	if cmd.IsZero() {
+		return aliases.AliasEndpointURL{}, errors.New("zero value of command")
+	}
+
+	user, err := refs.NewIdentityFromPublic(cmd.user)
+	if err != nil {
+		return aliases.AliasEndpointURL{}, errors.Wrap(err, "error creating the user ref")
+	}
+
+	var url aliases.AliasEndpointURL
+
+	if err := h.transaction.Transact(func(adapters Adapters) error {
+		member, err := isRoomMember(adapters, cmd.user)
+		if err != nil {
+			return errors.Wrap(err, "error checking membership")
+		}
+
+		registration, tmp, err := h.roomServer.RegisterAlias(member, user, cmd.alias, cmd.signature)
+		if err != nil {
+			return errors.Wrap(err, "error registering the alias")
+		}
+
+		existing, err := adapters.RoomAlias.Get(cmd.alias)
+		if err != nil {
+			if !errors.Is(err, common.ErrRoomAliasNotFound) {
+				return errors.Wrap(err, "error getting the alias")
+			}
+		} else if !existing.User().Equal(user) {
+			return ErrRoomAliasAlreadyTaken
+		}
+
+		if err := adapters.RoomAlias.Put(registration); err != nil {
+			return errors.Wrap(err, "error saving the alias")
+		}
+
+		url = tmp
+		return nil
+	}); err != nil {
+		return aliases.AliasEndpointURL{}, errors.Wrap(err, "transaction failed")
+	}
+
+	return url, nil
+}
@@ -0,0 +1,131 @@
+package commands_test
+
+import (
+	"testing"
+
+	"github.com/planetary-social/scuttlego/internal/fixtures"
+	"github.com/planetary-social/scuttlego/internal/mocks"
+	"github.com/planetary-social/scuttlego/service/app/commands"
+	"github.com/planetary-social/scuttlego/service/app/common"
+	"github.com/planetary-social/scuttlego/service/domain/identity"
+	"github.com/planetary-social/scuttlego/service/domain/refs"
+	"github.com/planetary-social/scuttlego/service/domain/rooms/aliases"
+	"github.com/planetary-social/scuttlego/service/domain/rooms/server"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoomServerRegisterAliasHandler_RegistersAndRevokesAliases(t *testing.T) {
+	ts := newRoomServerAliasTestSetup(t)
+
+	user := fixtures.SomePrivateIdentity()
+	alias := fixtures.SomeAlias()
+
+	err := ts.Members.Add(refs.MustNewIdentityFromPublic(user.Public()))
+	require.NoError(t, err)
+
+	url, err := ts.Register.Handle(commands.MustNewRoomServerRegisterAlias(
+		user.Public(),
+		alias,
+		ts.signature(t, user, alias),
+	))
+	require.NoError(t, err)
+	require.Equal(t, "https://"+alias.String()+".room.example.com", url.String())
+
+	registration, err := ts.Aliases.Get(alias)
+	require.NoError(t, err)
+	require.Equal(t, refs.MustNewIdentityFromPublic(user.Public()), registration.User())
+
+	err = ts.Revoke.Handle(commands.MustNewRoomServerRevokeAlias(user.Public(), alias))
+	require.NoError(t, err)
+
+	_, err = ts.Aliases.Get(alias)
+	require.ErrorIs(t, err, common.ErrRoomAliasNotFound)
+}
+
+func TestRoomServerRegisterAliasHandler_AliasesCanNotBeTakenOverByOtherUsers(t *testing.T) {
+	ts := newRoomServerAliasTestSetup(t)
+
+	user := fixtures.SomePrivateIdentity()
+	otherUser := fixtures.SomePrivateIdentity()
+	alias := fixtures.SomeAlias()
+
+	for _, u := range []identity.Private{user, otherUser} {
+		err := ts.Members.Add(refs.MustNewIdentityFromPublic(u.Public()))
+		require.NoError(t, err)
+	}
+
+	_, err := ts.Register.Handle(commands.MustNewRoomServerRegisterAlias(user.Public(), alias, ts.signature(t, user, alias)))
+	require.NoError(t, err)
+
+	_, err = ts.Register.Handle(commands.MustNewRoomServerRegisterAlias(user.Public(), alias, ts.signature(t, user, alias)))
+	require.NoError(t, err, "registering the same alias again should be possible")
+
+	_, err = ts.Register.Handle(commands.MustNewRoomServerRegisterAlias(otherUser.Public(), alias, ts.signature(t, otherUser, alias)))
+	require.ErrorIs(t, err, commands.ErrRoomAliasAlreadyTaken)
+
+	err = ts.Revoke.Handle(commands.MustNewRoomServerRevokeAlias(otherUser.Public(), alias))
+	require.ErrorIs(t, err, commands.ErrRoomAliasRegisteredByDifferentUser)
+}
+
+func TestRoomServerRegisterAliasHandler_OnlyMembersCanRegisterAliases(t *testing.T) {
+	ts := newRoomServerAliasTestSetup(t)
+
+	user := fixtures.SomePrivateIdentity()
+	alias := fixtures.SomeAlias()
+
+	_, err := ts.Register.Handle(commands.MustNewRoomServerRegisterAlias(user.Public(), alias, ts.signature(t, user, alias)))
+	require.ErrorIs(t, err, server.ErrNotAMember)
+}
+
+type roomServerAliasTestSetup struct {
+	Register *commands.RoomServerRegisterAliasHandler
+	Revoke   *commands.RoomServerRevokeAliasHandler
+	Members  *mocks.RoomMemberRepositoryMock
+	Aliases  *mocks.RoomAliasRepositoryMock
+
+	room refs.Identity
+}
+
+func newRoomServerAliasTestSetup(t *testing.T) roomServerAliasTestSetup {
+	return newRoomServerAliasTestSetupWithMode(t, server.PrivacyModeCommunity)
+}
+
+func newRoomServerAliasTestSetupWithMode(t *testing.T, mode server.PrivacyMode) roomServerAliasTestSetup {
+	local := fixtures.SomePublicIdentity()
+
+	members := mocks.NewRoomMemberRepositoryMock()
+	aliasRepository := mocks.NewRoomAliasRepositoryMock()
+
+	transaction := mocks.NewMockCommandsTransactionProvider(commands.Adapters{
+		RoomMember: members,
+		RoomAlias:  aliasRepository,
+	})
+
+	room := server.NewRoom(
+		local,
+		server.Config{
+			PrivacyMode: mode,
+			AliasDomain: "room.example.com",
+		},
+		fixtures.TestLogger(t),
+	)
+
+	return roomServerAliasTestSetup{
+		Register: commands.NewRoomServerRegisterAliasHandler(transaction, room),
+		Revoke:   commands.NewRoomServerRevokeAliasHandler(transaction, room),
+		Members:  members,
+		Aliases:  aliasRepository,
+
+		room: refs.MustNewIdentityFromPublic(local),
+	}
+}
+
+func (ts roomServerAliasTestSetup) signature(t *testing.T, user identity.Private, alias aliases.Alias) aliases.RegistrationSignature {
+	msg, err := aliases.NewRegistrationMessage(alias, refs.MustNewIdentityFromPublic(user.Public()), ts.room)
+	require.NoError(t, err)
+
+	signature, err := aliases.NewRegistrationSignature(msg, user)
+	require.NoError(t, err)
+
+	return signature
+}
@@ -0,0 +1,108 @@
+package commands
+
+import (
+	"github.com/boreq/errors"
+	"github.com/planetary-social/scuttlego/service/domain/identity"
+	"github.com/planetary-social/scuttlego/service/domain/refs"
+	"github.com/planetary-social/scuttlego/service/domain/rooms/aliases"
+)
+
+var ErrRoomAliasRegisteredByDifferentUser = errors.New("alias was registered by a different user")
+
+type RoomServerRevokeAlias struct {
+	user  identity.Public
+	alias aliases.Alias
+}
+
+func NewRoomServerRevokeAlias(user identity.Public, alias aliases.Alias) (RoomServerRevokeAlias, error) {
+	if user.IsZero() {
+		return RoomServerRevokeAlias{}, errors.New("zero value of user")
+	}
+
+	if alias.IsZero() {
+		return RoomServerRevokeAlias{}, errors.New("zero value of alias")
+	}
+
+	return RoomServerRevokeAlias{
+		user:  user,
+		alias: alias,
+	}, nil
+}
+
+func MustNewRoomServerRevokeAlias(user identity.Public, alias aliases.Alias) RoomServerRevokeAlias {
+	v, err := NewRoomServerRevokeAlias(user, alias)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+func (c RoomServerRevokeAlias) User() identity.Public {
+	return c.user
+}
+
+func (c RoomServerRevokeAlias) Alias() aliases.Alias {
+	return c.alias
+}
+
+func (c RoomServerRevokeAlias) IsZero() bool {
+	return c.user.IsZero()
+}
+
+type RoomServerRevokeAliasHandler struct {
+	transaction TransactionProvider
+	roomServer  RoomServer
+}
+
+func NewRoomServerRevokeAliasHandler(
+	transaction TransactionProvider,
+	roomServer RoomServer,
+) *RoomServerRevokeAliasHandler {
+	return &RoomServerRevokeAliasHandler{
+		transaction: transaction,
+		roomServer:  roomServer,
+	}
+}
+
+// Handle returns common.ErrRoomAliasNotFound if the alias isn't registered and
+// ErrRoomAliasRegisteredByDifferentUser if the alias belongs to someone else.
+func (h *RoomServerRevokeAliasHandler) Handle(cmd RoomServerRevokeAlias) error {
+	if cmd.IsZero() {
+		return errors.New("zero value of command")
+	}
+
+	user, err := refs.NewIdentityFromPublic(cmd.user)
+	if err != nil {
+		return errors.Wrap(err, "error creating the user ref")
+	}
+
+	if err := h.transaction.Transact(func(adapters Adapters) error {
+		member, err := isRoomMember(adapters, cmd.user)
+		if err != nil {
+			return errors.Wrap(err, "error checking membership")
+		}
+
+		if err := h.roomServer.CanManageAliases(member); err != nil {
+			return errors.Wrap(err, "access denied")
+		}
+
+		existing, err := adapters.RoomAlias.Get(cmd.alias)
+		if err != nil {
+			return errors.Wrap(err, "error getting the alias")
+		}
+
+		if !existing.User().Equal(user) {
+			return ErrRoomAliasRegisteredByDifferentUser
+		}
+
+		if err := adapters.RoomAlias.Delete(cmd.alias); err != nil {
+			return errors.Wrap(err, "error deleting the alias")
+		}
+
+		return nil
+	}); err != nil {
+		return errors.Wrap(err, "transaction failed")
+	}
+
+	return nil
+}
@@ -0,0 +1,127 @@
+package commands_test
+
+import (
+	"testing"
+
+	"github.com/planetary-social/scuttlego/internal/fixtures"
+	"github.com/planetary-social/scuttlego/service/app/commands"
+	"github.com/planetary-social/scuttlego/service/app/common"
+	"github.com/planetary-social/scuttlego/service/domain/refs"
+	"github.com/planetary-social/scuttlego/service/domain/rooms/aliases"
+	"github.com/planetary-social/scuttlego/service/domain/rooms/server"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoomServerRevokeAliasHandler(t *testing.T) {
+	testCases := []struct {
+		Name string
+
+		Mode            server.PrivacyMode
+		AliasRegistered bool
+		RevokedByOwner  bool
+		RevokerIsMember bool
+
+		ExpectedError error
+	}{
+		{
+			Name:            "owner",
+			Mode:            server.PrivacyModeCommunity,
+			AliasRegistered: true,
+			RevokedByOwner:  true,
+			RevokerIsMember: true,
+			ExpectedError:   nil,
+		},
+		{
+			Name:            "owner_in_open_room",
+			Mode:            server.PrivacyModeOpen,
+			AliasRegistered: true,
+			RevokedByOwner:  true,
+			RevokerIsMember: true,
+			ExpectedError:   nil,
+		},
+		{
+			Name:            "owner_which_is_no_longer_a_member",
+			Mode:            server.PrivacyModeCommunity,
+			AliasRegistered: true,
+			RevokedByOwner:  true,
+			RevokerIsMember: false,
+			ExpectedError:   server.ErrNotAMember,
+		},
+		{
+			Name:            "other_member",
+			Mode:            server.PrivacyModeCommunity,
+			AliasRegistered: true,
+			RevokedByOwner:  false,
+			RevokerIsMember: true,
+			ExpectedError:   commands.ErrRoomAliasRegisteredByDifferentUser,
+		},
+		{
+			Name:            "non_member",
+			Mode:            server.PrivacyModeCommunity,
+			AliasRegistered: true,
+			RevokedByOwner:  false,
+			RevokerIsMember: false,
+			ExpectedError:   server.ErrNotAMember,
+		},
+		{
+			Name:            "alias_not_registered",
+			Mode:            server.PrivacyModeCommunity,
+			AliasRegistered: false,
+			RevokedByOwner:  true,
+			RevokerIsMember: true,
+			ExpectedError:   common.ErrRoomAliasNotFound,
+		},
+		{
+			Name:            "restricted_room",
+			Mode:            server.PrivacyModeRestricted,
+			AliasRegistered: true,
+			RevokedByOwner:  true,
+			RevokerIsMember: true,
+			ExpectedError:   server.ErrAliasesNotSupported,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			ts := newRoomServerAliasTestSetupWithMode(t, testCase.Mode)
+
+			owner := fixtures.SomePrivateIdentity()
+			otherUser := fixtures.SomePrivateIdentity()
+			alias := fixtures.SomeAlias()
+
+			if testCase.AliasRegistered {
+				msg, err := aliases.NewRegistrationMessage(alias, refs.MustNewIdentityFromPublic(owner.Public()), ts.room)
+				require.NoError(t, err)
+
+				err = ts.Aliases.Put(aliases.MustNewRegistration(msg, ts.signature(t, owner, alias)))
+				require.NoError(t, err)
+			}
+
+			revoker := otherUser
+			if testCase.RevokedByOwner {
+				revoker = owner
+			}
+
+			if testCase.RevokerIsMember {
+				err := ts.Members.Add(refs.MustNewIdentityFromPublic(revoker.Public()))
+				require.NoError(t, err)
+			}
+
+			err := ts.Revoke.Handle(commands.MustNewRoomServerRevokeAlias(revoker.Public(), alias))
+			if testCase.ExpectedError != nil {
+				require.ErrorIs(t, err, testCase.ExpectedError)
+
+				if testCase.AliasRegistered {
+					registration, err := ts.Aliases.Get(alias)
+					require.NoError(t, err, "alias should still be registered")
+					require.Equal(t, refs.MustNewIdentityFromPublic(owner.Public()), registration.User())
+				}
+				return
+			}
+			require.NoError(t, err)
+
+			_, err = ts.Aliases.Get(alias)
+			require.ErrorIs(t, err, common.ErrRoomAliasNotFound)
+		})
+	}
+}
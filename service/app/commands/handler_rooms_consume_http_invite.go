@@ -0,0 +1,68 @@
+package commands
+
+import (
+	"context"
+
+	"github.com/boreq/errors"
+	"github.com/planetary-social/scuttlego/service/domain/identity"
+	"github.com/planetary-social/scuttlego/service/domain/network"
+	"github.com/planetary-social/scuttlego/service/domain/refs"
+	"github.com/planetary-social/scuttlego/service/domain/rooms"
+)
+
+type RoomsConsumeHttpInvite struct {
+	invite rooms.HTTPInvite
+}
+
+func NewRoomsConsumeHttpInvite(invite rooms.HTTPInvite) (RoomsConsumeHttpInvite, error) {
+	if invite.IsZero() {
+		return RoomsConsumeHttpInvite{}, errors.New("zero value of invite")
+	}
+
+	return RoomsConsumeHttpInvite{
+		invite: invite,
+	}, nil
+}
+
+func (r RoomsConsumeHttpInvite) Invite() rooms.HTTPInvite {
+	return r.invite
+}
+
+func (r RoomsConsumeHttpInvite) IsZero() bool {
+	return r.invite.IsZero()
+}
+
+type RoomsConsumeHttpInviteHandler struct {
+	client RoomHTTPClient
+	local  identity.Private
+}
+
+func NewRoomsConsumeHttpInviteHandler(
+	client RoomHTTPClient,
+	local identity.Private,
+) *RoomsConsumeHttpInviteHandler {
+	return &RoomsConsumeHttpInviteHandler{
+		client: client,
+		local:  local,
+	}
+}
+
+// Handle makes the local identity a member of the room and returns the address
+// of that room.
+func (h *RoomsConsumeHttpInviteHandler) Handle(ctx context.Context, cmd RoomsConsumeHttpInvite) (network.MultiserverAddress, error) {
+	if cmd.IsZero() {
+		return network.MultiserverAddress{}, errors.New("zero value of command")
+	}
+
+	user, err := refs.NewIdentityFromPublic(h.local.Public())
+	if err != nil {
+		return network.MultiserverAddress{}, errors.Wrap(err, "failed to create user ref")
+	}
+
+	address, err := h.client.ConsumeInvite(ctx, cmd.Invite(), user)
+	if err != nil {
+		return network.MultiserverAddress{}, errors.Wrap(err, "error consuming the invite")
+	}
+
+	return address, nil
+}
@@ -0,0 +1,96 @@
+package commands_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/planetary-social/scuttlego/internal/fixtures"
+	"github.com/planetary-social/scuttlego/service/app/commands"
+	"github.com/planetary-social/scuttlego/service/domain/network"
+	"github.com/planetary-social/scuttlego/service/domain/refs"
+	"github.com/planetary-social/scuttlego/service/domain/rooms"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoomsConsumeHttpInviteHandler(t *testing.T) {
+	local := fixtures.SomePrivateIdentity()
+	room := fixtures.SomeRefIdentity()
+	token := fixtures.SomeString()
+	address := "net:room.example.com:8008~shs:" + strings.TrimSuffix(strings.TrimPrefix(room.String(), "@"), ".ed25519")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/invite/consume" {
+			http.NotFound(w, r)
+			return
+		}
+
+		var request map[string]string
+		if err := jsoniter.NewDecoder(r.Body).Decode(&request); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+
+		if request["invite"] != token || request["id"] != refs.MustNewIdentityFromPublic(local.Public()).String() {
+			w.WriteHeader(http.StatusBadRequest)
+			_ = jsoniter.NewEncoder(w).Encode(map[string]string{
+				"status": "failed",
+				"error":  "invalid invite",
+			})
+			return
+		}
+
+		_ = jsoniter.NewEncoder(w).Encode(map[string]string{
+			"status":             "successful",
+			"multiserverAddress": address,
+		})
+	}))
+	defer server.Close()
+
+	notARoom := httptest.NewServer(http.NotFoundHandler())
+	defer notARoom.Close()
+
+	testCases := []struct {
+		Name            string
+		Invite          string
+		ExpectedAddress string
+		ExpectedError   string
+	}{
+		{
+			Name:            "valid",
+			Invite:          server.URL + "/join?invite=" + token,
+			ExpectedAddress: address,
+		},
+		{
+			Name:          "invalid_token",
+			Invite:        server.URL + "/join?invite=" + fixtures.SomeString(),
+			ExpectedError: "invalid invite",
+		},
+		{
+			Name:          "not_a_room",
+			Invite:        notARoom.URL + "/join?invite=" + token,
+			ExpectedError: "json unmarshal failed (status code: 404)",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			handler := commands.NewRoomsConsumeHttpInviteHandler(rooms.NewHTTPClient(), local)
+
+			cmd, err := commands.NewRoomsConsumeHttpInvite(rooms.MustNewHTTPInvite(testCase.Invite))
+			require.NoError(t, err)
+
+			result, err := handler.Handle(fixtures.TestContext(t), cmd)
+			if testCase.ExpectedError != "" {
+				require.ErrorContains(t, err, testCase.ExpectedError)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, network.MustNewMultiserverAddress(testCase.ExpectedAddress), result)
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package commands
+
+import (
+	"github.com/boreq/errors"
+	"github.com/planetary-social/scuttlego/service/domain/refs"
+	"github.com/planetary-social/scuttlego/service/domain/rooms/httpauth"
+)
+
+type RoomsHttpAuthCreateClientChallenge struct {
+	room refs.Identity
+}
+
+func NewRoomsHttpAuthCreateClientChallenge(room refs.Identity) (RoomsHttpAuthCreateClientChallenge, error) {
+	if room.IsZero() {
+		return RoomsHttpAuthCreateClientChallenge{}, errors.New("zero value of room")
+	}
+
+	return RoomsHttpAuthCreateClientChallenge{
+		room: room,
+	}, nil
+}
+
+func MustNewRoomsHttpAuthCreateClientChallenge(room refs.Identity) RoomsHttpAuthCreateClientChallenge {
+	v, err := NewRoomsHttpAuthCreateClientChallenge(room)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+func (r RoomsHttpAuthCreateClientChallenge) Room() refs.Identity {
+	return r.room
+}
+
+func (r RoomsHttpAuthCreateClientChallenge) IsZero() bool {
+	return r.room.IsZero()
+}
+
+// RoomsHttpAuthCreateClientChallengeHandler creates a client challenge used
+// to sign in to the room's website using the client-initiated flow. The room
+// will request a solution for this challenge using httpAuth.requestSolution
+// after the user opens the sign-in page of the room with the challenge. The
+// challenge can be used only once and only by the room for which it was
+// created.
+type RoomsHttpAuthCreateClientChallengeHandler struct {
+	repository          HttpAuthClientChallengeRepository
+	currentTimeProvider CurrentTimeProvider
+}
+
+func NewRoomsHttpAuthCreateClientChallengeHandler(
+	repository HttpAuthClientChallengeRepository,
+	currentTimeProvider CurrentTimeProvider,
+) *RoomsHttpAuthCreateClientChallengeHandler {
+	return &RoomsHttpAuthCreateClientChallengeHandler{
+		repository:          repository,
+		currentTimeProvider: currentTimeProvider,
+	}
+}
+
+func (h *RoomsHttpAuthCreateClientChallengeHandler) Handle(cmd RoomsHttpAuthCreateClientChallenge) (httpauth.Challenge, error) {
+	if cmd.IsZero() {
+		return httpauth.Challenge{}, errors.New("zero value of command")
+	}
+
+	cc, err := httpauth.NewChallenge()
+	if err != nil {
+		return httpauth.Challenge{}, errors.Wrap(err, "error creating the challenge")
+	}
+
+	challenge, err := httpauth.NewClientChallenge(cmd.Room(), cc, h.currentTimeProvider.Get())
+	if err != nil {
+		return httpauth.Challenge{}, errors.Wrap(err, "error creating the client challenge")
+	}
+
+	if err := h.repository.Put(challenge); err != nil {
+		return httpauth.Challenge{}, errors.Wrap(err, "error storing the client challenge")
+	}
+
+	return cc, nil
+}
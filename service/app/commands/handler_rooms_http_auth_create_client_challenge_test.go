@@ -0,0 +1,105 @@
+package commands_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/planetary-social/scuttlego/internal/fixtures"
+	"github.com/planetary-social/scuttlego/internal/mocks"
+	"github.com/planetary-social/scuttlego/service/adapters"
+	"github.com/planetary-social/scuttlego/service/app/commands"
+	"github.com/planetary-social/scuttlego/service/di"
+	"github.com/planetary-social/scuttlego/service/domain/refs"
+	"github.com/planetary-social/scuttlego/service/domain/rooms/httpauth"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoomsHttpAuthCreateClientChallengeHandler_StoresChallengesForTheRoom(t *testing.T) {
+	repository := adapters.NewHttpAuthClientChallengeRepository()
+	currentTimeProvider := mocks.NewCurrentTimeProviderMock()
+	currentTimeProvider.CurrentTime = fixtures.SomeTime()
+
+	handler := commands.NewRoomsHttpAuthCreateClientChallengeHandler(repository, currentTimeProvider)
+
+	room := fixtures.SomeRefIdentity()
+
+	cc1, err := handler.Handle(commands.MustNewRoomsHttpAuthCreateClientChallenge(room))
+	require.NoError(t, err)
+
+	cc2, err := handler.Handle(commands.MustNewRoomsHttpAuthCreateClientChallenge(room))
+	require.NoError(t, err)
+
+	require.NotEqual(t, cc1, cc2)
+
+	for _, cc := range []httpauth.Challenge{cc1, cc2} {
+		challenge, err := repository.Pop(cc)
+		require.NoError(t, err)
+		require.Equal(t, room, challenge.Room())
+		require.Equal(t, cc, challenge.Challenge())
+		require.Equal(t, currentTimeProvider.CurrentTime, challenge.CreatedAt())
+	}
+}
+
+func TestRoomsHttpAuthCreateClientChallengeHandler_ChallengeCanBeUsedToSignInToTheRoom(t *testing.T) {
+	tc, err := di.BuildTestCommands(t)
+	require.NoError(t, err)
+
+	room := fixtures.SomeRefIdentity()
+	local := refs.MustNewIdentityFromPublic(tc.Local)
+
+	// The room serves the sign-in page and requests a solution for the client
+	// challenge which in reality happens over httpAuth.requestSolution.
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+
+		if r.URL.Path != "/login" || query.Get("ssb-http-auth") != "1" || query.Get("cid") != local.String() {
+			http.NotFound(w, r)
+			return
+		}
+
+		cc, err := httpauth.NewChallengeFromString(query.Get("cc"))
+		if err != nil {
+			http.Error(w, "invalid cc", http.StatusBadRequest)
+			return
+		}
+
+		sc := httpauth.MustNewChallenge()
+
+		solution, err := tc.RoomsHttpAuthSolveChallenge.Handle(commands.MustNewRoomsHttpAuthSolveChallenge(room, sc, cc))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
+
+		msg, err := httpauth.NewSignInMessage(room, local, sc, cc)
+		if err != nil || !solution.Verify(msg) {
+			http.Error(w, "invalid solution", http.StatusForbidden)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	signIn := func(cc httpauth.Challenge) int {
+		query := url.Values{}
+		query.Set("ssb-http-auth", "1")
+		query.Set("cid", local.String())
+		query.Set("cc", cc.String())
+
+		resp, err := http.Get(server.URL + "/login?" + query.Encode())
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		return resp.StatusCode
+	}
+
+	cc, err := tc.RoomsHttpAuthCreateClientChallenge.Handle(commands.MustNewRoomsHttpAuthCreateClientChallenge(room))
+	require.NoError(t, err)
+
+	require.Equal(t, http.StatusOK, signIn(cc))
+	require.Equal(t, http.StatusForbidden, signIn(cc), "challenges can be used only once")
+	require.Equal(t, http.StatusForbidden, signIn(httpauth.MustNewChallenge()), "unknown challenges can't be used")
+}
@@ -0,0 +1,127 @@
+package commands
+
+import (
+	"context"
+	"time"
+
+	"github.com/boreq/errors"
+	"github.com/planetary-social/scuttlego/service/domain/identity"
+	"github.com/planetary-social/scuttlego/service/domain/messages"
+	"github.com/planetary-social/scuttlego/service/domain/network"
+	"github.com/planetary-social/scuttlego/service/domain/refs"
+	"github.com/planetary-social/scuttlego/service/domain/rooms/httpauth"
+)
+
+type RoomsHttpAuthSignIn struct {
+	room    refs.Identity
+	address network.Address
+	sc      httpauth.Challenge
+}
+
+func NewRoomsHttpAuthSignIn(
+	room refs.Identity,
+	address network.Address,
+	sc httpauth.Challenge,
+) (RoomsHttpAuthSignIn, error) {
+	if room.IsZero() {
+		return RoomsHttpAuthSignIn{}, errors.New("zero value of room")
+	}
+
+	if address.IsZero() {
+		return RoomsHttpAuthSignIn{}, errors.New("zero value of address")
+	}
+
+	if sc.IsZero() {
+		return RoomsHttpAuthSignIn{}, errors.New("zero value of server challenge")
+	}
+
+	return RoomsHttpAuthSignIn{
+		room:    room,
+		address: address,
+		sc:      sc,
+	}, nil
+}
+
+func (r RoomsHttpAuthSignIn) Room() refs.Identity {
+	return r.room
+}
+
+func (r RoomsHttpAuthSignIn) Address() network.Address {
+	return r.address
+}
+
+func (r RoomsHttpAuthSignIn) Sc() httpauth.Challenge {
+	return r.sc
+}
+
+func (r RoomsHttpAuthSignIn) IsZero() bool {
+	return r.room.IsZero()
+}
+
+// RoomsHttpAuthSignInHandler signs in to the room's website using the
+// server-initiated flow. The server challenge is usually obtained by
+// scanning a QR code or following a link displayed by the room.
+type RoomsHttpAuthSignInHandler struct {
+	dialer Dialer
+	local  identity.Private
+}
+
+func NewRoomsHttpAuthSignInHandler(
+	dialer Dialer,
+	local identity.Private,
+) *RoomsHttpAuthSignInHandler {
+	return &RoomsHttpAuthSignInHandler{
+		dialer: dialer,
+		local:  local,
+	}
+}
+
+func (h *RoomsHttpAuthSignInHandler) Handle(ctx context.Context, cmd RoomsHttpAuthSignIn) error {
+	if cmd.IsZero() {
+		return errors.New("zero value of command")
+	}
+
+	cc, err := httpauth.NewChallenge()
+	if err != nil {
+		return errors.Wrap(err, "error creating the client challenge")
+	}
+
+	solution, err := solveHttpAuthChallenge(h.local, cmd.Room(), cmd.Sc(), cc)
+	if err != nil {
+		return errors.Wrap(err, "error solving the challenge")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	peer, err := h.dialer.Dial(ctx, cmd.Room().Identity(), cmd.Address())
+	if err != nil {
+		return errors.Wrap(err, "dial error")
+	}
+
+	args, err := messages.NewHttpAuthSendSolutionArguments(cmd.Sc(), cc, solution)
+	if err != nil {
+		return errors.Wrap(err, "could not create args")
+	}
+
+	req, err := messages.NewHttpAuthSendSolution(args)
+	if err != nil {
+		return errors.Wrap(err, "could not create the request")
+	}
+
+	rs, err := peer.Conn().PerformRequest(ctx, req)
+	if err != nil {
+		return errors.Wrap(err, "failed to perform a request")
+	}
+
+	response, ok := <-rs.Channel()
+	if !ok {
+		return errors.New("channel closed")
+	}
+
+	if err := response.Err; err != nil {
+		return errors.Wrap(err, "received an error")
+	}
+
+	return nil
+}
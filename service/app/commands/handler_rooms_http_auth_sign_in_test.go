@@ -0,0 +1,134 @@
+package commands_test
+
+import (
+	"testing"
+
+	"github.com/boreq/errors"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/planetary-social/scuttlego/internal/fixtures"
+	"github.com/planetary-social/scuttlego/internal/mocks"
+	"github.com/planetary-social/scuttlego/service/app/commands"
+	"github.com/planetary-social/scuttlego/service/domain/identity"
+	"github.com/planetary-social/scuttlego/service/domain/messages"
+	"github.com/planetary-social/scuttlego/service/domain/network"
+	"github.com/planetary-social/scuttlego/service/domain/refs"
+	"github.com/planetary-social/scuttlego/service/domain/rooms/httpauth"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoomsHttpAuthSignInHandler(t *testing.T) {
+	testCases := []struct {
+		Name          string
+		RoomError     error
+		ExpectedError string
+	}{
+		{
+			Name:          "room_accepts_the_solution",
+			RoomError:     nil,
+			ExpectedError: "",
+		},
+		{
+			Name:          "room_rejects_the_solution",
+			RoomError:     errors.New("invalid solution"),
+			ExpectedError: "received an error: invalid solution",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			ctx := fixtures.TestContext(t)
+
+			local := fixtures.SomePrivateIdentity()
+			room := fixtures.SomeRefIdentity()
+			address := network.NewAddress(fixtures.SomeString())
+			sc := httpauth.MustNewChallenge()
+
+			dialer := mocks.NewDialerMock()
+			connection := mocks.NewConnectionMock(ctx)
+			connection.Mock(newHttpAuthRoomStandIn(room, local.Public(), sc, testCase.RoomError))
+			dialer.MockPeer(room.Identity(), address, connection)
+
+			handler := commands.NewRoomsHttpAuthSignInHandler(dialer, local)
+
+			cmd, err := commands.NewRoomsHttpAuthSignIn(room, address, sc)
+			require.NoError(t, err)
+
+			err = handler.Handle(ctx, cmd)
+			if testCase.ExpectedError != "" {
+				require.EqualError(t, err, testCase.ExpectedError)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestRoomsHttpAuthSignInHandler_ReturnsAnErrorIfRoomCanNotBeDialed(t *testing.T) {
+	ctx := fixtures.TestContext(t)
+
+	handler := commands.NewRoomsHttpAuthSignInHandler(mocks.NewDialerMock(), fixtures.SomePrivateIdentity())
+
+	cmd, err := commands.NewRoomsHttpAuthSignIn(fixtures.SomeRefIdentity(), network.NewAddress(fixtures.SomeString()), httpauth.MustNewChallenge())
+	require.NoError(t, err)
+
+	err = handler.Handle(ctx, cmd)
+	require.ErrorContains(t, err, "dial error")
+}
+
+// newHttpAuthRoomStandIn returns a function which acts like a room receiving
+// httpAuth.sendSolution. The room responds with an error unless the solution
+// was signed by the client for the server challenge issued by the room.
+func newHttpAuthRoomStandIn(room refs.Identity, client identity.Public, sc httpauth.Challenge, roomErr error) func(req *rpc.Request) []rpc.ResponseWithError {
+	return func(req *rpc.Request) []rpc.ResponseWithError {
+		if err := verifyHttpAuthSendSolution(req, room, client, sc); err != nil {
+			return []rpc.ResponseWithError{{Err: err}}
+		}
+
+		if roomErr != nil {
+			return []rpc.ResponseWithError{{Err: roomErr}}
+		}
+
+		return []rpc.ResponseWithError{{Value: rpc.NewResponse([]byte("true"))}}
+	}
+}
+
+func verifyHttpAuthSendSolution(req *rpc.Request, room refs.Identity, client identity.Public, sc httpauth.Challenge) error {
+	if req.Name().String() != messages.HttpAuthSendSolutionProcedure.Name().String() {
+		return errors.New("unexpected procedure")
+	}
+
+	var args []string
+	if err := jsoniter.Unmarshal(req.Arguments(), &args); err != nil {
+		return errors.Wrap(err, "invalid arguments")
+	}
+
+	if len(args) != 3 {
+		return errors.New("expected three arguments")
+	}
+
+	if args[0] != sc.String() {
+		return errors.New("unexpected server challenge")
+	}
+
+	cc, err := httpauth.NewChallengeFromString(args[1])
+	if err != nil {
+		return errors.Wrap(err, "invalid client challenge")
+	}
+
+	solution, err := httpauth.NewSolutionFromString(args[2])
+	if err != nil {
+		return errors.Wrap(err, "invalid solution")
+	}
+
+	msg, err := httpauth.NewSignInMessage(room, refs.MustNewIdentityFromPublic(client), sc, cc)
+	if err != nil {
+		return errors.Wrap(err, "error creating the message")
+	}
+
+	if !solution.Verify(msg) {
+		return errors.New("solution is not valid")
+	}
+
+	return nil
+}
@@ -0,0 +1,127 @@
+package commands
+
+import (
+	"github.com/boreq/errors"
+	"github.com/planetary-social/scuttlego/service/domain/identity"
+	"github.com/planetary-social/scuttlego/service/domain/refs"
+	"github.com/planetary-social/scuttlego/service/domain/rooms/httpauth"
+)
+
+type RoomsHttpAuthSolveChallenge struct {
+	room refs.Identity
+	sc   httpauth.Challenge
+	cc   httpauth.Challenge
+}
+
+func NewRoomsHttpAuthSolveChallenge(
+	room refs.Identity,
+	sc httpauth.Challenge,
+	cc httpauth.Challenge,
+) (RoomsHttpAuthSolveChallenge, error) {
+	if room.IsZero() {
+		return RoomsHttpAuthSolveChallenge{}, errors.New("zero value of room")
+	}
+
+	if sc.IsZero() {
+		return RoomsHttpAuthSolveChallenge{}, errors.New("zero value of server challenge")
+	}
+
+	if cc.IsZero() {
+		return RoomsHttpAuthSolveChallenge{}, errors.New("zero value of client challenge")
+	}
+
+	return RoomsHttpAuthSolveChallenge{
+		room: room,
+		sc:   sc,
+		cc:   cc,
+	}, nil
+}
+
+func MustNewRoomsHttpAuthSolveChallenge(
+	room refs.Identity,
+	sc httpauth.Challenge,
+	cc httpauth.Challenge,
+) RoomsHttpAuthSolveChallenge {
+	v, err := NewRoomsHttpAuthSolveChallenge(room, sc, cc)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+func (r RoomsHttpAuthSolveChallenge) Room() refs.Identity {
+	return r.room
+}
+
+func (r RoomsHttpAuthSolveChallenge) Sc() httpauth.Challenge {
+	return r.sc
+}
+
+func (r RoomsHttpAuthSolveChallenge) Cc() httpauth.Challenge {
+	return r.cc
+}
+
+func (r RoomsHttpAuthSolveChallenge) IsZero() bool {
+	return r.room.IsZero()
+}
+
+// RoomsHttpAuthSolveChallengeHandler answers challenges sent by rooms when
+// the user is signing in to the room's website using the client-initiated
+// flow. Only client challenges created by
+// RoomsHttpAuthCreateClientChallengeHandler for the requesting room are
+// answered, otherwise any room could sign in as this user.
+type RoomsHttpAuthSolveChallengeHandler struct {
+	repository          HttpAuthClientChallengeRepository
+	currentTimeProvider CurrentTimeProvider
+	local               identity.Private
+}
+
+func NewRoomsHttpAuthSolveChallengeHandler(
+	repository HttpAuthClientChallengeRepository,
+	currentTimeProvider CurrentTimeProvider,
+	local identity.Private,
+) *RoomsHttpAuthSolveChallengeHandler {
+	return &RoomsHttpAuthSolveChallengeHandler{
+		repository:          repository,
+		currentTimeProvider: currentTimeProvider,
+		local:               local,
+	}
+}
+
+// Handle returns ErrClientChallengeNotFound if the client challenge is
+// unknown or was already used.
+func (h *RoomsHttpAuthSolveChallengeHandler) Handle(cmd RoomsHttpAuthSolveChallenge) (httpauth.Solution, error) {
+	if cmd.IsZero() {
+		return httpauth.Solution{}, errors.New("zero value of command")
+	}
+
+	challenge, err := h.repository.Pop(cmd.Cc())
+	if err != nil {
+		return httpauth.Solution{}, errors.Wrap(err, "error getting the client challenge")
+	}
+
+	if err := challenge.CheckCanBeUsed(cmd.Room(), h.currentTimeProvider.Get()); err != nil {
+		return httpauth.Solution{}, errors.Wrap(err, "client challenge can't be used")
+	}
+
+	return solveHttpAuthChallenge(h.local, cmd.Room(), cmd.Sc(), cmd.Cc())
+}
+
+func solveHttpAuthChallenge(local identity.Private, room refs.Identity, sc, cc httpauth.Challenge) (httpauth.Solution, error) {
+	user, err := refs.NewIdentityFromPublic(local.Public())
+	if err != nil {
+		return httpauth.Solution{}, errors.Wrap(err, "failed to create user ref")
+	}
+
+	msg, err := httpauth.NewSignInMessage(room, user, sc, cc)
+	if err != nil {
+		return httpauth.Solution{}, errors.Wrap(err, "error creating the sign in message")
+	}
+
+	solution, err := httpauth.NewSolution(msg, local)
+	if err != nil {
+		return httpauth.Solution{}, errors.Wrap(err, "error creating the solution")
+	}
+
+	return solution, nil
+}
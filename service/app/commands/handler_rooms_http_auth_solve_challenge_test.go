@@ -0,0 +1,101 @@
+package commands_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/planetary-social/scuttlego/internal/fixtures"
+	"github.com/planetary-social/scuttlego/service/app/commands"
+	"github.com/planetary-social/scuttlego/service/di"
+	"github.com/planetary-social/scuttlego/service/domain/refs"
+	"github.com/planetary-social/scuttlego/service/domain/rooms/httpauth"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoomsHttpAuthSolveChallengeHandler_SolvesChallengesCreatedForTheRoom(t *testing.T) {
+	tc, err := di.BuildTestCommands(t)
+	require.NoError(t, err)
+
+	room := fixtures.SomeRefIdentity()
+	sc := httpauth.MustNewChallenge()
+
+	cc, err := tc.RoomsHttpAuthCreateClientChallenge.Handle(commands.MustNewRoomsHttpAuthCreateClientChallenge(room))
+	require.NoError(t, err)
+
+	solution, err := tc.RoomsHttpAuthSolveChallenge.Handle(commands.MustNewRoomsHttpAuthSolveChallenge(room, sc, cc))
+	require.NoError(t, err)
+
+	msg, err := httpauth.NewSignInMessage(room, refs.MustNewIdentityFromPublic(tc.Local), sc, cc)
+	require.NoError(t, err)
+	require.True(t, solution.Verify(msg))
+}
+
+func TestRoomsHttpAuthSolveChallengeHandler_RejectsChallengesWhichCanNotBeUsed(t *testing.T) {
+	testCases := []struct {
+		Name          string
+		Test          func(t *testing.T, tc di.TestCommands) error
+		ExpectedError error
+	}{
+		{
+			Name: "unknown",
+			Test: func(t *testing.T, tc di.TestCommands) error {
+				cmd := commands.MustNewRoomsHttpAuthSolveChallenge(fixtures.SomeRefIdentity(), httpauth.MustNewChallenge(), httpauth.MustNewChallenge())
+				_, err := tc.RoomsHttpAuthSolveChallenge.Handle(cmd)
+				return err
+			},
+			ExpectedError: commands.ErrClientChallengeNotFound,
+		},
+		{
+			Name: "expired",
+			Test: func(t *testing.T, tc di.TestCommands) error {
+				room := fixtures.SomeRefIdentity()
+
+				tc.CurrentTimeProvider.CurrentTime = fixtures.SomeTime()
+				cc, err := tc.RoomsHttpAuthCreateClientChallenge.Handle(commands.MustNewRoomsHttpAuthCreateClientChallenge(room))
+				require.NoError(t, err)
+
+				tc.CurrentTimeProvider.CurrentTime = tc.CurrentTimeProvider.CurrentTime.Add(httpauth.ClientChallengeTTL + time.Second)
+				_, err = tc.RoomsHttpAuthSolveChallenge.Handle(commands.MustNewRoomsHttpAuthSolveChallenge(room, httpauth.MustNewChallenge(), cc))
+				return err
+			},
+			ExpectedError: httpauth.ErrClientChallengeExpired,
+		},
+		{
+			Name: "already_used",
+			Test: func(t *testing.T, tc di.TestCommands) error {
+				room := fixtures.SomeRefIdentity()
+
+				cc, err := tc.RoomsHttpAuthCreateClientChallenge.Handle(commands.MustNewRoomsHttpAuthCreateClientChallenge(room))
+				require.NoError(t, err)
+
+				_, err = tc.RoomsHttpAuthSolveChallenge.Handle(commands.MustNewRoomsHttpAuthSolveChallenge(room, httpauth.MustNewChallenge(), cc))
+				require.NoError(t, err)
+
+				_, err = tc.RoomsHttpAuthSolveChallenge.Handle(commands.MustNewRoomsHttpAuthSolveChallenge(room, httpauth.MustNewChallenge(), cc))
+				return err
+			},
+			ExpectedError: commands.ErrClientChallengeNotFound,
+		},
+		{
+			Name: "created_for_another_room",
+			Test: func(t *testing.T, tc di.TestCommands) error {
+				cc, err := tc.RoomsHttpAuthCreateClientChallenge.Handle(commands.MustNewRoomsHttpAuthCreateClientChallenge(fixtures.SomeRefIdentity()))
+				require.NoError(t, err)
+
+				_, err = tc.RoomsHttpAuthSolveChallenge.Handle(commands.MustNewRoomsHttpAuthSolveChallenge(fixtures.SomeRefIdentity(), httpauth.MustNewChallenge(), cc))
+				return err
+			},
+			ExpectedError: httpauth.ErrClientChallengeForAnotherRoom,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			tc, err := di.BuildTestCommands(t)
+			require.NoError(t, err)
+
+			err = testCase.Test(t, tc)
+			require.ErrorIs(t, err, testCase.ExpectedError)
+		})
+	}
+}
@@ -11,6 +11,12 @@ type MigrationsRunner interface {
 	Run(ctx context.Context, migrations migrations.Migrations, progressCallback migrations.ProgressCallback) error
 }
 
+type MigrationsProgressPublisher interface {
+	PublishMigrationRunning(migrationIndex int, migrationsCount int)
+	PublishMigrationFailed(migrationIndex int, migrationsCount int, err error)
+	PublishMigrationsDone(migrationsCount int)
+}
+
 type RunMigrations struct {
 	progressCallback migrations.ProgressCallback
 }
@@ -29,15 +35,18 @@ func (cmd RunMigrations) IsZero() bool {
 type RunMigrationsHandler struct {
 	runner     MigrationsRunner
 	migrations migrations.Migrations
+	publisher  MigrationsProgressPublisher
 }
 
 func NewRunMigrationsHandler(
 	runner MigrationsRunner,
 	migrations migrations.Migrations,
+	publisher MigrationsProgressPublisher,
 ) *RunMigrationsHandler {
 	return &RunMigrationsHandler{
 		runner:     runner,
 		migrations: migrations,
+		publisher:  publisher,
 	}
 }
 
@@ -46,5 +55,31 @@ func (h RunMigrationsHandler) Run(ctx context.Context, cmd RunMigrations) error
 		return errors.New("zero value of cmd")
 	}
 
-	return h.runner.Run(ctx, h.migrations, cmd.progressCallback)
+	return h.runner.Run(ctx, h.migrations, newPublishingProgressCallback(cmd.progressCallback, h.publisher))
+}
+
+// publishingProgressCallback passes progress to the callback provided by the
+// caller and publishes it.
+type publishingProgressCallback struct {
+	callback  migrations.ProgressCallback
+	publisher MigrationsProgressPublisher
+}
+
+func newPublishingProgressCallback(callback migrations.ProgressCallback, publisher MigrationsProgressPublisher) publishingProgressCallback {
+	return publishingProgressCallback{callback: callback, publisher: publisher}
+}
+
+func (p publishingProgressCallback) OnRunning(migrationIndex int, migrationsCount int) {
+	p.callback.OnRunning(migrationIndex, migrationsCount)
+	p.publisher.PublishMigrationRunning(migrationIndex, migrationsCount)
+}
+
+func (p publishingProgressCallback) OnError(migrationIndex int, migrationsCount int, err error) {
+	p.callback.OnError(migrationIndex, migrationsCount, err)
+	p.publisher.PublishMigrationFailed(migrationIndex, migrationsCount, err)
+}
+
+func (p publishingProgressCallback) OnDone(migrationsCount int) {
+	p.callback.OnDone(migrationsCount)
+	p.publisher.PublishMigrationsDone(migrationsCount)
 }
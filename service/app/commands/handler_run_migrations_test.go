@@ -0,0 +1,108 @@
+package commands_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/planetary-social/scuttlego/internal/fixtures"
+	"github.com/planetary-social/scuttlego/migrations"
+	"github.com/planetary-social/scuttlego/service/app/commands"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunMigrationsHandler_ProgressIsPassedToCallbackAndPublished(t *testing.T) {
+	runner := newMigrationsRunnerMock()
+	publisher := newMigrationsProgressPublisherMock()
+	handler := commands.NewRunMigrationsHandler(runner, migrations.MustNewMigrations(nil), publisher)
+
+	callback := newMigrationsProgressCallbackMock()
+	cmd, err := commands.NewRunMigrations(callback)
+	require.NoError(t, err)
+
+	migrationErr := fixtures.SomeError()
+	runner.Progress = func(callback migrations.ProgressCallback) {
+		callback.OnRunning(0, 2)
+		callback.OnError(0, 2, migrationErr)
+		callback.OnRunning(1, 2)
+		callback.OnDone(2)
+	}
+
+	ctx := fixtures.TestContext(t)
+	err = handler.Run(ctx, cmd)
+	require.NoError(t, err)
+
+	expectedCalls := []migrationsProgressCall{
+		{Running: true, MigrationIndex: 0, MigrationsCount: 2},
+		{Failed: true, MigrationIndex: 0, MigrationsCount: 2, Err: migrationErr},
+		{Running: true, MigrationIndex: 1, MigrationsCount: 2},
+		{Done: true, MigrationsCount: 2},
+	}
+
+	require.Equal(t, expectedCalls, callback.Calls)
+	require.Equal(t, expectedCalls, publisher.Calls)
+}
+
+type migrationsRunnerMock struct {
+	Progress func(callback migrations.ProgressCallback)
+}
+
+func newMigrationsRunnerMock() *migrationsRunnerMock {
+	return &migrationsRunnerMock{}
+}
+
+func (m *migrationsRunnerMock) Run(ctx context.Context, migrations migrations.Migrations, progressCallback migrations.ProgressCallback) error {
+	if m.Progress != nil {
+		m.Progress(progressCallback)
+	}
+	return nil
+}
+
+type migrationsProgressCall struct {
+	Running bool
+	Failed  bool
+	Done    bool
+
+	MigrationIndex  int
+	MigrationsCount int
+	Err             error
+}
+
+type migrationsProgressCallbackMock struct {
+	Calls []migrationsProgressCall
+}
+
+func newMigrationsProgressCallbackMock() *migrationsProgressCallbackMock {
+	return &migrationsProgressCallbackMock{}
+}
+
+func (m *migrationsProgressCallbackMock) OnRunning(migrationIndex int, migrationsCount int) {
+	m.Calls = append(m.Calls, migrationsProgressCall{Running: true, MigrationIndex: migrationIndex, MigrationsCount: migrationsCount})
+}
+
+func (m *migrationsProgressCallbackMock) OnError(migrationIndex int, migrationsCount int, err error) {
+	m.Calls = append(m.Calls, migrationsProgressCall{Failed: true, MigrationIndex: migrationIndex, MigrationsCount: migrationsCount, Err: err})
+}
+
+func (m *migrationsProgressCallbackMock) OnDone(migrationsCount int) {
+	m.Calls = append(m.Calls, migrationsProgressCall{Done: true, MigrationsCount: migrationsCount})
+}
+
+type migrationsProgressPublisherMock struct {
+	Calls []migrationsProgressCall
+}
+
+func newMigrationsProgressPublisherMock() *migrationsProgressPublisherMock {
+	return &migrationsProgressPublisherMock{}
+}
+
+func (m *migrationsProgressPublisherMock) PublishMigrationRunning(migrationIndex int, migrationsCount int) {
+	m.Calls = append(m.Calls, migrationsProgressCall{Running: true, MigrationIndex: migrationIndex, MigrationsCount: migrationsCount})
+}
+
+func (m *migrationsProgressPublisherMock) PublishMigrationFailed(migrationIndex int, migrationsCount int, err error) {
+	m.Calls = append(m.Calls, migrationsProgressCall{Failed: true, MigrationIndex: migrationIndex, MigrationsCount: migrationsCount, Err: err})
+}
+
+func (m *migrationsProgressPublisherMock) PublishMigrationsDone(migrationsCount int) {
+	m.Calls = append(m.Calls, migrationsProgressCall{Done: true, MigrationsCount: migrationsCount})
+}
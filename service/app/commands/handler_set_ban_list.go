@@ -23,10 +23,11 @@ func NewSetBanList(hashes []bans.Hash) (SetBanList, error) {
 
 type SetBanListHandler struct {
 	transaction TransactionProvider
+	publisher   BanListChangedPublisher
 }
 
-func NewSetBanListHandler(transaction TransactionProvider) *SetBanListHandler {
-	return &SetBanListHandler{transaction: transaction}
+func NewSetBanListHandler(transaction TransactionProvider, publisher BanListChangedPublisher) *SetBanListHandler {
+	return &SetBanListHandler{transaction: transaction, publisher: publisher}
 }
 
 func (h *SetBanListHandler) Handle(cmd SetBanList) error {
@@ -50,6 +51,7 @@ func (h *SetBanListHandler) Handle(cmd SetBanList) error {
 		return errors.Wrap(err, "transaction failed")
 	}
 
+	h.publisher.PublishBanListChanged()
 	return nil
 }
 
@@ -0,0 +1,118 @@
+package commands_test
+
+import (
+	"testing"
+
+	"github.com/planetary-social/scuttlego/internal/fixtures"
+	"github.com/planetary-social/scuttlego/service/app/commands"
+	"github.com/planetary-social/scuttlego/service/domain/bans"
+	"github.com/planetary-social/scuttlego/service/domain/refs"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetBanListHandler_BanListChangedIsPublishedAfterTransaction(t *testing.T) {
+	transaction := newBanListTransactionProviderMock()
+	publisher := newBanListChangedPublisherMock(transaction)
+	handler := commands.NewSetBanListHandler(transaction, publisher)
+
+	hash := someHash()
+	cmd, err := commands.NewSetBanList([]bans.Hash{hash})
+	require.NoError(t, err)
+
+	err = handler.Handle(cmd)
+	require.NoError(t, err)
+
+	require.Equal(t, 1, transaction.BanList.ClearCalls)
+	require.Equal(t, []bans.Hash{hash}, transaction.BanList.AddCalls)
+	require.Equal(t, 1, publisher.Calls)
+	require.True(t, publisher.CalledAfterTransaction)
+}
+
+func TestSetBanListHandler_BanListChangedIsNotPublishedIfTransactionFails(t *testing.T) {
+	transaction := newBanListTransactionProviderMock()
+	transaction.BanList.ClearReturnErr = fixtures.SomeError()
+	publisher := newBanListChangedPublisherMock(transaction)
+	handler := commands.NewSetBanListHandler(transaction, publisher)
+
+	cmd, err := commands.NewSetBanList([]bans.Hash{someHash()})
+	require.NoError(t, err)
+
+	err = handler.Handle(cmd)
+	require.Error(t, err)
+
+	require.Equal(t, 0, publisher.Calls)
+}
+
+func someHash() bans.Hash {
+	return bans.MustNewHash(fixtures.SomeBytesOfLength(32))
+}
+
+type banListTransactionProviderMock struct {
+	BanList *banListRepositoryMock
+
+	inTransaction bool
+}
+
+func newBanListTransactionProviderMock() *banListTransactionProviderMock {
+	return &banListTransactionProviderMock{
+		BanList: newBanListRepositoryMock(),
+	}
+}
+
+func (m *banListTransactionProviderMock) Transact(f func(adapters commands.Adapters) error) error {
+	m.inTransaction = true
+	defer func() {
+		m.inTransaction = false
+	}()
+	return f(commands.Adapters{BanList: m.BanList})
+}
+
+type banListRepositoryMock struct {
+	AddCalls       []bans.Hash
+	RemoveCalls    []bans.Hash
+	ClearCalls     int
+	ClearReturnErr error
+}
+
+func newBanListRepositoryMock() *banListRepositoryMock {
+	return &banListRepositoryMock{}
+}
+
+func (m *banListRepositoryMock) Add(hash bans.Hash) error {
+	m.AddCalls = append(m.AddCalls, hash)
+	return nil
+}
+
+func (m *banListRepositoryMock) Remove(hash bans.Hash) error {
+	m.RemoveCalls = append(m.RemoveCalls, hash)
+	return nil
+}
+
+func (m *banListRepositoryMock) Clear() error {
+	m.ClearCalls++
+	return m.ClearReturnErr
+}
+
+func (m *banListRepositoryMock) ContainsFeed(feed refs.Feed) (bool, error) {
+	return false, nil
+}
+
+func (m *banListRepositoryMock) LookupMapping(hash bans.Hash) (commands.BannableRef, error) {
+	return commands.BannableRef{}, commands.ErrBanListMappingNotFound
+}
+
+type banListChangedPublisherMock struct {
+	Calls                  int
+	CalledAfterTransaction bool
+
+	transaction *banListTransactionProviderMock
+}
+
+func newBanListChangedPublisherMock(transaction *banListTransactionProviderMock) *banListChangedPublisherMock {
+	return &banListChangedPublisherMock{transaction: transaction}
+}
+
+func (m *banListChangedPublisherMock) PublishBanListChanged() {
+	m.Calls++
+	m.CalledAfterTransaction = !m.transaction.inTransaction
+}
@@ -0,0 +1,137 @@
+package commands
+
+import (
+	"github.com/boreq/errors"
+	"github.com/planetary-social/scuttlego/logging"
+	"github.com/planetary-social/scuttlego/service/domain/feeds/content"
+	"github.com/planetary-social/scuttlego/service/domain/identity"
+	"github.com/planetary-social/scuttlego/service/domain/invites"
+	"github.com/planetary-social/scuttlego/service/domain/refs"
+)
+
+type UseInvite struct {
+	invite refs.Identity
+	target refs.Identity
+}
+
+// NewUseInvite creates a command which uses the invite identified by the
+// identity derived from its seed to make this node follow the target.
+func NewUseInvite(invite refs.Identity, target refs.Identity) (UseInvite, error) {
+	if invite.IsZero() {
+		return UseInvite{}, errors.New("zero value of invite")
+	}
+	if target.IsZero() {
+		return UseInvite{}, errors.New("zero value of target")
+	}
+	return UseInvite{
+		invite: invite,
+		target: target,
+	}, nil
+}
+
+func MustNewUseInvite(invite refs.Identity, target refs.Identity) UseInvite {
+	v, err := NewUseInvite(invite, target)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+func (cmd UseInvite) Invite() refs.Identity {
+	return cmd.invite
+}
+
+func (cmd UseInvite) Target() refs.Identity {
+	return cmd.target
+}
+
+func (cmd UseInvite) IsZero() bool {
+	return cmd.invite.IsZero()
+}
+
+type UseInviteHandler struct {
+	transaction         TransactionProvider
+	local               identity.Private
+	marshaler           content.Marshaler
+	currentTimeProvider CurrentTimeProvider
+	logger              logging.Logger
+}
+
+func NewUseInviteHandler(
+	transaction TransactionProvider,
+	local identity.Private,
+	marshaler content.Marshaler,
+	currentTimeProvider CurrentTimeProvider,
+	logger logging.Logger,
+) *UseInviteHandler {
+	return &UseInviteHandler{
+		transaction:         transaction,
+		local:               local,
+		marshaler:           marshaler,
+		currentTimeProvider: currentTimeProvider,
+		logger:              logger.New("use_invite_handler"),
+	}
+}
+
+// Handle returns the id of the published follow message. Returns
+// ErrInviteNotFound if the invite doesn't exist and invites.ErrAlreadyFollowing
+// if the target is already followed in which case the invite isn't used.
+func (h *UseInviteHandler) Handle(cmd UseInvite) (refs.Message, error) {
+	if cmd.IsZero() {
+		return refs.Message{}, errors.New("zero value of command")
+	}
+
+	localRef, err := refs.NewIdentityFromPublic(h.local.Public())
+	if err != nil {
+		return refs.Message{}, errors.Wrap(err, "error creating the local ref")
+	}
+
+	var id refs.Message
+	if err := h.transaction.Transact(func(adapters Adapters) error {
+		alreadyFollowing, err := h.isFollowing(adapters, localRef, cmd.target)
+		if err != nil {
+			return errors.Wrap(err, "error checking if the target is already followed")
+		}
+
+		if alreadyFollowing {
+			return invites.ErrAlreadyFollowing
+		}
+
+		if err := adapters.Invite.UpdateInvite(cmd.invite, func(invite *invites.PubInvite) error {
+			return invite.Use(h.currentTimeProvider.Get())
+		}); err != nil {
+			return errors.Wrap(err, "error using the invite")
+		}
+
+		id, err = publishFollow(adapters, h.local, h.marshaler, cmd.target)
+		if err != nil {
+			return errors.Wrap(err, "error publishing the follow message")
+		}
+
+		return nil
+	}); err != nil {
+		return refs.Message{}, errors.Wrap(err, "transaction failed")
+	}
+
+	h.logger.Debug().
+		WithField("invite", cmd.invite.String()).
+		WithField("target", cmd.target.String()).
+		Message("invite used")
+
+	return id, nil
+}
+
+func (h *UseInviteHandler) isFollowing(adapters Adapters, local, target refs.Identity) (bool, error) {
+	contacts, err := adapters.SocialGraph.GetContacts(local)
+	if err != nil {
+		return false, errors.Wrap(err, "error getting contacts")
+	}
+
+	for _, contact := range contacts {
+		if contact.Target().Equal(target) {
+			return contact.Following(), nil
+		}
+	}
+
+	return false, nil
+}
@@ -0,0 +1,170 @@
+package commands_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/planetary-social/scuttlego/internal/fixtures"
+	"github.com/planetary-social/scuttlego/internal/mocks"
+	"github.com/planetary-social/scuttlego/service/app/commands"
+	"github.com/planetary-social/scuttlego/service/di"
+	"github.com/planetary-social/scuttlego/service/domain/feeds"
+	"github.com/planetary-social/scuttlego/service/domain/invites"
+	"github.com/planetary-social/scuttlego/service/domain/refs"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUseInviteHandler_InviteCanBeUsedUntilItIsExhausted(t *testing.T) {
+	tc, err := di.BuildTestCommands(t)
+	require.NoError(t, err)
+
+	inviteRef := fixtures.SomeRefIdentity()
+	err = tc.InviteRepository.Put(invites.MustNewPubInvite(inviteRef, 2, time.Time{}))
+	require.NoError(t, err)
+
+	for i := 0; i < 2; i++ {
+		_, err = tc.UseInvite.Handle(commands.MustNewUseInvite(inviteRef, fixtures.SomeRefIdentity()))
+		require.NoError(t, err)
+	}
+
+	_, err = tc.UseInvite.Handle(commands.MustNewUseInvite(inviteRef, fixtures.SomeRefIdentity()))
+	require.ErrorIs(t, err, invites.ErrInviteUsedUp)
+
+	require.Len(t, tc.FeedRepository.UpdateFeedCalls(), 2, "follow shouldn't be published if the invite is exhausted")
+}
+
+func TestUseInviteHandler_ExpiredInviteCanNotBeUsed(t *testing.T) {
+	tc, err := di.BuildTestCommands(t)
+	require.NoError(t, err)
+
+	now := fixtures.SomeTime()
+	tc.CurrentTimeProvider.CurrentTime = now
+
+	testCases := []struct {
+		Name          string
+		ExpiresAt     time.Time
+		ExpectedError error
+	}{
+		{
+			Name:          "never_expires",
+			ExpiresAt:     time.Time{},
+			ExpectedError: nil,
+		},
+		{
+			Name:          "expires_in_the_future",
+			ExpiresAt:     now.Add(time.Second),
+			ExpectedError: nil,
+		},
+		{
+			Name:          "expires_now",
+			ExpiresAt:     now,
+			ExpectedError: invites.ErrInviteExpired,
+		},
+		{
+			Name:          "expired",
+			ExpiresAt:     now.Add(-time.Second),
+			ExpectedError: invites.ErrInviteExpired,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			inviteRef := fixtures.SomeRefIdentity()
+			err = tc.InviteRepository.Put(invites.MustNewPubInvite(inviteRef, 1, testCase.ExpiresAt))
+			require.NoError(t, err)
+
+			updateFeedCallsBefore := len(tc.FeedRepository.UpdateFeedCalls())
+
+			_, err = tc.UseInvite.Handle(commands.MustNewUseInvite(inviteRef, fixtures.SomeRefIdentity()))
+			if testCase.ExpectedError == nil {
+				require.NoError(t, err)
+				require.Len(t, tc.FeedRepository.UpdateFeedCalls(), updateFeedCallsBefore+1)
+			} else {
+				require.ErrorIs(t, err, testCase.ExpectedError)
+				require.Len(t, tc.FeedRepository.UpdateFeedCalls(), updateFeedCallsBefore)
+			}
+		})
+	}
+}
+
+func TestUseInviteHandler_InviteIsNotUsedIfTargetIsAlreadyFollowed(t *testing.T) {
+	testCases := []struct {
+		Name          string
+		Following     bool
+		ExpectedError error
+	}{
+		{
+			Name:          "following",
+			Following:     true,
+			ExpectedError: invites.ErrAlreadyFollowing,
+		},
+		{
+			Name:          "contact_exists_but_not_following",
+			Following:     false,
+			ExpectedError: nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			tc, err := di.BuildTestCommands(t)
+			require.NoError(t, err)
+
+			local := refs.MustNewIdentityFromPublic(tc.Local)
+			target := fixtures.SomeRefIdentity()
+
+			tc.SocialGraphRepository.MockContacts([]*feeds.Contact{
+				feeds.MustNewContactFromHistory(local, target, testCase.Following, false),
+			})
+
+			inviteRef := fixtures.SomeRefIdentity()
+			err = tc.InviteRepository.Put(invites.MustNewPubInvite(inviteRef, 1, time.Time{}))
+			require.NoError(t, err)
+
+			_, err = tc.UseInvite.Handle(commands.MustNewUseInvite(inviteRef, target))
+			if testCase.ExpectedError == nil {
+				require.NoError(t, err)
+				require.Equal(t, 0, tc.InviteRepository.Invites()[0].RemainingUses())
+				require.Len(t, tc.FeedRepository.UpdateFeedCalls(), 1)
+			} else {
+				require.ErrorIs(t, err, testCase.ExpectedError)
+				require.Equal(t, 1, tc.InviteRepository.Invites()[0].RemainingUses(), "invite shouldn't be used")
+				require.Empty(t, tc.FeedRepository.UpdateFeedCalls())
+			}
+		})
+	}
+}
+
+func TestUseInviteHandler_FollowIsPublishedInTheSameTransactionInWhichTheInviteIsUsed(t *testing.T) {
+	tc, err := di.BuildTestCommands(t)
+	require.NoError(t, err)
+
+	inviteRef := fixtures.SomeRefIdentity()
+	err = tc.InviteRepository.Put(invites.MustNewPubInvite(inviteRef, 1, time.Time{}))
+	require.NoError(t, err)
+
+	_, err = tc.UseInvite.Handle(commands.MustNewUseInvite(inviteRef, fixtures.SomeRefIdentity()))
+	require.NoError(t, err)
+
+	// Adapters can only be accessed within a transaction therefore if only
+	// one transaction was started then both operations were performed in it.
+	require.Equal(t, 1, tc.TransactionProvider.TransactCalls())
+	require.Equal(t, []refs.Identity{inviteRef}, tc.InviteRepository.UpdateInviteCalls)
+	require.Equal(t,
+		[]mocks.FeedRepositoryMockUpdateFeedCall{
+			{
+				Feed: refs.MustNewIdentityFromPublic(tc.Local).MainFeed(),
+			},
+		},
+		tc.FeedRepository.UpdateFeedCalls(),
+	)
+}
+
+func TestUseInviteHandler_ReturnsErrInviteNotFound(t *testing.T) {
+	tc, err := di.BuildTestCommands(t)
+	require.NoError(t, err)
+
+	_, err = tc.UseInvite.Handle(commands.MustNewUseInvite(fixtures.SomeRefIdentity(), fixtures.SomeRefIdentity()))
+	require.ErrorIs(t, err, commands.ErrInviteNotFound)
+	require.Empty(t, tc.FeedRepository.UpdateFeedCalls())
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/planetary-social/scuttlego/service/domain/identity"
 	"github.com/planetary-social/scuttlego/service/domain/messagebuffer"
 	"github.com/planetary-social/scuttlego/service/domain/refs"
+	"github.com/planetary-social/scuttlego/tracing"
 )
 
 const (
@@ -31,6 +32,11 @@ type ForkedFeedTracker interface {
 	AddForkedFeed(replicatedFrom identity.Public, feed refs.Feed)
 }
 
+type MessageBufferMetrics interface {
+	ReportMessagesPersisted(n int)
+	ReportMessageBufferSize(n int)
+}
+
 type MessageBuffer struct {
 	messages     messagesList
 	messagesLock *sync.Mutex
@@ -41,6 +47,8 @@ type MessageBuffer struct {
 	transaction       TransactionProvider
 	identifier        RawMessageIdentifier
 	forkedFeedTracker ForkedFeedTracker
+	metrics           MessageBufferMetrics
+	tracer            *tracing.Tracer
 	logger            logging.Logger
 }
 
@@ -48,6 +56,8 @@ func NewMessageBuffer(
 	transaction TransactionProvider,
 	identifier RawMessageIdentifier,
 	forkedFeedTracker ForkedFeedTracker,
+	metrics MessageBufferMetrics,
+	tracer *tracing.Tracer,
 	logger logging.Logger,
 ) *MessageBuffer {
 	return &MessageBuffer{
@@ -59,13 +69,15 @@ func NewMessageBuffer(
 		transaction:       transaction,
 		identifier:        identifier,
 		forkedFeedTracker: forkedFeedTracker,
+		metrics:           metrics,
+		tracer:            tracer,
 		logger:            logger.New("message_buffer"),
 	}
 }
 
 func (m *MessageBuffer) Run(ctx context.Context) error {
 	for {
-		if err := m.persist(); err != nil {
+		if err := m.persist(ctx); err != nil {
 			m.logger.Error().WithError(err).Message("error persisting messages")
 		}
 
@@ -100,7 +112,10 @@ func (m *MessageBuffer) Handle(replicatedFrom identity.Public, rawMsg message.Ra
 		return errors.Wrap(err, "could not add a message")
 	}
 
-	if m.messages.MessageCount() > messageBufferPersistAtMessages {
+	messageCount := m.messages.MessageCount()
+	m.metrics.ReportMessageBufferSize(messageCount)
+
+	if messageCount > messageBufferPersistAtMessages {
 		m.forcePersistOnce.Do(
 			func() {
 				close(m.forcePersistCh)
@@ -111,7 +126,7 @@ func (m *MessageBuffer) Handle(replicatedFrom identity.Public, rawMsg message.Ra
 	return nil
 }
 
-func (m *MessageBuffer) persist() error {
+func (m *MessageBuffer) persist(ctx context.Context) error {
 	m.messagesLock.Lock()
 	defer m.messagesLock.Unlock()
 
@@ -126,15 +141,24 @@ func (m *MessageBuffer) persist() error {
 
 	start := time.Now()
 
+	_, span := m.tracer.Start(ctx, "message_buffer.persist")
+	defer span.End()
+
 	var updatedSequences map[string]message.Sequence
+	var persistedMessages int
 
 	if err := m.transaction.Transact(func(adapters Adapters) (err error) {
-		updatedSequences, err = m.persistTransaction(adapters)
+		updatedSequences, persistedMessages, err = m.persistTransaction(adapters)
 		return err
 	}); err != nil {
+		span.SetError(err)
 		return errors.Wrap(err, "transaction failed")
 	}
 
+	span.SetAttribute("persisted_messages", persistedMessages)
+
+	m.metrics.ReportMessagesPersisted(persistedMessages)
+
 	for key, updatedSequence := range updatedSequences {
 		logger := m.logger.WithField("key", key).WithField("updated_sequence", updatedSequence.Int())
 
@@ -155,10 +179,10 @@ func (m *MessageBuffer) persist() error {
 	return nil
 }
 
-func (m *MessageBuffer) persistTransaction(adapters Adapters) (map[string]message.Sequence, error) {
+func (m *MessageBuffer) persistTransaction(adapters Adapters) (map[string]message.Sequence, int, error) {
 	socialGraphBuilder, err := adapters.SocialGraph.GetSocialGraphBuilder()
 	if err != nil {
-		return nil, errors.Wrap(err, "could not load the social graph")
+		return nil, 0, errors.Wrap(err, "could not load the social graph")
 	}
 
 	counterAllMessages := 0
@@ -175,7 +199,7 @@ func (m *MessageBuffer) persistTransaction(adapters Adapters) (map[string]messag
 
 		shouldSave, err := m.shouldSave(adapters, socialGraphBuilder, feedRef)
 		if err != nil {
-			return nil, errors.Wrap(err, "error checking if this feed should be saved")
+			return nil, 0, errors.Wrap(err, "error checking if this feed should be saved")
 		}
 
 		if !shouldSave {
@@ -234,7 +258,7 @@ func (m *MessageBuffer) persistTransaction(adapters Adapters) (map[string]messag
 
 			return nil
 		}); err != nil {
-			return nil, errors.Wrapf(err, "failed to update the feed '%s'", feedRef)
+			return nil, 0, errors.Wrapf(err, "failed to update the feed '%s'", feedRef)
 		}
 	}
 
@@ -248,7 +272,7 @@ func (m *MessageBuffer) persistTransaction(adapters Adapters) (map[string]messag
 		WithField("health", float64(counterPersistedMessages)/float64(counterAllMessages)).
 		Message("update complete")
 
-	return updatedSequences, nil
+	return updatedSequences, counterPersistedMessages, nil
 }
 
 func (m *MessageBuffer) shouldSave(adapters Adapters, socialGraphBuilder *graph.SocialGraphBuilder, feedRef refs.Feed) (bool, error) {
@@ -300,6 +324,8 @@ func (m *MessageBuffer) cleanup() {
 	messagesAfter := m.messages.MessageCount()
 	feedsAfter := len(m.messages)
 
+	m.metrics.ReportMessageBufferSize(messagesAfter)
+
 	m.logger.
 		Trace().
 		WithField("messages_before", messagesBefore).
@@ -42,4 +42,6 @@ var (
 	ErrReceiveLogEntryNotFound = errors.New("receive log entry not found")
 	ErrFeedNotFound            = errors.New("feed not found")
 	ErrFeedMessageNotFound     = errors.New("feed message not found")
+	ErrMessageNotFound         = errors.New("message not found")
+	ErrRoomAliasNotFound       = errors.New("room alias not found")
 )
@@ -2,14 +2,19 @@ package queries
 
 import (
 	"context"
+	"time"
 
+	"github.com/boreq/errors"
 	"github.com/planetary-social/scuttlego/service/app/common"
 	"github.com/planetary-social/scuttlego/service/domain/feeds"
 	"github.com/planetary-social/scuttlego/service/domain/feeds/message"
 	"github.com/planetary-social/scuttlego/service/domain/graph"
 	"github.com/planetary-social/scuttlego/service/domain/identity"
+	"github.com/planetary-social/scuttlego/service/domain/messages"
 	"github.com/planetary-social/scuttlego/service/domain/network"
 	"github.com/planetary-social/scuttlego/service/domain/refs"
+	"github.com/planetary-social/scuttlego/service/domain/rooms"
+	"github.com/planetary-social/scuttlego/service/domain/rooms/aliases"
 	"github.com/planetary-social/scuttlego/service/domain/transport"
 )
 
@@ -18,21 +23,67 @@ type LogMessage struct {
 	Sequence common.ReceiveLogSequence
 }
 
+// TimestampRange selects messages using the timestamps claimed by their
+// authors. Nil bounds are ignored.
+type TimestampRange struct {
+	// Gte excludes messages with timestamps before it.
+	Gte *time.Time
+
+	// Lt excludes messages with timestamps equal to or after it.
+	Lt *time.Time
+}
+
+// Includes returns true if the timestamp falls within the range.
+func (r TimestampRange) Includes(t time.Time) bool {
+	if r.Gte != nil && t.Before(*r.Gte) {
+		return false
+	}
+	if r.Lt != nil && !t.Before(*r.Lt) {
+		return false
+	}
+	return true
+}
+
 type Dialer interface {
 	Dial(ctx context.Context, remote identity.Public, address network.Address) (transport.Peer, error)
 }
 
+type RoomHTTPClient interface {
+	ResolveAlias(ctx context.Context, endpoint aliases.AliasEndpointURL) (rooms.ResolvedAlias, error)
+}
+
+// RoomServer is used when this node acts as a room.
+type RoomServer interface {
+	Metadata(member bool) (messages.RoomMetadataResponse, error)
+	Attendants(ctx context.Context, member bool) ([]refs.Identity, <-chan rooms.RoomAttendantsEvent, error)
+	Endpoints(ctx context.Context, member bool) (<-chan []refs.Identity, error)
+	CanUse(member bool) error
+}
+
+// RoomAttendantsRegistry keeps track of attendants of rooms that we are
+// connected to.
+type RoomAttendantsRegistry interface {
+	List() []rooms.PortalAttendants
+
+	// Subscribe returns a channel which receives the identity of a portal
+	// every time attendants of that portal change.
+	Subscribe(ctx context.Context) <-chan refs.Identity
+}
+
 type TransactionProvider interface {
 	Transact(func(adapters Adapters) error) error
 }
 
 type Adapters struct {
-	Feed         FeedRepository
-	ReceiveLog   ReceiveLogRepository
-	Message      MessageRepository
-	SocialGraph  SocialGraphRepository
-	FeedWantList FeedWantListRepository
-	BanList      BanListRepository
+	Feed              FeedRepository
+	ReceiveLog        ReceiveLogRepository
+	Message           MessageRepository
+	SocialGraph       SocialGraphRepository
+	FeedWantList      FeedWantListRepository
+	BanList           BanListRepository
+	RoomMember        RoomMemberRepository
+	RoomAlias         RoomAliasRepository
+	OutOfOrderMessage OutOfOrderMessageRepository
 }
 type FeedRepository interface {
 	// GetMessages returns messages with a sequence greater or equal to the
@@ -42,12 +93,22 @@ type FeedRepository interface {
 	// sequence criteria are returned.
 	GetMessages(id refs.Feed, seq *message.Sequence, limit *int) ([]message.Message, error)
 
+	// ListMessages returns messages from the feed selected using the provided
+	// options. Messages are ordered by their sequences, newest first if
+	// Reverse is set.
+	ListMessages(id refs.Feed, options FeedMessagesListOptions) ([]message.Message, error)
+
 	// GetFeed returns common.ErrFeedNotFound if the feed doesn't exist.
 	GetFeed(ref refs.Feed) (*feeds.Feed, error)
 
 	// GetSequence returns common.ErrFeedNotFound if the feed doesn't exist.
 	GetSequence(ref refs.Feed) (message.Sequence, error)
 
+	// ListFeeds returns up to limit feeds for which at least one message is
+	// stored starting after the provided feed. If after is nil feeds are
+	// returned starting from the first one.
+	ListFeeds(after *refs.Feed, limit int) ([]refs.Feed, error)
+
 	// GetMessage returns a message with a given sequence from the specified
 	// feed.
 	GetMessage(feed refs.Feed, sequence message.Sequence) (message.Message, error)
@@ -57,12 +118,12 @@ type FeedRepository interface {
 }
 
 type ReceiveLogRepository interface {
-	// List returns messages from the log starting with the provided sequence.
+	// List returns messages from the log selected using the provided options.
 	// This is supposed to simulate the behaviour of go-ssb's receive log as
 	// such a concept doesn't exist within this implementation. The log is zero
 	// indexed. If limit isn't positive an error is returned. Sequence has
 	// nothing to do with the sequence field of Scuttlebutt messages.
-	List(startSeq common.ReceiveLogSequence, limit int) ([]LogMessage, error)
+	List(options ReceiveLogListOptions) ([]LogMessage, error)
 
 	// GetMessage returns the message that the provided receive log sequence
 	// points to.
@@ -74,16 +135,88 @@ type ReceiveLogRepository interface {
 	GetSequences(ref refs.Message) ([]common.ReceiveLogSequence, error)
 }
 
+type FeedMessagesListOptions struct {
+	// Gte is the lowest sequence which can be returned. Nil means that
+	// messages are returned starting from the beginning of the feed.
+	Gte *message.Sequence
+
+	// Lt is the sequence above the highest sequence which can be returned.
+	// Nil means that there is no upper bound.
+	Lt *message.Sequence
+
+	// Lte is the highest sequence which can be returned. Nil means that there
+	// is no upper bound. If both Lt and Lte are set then the lower of the two
+	// bounds is used.
+	Lte *message.Sequence
+
+	// Reverse makes the repository return the newest messages first.
+	Reverse bool
+
+	// Timestamps are used to skip messages while iterating. Skipped messages
+	// don't count towards the limit.
+	Timestamps TimestampRange
+
+	// Limit specifies the max number of returned messages. Nil means that all
+	// messages matching the criteria are returned.
+	Limit *int
+}
+
+type ReceiveLogListOptions struct {
+	// Gte is the lowest sequence which can be returned.
+	Gte common.ReceiveLogSequence
+
+	// Lt is the sequence above the highest sequence which can be returned.
+	// Nil means that there is no upper bound.
+	Lt *common.ReceiveLogSequence
+
+	// Lte is the highest sequence which can be returned. Nil means that there
+	// is no upper bound. If both Lt and Lte are set then the lower of the two
+	// bounds is used.
+	Lte *common.ReceiveLogSequence
+
+	// Reverse makes the repository return the newest messages first.
+	Reverse bool
+
+	// Timestamps are used to skip messages while iterating. Skipped messages
+	// don't count towards the limit.
+	Timestamps TimestampRange
+
+	// Limit specifies the max number of returned messages. Limit must be
+	// positive.
+	Limit int
+}
+
 type MessageRepository interface {
 	// Count returns the number of stored messages.
 	Count() (int, error)
 
-	// Get retrieves a message.
+	// Get retrieves a message. Returns common.ErrMessageNotFound if the message
+	// doesn't exist.
+	Get(id refs.Message) (message.Message, error)
+}
+
+// OutOfOrderMessageRepository stores messages which were retrieved by their id
+// and don't belong to any of the stored feeds.
+type OutOfOrderMessageRepository interface {
+	// Get returns common.ErrMessageNotFound if the message doesn't exist.
 	Get(id refs.Message) (message.Message, error)
 }
 
 type SocialGraphRepository interface {
 	GetSocialGraph() (graph.SocialGraph, error)
+
+	// GetSocialGraphFrom builds a social graph starting from the provided
+	// root.
+	GetSocialGraphFrom(root refs.Identity, hops graph.Hops) (graph.SocialGraph, error)
+
+	// GetContact returns a contact which is neither following nor blocking
+	// if the author never interacted with the target.
+	GetContact(author, target refs.Identity) (*feeds.Contact, error)
+
+	// ListContacts returns at most limit stored contacts. If after is not nil
+	// then only contacts which come after it are returned which makes it
+	// possible to go through all contacts page by page.
+	ListContacts(after *feeds.Contact, limit int) ([]*feeds.Contact, error)
 }
 
 type FeedWantListRepository interface {
@@ -93,3 +226,41 @@ type FeedWantListRepository interface {
 type BanListRepository interface {
 	ContainsFeed(feed refs.Feed) (bool, error)
 }
+
+// RoomMemberRepository stores members of the room hosted by this node.
+type RoomMemberRepository interface {
+	Contains(id refs.Identity) (bool, error)
+	List() ([]refs.Identity, error)
+}
+
+// RoomAliasRepository stores aliases registered in the room hosted by this
+// node.
+type RoomAliasRepository interface {
+	// Get returns common.ErrRoomAliasNotFound if the alias isn't registered.
+	Get(alias aliases.Alias) (aliases.Registration, error)
+
+	// List returns aliases registered by the user.
+	List(user refs.Identity) ([]aliases.Alias, error)
+}
+
+func isRoomMember(transaction TransactionProvider, remote identity.Public) (bool, error) {
+	ref, err := refs.NewIdentityFromPublic(remote)
+	if err != nil {
+		return false, errors.Wrap(err, "error creating a ref")
+	}
+
+	var member bool
+
+	if err := transaction.Transact(func(adapters Adapters) error {
+		tmp, err := adapters.RoomMember.Contains(ref)
+		if err != nil {
+			return errors.Wrap(err, "error checking membership")
+		}
+		member = tmp
+		return nil
+	}); err != nil {
+		return false, errors.Wrap(err, "transaction failed")
+	}
+
+	return member, nil
+}
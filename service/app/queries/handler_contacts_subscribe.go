@@ -0,0 +1,77 @@
+package queries
+
+import (
+	"context"
+
+	"github.com/boreq/errors"
+	"github.com/planetary-social/scuttlego/service/domain/feeds"
+)
+
+// contactsPageSize is the number of contacts loaded from the database in a
+// single transaction.
+const contactsPageSize = 1000
+
+type ContactSubscriber interface {
+	// SubscribeToContacts receives contacts every time they are updated.
+	// Contacts may be dropped if the subscriber doesn't receive them quickly
+	// enough.
+	SubscribeToContacts(ctx context.Context) <-chan *feeds.Contact
+}
+
+// ContactsPageFn is called with consecutive pages of contacts. The first page
+// may be empty if there are no contacts.
+type ContactsPageFn func(contacts []*feeds.Contact) error
+
+// ContactsSubscribeHandler passes all contacts currently stored in the
+// database to the provided function page by page and returns a channel which
+// receives contacts as they are updated. The channel is closed when the
+// context is cancelled. Contacts updated while the existing contacts are being
+// loaded may appear both in the pages and in the channel.
+type ContactsSubscribeHandler struct {
+	transaction TransactionProvider
+	subscriber  ContactSubscriber
+}
+
+func NewContactsSubscribeHandler(
+	transaction TransactionProvider,
+	subscriber ContactSubscriber,
+) *ContactsSubscribeHandler {
+	return &ContactsSubscribeHandler{
+		transaction: transaction,
+		subscriber:  subscriber,
+	}
+}
+
+func (h *ContactsSubscribeHandler) Handle(ctx context.Context, fn ContactsPageFn) (<-chan *feeds.Contact, error) {
+	changes := h.subscriber.SubscribeToContacts(ctx)
+
+	var after *feeds.Contact
+
+	for {
+		var page []*feeds.Contact
+
+		if err := h.transaction.Transact(func(adapters Adapters) error {
+			tmp, err := adapters.SocialGraph.ListContacts(after, contactsPageSize)
+			if err != nil {
+				return errors.Wrap(err, "failed to list contacts")
+			}
+
+			page = tmp
+			return nil
+		}); err != nil {
+			return nil, errors.Wrap(err, "transaction failed")
+		}
+
+		if len(page) > 0 || after == nil {
+			if err := fn(page); err != nil {
+				return nil, errors.Wrap(err, "function returned an error")
+			}
+		}
+
+		if len(page) < contactsPageSize {
+			return changes, nil
+		}
+
+		after = page[len(page)-1]
+	}
+}
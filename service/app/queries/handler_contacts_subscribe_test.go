@@ -0,0 +1,118 @@
+package queries_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/planetary-social/scuttlego/internal/fixtures"
+	"github.com/planetary-social/scuttlego/service/di"
+	"github.com/planetary-social/scuttlego/service/domain/feeds"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContactsSubscribe_ReturnsStoredContactsAndPublishedChanges(t *testing.T) {
+	a, err := di.BuildTestQueries(t)
+	require.NoError(t, err)
+
+	ctx := fixtures.TestContext(t)
+
+	storedContacts := []*feeds.Contact{
+		feeds.MustNewContactFromHistory(fixtures.SomeRefIdentity(), fixtures.SomeRefIdentity(), true, false),
+	}
+	a.SocialGraphRepository.MockContacts(storedContacts)
+
+	var pages [][]*feeds.Contact
+	changes, err := a.Queries.ContactsSubscribe.Handle(ctx, func(contacts []*feeds.Contact) error {
+		pages = append(pages, contacts)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, [][]*feeds.Contact{storedContacts}, pages)
+
+	change := feeds.MustNewContactFromHistory(fixtures.SomeRefIdentity(), fixtures.SomeRefIdentity(), false, true)
+	a.ContactPubSub.PublishContact(change)
+
+	select {
+	case v := <-changes:
+		require.Equal(t, change, v)
+	case <-time.After(1 * time.Second):
+		t.Fatal("timeout")
+	}
+}
+
+func TestContactsSubscribe_ContactsAreReturnedInPages(t *testing.T) {
+	testCases := []struct {
+		Name          string
+		NumContacts   int
+		ExpectedPages []int
+	}{
+		{
+			Name:          "no_contacts",
+			NumContacts:   0,
+			ExpectedPages: []int{0},
+		},
+		{
+			Name:          "less_than_a_page",
+			NumContacts:   10,
+			ExpectedPages: []int{10},
+		},
+		{
+			Name:          "exactly_one_page",
+			NumContacts:   1000,
+			ExpectedPages: []int{1000},
+		},
+		{
+			Name:          "more_than_a_page",
+			NumContacts:   2500,
+			ExpectedPages: []int{1000, 1000, 500},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			a, err := di.BuildTestQueries(t)
+			require.NoError(t, err)
+
+			var storedContacts []*feeds.Contact
+			for i := 0; i < testCase.NumContacts; i++ {
+				storedContacts = append(storedContacts, feeds.MustNewContactFromHistory(fixtures.SomeRefIdentity(), fixtures.SomeRefIdentity(), true, false))
+			}
+			a.SocialGraphRepository.MockContacts(storedContacts)
+
+			var pageSizes []int
+			var contacts []*feeds.Contact
+			_, err = a.Queries.ContactsSubscribe.Handle(fixtures.TestContext(t), func(page []*feeds.Contact) error {
+				pageSizes = append(pageSizes, len(page))
+				contacts = append(contacts, page...)
+				return nil
+			})
+			require.NoError(t, err)
+			require.Equal(t, testCase.ExpectedPages, pageSizes)
+			require.Equal(t, storedContacts, contacts)
+		})
+	}
+}
+
+func TestContactsSubscribe_PublishingDoesNotWaitForSubscribers(t *testing.T) {
+	a, err := di.BuildTestQueries(t)
+	require.NoError(t, err)
+
+	_, err = a.Queries.ContactsSubscribe.Handle(fixtures.TestContext(t), func(page []*feeds.Contact) error {
+		return nil
+	})
+	require.NoError(t, err)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 10000; i++ {
+			a.ContactPubSub.PublishContact(feeds.MustNewContactFromHistory(fixtures.SomeRefIdentity(), fixtures.SomeRefIdentity(), true, false))
+		}
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("publishing blocked on a subscriber which doesn't receive contacts")
+	}
+}
@@ -0,0 +1,114 @@
+package queries
+
+import (
+	"github.com/boreq/errors"
+	"github.com/planetary-social/scuttlego/service/domain/feeds/message"
+	"github.com/planetary-social/scuttlego/service/domain/refs"
+)
+
+type FeedMessages struct {
+	feed refs.Feed
+
+	// Only messages with a sequence greater or equal to this sequence are
+	// returned. Nil means that messages are returned starting from the
+	// beginning of the feed.
+	gte *message.Sequence
+
+	// Only messages with a sequence lower than this sequence are returned.
+	// Nil means that there is no upper bound.
+	lt *message.Sequence
+
+	// If reverse is true then the newest messages are returned first.
+	reverse bool
+
+	// Limit specifies the max number of messages which will be returned. Limit
+	// must be positive.
+	limit int
+}
+
+// NewFeedMessages creates a query which can read the feed newest first. When
+// reading in reverse the lt sequence should be used for pagination.
+func NewFeedMessages(
+	feed refs.Feed,
+	gte *message.Sequence,
+	lt *message.Sequence,
+	reverse bool,
+	limit int,
+) (FeedMessages, error) {
+	if feed.IsZero() {
+		return FeedMessages{}, errors.New("zero value of feed")
+	}
+
+	if limit <= 0 {
+		return FeedMessages{}, errors.New("limit must be positive")
+	}
+
+	return FeedMessages{
+		feed:    feed,
+		gte:     gte,
+		lt:      lt,
+		reverse: reverse,
+		limit:   limit,
+	}, nil
+}
+
+func (f FeedMessages) Feed() refs.Feed {
+	return f.feed
+}
+
+func (f FeedMessages) Gte() *message.Sequence {
+	return f.gte
+}
+
+func (f FeedMessages) Lt() *message.Sequence {
+	return f.lt
+}
+
+func (f FeedMessages) Reverse() bool {
+	return f.reverse
+}
+
+func (f FeedMessages) Limit() int {
+	return f.limit
+}
+
+func (f FeedMessages) IsZero() bool {
+	return f.feed.IsZero()
+}
+
+// FeedMessagesHandler returns messages from a single feed ordered by their
+// sequences.
+type FeedMessagesHandler struct {
+	transaction TransactionProvider
+}
+
+func NewFeedMessagesHandler(transaction TransactionProvider) *FeedMessagesHandler {
+	return &FeedMessagesHandler{transaction: transaction}
+}
+
+func (h *FeedMessagesHandler) Handle(query FeedMessages) ([]message.Message, error) {
+	if query.IsZero() {
+		return nil, errors.New("zero value of query")
+	}
+
+	var result []message.Message
+
+	if err := h.transaction.Transact(func(adapters Adapters) error {
+		limit := query.Limit()
+		tmp, err := adapters.Feed.ListMessages(query.Feed(), FeedMessagesListOptions{
+			Gte:     query.Gte(),
+			Lt:      query.Lt(),
+			Reverse: query.Reverse(),
+			Limit:   &limit,
+		})
+		if err != nil {
+			return errors.Wrap(err, "error listing messages")
+		}
+		result = tmp
+		return nil
+	}); err != nil {
+		return nil, errors.Wrap(err, "transaction failed")
+	}
+
+	return result, nil
+}
@@ -0,0 +1,126 @@
+package queries_test
+
+import (
+	"testing"
+
+	"github.com/boreq/errors"
+	"github.com/planetary-social/scuttlego/internal"
+	"github.com/planetary-social/scuttlego/internal/fixtures"
+	"github.com/planetary-social/scuttlego/internal/mocks"
+	"github.com/planetary-social/scuttlego/service/app/queries"
+	"github.com/planetary-social/scuttlego/service/di"
+	"github.com/planetary-social/scuttlego/service/domain/feeds/message"
+	"github.com/planetary-social/scuttlego/service/domain/refs"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewFeedMessages(t *testing.T) {
+	testCases := []struct {
+		Name          string
+		Feed          refs.Feed
+		Limit         int
+		ExpectedError error
+	}{
+		{
+			Name:          "valid",
+			Feed:          fixtures.SomeRefFeed(),
+			Limit:         1,
+			ExpectedError: nil,
+		},
+		{
+			Name:          "zero_feed",
+			Feed:          refs.Feed{},
+			Limit:         1,
+			ExpectedError: errors.New("zero value of feed"),
+		},
+		{
+			Name:          "limit_zero",
+			Feed:          fixtures.SomeRefFeed(),
+			Limit:         0,
+			ExpectedError: errors.New("limit must be positive"),
+		},
+		{
+			Name:          "limit_negative",
+			Feed:          fixtures.SomeRefFeed(),
+			Limit:         -1,
+			ExpectedError: errors.New("limit must be positive"),
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			gte := internal.Ptr(fixtures.SomeSequence())
+			lt := internal.Ptr(fixtures.SomeSequence())
+
+			q, err := queries.NewFeedMessages(testCase.Feed, gte, lt, true, testCase.Limit)
+			if testCase.ExpectedError == nil {
+				require.NoError(t, err)
+				require.Equal(t, testCase.Feed, q.Feed())
+				require.Equal(t, gte, q.Gte())
+				require.Equal(t, lt, q.Lt())
+				require.True(t, q.Reverse())
+				require.Equal(t, testCase.Limit, q.Limit())
+			} else {
+				require.EqualError(t, err, testCase.ExpectedError.Error())
+			}
+		})
+	}
+}
+
+func TestFeedMessagesHandler_PassesArgumentsToRepository(t *testing.T) {
+	tq, err := di.BuildTestQueries(t)
+	require.NoError(t, err)
+
+	feed := fixtures.SomeRefFeed()
+	gte := internal.Ptr(message.MustNewSequence(2))
+	lt := internal.Ptr(message.MustNewSequence(5))
+	msgs := []message.Message{
+		fixtures.SomeMessage(message.MustNewSequence(4), feed),
+		fixtures.SomeMessage(message.MustNewSequence(3), feed),
+	}
+
+	tq.FeedRepository.ListMessagesReturnValue = msgs
+
+	query, err := queries.NewFeedMessages(feed, gte, lt, true, 10)
+	require.NoError(t, err)
+
+	result, err := tq.Queries.FeedMessages.Handle(query)
+	require.NoError(t, err)
+	require.Equal(t, msgs, result)
+
+	require.Equal(t,
+		[]mocks.FeedRepositoryMockListMessagesCall{
+			{
+				Id: feed,
+				Options: queries.FeedMessagesListOptions{
+					Gte:     gte,
+					Lt:      lt,
+					Reverse: true,
+					Limit:   internal.Ptr(10),
+				},
+			},
+		},
+		tq.FeedRepository.ListMessagesCalls,
+	)
+}
+
+func TestFeedMessagesHandler_ReturnsRepositoryErrors(t *testing.T) {
+	tq, err := di.BuildTestQueries(t)
+	require.NoError(t, err)
+
+	tq.FeedRepository.ListMessagesReturnErr = errors.New("forced error")
+
+	query, err := queries.NewFeedMessages(fixtures.SomeRefFeed(), nil, nil, false, 10)
+	require.NoError(t, err)
+
+	_, err = tq.Queries.FeedMessages.Handle(query)
+	require.ErrorContains(t, err, "forced error")
+}
+
+func TestFeedMessagesHandler_ZeroValueOfQueryIsRejected(t *testing.T) {
+	tq, err := di.BuildTestQueries(t)
+	require.NoError(t, err)
+
+	_, err = tq.Queries.FeedMessages.Handle(queries.FeedMessages{})
+	require.EqualError(t, err, "zero value of query")
+}
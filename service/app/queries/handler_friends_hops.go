@@ -0,0 +1,69 @@
+package queries
+
+import (
+	"github.com/boreq/errors"
+	"github.com/planetary-social/scuttlego/service/domain/graph"
+	"github.com/planetary-social/scuttlego/service/domain/identity"
+	"github.com/planetary-social/scuttlego/service/domain/refs"
+)
+
+type FriendsHops struct {
+	// Start is the root of the graph. If it is zero then the local identity
+	// is used.
+	Start refs.Identity
+
+	// Max limits the distance from the root. If it is nil then the
+	// configured number of hops is used.
+	Max *graph.Hops
+}
+
+type FriendsHopsHandler struct {
+	transaction TransactionProvider
+	local       refs.Identity
+	hops        graph.Hops
+}
+
+func NewFriendsHopsHandler(
+	transaction TransactionProvider,
+	local identity.Public,
+	hops graph.Hops,
+) (*FriendsHopsHandler, error) {
+	localRef, err := refs.NewIdentityFromPublic(local)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not create a public identity")
+	}
+
+	return &FriendsHopsHandler{
+		transaction: transaction,
+		local:       localRef,
+		hops:        hops,
+	}, nil
+}
+
+func (h *FriendsHopsHandler) Handle(query FriendsHops) (graph.SocialGraph, error) {
+	root := h.local
+	if !query.Start.IsZero() {
+		root = query.Start
+	}
+
+	hops := h.hops
+	if query.Max != nil {
+		hops = *query.Max
+	}
+
+	var result graph.SocialGraph
+
+	if err := h.transaction.Transact(func(adapters Adapters) error {
+		tmp, err := adapters.SocialGraph.GetSocialGraphFrom(root, hops)
+		if err != nil {
+			return errors.Wrap(err, "failed to get the social graph")
+		}
+
+		result = tmp
+		return nil
+	}); err != nil {
+		return graph.SocialGraph{}, errors.Wrap(err, "transaction failed")
+	}
+
+	return result, nil
+}
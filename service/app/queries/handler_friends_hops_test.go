@@ -0,0 +1,62 @@
+package queries_test
+
+import (
+	"testing"
+
+	"github.com/planetary-social/scuttlego/internal"
+	"github.com/planetary-social/scuttlego/internal/fixtures"
+	"github.com/planetary-social/scuttlego/internal/mocks"
+	"github.com/planetary-social/scuttlego/service/app/queries"
+	"github.com/planetary-social/scuttlego/service/di"
+	"github.com/planetary-social/scuttlego/service/domain/graph"
+	"github.com/planetary-social/scuttlego/service/domain/refs"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFriendsHops_DefaultsToLocalIdentityAndConfiguredHops(t *testing.T) {
+	a, err := di.BuildTestQueries(t)
+	require.NoError(t, err)
+
+	expectedGraph := graph.NewSocialGraph(map[string]graph.Hops{
+		fixtures.SomeRefIdentity().String(): fixtures.SomeHops(),
+	})
+	a.SocialGraphRepository.GetSocialGraphFromReturnValue = expectedGraph
+
+	result, err := a.Queries.FriendsHops.Handle(queries.FriendsHops{})
+	require.NoError(t, err)
+	require.Equal(t, expectedGraph, result)
+
+	require.Equal(t,
+		[]mocks.SocialGraphRepositoryMockGetSocialGraphFromCall{
+			{
+				Root: refs.MustNewIdentityFromPublic(a.LocalIdentity),
+				Hops: a.Hops,
+			},
+		},
+		a.SocialGraphRepository.GetSocialGraphFromCalls,
+	)
+}
+
+func TestFriendsHops_UsesProvidedStartAndMax(t *testing.T) {
+	a, err := di.BuildTestQueries(t)
+	require.NoError(t, err)
+
+	start := fixtures.SomeRefIdentity()
+	max := graph.MustNewHops(fixtures.SomeNonNegativeInt())
+
+	_, err = a.Queries.FriendsHops.Handle(queries.FriendsHops{
+		Start: start,
+		Max:   internal.Ptr(max),
+	})
+	require.NoError(t, err)
+
+	require.Equal(t,
+		[]mocks.SocialGraphRepositoryMockGetSocialGraphFromCall{
+			{
+				Root: start,
+				Hops: max,
+			},
+		},
+		a.SocialGraphRepository.GetSocialGraphFromCalls,
+	)
+}
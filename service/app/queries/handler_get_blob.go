@@ -11,6 +11,7 @@ import (
 type BlobStorage interface {
 	Get(id refs.Blob) (io.ReadCloser, error)
 	Size(id refs.Blob) (blobs.Size, error)
+	Has(id refs.Blob) (bool, error)
 }
 
 type GetBlob struct {
@@ -0,0 +1,44 @@
+package queries
+
+import (
+	"github.com/boreq/errors"
+	"github.com/planetary-social/scuttlego/service/domain/blobs"
+	"github.com/planetary-social/scuttlego/service/domain/refs"
+)
+
+type GetBlobSize struct {
+	Id refs.Blob
+}
+
+type GetBlobSizeHandler struct {
+	storage BlobStorage
+}
+
+func NewGetBlobSizeHandler(storage BlobStorage) *GetBlobSizeHandler {
+	return &GetBlobSizeHandler{
+		storage: storage,
+	}
+}
+
+// Handle returns the size of the blob or nil if the blob doesn't exist.
+func (h *GetBlobSizeHandler) Handle(query GetBlobSize) (*blobs.Size, error) {
+	if query.Id.IsZero() {
+		return nil, errors.New("zero value of id")
+	}
+
+	has, err := h.storage.Has(query.Id)
+	if err != nil {
+		return nil, errors.Wrap(err, "error checking if the blob exists")
+	}
+
+	if !has {
+		return nil, nil
+	}
+
+	size, err := h.storage.Size(query.Id)
+	if err != nil {
+		return nil, errors.Wrap(err, "error getting the blob size")
+	}
+
+	return &size, nil
+}
@@ -0,0 +1,48 @@
+package queries
+
+import (
+	"github.com/boreq/errors"
+	"github.com/planetary-social/scuttlego/service/domain/feeds"
+	"github.com/planetary-social/scuttlego/service/domain/refs"
+)
+
+type GetContact struct {
+	Author refs.Identity
+	Target refs.Identity
+}
+
+type GetContactHandler struct {
+	transaction TransactionProvider
+}
+
+func NewGetContactHandler(transaction TransactionProvider) *GetContactHandler {
+	return &GetContactHandler{
+		transaction: transaction,
+	}
+}
+
+func (h *GetContactHandler) Handle(query GetContact) (*feeds.Contact, error) {
+	if query.Author.IsZero() {
+		return nil, errors.New("zero value of author")
+	}
+
+	if query.Target.IsZero() {
+		return nil, errors.New("zero value of target")
+	}
+
+	var result *feeds.Contact
+
+	if err := h.transaction.Transact(func(adapters Adapters) error {
+		tmp, err := adapters.SocialGraph.GetContact(query.Author, query.Target)
+		if err != nil {
+			return errors.Wrap(err, "failed to get the contact")
+		}
+
+		result = tmp
+		return nil
+	}); err != nil {
+		return nil, errors.Wrap(err, "transaction failed")
+	}
+
+	return result, nil
+}
@@ -2,6 +2,7 @@ package queries
 
 import (
 	"github.com/boreq/errors"
+	"github.com/planetary-social/scuttlego/service/app/common"
 	"github.com/planetary-social/scuttlego/service/domain/feeds/message"
 	"github.com/planetary-social/scuttlego/service/domain/refs"
 )
@@ -25,6 +26,8 @@ func (q *GetMessage) IsZero() bool {
 	return q.id.IsZero()
 }
 
+// GetMessageHandler returns messages which belong to stored feeds or were
+// retrieved out of order using the FetchOutOfOrderMessage command.
 type GetMessageHandler struct {
 	transaction TransactionProvider
 }
@@ -41,9 +44,19 @@ func (h *GetMessageHandler) Handle(query GetMessage) (message.Message, error) {
 	var result message.Message
 	if err := h.transaction.Transact(func(adapters Adapters) error {
 		tmp, err := adapters.Message.Get(query.Id())
-		if err != nil {
+		if err == nil {
+			result = tmp
+			return nil
+		}
+
+		if !errors.Is(err, common.ErrMessageNotFound) {
 			return errors.Wrap(err, "error getting message")
 		}
+
+		tmp, err = adapters.OutOfOrderMessage.Get(query.Id())
+		if err != nil {
+			return errors.Wrap(err, "error getting out-of-order message")
+		}
 		result = tmp
 		return nil
 	}); err != nil {
@@ -5,6 +5,7 @@ import (
 
 	"github.com/planetary-social/scuttlego/internal/fixtures"
 	"github.com/planetary-social/scuttlego/internal/mocks"
+	"github.com/planetary-social/scuttlego/service/app/common"
 	"github.com/planetary-social/scuttlego/service/app/queries"
 	"github.com/planetary-social/scuttlego/service/di"
 	"github.com/stretchr/testify/require"
@@ -33,3 +34,23 @@ func TestGetMessageHandler(t *testing.T) {
 		tq.MessageRepository.GetCalls(),
 	)
 }
+
+func TestGetMessageHandler_FallsBackToOutOfOrderMessages(t *testing.T) {
+	tq, err := di.BuildTestQueries(t)
+	require.NoError(t, err)
+
+	msg := fixtures.SomeMessage(fixtures.SomeSequence(), fixtures.SomeRefFeed())
+
+	query, err := queries.NewGetMessage(msg.Id())
+	require.NoError(t, err)
+
+	_, err = tq.Queries.GetMessage.Handle(query)
+	require.ErrorIs(t, err, common.ErrMessageNotFound)
+
+	err = tq.OutOfOrderMessage.Put(msg)
+	require.NoError(t, err)
+
+	retrievedMsg, err := tq.Queries.GetMessage.Handle(query)
+	require.NoError(t, err)
+	require.Equal(t, msg, retrievedMsg)
+}
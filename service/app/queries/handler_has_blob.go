@@ -0,0 +1,28 @@
+package queries
+
+import (
+	"github.com/boreq/errors"
+	"github.com/planetary-social/scuttlego/service/domain/refs"
+)
+
+type HasBlob struct {
+	Id refs.Blob
+}
+
+type HasBlobHandler struct {
+	storage BlobStorage
+}
+
+func NewHasBlobHandler(storage BlobStorage) *HasBlobHandler {
+	return &HasBlobHandler{
+		storage: storage,
+	}
+}
+
+func (h *HasBlobHandler) Handle(query HasBlob) (bool, error) {
+	if query.Id.IsZero() {
+		return false, errors.New("zero value of id")
+	}
+
+	return h.storage.Has(query.Id)
+}
@@ -0,0 +1,44 @@
+package queries
+
+import (
+	"github.com/boreq/errors"
+	"github.com/planetary-social/scuttlego/service/domain/feeds/message"
+	"github.com/planetary-social/scuttlego/service/domain/refs"
+)
+
+type LatestSequence struct {
+	Feed refs.Feed
+}
+
+type LatestSequenceHandler struct {
+	transaction TransactionProvider
+}
+
+func NewLatestSequenceHandler(transaction TransactionProvider) *LatestSequenceHandler {
+	return &LatestSequenceHandler{
+		transaction: transaction,
+	}
+}
+
+// Handle returns common.ErrFeedNotFound if the feed doesn't exist.
+func (h *LatestSequenceHandler) Handle(query LatestSequence) (message.Sequence, error) {
+	if query.Feed.IsZero() {
+		return message.Sequence{}, errors.New("zero value of feed")
+	}
+
+	var result message.Sequence
+
+	if err := h.transaction.Transact(func(adapters Adapters) error {
+		tmp, err := adapters.Feed.GetSequence(query.Feed)
+		if err != nil {
+			return errors.Wrap(err, "failed to get the sequence")
+		}
+
+		result = tmp
+		return nil
+	}); err != nil {
+		return message.Sequence{}, errors.Wrap(err, "transaction failed")
+	}
+
+	return result, nil
+}
@@ -0,0 +1,33 @@
+package queries_test
+
+import (
+	"testing"
+
+	"github.com/planetary-social/scuttlego/internal/fixtures"
+	"github.com/planetary-social/scuttlego/service/app/common"
+	"github.com/planetary-social/scuttlego/service/app/queries"
+	"github.com/planetary-social/scuttlego/service/di"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLatestSequence(t *testing.T) {
+	a, err := di.BuildTestQueries(t)
+	require.NoError(t, err)
+
+	feed := fixtures.SomeRefFeed()
+	sequence := fixtures.SomeSequence()
+
+	a.FeedRepository.MockGetSequence(feed, sequence)
+
+	result, err := a.Queries.LatestSequence.Handle(queries.LatestSequence{Feed: feed})
+	require.NoError(t, err)
+	require.Equal(t, sequence, result)
+}
+
+func TestLatestSequence_ReturnsErrFeedNotFound(t *testing.T) {
+	a, err := di.BuildTestQueries(t)
+	require.NoError(t, err)
+
+	_, err = a.Queries.LatestSequence.Handle(queries.LatestSequence{Feed: fixtures.SomeRefFeed()})
+	require.ErrorIs(t, err, common.ErrFeedNotFound)
+}
@@ -0,0 +1,26 @@
+package queries
+
+import (
+	"context"
+
+	"github.com/planetary-social/scuttlego/service/domain/feeds/message"
+)
+
+// MessageSavedEventsHandler lets callers observe messages as they are
+// persisted. Messages are delivered only after the transaction in which they
+// were saved is committed.
+type MessageSavedEventsHandler struct {
+	subscriber MessageSubscriber
+}
+
+func NewMessageSavedEventsHandler(subscriber MessageSubscriber) *MessageSavedEventsHandler {
+	return &MessageSavedEventsHandler{subscriber: subscriber}
+}
+
+// Handle returns a channel on which new messages are sent. The channel is
+// closed once the context is cancelled. Saving messages doesn't wait for the
+// caller, messages are buffered and dropped if the caller falls too far
+// behind.
+func (h *MessageSavedEventsHandler) Handle(ctx context.Context) <-chan message.Message {
+	return h.subscriber.SubscribeToNewMessages(ctx)
+}
@@ -0,0 +1,107 @@
+package queries
+
+import (
+	"context"
+
+	"github.com/planetary-social/scuttlego/service/domain/identity"
+	"github.com/planetary-social/scuttlego/service/domain/refs"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc"
+)
+
+type NodeEventSubscriber interface {
+	Subscribe(ctx context.Context) <-chan NodeEvent
+}
+
+// NodeEvent is one of PeerConnected, PeerDisconnected, HandshakeFailed,
+// ReplicationStarted, ReplicationFinished, ForkDetected, MigrationProgress or
+// BanListChanged.
+type NodeEvent interface {
+	isNodeEvent()
+}
+
+type PeerConnected struct {
+	Remote            identity.Public
+	ConnectionId      rpc.ConnectionId
+	InitiatedByRemote bool
+}
+
+type PeerDisconnected struct {
+	Remote       identity.Public
+	ConnectionId rpc.ConnectionId
+}
+
+type HandshakeFailed struct {
+	ConnectionId      rpc.ConnectionId
+	InitiatedByRemote bool
+
+	// Remote is a zero value if the handshake was initiated by the remote as
+	// its identity is not known in that case.
+	Remote identity.Public
+
+	Err error
+}
+
+// ReplicationStarted is published when this node asks a peer for messages
+// from a feed using createHistoryStream or in an EBT session.
+type ReplicationStarted struct {
+	Peer identity.Public
+	Feed refs.Feed
+}
+
+// ReplicationFinished is published once the request described by
+// ReplicationStarted ends. Err is set if it failed.
+type ReplicationFinished struct {
+	Peer             identity.Public
+	Feed             refs.Feed
+	ReceivedMessages int
+	Err              error
+}
+
+// ForkDetected is published when a peer sends a message which doesn't fit
+// into the local copy of a feed.
+type ForkDetected struct {
+	ReplicatedFrom identity.Public
+	Feed           refs.Feed
+}
+
+// MigrationProgress is published when a migration starts running, when it
+// fails in which case Err is set and once all migrations are done in which
+// case Done is set.
+type MigrationProgress struct {
+	MigrationIndex  int
+	MigrationsCount int
+	Done            bool
+	Err             error
+}
+
+// BanListChanged is published after the ban list is modified. The ban list
+// itself isn't included as it contains only hashes.
+type BanListChanged struct {
+}
+
+func (PeerConnected) isNodeEvent()       {}
+func (PeerDisconnected) isNodeEvent()    {}
+func (HandshakeFailed) isNodeEvent()     {}
+func (ReplicationStarted) isNodeEvent()  {}
+func (ReplicationFinished) isNodeEvent() {}
+func (ForkDetected) isNodeEvent()        {}
+func (MigrationProgress) isNodeEvent()   {}
+func (BanListChanged) isNodeEvent()      {}
+
+// NodeEventsHandler lets callers observe what the node is doing e.g. to
+// display it to the user.
+type NodeEventsHandler struct {
+	subscriber NodeEventSubscriber
+}
+
+func NewNodeEventsHandler(subscriber NodeEventSubscriber) *NodeEventsHandler {
+	return &NodeEventsHandler{subscriber: subscriber}
+}
+
+// Handle returns a channel on which events are sent. The channel is closed
+// once the context is cancelled. The node never waits for the caller to
+// receive the events, a limited number of events is buffered and further
+// events are dropped if the caller doesn't keep up.
+func (h *NodeEventsHandler) Handle(ctx context.Context) <-chan NodeEvent {
+	return h.subscriber.Subscribe(ctx)
+}
@@ -0,0 +1,49 @@
+package queries_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/planetary-social/scuttlego/internal/fixtures"
+	"github.com/planetary-social/scuttlego/service/app/queries"
+	"github.com/planetary-social/scuttlego/service/di"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNodeEvents_ReturnsPublishedEvents(t *testing.T) {
+	a, err := di.BuildTestQueries(t)
+	require.NoError(t, err)
+
+	ctx := fixtures.TestContext(t)
+
+	events := a.Queries.NodeEvents.Handle(ctx)
+
+	peer := fixtures.SomePublicIdentity()
+	feed := fixtures.SomeRefFeed()
+	a.NodeEventPubSub.PublishReplicationStarted(peer, feed)
+
+	select {
+	case v := <-events:
+		require.Equal(t, queries.ReplicationStarted{Peer: peer, Feed: feed}, v)
+	case <-time.After(1 * time.Second):
+		t.Fatal("timeout")
+	}
+}
+
+func TestNodeEvents_ChannelIsClosedWhenContextIsCancelled(t *testing.T) {
+	a, err := di.BuildTestQueries(t)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(fixtures.TestContext(t))
+
+	events := a.Queries.NodeEvents.Handle(ctx)
+	cancel()
+
+	select {
+	case _, ok := <-events:
+		require.False(t, ok)
+	case <-time.After(1 * time.Second):
+		t.Fatal("timeout")
+	}
+}
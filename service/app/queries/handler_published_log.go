@@ -3,6 +3,7 @@ package queries
 import (
 	"github.com/boreq/errors"
 	"github.com/planetary-social/scuttlego/service/app/common"
+	"github.com/planetary-social/scuttlego/service/domain/feeds/message"
 	"github.com/planetary-social/scuttlego/service/domain/identity"
 	"github.com/planetary-social/scuttlego/service/domain/refs"
 )
@@ -13,6 +14,16 @@ type PublishedLog struct {
 	// message has multiple receive log sequence numbers the higher one is used.
 	// Pass nil to get all messages.
 	LastSeq *common.ReceiveLogSequence
+
+	// Only messages with receive log sequence numbers lower than the given
+	// receive log sequence are returned. Pass nil to disable the upper bound.
+	EndSeq *common.ReceiveLogSequence
+
+	// Only messages with timestamps falling within this range are returned.
+	Timestamps TimestampRange
+
+	// If reverse is true then the newest messages are returned first.
+	Reverse bool
 }
 
 type PublishedLogHandler struct {
@@ -72,13 +83,19 @@ func (h *PublishedLogHandler) Handle(query PublishedLog) ([]LogMessage, error) {
 				break
 			}
 
-			result = append(
-				[]LogMessage{
-					{
-						Message:  msg,
-						Sequence: receiveLogSequence,
-					},
-				}, result...)
+			if h.shouldInclude(query, msg, receiveLogSequence) {
+				logMessage := LogMessage{
+					Message:  msg,
+					Sequence: receiveLogSequence,
+				}
+
+				// The feed is read starting with the newest message.
+				if query.Reverse {
+					result = append(result, logMessage)
+				} else {
+					result = append([]LogMessage{logMessage}, result...)
+				}
+			}
 
 			tmp, previousSequenceExists := messageSequence.Previous()
 			if !previousSequenceExists {
@@ -96,6 +113,13 @@ func (h *PublishedLogHandler) Handle(query PublishedLog) ([]LogMessage, error) {
 	return result, nil
 }
 
+func (h *PublishedLogHandler) shouldInclude(query PublishedLog, msg message.Message, receiveLogSequence common.ReceiveLogSequence) bool {
+	if query.EndSeq != nil && receiveLogSequence.Int() >= query.EndSeq.Int() {
+		return false
+	}
+	return query.Timestamps.Includes(msg.Timestamp())
+}
+
 func (h *PublishedLogHandler) highestReceiveLogSequence(sequences []common.ReceiveLogSequence) (common.ReceiveLogSequence, error) {
 	if len(sequences) == 0 {
 		return common.ReceiveLogSequence{}, errors.New("no sequences given")
@@ -232,6 +232,49 @@ func TestPublishedLog_HighestSequenceFromTheReturnedSequencesIsUsed(t *testing.T
 	)
 }
 
+func TestPublishedLog_ReverseAndEndSequence(t *testing.T) {
+	app, err := di.BuildTestQueries(t)
+	require.NoError(t, err)
+
+	localFeed := refs.MustNewIdentityFromPublic(app.LocalIdentity).MainFeed()
+	msgs := mockFeedMessages(localFeed, 4)
+
+	f := feeds.NewFeed(nil)
+	for _, msg := range msgs {
+		err := f.AppendMessage(msg)
+		require.NoError(t, err)
+	}
+	app.FeedRepository.GetFeedReturnValue = f
+
+	for i, msg := range msgs {
+		app.ReceiveLogRepository.MockMessage(common.MustNewReceiveLogSequence(i), msg)
+		app.FeedRepository.MockGetMessage(msg)
+	}
+
+	query := queries.PublishedLog{
+		LastSeq: internal.Ptr(common.MustNewReceiveLogSequence(0)),
+		EndSeq:  internal.Ptr(common.MustNewReceiveLogSequence(3)),
+		Reverse: true,
+	}
+
+	result, err := app.Queries.PublishedLog.Handle(query)
+	require.NoError(t, err)
+
+	require.Equal(t,
+		[]queries.LogMessage{
+			{
+				Message:  msgs[2],
+				Sequence: common.MustNewReceiveLogSequence(2),
+			},
+			{
+				Message:  msgs[1],
+				Sequence: common.MustNewReceiveLogSequence(1),
+			},
+		},
+		result,
+	)
+}
+
 func mockFeedMessages(feed refs.Feed, numberOfMessages int) []message.Message {
 	var messages []message.Message
 	for i := 0; i < numberOfMessages; i++ {
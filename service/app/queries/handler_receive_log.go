@@ -10,23 +10,63 @@ type ReceiveLog struct {
 	// returned.
 	startSeq common.ReceiveLogSequence
 
+	// Only messages with a sequence lower than the end sequence are returned.
+	// Nil means that there is no upper bound.
+	endSeq *common.ReceiveLogSequence
+
+	// If reverse is true then the newest messages are returned first.
+	reverse bool
+
+	// Only messages with timestamps falling within this range are returned.
+	timestamps TimestampRange
+
 	// Limit specifies the max number of messages which will be returned. Limit
 	// must be positive.
 	limit int
 }
 
 func NewReceiveLog(startSeq common.ReceiveLogSequence, limit int) (ReceiveLog, error) {
+	return NewReceiveLogInRange(startSeq, nil, false, TimestampRange{}, limit)
+}
+
+// NewReceiveLogInRange creates a query which can read the log newest first.
+// When reading in reverse the end sequence should be used for pagination.
+func NewReceiveLogInRange(
+	startSeq common.ReceiveLogSequence,
+	endSeq *common.ReceiveLogSequence,
+	reverse bool,
+	timestamps TimestampRange,
+	limit int,
+) (ReceiveLog, error) {
 	if limit <= 0 {
 		return ReceiveLog{}, errors.New("limit must be positive")
 	}
 
-	return ReceiveLog{startSeq: startSeq, limit: limit}, nil
+	return ReceiveLog{
+		startSeq:   startSeq,
+		endSeq:     endSeq,
+		reverse:    reverse,
+		timestamps: timestamps,
+		limit:      limit,
+	}, nil
 }
 
 func (r ReceiveLog) StartSeq() common.ReceiveLogSequence {
 	return r.startSeq
 }
 
+func (r ReceiveLog) EndSeq() *common.ReceiveLogSequence {
+	return r.endSeq
+}
+
+func (r ReceiveLog) Reverse() bool {
+	return r.reverse
+}
+
+func (r ReceiveLog) Timestamps() TimestampRange {
+	return r.timestamps
+}
+
 func (r ReceiveLog) Limit() int {
 	return r.limit
 }
@@ -51,7 +91,13 @@ func (h *ReceiveLogHandler) Handle(query ReceiveLog) ([]LogMessage, error) {
 	var result []LogMessage
 
 	if err := h.transaction.Transact(func(adapters Adapters) error {
-		tmp, err := adapters.ReceiveLog.List(query.StartSeq(), query.Limit())
+		tmp, err := adapters.ReceiveLog.List(ReceiveLogListOptions{
+			Gte:        query.StartSeq(),
+			Lt:         query.EndSeq(),
+			Reverse:    query.Reverse(),
+			Timestamps: query.Timestamps(),
+			Limit:      query.Limit(),
+		})
 		if err != nil {
 			return errors.Wrap(err, "error listing messages")
 		}
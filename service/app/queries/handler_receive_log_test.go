@@ -2,9 +2,12 @@ package queries_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/boreq/errors"
+	"github.com/planetary-social/scuttlego/internal"
 	"github.com/planetary-social/scuttlego/internal/fixtures"
+	"github.com/planetary-social/scuttlego/service/app/common"
 	"github.com/planetary-social/scuttlego/service/app/queries"
 	"github.com/stretchr/testify/require"
 )
@@ -46,3 +49,20 @@ func TestReceiveLog(t *testing.T) {
 		})
 	}
 }
+
+func TestNewReceiveLogInRange(t *testing.T) {
+	startSeq := common.MustNewReceiveLogSequence(1)
+	endSeq := common.MustNewReceiveLogSequence(10)
+	timestamps := queries.TimestampRange{
+		Gte: internal.Ptr(time.UnixMilli(100)),
+		Lt:  internal.Ptr(time.UnixMilli(200)),
+	}
+
+	q, err := queries.NewReceiveLogInRange(startSeq, &endSeq, true, timestamps, 5)
+	require.NoError(t, err)
+	require.Equal(t, startSeq, q.StartSeq())
+	require.Equal(t, &endSeq, q.EndSeq())
+	require.True(t, q.Reverse())
+	require.Equal(t, timestamps, q.Timestamps())
+	require.Equal(t, 5, q.Limit())
+}
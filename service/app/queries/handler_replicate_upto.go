@@ -0,0 +1,97 @@
+package queries
+
+import (
+	"github.com/boreq/errors"
+	"github.com/planetary-social/scuttlego/service/domain/feeds/message"
+	"github.com/planetary-social/scuttlego/service/domain/refs"
+)
+
+type ReplicateUpto struct {
+	after *refs.Feed
+	limit int
+}
+
+// NewReplicateUpto creates a query which returns up to limit feeds starting
+// after the provided feed. If after is nil feeds are returned starting from
+// the first one.
+func NewReplicateUpto(after *refs.Feed, limit int) (ReplicateUpto, error) {
+	if after != nil && after.IsZero() {
+		return ReplicateUpto{}, errors.New("zero value of after")
+	}
+	if limit <= 0 {
+		return ReplicateUpto{}, errors.New("limit must be positive")
+	}
+	return ReplicateUpto{after: after, limit: limit}, nil
+}
+
+func MustNewReplicateUpto(after *refs.Feed, limit int) ReplicateUpto {
+	v, err := NewReplicateUpto(after, limit)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+func (q ReplicateUpto) After() *refs.Feed {
+	return q.after
+}
+
+func (q ReplicateUpto) Limit() int {
+	return q.limit
+}
+
+func (q ReplicateUpto) IsZero() bool {
+	return q.limit == 0
+}
+
+type FeedSequence struct {
+	Feed     refs.Feed
+	Sequence message.Sequence
+}
+
+// ReplicateUptoHandler returns the latest sequence of feeds for which at least
+// one message is stored. The results are paginated so that the entire list
+// of feeds doesn't have to be loaded in a single transaction. Fewer results
+// than the limit indicate that there are no more feeds.
+type ReplicateUptoHandler struct {
+	transaction TransactionProvider
+}
+
+func NewReplicateUptoHandler(transaction TransactionProvider) *ReplicateUptoHandler {
+	return &ReplicateUptoHandler{
+		transaction: transaction,
+	}
+}
+
+func (h *ReplicateUptoHandler) Handle(query ReplicateUpto) ([]FeedSequence, error) {
+	if query.IsZero() {
+		return nil, errors.New("zero value of query")
+	}
+
+	var result []FeedSequence
+
+	if err := h.transaction.Transact(func(adapters Adapters) error {
+		feeds, err := adapters.Feed.ListFeeds(query.After(), query.Limit())
+		if err != nil {
+			return errors.Wrap(err, "error listing feeds")
+		}
+
+		for _, feed := range feeds {
+			sequence, err := adapters.Feed.GetSequence(feed)
+			if err != nil {
+				return errors.Wrapf(err, "error getting sequence of feed '%s'", feed)
+			}
+
+			result = append(result, FeedSequence{
+				Feed:     feed,
+				Sequence: sequence,
+			})
+		}
+
+		return nil
+	}); err != nil {
+		return nil, errors.Wrap(err, "transaction failed")
+	}
+
+	return result, nil
+}
@@ -0,0 +1,103 @@
+package queries_test
+
+import (
+	"testing"
+
+	"github.com/planetary-social/scuttlego/internal/fixtures"
+	"github.com/planetary-social/scuttlego/service/app/queries"
+	"github.com/planetary-social/scuttlego/service/di"
+	"github.com/planetary-social/scuttlego/service/domain/graph"
+	"github.com/planetary-social/scuttlego/service/domain/refs"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReplicateUpto_ReturnsEmptyListIfNoFeedsAreStored(t *testing.T) {
+	a, err := di.BuildTestQueries(t)
+	require.NoError(t, err)
+
+	result, err := a.Queries.ReplicateUpto.Handle(queries.MustNewReplicateUpto(nil, 10))
+	require.NoError(t, err)
+	require.Empty(t, result)
+}
+
+func TestReplicateUpto_ReturnsSequencesOfStoredFeeds(t *testing.T) {
+	a, err := di.BuildTestQueries(t)
+	require.NoError(t, err)
+
+	contactWithoutMessages := fixtures.SomeRefIdentity()
+	storedFeed1 := fixtures.SomeRefFeed()
+	storedFeed2 := fixtures.SomeRefFeed()
+
+	storedFeed1Sequence := fixtures.SomeSequence()
+	storedFeed2Sequence := fixtures.SomeSequence()
+
+	a.SocialGraphRepository.GetSocialGraphReturnValue = graph.NewSocialGraph(map[string]graph.Hops{
+		contactWithoutMessages.String(): fixtures.SomeHops(),
+	})
+
+	a.FeedRepository.MockGetSequence(storedFeed1, storedFeed1Sequence)
+	a.FeedRepository.MockGetSequence(storedFeed2, storedFeed2Sequence)
+
+	result, err := a.Queries.ReplicateUpto.Handle(queries.MustNewReplicateUpto(nil, 10))
+	require.NoError(t, err)
+	require.ElementsMatch(t,
+		[]queries.FeedSequence{
+			{
+				Feed:     storedFeed1,
+				Sequence: storedFeed1Sequence,
+			},
+			{
+				Feed:     storedFeed2,
+				Sequence: storedFeed2Sequence,
+			},
+		},
+		result,
+	)
+}
+
+func TestReplicateUpto_ReturnsFeedsAfterTheProvidedFeed(t *testing.T) {
+	a, err := di.BuildTestQueries(t)
+	require.NoError(t, err)
+
+	var sequences []queries.FeedSequence
+	for i := 0; i < 5; i++ {
+		feedSequence := queries.FeedSequence{
+			Feed:     fixtures.SomeRefFeed(),
+			Sequence: fixtures.SomeSequence(),
+		}
+		a.FeedRepository.MockGetSequence(feedSequence.Feed, feedSequence.Sequence)
+		sequences = append(sequences, feedSequence)
+	}
+
+	var result []queries.FeedSequence
+	var after *refs.Feed
+	for {
+		page, err := a.Queries.ReplicateUpto.Handle(queries.MustNewReplicateUpto(after, 2))
+		require.NoError(t, err)
+		require.LessOrEqual(t, len(page), 2)
+
+		result = append(result, page...)
+
+		if len(page) < 2 {
+			break
+		}
+
+		after = &page[len(page)-1].Feed
+	}
+
+	require.ElementsMatch(t, sequences, result)
+}
+
+func TestNewReplicateUpto(t *testing.T) {
+	_, err := queries.NewReplicateUpto(nil, 0)
+	require.Error(t, err)
+
+	_, err = queries.NewReplicateUpto(&refs.Feed{}, 1)
+	require.Error(t, err)
+
+	feed := fixtures.SomeRefFeed()
+	query, err := queries.NewReplicateUpto(&feed, 1)
+	require.NoError(t, err)
+	require.Equal(t, &feed, query.After())
+	require.Equal(t, 1, query.Limit())
+}
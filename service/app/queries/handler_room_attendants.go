@@ -0,0 +1,90 @@
+package queries
+
+import (
+	"time"
+
+	"github.com/planetary-social/scuttlego/service/domain/refs"
+	"github.com/planetary-social/scuttlego/service/domain/rooms"
+	"github.com/planetary-social/scuttlego/service/domain/transport"
+)
+
+type RoomAttendantsPortal struct {
+	Portal     refs.Identity
+	Attendants []RoomAttendant
+}
+
+type RoomAttendant struct {
+	Id refs.Identity
+
+	// Joined is the time at which we learned that the attendant joined the
+	// room.
+	Joined time.Time
+
+	// Tunnelled is set to true if we are currently connected to the
+	// attendant using a tunnel opened via this room. It becomes false once
+	// the tunnel is closed.
+	Tunnelled bool
+}
+
+// RoomAttendantsHandler returns attendants of rooms that we are currently
+// connected to.
+type RoomAttendantsHandler struct {
+	registry    RoomAttendantsRegistry
+	peerManager PeerManager
+}
+
+func NewRoomAttendantsHandler(
+	registry RoomAttendantsRegistry,
+	peerManager PeerManager,
+) *RoomAttendantsHandler {
+	return &RoomAttendantsHandler{
+		registry:    registry,
+		peerManager: peerManager,
+	}
+}
+
+func (h *RoomAttendantsHandler) Handle() []RoomAttendantsPortal {
+	return listRoomAttendants(h.registry, h.peerManager)
+}
+
+func listRoomAttendants(registry RoomAttendantsRegistry, peerManager PeerManager) []RoomAttendantsPortal {
+	connected := make(map[string]transport.Connection)
+	for _, peer := range peerManager.Peers() {
+		connected[refs.MustNewIdentityFromPublic(peer.Identity()).String()] = peer.Conn()
+	}
+
+	var result []RoomAttendantsPortal
+	for _, portal := range registry.List() {
+		result = append(result, newRoomAttendantsPortal(portal, connected))
+	}
+	return result
+}
+
+func newRoomAttendantsPortal(portal rooms.PortalAttendants, connected map[string]transport.Connection) RoomAttendantsPortal {
+	result := RoomAttendantsPortal{
+		Portal: portal.Portal(),
+	}
+
+	for _, attendant := range portal.Attendants() {
+		result.Attendants = append(result.Attendants, RoomAttendant{
+			Id:        attendant.Id(),
+			Joined:    attendant.Joined(),
+			Tunnelled: isTunnelled(attendant, connected),
+		})
+	}
+
+	return result
+}
+
+// isTunnelled checks if the connection which is currently open with the
+// attendant is the tunnel which we opened via the room. The tunnel is
+// removed from the live connections once it is closed.
+func isTunnelled(attendant rooms.Attendant, connected map[string]transport.Connection) bool {
+	tunnel, ok := attendant.Tunnel()
+	if !ok {
+		return false
+	}
+
+	conn, ok := connected[attendant.Id().String()]
+	return ok && conn == tunnel
+}
@@ -0,0 +1,54 @@
+package queries
+
+import (
+	"context"
+)
+
+// RoomAttendantsSubscribeHandler returns a channel which receives attendants
+// of all rooms that we are connected to. The current state is sent right away
+// and then again every time attendants of any of the rooms change. The channel
+// is closed when the context is cancelled.
+type RoomAttendantsSubscribeHandler struct {
+	registry    RoomAttendantsRegistry
+	peerManager PeerManager
+}
+
+func NewRoomAttendantsSubscribeHandler(
+	registry RoomAttendantsRegistry,
+	peerManager PeerManager,
+) *RoomAttendantsSubscribeHandler {
+	return &RoomAttendantsSubscribeHandler{
+		registry:    registry,
+		peerManager: peerManager,
+	}
+}
+
+func (h *RoomAttendantsSubscribeHandler) Handle(ctx context.Context) <-chan []RoomAttendantsPortal {
+	changes := h.registry.Subscribe(ctx)
+	ch := make(chan []RoomAttendantsPortal)
+
+	go func() {
+		defer close(ch)
+
+		if !h.send(ctx, ch) {
+			return
+		}
+
+		for range changes {
+			if !h.send(ctx, ch) {
+				return
+			}
+		}
+	}()
+
+	return ch
+}
+
+func (h *RoomAttendantsSubscribeHandler) send(ctx context.Context, ch chan<- []RoomAttendantsPortal) bool {
+	select {
+	case ch <- listRoomAttendants(h.registry, h.peerManager):
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
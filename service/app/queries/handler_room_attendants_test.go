@@ -0,0 +1,138 @@
+package queries_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/planetary-social/scuttlego/internal/fixtures"
+	"github.com/planetary-social/scuttlego/internal/mocks"
+	"github.com/planetary-social/scuttlego/service/app/queries"
+	"github.com/planetary-social/scuttlego/service/di"
+	"github.com/planetary-social/scuttlego/service/domain/refs"
+	"github.com/planetary-social/scuttlego/service/domain/transport"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoomAttendants_AttendantsAreTunnelledOnlyIfTunnelWasEstablishedAndTheyAreConnected(t *testing.T) {
+	a, err := di.BuildTestQueries(t)
+	require.NoError(t, err)
+
+	ctx := fixtures.TestContext(t)
+
+	portal := transport.MustNewPeer(fixtures.SomePublicIdentity(), mocks.NewConnectionMock(ctx))
+	tunnelledAndConnected := fixtures.SomeRefIdentity()
+	tunnelledAndDisconnected := fixtures.SomeRefIdentity()
+	notTunnelledButConnected := fixtures.SomeRefIdentity()
+
+	joined := fixtures.SomeTime()
+
+	a.RoomAttendantsRegistry.Joined(ctx, portal, tunnelledAndConnected, joined)
+	a.RoomAttendantsRegistry.Joined(ctx, portal, tunnelledAndDisconnected, joined.Add(1*time.Second))
+	a.RoomAttendantsRegistry.Joined(ctx, portal, notTunnelledButConnected, joined.Add(2*time.Second))
+
+	tunnelledAndConnectedTunnel := transport.MustNewPeer(tunnelledAndConnected.Identity(), mocks.NewConnectionMock(ctx))
+	tunnelledAndDisconnectedTunnel := transport.MustNewPeer(tunnelledAndDisconnected.Identity(), mocks.NewConnectionMock(ctx))
+	a.RoomAttendantsRegistry.TunnelEstablished(portal, tunnelledAndConnected, tunnelledAndConnectedTunnel)
+	a.RoomAttendantsRegistry.TunnelEstablished(portal, tunnelledAndDisconnected, tunnelledAndDisconnectedTunnel)
+
+	a.PeerManager.MockPeers([]transport.Peer{
+		portal,
+		tunnelledAndConnectedTunnel,
+		transport.MustNewPeer(notTunnelledButConnected.Identity(), mocks.NewConnectionMock(ctx)),
+	})
+
+	result := a.Queries.RoomAttendants.Handle()
+	require.Equal(t,
+		[]queries.RoomAttendantsPortal{
+			{
+				Portal: refs.MustNewIdentityFromPublic(portal.Identity()),
+				Attendants: []queries.RoomAttendant{
+					{
+						Id:        tunnelledAndConnected,
+						Joined:    joined,
+						Tunnelled: true,
+					},
+					{
+						Id:        tunnelledAndDisconnected,
+						Joined:    joined.Add(1 * time.Second),
+						Tunnelled: false,
+					},
+					{
+						Id:        notTunnelledButConnected,
+						Joined:    joined.Add(2 * time.Second),
+						Tunnelled: false,
+					},
+				},
+			},
+		},
+		result,
+	)
+}
+
+func TestRoomAttendants_AttendantsAreNoLongerTunnelledOnceTheTunnelIsClosed(t *testing.T) {
+	a, err := di.BuildTestQueries(t)
+	require.NoError(t, err)
+
+	ctx := fixtures.TestContext(t)
+
+	portal := transport.MustNewPeer(fixtures.SomePublicIdentity(), mocks.NewConnectionMock(ctx))
+	attendant := fixtures.SomeRefIdentity()
+	tunnelConn := mocks.NewConnectionMock(ctx)
+	tunnel := transport.MustNewPeer(attendant.Identity(), tunnelConn)
+
+	a.RoomAttendantsRegistry.Joined(ctx, portal, attendant, fixtures.SomeTime())
+	a.RoomAttendantsRegistry.TunnelEstablished(portal, attendant, tunnel)
+	a.PeerManager.MockPeers([]transport.Peer{portal, tunnel})
+
+	isTunnelled := func() bool {
+		result := a.Queries.RoomAttendants.Handle()
+		require.Len(t, result, 1)
+		require.Len(t, result[0].Attendants, 1)
+		return result[0].Attendants[0].Tunnelled
+	}
+
+	require.True(t, isTunnelled())
+
+	err = tunnelConn.Close()
+	require.NoError(t, err)
+	a.PeerManager.MockPeers([]transport.Peer{portal})
+
+	require.False(t, isTunnelled(), "the tunnel was closed")
+
+	a.PeerManager.MockPeers([]transport.Peer{
+		portal,
+		transport.MustNewPeer(attendant.Identity(), mocks.NewConnectionMock(ctx)),
+	})
+
+	require.False(t, isTunnelled(), "a new connection which isn't the tunnel was established")
+}
+
+func TestRoomAttendantsSubscribe_SendsCurrentStateAndThenChanges(t *testing.T) {
+	a, err := di.BuildTestQueries(t)
+	require.NoError(t, err)
+
+	ctx := fixtures.TestContext(t)
+
+	portal := transport.MustNewPeer(fixtures.SomePublicIdentity(), mocks.NewConnectionMock(ctx))
+	attendant := fixtures.SomeRefIdentity()
+
+	ch := a.Queries.RoomAttendantsSubscribe.Handle(ctx)
+
+	select {
+	case v := <-ch:
+		require.Empty(t, v)
+	case <-time.After(time.Second):
+		t.Fatal("timeout")
+	}
+
+	go a.RoomAttendantsRegistry.Joined(ctx, portal, attendant, fixtures.SomeTime())
+
+	select {
+	case v := <-ch:
+		require.Len(t, v, 1)
+		require.Len(t, v[0].Attendants, 1)
+		require.Equal(t, attendant, v[0].Attendants[0].Id)
+	case <-time.After(time.Second):
+		t.Fatal("timeout")
+	}
+}
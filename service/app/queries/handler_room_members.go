@@ -0,0 +1,36 @@
+package queries
+
+import (
+	"github.com/boreq/errors"
+	"github.com/planetary-social/scuttlego/service/domain/refs"
+)
+
+type RoomMembersHandler struct {
+	transaction TransactionProvider
+}
+
+func NewRoomMembersHandler(
+	transaction TransactionProvider,
+) *RoomMembersHandler {
+	return &RoomMembersHandler{
+		transaction: transaction,
+	}
+}
+
+// Handle returns members of the room hosted by this node.
+func (h *RoomMembersHandler) Handle() ([]refs.Identity, error) {
+	var result []refs.Identity
+
+	if err := h.transaction.Transact(func(adapters Adapters) error {
+		tmp, err := adapters.RoomMember.List()
+		if err != nil {
+			return errors.Wrap(err, "error listing members")
+		}
+		result = tmp
+		return nil
+	}); err != nil {
+		return nil, errors.Wrap(err, "transaction failed")
+	}
+
+	return result, nil
+}
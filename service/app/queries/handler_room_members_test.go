@@ -0,0 +1,45 @@
+package queries_test
+
+import (
+	"testing"
+
+	"github.com/planetary-social/scuttlego/internal/fixtures"
+	"github.com/planetary-social/scuttlego/internal/mocks"
+	"github.com/planetary-social/scuttlego/service/app/queries"
+	"github.com/planetary-social/scuttlego/service/domain/refs"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoomMembersHandler(t *testing.T) {
+	testCases := []struct {
+		Name    string
+		Members []refs.Identity
+	}{
+		{
+			Name:    "no_members",
+			Members: nil,
+		},
+		{
+			Name: "members",
+			Members: []refs.Identity{
+				fixtures.SomeRefIdentity(),
+				fixtures.SomeRefIdentity(),
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			roomMember := mocks.NewRoomMemberRepositoryMock()
+			for _, member := range testCase.Members {
+				roomMember.Mock(member)
+			}
+
+			handler := queries.NewRoomMembersHandler(mocks.NewMockQueriesTransactionProvider(queries.Adapters{RoomMember: roomMember}))
+
+			members, err := handler.Handle()
+			require.NoError(t, err)
+			require.ElementsMatch(t, testCase.Members, members)
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package queries
+
+import (
+	"context"
+
+	"github.com/boreq/errors"
+	"github.com/planetary-social/scuttlego/service/domain/identity"
+	"github.com/planetary-social/scuttlego/service/domain/refs"
+	"github.com/planetary-social/scuttlego/service/domain/rooms"
+)
+
+type RoomServerAttendants struct {
+	remote identity.Public
+}
+
+func NewRoomServerAttendants(remote identity.Public) (RoomServerAttendants, error) {
+	if remote.IsZero() {
+		return RoomServerAttendants{}, errors.New("zero value of remote")
+	}
+	return RoomServerAttendants{remote: remote}, nil
+}
+
+func MustNewRoomServerAttendants(remote identity.Public) RoomServerAttendants {
+	v, err := NewRoomServerAttendants(remote)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+func (q RoomServerAttendants) Remote() identity.Public {
+	return q.remote
+}
+
+func (q RoomServerAttendants) IsZero() bool {
+	return q.remote.IsZero()
+}
+
+type RoomServerAttendantsHandler struct {
+	transaction TransactionProvider
+	roomServer  RoomServer
+}
+
+func NewRoomServerAttendantsHandler(
+	transaction TransactionProvider,
+	roomServer RoomServer,
+) *RoomServerAttendantsHandler {
+	return &RoomServerAttendantsHandler{
+		transaction: transaction,
+		roomServer:  roomServer,
+	}
+}
+
+// Handle returns the current list of attendants and a channel which receives
+// events when attendants join or leave the room. The channel is closed when
+// the context is cancelled.
+func (h *RoomServerAttendantsHandler) Handle(ctx context.Context, query RoomServerAttendants) ([]refs.Identity, <-chan rooms.RoomAttendantsEvent, error) {
+	if query.IsZero() {
+		return nil, nil, errors.New("zero value of query")
+	}
+
+	member, err := isRoomMember(h.transaction, query.remote)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "error checking membership")
+	}
+
+	return h.roomServer.Attendants(ctx, member)
+}
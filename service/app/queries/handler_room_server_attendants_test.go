@@ -0,0 +1,154 @@
+package queries_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/planetary-social/scuttlego/internal/fixtures"
+	"github.com/planetary-social/scuttlego/internal/mocks"
+	"github.com/planetary-social/scuttlego/service/app/queries"
+	"github.com/planetary-social/scuttlego/service/domain/identity"
+	"github.com/planetary-social/scuttlego/service/domain/refs"
+	"github.com/planetary-social/scuttlego/service/domain/rooms"
+	"github.com/planetary-social/scuttlego/service/domain/rooms/server"
+	"github.com/planetary-social/scuttlego/service/domain/transport"
+	"github.com/stretchr/testify/require"
+)
+
+// roomServerAccessTestCases describe who can use a room depending on its
+// privacy mode.
+var roomServerAccessTestCases = []struct {
+	Name          string
+	Mode          server.PrivacyMode
+	Member        bool
+	ExpectedError error
+}{
+	{
+		Name:          "disabled",
+		Mode:          server.PrivacyMode{},
+		Member:        true,
+		ExpectedError: server.ErrRoomDisabled,
+	},
+	{
+		Name:          "open_member",
+		Mode:          server.PrivacyModeOpen,
+		Member:        true,
+		ExpectedError: nil,
+	},
+	{
+		Name:          "open_non_member",
+		Mode:          server.PrivacyModeOpen,
+		Member:        false,
+		ExpectedError: nil,
+	},
+	{
+		Name:          "community_member",
+		Mode:          server.PrivacyModeCommunity,
+		Member:        true,
+		ExpectedError: nil,
+	},
+	{
+		Name:          "community_non_member",
+		Mode:          server.PrivacyModeCommunity,
+		Member:        false,
+		ExpectedError: server.ErrNotAMember,
+	},
+	{
+		Name:          "restricted_member",
+		Mode:          server.PrivacyModeRestricted,
+		Member:        true,
+		ExpectedError: nil,
+	},
+	{
+		Name:          "restricted_non_member",
+		Mode:          server.PrivacyModeRestricted,
+		Member:        false,
+		ExpectedError: server.ErrNotAMember,
+	},
+}
+
+func TestRoomServerAttendantsHandler(t *testing.T) {
+	for _, testCase := range roomServerAccessTestCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			ctx := fixtures.TestContext(t)
+			ts := newRoomServerTest(t, testCase.Mode)
+
+			remote := ts.Remote(testCase.Member)
+			attendant := ts.RunAttendant(ctx)
+
+			handler := queries.NewRoomServerAttendantsHandler(ts.Transaction, ts.Room)
+
+			state, events, err := handler.Handle(ctx, queries.MustNewRoomServerAttendants(remote))
+			if testCase.ExpectedError != nil {
+				require.ErrorIs(t, err, testCase.ExpectedError)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, []refs.Identity{refs.MustNewIdentityFromPublic(attendant.Identity())}, state)
+
+			err = ts.Room.Leave(attendant.Identity())
+			require.NoError(t, err)
+
+			select {
+			case event := <-events:
+				require.Equal(t, rooms.RoomAttendantsEventTypeLeft, event.Typ())
+				require.Equal(t, refs.MustNewIdentityFromPublic(attendant.Identity()), event.Id())
+			case <-time.After(5 * time.Second):
+				t.Fatal("timeout")
+			}
+		})
+	}
+}
+
+type roomServerTest struct {
+	t           *testing.T
+	Room        *server.Room
+	RoomMember  *mocks.RoomMemberRepositoryMock
+	RoomAlias   *mocks.RoomAliasRepositoryMock
+	Transaction *mocks.MockQueriesTransactionProvider
+}
+
+func newRoomServerTest(t *testing.T, mode server.PrivacyMode) roomServerTest {
+	roomMember := mocks.NewRoomMemberRepositoryMock()
+	roomAlias := mocks.NewRoomAliasRepositoryMock()
+	return roomServerTest{
+		t:          t,
+		Room:       server.NewRoom(fixtures.SomePublicIdentity(), server.Config{PrivacyMode: mode}, fixtures.TestLogger(t)),
+		RoomMember: roomMember,
+		RoomAlias:  roomAlias,
+		Transaction: mocks.NewMockQueriesTransactionProvider(queries.Adapters{
+			RoomMember: roomMember,
+			RoomAlias:  roomAlias,
+		}),
+	}
+}
+
+// Remote returns an identity of a peer which optionally is a member.
+func (ts roomServerTest) Remote(member bool) identity.Public {
+	remote := fixtures.SomePublicIdentity()
+	if member {
+		ts.RoomMember.Mock(refs.MustNewIdentityFromPublic(remote))
+	}
+	return remote
+}
+
+// RunAttendant connects a new peer to the room and announces it unless the
+// room is disabled.
+func (ts roomServerTest) RunAttendant(ctx context.Context) transport.Peer {
+	peer := transport.MustNewPeer(fixtures.SomePublicIdentity(), mocks.NewConnectionMock(ctx))
+
+	go func() {
+		_ = ts.Room.Run(ctx, peer)
+	}()
+
+	if _, err := ts.Room.Metadata(true); err != nil {
+		return peer
+	}
+
+	require.Eventually(ts.t, func() bool {
+		return ts.Room.Announce(peer.Identity(), true) == nil
+	}, 5*time.Second, 10*time.Millisecond)
+
+	return peer
+}
@@ -0,0 +1,66 @@
+package queries
+
+import (
+	"context"
+
+	"github.com/boreq/errors"
+	"github.com/planetary-social/scuttlego/service/domain/identity"
+	"github.com/planetary-social/scuttlego/service/domain/refs"
+)
+
+type RoomServerEndpoints struct {
+	remote identity.Public
+}
+
+func NewRoomServerEndpoints(remote identity.Public) (RoomServerEndpoints, error) {
+	if remote.IsZero() {
+		return RoomServerEndpoints{}, errors.New("zero value of remote")
+	}
+	return RoomServerEndpoints{remote: remote}, nil
+}
+
+func MustNewRoomServerEndpoints(remote identity.Public) RoomServerEndpoints {
+	v, err := NewRoomServerEndpoints(remote)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+func (q RoomServerEndpoints) Remote() identity.Public {
+	return q.remote
+}
+
+func (q RoomServerEndpoints) IsZero() bool {
+	return q.remote.IsZero()
+}
+
+type RoomServerEndpointsHandler struct {
+	transaction TransactionProvider
+	roomServer  RoomServer
+}
+
+func NewRoomServerEndpointsHandler(
+	transaction TransactionProvider,
+	roomServer RoomServer,
+) *RoomServerEndpointsHandler {
+	return &RoomServerEndpointsHandler{
+		transaction: transaction,
+		roomServer:  roomServer,
+	}
+}
+
+// Handle returns a channel which receives the list of attendants every time
+// that list changes. The channel is closed when the context is cancelled.
+func (h *RoomServerEndpointsHandler) Handle(ctx context.Context, query RoomServerEndpoints) (<-chan []refs.Identity, error) {
+	if query.IsZero() {
+		return nil, errors.New("zero value of query")
+	}
+
+	member, err := isRoomMember(h.transaction, query.remote)
+	if err != nil {
+		return nil, errors.Wrap(err, "error checking membership")
+	}
+
+	return h.roomServer.Endpoints(ctx, member)
+}
@@ -0,0 +1,39 @@
+package queries_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/planetary-social/scuttlego/internal/fixtures"
+	"github.com/planetary-social/scuttlego/service/app/queries"
+	"github.com/planetary-social/scuttlego/service/domain/refs"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoomServerEndpointsHandler(t *testing.T) {
+	for _, testCase := range roomServerAccessTestCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			ctx := fixtures.TestContext(t)
+			ts := newRoomServerTest(t, testCase.Mode)
+
+			remote := ts.Remote(testCase.Member)
+			attendant := ts.RunAttendant(ctx)
+
+			handler := queries.NewRoomServerEndpointsHandler(ts.Transaction, ts.Room)
+
+			endpoints, err := handler.Handle(ctx, queries.MustNewRoomServerEndpoints(remote))
+			if testCase.ExpectedError != nil {
+				require.ErrorIs(t, err, testCase.ExpectedError)
+				return
+			}
+			require.NoError(t, err)
+
+			select {
+			case v := <-endpoints:
+				require.Equal(t, []refs.Identity{refs.MustNewIdentityFromPublic(attendant.Identity())}, v)
+			case <-time.After(5 * time.Second):
+				t.Fatal("timeout")
+			}
+		})
+	}
+}
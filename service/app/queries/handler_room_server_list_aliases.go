@@ -0,0 +1,95 @@
+package queries
+
+import (
+	"github.com/boreq/errors"
+	"github.com/planetary-social/scuttlego/service/domain/identity"
+	"github.com/planetary-social/scuttlego/service/domain/refs"
+	"github.com/planetary-social/scuttlego/service/domain/rooms/aliases"
+)
+
+type RoomServerListAliases struct {
+	remote identity.Public
+	user   refs.Identity
+}
+
+func NewRoomServerListAliases(remote identity.Public, user refs.Identity) (RoomServerListAliases, error) {
+	if remote.IsZero() {
+		return RoomServerListAliases{}, errors.New("zero value of remote")
+	}
+
+	if user.IsZero() {
+		return RoomServerListAliases{}, errors.New("zero value of user")
+	}
+
+	return RoomServerListAliases{
+		remote: remote,
+		user:   user,
+	}, nil
+}
+
+func MustNewRoomServerListAliases(remote identity.Public, user refs.Identity) RoomServerListAliases {
+	v, err := NewRoomServerListAliases(remote, user)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+func (q RoomServerListAliases) Remote() identity.Public {
+	return q.remote
+}
+
+func (q RoomServerListAliases) User() refs.Identity {
+	return q.user
+}
+
+func (q RoomServerListAliases) IsZero() bool {
+	return q.remote.IsZero()
+}
+
+type RoomServerListAliasesHandler struct {
+	transaction TransactionProvider
+	roomServer  RoomServer
+}
+
+func NewRoomServerListAliasesHandler(
+	transaction TransactionProvider,
+	roomServer RoomServer,
+) *RoomServerListAliasesHandler {
+	return &RoomServerListAliasesHandler{
+		transaction: transaction,
+		roomServer:  roomServer,
+	}
+}
+
+// Handle returns aliases registered by the user in the room hosted by this
+// node.
+func (h *RoomServerListAliasesHandler) Handle(query RoomServerListAliases) ([]aliases.Alias, error) {
+	if query.IsZero() {
+		return nil, errors.New("zero value of query")
+	}
+
+	member, err := isRoomMember(h.transaction, query.remote)
+	if err != nil {
+		return nil, errors.Wrap(err, "error checking membership")
+	}
+
+	if err := h.roomServer.CanUse(member); err != nil {
+		return nil, errors.Wrap(err, "access denied")
+	}
+
+	var result []aliases.Alias
+
+	if err := h.transaction.Transact(func(adapters Adapters) error {
+		tmp, err := adapters.RoomAlias.List(query.user)
+		if err != nil {
+			return errors.Wrap(err, "error listing aliases")
+		}
+		result = tmp
+		return nil
+	}); err != nil {
+		return nil, errors.Wrap(err, "transaction failed")
+	}
+
+	return result, nil
+}
@@ -0,0 +1,65 @@
+package queries_test
+
+import (
+	"testing"
+
+	"github.com/planetary-social/scuttlego/internal/fixtures"
+	"github.com/planetary-social/scuttlego/service/app/queries"
+	"github.com/planetary-social/scuttlego/service/domain/identity"
+	"github.com/planetary-social/scuttlego/service/domain/refs"
+	"github.com/planetary-social/scuttlego/service/domain/rooms/aliases"
+	"github.com/planetary-social/scuttlego/service/domain/rooms/server"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoomServerListAliasesHandler(t *testing.T) {
+	for _, testCase := range roomServerAccessTestCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			ts := newRoomServerTest(t, testCase.Mode)
+
+			remote := ts.Remote(testCase.Member)
+
+			user := fixtures.SomePrivateIdentity()
+			userRef := refs.MustNewIdentityFromPublic(user.Public())
+			alias := ts.RegisterAlias(user)
+			ts.RegisterAlias(fixtures.SomePrivateIdentity())
+
+			handler := queries.NewRoomServerListAliasesHandler(ts.Transaction, ts.Room)
+
+			result, err := handler.Handle(queries.MustNewRoomServerListAliases(remote, userRef))
+			if testCase.ExpectedError != nil {
+				require.ErrorIs(t, err, testCase.ExpectedError)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, []aliases.Alias{alias}, result)
+		})
+	}
+}
+
+func TestRoomServerListAliasesHandler_ReturnsAnEmptyListIfUserHasNoAliases(t *testing.T) {
+	ts := newRoomServerTest(t, server.PrivacyModeOpen)
+
+	handler := queries.NewRoomServerListAliasesHandler(ts.Transaction, ts.Room)
+
+	result, err := handler.Handle(queries.MustNewRoomServerListAliases(ts.Remote(true), fixtures.SomeRefIdentity()))
+	require.NoError(t, err)
+	require.Empty(t, result)
+}
+
+// RegisterAlias stores a new alias registered by the user.
+func (ts roomServerTest) RegisterAlias(user identity.Private) aliases.Alias {
+	alias := fixtures.SomeAlias()
+	userRef := refs.MustNewIdentityFromPublic(user.Public())
+
+	msg, err := aliases.NewRegistrationMessage(alias, userRef, fixtures.SomeRefIdentity())
+	require.NoError(ts.t, err)
+
+	signature, err := aliases.NewRegistrationSignature(msg, user)
+	require.NoError(ts.t, err)
+
+	err = ts.RoomAlias.Put(aliases.MustNewRegistration(msg, signature))
+	require.NoError(ts.t, err)
+
+	return alias
+}
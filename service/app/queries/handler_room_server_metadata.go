@@ -0,0 +1,62 @@
+package queries
+
+import (
+	"github.com/boreq/errors"
+	"github.com/planetary-social/scuttlego/service/domain/identity"
+	"github.com/planetary-social/scuttlego/service/domain/messages"
+)
+
+type RoomServerMetadata struct {
+	remote identity.Public
+}
+
+func NewRoomServerMetadata(remote identity.Public) (RoomServerMetadata, error) {
+	if remote.IsZero() {
+		return RoomServerMetadata{}, errors.New("zero value of remote")
+	}
+	return RoomServerMetadata{remote: remote}, nil
+}
+
+func MustNewRoomServerMetadata(remote identity.Public) RoomServerMetadata {
+	v, err := NewRoomServerMetadata(remote)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+func (q RoomServerMetadata) Remote() identity.Public {
+	return q.remote
+}
+
+func (q RoomServerMetadata) IsZero() bool {
+	return q.remote.IsZero()
+}
+
+type RoomServerMetadataHandler struct {
+	transaction TransactionProvider
+	roomServer  RoomServer
+}
+
+func NewRoomServerMetadataHandler(
+	transaction TransactionProvider,
+	roomServer RoomServer,
+) *RoomServerMetadataHandler {
+	return &RoomServerMetadataHandler{
+		transaction: transaction,
+		roomServer:  roomServer,
+	}
+}
+
+func (h *RoomServerMetadataHandler) Handle(query RoomServerMetadata) (messages.RoomMetadataResponse, error) {
+	if query.IsZero() {
+		return messages.RoomMetadataResponse{}, errors.New("zero value of query")
+	}
+
+	member, err := isRoomMember(h.transaction, query.remote)
+	if err != nil {
+		return messages.RoomMetadataResponse{}, errors.Wrap(err, "error checking membership")
+	}
+
+	return h.roomServer.Metadata(member)
+}
@@ -0,0 +1,31 @@
+package queries_test
+
+import (
+	"testing"
+
+	"github.com/planetary-social/scuttlego/service/app/queries"
+	"github.com/planetary-social/scuttlego/service/domain/rooms/server"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoomServerMetadataHandler(t *testing.T) {
+	for _, testCase := range roomServerAccessTestCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			ts := newRoomServerTest(t, testCase.Mode)
+
+			remote := ts.Remote(testCase.Member)
+
+			handler := queries.NewRoomServerMetadataHandler(ts.Transaction, ts.Room)
+
+			// Metadata is available to everyone so that clients can learn
+			// whether they are members.
+			metadata, err := handler.Handle(queries.MustNewRoomServerMetadata(remote))
+			if testCase.Mode.IsZero() {
+				require.ErrorIs(t, err, server.ErrRoomDisabled)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, testCase.Member, metadata.Membership())
+		})
+	}
+}
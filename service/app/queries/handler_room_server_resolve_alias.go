@@ -0,0 +1,41 @@
+package queries
+
+import (
+	"github.com/boreq/errors"
+	"github.com/planetary-social/scuttlego/service/domain/rooms/aliases"
+)
+
+type RoomServerResolveAliasHandler struct {
+	transaction TransactionProvider
+}
+
+func NewRoomServerResolveAliasHandler(
+	transaction TransactionProvider,
+) *RoomServerResolveAliasHandler {
+	return &RoomServerResolveAliasHandler{
+		transaction: transaction,
+	}
+}
+
+// Handle looks up an alias registered in the room hosted by this node. It
+// returns common.ErrRoomAliasNotFound if the alias isn't registered.
+func (h *RoomServerResolveAliasHandler) Handle(alias aliases.Alias) (aliases.Registration, error) {
+	if alias.IsZero() {
+		return aliases.Registration{}, errors.New("zero value of alias")
+	}
+
+	var result aliases.Registration
+
+	if err := h.transaction.Transact(func(adapters Adapters) error {
+		tmp, err := adapters.RoomAlias.Get(alias)
+		if err != nil {
+			return errors.Wrap(err, "error getting the alias")
+		}
+		result = tmp
+		return nil
+	}); err != nil {
+		return aliases.Registration{}, errors.Wrap(err, "transaction failed")
+	}
+
+	return result, nil
+}
@@ -0,0 +1,35 @@
+package queries_test
+
+import (
+	"testing"
+
+	"github.com/planetary-social/scuttlego/internal/fixtures"
+	"github.com/planetary-social/scuttlego/service/app/common"
+	"github.com/planetary-social/scuttlego/service/app/queries"
+	"github.com/planetary-social/scuttlego/service/domain/refs"
+	"github.com/planetary-social/scuttlego/service/domain/rooms/server"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoomServerResolveAliasHandler(t *testing.T) {
+	ts := newRoomServerTest(t, server.PrivacyModeCommunity)
+
+	user := fixtures.SomePrivateIdentity()
+	alias := ts.RegisterAlias(user)
+
+	handler := queries.NewRoomServerResolveAliasHandler(ts.Transaction)
+
+	registration, err := handler.Handle(alias)
+	require.NoError(t, err)
+	require.Equal(t, alias, registration.Alias())
+	require.Equal(t, refs.MustNewIdentityFromPublic(user.Public()), registration.User())
+}
+
+func TestRoomServerResolveAliasHandler_ReturnsAnErrorIfAliasIsNotRegistered(t *testing.T) {
+	ts := newRoomServerTest(t, server.PrivacyModeCommunity)
+
+	handler := queries.NewRoomServerResolveAliasHandler(ts.Transaction)
+
+	_, err := handler.Handle(fixtures.SomeAlias())
+	require.ErrorIs(t, err, common.ErrRoomAliasNotFound)
+}
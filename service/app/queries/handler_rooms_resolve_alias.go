@@ -0,0 +1,56 @@
+package queries
+
+import (
+	"context"
+
+	"github.com/boreq/errors"
+	"github.com/planetary-social/scuttlego/service/domain/rooms"
+	"github.com/planetary-social/scuttlego/service/domain/rooms/aliases"
+)
+
+type RoomsResolveAlias struct {
+	endpoint aliases.AliasEndpointURL
+}
+
+func NewRoomsResolveAlias(endpoint aliases.AliasEndpointURL) (RoomsResolveAlias, error) {
+	if endpoint.IsZero() {
+		return RoomsResolveAlias{}, errors.New("zero value of endpoint")
+	}
+
+	return RoomsResolveAlias{
+		endpoint: endpoint,
+	}, nil
+}
+
+func (r RoomsResolveAlias) Endpoint() aliases.AliasEndpointURL {
+	return r.endpoint
+}
+
+func (r RoomsResolveAlias) IsZero() bool {
+	return r.endpoint.IsZero()
+}
+
+type RoomsResolveAliasHandler struct {
+	client RoomHTTPClient
+}
+
+func NewRoomsResolveAliasHandler(client RoomHTTPClient) *RoomsResolveAliasHandler {
+	return &RoomsResolveAliasHandler{
+		client: client,
+	}
+}
+
+// Handle resolves an alias using the HTTP endpoint exposed by the room. The
+// returned address can be used to connect to the user through that room.
+func (h *RoomsResolveAliasHandler) Handle(ctx context.Context, query RoomsResolveAlias) (rooms.ResolvedAlias, error) {
+	if query.IsZero() {
+		return rooms.ResolvedAlias{}, errors.New("zero value of query")
+	}
+
+	resolved, err := h.client.ResolveAlias(ctx, query.Endpoint())
+	if err != nil {
+		return rooms.ResolvedAlias{}, errors.Wrap(err, "error resolving the alias")
+	}
+
+	return resolved, nil
+}
@@ -0,0 +1,81 @@
+package queries_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/planetary-social/scuttlego/internal/fixtures"
+	"github.com/planetary-social/scuttlego/service/app/queries"
+	"github.com/planetary-social/scuttlego/service/domain/network"
+	"github.com/planetary-social/scuttlego/service/domain/refs"
+	"github.com/planetary-social/scuttlego/service/domain/rooms"
+	"github.com/planetary-social/scuttlego/service/domain/rooms/aliases"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoomsResolveAliasHandler(t *testing.T) {
+	room := fixtures.SomeRefIdentity()
+	alias := fixtures.SomeAlias()
+	user := fixtures.SomePrivateIdentity()
+	userRef := refs.MustNewIdentityFromPublic(user.Public())
+	address := "net:room.example.com:8008~shs:" + strings.TrimSuffix(strings.TrimPrefix(room.String(), "@"), ".ed25519")
+
+	msg, err := aliases.NewRegistrationMessage(alias, userRef, room)
+	require.NoError(t, err)
+
+	signature, err := aliases.NewRegistrationSignature(msg, user)
+	require.NoError(t, err)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("encoding") != "json" {
+			http.Error(w, "html not supported by this stand-in", http.StatusNotAcceptable)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+
+		if r.URL.Path != "/"+alias.String() {
+			w.WriteHeader(http.StatusNotFound)
+			_ = jsoniter.NewEncoder(w).Encode(map[string]string{
+				"status": "failed",
+				"error":  "alias not found",
+			})
+			return
+		}
+
+		_ = jsoniter.NewEncoder(w).Encode(map[string]string{
+			"status":             "successful",
+			"multiserverAddress": address,
+			"roomId":             room.String(),
+			"userId":             userRef.String(),
+			"alias":              alias.String(),
+			"signature":          signature.String(),
+		})
+	}))
+	defer server.Close()
+
+	handler := queries.NewRoomsResolveAliasHandler(rooms.NewHTTPClient())
+
+	t.Run("registered", func(t *testing.T) {
+		query, err := queries.NewRoomsResolveAlias(aliases.MustNewAliasEndpointURL(server.URL + "/" + alias.String()))
+		require.NoError(t, err)
+
+		resolved, err := handler.Handle(fixtures.TestContext(t), query)
+		require.NoError(t, err)
+		require.Equal(t, alias, resolved.Alias())
+		require.Equal(t, userRef, resolved.User())
+		require.Equal(t, room, resolved.Room())
+		require.Equal(t, network.MustNewMultiserverAddress(address), resolved.Address())
+	})
+
+	t.Run("not_registered", func(t *testing.T) {
+		query, err := queries.NewRoomsResolveAlias(aliases.MustNewAliasEndpointURL(server.URL + "/" + fixtures.SomeAlias().String()))
+		require.NoError(t, err)
+
+		_, err = handler.Handle(fixtures.TestContext(t), query)
+		require.ErrorContains(t, err, "alias not found")
+	})
+}
@@ -1,6 +1,8 @@
 package queries
 
 import (
+	"time"
+
 	"github.com/boreq/errors"
 	"github.com/planetary-social/scuttlego/service/domain/identity"
 	"github.com/planetary-social/scuttlego/service/domain/transport"
@@ -14,6 +16,10 @@ type StatusResult struct {
 
 type Peer struct {
 	Identity identity.Public
+
+	// RoundTripTime is the most recently measured round-trip time. Zero
+	// value means that no measurements are available yet.
+	RoundTripTime time.Duration
 }
 
 type PeerManager interface {
@@ -21,18 +27,27 @@ type PeerManager interface {
 	Peers() []transport.Peer
 }
 
+type RoundTripTimeProvider interface {
+	// RoundTripTime returns the most recently measured round-trip time for
+	// the given peer. Returns false if no measurements are available.
+	RoundTripTime(peer transport.Peer) (time.Duration, bool)
+}
+
 type StatusHandler struct {
-	transaction TransactionProvider
-	peerManager PeerManager
+	transaction           TransactionProvider
+	peerManager           PeerManager
+	roundTripTimeProvider RoundTripTimeProvider
 }
 
 func NewStatusHandler(
 	transaction TransactionProvider,
 	peerManager PeerManager,
+	roundTripTimeProvider RoundTripTimeProvider,
 ) *StatusHandler {
 	return &StatusHandler{
-		transaction: transaction,
-		peerManager: peerManager,
+		transaction:           transaction,
+		peerManager:           peerManager,
+		roundTripTimeProvider: roundTripTimeProvider,
 	}
 }
 
@@ -70,8 +85,11 @@ func (h StatusHandler) getPeers() ([]Peer, error) {
 	var result []Peer
 
 	for _, peer := range h.peerManager.Peers() {
+		rtt, _ := h.roundTripTimeProvider.RoundTripTime(peer)
+
 		result = append(result, Peer{
-			Identity: peer.Identity(),
+			Identity:      peer.Identity(),
+			RoundTripTime: rtt,
 		})
 	}
 
@@ -2,6 +2,7 @@ package queries_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/planetary-social/scuttlego/internal/fixtures"
 	"github.com/planetary-social/scuttlego/internal/mocks"
@@ -21,12 +22,14 @@ func TestStatus(t *testing.T) {
 	expectedFeedCount := 456
 
 	remote := fixtures.SomePublicIdentity()
+	remoteRoundTripTime := 123 * time.Millisecond
 
 	a.MessageRepository.CountReturnValue = expectedMessageCount
 	a.FeedRepository.CountReturnValue = expectedFeedCount
-	a.PeerManager.MockPeers([]transport.Peer{
-		transport.MustNewPeer(remote, mocks.NewConnectionMock(ctx)),
-	})
+	peer := transport.MustNewPeer(remote, mocks.NewConnectionMock(ctx))
+
+	a.PeerManager.MockPeers([]transport.Peer{peer})
+	a.RoundTripTimeProvider.MockRoundTripTime(peer, remoteRoundTripTime)
 
 	result, err := a.Queries.Status.Handle()
 	require.NoError(t, err)
@@ -36,7 +39,8 @@ func TestStatus(t *testing.T) {
 	require.Equal(t,
 		[]queries.Peer{
 			{
-				Identity: remote,
+				Identity:      remote,
+				RoundTripTime: remoteRoundTripTime,
 			},
 		},
 		result.Peers,
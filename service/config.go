@@ -1,6 +1,8 @@
 package service
 
 import (
+	"time"
+
 	"github.com/dgraph-io/badger/v3"
 	"github.com/dgraph-io/badger/v3/options"
 	"github.com/planetary-social/scuttlego/internal"
@@ -8,7 +10,9 @@ import (
 	"github.com/planetary-social/scuttlego/service/domain"
 	"github.com/planetary-social/scuttlego/service/domain/feeds/formats"
 	"github.com/planetary-social/scuttlego/service/domain/graph"
+	"github.com/planetary-social/scuttlego/service/domain/rooms/server"
 	"github.com/planetary-social/scuttlego/service/domain/transport/boxstream"
+	"github.com/planetary-social/scuttlego/tracing"
 )
 
 type Config struct {
@@ -25,6 +29,41 @@ type Config struct {
 	// Optional, defaults to ":8008".
 	ListenAddress string
 
+	// WebSocketListenAddress for the WebSocket listener in the format accepted
+	// by the standard library e.g. ":8989".
+	// Optional, the WebSocket listener is disabled if this is not set.
+	WebSocketListenAddress string
+
+	// LocalSocketPath is the path at which a Unix domain socket used by local
+	// clients will be created. Local clients skip the secret handshake, are
+	// treated as the local identity and can call privileged procedures.
+	// Optional, the local socket is disabled if this is not set.
+	LocalSocketPath string
+
+	// HTTPAPIListenAddress for the HTTP API in the format accepted by the
+	// standard library e.g. ":8080". The API exposes the same functionality as
+	// the local socket so HTTPAPIToken must be set as well.
+	// Optional, the HTTP API is disabled if this is not set.
+	HTTPAPIListenAddress string
+
+	// HTTPAPIToken is the bearer token which clients of the HTTP API have to
+	// present.
+	// Required if HTTPAPIListenAddress is set.
+	HTTPAPIToken string
+
+	// MetricsListenAddress for the HTTP server exposing metrics in the
+	// Prometheus text exposition format at "/metrics" e.g. ":9090". The
+	// metrics aren't protected by a token so this address shouldn't be
+	// publicly reachable.
+	// Optional, metrics aren't exposed if this is not set.
+	MetricsListenAddress string
+
+	// DisableLocalAdvertising stops this node from announcing its presence to
+	// other nodes in the local network. Announcements sent by other nodes are
+	// still received.
+	// Optional, defaults to false.
+	DisableLocalAdvertising bool
+
 	// Setting NetworkKey is mainly useful for test networks.
 	// Optional, defaults to boxstream.NewDefaultNetworkKey().
 	NetworkKey boxstream.NetworkKey
@@ -37,10 +76,54 @@ type Config struct {
 	// Optional, defaults to logging.NewDevNullLoggingSystem().
 	LoggingSystem logging.LoggingSystem
 
+	// TracingExporter receives spans describing handshakes, RPC request
+	// streams, replication and message persistence.
+	// Optional, defaults to tracing.NewDevNullExporter().
+	TracingExporter tracing.Exporter
+
 	// PeerManagerConfig specifies the config for the peer manager which is responsible for establishing new
 	// connections and managing existing connections.
 	PeerManagerConfig domain.PeerManagerConfig
 
+	// PingInterval specifies how often connected peers are pinged in order to
+	// measure round-trip times and detect dead connections.
+	// Optional, defaults to 30 seconds.
+	PingInterval time.Duration
+
+	// MaxMissedPings specifies after how many consecutive unanswered pings a
+	// connection is considered dead and closed.
+	// Optional, defaults to 3.
+	MaxMissedPings int
+
+	// FirstResponseTimeout limits how long async and duplex requests sent to
+	// other peers wait for the first response.
+	// Optional, defaults to 30 seconds.
+	FirstResponseTimeout time.Duration
+
+	// IdleDuplexStreamTimeout limits how long duplex streams opened by this
+	// node can go without receiving any messages.
+	// Optional, disabled by default as some protocols such as EBT can
+	// legitimately remain idle for a long time.
+	IdleDuplexStreamTimeout time.Duration
+
+	// TunnelDialTimeout limits how long establishing a connection tunnelled
+	// through a room can take.
+	// Optional, defaults to 30 seconds.
+	TunnelDialTimeout time.Duration
+
+	// RoomServerPrivacyMode enables the room server mode which lets this node
+	// act as a room for other peers. Open rooms can be used by anyone while
+	// community and restricted rooms can only be used by members.
+	// Optional, the room server mode is disabled if this is not set.
+	RoomServerPrivacyMode server.PrivacyMode
+
+	// RoomServerAliasDomain is the domain under which aliases registered in
+	// the room hosted by this node can be resolved e.g. if it is set to
+	// "room.example.com" then "somealias" is available at
+	// "https://somealias.room.example.com".
+	// Optional, alias registration is disabled if this is not set.
+	RoomServerAliasDomain string
+
 	// Hops specifies how far away the feeds which are automatically replicated
 	// based on contact messages can be in the social graph.
 	// Optional, defaults to 2 (followees of your followees).
@@ -68,6 +151,26 @@ func (c *Config) SetDefaults() {
 		c.LoggingSystem = logging.NewDevNullLoggingSystem()
 	}
 
+	if c.TracingExporter == nil {
+		c.TracingExporter = tracing.NewDevNullExporter()
+	}
+
+	if c.PingInterval == 0 {
+		c.PingInterval = 30 * time.Second
+	}
+
+	if c.MaxMissedPings == 0 {
+		c.MaxMissedPings = 3
+	}
+
+	if c.FirstResponseTimeout == 0 {
+		c.FirstResponseTimeout = 30 * time.Second
+	}
+
+	if c.TunnelDialTimeout == 0 {
+		c.TunnelDialTimeout = 30 * time.Second
+	}
+
 	if c.Hops == nil {
 		c.Hops = internal.Ptr(graph.MustNewHops(2))
 	}
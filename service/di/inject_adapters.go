@@ -18,6 +18,7 @@ import (
 	"github.com/planetary-social/scuttlego/service/domain/invites"
 	"github.com/planetary-social/scuttlego/service/domain/replication/ebt"
 	"github.com/planetary-social/scuttlego/service/domain/transport/boxstream"
+	portsrpc "github.com/planetary-social/scuttlego/service/ports/rpc"
 )
 
 var mockQueryAdaptersSet = wire.NewSet(
@@ -38,6 +39,15 @@ var mockQueryAdaptersSet = wire.NewSet(
 
 	mocks2.NewBanListRepositoryMock,
 	wire.Bind(new(queries.BanListRepository), new(*mocks2.BanListRepositoryMock)),
+
+	mocks2.NewRoomMemberRepositoryMock,
+	wire.Bind(new(queries.RoomMemberRepository), new(*mocks2.RoomMemberRepositoryMock)),
+
+	mocks2.NewRoomAliasRepositoryMock,
+	wire.Bind(new(queries.RoomAliasRepository), new(*mocks2.RoomAliasRepositoryMock)),
+
+	mocks2.NewOutOfOrderMessageRepositoryMock,
+	wire.Bind(new(queries.OutOfOrderMessageRepository), new(*mocks2.OutOfOrderMessageRepositoryMock)),
 )
 
 var blobsAdaptersSet = wire.NewSet(
@@ -49,8 +59,8 @@ var blobsAdaptersSet = wire.NewSet(
 	wire.Bind(new(commands.BlobCreator), new(*blobs.FilesystemStorage)),
 )
 
-func newFilesystemStorage(logger logging.Logger, config service.Config) (*blobs.FilesystemStorage, error) {
-	return blobs.NewFilesystemStorage(path.Join(config.GoSSBDataDirectory, "blobs"), logger)
+func newFilesystemStorage(metrics blobs.Metrics, logger logging.Logger, config service.Config) (*blobs.FilesystemStorage, error) {
+	return blobs.NewFilesystemStorage(path.Join(config.GoSSBDataDirectory, "blobs"), metrics, logger)
 }
 
 var adaptersSet = wire.NewSet(
@@ -59,6 +69,7 @@ var adaptersSet = wire.NewSet(
 	wire.Bind(new(boxstream.CurrentTimeProvider), new(*adapters.CurrentTimeProvider)),
 	wire.Bind(new(invitesadapters.CurrentTimeProvider), new(*adapters.CurrentTimeProvider)),
 	wire.Bind(new(blobreplication.CurrentTimeProvider), new(*adapters.CurrentTimeProvider)),
+	wire.Bind(new(portsrpc.CurrentTimeProvider), new(*adapters.CurrentTimeProvider)),
 
 	adapters.NewBanListHasher,
 	wire.Bind(new(badger.BanListHasher), new(*adapters.BanListHasher)),
@@ -69,3 +80,14 @@ var adaptersSet = wire.NewSet(
 	invitesadapters.NewInviteDialer,
 	wire.Bind(new(invites.InviteDialer), new(*invitesadapters.InviteDialer)),
 )
+
+var roomAttendantsRegistrySet = wire.NewSet(
+	adapters.NewRoomAttendantsRegistry,
+	wire.Bind(new(commands.RoomAttendantsRegistry), new(*adapters.RoomAttendantsRegistry)),
+	wire.Bind(new(queries.RoomAttendantsRegistry), new(*adapters.RoomAttendantsRegistry)),
+)
+
+var httpAuthClientChallengeRepositorySet = wire.NewSet(
+	adapters.NewHttpAuthClientChallengeRepository,
+	wire.Bind(new(commands.HttpAuthClientChallengeRepository), new(*adapters.HttpAuthClientChallengeRepository)),
+)
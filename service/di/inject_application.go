@@ -23,19 +23,37 @@ var commandsSet = wire.NewSet(
 	wire.Struct(new(app.Commands), "*"),
 
 	commands.NewRedeemInviteHandler,
+	wire.Bind(new(portsrpc.RedeemInviteCommandHandler), new(*commands.RedeemInviteHandler)),
+	commands.NewCreateInviteHandler,
+	commands.NewUseInviteHandler,
+	wire.Bind(new(portsrpc.UseInviteCommandHandler), new(*commands.UseInviteHandler)),
 	commands.NewFollowHandler,
+	wire.Bind(new(portsrpc.FollowCommandHandler), new(*commands.FollowHandler)),
 	commands.NewConnectHandler,
+	wire.Bind(new(portsrpc.ConnectCommandHandler), new(*commands.ConnectHandler)),
+	commands.NewFetchOutOfOrderMessageHandler,
 	commands.NewDisconnectAllHandler,
 	commands.NewPublishRawHandler,
+	wire.Bind(new(portsrpc.PublishRawCommandHandler), new(*commands.PublishRawHandler)),
 	commands.NewPublishRawAsIdentityHandler,
 	commands.NewDownloadBlobHandler,
 	commands.NewCreateBlobHandler,
+	wire.Bind(new(portsrpc.CreateBlobCommandHandler), new(*commands.CreateBlobHandler)),
 	commands.NewDownloadFeedHandler,
 	commands.NewRoomsAliasRegisterHandler,
+	wire.Bind(new(portsrpc.RoomsAliasRegisterCommandHandler), new(*commands.RoomsAliasRegisterHandler)),
 	commands.NewRoomsAliasRevokeHandler,
+	wire.Bind(new(portsrpc.RoomsAliasRevokeCommandHandler), new(*commands.RoomsAliasRevokeHandler)),
+	commands.NewRoomsHttpAuthSignInHandler,
+	commands.NewRoomsHttpAuthCreateClientChallengeHandler,
+	commands.NewRoomsConsumeHttpInviteHandler,
 	commands.NewAddToBanListHandler,
+	wire.Bind(new(portsrpc.AddToBanListCommandHandler), new(*commands.AddToBanListHandler)),
 	commands.NewRemoveFromBanListHandler,
+	wire.Bind(new(portsrpc.RemoveFromBanListCommandHandler), new(*commands.RemoveFromBanListHandler)),
 	commands.NewSetBanListHandler,
+	commands.NewAddRoomMemberHandler,
+	commands.NewRemoveRoomMemberHandler,
 	commands.NewRunMigrationsHandler,
 
 	commands.NewProcessNewLocalDiscoveryHandler,
@@ -61,18 +79,46 @@ var commandsSet = wire.NewSet(
 
 	commands.NewAcceptTunnelConnectHandler,
 	wire.Bind(new(portsrpc.AcceptTunnelConnectHandler), new(*commands.AcceptTunnelConnectHandler)),
+
+	commands.NewRoomServerAnnounceHandler,
+	wire.Bind(new(portsrpc.RoomServerAnnounceCommandHandler), new(*commands.RoomServerAnnounceHandler)),
+
+	commands.NewRoomServerLeaveHandler,
+	wire.Bind(new(portsrpc.RoomServerLeaveCommandHandler), new(*commands.RoomServerLeaveHandler)),
+
+	commands.NewRoomServerConnectHandler,
+	wire.Bind(new(portsrpc.RoomServerConnectCommandHandler), new(*commands.RoomServerConnectHandler)),
+
+	commands.NewRoomServerRegisterAliasHandler,
+	wire.Bind(new(portsrpc.RoomServerRegisterAliasCommandHandler), new(*commands.RoomServerRegisterAliasHandler)),
+
+	commands.NewRoomServerRevokeAliasHandler,
+	wire.Bind(new(portsrpc.RoomServerRevokeAliasCommandHandler), new(*commands.RoomServerRevokeAliasHandler)),
+
+	commands.NewRoomsHttpAuthSolveChallengeHandler,
+	wire.Bind(new(portsrpc.RoomsHttpAuthSolveChallengeCommandHandler), new(*commands.RoomsHttpAuthSolveChallengeHandler)),
 )
 
 var queriesSet = wire.NewSet(
 	wire.Struct(new(app.Queries), "*"),
 
 	queries.NewReceiveLogHandler,
+	wire.Bind(new(portsrpc.ReceiveLogQueryHandler), new(*queries.ReceiveLogHandler)),
+	queries.NewFeedMessagesHandler,
+	wire.Bind(new(portsrpc.FeedMessagesQueryHandler), new(*queries.FeedMessagesHandler)),
 	queries.NewPublishedLogHandler,
 	queries.NewStatusHandler,
-	queries.NewBlobDownloadedEventsHandler,
+	wire.Bind(new(portsrpc.StatusQueryHandler), new(*queries.StatusHandler)),
 	queries.NewRoomsListAliasesHandler,
+	wire.Bind(new(portsrpc.RoomsListAliasesQueryHandler), new(*queries.RoomsListAliasesHandler)),
+	queries.NewRoomsResolveAliasHandler,
+	queries.NewRoomAttendantsHandler,
+	queries.NewRoomAttendantsSubscribeHandler,
 	queries.NewGetMessageHandler,
+	wire.Bind(new(portsrpc.GetMessageQueryHandler), new(*queries.GetMessageHandler)),
 	queries.NewGetMessageBySequenceHandler,
+	queries.NewRoomMembersHandler,
+	queries.NewRoomServerResolveAliasHandler,
 
 	queries.NewCreateHistoryStreamHandler,
 	wire.Bind(new(portsrpc.CreateHistoryStreamQueryHandler), new(*queries.CreateHistoryStreamHandler)),
@@ -80,4 +126,45 @@ var queriesSet = wire.NewSet(
 
 	queries.NewGetBlobHandler,
 	wire.Bind(new(portsrpc.GetBlobQueryHandler), new(*queries.GetBlobHandler)),
+
+	queries.NewHasBlobHandler,
+	wire.Bind(new(portsrpc.HasBlobQueryHandler), new(*queries.HasBlobHandler)),
+
+	queries.NewGetBlobSizeHandler,
+	wire.Bind(new(portsrpc.GetBlobSizeQueryHandler), new(*queries.GetBlobSizeHandler)),
+
+	queries.NewBlobDownloadedEventsHandler,
+	wire.Bind(new(portsrpc.BlobDownloadedEventsQueryHandler), new(*queries.BlobDownloadedEventsHandler)),
+
+	queries.NewNodeEventsHandler,
+
+	queries.NewMessageSavedEventsHandler,
+	wire.Bind(new(portsrpc.MessageSavedEventsQueryHandler), new(*queries.MessageSavedEventsHandler)),
+
+	queries.NewRoomServerMetadataHandler,
+	wire.Bind(new(portsrpc.RoomServerMetadataQueryHandler), new(*queries.RoomServerMetadataHandler)),
+
+	queries.NewRoomServerAttendantsHandler,
+	wire.Bind(new(portsrpc.RoomServerAttendantsQueryHandler), new(*queries.RoomServerAttendantsHandler)),
+
+	queries.NewRoomServerEndpointsHandler,
+	wire.Bind(new(portsrpc.RoomServerEndpointsQueryHandler), new(*queries.RoomServerEndpointsHandler)),
+
+	queries.NewRoomServerListAliasesHandler,
+	wire.Bind(new(portsrpc.RoomServerListAliasesQueryHandler), new(*queries.RoomServerListAliasesHandler)),
+
+	queries.NewFriendsHopsHandler,
+	wire.Bind(new(portsrpc.FriendsHopsQueryHandler), new(*queries.FriendsHopsHandler)),
+
+	queries.NewGetContactHandler,
+	wire.Bind(new(portsrpc.GetContactQueryHandler), new(*queries.GetContactHandler)),
+
+	queries.NewContactsSubscribeHandler,
+	wire.Bind(new(portsrpc.ContactsSubscribeQueryHandler), new(*queries.ContactsSubscribeHandler)),
+
+	queries.NewReplicateUptoHandler,
+	wire.Bind(new(portsrpc.ReplicateUptoQueryHandler), new(*queries.ReplicateUptoHandler)),
+
+	queries.NewLatestSequenceHandler,
+	wire.Bind(new(portsrpc.LatestSequenceQueryHandler), new(*queries.LatestSequenceHandler)),
 )
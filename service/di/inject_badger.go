@@ -69,6 +69,21 @@ var badgerRepositoriesSet = wire.NewSet(
 	badgeradapters.NewMessageRepository,
 	wire.Bind(new(queries.MessageRepository), new(*badgeradapters.MessageRepository)),
 
+	badgeradapters.NewInviteRepository,
+	wire.Bind(new(commands.InviteRepository), new(*badgeradapters.InviteRepository)),
+
+	badgeradapters.NewRoomMemberRepository,
+	wire.Bind(new(commands.RoomMemberRepository), new(*badgeradapters.RoomMemberRepository)),
+	wire.Bind(new(queries.RoomMemberRepository), new(*badgeradapters.RoomMemberRepository)),
+
+	badgeradapters.NewRoomAliasRepository,
+	wire.Bind(new(commands.RoomAliasRepository), new(*badgeradapters.RoomAliasRepository)),
+	wire.Bind(new(queries.RoomAliasRepository), new(*badgeradapters.RoomAliasRepository)),
+
+	badgeradapters.NewOutOfOrderMessageRepository,
+	wire.Bind(new(commands.OutOfOrderMessageRepository), new(*badgeradapters.OutOfOrderMessageRepository)),
+	wire.Bind(new(queries.OutOfOrderMessageRepository), new(*badgeradapters.OutOfOrderMessageRepository)),
+
 	badgeradapters.NewPubRepository,
 	badgeradapters.NewBlobRepository,
 )
@@ -130,8 +145,8 @@ func testAdaptersFactory() badgeradapters.TestAdaptersFactory {
 }
 
 func badgerCommandsAdaptersFactory(config service.Config, local identity.Public, logger logging.Logger) badgeradapters.CommandsAdaptersFactory {
-	return func(tx *badger.Txn) (commands.Adapters, error) {
-		return buildBadgerCommandsAdapters(tx, local, config, logger)
+	return func(tx *badger.Txn, events *badgeradapters.TransactionEvents) (commands.Adapters, error) {
+		return buildBadgerCommandsAdapters(tx, events, local, config, logger)
 	}
 }
 
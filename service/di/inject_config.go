@@ -7,15 +7,23 @@ import (
 	"github.com/planetary-social/scuttlego/service/domain"
 	"github.com/planetary-social/scuttlego/service/domain/feeds/formats"
 	"github.com/planetary-social/scuttlego/service/domain/graph"
+	"github.com/planetary-social/scuttlego/service/domain/ping"
+	"github.com/planetary-social/scuttlego/service/domain/rooms/server"
 	"github.com/planetary-social/scuttlego/service/domain/transport/boxstream"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc"
+	"github.com/planetary-social/scuttlego/tracing"
 )
 
 var extractFromConfigSet = wire.NewSet(
 	extractNetworkKeyFromConfig,
 	extractMessageHMACFromConfig,
 	extractLoggingSystemFromConfig,
+	extractTracingExporterFromConfig,
 	extractPeerManagerConfigFromConfig,
 	extractHopsFromConfig,
+	extractPingConfigFromConfig,
+	extractResponseStreamTimeoutsFromConfig,
+	extractRoomServerConfigFromConfig,
 )
 
 func extractNetworkKeyFromConfig(config service.Config) boxstream.NetworkKey {
@@ -30,6 +38,10 @@ func extractLoggingSystemFromConfig(config service.Config) logging.LoggingSystem
 	return config.LoggingSystem
 }
 
+func extractTracingExporterFromConfig(config service.Config) tracing.Exporter {
+	return config.TracingExporter
+}
+
 func extractPeerManagerConfigFromConfig(config service.Config) domain.PeerManagerConfig {
 	return config.PeerManagerConfig
 }
@@ -37,3 +49,24 @@ func extractPeerManagerConfigFromConfig(config service.Config) domain.PeerManage
 func extractHopsFromConfig(config service.Config) graph.Hops {
 	return *config.Hops
 }
+
+func extractPingConfigFromConfig(config service.Config) ping.Config {
+	return ping.Config{
+		Interval:       config.PingInterval,
+		MaxMissedPings: config.MaxMissedPings,
+	}
+}
+
+func extractResponseStreamTimeoutsFromConfig(config service.Config) rpc.ResponseStreamTimeouts {
+	return rpc.ResponseStreamTimeouts{
+		FirstResponse:    config.FirstResponseTimeout,
+		IdleDuplexStream: config.IdleDuplexStreamTimeout,
+	}
+}
+
+func extractRoomServerConfigFromConfig(config service.Config) server.Config {
+	return server.Config{
+		PrivacyMode: config.RoomServerPrivacyMode,
+		AliasDomain: config.RoomServerAliasDomain,
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/planetary-social/scuttlego/service/domain/feeds/content"
 	"github.com/planetary-social/scuttlego/service/domain/feeds/content/transport"
 	"github.com/planetary-social/scuttlego/service/domain/feeds/formats"
+	"github.com/planetary-social/scuttlego/service/domain/replication/ebt"
 )
 
 var formatsSet = wire.NewSet(
@@ -23,6 +24,7 @@ var formatsSet = wire.NewSet(
 	formats.NewRawMessageIdentifier,
 	wire.Bind(new(commands.RawMessageIdentifier), new(*formats.RawMessageIdentifier)),
 	wire.Bind(new(badger.RawMessageIdentifier), new(*formats.RawMessageIdentifier)),
+	wire.Bind(new(ebt.RawMessagePeeker), new(*formats.RawMessageIdentifier)),
 )
 
 func newFormats(
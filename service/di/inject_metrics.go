@@ -0,0 +1,43 @@
+package di
+
+import (
+	"github.com/google/wire"
+	"github.com/planetary-social/scuttlego/service"
+	"github.com/planetary-social/scuttlego/service/adapters/badger"
+	"github.com/planetary-social/scuttlego/service/adapters/blobs"
+	"github.com/planetary-social/scuttlego/service/adapters/prometheus"
+	"github.com/planetary-social/scuttlego/service/app/commands"
+	"github.com/planetary-social/scuttlego/service/domain/replication"
+	domaintransport "github.com/planetary-social/scuttlego/service/domain/transport"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc/mux"
+	portshttp "github.com/planetary-social/scuttlego/service/ports/http"
+)
+
+var metricsSet = wire.NewSet(
+	newMetrics,
+	wire.Bind(new(domaintransport.Metrics), new(metrics)),
+	wire.Bind(new(mux.Metrics), new(metrics)),
+	wire.Bind(new(commands.MessageBufferMetrics), new(metrics)),
+	wire.Bind(new(replication.Metrics), new(metrics)),
+	wire.Bind(new(blobs.Metrics), new(metrics)),
+	wire.Bind(new(badger.GarbageCollectorMetrics), new(metrics)),
+	wire.Bind(new(portshttp.Metrics), new(metrics)),
+)
+
+// metrics is implemented by both Prometheus and NoopMetrics.
+type metrics interface {
+	domaintransport.Metrics
+	mux.Metrics
+	commands.MessageBufferMetrics
+	replication.Metrics
+	blobs.Metrics
+	badger.GarbageCollectorMetrics
+	portshttp.Metrics
+}
+
+func newMetrics(config service.Config) metrics {
+	if config.MetricsListenAddress == "" {
+		return prometheus.NewNoopMetrics()
+	}
+	return prometheus.NewPrometheus()
+}
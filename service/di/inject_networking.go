@@ -7,6 +7,7 @@ import (
 	"github.com/planetary-social/scuttlego/service/app/queries"
 	"github.com/planetary-social/scuttlego/service/domain"
 	"github.com/planetary-social/scuttlego/service/domain/network"
+	"github.com/planetary-social/scuttlego/service/domain/rooms"
 	"github.com/planetary-social/scuttlego/service/domain/rooms/tunnel"
 	domaintransport "github.com/planetary-social/scuttlego/service/domain/transport"
 	"github.com/planetary-social/scuttlego/service/domain/transport/boxstream"
@@ -30,4 +31,8 @@ var networkingSet = wire.NewSet(
 	wire.Bind(new(queries.Dialer), new(*network.Dialer)),
 	wire.Bind(new(domain.Dialer), new(*network.Dialer)),
 	wire.Bind(new(invitesadapters.Dialer), new(*network.Dialer)),
+
+	rooms.NewHTTPClient,
+	wire.Bind(new(commands.RoomHTTPClient), new(*rooms.HTTPClient)),
+	wire.Bind(new(queries.RoomHTTPClient), new(*rooms.HTTPClient)),
 )
@@ -4,25 +4,71 @@ import (
 	"github.com/google/wire"
 	"github.com/planetary-social/scuttlego/logging"
 	"github.com/planetary-social/scuttlego/service"
+	"github.com/planetary-social/scuttlego/service/app"
+	"github.com/planetary-social/scuttlego/service/domain/identity"
 	"github.com/planetary-social/scuttlego/service/domain/network/local"
-	"github.com/planetary-social/scuttlego/service/domain/transport/rpc/mux"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc"
+	portshttp "github.com/planetary-social/scuttlego/service/ports/http"
 	portsnetwork "github.com/planetary-social/scuttlego/service/ports/network"
 	portspubsub "github.com/planetary-social/scuttlego/service/ports/pubsub"
 	portsrpc "github.com/planetary-social/scuttlego/service/ports/rpc"
 )
 
 var portsSet = wire.NewSet(
-	mux.NewMux,
+	portsrpc.NewMux,
 
 	portsrpc.NewMuxHandlers,
 	portsrpc.NewHandlerBlobsGet,
 	portsrpc.NewHandlerBlobsCreateWants,
 	portsrpc.NewHandlerEbtReplicate,
 	portsrpc.NewHandlerTunnelConnect,
+	portsrpc.NewHandlerGossipPing,
+	portsrpc.NewHandlerWhoami,
+	portsrpc.NewHandlerInviteUse,
+	portsrpc.NewHandlerRoomMetadata,
+	portsrpc.NewHandlerRoomAttendants,
+	portsrpc.NewHandlerTunnelAnnounce,
+	portsrpc.NewHandlerTunnelLeave,
+	portsrpc.NewHandlerTunnelEndpoints,
+	portsrpc.NewHandlerRoomRegisterAlias,
+	portsrpc.NewHandlerRoomRevokeAlias,
+	portsrpc.NewHandlerRoomListAliases,
+	portsrpc.NewHandlerHttpAuthRequestSolution,
+	portsrpc.NewHandlerBlobsHas,
+	portsrpc.NewHandlerBlobsSize,
+	portsrpc.NewHandlerBlobsChanges,
+	portsrpc.NewHandlerOooGet,
+	portsrpc.NewHandlerReplicateUpto,
+	portsrpc.NewHandlerLatestSequence,
 
 	portsrpc.NewMuxClosingHandlers,
 	portsrpc.NewHandlerCreateHistoryStream,
 
+	portsrpc.NewMuxPrivilegedHandlers,
+	portsrpc.NewHandlerFriendsHops,
+	portsrpc.NewHandlerFriendsIsFollowing,
+	portsrpc.NewHandlerFriendsIsBlocking,
+	portsrpc.NewHandlerFriendsStream,
+	portsrpc.NewHandlerScuttlegoPublish,
+	portsrpc.NewHandlerScuttlegoFollow,
+	portsrpc.NewHandlerScuttlegoStatus,
+	portsrpc.NewHandlerScuttlegoGetMessage,
+	portsrpc.NewHandlerScuttlegoReceiveLog,
+	portsrpc.NewHandlerScuttlegoAddBlob,
+	portsrpc.NewHandlerScuttlegoAddToBanList,
+	portsrpc.NewHandlerScuttlegoRemoveFromBanList,
+	portsrpc.NewHandlerScuttlegoRedeemInvite,
+	portsrpc.NewHandlerScuttlegoRegisterAlias,
+	portsrpc.NewHandlerScuttlegoRevokeAlias,
+	portsrpc.NewHandlerScuttlegoListAliases,
+	portsrpc.NewHandlerScuttlegoConnect,
+	portsrpc.NewHandlerPublish,
+	portsrpc.NewHandlerCreateLogStream,
+	portsrpc.NewHandlerCreateUserStream,
+	portsrpc.NewHandlerGet,
+	portsrpc.NewHandlerBlobsAdd,
+	portsrpc.NewHandlerStatus,
+
 	portspubsub.NewRequestSubscriber,
 	portspubsub.NewRoomAttendantEventSubscriber,
 	portspubsub.NewNewPeerSubscriber,
@@ -32,6 +78,10 @@ var portsSet = wire.NewSet(
 	portsnetwork.NewConnectionEstablisher,
 
 	newListener,
+	newWebSocketListener,
+	newUnixListener,
+	newHTTPServer,
+	newMetricsServer,
 )
 
 func newListener(
@@ -41,3 +91,70 @@ func newListener(
 ) (*portsnetwork.Listener, error) {
 	return portsnetwork.NewListener(initializer, config.ListenAddress, logger)
 }
+
+func newWebSocketListener(
+	initializer portsnetwork.ServerPeerInitializer,
+	config service.Config,
+	logger logging.Logger,
+) (*portsnetwork.WebSocketListener, error) {
+	if config.WebSocketListenAddress == "" {
+		return nil, nil
+	}
+	return portsnetwork.NewWebSocketListener(initializer, config.WebSocketListenAddress, logger)
+}
+
+func newUnixListener(
+	local identity.Public,
+	requestHandler rpc.RequestHandler,
+	connectionIdGenerator *rpc.ConnectionIdGenerator,
+	config service.Config,
+	logger logging.Logger,
+) (*portsnetwork.UnixListener, error) {
+	if config.LocalSocketPath == "" {
+		return nil, nil
+	}
+	return portsnetwork.NewUnixListener(local, requestHandler, connectionIdGenerator, config.LocalSocketPath, logger)
+}
+
+func newHTTPServer(
+	app app.Application,
+	config service.Config,
+	logger logging.Logger,
+) (*portshttp.Server, error) {
+	if config.HTTPAPIListenAddress == "" {
+		return nil, nil
+	}
+
+	httpApp := portshttp.Application{
+		PublishRaw:         app.Commands.PublishRaw,
+		Follow:             app.Commands.Follow,
+		CreateBlob:         app.Commands.CreateBlob,
+		AddToBanList:       app.Commands.AddToBanList,
+		RemoveFromBanList:  app.Commands.RemoveFromBanList,
+		RedeemInvite:       app.Commands.RedeemInvite,
+		Connect:            app.Commands.Connect,
+		RoomsAliasRegister: app.Commands.RoomsAliasRegister,
+		RoomsAliasRevoke:   app.Commands.RoomsAliasRevoke,
+
+		Status:               app.Queries.Status,
+		GetMessage:           app.Queries.GetMessage,
+		ReceiveLog:           app.Queries.ReceiveLog,
+		GetBlob:              app.Queries.GetBlob,
+		RoomsListAliases:     app.Queries.RoomsListAliases,
+		MessageSavedEvents:   app.Queries.MessageSavedEvents,
+		BlobDownloadedEvents: app.Queries.BlobDownloadedEvents,
+	}
+
+	return portshttp.NewServer(config.HTTPAPIListenAddress, config.HTTPAPIToken, httpApp, logger)
+}
+
+func newMetricsServer(
+	metrics portshttp.Metrics,
+	config service.Config,
+	logger logging.Logger,
+) *portshttp.MetricsServer {
+	if config.MetricsListenAddress == "" {
+		return nil
+	}
+	return portshttp.NewMetricsServer(config.MetricsListenAddress, metrics, logger)
+}
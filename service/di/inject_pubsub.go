@@ -2,9 +2,13 @@ package di
 
 import (
 	"github.com/google/wire"
+	"github.com/planetary-social/scuttlego/service/adapters"
+	badgeradapters "github.com/planetary-social/scuttlego/service/adapters/badger"
 	"github.com/planetary-social/scuttlego/service/adapters/pubsub"
+	"github.com/planetary-social/scuttlego/service/app/commands"
 	"github.com/planetary-social/scuttlego/service/app/queries"
 	blobReplication "github.com/planetary-social/scuttlego/service/domain/blobs/replication"
+	"github.com/planetary-social/scuttlego/service/domain/replication"
 	"github.com/planetary-social/scuttlego/service/domain/rooms"
 	"github.com/planetary-social/scuttlego/service/domain/transport"
 	"github.com/planetary-social/scuttlego/service/domain/transport/rpc"
@@ -16,6 +20,8 @@ var pubSubSet = wire.NewSet(
 	blobDownloadedPubSubSet,
 	roomAttendantEventPubSubSet,
 	newPeerPubSubSet,
+	contactPubSubSet,
+	nodeEventPubSubSet,
 )
 
 var requestPubSubSet = wire.NewSet(
@@ -26,6 +32,7 @@ var requestPubSubSet = wire.NewSet(
 var messagePubSubSet = wire.NewSet(
 	pubsub.NewMessagePubSub,
 	wire.Bind(new(queries.MessageSubscriber), new(*pubsub.MessagePubSub)),
+	wire.Bind(new(badgeradapters.MessagePublisher), new(*pubsub.MessagePubSub)),
 )
 
 var blobDownloadedPubSubSet = wire.NewSet(
@@ -43,3 +50,19 @@ var newPeerPubSubSet = wire.NewSet(
 	pubsub.NewNewPeerPubSub,
 	wire.Bind(new(transport.NewPeerHandler), new(*pubsub.NewPeerPubSub)),
 )
+
+var nodeEventPubSubSet = wire.NewSet(
+	pubsub.NewNodeEventPubSub,
+	wire.Bind(new(queries.NodeEventSubscriber), new(*pubsub.NodeEventPubSub)),
+	wire.Bind(new(transport.EventPublisher), new(*pubsub.NodeEventPubSub)),
+	wire.Bind(new(replication.EventPublisher), new(*pubsub.NodeEventPubSub)),
+	wire.Bind(new(adapters.ForkDetectedPublisher), new(*pubsub.NodeEventPubSub)),
+	wire.Bind(new(commands.BanListChangedPublisher), new(*pubsub.NodeEventPubSub)),
+	wire.Bind(new(commands.MigrationsProgressPublisher), new(*pubsub.NodeEventPubSub)),
+)
+
+var contactPubSubSet = wire.NewSet(
+	pubsub.NewContactPubSub,
+	wire.Bind(new(queries.ContactSubscriber), new(*pubsub.ContactPubSub)),
+	wire.Bind(new(badgeradapters.ContactPublisher), new(*pubsub.ContactPubSub)),
+)
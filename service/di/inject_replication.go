@@ -2,6 +2,7 @@ package di
 
 import (
 	"github.com/google/wire"
+	"github.com/planetary-social/scuttlego/service/adapters"
 	"github.com/planetary-social/scuttlego/service/app/commands"
 	"github.com/planetary-social/scuttlego/service/app/queries"
 	"github.com/planetary-social/scuttlego/service/domain/replication"
@@ -25,7 +26,10 @@ var replicationSet = wire.NewSet(
 
 	replication.NewWantedFeedsCache,
 	wire.Bind(new(replication.ContactsStorage), new(*replication.WantedFeedsCache)),
-	wire.Bind(new(commands.ForkedFeedTracker), new(*replication.WantedFeedsCache)),
+	wire.Bind(new(adapters.ForkedFeedTracker), new(*replication.WantedFeedsCache)),
+
+	adapters.NewPublishingForkedFeedTracker,
+	wire.Bind(new(commands.ForkedFeedTracker), new(*adapters.PublishingForkedFeedTracker)),
 
 	ebt.NewSessionTracker,
 	wire.Bind(new(ebt.Tracker), new(*ebt.SessionTracker)),
@@ -0,0 +1,10 @@
+package di
+
+import (
+	"github.com/google/wire"
+	"github.com/planetary-social/scuttlego/tracing"
+)
+
+var tracingSet = wire.NewSet(
+	tracing.NewTracer,
+)
@@ -14,6 +14,7 @@ import (
 	mocks2 "github.com/planetary-social/scuttlego/internal/mocks"
 	"github.com/planetary-social/scuttlego/logging"
 	"github.com/planetary-social/scuttlego/service"
+	"github.com/planetary-social/scuttlego/service/adapters"
 	badgeradapters "github.com/planetary-social/scuttlego/service/adapters/badger"
 	"github.com/planetary-social/scuttlego/service/adapters/badger/notx"
 	"github.com/planetary-social/scuttlego/service/adapters/pubsub"
@@ -24,10 +25,13 @@ import (
 	"github.com/planetary-social/scuttlego/service/domain/feeds/content"
 	"github.com/planetary-social/scuttlego/service/domain/feeds/content/transport"
 	"github.com/planetary-social/scuttlego/service/domain/feeds/formats"
+	"github.com/planetary-social/scuttlego/service/domain/graph"
 	"github.com/planetary-social/scuttlego/service/domain/identity"
 	"github.com/planetary-social/scuttlego/service/domain/invites"
 	"github.com/planetary-social/scuttlego/service/domain/network/local"
+	"github.com/planetary-social/scuttlego/service/domain/ping"
 	"github.com/planetary-social/scuttlego/service/domain/rooms"
+	"github.com/planetary-social/scuttlego/service/domain/rooms/server"
 	"github.com/planetary-social/scuttlego/service/domain/rooms/tunnel"
 )
 
@@ -82,6 +86,7 @@ func buildTestBadgerNoTxTxAdapters(*badger.Txn, badgeradapters.TestAdaptersDepen
 		wire.Struct(new(notx.TxAdapters), "*"),
 
 		badgerRepositoriesSet,
+		badgeradapters.NewTransactionEvents,
 		badgerUnpackTestDependenciesSet,
 		contentSet,
 
@@ -104,6 +109,7 @@ func buildBadgerNoTxTxAdapters(*badger.Txn, identity.Public, service.Config, log
 		wire.Struct(new(notx.TxAdapters), "*"),
 
 		badgerRepositoriesSet,
+		badgeradapters.NewTransactionEvents,
 		formatsSet,
 		extractFromConfigSet,
 		adaptersSet,
@@ -118,6 +124,7 @@ func buildBadgerTestAdapters(*badger.Txn, badgeradapters.TestAdaptersDependencie
 		wire.Struct(new(badgeradapters.TestAdapters), "*"),
 
 		badgerRepositoriesSet,
+		badgeradapters.NewTransactionEvents,
 		badgerUnpackTestDependenciesSet,
 		contentSet,
 
@@ -142,13 +149,21 @@ type TestCommands struct {
 	DisconnectAll             *commands.DisconnectAllHandler
 	DownloadFeed              *commands.DownloadFeedHandler
 	RedeemInvite              *commands.RedeemInviteHandler
+	CreateInvite              *commands.CreateInviteHandler
+	UseInvite                 *commands.UseInviteHandler
 	AcceptTunnelConnect       *commands.AcceptTunnelConnectHandler
+	FetchOutOfOrderMessage    *commands.FetchOutOfOrderMessageHandler
+
+	RoomsHttpAuthCreateClientChallenge *commands.RoomsHttpAuthCreateClientChallengeHandler
+	RoomsHttpAuthSolveChallenge        *commands.RoomsHttpAuthSolveChallengeHandler
 
 	MigrationImportDataFromGoSSB *commands.MigrationHandlerImportDataFromGoSSB
 
 	PeerManager            *mocks2.PeerManagerMock
 	Dialer                 *mocks2.DialerMock
 	FeedWantListRepository *mocks2.FeedWantListRepositoryMock
+	OutOfOrderMessage      *mocks2.OutOfOrderMessageRepositoryMock
+	RawMessageIdentifier   *mocks2.RawMessageIdentifierMock
 	CurrentTimeProvider    *mocks2.CurrentTimeProviderMock
 	InviteRedeemer         *mocks2.InviteRedeemerMock
 	Local                  identity.Public
@@ -156,6 +171,10 @@ type TestCommands struct {
 	GoSSBRepoReader        *mocks2.GoSSBRepoReaderMock
 	FeedRepository         *mocks2.FeedRepositoryMock
 	ReceiveLog             *mocks2.ReceiveLogRepositoryMock
+	InviteRepository       *mocks2.InviteRepositoryMock
+	SocialGraphRepository  *mocks2.SocialGraphRepositoryMock
+	TransactionProvider    *mocks2.MockCommandsTransactionProvider
+	RoomAttendantsRegistry *adapters.RoomAttendantsRegistry
 }
 
 func BuildTestCommands(testing.TB) (TestCommands, error) {
@@ -180,14 +199,26 @@ func BuildTestCommands(testing.TB) (TestCommands, error) {
 			"FeedWantList",
 			"Feed",
 			"ReceiveLog",
+			"Invite",
+			"SocialGraph",
+			"OutOfOrderMessage",
 		),
 
+		mocks2.NewOutOfOrderMessageRepositoryMock,
+		wire.Bind(new(commands.OutOfOrderMessageRepository), new(*mocks2.OutOfOrderMessageRepositoryMock)),
+
+		mocks2.NewRawMessageIdentifierMock,
+		wire.Bind(new(commands.RawMessageIdentifier), new(*mocks2.RawMessageIdentifierMock)),
+
 		mocks2.NewFeedWantListRepositoryMock,
 		wire.Bind(new(commands.FeedWantListRepository), new(*mocks2.FeedWantListRepositoryMock)),
 
 		mocks2.NewCurrentTimeProviderMock,
 		wire.Bind(new(commands.CurrentTimeProvider), new(*mocks2.CurrentTimeProviderMock)),
 
+		roomAttendantsRegistrySet,
+		httpAuthClientChallengeRepositorySet,
+
 		mocks2.NewInviteRedeemerMock,
 		wire.Bind(new(commands.InviteRedeemer), new(*mocks2.InviteRedeemerMock)),
 
@@ -206,6 +237,16 @@ func BuildTestCommands(testing.TB) (TestCommands, error) {
 		mocks2.NewReceiveLogRepositoryMock,
 		wire.Bind(new(commands.ReceiveLogRepository), new(*mocks2.ReceiveLogRepositoryMock)),
 
+		mocks2.NewInviteRepositoryMock,
+		wire.Bind(new(commands.InviteRepository), new(*mocks2.InviteRepositoryMock)),
+
+		mocks2.NewSocialGraphRepositoryMock,
+		wire.Bind(new(commands.SocialGraphRepository), new(*mocks2.SocialGraphRepositoryMock)),
+
+		transport.DefaultMappings,
+		transport.NewMarshaler,
+		wire.Bind(new(content.Marshaler), new(*transport.Marshaler)),
+
 		fixtures.TestLogger,
 
 		wire.Struct(new(TestCommands), "*"),
@@ -225,12 +266,19 @@ type TestQueries struct {
 	SocialGraphRepository  *mocks2.SocialGraphRepositoryMock
 	FeedWantListRepository *mocks2.FeedWantListRepositoryMock
 	BanListRepository      *mocks2.BanListRepositoryMock
+	OutOfOrderMessage      *mocks2.OutOfOrderMessageRepositoryMock
 	MessagePubSub          *mocks2.MessagePubSubMock
 	PeerManager            *mocks2.PeerManagerMock
+	RoundTripTimeProvider  *mocks2.RoundTripTimeProviderMock
 	BlobStorage            *mocks2.BlobStorageMock
 	Dialer                 *mocks2.DialerMock
+	RoomHTTPClient         *mocks2.RoomHTTPClientMock
+	RoomAttendantsRegistry *adapters.RoomAttendantsRegistry
+	ContactPubSub          *pubsub.ContactPubSub
+	NodeEventPubSub        *pubsub.NodeEventPubSub
 
 	LocalIdentity identity.Public
+	Hops          graph.Hops
 }
 
 func BuildTestQueries(testing.TB) (TestQueries, error) {
@@ -251,6 +299,9 @@ func BuildTestQueries(testing.TB) (TestQueries, error) {
 		mocks2.NewPeerManagerMock,
 		wire.Bind(new(queries.PeerManager), new(*mocks2.PeerManagerMock)),
 
+		mocks2.NewRoundTripTimeProviderMock,
+		wire.Bind(new(queries.RoundTripTimeProvider), new(*mocks2.RoundTripTimeProviderMock)),
+
 		identity.NewPrivate,
 		privateIdentityToPublicIdentity,
 
@@ -263,6 +314,17 @@ func BuildTestQueries(testing.TB) (TestQueries, error) {
 		mocks2.NewDialerMock,
 		wire.Bind(new(queries.Dialer), new(*mocks2.DialerMock)),
 
+		mocks2.NewRoomHTTPClientMock,
+		wire.Bind(new(queries.RoomHTTPClient), new(*mocks2.RoomHTTPClientMock)),
+
+		roomAttendantsRegistrySet,
+		nodeEventPubSubSet,
+
+		pubsub.NewContactPubSub,
+		wire.Bind(new(queries.ContactSubscriber), new(*pubsub.ContactPubSub)),
+
+		fixtures.SomeHops,
+
 		wire.Struct(new(TestQueries), "*"),
 
 		fixtures.TestLogger,
@@ -271,7 +333,7 @@ func BuildTestQueries(testing.TB) (TestQueries, error) {
 	return TestQueries{}, nil
 }
 
-func buildBadgerCommandsAdapters(*badger.Txn, identity.Public, service.Config, logging.Logger) (commands.Adapters, error) {
+func buildBadgerCommandsAdapters(*badger.Txn, *badgeradapters.TransactionEvents, identity.Public, service.Config, logging.Logger) (commands.Adapters, error) {
 	wire.Build(
 		wire.Struct(new(commands.Adapters), "*"),
 
@@ -290,6 +352,7 @@ func buildBadgerQueriesAdapters(*badger.Txn, identity.Public, service.Config, lo
 		wire.Struct(new(queries.Adapters), "*"),
 
 		badgerRepositoriesSet,
+		badgeradapters.NewTransactionEvents,
 		formatsSet,
 		extractFromConfigSet,
 		adaptersSet,
@@ -319,11 +382,19 @@ func BuildService(identity.Private, service.Config) (service.Service, func(), er
 		rooms.NewScanner,
 		wire.Bind(new(commands.RoomScanner), new(*rooms.Scanner)),
 
+		ping.NewPinger,
+		wire.Bind(new(commands.Pinger), new(*ping.Pinger)),
+		wire.Bind(new(queries.RoundTripTimeProvider), new(*ping.Pinger)),
+
+		server.NewRoom,
+		wire.Bind(new(commands.RoomServer), new(*server.Room)),
+		wire.Bind(new(queries.RoomServer), new(*server.Room)),
+
 		rooms.NewPeerRPCAdapter,
 		wire.Bind(new(rooms.MetadataGetter), new(*rooms.PeerRPCAdapter)),
 		wire.Bind(new(rooms.AttendantsGetter), new(*rooms.PeerRPCAdapter)),
 
-		tunnel.NewDialer,
+		newTunnelDialer,
 		wire.Bind(new(domain.RoomDialer), new(*tunnel.Dialer)),
 
 		invites.NewInviteRedeemer,
@@ -340,6 +411,8 @@ func BuildService(identity.Private, service.Config) (service.Service, func(), er
 		blobReplicatorSet,
 		formatsSet,
 		pubSubSet,
+		roomAttendantsRegistrySet,
+		httpAuthClientChallengeRepositorySet,
 		badgerNoTxRepositoriesSet,
 		badgerTransactionProviderSet,
 		badgerNoTxTransactionProviderSet,
@@ -350,6 +423,8 @@ func BuildService(identity.Private, service.Config) (service.Service, func(), er
 		networkingSet,
 		migrationsSet,
 		contentSet,
+		metricsSet,
+		tracingSet,
 	)
 	return service.Service{}, nil, nil
 }
@@ -392,11 +467,19 @@ func buildIntegrationTestsService(t *testing.T) (IntegrationTestsService, func()
 		rooms.NewScanner,
 		wire.Bind(new(commands.RoomScanner), new(*rooms.Scanner)),
 
+		ping.NewPinger,
+		wire.Bind(new(commands.Pinger), new(*ping.Pinger)),
+		wire.Bind(new(queries.RoundTripTimeProvider), new(*ping.Pinger)),
+
+		server.NewRoom,
+		wire.Bind(new(commands.RoomServer), new(*server.Room)),
+		wire.Bind(new(queries.RoomServer), new(*server.Room)),
+
 		rooms.NewPeerRPCAdapter,
 		wire.Bind(new(rooms.MetadataGetter), new(*rooms.PeerRPCAdapter)),
 		wire.Bind(new(rooms.AttendantsGetter), new(*rooms.PeerRPCAdapter)),
 
-		tunnel.NewDialer,
+		newTunnelDialer,
 		wire.Bind(new(domain.RoomDialer), new(*tunnel.Dialer)),
 
 		invites.NewInviteRedeemer,
@@ -413,6 +496,8 @@ func buildIntegrationTestsService(t *testing.T) (IntegrationTestsService, func()
 		blobReplicatorSet,
 		formatsSet,
 		pubSubSet,
+		roomAttendantsRegistrySet,
+		httpAuthClientChallengeRepositorySet,
 		badgerNoTxRepositoriesSet,
 		badgerTransactionProviderSet,
 		badgerNoTxTransactionProviderSet,
@@ -423,12 +508,21 @@ func buildIntegrationTestsService(t *testing.T) (IntegrationTestsService, func()
 		networkingSet,
 		migrationsSet,
 		contentSet,
+		metricsSet,
+		tracingSet,
 	)
 	return IntegrationTestsService{}, nil, nil
 }
 
-func newAdvertiser(l identity.Public, config service.Config) (*local.Advertiser, error) {
-	return local.NewAdvertiser(l, config.ListenAddress)
+func newAdvertiser(l identity.Public, config service.Config, logger logging.Logger) (*local.Advertiser, error) {
+	if config.DisableLocalAdvertising {
+		return nil, nil
+	}
+	return local.NewAdvertiser(l, config.ListenAddress, logger)
+}
+
+func newTunnelDialer(initializer tunnel.ClientPeerInitializer, config service.Config) *tunnel.Dialer {
+	return tunnel.NewDialer(initializer, config.TunnelDialTimeout)
 }
 
 func newIntegrationTestConfig(t *testing.T) service.Config {
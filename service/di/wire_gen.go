@@ -33,22 +33,25 @@ import (
 	"github.com/planetary-social/scuttlego/service/domain/feeds/content"
 	"github.com/planetary-social/scuttlego/service/domain/feeds/content/transport"
 	"github.com/planetary-social/scuttlego/service/domain/feeds/formats"
+	"github.com/planetary-social/scuttlego/service/domain/graph"
 	"github.com/planetary-social/scuttlego/service/domain/identity"
 	invites2 "github.com/planetary-social/scuttlego/service/domain/invites"
 	"github.com/planetary-social/scuttlego/service/domain/network"
 	"github.com/planetary-social/scuttlego/service/domain/network/local"
+	"github.com/planetary-social/scuttlego/service/domain/ping"
 	replication2 "github.com/planetary-social/scuttlego/service/domain/replication"
 	"github.com/planetary-social/scuttlego/service/domain/replication/ebt"
 	"github.com/planetary-social/scuttlego/service/domain/replication/gossip"
 	"github.com/planetary-social/scuttlego/service/domain/rooms"
+	"github.com/planetary-social/scuttlego/service/domain/rooms/server"
 	"github.com/planetary-social/scuttlego/service/domain/rooms/tunnel"
 	transport2 "github.com/planetary-social/scuttlego/service/domain/transport"
 	"github.com/planetary-social/scuttlego/service/domain/transport/boxstream"
 	"github.com/planetary-social/scuttlego/service/domain/transport/rpc"
-	"github.com/planetary-social/scuttlego/service/domain/transport/rpc/mux"
 	network2 "github.com/planetary-social/scuttlego/service/ports/network"
 	pubsub2 "github.com/planetary-social/scuttlego/service/ports/pubsub"
 	rpc2 "github.com/planetary-social/scuttlego/service/ports/rpc"
+	"github.com/planetary-social/scuttlego/tracing"
 )
 
 // Injectors from wire.go:
@@ -127,7 +130,8 @@ func buildTestBadgerNoTxTxAdapters(txn *badger2.Txn, testAdaptersDependencies ba
 	receiveLogRepository := badger.NewReceiveLogRepository(txn, messageRepository)
 	public := testAdaptersDependencies.LocalIdentity
 	hops := fixtures.SomeHops()
-	socialGraphRepository := badger.NewSocialGraphRepository(txn, public, hops, banListRepository, banListHasherMock)
+	transactionEvents := badger.NewTransactionEvents()
+	socialGraphRepository := badger.NewSocialGraphRepository(txn, public, hops, banListRepository, banListHasherMock, transactionEvents)
 	pubRepository := badger.NewPubRepository(txn)
 	messageContentMappings := transport.DefaultMappings()
 	logger := fixtures.SomeLogger()
@@ -139,7 +143,7 @@ func buildTestBadgerNoTxTxAdapters(txn *badger2.Txn, testAdaptersDependencies ba
 	parser := content.NewParser(marshaler, scanner)
 	messageHMAC := formats.NewDefaultMessageHMAC()
 	scuttlebutt := formats.NewScuttlebutt(parser, messageHMAC)
-	feedRepository := badger.NewFeedRepository(txn, socialGraphRepository, receiveLogRepository, messageRepository, pubRepository, blobRepository, banListRepository, scuttlebutt)
+	feedRepository := badger.NewFeedRepository(txn, socialGraphRepository, receiveLogRepository, messageRepository, pubRepository, blobRepository, banListRepository, scuttlebutt, transactionEvents)
 	txAdapters := notx.TxAdapters{
 		BanListRepository:      banListRepository,
 		BlobRepository:         blobRepository,
@@ -175,9 +179,10 @@ func buildBadgerNoTxTxAdapters(txn *badger2.Txn, public identity.Public, config
 	messageRepository := badger.NewMessageRepository(txn, rawMessageIdentifier)
 	receiveLogRepository := badger.NewReceiveLogRepository(txn, messageRepository)
 	hops := extractHopsFromConfig(config)
-	socialGraphRepository := badger.NewSocialGraphRepository(txn, public, hops, banListRepository, banListHasher)
+	transactionEvents := badger.NewTransactionEvents()
+	socialGraphRepository := badger.NewSocialGraphRepository(txn, public, hops, banListRepository, banListHasher, transactionEvents)
 	pubRepository := badger.NewPubRepository(txn)
-	feedRepository := badger.NewFeedRepository(txn, socialGraphRepository, receiveLogRepository, messageRepository, pubRepository, blobRepository, banListRepository, scuttlebutt)
+	feedRepository := badger.NewFeedRepository(txn, socialGraphRepository, receiveLogRepository, messageRepository, pubRepository, blobRepository, banListRepository, scuttlebutt, transactionEvents)
 	txAdapters := notx.TxAdapters{
 		BanListRepository:      banListRepository,
 		BlobRepository:         blobRepository,
@@ -204,7 +209,8 @@ func buildBadgerTestAdapters(txn *badger2.Txn, testAdaptersDependencies badger.T
 	receiveLogRepository := badger.NewReceiveLogRepository(txn, messageRepository)
 	public := testAdaptersDependencies.LocalIdentity
 	hops := fixtures.SomeHops()
-	socialGraphRepository := badger.NewSocialGraphRepository(txn, public, hops, banListRepository, banListHasherMock)
+	transactionEvents := badger.NewTransactionEvents()
+	socialGraphRepository := badger.NewSocialGraphRepository(txn, public, hops, banListRepository, banListHasherMock, transactionEvents)
 	pubRepository := badger.NewPubRepository(txn)
 	messageContentMappings := transport.DefaultMappings()
 	logger := fixtures.SomeLogger()
@@ -216,17 +222,25 @@ func buildBadgerTestAdapters(txn *badger2.Txn, testAdaptersDependencies badger.T
 	parser := content.NewParser(marshaler, scanner)
 	messageHMAC := formats.NewDefaultMessageHMAC()
 	scuttlebutt := formats.NewScuttlebutt(parser, messageHMAC)
-	feedRepository := badger.NewFeedRepository(txn, socialGraphRepository, receiveLogRepository, messageRepository, pubRepository, blobRepository, banListRepository, scuttlebutt)
+	feedRepository := badger.NewFeedRepository(txn, socialGraphRepository, receiveLogRepository, messageRepository, pubRepository, blobRepository, banListRepository, scuttlebutt, transactionEvents)
+	inviteRepository := badger.NewInviteRepository(txn)
+	roomMemberRepository := badger.NewRoomMemberRepository(txn)
+	roomAliasRepository := badger.NewRoomAliasRepository(txn)
+	outOfOrderMessageRepository := badger.NewOutOfOrderMessageRepository(txn, rawMessageIdentifierMock)
 	testAdapters := badger.TestAdapters{
-		BanListRepository:      banListRepository,
-		BlobRepository:         blobRepository,
-		BlobWantListRepository: blobWantListRepository,
-		FeedWantListRepository: feedWantListRepository,
-		MessageRepository:      messageRepository,
-		ReceiveLogRepository:   receiveLogRepository,
-		SocialGraphRepository:  socialGraphRepository,
-		PubRepository:          pubRepository,
-		FeedRepository:         feedRepository,
+		BanListRepository:           banListRepository,
+		BlobRepository:              blobRepository,
+		BlobWantListRepository:      blobWantListRepository,
+		FeedWantListRepository:      feedWantListRepository,
+		MessageRepository:           messageRepository,
+		ReceiveLogRepository:        receiveLogRepository,
+		SocialGraphRepository:       socialGraphRepository,
+		PubRepository:               pubRepository,
+		FeedRepository:              feedRepository,
+		InviteRepository:            inviteRepository,
+		RoomMemberRepository:        roomMemberRepository,
+		RoomAliasRepository:         roomAliasRepository,
+		OutOfOrderMessageRepository: outOfOrderMessageRepository,
 	}
 	return testAdapters, nil
 }
@@ -240,47 +254,77 @@ func BuildTestCommands(tb testing.TB) (TestCommands, error) {
 	roomsAliasRegisterHandler := commands.NewRoomsAliasRegisterHandler(dialerMock, private)
 	roomsAliasRevokeHandler := commands.NewRoomsAliasRevokeHandler(dialerMock)
 	peerManagerMock := mocks.NewPeerManagerMock()
-	processRoomAttendantEventHandler := commands.NewProcessRoomAttendantEventHandler(peerManagerMock)
+	roomAttendantsRegistry := adapters.NewRoomAttendantsRegistry()
+	currentTimeProviderMock := mocks.NewCurrentTimeProviderMock()
+	processRoomAttendantEventHandler := commands.NewProcessRoomAttendantEventHandler(peerManagerMock, roomAttendantsRegistry, currentTimeProviderMock)
 	disconnectAllHandler := commands.NewDisconnectAllHandler(peerManagerMock)
 	feedWantListRepositoryMock := mocks.NewFeedWantListRepositoryMock()
 	feedRepositoryMock := mocks.NewFeedRepositoryMock()
 	receiveLogRepositoryMock := mocks.NewReceiveLogRepositoryMock()
+	inviteRepositoryMock := mocks.NewInviteRepositoryMock()
+	socialGraphRepositoryMock := mocks.NewSocialGraphRepositoryMock()
+	outOfOrderMessageRepositoryMock := mocks.NewOutOfOrderMessageRepositoryMock()
 	commandsAdapters := commands.Adapters{
-		FeedWantList: feedWantListRepositoryMock,
-		Feed:         feedRepositoryMock,
-		ReceiveLog:   receiveLogRepositoryMock,
+		FeedWantList:      feedWantListRepositoryMock,
+		Feed:              feedRepositoryMock,
+		ReceiveLog:        receiveLogRepositoryMock,
+		Invite:            inviteRepositoryMock,
+		SocialGraph:       socialGraphRepositoryMock,
+		OutOfOrderMessage: outOfOrderMessageRepositoryMock,
 	}
 	mockCommandsTransactionProvider := mocks.NewMockCommandsTransactionProvider(commandsAdapters)
-	currentTimeProviderMock := mocks.NewCurrentTimeProviderMock()
 	downloadFeedHandler := commands.NewDownloadFeedHandler(mockCommandsTransactionProvider, currentTimeProviderMock)
 	inviteRedeemerMock := mocks.NewInviteRedeemerMock()
 	logger := fixtures.TestLogger(tb)
 	redeemInviteHandler := commands.NewRedeemInviteHandler(inviteRedeemerMock, private, logger)
 	public := privateIdentityToPublicIdentity(private)
+	createInviteHandler := commands.NewCreateInviteHandler(mockCommandsTransactionProvider, public, logger)
+	messageContentMappings := transport.DefaultMappings()
+	marshaler, err := transport.NewMarshaler(messageContentMappings, logger)
+	if err != nil {
+		return TestCommands{}, err
+	}
+	useInviteHandler := commands.NewUseInviteHandler(mockCommandsTransactionProvider, private, marshaler, currentTimeProviderMock, logger)
 	peerInitializerMock := mocks.NewPeerInitializerMock()
 	acceptTunnelConnectHandler := commands.NewAcceptTunnelConnectHandler(public, peerInitializerMock)
+	rawMessageIdentifierMock := mocks.NewRawMessageIdentifierMock()
+	fetchOutOfOrderMessageHandler := commands.NewFetchOutOfOrderMessageHandler(peerManagerMock, rawMessageIdentifierMock, mockCommandsTransactionProvider, logger)
+	httpAuthClientChallengeRepository := adapters.NewHttpAuthClientChallengeRepository()
+	roomsHttpAuthCreateClientChallengeHandler := commands.NewRoomsHttpAuthCreateClientChallengeHandler(httpAuthClientChallengeRepository, currentTimeProviderMock)
+	roomsHttpAuthSolveChallengeHandler := commands.NewRoomsHttpAuthSolveChallengeHandler(httpAuthClientChallengeRepository, currentTimeProviderMock, private)
 	goSSBRepoReaderMock := mocks.NewGoSSBRepoReaderMock()
 	contentParser := mocks.NewContentParser()
 	migrationHandlerImportDataFromGoSSB := commands.NewMigrationHandlerImportDataFromGoSSB(goSSBRepoReaderMock, mockCommandsTransactionProvider, contentParser, logger)
 	testCommands := TestCommands{
-		RoomsAliasRegister:           roomsAliasRegisterHandler,
-		RoomsAliasRevoke:             roomsAliasRevokeHandler,
-		ProcessRoomAttendantEvent:    processRoomAttendantEventHandler,
-		DisconnectAll:                disconnectAllHandler,
-		DownloadFeed:                 downloadFeedHandler,
-		RedeemInvite:                 redeemInviteHandler,
-		AcceptTunnelConnect:          acceptTunnelConnectHandler,
-		MigrationImportDataFromGoSSB: migrationHandlerImportDataFromGoSSB,
-		PeerManager:                  peerManagerMock,
-		Dialer:                       dialerMock,
-		FeedWantListRepository:       feedWantListRepositoryMock,
-		CurrentTimeProvider:          currentTimeProviderMock,
-		InviteRedeemer:               inviteRedeemerMock,
-		Local:                        public,
-		PeerInitializer:              peerInitializerMock,
-		GoSSBRepoReader:              goSSBRepoReaderMock,
-		FeedRepository:               feedRepositoryMock,
-		ReceiveLog:                   receiveLogRepositoryMock,
+		RoomsAliasRegister:                 roomsAliasRegisterHandler,
+		RoomsAliasRevoke:                   roomsAliasRevokeHandler,
+		ProcessRoomAttendantEvent:          processRoomAttendantEventHandler,
+		DisconnectAll:                      disconnectAllHandler,
+		DownloadFeed:                       downloadFeedHandler,
+		RedeemInvite:                       redeemInviteHandler,
+		CreateInvite:                       createInviteHandler,
+		UseInvite:                          useInviteHandler,
+		AcceptTunnelConnect:                acceptTunnelConnectHandler,
+		FetchOutOfOrderMessage:             fetchOutOfOrderMessageHandler,
+		RoomsHttpAuthCreateClientChallenge: roomsHttpAuthCreateClientChallengeHandler,
+		RoomsHttpAuthSolveChallenge:        roomsHttpAuthSolveChallengeHandler,
+		MigrationImportDataFromGoSSB:       migrationHandlerImportDataFromGoSSB,
+		PeerManager:                        peerManagerMock,
+		Dialer:                             dialerMock,
+		FeedWantListRepository:             feedWantListRepositoryMock,
+		OutOfOrderMessage:                  outOfOrderMessageRepositoryMock,
+		RawMessageIdentifier:               rawMessageIdentifierMock,
+		CurrentTimeProvider:                currentTimeProviderMock,
+		InviteRedeemer:                     inviteRedeemerMock,
+		Local:                              public,
+		PeerInitializer:                    peerInitializerMock,
+		GoSSBRepoReader:                    goSSBRepoReaderMock,
+		FeedRepository:                     feedRepositoryMock,
+		ReceiveLog:                         receiveLogRepositoryMock,
+		InviteRepository:                   inviteRepositoryMock,
+		SocialGraphRepository:              socialGraphRepositoryMock,
+		TransactionProvider:                mockCommandsTransactionProvider,
+		RoomAttendantsRegistry:             roomAttendantsRegistry,
 	}
 	return testCommands, nil
 }
@@ -292,13 +336,19 @@ func BuildTestQueries(tb testing.TB) (TestQueries, error) {
 	socialGraphRepositoryMock := mocks.NewSocialGraphRepositoryMock()
 	feedWantListRepositoryMock := mocks.NewFeedWantListRepositoryMock()
 	banListRepositoryMock := mocks.NewBanListRepositoryMock()
+	roomMemberRepositoryMock := mocks.NewRoomMemberRepositoryMock()
+	roomAliasRepositoryMock := mocks.NewRoomAliasRepositoryMock()
+	outOfOrderMessageRepositoryMock := mocks.NewOutOfOrderMessageRepositoryMock()
 	queriesAdapters := queries.Adapters{
-		Feed:         feedRepositoryMock,
-		ReceiveLog:   receiveLogRepositoryMock,
-		Message:      messageRepositoryMock,
-		SocialGraph:  socialGraphRepositoryMock,
-		FeedWantList: feedWantListRepositoryMock,
-		BanList:      banListRepositoryMock,
+		Feed:              feedRepositoryMock,
+		ReceiveLog:        receiveLogRepositoryMock,
+		Message:           messageRepositoryMock,
+		SocialGraph:       socialGraphRepositoryMock,
+		FeedWantList:      feedWantListRepositoryMock,
+		BanList:           banListRepositoryMock,
+		RoomMember:        roomMemberRepositoryMock,
+		RoomAlias:         roomAliasRepositoryMock,
+		OutOfOrderMessage: outOfOrderMessageRepositoryMock,
 	}
 	mockQueriesTransactionProvider := mocks.NewMockQueriesTransactionProvider(queriesAdapters)
 	messagePubSub := pubsub.NewMessagePubSub()
@@ -306,6 +356,7 @@ func BuildTestQueries(tb testing.TB) (TestQueries, error) {
 	logger := fixtures.TestLogger(tb)
 	createHistoryStreamHandler := queries.NewCreateHistoryStreamHandler(mockQueriesTransactionProvider, messagePubSubMock, logger)
 	receiveLogHandler := queries.NewReceiveLogHandler(mockQueriesTransactionProvider)
+	feedMessagesHandler := queries.NewFeedMessagesHandler(mockQueriesTransactionProvider)
 	private, err := identity.NewPrivate()
 	if err != nil {
 		return TestQueries{}, err
@@ -316,7 +367,8 @@ func BuildTestQueries(tb testing.TB) (TestQueries, error) {
 		return TestQueries{}, err
 	}
 	peerManagerMock := mocks.NewPeerManagerMock()
-	statusHandler := queries.NewStatusHandler(mockQueriesTransactionProvider, peerManagerMock)
+	roundTripTimeProviderMock := mocks.NewRoundTripTimeProviderMock()
+	statusHandler := queries.NewStatusHandler(mockQueriesTransactionProvider, peerManagerMock, roundTripTimeProviderMock)
 	blobStorageMock := mocks.NewBlobStorageMock()
 	getBlobHandler, err := queries.NewGetBlobHandler(blobStorageMock)
 	if err != nil {
@@ -324,23 +376,56 @@ func BuildTestQueries(tb testing.TB) (TestQueries, error) {
 	}
 	blobDownloadedPubSubMock := mocks.NewBlobDownloadedPubSubMock()
 	blobDownloadedEventsHandler := queries.NewBlobDownloadedEventsHandler(blobDownloadedPubSubMock)
+	messageSavedEventsHandler := queries.NewMessageSavedEventsHandler(messagePubSubMock)
+	nodeEventPubSub := pubsub.NewNodeEventPubSub()
+	nodeEventsHandler := queries.NewNodeEventsHandler(nodeEventPubSub)
 	dialerMock := mocks.NewDialerMock()
 	roomsListAliasesHandler, err := queries.NewRoomsListAliasesHandler(dialerMock, public)
 	if err != nil {
 		return TestQueries{}, err
 	}
+	roomHTTPClientMock := mocks.NewRoomHTTPClientMock()
+	roomsResolveAliasHandler := queries.NewRoomsResolveAliasHandler(roomHTTPClientMock)
 	getMessageHandler := queries.NewGetMessageHandler(mockQueriesTransactionProvider)
 	getMessageBySequenceHandler := queries.NewGetMessageBySequenceHandler(mockQueriesTransactionProvider)
+	roomMembersHandler := queries.NewRoomMembersHandler(mockQueriesTransactionProvider)
+	roomServerResolveAliasHandler := queries.NewRoomServerResolveAliasHandler(mockQueriesTransactionProvider)
+	roomAttendantsRegistry := adapters.NewRoomAttendantsRegistry()
+	roomAttendantsHandler := queries.NewRoomAttendantsHandler(roomAttendantsRegistry, peerManagerMock)
+	roomAttendantsSubscribeHandler := queries.NewRoomAttendantsSubscribeHandler(roomAttendantsRegistry, peerManagerMock)
+	hops := fixtures.SomeHops()
+	friendsHopsHandler, err := queries.NewFriendsHopsHandler(mockQueriesTransactionProvider, public, hops)
+	if err != nil {
+		return TestQueries{}, err
+	}
+	getContactHandler := queries.NewGetContactHandler(mockQueriesTransactionProvider)
+	contactPubSub := pubsub.NewContactPubSub()
+	contactsSubscribeHandler := queries.NewContactsSubscribeHandler(mockQueriesTransactionProvider, contactPubSub)
+	replicateUptoHandler := queries.NewReplicateUptoHandler(mockQueriesTransactionProvider)
+	latestSequenceHandler := queries.NewLatestSequenceHandler(mockQueriesTransactionProvider)
 	appQueries := app.Queries{
-		CreateHistoryStream:  createHistoryStreamHandler,
-		ReceiveLog:           receiveLogHandler,
-		PublishedLog:         publishedLogHandler,
-		Status:               statusHandler,
-		GetBlob:              getBlobHandler,
-		BlobDownloadedEvents: blobDownloadedEventsHandler,
-		RoomsListAliases:     roomsListAliasesHandler,
-		GetMessage:           getMessageHandler,
-		GetMessageBySequence: getMessageBySequenceHandler,
+		CreateHistoryStream:     createHistoryStreamHandler,
+		ReceiveLog:              receiveLogHandler,
+		FeedMessages:            feedMessagesHandler,
+		PublishedLog:            publishedLogHandler,
+		Status:                  statusHandler,
+		GetBlob:                 getBlobHandler,
+		BlobDownloadedEvents:    blobDownloadedEventsHandler,
+		MessageSavedEvents:      messageSavedEventsHandler,
+		NodeEvents:              nodeEventsHandler,
+		RoomsListAliases:        roomsListAliasesHandler,
+		RoomsResolveAlias:       roomsResolveAliasHandler,
+		GetMessage:              getMessageHandler,
+		GetMessageBySequence:    getMessageBySequenceHandler,
+		RoomMembers:             roomMembersHandler,
+		RoomServerResolveAlias:  roomServerResolveAliasHandler,
+		RoomAttendants:          roomAttendantsHandler,
+		RoomAttendantsSubscribe: roomAttendantsSubscribeHandler,
+		FriendsHops:             friendsHopsHandler,
+		GetContact:              getContactHandler,
+		ContactsSubscribe:       contactsSubscribeHandler,
+		ReplicateUpto:           replicateUptoHandler,
+		LatestSequence:          latestSequenceHandler,
 	}
 	wantedFeedsProvider := queries.NewWantedFeedsProvider(mockQueriesTransactionProvider)
 	testQueries := TestQueries{
@@ -352,20 +437,27 @@ func BuildTestQueries(tb testing.TB) (TestQueries, error) {
 		SocialGraphRepository:  socialGraphRepositoryMock,
 		FeedWantListRepository: feedWantListRepositoryMock,
 		BanListRepository:      banListRepositoryMock,
+		OutOfOrderMessage:      outOfOrderMessageRepositoryMock,
 		MessagePubSub:          messagePubSubMock,
 		PeerManager:            peerManagerMock,
+		RoundTripTimeProvider:  roundTripTimeProviderMock,
 		BlobStorage:            blobStorageMock,
 		Dialer:                 dialerMock,
+		RoomHTTPClient:         roomHTTPClientMock,
+		RoomAttendantsRegistry: roomAttendantsRegistry,
+		ContactPubSub:          contactPubSub,
+		NodeEventPubSub:        nodeEventPubSub,
 		LocalIdentity:          public,
+		Hops:                   hops,
 	}
 	return testQueries, nil
 }
 
-func buildBadgerCommandsAdapters(txn *badger2.Txn, public identity.Public, config service.Config, logger logging.Logger) (commands.Adapters, error) {
+func buildBadgerCommandsAdapters(txn *badger2.Txn, transactionEvents *badger.TransactionEvents, public identity.Public, config service.Config, logger logging.Logger) (commands.Adapters, error) {
 	hops := extractHopsFromConfig(config)
 	banListHasher := adapters.NewBanListHasher()
 	banListRepository := badger.NewBanListRepository(txn, banListHasher)
-	socialGraphRepository := badger.NewSocialGraphRepository(txn, public, hops, banListRepository, banListHasher)
+	socialGraphRepository := badger.NewSocialGraphRepository(txn, public, hops, banListRepository, banListHasher, transactionEvents)
 	messageContentMappings := transport.DefaultMappings()
 	marshaler, err := transport.NewMarshaler(messageContentMappings, logger)
 	if err != nil {
@@ -381,17 +473,25 @@ func buildBadgerCommandsAdapters(txn *badger2.Txn, public identity.Public, confi
 	receiveLogRepository := badger.NewReceiveLogRepository(txn, messageRepository)
 	pubRepository := badger.NewPubRepository(txn)
 	blobRepository := badger.NewBlobRepository(txn)
-	feedRepository := badger.NewFeedRepository(txn, socialGraphRepository, receiveLogRepository, messageRepository, pubRepository, blobRepository, banListRepository, scuttlebutt)
+	feedRepository := badger.NewFeedRepository(txn, socialGraphRepository, receiveLogRepository, messageRepository, pubRepository, blobRepository, banListRepository, scuttlebutt, transactionEvents)
 	currentTimeProvider := adapters.NewCurrentTimeProvider()
 	blobWantListRepository := badger.NewBlobWantListRepository(txn, currentTimeProvider)
 	feedWantListRepository := badger.NewFeedWantListRepository(txn, currentTimeProvider)
+	inviteRepository := badger.NewInviteRepository(txn)
+	roomMemberRepository := badger.NewRoomMemberRepository(txn)
+	roomAliasRepository := badger.NewRoomAliasRepository(txn)
+	outOfOrderMessageRepository := badger.NewOutOfOrderMessageRepository(txn, rawMessageIdentifier)
 	commandsAdapters := commands.Adapters{
-		Feed:         feedRepository,
-		ReceiveLog:   receiveLogRepository,
-		SocialGraph:  socialGraphRepository,
-		BlobWantList: blobWantListRepository,
-		FeedWantList: feedWantListRepository,
-		BanList:      banListRepository,
+		Feed:              feedRepository,
+		ReceiveLog:        receiveLogRepository,
+		SocialGraph:       socialGraphRepository,
+		BlobWantList:      blobWantListRepository,
+		FeedWantList:      feedWantListRepository,
+		BanList:           banListRepository,
+		Invite:            inviteRepository,
+		RoomMember:        roomMemberRepository,
+		RoomAlias:         roomAliasRepository,
+		OutOfOrderMessage: outOfOrderMessageRepository,
 	}
 	return commandsAdapters, nil
 }
@@ -400,7 +500,8 @@ func buildBadgerQueriesAdapters(txn *badger2.Txn, public identity.Public, config
 	hops := extractHopsFromConfig(config)
 	banListHasher := adapters.NewBanListHasher()
 	banListRepository := badger.NewBanListRepository(txn, banListHasher)
-	socialGraphRepository := badger.NewSocialGraphRepository(txn, public, hops, banListRepository, banListHasher)
+	transactionEvents := badger.NewTransactionEvents()
+	socialGraphRepository := badger.NewSocialGraphRepository(txn, public, hops, banListRepository, banListHasher, transactionEvents)
 	messageContentMappings := transport.DefaultMappings()
 	marshaler, err := transport.NewMarshaler(messageContentMappings, logger)
 	if err != nil {
@@ -416,16 +517,22 @@ func buildBadgerQueriesAdapters(txn *badger2.Txn, public identity.Public, config
 	receiveLogRepository := badger.NewReceiveLogRepository(txn, messageRepository)
 	pubRepository := badger.NewPubRepository(txn)
 	blobRepository := badger.NewBlobRepository(txn)
-	feedRepository := badger.NewFeedRepository(txn, socialGraphRepository, receiveLogRepository, messageRepository, pubRepository, blobRepository, banListRepository, scuttlebutt)
+	feedRepository := badger.NewFeedRepository(txn, socialGraphRepository, receiveLogRepository, messageRepository, pubRepository, blobRepository, banListRepository, scuttlebutt, transactionEvents)
 	currentTimeProvider := adapters.NewCurrentTimeProvider()
 	feedWantListRepository := badger.NewFeedWantListRepository(txn, currentTimeProvider)
+	roomMemberRepository := badger.NewRoomMemberRepository(txn)
+	roomAliasRepository := badger.NewRoomAliasRepository(txn)
+	outOfOrderMessageRepository := badger.NewOutOfOrderMessageRepository(txn, rawMessageIdentifier)
 	queriesAdapters := queries.Adapters{
-		Feed:         feedRepository,
-		ReceiveLog:   receiveLogRepository,
-		Message:      messageRepository,
-		SocialGraph:  socialGraphRepository,
-		FeedWantList: feedWantListRepository,
-		BanList:      banListRepository,
+		Feed:              feedRepository,
+		ReceiveLog:        receiveLogRepository,
+		Message:           messageRepository,
+		SocialGraph:       socialGraphRepository,
+		FeedWantList:      feedWantListRepository,
+		BanList:           banListRepository,
+		RoomMember:        roomMemberRepository,
+		RoomAlias:         roomAliasRepository,
+		OutOfOrderMessage: outOfOrderMessageRepository,
 	}
 	return queriesAdapters, nil
 }
@@ -442,14 +549,19 @@ func BuildService(private identity.Private, config service.Config) (service.Serv
 	requestPubSub := pubsub.NewRequestPubSub()
 	connectionIdGenerator := rpc.NewConnectionIdGenerator()
 	newPeerPubSub := pubsub.NewNewPeerPubSub()
+	responseStreamTimeouts := extractResponseStreamTimeoutsFromConfig(config)
+	diMetrics := newMetrics(config)
+	exporter := extractTracingExporterFromConfig(config)
 	loggingSystem := extractLoggingSystemFromConfig(config)
 	logger := newContextLogger(loggingSystem)
-	peerInitializer := transport2.NewPeerInitializer(handshaker, requestPubSub, connectionIdGenerator, newPeerPubSub, logger)
+	tracer := tracing.NewTracer(exporter, logger)
+	nodeEventPubSub := pubsub.NewNodeEventPubSub()
+	peerInitializer := transport2.NewPeerInitializer(handshaker, requestPubSub, connectionIdGenerator, newPeerPubSub, responseStreamTimeouts, diMetrics, tracer, nodeEventPubSub, logger)
 	dialer, err := network.NewDialer(peerInitializer, logger)
 	if err != nil {
 		return service.Service{}, nil, err
 	}
-	inviteDialer := invites.NewInviteDialer(dialer, networkKey, requestPubSub, connectionIdGenerator, currentTimeProvider, logger)
+	inviteDialer := invites.NewInviteDialer(dialer, networkKey, requestPubSub, connectionIdGenerator, currentTimeProvider, responseStreamTimeouts, diMetrics, tracer, nodeEventPubSub, logger)
 	inviteRedeemer := invites2.NewInviteRedeemer(inviteDialer, logger)
 	redeemInviteHandler := commands.NewRedeemInviteHandler(inviteRedeemer, private, logger)
 	db, cleanup, err := newBadger(loggingSystem, logger, config)
@@ -458,41 +570,57 @@ func BuildService(private identity.Private, config service.Config) (service.Serv
 	}
 	public := privateIdentityToPublicIdentity(private)
 	commandsAdaptersFactory := badgerCommandsAdaptersFactory(config, public, logger)
-	commandsTransactionProvider := badger.NewCommandsTransactionProvider(db, commandsAdaptersFactory)
+	contactPubSub := pubsub.NewContactPubSub()
+	messagePubSub := pubsub.NewMessagePubSub()
+	commandsTransactionProvider := badger.NewCommandsTransactionProvider(db, commandsAdaptersFactory, contactPubSub, messagePubSub)
+	createInviteHandler := commands.NewCreateInviteHandler(commandsTransactionProvider, public, logger)
 	messageContentMappings := transport.DefaultMappings()
 	marshaler, err := transport.NewMarshaler(messageContentMappings, logger)
 	if err != nil {
 		cleanup()
 		return service.Service{}, nil, err
 	}
+	useInviteHandler := commands.NewUseInviteHandler(commandsTransactionProvider, private, marshaler, currentTimeProvider, logger)
 	followHandler := commands.NewFollowHandler(commandsTransactionProvider, private, marshaler, logger)
 	transactionRawMessagePublisher := commands.NewTransactionRawMessagePublisher(commandsTransactionProvider)
 	publishRawHandler := commands.NewPublishRawHandler(transactionRawMessagePublisher, private)
 	publishRawAsIdentityHandler := commands.NewPublishRawAsIdentityHandler(transactionRawMessagePublisher)
 	downloadFeedHandler := commands.NewDownloadFeedHandler(commandsTransactionProvider, currentTimeProvider)
 	peerManagerConfig := extractPeerManagerConfigFromConfig(config)
-	tunnelDialer := tunnel.NewDialer(peerInitializer)
+	tunnelDialer := newTunnelDialer(peerInitializer, config)
 	peerManager := domain.NewPeerManager(peerManagerConfig, dialer, tunnelDialer, logger)
+	scanner := blobs.NewScanner()
+	parser := content.NewParser(marshaler, scanner)
+	messageHMAC := extractMessageHMACFromConfig(config)
+	scuttlebutt := formats.NewScuttlebutt(parser, messageHMAC)
+	v := newFormats(scuttlebutt)
+	rawMessageIdentifier := formats.NewRawMessageIdentifier(v)
+	fetchOutOfOrderMessageHandler := commands.NewFetchOutOfOrderMessageHandler(peerManager, rawMessageIdentifier, commandsTransactionProvider, logger)
 	connectHandler := commands.NewConnectHandler(peerManager, logger)
 	disconnectAllHandler := commands.NewDisconnectAllHandler(peerManager)
 	downloadBlobHandler := commands.NewDownloadBlobHandler(commandsTransactionProvider, currentTimeProvider)
-	filesystemStorage, err := newFilesystemStorage(logger, config)
+	filesystemStorage, err := newFilesystemStorage(diMetrics, logger, config)
 	if err != nil {
 		cleanup()
 		return service.Service{}, nil, err
 	}
 	createBlobHandler := commands.NewCreateBlobHandler(filesystemStorage)
-	addToBanListHandler := commands.NewAddToBanListHandler(commandsTransactionProvider)
-	removeFromBanListHandler := commands.NewRemoveFromBanListHandler(commandsTransactionProvider)
-	setBanListHandler := commands.NewSetBanListHandler(commandsTransactionProvider)
+	addToBanListHandler := commands.NewAddToBanListHandler(commandsTransactionProvider, nodeEventPubSub)
+	removeFromBanListHandler := commands.NewRemoveFromBanListHandler(commandsTransactionProvider, nodeEventPubSub)
+	setBanListHandler := commands.NewSetBanListHandler(commandsTransactionProvider, nodeEventPubSub)
 	roomsAliasRegisterHandler := commands.NewRoomsAliasRegisterHandler(dialer, private)
 	roomsAliasRevokeHandler := commands.NewRoomsAliasRevokeHandler(dialer)
+	roomsHttpAuthSignInHandler := commands.NewRoomsHttpAuthSignInHandler(dialer, private)
+	httpAuthClientChallengeRepository := adapters.NewHttpAuthClientChallengeRepository()
+	roomsHttpAuthCreateClientChallengeHandler := commands.NewRoomsHttpAuthCreateClientChallengeHandler(httpAuthClientChallengeRepository, currentTimeProvider)
+	httpClient := rooms.NewHTTPClient()
+	roomsConsumeHttpInviteHandler := commands.NewRoomsConsumeHttpInviteHandler(httpClient, private)
+	addRoomMemberHandler := commands.NewAddRoomMemberHandler(commandsTransactionProvider)
+	removeRoomMemberHandler := commands.NewRemoveRoomMemberHandler(commandsTransactionProvider)
 	badgerStorage := migrations.NewBadgerStorage(db)
 	runner := migrations2.NewRunner(badgerStorage, logger)
 	goSSBRepoReader := migrations.NewGoSSBRepoReader(logger)
 	migrationHandlerDeleteGoSSBRepositoryInOldFormat := commands.NewMigrationHandlerDeleteGoSSBRepositoryInOldFormat(goSSBRepoReader, logger)
-	scanner := blobs.NewScanner()
-	parser := content.NewParser(marshaler, scanner)
 	migrationHandlerImportDataFromGoSSB := commands.NewMigrationHandlerImportDataFromGoSSB(goSSBRepoReader, commandsTransactionProvider, parser, logger)
 	commandsMigrations := commands.Migrations{
 		MigrationDeleteGoSSBRepositoryInOldFormat: migrationHandlerDeleteGoSSBRepositoryInOldFormat,
@@ -500,41 +628,55 @@ func BuildService(private identity.Private, config service.Config) (service.Serv
 	}
 	commandDeleteGoSsbRepositoryInOldFormatAdapter := newCommandDeleteGoSsbRepositoryInOldFormatAdapter(config, commandsMigrations)
 	commandImportDataFromGoSSBHandlerAdapter := newCommandImportDataFromGoSSBHandlerAdapter(config, commandsMigrations)
-	v := newMigrationsList(commandDeleteGoSsbRepositoryInOldFormatAdapter, commandImportDataFromGoSSBHandlerAdapter)
-	migrationsMigrations, err := migrations2.NewMigrations(v)
+	v2 := newMigrationsList(commandDeleteGoSsbRepositoryInOldFormatAdapter, commandImportDataFromGoSSBHandlerAdapter)
+	migrationsMigrations, err := migrations2.NewMigrations(v2)
 	if err != nil {
 		cleanup()
 		return service.Service{}, nil, err
 	}
-	runMigrationsHandler := commands.NewRunMigrationsHandler(runner, migrationsMigrations)
+	runMigrationsHandler := commands.NewRunMigrationsHandler(runner, migrationsMigrations, nodeEventPubSub)
 	appCommands := app.Commands{
-		RedeemInvite:         redeemInviteHandler,
-		Follow:               followHandler,
-		PublishRaw:           publishRawHandler,
-		PublishRawAsIdentity: publishRawAsIdentityHandler,
-		DownloadFeed:         downloadFeedHandler,
-		Connect:              connectHandler,
-		DisconnectAll:        disconnectAllHandler,
-		DownloadBlob:         downloadBlobHandler,
-		CreateBlob:           createBlobHandler,
-		AddToBanList:         addToBanListHandler,
-		RemoveFromBanList:    removeFromBanListHandler,
-		SetBanList:           setBanListHandler,
-		RoomsAliasRegister:   roomsAliasRegisterHandler,
-		RoomsAliasRevoke:     roomsAliasRevokeHandler,
-		RunMigrations:        runMigrationsHandler,
+		RedeemInvite:                       redeemInviteHandler,
+		CreateInvite:                       createInviteHandler,
+		UseInvite:                          useInviteHandler,
+		Follow:                             followHandler,
+		PublishRaw:                         publishRawHandler,
+		PublishRawAsIdentity:               publishRawAsIdentityHandler,
+		DownloadFeed:                       downloadFeedHandler,
+		FetchOutOfOrderMessage:             fetchOutOfOrderMessageHandler,
+		Connect:                            connectHandler,
+		DisconnectAll:                      disconnectAllHandler,
+		DownloadBlob:                       downloadBlobHandler,
+		CreateBlob:                         createBlobHandler,
+		AddToBanList:                       addToBanListHandler,
+		RemoveFromBanList:                  removeFromBanListHandler,
+		SetBanList:                         setBanListHandler,
+		RoomsAliasRegister:                 roomsAliasRegisterHandler,
+		RoomsAliasRevoke:                   roomsAliasRevokeHandler,
+		RoomsHttpAuthSignIn:                roomsHttpAuthSignInHandler,
+		RoomsHttpAuthCreateClientChallenge: roomsHttpAuthCreateClientChallengeHandler,
+		RoomsConsumeHttpInvite:             roomsConsumeHttpInviteHandler,
+		AddRoomMember:                      addRoomMemberHandler,
+		RemoveRoomMember:                   removeRoomMemberHandler,
+		RunMigrations:                      runMigrationsHandler,
 	}
 	queriesAdaptersFactory := badgerQueriesAdaptersFactory(config, public, logger)
 	queriesTransactionProvider := badger.NewQueriesTransactionProvider(db, queriesAdaptersFactory)
-	messagePubSub := pubsub.NewMessagePubSub()
 	createHistoryStreamHandler := queries.NewCreateHistoryStreamHandler(queriesTransactionProvider, messagePubSub, logger)
 	receiveLogHandler := queries.NewReceiveLogHandler(queriesTransactionProvider)
+	feedMessagesHandler := queries.NewFeedMessagesHandler(queriesTransactionProvider)
 	publishedLogHandler, err := queries.NewPublishedLogHandler(queriesTransactionProvider, public)
 	if err != nil {
 		cleanup()
 		return service.Service{}, nil, err
 	}
-	statusHandler := queries.NewStatusHandler(queriesTransactionProvider, peerManager)
+	pingConfig := extractPingConfigFromConfig(config)
+	pinger, err := ping.NewPinger(pingConfig, logger)
+	if err != nil {
+		cleanup()
+		return service.Service{}, nil, err
+	}
+	statusHandler := queries.NewStatusHandler(queriesTransactionProvider, peerManager, pinger)
 	getBlobHandler, err := queries.NewGetBlobHandler(filesystemStorage)
 	if err != nil {
 		cleanup()
@@ -542,23 +684,54 @@ func BuildService(private identity.Private, config service.Config) (service.Serv
 	}
 	blobDownloadedPubSub := pubsub.NewBlobDownloadedPubSub()
 	blobDownloadedEventsHandler := queries.NewBlobDownloadedEventsHandler(blobDownloadedPubSub)
+	messageSavedEventsHandler := queries.NewMessageSavedEventsHandler(messagePubSub)
+	nodeEventsHandler := queries.NewNodeEventsHandler(nodeEventPubSub)
 	roomsListAliasesHandler, err := queries.NewRoomsListAliasesHandler(dialer, public)
 	if err != nil {
 		cleanup()
 		return service.Service{}, nil, err
 	}
+	roomsResolveAliasHandler := queries.NewRoomsResolveAliasHandler(httpClient)
 	getMessageHandler := queries.NewGetMessageHandler(queriesTransactionProvider)
 	getMessageBySequenceHandler := queries.NewGetMessageBySequenceHandler(queriesTransactionProvider)
+	roomMembersHandler := queries.NewRoomMembersHandler(queriesTransactionProvider)
+	roomServerResolveAliasHandler := queries.NewRoomServerResolveAliasHandler(queriesTransactionProvider)
+	roomAttendantsRegistry := adapters.NewRoomAttendantsRegistry()
+	roomAttendantsHandler := queries.NewRoomAttendantsHandler(roomAttendantsRegistry, peerManager)
+	roomAttendantsSubscribeHandler := queries.NewRoomAttendantsSubscribeHandler(roomAttendantsRegistry, peerManager)
+	hops := extractHopsFromConfig(config)
+	friendsHopsHandler, err := queries.NewFriendsHopsHandler(queriesTransactionProvider, public, hops)
+	if err != nil {
+		cleanup()
+		return service.Service{}, nil, err
+	}
+	getContactHandler := queries.NewGetContactHandler(queriesTransactionProvider)
+	contactsSubscribeHandler := queries.NewContactsSubscribeHandler(queriesTransactionProvider, contactPubSub)
+	replicateUptoHandler := queries.NewReplicateUptoHandler(queriesTransactionProvider)
+	latestSequenceHandler := queries.NewLatestSequenceHandler(queriesTransactionProvider)
 	appQueries := app.Queries{
-		CreateHistoryStream:  createHistoryStreamHandler,
-		ReceiveLog:           receiveLogHandler,
-		PublishedLog:         publishedLogHandler,
-		Status:               statusHandler,
-		GetBlob:              getBlobHandler,
-		BlobDownloadedEvents: blobDownloadedEventsHandler,
-		RoomsListAliases:     roomsListAliasesHandler,
-		GetMessage:           getMessageHandler,
-		GetMessageBySequence: getMessageBySequenceHandler,
+		CreateHistoryStream:     createHistoryStreamHandler,
+		ReceiveLog:              receiveLogHandler,
+		FeedMessages:            feedMessagesHandler,
+		PublishedLog:            publishedLogHandler,
+		Status:                  statusHandler,
+		GetBlob:                 getBlobHandler,
+		BlobDownloadedEvents:    blobDownloadedEventsHandler,
+		MessageSavedEvents:      messageSavedEventsHandler,
+		NodeEvents:              nodeEventsHandler,
+		RoomsListAliases:        roomsListAliasesHandler,
+		RoomsResolveAlias:       roomsResolveAliasHandler,
+		GetMessage:              getMessageHandler,
+		GetMessageBySequence:    getMessageBySequenceHandler,
+		RoomMembers:             roomMembersHandler,
+		RoomServerResolveAlias:  roomServerResolveAliasHandler,
+		RoomAttendants:          roomAttendantsHandler,
+		RoomAttendantsSubscribe: roomAttendantsSubscribeHandler,
+		FriendsHops:             friendsHopsHandler,
+		GetContact:              getContactHandler,
+		ContactsSubscribe:       contactsSubscribeHandler,
+		ReplicateUpto:           replicateUptoHandler,
+		LatestSequence:          latestSequenceHandler,
 	}
 	application := app.Application{
 		Commands: appCommands,
@@ -569,6 +742,22 @@ func BuildService(private identity.Private, config service.Config) (service.Serv
 		cleanup()
 		return service.Service{}, nil, err
 	}
+	webSocketListener, err := newWebSocketListener(peerInitializer, config, logger)
+	if err != nil {
+		cleanup()
+		return service.Service{}, nil, err
+	}
+	unixListener, err := newUnixListener(public, requestPubSub, connectionIdGenerator, config, logger)
+	if err != nil {
+		cleanup()
+		return service.Service{}, nil, err
+	}
+	httpServer, err := newHTTPServer(application, config, logger)
+	if err != nil {
+		cleanup()
+		return service.Service{}, nil, err
+	}
+	metricsServer := newMetricsServer(diMetrics, config, logger)
 	discoverer, err := local.NewDiscoverer(public, logger)
 	if err != nil {
 		cleanup()
@@ -596,18 +785,15 @@ func BuildService(private identity.Private, config service.Config) (service.Serv
 	createWantsHandler := commands.NewCreateWantsHandler(manager)
 	handlerBlobsCreateWants := rpc2.NewHandlerBlobsCreateWants(createWantsHandler)
 	sessionTracker := ebt.NewSessionTracker()
-	messageHMAC := extractMessageHMACFromConfig(config)
-	scuttlebutt := formats.NewScuttlebutt(parser, messageHMAC)
-	v2 := newFormats(scuttlebutt)
-	rawMessageIdentifier := formats.NewRawMessageIdentifier(v2)
 	wantedFeedsProvider := queries.NewWantedFeedsProvider(queriesTransactionProvider)
-	wantedFeedsCache := replication2.NewWantedFeedsCache(wantedFeedsProvider)
-	messageBuffer := commands.NewMessageBuffer(commandsTransactionProvider, rawMessageIdentifier, wantedFeedsCache, logger)
+	wantedFeedsCache := replication2.NewWantedFeedsCache(wantedFeedsProvider, diMetrics)
+	publishingForkedFeedTracker := adapters.NewPublishingForkedFeedTracker(wantedFeedsCache, nodeEventPubSub)
+	messageBuffer := commands.NewMessageBuffer(commandsTransactionProvider, rawMessageIdentifier, publishingForkedFeedTracker, diMetrics, tracer, logger)
 	rawMessageHandler := commands.NewRawMessageHandler(rawMessageIdentifier, messageBuffer, logger)
 	createHistoryStreamHandlerAdapter := ebt2.NewCreateHistoryStreamHandlerAdapter(createHistoryStreamHandler)
-	sessionRunner := ebt.NewSessionRunner(logger, rawMessageHandler, wantedFeedsCache, createHistoryStreamHandlerAdapter)
-	gossipManager := gossip.NewManager(logger, wantedFeedsCache)
-	gossipReplicator, err := gossip.NewGossipReplicator(gossipManager, rawMessageHandler, logger)
+	sessionRunner := ebt.NewSessionRunner(logger, rawMessageHandler, wantedFeedsCache, createHistoryStreamHandlerAdapter, diMetrics, rawMessageIdentifier, nodeEventPubSub, tracer)
+	gossipManager := gossip.NewManager(logger, wantedFeedsCache, diMetrics)
+	gossipReplicator, err := gossip.NewGossipReplicator(gossipManager, rawMessageHandler, tracer, nodeEventPubSub, logger)
 	if err != nil {
 		cleanup()
 		return service.Service{}, nil, err
@@ -616,33 +802,89 @@ func BuildService(private identity.Private, config service.Config) (service.Serv
 	handleIncomingEbtReplicateHandler := commands.NewHandleIncomingEbtReplicateHandler(replicator)
 	handlerEbtReplicate := rpc2.NewHandlerEbtReplicate(handleIncomingEbtReplicateHandler)
 	acceptTunnelConnectHandler := commands.NewAcceptTunnelConnectHandler(public, peerInitializer)
-	handlerTunnelConnect := rpc2.NewHandlerTunnelConnect(acceptTunnelConnectHandler)
-	v3 := rpc2.NewMuxHandlers(handlerBlobsGet, handlerBlobsCreateWants, handlerEbtReplicate, handlerTunnelConnect)
+	serverConfig := extractRoomServerConfigFromConfig(config)
+	room := server.NewRoom(public, serverConfig, logger)
+	roomServerConnectHandler := commands.NewRoomServerConnectHandler(commandsTransactionProvider, room)
+	handlerTunnelConnect := rpc2.NewHandlerTunnelConnect(public, acceptTunnelConnectHandler, roomServerConnectHandler)
+	handlerGossipPing := rpc2.NewHandlerGossipPing(currentTimeProvider)
+	handlerWhoami := rpc2.NewHandlerWhoami(public)
+	handlerInviteUse := rpc2.NewHandlerInviteUse(useInviteHandler, getMessageHandler)
+	roomServerMetadataHandler := queries.NewRoomServerMetadataHandler(queriesTransactionProvider, room)
+	handlerRoomMetadata := rpc2.NewHandlerRoomMetadata(roomServerMetadataHandler)
+	roomServerAttendantsHandler := queries.NewRoomServerAttendantsHandler(queriesTransactionProvider, room)
+	handlerRoomAttendants := rpc2.NewHandlerRoomAttendants(roomServerAttendantsHandler)
+	roomServerAnnounceHandler := commands.NewRoomServerAnnounceHandler(commandsTransactionProvider, room)
+	handlerTunnelAnnounce := rpc2.NewHandlerTunnelAnnounce(roomServerAnnounceHandler)
+	roomServerLeaveHandler := commands.NewRoomServerLeaveHandler(room)
+	handlerTunnelLeave := rpc2.NewHandlerTunnelLeave(roomServerLeaveHandler)
+	roomServerEndpointsHandler := queries.NewRoomServerEndpointsHandler(queriesTransactionProvider, room)
+	handlerTunnelEndpoints := rpc2.NewHandlerTunnelEndpoints(roomServerEndpointsHandler)
+	roomServerRegisterAliasHandler := commands.NewRoomServerRegisterAliasHandler(commandsTransactionProvider, room)
+	handlerRoomRegisterAlias := rpc2.NewHandlerRoomRegisterAlias(roomServerRegisterAliasHandler)
+	roomServerRevokeAliasHandler := commands.NewRoomServerRevokeAliasHandler(commandsTransactionProvider, room)
+	handlerRoomRevokeAlias := rpc2.NewHandlerRoomRevokeAlias(roomServerRevokeAliasHandler)
+	roomServerListAliasesHandler := queries.NewRoomServerListAliasesHandler(queriesTransactionProvider, room)
+	handlerRoomListAliases := rpc2.NewHandlerRoomListAliases(roomServerListAliasesHandler)
+	roomsHttpAuthSolveChallengeHandler := commands.NewRoomsHttpAuthSolveChallengeHandler(httpAuthClientChallengeRepository, currentTimeProvider, private)
+	handlerHttpAuthRequestSolution := rpc2.NewHandlerHttpAuthRequestSolution(roomsHttpAuthSolveChallengeHandler)
+	hasBlobHandler := queries.NewHasBlobHandler(filesystemStorage)
+	handlerBlobsHas := rpc2.NewHandlerBlobsHas(hasBlobHandler)
+	getBlobSizeHandler := queries.NewGetBlobSizeHandler(filesystemStorage)
+	handlerBlobsSize := rpc2.NewHandlerBlobsSize(getBlobSizeHandler)
+	handlerBlobsChanges := rpc2.NewHandlerBlobsChanges(blobDownloadedEventsHandler)
+	handlerOooGet := rpc2.NewHandlerOooGet(getMessageHandler)
+	handlerReplicateUpto := rpc2.NewHandlerReplicateUpto(replicateUptoHandler)
+	handlerLatestSequence := rpc2.NewHandlerLatestSequence(latestSequenceHandler)
+	v3 := rpc2.NewMuxHandlers(handlerBlobsGet, handlerBlobsCreateWants, handlerEbtReplicate, handlerTunnelConnect, handlerGossipPing, handlerWhoami, handlerInviteUse, handlerRoomMetadata, handlerRoomAttendants, handlerTunnelAnnounce, handlerTunnelLeave, handlerTunnelEndpoints, handlerRoomRegisterAlias, handlerRoomRevokeAlias, handlerRoomListAliases, handlerHttpAuthRequestSolution, handlerBlobsHas, handlerBlobsSize, handlerBlobsChanges, handlerOooGet, handlerReplicateUpto, handlerLatestSequence)
 	handlerCreateHistoryStream := rpc2.NewHandlerCreateHistoryStream(createHistoryStreamHandler, logger)
 	v4 := rpc2.NewMuxClosingHandlers(handlerCreateHistoryStream)
-	muxMux, err := mux.NewMux(logger, v3, v4)
+	handlerFriendsHops := rpc2.NewHandlerFriendsHops(friendsHopsHandler)
+	handlerFriendsIsFollowing := rpc2.NewHandlerFriendsIsFollowing(getContactHandler)
+	handlerFriendsIsBlocking := rpc2.NewHandlerFriendsIsBlocking(getContactHandler)
+	handlerFriendsStream := rpc2.NewHandlerFriendsStream(contactsSubscribeHandler)
+	handlerScuttlegoPublish := rpc2.NewHandlerScuttlegoPublish(publishRawHandler)
+	handlerScuttlegoFollow := rpc2.NewHandlerScuttlegoFollow(followHandler)
+	handlerScuttlegoStatus := rpc2.NewHandlerScuttlegoStatus(statusHandler)
+	handlerScuttlegoGetMessage := rpc2.NewHandlerScuttlegoGetMessage(getMessageHandler)
+	handlerScuttlegoReceiveLog := rpc2.NewHandlerScuttlegoReceiveLog(receiveLogHandler)
+	handlerScuttlegoAddBlob := rpc2.NewHandlerScuttlegoAddBlob(createBlobHandler)
+	handlerScuttlegoAddToBanList := rpc2.NewHandlerScuttlegoAddToBanList(addToBanListHandler)
+	handlerScuttlegoRemoveFromBanList := rpc2.NewHandlerScuttlegoRemoveFromBanList(removeFromBanListHandler)
+	handlerScuttlegoRedeemInvite := rpc2.NewHandlerScuttlegoRedeemInvite(redeemInviteHandler)
+	handlerScuttlegoRegisterAlias := rpc2.NewHandlerScuttlegoRegisterAlias(roomsAliasRegisterHandler)
+	handlerScuttlegoRevokeAlias := rpc2.NewHandlerScuttlegoRevokeAlias(roomsAliasRevokeHandler)
+	handlerScuttlegoListAliases := rpc2.NewHandlerScuttlegoListAliases(roomsListAliasesHandler)
+	handlerScuttlegoConnect := rpc2.NewHandlerScuttlegoConnect(connectHandler)
+	handlerPublish := rpc2.NewHandlerPublish(publishRawHandler, getMessageHandler)
+	handlerCreateLogStream := rpc2.NewHandlerCreateLogStream(receiveLogHandler, messageSavedEventsHandler)
+	handlerCreateUserStream := rpc2.NewHandlerCreateUserStream(feedMessagesHandler, messageSavedEventsHandler)
+	handlerGet := rpc2.NewHandlerGet(getMessageHandler)
+	handlerBlobsAdd := rpc2.NewHandlerBlobsAdd(createBlobHandler)
+	handlerStatus := rpc2.NewHandlerStatus(statusHandler)
+	privilegedHandlers := rpc2.NewMuxPrivilegedHandlers(handlerFriendsHops, handlerFriendsIsFollowing, handlerFriendsIsBlocking, handlerFriendsStream, handlerScuttlegoPublish, handlerScuttlegoFollow, handlerScuttlegoStatus, handlerScuttlegoGetMessage, handlerScuttlegoReceiveLog, handlerScuttlegoAddBlob, handlerScuttlegoAddToBanList, handlerScuttlegoRemoveFromBanList, handlerScuttlegoRedeemInvite, handlerScuttlegoRegisterAlias, handlerScuttlegoRevokeAlias, handlerScuttlegoListAliases, handlerScuttlegoConnect, handlerPublish, handlerCreateLogStream, handlerCreateUserStream, handlerGet, handlerBlobsAdd, handlerStatus)
+	mux, err := rpc2.NewMux(logger, diMetrics, v3, v4, privilegedHandlers)
 	if err != nil {
 		cleanup()
 		return service.Service{}, nil, err
 	}
-	requestSubscriber := pubsub2.NewRequestSubscriber(requestPubSub, muxMux)
+	requestSubscriber := pubsub2.NewRequestSubscriber(requestPubSub, mux)
 	roomAttendantEventPubSub := pubsub.NewRoomAttendantEventPubSub()
-	processRoomAttendantEventHandler := commands.NewProcessRoomAttendantEventHandler(peerManager)
+	processRoomAttendantEventHandler := commands.NewProcessRoomAttendantEventHandler(peerManager, roomAttendantsRegistry, currentTimeProvider)
 	roomAttendantEventSubscriber := pubsub2.NewRoomAttendantEventSubscriber(roomAttendantEventPubSub, processRoomAttendantEventHandler, logger)
 	negotiator := replication2.NewNegotiator(logger, replicator, gossipReplicator)
 	replicationReplicator := replication.NewReplicator(manager)
 	peerRPCAdapter := rooms.NewPeerRPCAdapter(logger)
 	roomsScanner := rooms.NewScanner(peerRPCAdapter, peerRPCAdapter, roomAttendantEventPubSub, logger)
-	acceptNewPeerHandler := commands.NewAcceptNewPeerHandler(peerManager, negotiator, replicationReplicator, roomsScanner, logger)
+	acceptNewPeerHandler := commands.NewAcceptNewPeerHandler(peerManager, negotiator, replicationReplicator, roomsScanner, pinger, room, logger)
 	newPeerSubscriber := pubsub2.NewNewPeerSubscriber(newPeerPubSub, acceptNewPeerHandler, logger)
-	advertiser, err := newAdvertiser(public, config)
+	advertiser, err := newAdvertiser(public, config, logger)
 	if err != nil {
 		cleanup()
 		return service.Service{}, nil, err
 	}
-	garbageCollector := badger.NewGarbageCollector(db, logger)
+	garbageCollector := badger.NewGarbageCollector(db, diMetrics, logger)
 	noTxFeedWantListRepository := notx.NewNoTxFeedWantListRepository(txAdaptersFactoryTransactionProvider, logger)
-	serviceService := service.NewService(application, listener, networkDiscoverer, connectionEstablisher, requestSubscriber, roomAttendantEventSubscriber, newPeerSubscriber, advertiser, messageBuffer, createHistoryStreamHandler, garbageCollector, noTxFeedWantListRepository, noTxBlobWantListRepository)
+	serviceService := service.NewService(application, listener, webSocketListener, unixListener, httpServer, metricsServer, networkDiscoverer, connectionEstablisher, requestSubscriber, roomAttendantEventSubscriber, newPeerSubscriber, advertiser, messageBuffer, createHistoryStreamHandler, garbageCollector, noTxFeedWantListRepository, noTxBlobWantListRepository)
 	return serviceService, func() {
 		cleanup()
 	}, nil
@@ -660,14 +902,19 @@ func buildIntegrationTestsService(t *testing.T) (IntegrationTestsService, func()
 	requestPubSub := pubsub.NewRequestPubSub()
 	connectionIdGenerator := rpc.NewConnectionIdGenerator()
 	newPeerPubSub := pubsub.NewNewPeerPubSub()
+	responseStreamTimeouts := extractResponseStreamTimeoutsFromConfig(config)
+	diMetrics := newMetrics(config)
+	exporter := extractTracingExporterFromConfig(config)
 	loggingSystem := extractLoggingSystemFromConfig(config)
 	logger := newContextLogger(loggingSystem)
-	peerInitializer := transport2.NewPeerInitializer(handshaker, requestPubSub, connectionIdGenerator, newPeerPubSub, logger)
+	tracer := tracing.NewTracer(exporter, logger)
+	nodeEventPubSub := pubsub.NewNodeEventPubSub()
+	peerInitializer := transport2.NewPeerInitializer(handshaker, requestPubSub, connectionIdGenerator, newPeerPubSub, responseStreamTimeouts, diMetrics, tracer, nodeEventPubSub, logger)
 	dialer, err := network.NewDialer(peerInitializer, logger)
 	if err != nil {
 		return IntegrationTestsService{}, nil, err
 	}
-	inviteDialer := invites.NewInviteDialer(dialer, networkKey, requestPubSub, connectionIdGenerator, currentTimeProvider, logger)
+	inviteDialer := invites.NewInviteDialer(dialer, networkKey, requestPubSub, connectionIdGenerator, currentTimeProvider, responseStreamTimeouts, diMetrics, tracer, nodeEventPubSub, logger)
 	inviteRedeemer := invites2.NewInviteRedeemer(inviteDialer, logger)
 	redeemInviteHandler := commands.NewRedeemInviteHandler(inviteRedeemer, private, logger)
 	db, cleanup, err := newBadger(loggingSystem, logger, config)
@@ -676,41 +923,57 @@ func buildIntegrationTestsService(t *testing.T) (IntegrationTestsService, func()
 	}
 	public := privateIdentityToPublicIdentity(private)
 	commandsAdaptersFactory := badgerCommandsAdaptersFactory(config, public, logger)
-	commandsTransactionProvider := badger.NewCommandsTransactionProvider(db, commandsAdaptersFactory)
+	contactPubSub := pubsub.NewContactPubSub()
+	messagePubSub := pubsub.NewMessagePubSub()
+	commandsTransactionProvider := badger.NewCommandsTransactionProvider(db, commandsAdaptersFactory, contactPubSub, messagePubSub)
+	createInviteHandler := commands.NewCreateInviteHandler(commandsTransactionProvider, public, logger)
 	messageContentMappings := transport.DefaultMappings()
 	marshaler, err := transport.NewMarshaler(messageContentMappings, logger)
 	if err != nil {
 		cleanup()
 		return IntegrationTestsService{}, nil, err
 	}
+	useInviteHandler := commands.NewUseInviteHandler(commandsTransactionProvider, private, marshaler, currentTimeProvider, logger)
 	followHandler := commands.NewFollowHandler(commandsTransactionProvider, private, marshaler, logger)
 	transactionRawMessagePublisher := commands.NewTransactionRawMessagePublisher(commandsTransactionProvider)
 	publishRawHandler := commands.NewPublishRawHandler(transactionRawMessagePublisher, private)
 	publishRawAsIdentityHandler := commands.NewPublishRawAsIdentityHandler(transactionRawMessagePublisher)
 	downloadFeedHandler := commands.NewDownloadFeedHandler(commandsTransactionProvider, currentTimeProvider)
 	peerManagerConfig := extractPeerManagerConfigFromConfig(config)
-	tunnelDialer := tunnel.NewDialer(peerInitializer)
+	tunnelDialer := newTunnelDialer(peerInitializer, config)
 	peerManager := domain.NewPeerManager(peerManagerConfig, dialer, tunnelDialer, logger)
+	scanner := blobs.NewScanner()
+	parser := content.NewParser(marshaler, scanner)
+	messageHMAC := extractMessageHMACFromConfig(config)
+	scuttlebutt := formats.NewScuttlebutt(parser, messageHMAC)
+	v := newFormats(scuttlebutt)
+	rawMessageIdentifier := formats.NewRawMessageIdentifier(v)
+	fetchOutOfOrderMessageHandler := commands.NewFetchOutOfOrderMessageHandler(peerManager, rawMessageIdentifier, commandsTransactionProvider, logger)
 	connectHandler := commands.NewConnectHandler(peerManager, logger)
 	disconnectAllHandler := commands.NewDisconnectAllHandler(peerManager)
 	downloadBlobHandler := commands.NewDownloadBlobHandler(commandsTransactionProvider, currentTimeProvider)
-	filesystemStorage, err := newFilesystemStorage(logger, config)
+	filesystemStorage, err := newFilesystemStorage(diMetrics, logger, config)
 	if err != nil {
 		cleanup()
 		return IntegrationTestsService{}, nil, err
 	}
 	createBlobHandler := commands.NewCreateBlobHandler(filesystemStorage)
-	addToBanListHandler := commands.NewAddToBanListHandler(commandsTransactionProvider)
-	removeFromBanListHandler := commands.NewRemoveFromBanListHandler(commandsTransactionProvider)
-	setBanListHandler := commands.NewSetBanListHandler(commandsTransactionProvider)
+	addToBanListHandler := commands.NewAddToBanListHandler(commandsTransactionProvider, nodeEventPubSub)
+	removeFromBanListHandler := commands.NewRemoveFromBanListHandler(commandsTransactionProvider, nodeEventPubSub)
+	setBanListHandler := commands.NewSetBanListHandler(commandsTransactionProvider, nodeEventPubSub)
 	roomsAliasRegisterHandler := commands.NewRoomsAliasRegisterHandler(dialer, private)
 	roomsAliasRevokeHandler := commands.NewRoomsAliasRevokeHandler(dialer)
+	roomsHttpAuthSignInHandler := commands.NewRoomsHttpAuthSignInHandler(dialer, private)
+	httpAuthClientChallengeRepository := adapters.NewHttpAuthClientChallengeRepository()
+	roomsHttpAuthCreateClientChallengeHandler := commands.NewRoomsHttpAuthCreateClientChallengeHandler(httpAuthClientChallengeRepository, currentTimeProvider)
+	httpClient := rooms.NewHTTPClient()
+	roomsConsumeHttpInviteHandler := commands.NewRoomsConsumeHttpInviteHandler(httpClient, private)
+	addRoomMemberHandler := commands.NewAddRoomMemberHandler(commandsTransactionProvider)
+	removeRoomMemberHandler := commands.NewRemoveRoomMemberHandler(commandsTransactionProvider)
 	badgerStorage := migrations.NewBadgerStorage(db)
 	runner := migrations2.NewRunner(badgerStorage, logger)
 	goSSBRepoReader := migrations.NewGoSSBRepoReader(logger)
 	migrationHandlerDeleteGoSSBRepositoryInOldFormat := commands.NewMigrationHandlerDeleteGoSSBRepositoryInOldFormat(goSSBRepoReader, logger)
-	scanner := blobs.NewScanner()
-	parser := content.NewParser(marshaler, scanner)
 	migrationHandlerImportDataFromGoSSB := commands.NewMigrationHandlerImportDataFromGoSSB(goSSBRepoReader, commandsTransactionProvider, parser, logger)
 	commandsMigrations := commands.Migrations{
 		MigrationDeleteGoSSBRepositoryInOldFormat: migrationHandlerDeleteGoSSBRepositoryInOldFormat,
@@ -718,41 +981,55 @@ func buildIntegrationTestsService(t *testing.T) (IntegrationTestsService, func()
 	}
 	commandDeleteGoSsbRepositoryInOldFormatAdapter := newCommandDeleteGoSsbRepositoryInOldFormatAdapter(config, commandsMigrations)
 	commandImportDataFromGoSSBHandlerAdapter := newCommandImportDataFromGoSSBHandlerAdapter(config, commandsMigrations)
-	v := newMigrationsList(commandDeleteGoSsbRepositoryInOldFormatAdapter, commandImportDataFromGoSSBHandlerAdapter)
-	migrationsMigrations, err := migrations2.NewMigrations(v)
+	v2 := newMigrationsList(commandDeleteGoSsbRepositoryInOldFormatAdapter, commandImportDataFromGoSSBHandlerAdapter)
+	migrationsMigrations, err := migrations2.NewMigrations(v2)
 	if err != nil {
 		cleanup()
 		return IntegrationTestsService{}, nil, err
 	}
-	runMigrationsHandler := commands.NewRunMigrationsHandler(runner, migrationsMigrations)
+	runMigrationsHandler := commands.NewRunMigrationsHandler(runner, migrationsMigrations, nodeEventPubSub)
 	appCommands := app.Commands{
-		RedeemInvite:         redeemInviteHandler,
-		Follow:               followHandler,
-		PublishRaw:           publishRawHandler,
-		PublishRawAsIdentity: publishRawAsIdentityHandler,
-		DownloadFeed:         downloadFeedHandler,
-		Connect:              connectHandler,
-		DisconnectAll:        disconnectAllHandler,
-		DownloadBlob:         downloadBlobHandler,
-		CreateBlob:           createBlobHandler,
-		AddToBanList:         addToBanListHandler,
-		RemoveFromBanList:    removeFromBanListHandler,
-		SetBanList:           setBanListHandler,
-		RoomsAliasRegister:   roomsAliasRegisterHandler,
-		RoomsAliasRevoke:     roomsAliasRevokeHandler,
-		RunMigrations:        runMigrationsHandler,
+		RedeemInvite:                       redeemInviteHandler,
+		CreateInvite:                       createInviteHandler,
+		UseInvite:                          useInviteHandler,
+		Follow:                             followHandler,
+		PublishRaw:                         publishRawHandler,
+		PublishRawAsIdentity:               publishRawAsIdentityHandler,
+		DownloadFeed:                       downloadFeedHandler,
+		FetchOutOfOrderMessage:             fetchOutOfOrderMessageHandler,
+		Connect:                            connectHandler,
+		DisconnectAll:                      disconnectAllHandler,
+		DownloadBlob:                       downloadBlobHandler,
+		CreateBlob:                         createBlobHandler,
+		AddToBanList:                       addToBanListHandler,
+		RemoveFromBanList:                  removeFromBanListHandler,
+		SetBanList:                         setBanListHandler,
+		RoomsAliasRegister:                 roomsAliasRegisterHandler,
+		RoomsAliasRevoke:                   roomsAliasRevokeHandler,
+		RoomsHttpAuthSignIn:                roomsHttpAuthSignInHandler,
+		RoomsHttpAuthCreateClientChallenge: roomsHttpAuthCreateClientChallengeHandler,
+		RoomsConsumeHttpInvite:             roomsConsumeHttpInviteHandler,
+		AddRoomMember:                      addRoomMemberHandler,
+		RemoveRoomMember:                   removeRoomMemberHandler,
+		RunMigrations:                      runMigrationsHandler,
 	}
 	queriesAdaptersFactory := badgerQueriesAdaptersFactory(config, public, logger)
 	queriesTransactionProvider := badger.NewQueriesTransactionProvider(db, queriesAdaptersFactory)
-	messagePubSub := pubsub.NewMessagePubSub()
 	createHistoryStreamHandler := queries.NewCreateHistoryStreamHandler(queriesTransactionProvider, messagePubSub, logger)
 	receiveLogHandler := queries.NewReceiveLogHandler(queriesTransactionProvider)
+	feedMessagesHandler := queries.NewFeedMessagesHandler(queriesTransactionProvider)
 	publishedLogHandler, err := queries.NewPublishedLogHandler(queriesTransactionProvider, public)
 	if err != nil {
 		cleanup()
 		return IntegrationTestsService{}, nil, err
 	}
-	statusHandler := queries.NewStatusHandler(queriesTransactionProvider, peerManager)
+	pingConfig := extractPingConfigFromConfig(config)
+	pinger, err := ping.NewPinger(pingConfig, logger)
+	if err != nil {
+		cleanup()
+		return IntegrationTestsService{}, nil, err
+	}
+	statusHandler := queries.NewStatusHandler(queriesTransactionProvider, peerManager, pinger)
 	getBlobHandler, err := queries.NewGetBlobHandler(filesystemStorage)
 	if err != nil {
 		cleanup()
@@ -760,23 +1037,54 @@ func buildIntegrationTestsService(t *testing.T) (IntegrationTestsService, func()
 	}
 	blobDownloadedPubSub := pubsub.NewBlobDownloadedPubSub()
 	blobDownloadedEventsHandler := queries.NewBlobDownloadedEventsHandler(blobDownloadedPubSub)
+	messageSavedEventsHandler := queries.NewMessageSavedEventsHandler(messagePubSub)
+	nodeEventsHandler := queries.NewNodeEventsHandler(nodeEventPubSub)
 	roomsListAliasesHandler, err := queries.NewRoomsListAliasesHandler(dialer, public)
 	if err != nil {
 		cleanup()
 		return IntegrationTestsService{}, nil, err
 	}
+	roomsResolveAliasHandler := queries.NewRoomsResolveAliasHandler(httpClient)
 	getMessageHandler := queries.NewGetMessageHandler(queriesTransactionProvider)
 	getMessageBySequenceHandler := queries.NewGetMessageBySequenceHandler(queriesTransactionProvider)
+	roomMembersHandler := queries.NewRoomMembersHandler(queriesTransactionProvider)
+	roomServerResolveAliasHandler := queries.NewRoomServerResolveAliasHandler(queriesTransactionProvider)
+	roomAttendantsRegistry := adapters.NewRoomAttendantsRegistry()
+	roomAttendantsHandler := queries.NewRoomAttendantsHandler(roomAttendantsRegistry, peerManager)
+	roomAttendantsSubscribeHandler := queries.NewRoomAttendantsSubscribeHandler(roomAttendantsRegistry, peerManager)
+	hops := extractHopsFromConfig(config)
+	friendsHopsHandler, err := queries.NewFriendsHopsHandler(queriesTransactionProvider, public, hops)
+	if err != nil {
+		cleanup()
+		return IntegrationTestsService{}, nil, err
+	}
+	getContactHandler := queries.NewGetContactHandler(queriesTransactionProvider)
+	contactsSubscribeHandler := queries.NewContactsSubscribeHandler(queriesTransactionProvider, contactPubSub)
+	replicateUptoHandler := queries.NewReplicateUptoHandler(queriesTransactionProvider)
+	latestSequenceHandler := queries.NewLatestSequenceHandler(queriesTransactionProvider)
 	appQueries := app.Queries{
-		CreateHistoryStream:  createHistoryStreamHandler,
-		ReceiveLog:           receiveLogHandler,
-		PublishedLog:         publishedLogHandler,
-		Status:               statusHandler,
-		GetBlob:              getBlobHandler,
-		BlobDownloadedEvents: blobDownloadedEventsHandler,
-		RoomsListAliases:     roomsListAliasesHandler,
-		GetMessage:           getMessageHandler,
-		GetMessageBySequence: getMessageBySequenceHandler,
+		CreateHistoryStream:     createHistoryStreamHandler,
+		ReceiveLog:              receiveLogHandler,
+		FeedMessages:            feedMessagesHandler,
+		PublishedLog:            publishedLogHandler,
+		Status:                  statusHandler,
+		GetBlob:                 getBlobHandler,
+		BlobDownloadedEvents:    blobDownloadedEventsHandler,
+		MessageSavedEvents:      messageSavedEventsHandler,
+		NodeEvents:              nodeEventsHandler,
+		RoomsListAliases:        roomsListAliasesHandler,
+		RoomsResolveAlias:       roomsResolveAliasHandler,
+		GetMessage:              getMessageHandler,
+		GetMessageBySequence:    getMessageBySequenceHandler,
+		RoomMembers:             roomMembersHandler,
+		RoomServerResolveAlias:  roomServerResolveAliasHandler,
+		RoomAttendants:          roomAttendantsHandler,
+		RoomAttendantsSubscribe: roomAttendantsSubscribeHandler,
+		FriendsHops:             friendsHopsHandler,
+		GetContact:              getContactHandler,
+		ContactsSubscribe:       contactsSubscribeHandler,
+		ReplicateUpto:           replicateUptoHandler,
+		LatestSequence:          latestSequenceHandler,
 	}
 	application := app.Application{
 		Commands: appCommands,
@@ -787,6 +1095,22 @@ func buildIntegrationTestsService(t *testing.T) (IntegrationTestsService, func()
 		cleanup()
 		return IntegrationTestsService{}, nil, err
 	}
+	webSocketListener, err := newWebSocketListener(peerInitializer, config, logger)
+	if err != nil {
+		cleanup()
+		return IntegrationTestsService{}, nil, err
+	}
+	unixListener, err := newUnixListener(public, requestPubSub, connectionIdGenerator, config, logger)
+	if err != nil {
+		cleanup()
+		return IntegrationTestsService{}, nil, err
+	}
+	httpServer, err := newHTTPServer(application, config, logger)
+	if err != nil {
+		cleanup()
+		return IntegrationTestsService{}, nil, err
+	}
+	metricsServer := newMetricsServer(diMetrics, config, logger)
 	discoverer, err := local.NewDiscoverer(public, logger)
 	if err != nil {
 		cleanup()
@@ -814,18 +1138,15 @@ func buildIntegrationTestsService(t *testing.T) (IntegrationTestsService, func()
 	createWantsHandler := commands.NewCreateWantsHandler(manager)
 	handlerBlobsCreateWants := rpc2.NewHandlerBlobsCreateWants(createWantsHandler)
 	sessionTracker := ebt.NewSessionTracker()
-	messageHMAC := extractMessageHMACFromConfig(config)
-	scuttlebutt := formats.NewScuttlebutt(parser, messageHMAC)
-	v2 := newFormats(scuttlebutt)
-	rawMessageIdentifier := formats.NewRawMessageIdentifier(v2)
 	wantedFeedsProvider := queries.NewWantedFeedsProvider(queriesTransactionProvider)
-	wantedFeedsCache := replication2.NewWantedFeedsCache(wantedFeedsProvider)
-	messageBuffer := commands.NewMessageBuffer(commandsTransactionProvider, rawMessageIdentifier, wantedFeedsCache, logger)
+	wantedFeedsCache := replication2.NewWantedFeedsCache(wantedFeedsProvider, diMetrics)
+	publishingForkedFeedTracker := adapters.NewPublishingForkedFeedTracker(wantedFeedsCache, nodeEventPubSub)
+	messageBuffer := commands.NewMessageBuffer(commandsTransactionProvider, rawMessageIdentifier, publishingForkedFeedTracker, diMetrics, tracer, logger)
 	rawMessageHandler := commands.NewRawMessageHandler(rawMessageIdentifier, messageBuffer, logger)
 	createHistoryStreamHandlerAdapter := ebt2.NewCreateHistoryStreamHandlerAdapter(createHistoryStreamHandler)
-	sessionRunner := ebt.NewSessionRunner(logger, rawMessageHandler, wantedFeedsCache, createHistoryStreamHandlerAdapter)
-	gossipManager := gossip.NewManager(logger, wantedFeedsCache)
-	gossipReplicator, err := gossip.NewGossipReplicator(gossipManager, rawMessageHandler, logger)
+	sessionRunner := ebt.NewSessionRunner(logger, rawMessageHandler, wantedFeedsCache, createHistoryStreamHandlerAdapter, diMetrics, rawMessageIdentifier, nodeEventPubSub, tracer)
+	gossipManager := gossip.NewManager(logger, wantedFeedsCache, diMetrics)
+	gossipReplicator, err := gossip.NewGossipReplicator(gossipManager, rawMessageHandler, tracer, nodeEventPubSub, logger)
 	if err != nil {
 		cleanup()
 		return IntegrationTestsService{}, nil, err
@@ -834,33 +1155,89 @@ func buildIntegrationTestsService(t *testing.T) (IntegrationTestsService, func()
 	handleIncomingEbtReplicateHandler := commands.NewHandleIncomingEbtReplicateHandler(replicator)
 	handlerEbtReplicate := rpc2.NewHandlerEbtReplicate(handleIncomingEbtReplicateHandler)
 	acceptTunnelConnectHandler := commands.NewAcceptTunnelConnectHandler(public, peerInitializer)
-	handlerTunnelConnect := rpc2.NewHandlerTunnelConnect(acceptTunnelConnectHandler)
-	v3 := rpc2.NewMuxHandlers(handlerBlobsGet, handlerBlobsCreateWants, handlerEbtReplicate, handlerTunnelConnect)
+	serverConfig := extractRoomServerConfigFromConfig(config)
+	room := server.NewRoom(public, serverConfig, logger)
+	roomServerConnectHandler := commands.NewRoomServerConnectHandler(commandsTransactionProvider, room)
+	handlerTunnelConnect := rpc2.NewHandlerTunnelConnect(public, acceptTunnelConnectHandler, roomServerConnectHandler)
+	handlerGossipPing := rpc2.NewHandlerGossipPing(currentTimeProvider)
+	handlerWhoami := rpc2.NewHandlerWhoami(public)
+	handlerInviteUse := rpc2.NewHandlerInviteUse(useInviteHandler, getMessageHandler)
+	roomServerMetadataHandler := queries.NewRoomServerMetadataHandler(queriesTransactionProvider, room)
+	handlerRoomMetadata := rpc2.NewHandlerRoomMetadata(roomServerMetadataHandler)
+	roomServerAttendantsHandler := queries.NewRoomServerAttendantsHandler(queriesTransactionProvider, room)
+	handlerRoomAttendants := rpc2.NewHandlerRoomAttendants(roomServerAttendantsHandler)
+	roomServerAnnounceHandler := commands.NewRoomServerAnnounceHandler(commandsTransactionProvider, room)
+	handlerTunnelAnnounce := rpc2.NewHandlerTunnelAnnounce(roomServerAnnounceHandler)
+	roomServerLeaveHandler := commands.NewRoomServerLeaveHandler(room)
+	handlerTunnelLeave := rpc2.NewHandlerTunnelLeave(roomServerLeaveHandler)
+	roomServerEndpointsHandler := queries.NewRoomServerEndpointsHandler(queriesTransactionProvider, room)
+	handlerTunnelEndpoints := rpc2.NewHandlerTunnelEndpoints(roomServerEndpointsHandler)
+	roomServerRegisterAliasHandler := commands.NewRoomServerRegisterAliasHandler(commandsTransactionProvider, room)
+	handlerRoomRegisterAlias := rpc2.NewHandlerRoomRegisterAlias(roomServerRegisterAliasHandler)
+	roomServerRevokeAliasHandler := commands.NewRoomServerRevokeAliasHandler(commandsTransactionProvider, room)
+	handlerRoomRevokeAlias := rpc2.NewHandlerRoomRevokeAlias(roomServerRevokeAliasHandler)
+	roomServerListAliasesHandler := queries.NewRoomServerListAliasesHandler(queriesTransactionProvider, room)
+	handlerRoomListAliases := rpc2.NewHandlerRoomListAliases(roomServerListAliasesHandler)
+	roomsHttpAuthSolveChallengeHandler := commands.NewRoomsHttpAuthSolveChallengeHandler(httpAuthClientChallengeRepository, currentTimeProvider, private)
+	handlerHttpAuthRequestSolution := rpc2.NewHandlerHttpAuthRequestSolution(roomsHttpAuthSolveChallengeHandler)
+	hasBlobHandler := queries.NewHasBlobHandler(filesystemStorage)
+	handlerBlobsHas := rpc2.NewHandlerBlobsHas(hasBlobHandler)
+	getBlobSizeHandler := queries.NewGetBlobSizeHandler(filesystemStorage)
+	handlerBlobsSize := rpc2.NewHandlerBlobsSize(getBlobSizeHandler)
+	handlerBlobsChanges := rpc2.NewHandlerBlobsChanges(blobDownloadedEventsHandler)
+	handlerOooGet := rpc2.NewHandlerOooGet(getMessageHandler)
+	handlerReplicateUpto := rpc2.NewHandlerReplicateUpto(replicateUptoHandler)
+	handlerLatestSequence := rpc2.NewHandlerLatestSequence(latestSequenceHandler)
+	v3 := rpc2.NewMuxHandlers(handlerBlobsGet, handlerBlobsCreateWants, handlerEbtReplicate, handlerTunnelConnect, handlerGossipPing, handlerWhoami, handlerInviteUse, handlerRoomMetadata, handlerRoomAttendants, handlerTunnelAnnounce, handlerTunnelLeave, handlerTunnelEndpoints, handlerRoomRegisterAlias, handlerRoomRevokeAlias, handlerRoomListAliases, handlerHttpAuthRequestSolution, handlerBlobsHas, handlerBlobsSize, handlerBlobsChanges, handlerOooGet, handlerReplicateUpto, handlerLatestSequence)
 	handlerCreateHistoryStream := rpc2.NewHandlerCreateHistoryStream(createHistoryStreamHandler, logger)
 	v4 := rpc2.NewMuxClosingHandlers(handlerCreateHistoryStream)
-	muxMux, err := mux.NewMux(logger, v3, v4)
+	handlerFriendsHops := rpc2.NewHandlerFriendsHops(friendsHopsHandler)
+	handlerFriendsIsFollowing := rpc2.NewHandlerFriendsIsFollowing(getContactHandler)
+	handlerFriendsIsBlocking := rpc2.NewHandlerFriendsIsBlocking(getContactHandler)
+	handlerFriendsStream := rpc2.NewHandlerFriendsStream(contactsSubscribeHandler)
+	handlerScuttlegoPublish := rpc2.NewHandlerScuttlegoPublish(publishRawHandler)
+	handlerScuttlegoFollow := rpc2.NewHandlerScuttlegoFollow(followHandler)
+	handlerScuttlegoStatus := rpc2.NewHandlerScuttlegoStatus(statusHandler)
+	handlerScuttlegoGetMessage := rpc2.NewHandlerScuttlegoGetMessage(getMessageHandler)
+	handlerScuttlegoReceiveLog := rpc2.NewHandlerScuttlegoReceiveLog(receiveLogHandler)
+	handlerScuttlegoAddBlob := rpc2.NewHandlerScuttlegoAddBlob(createBlobHandler)
+	handlerScuttlegoAddToBanList := rpc2.NewHandlerScuttlegoAddToBanList(addToBanListHandler)
+	handlerScuttlegoRemoveFromBanList := rpc2.NewHandlerScuttlegoRemoveFromBanList(removeFromBanListHandler)
+	handlerScuttlegoRedeemInvite := rpc2.NewHandlerScuttlegoRedeemInvite(redeemInviteHandler)
+	handlerScuttlegoRegisterAlias := rpc2.NewHandlerScuttlegoRegisterAlias(roomsAliasRegisterHandler)
+	handlerScuttlegoRevokeAlias := rpc2.NewHandlerScuttlegoRevokeAlias(roomsAliasRevokeHandler)
+	handlerScuttlegoListAliases := rpc2.NewHandlerScuttlegoListAliases(roomsListAliasesHandler)
+	handlerScuttlegoConnect := rpc2.NewHandlerScuttlegoConnect(connectHandler)
+	handlerPublish := rpc2.NewHandlerPublish(publishRawHandler, getMessageHandler)
+	handlerCreateLogStream := rpc2.NewHandlerCreateLogStream(receiveLogHandler, messageSavedEventsHandler)
+	handlerCreateUserStream := rpc2.NewHandlerCreateUserStream(feedMessagesHandler, messageSavedEventsHandler)
+	handlerGet := rpc2.NewHandlerGet(getMessageHandler)
+	handlerBlobsAdd := rpc2.NewHandlerBlobsAdd(createBlobHandler)
+	handlerStatus := rpc2.NewHandlerStatus(statusHandler)
+	privilegedHandlers := rpc2.NewMuxPrivilegedHandlers(handlerFriendsHops, handlerFriendsIsFollowing, handlerFriendsIsBlocking, handlerFriendsStream, handlerScuttlegoPublish, handlerScuttlegoFollow, handlerScuttlegoStatus, handlerScuttlegoGetMessage, handlerScuttlegoReceiveLog, handlerScuttlegoAddBlob, handlerScuttlegoAddToBanList, handlerScuttlegoRemoveFromBanList, handlerScuttlegoRedeemInvite, handlerScuttlegoRegisterAlias, handlerScuttlegoRevokeAlias, handlerScuttlegoListAliases, handlerScuttlegoConnect, handlerPublish, handlerCreateLogStream, handlerCreateUserStream, handlerGet, handlerBlobsAdd, handlerStatus)
+	mux, err := rpc2.NewMux(logger, diMetrics, v3, v4, privilegedHandlers)
 	if err != nil {
 		cleanup()
 		return IntegrationTestsService{}, nil, err
 	}
-	requestSubscriber := pubsub2.NewRequestSubscriber(requestPubSub, muxMux)
+	requestSubscriber := pubsub2.NewRequestSubscriber(requestPubSub, mux)
 	roomAttendantEventPubSub := pubsub.NewRoomAttendantEventPubSub()
-	processRoomAttendantEventHandler := commands.NewProcessRoomAttendantEventHandler(peerManager)
+	processRoomAttendantEventHandler := commands.NewProcessRoomAttendantEventHandler(peerManager, roomAttendantsRegistry, currentTimeProvider)
 	roomAttendantEventSubscriber := pubsub2.NewRoomAttendantEventSubscriber(roomAttendantEventPubSub, processRoomAttendantEventHandler, logger)
 	negotiator := replication2.NewNegotiator(logger, replicator, gossipReplicator)
 	replicationReplicator := replication.NewReplicator(manager)
 	peerRPCAdapter := rooms.NewPeerRPCAdapter(logger)
 	roomsScanner := rooms.NewScanner(peerRPCAdapter, peerRPCAdapter, roomAttendantEventPubSub, logger)
-	acceptNewPeerHandler := commands.NewAcceptNewPeerHandler(peerManager, negotiator, replicationReplicator, roomsScanner, logger)
+	acceptNewPeerHandler := commands.NewAcceptNewPeerHandler(peerManager, negotiator, replicationReplicator, roomsScanner, pinger, room, logger)
 	newPeerSubscriber := pubsub2.NewNewPeerSubscriber(newPeerPubSub, acceptNewPeerHandler, logger)
-	advertiser, err := newAdvertiser(public, config)
+	advertiser, err := newAdvertiser(public, config, logger)
 	if err != nil {
 		cleanup()
 		return IntegrationTestsService{}, nil, err
 	}
-	garbageCollector := badger.NewGarbageCollector(db, logger)
+	garbageCollector := badger.NewGarbageCollector(db, diMetrics, logger)
 	noTxFeedWantListRepository := notx.NewNoTxFeedWantListRepository(txAdaptersFactoryTransactionProvider, logger)
-	serviceService := service.NewService(application, listener, networkDiscoverer, connectionEstablisher, requestSubscriber, roomAttendantEventSubscriber, newPeerSubscriber, advertiser, messageBuffer, createHistoryStreamHandler, garbageCollector, noTxFeedWantListRepository, noTxBlobWantListRepository)
+	serviceService := service.NewService(application, listener, webSocketListener, unixListener, httpServer, metricsServer, networkDiscoverer, connectionEstablisher, requestSubscriber, roomAttendantEventSubscriber, newPeerSubscriber, advertiser, messageBuffer, createHistoryStreamHandler, garbageCollector, noTxFeedWantListRepository, noTxBlobWantListRepository)
 	banListHasher := adapters.NewBanListHasher()
 	integrationTestsService := IntegrationTestsService{
 		Service:       serviceService,
@@ -891,13 +1268,21 @@ type TestCommands struct {
 	DisconnectAll             *commands.DisconnectAllHandler
 	DownloadFeed              *commands.DownloadFeedHandler
 	RedeemInvite              *commands.RedeemInviteHandler
+	CreateInvite              *commands.CreateInviteHandler
+	UseInvite                 *commands.UseInviteHandler
 	AcceptTunnelConnect       *commands.AcceptTunnelConnectHandler
+	FetchOutOfOrderMessage    *commands.FetchOutOfOrderMessageHandler
+
+	RoomsHttpAuthCreateClientChallenge *commands.RoomsHttpAuthCreateClientChallengeHandler
+	RoomsHttpAuthSolveChallenge        *commands.RoomsHttpAuthSolveChallengeHandler
 
 	MigrationImportDataFromGoSSB *commands.MigrationHandlerImportDataFromGoSSB
 
 	PeerManager            *mocks.PeerManagerMock
 	Dialer                 *mocks.DialerMock
 	FeedWantListRepository *mocks.FeedWantListRepositoryMock
+	OutOfOrderMessage      *mocks.OutOfOrderMessageRepositoryMock
+	RawMessageIdentifier   *mocks.RawMessageIdentifierMock
 	CurrentTimeProvider    *mocks.CurrentTimeProviderMock
 	InviteRedeemer         *mocks.InviteRedeemerMock
 	Local                  identity.Public
@@ -905,6 +1290,10 @@ type TestCommands struct {
 	GoSSBRepoReader        *mocks.GoSSBRepoReaderMock
 	FeedRepository         *mocks.FeedRepositoryMock
 	ReceiveLog             *mocks.ReceiveLogRepositoryMock
+	InviteRepository       *mocks.InviteRepositoryMock
+	SocialGraphRepository  *mocks.SocialGraphRepositoryMock
+	TransactionProvider    *mocks.MockCommandsTransactionProvider
+	RoomAttendantsRegistry *adapters.RoomAttendantsRegistry
 }
 
 type TestQueries struct {
@@ -918,12 +1307,19 @@ type TestQueries struct {
 	SocialGraphRepository  *mocks.SocialGraphRepositoryMock
 	FeedWantListRepository *mocks.FeedWantListRepositoryMock
 	BanListRepository      *mocks.BanListRepositoryMock
+	OutOfOrderMessage      *mocks.OutOfOrderMessageRepositoryMock
 	MessagePubSub          *mocks.MessagePubSubMock
 	PeerManager            *mocks.PeerManagerMock
+	RoundTripTimeProvider  *mocks.RoundTripTimeProviderMock
 	BlobStorage            *mocks.BlobStorageMock
 	Dialer                 *mocks.DialerMock
+	RoomHTTPClient         *mocks.RoomHTTPClientMock
+	RoomAttendantsRegistry *adapters.RoomAttendantsRegistry
+	ContactPubSub          *pubsub.ContactPubSub
+	NodeEventPubSub        *pubsub.NodeEventPubSub
 
 	LocalIdentity identity.Public
+	Hops          graph.Hops
 }
 
 type IntegrationTestsService struct {
@@ -941,8 +1337,15 @@ func BuildIntegrationTestsService(t *testing.T) (IntegrationTestsService, error)
 	return service2, nil
 }
 
-func newAdvertiser(l identity.Public, config service.Config) (*local.Advertiser, error) {
-	return local.NewAdvertiser(l, config.ListenAddress)
+func newAdvertiser(l identity.Public, config service.Config, logger logging.Logger) (*local.Advertiser, error) {
+	if config.DisableLocalAdvertising {
+		return nil, nil
+	}
+	return local.NewAdvertiser(l, config.ListenAddress, logger)
+}
+
+func newTunnelDialer(initializer tunnel.ClientPeerInitializer, config service.Config) *tunnel.Dialer {
+	return tunnel.NewDialer(initializer, config.TunnelDialTimeout)
 }
 
 func newIntegrationTestConfig(t *testing.T) service.Config {
@@ -69,6 +69,10 @@ func (c *Contact) Update(actions known.ContactActions) error {
 	return nil
 }
 
+func (c *Contact) Author() refs.Identity {
+	return c.author
+}
+
 func (c *Contact) Target() refs.Identity {
 	return c.target
 }
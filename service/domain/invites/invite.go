@@ -20,6 +20,23 @@ const (
 	seedSeparator     = "~"
 )
 
+func NewInvite(remote refs.Identity, address network.Address, secretKeySeed []byte) (Invite, error) {
+	if remote.IsZero() {
+		return Invite{}, errors.New("zero value of remote")
+	}
+	if address.IsZero() {
+		return Invite{}, errors.New("zero value of address")
+	}
+	if len(secretKeySeed) == 0 {
+		return Invite{}, errors.New("empty secret key seed")
+	}
+	return Invite{
+		remote:        remote,
+		address:       address,
+		secretKeySeed: secretKeySeed,
+	}, nil
+}
+
 func NewInviteFromString(s string) (Invite, error) {
 	seedString, err := readAfter(&s, seedSeparator)
 	if err != nil {
@@ -81,3 +98,13 @@ func (i Invite) Address() network.Address {
 func (i Invite) SecretKeySeed() []byte {
 	return i.secretKeySeed
 }
+
+// String returns an invite code which can be parsed using
+// NewInviteFromString.
+func (i Invite) String() string {
+	return i.address.String() +
+		identitySeparator +
+		i.remote.String() +
+		seedSeparator +
+		base64.StdEncoding.EncodeToString(i.secretKeySeed)
+}
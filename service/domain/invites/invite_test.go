@@ -3,7 +3,9 @@ package invites_test
 import (
 	"testing"
 
+	"github.com/planetary-social/scuttlego/internal/fixtures"
 	"github.com/planetary-social/scuttlego/service/domain/invites"
+	"github.com/planetary-social/scuttlego/service/domain/network"
 	"github.com/stretchr/testify/require"
 )
 
@@ -22,3 +24,19 @@ func TestInviteString_empty(t *testing.T) {
 	_, err := invites.NewInviteFromString("")
 	require.Error(t, err)
 }
+
+func TestInvite_StringCanBeParsed(t *testing.T) {
+	remote := fixtures.SomeRefIdentity()
+	address := network.NewAddress("one.planetary.pub:8008")
+	seed := fixtures.SomeBytes()
+
+	invite, err := invites.NewInvite(remote, address, seed)
+	require.NoError(t, err)
+
+	parsed, err := invites.NewInviteFromString(invite.String())
+	require.NoError(t, err)
+
+	require.Equal(t, remote, parsed.Remote())
+	require.Equal(t, address, parsed.Address())
+	require.Equal(t, seed, parsed.SecretKeySeed())
+}
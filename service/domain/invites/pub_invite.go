@@ -0,0 +1,76 @@
+package invites
+
+import (
+	"time"
+
+	"github.com/boreq/errors"
+	"github.com/planetary-social/scuttlego/service/domain/refs"
+)
+
+var (
+	ErrInviteExpired = errors.New("invite expired")
+	ErrInviteUsedUp  = errors.New("invite has no remaining uses")
+)
+
+// PubInvite is an invite created by this node when it acts as a pub. The
+// identity of the invite is derived from the seed included in the invite code.
+// Peers connecting using that identity can use the invite to make the pub
+// follow them.
+type PubInvite struct {
+	id            refs.Identity
+	remainingUses int
+	expiresAt     time.Time
+}
+
+// NewPubInvite creates a new invite. Zero value of expiresAt means that the
+// invite never expires.
+func NewPubInvite(id refs.Identity, remainingUses int, expiresAt time.Time) (*PubInvite, error) {
+	if id.IsZero() {
+		return nil, errors.New("zero value of id")
+	}
+	if remainingUses < 0 {
+		return nil, errors.New("remaining uses can't be negative")
+	}
+	return &PubInvite{
+		id:            id,
+		remainingUses: remainingUses,
+		expiresAt:     expiresAt,
+	}, nil
+}
+
+func MustNewPubInvite(id refs.Identity, remainingUses int, expiresAt time.Time) *PubInvite {
+	v, err := NewPubInvite(id, remainingUses, expiresAt)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Use decrements the number of remaining uses. Returns ErrInviteExpired or
+// ErrInviteUsedUp if the invite can't be used anymore.
+func (i *PubInvite) Use(now time.Time) error {
+	if i.Expired(now) {
+		return ErrInviteExpired
+	}
+	if i.remainingUses == 0 {
+		return ErrInviteUsedUp
+	}
+	i.remainingUses--
+	return nil
+}
+
+func (i *PubInvite) Expired(now time.Time) bool {
+	return !i.expiresAt.IsZero() && !now.Before(i.expiresAt)
+}
+
+func (i *PubInvite) Id() refs.Identity {
+	return i.id
+}
+
+func (i *PubInvite) RemainingUses() int {
+	return i.remainingUses
+}
+
+func (i *PubInvite) ExpiresAt() time.Time {
+	return i.expiresAt
+}
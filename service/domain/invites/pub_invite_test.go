@@ -0,0 +1,81 @@
+package invites_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/planetary-social/scuttlego/internal/fixtures"
+	"github.com/planetary-social/scuttlego/service/domain/invites"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPubInvite_UseDecrementsRemainingUses(t *testing.T) {
+	now := time.Now()
+
+	invite, err := invites.NewPubInvite(fixtures.SomeRefIdentity(), 2, time.Time{})
+	require.NoError(t, err)
+
+	err = invite.Use(now)
+	require.NoError(t, err)
+	require.Equal(t, 1, invite.RemainingUses())
+
+	err = invite.Use(now)
+	require.NoError(t, err)
+	require.Equal(t, 0, invite.RemainingUses())
+
+	err = invite.Use(now)
+	require.ErrorIs(t, err, invites.ErrInviteUsedUp)
+	require.Equal(t, 0, invite.RemainingUses())
+}
+
+func TestPubInvite_ExpiredInvitesCanNotBeUsed(t *testing.T) {
+	now := time.Now()
+
+	testCases := []struct {
+		Name          string
+		ExpiresAt     time.Time
+		ExpectedError error
+	}{
+		{
+			Name:          "never_expires",
+			ExpiresAt:     time.Time{},
+			ExpectedError: nil,
+		},
+		{
+			Name:          "expires_in_the_future",
+			ExpiresAt:     now.Add(time.Second),
+			ExpectedError: nil,
+		},
+		{
+			Name:          "expires_now",
+			ExpiresAt:     now,
+			ExpectedError: invites.ErrInviteExpired,
+		},
+		{
+			Name:          "expired_in_the_past",
+			ExpiresAt:     now.Add(-time.Second),
+			ExpectedError: invites.ErrInviteExpired,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			invite, err := invites.NewPubInvite(fixtures.SomeRefIdentity(), 1, testCase.ExpiresAt)
+			require.NoError(t, err)
+
+			err = invite.Use(now)
+			if testCase.ExpectedError == nil {
+				require.NoError(t, err)
+				require.Equal(t, 0, invite.RemainingUses())
+			} else {
+				require.ErrorIs(t, err, testCase.ExpectedError)
+				require.Equal(t, 1, invite.RemainingUses())
+			}
+		})
+	}
+}
+
+func TestNewPubInvite_RemainingUsesCanNotBeNegative(t *testing.T) {
+	_, err := invites.NewPubInvite(fixtures.SomeRefIdentity(), -1, time.Time{})
+	require.Error(t, err)
+}
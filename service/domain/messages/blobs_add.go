@@ -0,0 +1,90 @@
+package messages
+
+import (
+	"github.com/boreq/errors"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/planetary-social/scuttlego/service/domain/refs"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc"
+)
+
+var (
+	// BlobsAddProcedure is a sink: the client sends the blob as a stream of
+	// binary messages and closes the stream. The stream is closed with an
+	// error if the blob couldn't be stored.
+	BlobsAddProcedure = rpc.MustNewProcedure(
+		rpc.MustNewProcedureName([]string{"blobs", "add"}),
+		rpc.ProcedureTypeSink,
+	)
+)
+
+func NewBlobsAdd(arguments BlobsAddArguments) (*rpc.Request, error) {
+	j, err := arguments.MarshalJSON()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal arguments")
+	}
+
+	return rpc.NewRequest(
+		BlobsAddProcedure.Name(),
+		BlobsAddProcedure.Typ(),
+		j,
+	)
+}
+
+type BlobsAddArguments struct {
+	id *refs.Blob
+}
+
+func NewBlobsAddArguments(
+	id *refs.Blob, // nil => id isn't verified
+) (BlobsAddArguments, error) {
+	if id != nil && id.IsZero() {
+		return BlobsAddArguments{}, errors.New("zero value of id")
+	}
+
+	return BlobsAddArguments{
+		id: id,
+	}, nil
+}
+
+// NewBlobsAddArgumentsFromBytes accepts either no arguments or the expected
+// id of the blob e.g. ["&id.sha256"].
+func NewBlobsAddArgumentsFromBytes(b []byte) (BlobsAddArguments, error) {
+	var args []string
+
+	if err := jsoniter.Unmarshal(b, &args); err != nil {
+		return BlobsAddArguments{}, errors.Wrap(err, "json unmarshal failed")
+	}
+
+	// Some clients pass null when the id isn't known.
+	if len(args) == 1 && args[0] == "" {
+		args = nil
+	}
+
+	switch len(args) {
+	case 0:
+		return NewBlobsAddArguments(nil)
+	case 1:
+		id, err := refs.NewBlob(args[0])
+		if err != nil {
+			return BlobsAddArguments{}, errors.Wrap(err, "could not create a blob ref")
+		}
+		return NewBlobsAddArguments(&id)
+	default:
+		return BlobsAddArguments{}, errors.New("expected at most one argument")
+	}
+}
+
+// Id returns the expected id of the blob if the client provided it.
+func (a BlobsAddArguments) Id() (refs.Blob, bool) {
+	if a.id == nil {
+		return refs.Blob{}, false
+	}
+	return *a.id, true
+}
+
+func (a BlobsAddArguments) MarshalJSON() ([]byte, error) {
+	if a.id == nil {
+		return []byte("[]"), nil
+	}
+	return jsoniter.Marshal([]string{a.id.String()})
+}
@@ -0,0 +1,58 @@
+package messages
+
+import (
+	"github.com/boreq/errors"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/planetary-social/scuttlego/service/domain/refs"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc"
+)
+
+var (
+	BlobsChangesProcedure = rpc.MustNewProcedure(
+		rpc.MustNewProcedureName([]string{"blobs", "changes"}),
+		rpc.ProcedureTypeSource,
+	)
+)
+
+func NewBlobsChanges() (*rpc.Request, error) {
+	return rpc.NewRequest(
+		BlobsChangesProcedure.Name(),
+		BlobsChangesProcedure.Typ(),
+		[]byte("[]"),
+	)
+}
+
+type BlobsChangesResponse struct {
+	id refs.Blob
+}
+
+func NewBlobsChangesResponse(id refs.Blob) (BlobsChangesResponse, error) {
+	if id.IsZero() {
+		return BlobsChangesResponse{}, errors.New("zero value of id")
+	}
+
+	return BlobsChangesResponse{id: id}, nil
+}
+
+func NewBlobsChangesResponseFromBytes(b []byte) (BlobsChangesResponse, error) {
+	var s string
+
+	if err := jsoniter.Unmarshal(b, &s); err != nil {
+		return BlobsChangesResponse{}, errors.Wrap(err, "json unmarshal failed")
+	}
+
+	id, err := refs.NewBlob(s)
+	if err != nil {
+		return BlobsChangesResponse{}, errors.Wrap(err, "could not create a blob ref")
+	}
+
+	return NewBlobsChangesResponse(id)
+}
+
+func (r BlobsChangesResponse) Id() refs.Blob {
+	return r.id
+}
+
+func (r BlobsChangesResponse) MarshalJSON() ([]byte, error) {
+	return jsoniter.Marshal(r.id.String())
+}
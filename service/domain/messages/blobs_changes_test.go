@@ -0,0 +1,24 @@
+package messages_test
+
+import (
+	"testing"
+
+	"github.com/planetary-social/scuttlego/service/domain/messages"
+	"github.com/planetary-social/scuttlego/service/domain/refs"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlobsChangesResponse(t *testing.T) {
+	id := refs.MustNewBlob("&uaGieSQDJcHfUp6hjIcIq55GoZh4Ug7tNmgaohoxrpw=.sha256")
+
+	response, err := messages.NewBlobsChangesResponse(id)
+	require.NoError(t, err)
+
+	j, err := response.MarshalJSON()
+	require.NoError(t, err)
+	require.JSONEq(t, `"&uaGieSQDJcHfUp6hjIcIq55GoZh4Ug7tNmgaohoxrpw=.sha256"`, string(j))
+
+	decoded, err := messages.NewBlobsChangesResponseFromBytes(j)
+	require.NoError(t, err)
+	require.Equal(t, id, decoded.Id())
+}
@@ -0,0 +1,174 @@
+package messages
+
+import (
+	"github.com/boreq/errors"
+	"github.com/hashicorp/go-multierror"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/planetary-social/scuttlego/service/domain/refs"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc"
+)
+
+var (
+	BlobsHasProcedure = rpc.MustNewProcedure(
+		rpc.MustNewProcedureName([]string{"blobs", "has"}),
+		rpc.ProcedureTypeAsync,
+	)
+)
+
+func NewBlobsHas(arguments BlobIdsArguments) (*rpc.Request, error) {
+	j, err := arguments.MarshalJSON()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal arguments")
+	}
+
+	return rpc.NewRequest(
+		BlobsHasProcedure.Name(),
+		BlobsHasProcedure.Typ(),
+		j,
+	)
+}
+
+// BlobIdsArguments are used by procedures which accept either a single blob
+// id, e.g. ["&id.sha256"], or a list of blob ids, e.g. [["&id.sha256"]]. In
+// the second case responses are also expected to be lists.
+type BlobIdsArguments struct {
+	ids  []refs.Blob
+	list bool
+}
+
+func NewBlobIdsArguments(id refs.Blob) (BlobIdsArguments, error) {
+	if id.IsZero() {
+		return BlobIdsArguments{}, errors.New("zero value of id")
+	}
+
+	return BlobIdsArguments{
+		ids:  []refs.Blob{id},
+		list: false,
+	}, nil
+}
+
+func NewBlobIdsArgumentsList(ids []refs.Blob) (BlobIdsArguments, error) {
+	for _, id := range ids {
+		if id.IsZero() {
+			return BlobIdsArguments{}, errors.New("zero value of id")
+		}
+	}
+
+	return BlobIdsArguments{
+		ids:  ids,
+		list: true,
+	}, nil
+}
+
+func NewBlobIdsArgumentsFromBytes(b []byte) (BlobIdsArguments, error) {
+	var err error
+
+	args, singleErr := newBlobIdsArgumentsFromBytesSingle(b)
+	err = multierror.Append(err, errors.Wrap(singleErr, "error unmarshaling arguments as a single id"))
+	if singleErr == nil {
+		return args, nil
+	}
+
+	args, listErr := newBlobIdsArgumentsFromBytesList(b)
+	err = multierror.Append(err, errors.Wrap(listErr, "error unmarshaling arguments as a list of ids"))
+	if listErr == nil {
+		return args, nil
+	}
+
+	return BlobIdsArguments{}, err
+}
+
+func newBlobIdsArgumentsFromBytesSingle(b []byte) (BlobIdsArguments, error) {
+	var args []string
+
+	if err := jsoniter.Unmarshal(b, &args); err != nil {
+		return BlobIdsArguments{}, errors.Wrap(err, "json unmarshal failed")
+	}
+
+	if len(args) != 1 {
+		return BlobIdsArguments{}, errors.New("expected exactly one argument")
+	}
+
+	id, err := refs.NewBlob(args[0])
+	if err != nil {
+		return BlobIdsArguments{}, errors.Wrap(err, "could not create a blob ref")
+	}
+
+	return NewBlobIdsArguments(id)
+}
+
+func newBlobIdsArgumentsFromBytesList(b []byte) (BlobIdsArguments, error) {
+	var args [][]string
+
+	if err := jsoniter.Unmarshal(b, &args); err != nil {
+		return BlobIdsArguments{}, errors.Wrap(err, "json unmarshal failed")
+	}
+
+	if len(args) != 1 {
+		return BlobIdsArguments{}, errors.New("expected exactly one argument")
+	}
+
+	var ids []refs.Blob
+	for _, v := range args[0] {
+		id, err := refs.NewBlob(v)
+		if err != nil {
+			return BlobIdsArguments{}, errors.Wrap(err, "could not create a blob ref")
+		}
+		ids = append(ids, id)
+	}
+
+	return NewBlobIdsArgumentsList(ids)
+}
+
+func (a BlobIdsArguments) Ids() []refs.Blob {
+	return a.ids
+}
+
+// List returns true if a list of ids was requested.
+func (a BlobIdsArguments) List() bool {
+	return a.list
+}
+
+func (a BlobIdsArguments) MarshalJSON() ([]byte, error) {
+	var ids []string
+	for _, id := range a.ids {
+		ids = append(ids, id.String())
+	}
+
+	if a.list {
+		if ids == nil {
+			ids = []string{}
+		}
+		return jsoniter.Marshal([][]string{ids})
+	}
+
+	return jsoniter.Marshal(ids)
+}
+
+type BlobsHasResponse struct {
+	has  []bool
+	list bool
+}
+
+// NewBlobsHasResponse creates a response for the provided arguments. Values
+// must be in the same order as ids returned by the arguments.
+func NewBlobsHasResponse(args BlobIdsArguments, has []bool) (BlobsHasResponse, error) {
+	if len(has) != len(args.Ids()) {
+		return BlobsHasResponse{}, errors.New("number of values doesn't match the number of ids")
+	}
+
+	return BlobsHasResponse{
+		has:  has,
+		list: args.List(),
+	}, nil
+}
+
+func (r BlobsHasResponse) MarshalJSON() ([]byte, error) {
+	if r.list {
+		if r.has == nil {
+			return jsoniter.Marshal([]bool{})
+		}
+		return jsoniter.Marshal(r.has)
+	}
+	return jsoniter.Marshal(r.has[0])
+}
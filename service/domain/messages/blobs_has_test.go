@@ -0,0 +1,106 @@
+package messages_test
+
+import (
+	"testing"
+
+	"github.com/planetary-social/scuttlego/service/domain/messages"
+	"github.com/planetary-social/scuttlego/service/domain/refs"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewBlobsHas(t *testing.T) {
+	id := refs.MustNewBlob("&uaGieSQDJcHfUp6hjIcIq55GoZh4Ug7tNmgaohoxrpw=.sha256")
+
+	args, err := messages.NewBlobIdsArguments(id)
+	require.NoError(t, err)
+
+	req, err := messages.NewBlobsHas(args)
+	require.NoError(t, err)
+
+	require.Equal(t, rpc.MustNewProcedureName([]string{"blobs", "has"}), req.Name())
+	require.Equal(t, rpc.ProcedureTypeAsync, req.Type())
+	require.JSONEq(t, `["&uaGieSQDJcHfUp6hjIcIq55GoZh4Ug7tNmgaohoxrpw=.sha256"]`, string(req.Arguments()))
+}
+
+func TestNewBlobIdsArgumentsFromBytes(t *testing.T) {
+	id1 := refs.MustNewBlob("&uaGieSQDJcHfUp6hjIcIq55GoZh4Ug7tNmgaohoxrpw=.sha256")
+	id2 := refs.MustNewBlob("&3Xf6RcLDxEzBIyxjSQeaAhzQn0bapM3bq2DCzTR0q/s=.sha256")
+
+	testCases := []struct {
+		Name          string
+		Arguments     string
+		ExpectedIds   []refs.Blob
+		ExpectedList  bool
+		ExpectedError bool
+	}{
+		{
+			Name:         "single",
+			Arguments:    `["&uaGieSQDJcHfUp6hjIcIq55GoZh4Ug7tNmgaohoxrpw=.sha256"]`,
+			ExpectedIds:  []refs.Blob{id1},
+			ExpectedList: false,
+		},
+		{
+			Name:         "list",
+			Arguments:    `[["&uaGieSQDJcHfUp6hjIcIq55GoZh4Ug7tNmgaohoxrpw=.sha256", "&3Xf6RcLDxEzBIyxjSQeaAhzQn0bapM3bq2DCzTR0q/s=.sha256"]]`,
+			ExpectedIds:  []refs.Blob{id1, id2},
+			ExpectedList: true,
+		},
+		{
+			Name:          "invalid_ref",
+			Arguments:     `["@uaGieSQDJcHfUp6hjIcIq55GoZh4Ug7tNmgaohoxrpw=.ed25519"]`,
+			ExpectedError: true,
+		},
+		{
+			Name:          "no_arguments",
+			Arguments:     `[]`,
+			ExpectedError: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			args, err := messages.NewBlobIdsArgumentsFromBytes([]byte(testCase.Arguments))
+			if testCase.ExpectedError {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, testCase.ExpectedIds, args.Ids())
+			require.Equal(t, testCase.ExpectedList, args.List())
+
+			j, err := args.MarshalJSON()
+			require.NoError(t, err)
+			require.JSONEq(t, testCase.Arguments, string(j))
+		})
+	}
+}
+
+func TestBlobsHasResponse_MarshalJSON(t *testing.T) {
+	id1 := refs.MustNewBlob("&uaGieSQDJcHfUp6hjIcIq55GoZh4Ug7tNmgaohoxrpw=.sha256")
+	id2 := refs.MustNewBlob("&3Xf6RcLDxEzBIyxjSQeaAhzQn0bapM3bq2DCzTR0q/s=.sha256")
+
+	single, err := messages.NewBlobIdsArguments(id1)
+	require.NoError(t, err)
+
+	list, err := messages.NewBlobIdsArgumentsList([]refs.Blob{id1, id2})
+	require.NoError(t, err)
+
+	response, err := messages.NewBlobsHasResponse(single, []bool{true})
+	require.NoError(t, err)
+
+	j, err := response.MarshalJSON()
+	require.NoError(t, err)
+	require.Equal(t, `true`, string(j))
+
+	response, err = messages.NewBlobsHasResponse(list, []bool{true, false})
+	require.NoError(t, err)
+
+	j, err = response.MarshalJSON()
+	require.NoError(t, err)
+	require.Equal(t, `[true,false]`, string(j))
+
+	_, err = messages.NewBlobsHasResponse(list, []bool{true})
+	require.Error(t, err)
+}
@@ -0,0 +1,64 @@
+package messages
+
+import (
+	"github.com/boreq/errors"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/planetary-social/scuttlego/service/domain/blobs"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc"
+)
+
+var (
+	BlobsSizeProcedure = rpc.MustNewProcedure(
+		rpc.MustNewProcedureName([]string{"blobs", "size"}),
+		rpc.ProcedureTypeAsync,
+	)
+)
+
+func NewBlobsSize(arguments BlobIdsArguments) (*rpc.Request, error) {
+	j, err := arguments.MarshalJSON()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal arguments")
+	}
+
+	return rpc.NewRequest(
+		BlobsSizeProcedure.Name(),
+		BlobsSizeProcedure.Typ(),
+		j,
+	)
+}
+
+type BlobsSizeResponse struct {
+	sizes []*blobs.Size
+	list  bool
+}
+
+// NewBlobsSizeResponse creates a response for the provided arguments. Values
+// must be in the same order as ids returned by the arguments. Nil values
+// indicate that a blob doesn't exist.
+func NewBlobsSizeResponse(args BlobIdsArguments, sizes []*blobs.Size) (BlobsSizeResponse, error) {
+	if len(sizes) != len(args.Ids()) {
+		return BlobsSizeResponse{}, errors.New("number of values doesn't match the number of ids")
+	}
+
+	return BlobsSizeResponse{
+		sizes: sizes,
+		list:  args.List(),
+	}, nil
+}
+
+func (r BlobsSizeResponse) MarshalJSON() ([]byte, error) {
+	values := make([]*int64, 0, len(r.sizes))
+	for _, size := range r.sizes {
+		if size == nil {
+			values = append(values, nil)
+			continue
+		}
+		v := size.InBytes()
+		values = append(values, &v)
+	}
+
+	if r.list {
+		return jsoniter.Marshal(values)
+	}
+	return jsoniter.Marshal(values[0])
+}
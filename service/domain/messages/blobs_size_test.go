@@ -0,0 +1,59 @@
+package messages_test
+
+import (
+	"testing"
+
+	"github.com/planetary-social/scuttlego/internal"
+	"github.com/planetary-social/scuttlego/service/domain/blobs"
+	"github.com/planetary-social/scuttlego/service/domain/messages"
+	"github.com/planetary-social/scuttlego/service/domain/refs"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlobsSizeResponse_MarshalJSON(t *testing.T) {
+	id1 := refs.MustNewBlob("&uaGieSQDJcHfUp6hjIcIq55GoZh4Ug7tNmgaohoxrpw=.sha256")
+	id2 := refs.MustNewBlob("&3Xf6RcLDxEzBIyxjSQeaAhzQn0bapM3bq2DCzTR0q/s=.sha256")
+
+	single, err := messages.NewBlobIdsArguments(id1)
+	require.NoError(t, err)
+
+	list, err := messages.NewBlobIdsArgumentsList([]refs.Blob{id1, id2})
+	require.NoError(t, err)
+
+	testCases := []struct {
+		Name         string
+		Arguments    messages.BlobIdsArguments
+		Sizes        []*blobs.Size
+		ExpectedJSON string
+	}{
+		{
+			Name:         "single",
+			Arguments:    single,
+			Sizes:        []*blobs.Size{internal.Ptr(blobs.MustNewSize(123))},
+			ExpectedJSON: `123`,
+		},
+		{
+			Name:         "single_not_found",
+			Arguments:    single,
+			Sizes:        []*blobs.Size{nil},
+			ExpectedJSON: `null`,
+		},
+		{
+			Name:         "list",
+			Arguments:    list,
+			Sizes:        []*blobs.Size{internal.Ptr(blobs.MustNewSize(123)), nil},
+			ExpectedJSON: `[123,null]`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			response, err := messages.NewBlobsSizeResponse(testCase.Arguments, testCase.Sizes)
+			require.NoError(t, err)
+
+			j, err := response.MarshalJSON()
+			require.NoError(t, err)
+			require.Equal(t, testCase.ExpectedJSON, string(j))
+		})
+	}
+}
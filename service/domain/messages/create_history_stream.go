@@ -32,9 +32,10 @@ func NewCreateHistoryStream(arguments CreateHistoryStreamArguments) (*rpc.Reques
 }
 
 const (
-	defaultLive = false
-	defaultOld  = true
-	defaultKeys = true
+	defaultLive    = false
+	defaultOld     = true
+	defaultKeys    = true
+	defaultReverse = false
 )
 
 type CreateHistoryStreamArguments struct {
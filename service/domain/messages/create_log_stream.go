@@ -0,0 +1,179 @@
+package messages
+
+import (
+	"math"
+	"time"
+
+	"github.com/boreq/errors"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc"
+)
+
+var (
+	// CreateLogStreamProcedure streams all stored messages in the order in
+	// which they were received. It is used by ssb-client based applications.
+	CreateLogStreamProcedure = rpc.MustNewProcedure(
+		rpc.MustNewProcedureName([]string{"createLogStream"}),
+		rpc.ProcedureTypeSource,
+	)
+)
+
+func NewCreateLogStream(arguments CreateLogStreamArguments) (*rpc.Request, error) {
+	j, err := arguments.MarshalJSON()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal arguments")
+	}
+
+	return rpc.NewRequest(
+		CreateLogStreamProcedure.Name(),
+		CreateLogStreamProcedure.Typ(),
+		j,
+	)
+}
+
+// CreateLogStreamArguments select messages using the timestamps returned in
+// KeyValueTimestamp. The range is normalized so that gt and lte are converted
+// to gte and lt.
+type CreateLogStreamArguments struct {
+	gte     *time.Time
+	lt      *time.Time
+	limit   *int
+	mode    MessageStreamMode
+	options MessageStreamOptions
+}
+
+func NewCreateLogStreamArguments(
+	gte *time.Time, // nil => no lower bound
+	lt *time.Time, // nil => no upper bound
+	limit *int, // nil => unlimited
+	mode MessageStreamMode,
+	options MessageStreamOptions,
+) (CreateLogStreamArguments, error) {
+	if limit != nil && *limit < 0 {
+		return CreateLogStreamArguments{}, errors.New("limit can't be negative")
+	}
+
+	return CreateLogStreamArguments{
+		gte:     gte,
+		lt:      lt,
+		limit:   limit,
+		mode:    mode,
+		options: options,
+	}, nil
+}
+
+// NewCreateLogStreamArgumentsFromBytes accepts either no arguments or a
+// single object e.g. [{"gt": 1514517067954, "live": true, "limit": 10}].
+func NewCreateLogStreamArgumentsFromBytes(b []byte) (CreateLogStreamArguments, error) {
+	var args []createLogStreamArgumentsTransport
+
+	if err := jsoniter.Unmarshal(b, &args); err != nil {
+		return CreateLogStreamArguments{}, errors.Wrap(err, "json unmarshal failed")
+	}
+
+	if len(args) > 1 {
+		return CreateLogStreamArguments{}, errors.New("expected at most one argument")
+	}
+
+	var transport createLogStreamArgumentsTransport
+	if len(args) == 1 {
+		transport = args[0]
+	}
+
+	gte, lt, err := transport.timestampRange()
+	if err != nil {
+		return CreateLogStreamArguments{}, errors.Wrap(err, "invalid range")
+	}
+
+	return NewCreateLogStreamArguments(
+		gte,
+		lt,
+		limitFromTransport(transport.Limit),
+		transport.mode(),
+		transport.options(),
+	)
+}
+
+func (a CreateLogStreamArguments) Gte() *time.Time {
+	return a.gte
+}
+
+func (a CreateLogStreamArguments) Lt() *time.Time {
+	return a.lt
+}
+
+// InRange returns true if the provided timestamp falls within the range
+// specified by the client.
+func (a CreateLogStreamArguments) InRange(t time.Time) bool {
+	if a.gte != nil && t.Before(*a.gte) {
+		return false
+	}
+	if a.lt != nil && !t.Before(*a.lt) {
+		return false
+	}
+	return true
+}
+
+func (a CreateLogStreamArguments) Limit() *int {
+	return a.limit
+}
+
+func (a CreateLogStreamArguments) Mode() MessageStreamMode {
+	return a.mode
+}
+
+func (a CreateLogStreamArguments) Options() MessageStreamOptions {
+	return a.options
+}
+
+func (a CreateLogStreamArguments) MarshalJSON() ([]byte, error) {
+	return jsoniter.Marshal([]createLogStreamArgumentsTransport{
+		{
+			messageStreamModeTransport:    newMessageStreamModeTransport(a.mode),
+			messageStreamOptionsTransport: newMessageStreamOptionsTransport(a.options),
+			Gte:                           timestampToTransport(a.gte),
+			Lt:                            timestampToTransport(a.lt),
+			Limit:                         a.limit,
+		},
+	})
+}
+
+type createLogStreamArgumentsTransport struct {
+	messageStreamModeTransport
+	messageStreamOptionsTransport
+	Gt    *float64 `json:"gt,omitempty"`
+	Gte   *float64 `json:"gte,omitempty"`
+	Lt    *float64 `json:"lt,omitempty"`
+	Lte   *float64 `json:"lte,omitempty"`
+	Limit *int     `json:"limit,omitempty"`
+}
+
+func (t createLogStreamArgumentsTransport) timestampRange() (*time.Time, *time.Time, error) {
+	if t.Gt != nil && t.Gte != nil {
+		return nil, nil, errors.New("both gt and gte are set")
+	}
+
+	if t.Lt != nil && t.Lte != nil {
+		return nil, nil, errors.New("both lt and lte are set")
+	}
+
+	// Timestamps are compared with millisecond precision but clients can send
+	// fractional values.
+	var gte, lt *time.Time
+
+	switch {
+	case t.Gte != nil:
+		gte = timestampFromTransport(math.Ceil(*t.Gte))
+	case t.Gt != nil:
+		gte = timestampFromTransport(math.Floor(*t.Gt) + 1)
+	}
+
+	switch {
+	case t.Lt != nil:
+		lt = timestampFromTransport(math.Ceil(*t.Lt))
+	case t.Lte != nil:
+		lt = timestampFromTransport(math.Floor(*t.Lte) + 1)
+	}
+
+	return gte, lt, nil
+}
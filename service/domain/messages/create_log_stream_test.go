@@ -0,0 +1,132 @@
+package messages_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/planetary-social/scuttlego/internal"
+	"github.com/planetary-social/scuttlego/service/domain/messages"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewCreateLogStreamArgumentsFromBytes(t *testing.T) {
+	testCases := []struct {
+		Name            string
+		Data            string
+		ExpectedGte     *time.Time
+		ExpectedLt      *time.Time
+		ExpectedLimit   *int
+		ExpectedMode    messages.MessageStreamMode
+		ExpectedOptions messages.MessageStreamOptions
+		ExpectedError   bool
+	}{
+		{
+			Name:            "no_arguments",
+			Data:            `[]`,
+			ExpectedMode:    messages.NewDefaultMessageStreamMode(),
+			ExpectedOptions: messages.NewMessageStreamOptions(true, true),
+		},
+		{
+			Name:            "empty_object",
+			Data:            `[{}]`,
+			ExpectedMode:    messages.NewDefaultMessageStreamMode(),
+			ExpectedOptions: messages.NewMessageStreamOptions(true, true),
+		},
+		{
+			Name:            "all_fields",
+			Data:            `[{"keys":false,"values":true,"limit":10,"live":true,"old":false,"reverse":true,"gte":1514517067954,"lt":1514517067960}]`,
+			ExpectedGte:     internal.Ptr(time.UnixMilli(1514517067954)),
+			ExpectedLt:      internal.Ptr(time.UnixMilli(1514517067960)),
+			ExpectedLimit:   internal.Ptr(10),
+			ExpectedMode:    messages.NewMessageStreamMode(true, false, true),
+			ExpectedOptions: messages.NewMessageStreamOptions(false, true),
+		},
+		{
+			Name:            "gt_and_lte_are_normalized",
+			Data:            `[{"gt":1514517067954,"lte":1514517067960}]`,
+			ExpectedGte:     internal.Ptr(time.UnixMilli(1514517067955)),
+			ExpectedLt:      internal.Ptr(time.UnixMilli(1514517067961)),
+			ExpectedMode:    messages.NewDefaultMessageStreamMode(),
+			ExpectedOptions: messages.NewMessageStreamOptions(true, true),
+		},
+		{
+			Name:            "fractional_timestamps",
+			Data:            `[{"gt":1514517067954.5,"lt":1514517067960.5}]`,
+			ExpectedGte:     internal.Ptr(time.UnixMilli(1514517067955)),
+			ExpectedLt:      internal.Ptr(time.UnixMilli(1514517067961)),
+			ExpectedMode:    messages.NewDefaultMessageStreamMode(),
+			ExpectedOptions: messages.NewMessageStreamOptions(true, true),
+		},
+		{
+			Name:            "negative_limit_means_unlimited",
+			Data:            `[{"limit":-1}]`,
+			ExpectedMode:    messages.NewDefaultMessageStreamMode(),
+			ExpectedOptions: messages.NewMessageStreamOptions(true, true),
+		},
+		{
+			Name:          "gt_and_gte",
+			Data:          `[{"gt":1514517067954,"gte":1514517067954}]`,
+			ExpectedError: true,
+		},
+		{
+			Name:          "lt_and_lte",
+			Data:          `[{"lt":1514517067954,"lte":1514517067954}]`,
+			ExpectedError: true,
+		},
+		{
+			Name:          "too_many_arguments",
+			Data:          `[{},{}]`,
+			ExpectedError: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			args, err := messages.NewCreateLogStreamArgumentsFromBytes([]byte(testCase.Data))
+			if testCase.ExpectedError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, testCase.ExpectedGte, args.Gte())
+			require.Equal(t, testCase.ExpectedLt, args.Lt())
+			require.Equal(t, testCase.ExpectedLimit, args.Limit())
+			require.Equal(t, testCase.ExpectedMode, args.Mode())
+			require.Equal(t, testCase.ExpectedOptions, args.Options())
+		})
+	}
+}
+
+func TestCreateLogStreamArguments_InRange(t *testing.T) {
+	args, err := messages.NewCreateLogStreamArguments(
+		internal.Ptr(time.UnixMilli(100)),
+		internal.Ptr(time.UnixMilli(200)),
+		nil,
+		messages.NewDefaultMessageStreamMode(),
+		messages.NewMessageStreamOptions(true, true),
+	)
+	require.NoError(t, err)
+
+	require.False(t, args.InRange(time.UnixMilli(99)))
+	require.True(t, args.InRange(time.UnixMilli(100)))
+	require.True(t, args.InRange(time.UnixMilli(199)))
+	require.False(t, args.InRange(time.UnixMilli(200)))
+}
+
+func TestCreateLogStreamArguments_MarshalJSON(t *testing.T) {
+	args, err := messages.NewCreateLogStreamArguments(
+		internal.Ptr(time.UnixMilli(1514517067954)),
+		internal.Ptr(time.UnixMilli(1514517067960)),
+		internal.Ptr(10),
+		messages.NewMessageStreamMode(true, true, true),
+		messages.NewMessageStreamOptions(true, false),
+	)
+	require.NoError(t, err)
+
+	j, err := args.MarshalJSON()
+	require.NoError(t, err)
+
+	parsed, err := messages.NewCreateLogStreamArgumentsFromBytes(j)
+	require.NoError(t, err)
+	require.Equal(t, args, parsed)
+}
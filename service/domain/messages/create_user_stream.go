@@ -0,0 +1,221 @@
+package messages
+
+import (
+	"github.com/boreq/errors"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/planetary-social/scuttlego/service/domain/feeds/message"
+	"github.com/planetary-social/scuttlego/service/domain/refs"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc"
+)
+
+var (
+	// CreateUserStreamProcedure streams messages from a single feed ordered
+	// by sequence. It is used by ssb-client based applications.
+	CreateUserStreamProcedure = rpc.MustNewProcedure(
+		rpc.MustNewProcedureName([]string{"createUserStream"}),
+		rpc.ProcedureTypeSource,
+	)
+)
+
+func NewCreateUserStream(arguments CreateUserStreamArguments) (*rpc.Request, error) {
+	j, err := arguments.MarshalJSON()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal arguments")
+	}
+
+	return rpc.NewRequest(
+		CreateUserStreamProcedure.Name(),
+		CreateUserStreamProcedure.Typ(),
+		j,
+	)
+}
+
+// CreateUserStreamArguments select messages using their sequence numbers. The
+// range is normalized so that gt and lte are converted to gte and lt.
+type CreateUserStreamArguments struct {
+	id      refs.Feed
+	gte     *message.Sequence
+	lt      *message.Sequence
+	limit   *int
+	mode    MessageStreamMode
+	options MessageStreamOptions
+}
+
+func NewCreateUserStreamArguments(
+	id refs.Feed,
+	gte *message.Sequence, // nil => from the beginning of the feed
+	lt *message.Sequence, // nil => no upper bound
+	limit *int, // nil => unlimited
+	mode MessageStreamMode,
+	options MessageStreamOptions,
+) (CreateUserStreamArguments, error) {
+	if id.IsZero() {
+		return CreateUserStreamArguments{}, errors.New("zero value of id")
+	}
+
+	if limit != nil && *limit < 0 {
+		return CreateUserStreamArguments{}, errors.New("limit can't be negative")
+	}
+
+	return CreateUserStreamArguments{
+		id:      id,
+		gte:     gte,
+		lt:      lt,
+		limit:   limit,
+		mode:    mode,
+		options: options,
+	}, nil
+}
+
+// NewCreateUserStreamArgumentsFromBytes accepts a single object e.g.
+// [{"id": "@id.ed25519", "gt": 10, "limit": 10, "reverse": true}]. Only one
+// of "gt" and "gte" and one of "lt" and "lte" can be set.
+func NewCreateUserStreamArgumentsFromBytes(b []byte) (CreateUserStreamArguments, error) {
+	var args []createUserStreamArgumentsTransport
+
+	if err := jsoniter.Unmarshal(b, &args); err != nil {
+		return CreateUserStreamArguments{}, errors.Wrap(err, "json unmarshal failed")
+	}
+
+	if len(args) != 1 {
+		return CreateUserStreamArguments{}, errors.New("expected exactly one argument")
+	}
+
+	transport := args[0]
+
+	id, err := refs.NewFeed(transport.Id)
+	if err != nil {
+		return CreateUserStreamArguments{}, errors.Wrap(err, "could not create a feed ref")
+	}
+
+	gte, err := transport.gte()
+	if err != nil {
+		return CreateUserStreamArguments{}, errors.Wrap(err, "invalid range")
+	}
+
+	lt, err := transport.lt()
+	if err != nil {
+		return CreateUserStreamArguments{}, errors.Wrap(err, "invalid range")
+	}
+
+	return NewCreateUserStreamArguments(
+		id,
+		gte,
+		lt,
+		limitFromTransport(transport.Limit),
+		transport.mode(),
+		transport.options(),
+	)
+}
+
+func (a CreateUserStreamArguments) Id() refs.Feed {
+	return a.id
+}
+
+// Gte is the lowest sequence which will be returned. Nil means that messages
+// will be returned starting from the beginning of the feed.
+func (a CreateUserStreamArguments) Gte() *message.Sequence {
+	return a.gte
+}
+
+// Lt is the sequence above the highest sequence which will be returned. Nil
+// means that there is no upper bound.
+func (a CreateUserStreamArguments) Lt() *message.Sequence {
+	return a.lt
+}
+
+func (a CreateUserStreamArguments) Limit() *int {
+	return a.limit
+}
+
+func (a CreateUserStreamArguments) Mode() MessageStreamMode {
+	return a.mode
+}
+
+func (a CreateUserStreamArguments) Options() MessageStreamOptions {
+	return a.options
+}
+
+func (a CreateUserStreamArguments) MarshalJSON() ([]byte, error) {
+	return jsoniter.Marshal([]createUserStreamArgumentsTransport{
+		{
+			messageStreamModeTransport:    newMessageStreamModeTransport(a.mode),
+			messageStreamOptionsTransport: newMessageStreamOptionsTransport(a.options),
+			Id:                            a.id.String(),
+			Gte:                           sequencePointerToIntPointer(a.gte),
+			Lt:                            sequencePointerToIntPointer(a.lt),
+			Limit:                         a.limit,
+		},
+	})
+}
+
+type createUserStreamArgumentsTransport struct {
+	messageStreamModeTransport
+	messageStreamOptionsTransport
+	Id    string `json:"id"`
+	Gt    *int   `json:"gt,omitempty"`
+	Gte   *int   `json:"gte,omitempty"`
+	Lt    *int   `json:"lt,omitempty"`
+	Lte   *int   `json:"lte,omitempty"`
+	Limit *int   `json:"limit,omitempty"`
+}
+
+func (t createUserStreamArgumentsTransport) gte() (*message.Sequence, error) {
+	if t.Gt != nil && t.Gte != nil {
+		return nil, errors.New("both gt and gte are set")
+	}
+
+	switch {
+	case t.Gte != nil:
+		return sequenceFromLowerBound(*t.Gte)
+	case t.Gt != nil:
+		return sequenceFromLowerBound(*t.Gt + 1)
+	default:
+		return nil, nil
+	}
+}
+
+func (t createUserStreamArgumentsTransport) lt() (*message.Sequence, error) {
+	if t.Lt != nil && t.Lte != nil {
+		return nil, errors.New("both lt and lte are set")
+	}
+
+	switch {
+	case t.Lt != nil:
+		return sequenceFromUpperBound(*t.Lt)
+	case t.Lte != nil:
+		return sequenceFromUpperBound(*t.Lte + 1)
+	default:
+		return nil, nil
+	}
+}
+
+// sequenceFromUpperBound clamps bounds below the first sequence to the first
+// sequence which results in an empty range.
+func sequenceFromUpperBound(v int) (*message.Sequence, error) {
+	if v < message.NewFirstSequence().Int() {
+		v = message.NewFirstSequence().Int()
+	}
+
+	seq, err := message.NewSequence(v)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not create a sequence")
+	}
+
+	return &seq, nil
+}
+
+// sequenceFromLowerBound treats bounds below the first sequence as no bound
+// at all as clients often pass 0 to request the entire feed.
+func sequenceFromLowerBound(v int) (*message.Sequence, error) {
+	if v <= message.NewFirstSequence().Int() {
+		return nil, nil
+	}
+
+	seq, err := message.NewSequence(v)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not create a sequence")
+	}
+
+	return &seq, nil
+}
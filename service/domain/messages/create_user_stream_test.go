@@ -0,0 +1,128 @@
+package messages_test
+
+import (
+	"testing"
+
+	"github.com/planetary-social/scuttlego/internal"
+	"github.com/planetary-social/scuttlego/service/domain/feeds/message"
+	"github.com/planetary-social/scuttlego/service/domain/messages"
+	"github.com/planetary-social/scuttlego/service/domain/refs"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewCreateUserStreamArgumentsFromBytes(t *testing.T) {
+	id := refs.MustNewFeed("@qFtLJ6P5Eh9vKxnj7Rsh8SkE6B6Z36DVLP7ZOKNeQ/Y=.ed25519")
+
+	testCases := []struct {
+		Name            string
+		Data            string
+		ExpectedGte     *message.Sequence
+		ExpectedLt      *message.Sequence
+		ExpectedLimit   *int
+		ExpectedMode    messages.MessageStreamMode
+		ExpectedOptions messages.MessageStreamOptions
+		ExpectedError   bool
+	}{
+		{
+			Name:            "only_id",
+			Data:            `[{"id":"@qFtLJ6P5Eh9vKxnj7Rsh8SkE6B6Z36DVLP7ZOKNeQ/Y=.ed25519"}]`,
+			ExpectedMode:    messages.NewDefaultMessageStreamMode(),
+			ExpectedOptions: messages.NewMessageStreamOptions(true, true),
+		},
+		{
+			Name:            "gt",
+			Data:            `[{"id":"@qFtLJ6P5Eh9vKxnj7Rsh8SkE6B6Z36DVLP7ZOKNeQ/Y=.ed25519","gt":5,"limit":10,"keys":false}]`,
+			ExpectedGte:     internal.Ptr(message.MustNewSequence(6)),
+			ExpectedLimit:   internal.Ptr(10),
+			ExpectedMode:    messages.NewDefaultMessageStreamMode(),
+			ExpectedOptions: messages.NewMessageStreamOptions(false, true),
+		},
+		{
+			Name:            "gte",
+			Data:            `[{"id":"@qFtLJ6P5Eh9vKxnj7Rsh8SkE6B6Z36DVLP7ZOKNeQ/Y=.ed25519","gte":5}]`,
+			ExpectedGte:     internal.Ptr(message.MustNewSequence(5)),
+			ExpectedMode:    messages.NewDefaultMessageStreamMode(),
+			ExpectedOptions: messages.NewMessageStreamOptions(true, true),
+		},
+		{
+			Name:            "gt_zero_means_entire_feed",
+			Data:            `[{"id":"@qFtLJ6P5Eh9vKxnj7Rsh8SkE6B6Z36DVLP7ZOKNeQ/Y=.ed25519","gt":0}]`,
+			ExpectedMode:    messages.NewDefaultMessageStreamMode(),
+			ExpectedOptions: messages.NewMessageStreamOptions(true, true),
+		},
+		{
+			Name:            "lt",
+			Data:            `[{"id":"@qFtLJ6P5Eh9vKxnj7Rsh8SkE6B6Z36DVLP7ZOKNeQ/Y=.ed25519","lt":5,"reverse":true,"live":true}]`,
+			ExpectedLt:      internal.Ptr(message.MustNewSequence(5)),
+			ExpectedMode:    messages.NewMessageStreamMode(true, true, true),
+			ExpectedOptions: messages.NewMessageStreamOptions(true, true),
+		},
+		{
+			Name:            "lte",
+			Data:            `[{"id":"@qFtLJ6P5Eh9vKxnj7Rsh8SkE6B6Z36DVLP7ZOKNeQ/Y=.ed25519","lte":5,"old":false}]`,
+			ExpectedLt:      internal.Ptr(message.MustNewSequence(6)),
+			ExpectedMode:    messages.NewMessageStreamMode(false, false, false),
+			ExpectedOptions: messages.NewMessageStreamOptions(true, true),
+		},
+		{
+			Name:            "lt_zero_means_empty_range",
+			Data:            `[{"id":"@qFtLJ6P5Eh9vKxnj7Rsh8SkE6B6Z36DVLP7ZOKNeQ/Y=.ed25519","lt":0}]`,
+			ExpectedLt:      internal.Ptr(message.NewFirstSequence()),
+			ExpectedMode:    messages.NewDefaultMessageStreamMode(),
+			ExpectedOptions: messages.NewMessageStreamOptions(true, true),
+		},
+		{
+			Name:          "lt_and_lte",
+			Data:          `[{"id":"@qFtLJ6P5Eh9vKxnj7Rsh8SkE6B6Z36DVLP7ZOKNeQ/Y=.ed25519","lt":5,"lte":5}]`,
+			ExpectedError: true,
+		},
+		{
+			Name:          "gt_and_gte",
+			Data:          `[{"id":"@qFtLJ6P5Eh9vKxnj7Rsh8SkE6B6Z36DVLP7ZOKNeQ/Y=.ed25519","gt":5,"gte":5}]`,
+			ExpectedError: true,
+		},
+		{
+			Name:          "missing_id",
+			Data:          `[{}]`,
+			ExpectedError: true,
+		},
+		{
+			Name:          "no_arguments",
+			Data:          `[]`,
+			ExpectedError: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			args, err := messages.NewCreateUserStreamArgumentsFromBytes([]byte(testCase.Data))
+			if testCase.ExpectedError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, id, args.Id())
+			require.Equal(t, testCase.ExpectedGte, args.Gte())
+			require.Equal(t, testCase.ExpectedLt, args.Lt())
+			require.Equal(t, testCase.ExpectedLimit, args.Limit())
+			require.Equal(t, testCase.ExpectedMode, args.Mode())
+			require.Equal(t, testCase.ExpectedOptions, args.Options())
+		})
+	}
+}
+
+func TestCreateUserStreamArguments_MarshalJSON(t *testing.T) {
+	args, err := messages.NewCreateUserStreamArguments(
+		refs.MustNewFeed("@qFtLJ6P5Eh9vKxnj7Rsh8SkE6B6Z36DVLP7ZOKNeQ/Y=.ed25519"),
+		internal.Ptr(message.MustNewSequence(5)),
+		internal.Ptr(message.MustNewSequence(8)),
+		internal.Ptr(10),
+		messages.NewMessageStreamMode(false, true, true),
+		messages.NewMessageStreamOptions(true, true),
+	)
+	require.NoError(t, err)
+
+	j, err := args.MarshalJSON()
+	require.NoError(t, err)
+	require.Equal(t, `[{"reverse":true,"id":"@qFtLJ6P5Eh9vKxnj7Rsh8SkE6B6Z36DVLP7ZOKNeQ/Y=.ed25519","gte":5,"lt":8,"limit":10}]`, string(j))
+}
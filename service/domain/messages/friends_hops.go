@@ -0,0 +1,131 @@
+package messages
+
+import (
+	"github.com/boreq/errors"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/planetary-social/scuttlego/service/domain/graph"
+	"github.com/planetary-social/scuttlego/service/domain/refs"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc"
+)
+
+var (
+	FriendsHopsProcedure = rpc.MustNewProcedure(
+		rpc.MustNewProcedureName([]string{"friends", "hops"}),
+		rpc.ProcedureTypeAsync,
+	)
+)
+
+func NewFriendsHops(arguments FriendsHopsArguments) (*rpc.Request, error) {
+	j, err := arguments.MarshalJSON()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal arguments")
+	}
+
+	return rpc.NewRequest(
+		FriendsHopsProcedure.Name(),
+		FriendsHopsProcedure.Typ(),
+		j,
+	)
+}
+
+type FriendsHopsArguments struct {
+	start *refs.Identity
+	max   *graph.Hops
+}
+
+func NewFriendsHopsArguments(
+	start *refs.Identity, // nil => local identity
+	max *graph.Hops, // nil => configured number of hops
+) (FriendsHopsArguments, error) {
+	if start != nil && start.IsZero() {
+		return FriendsHopsArguments{}, errors.New("zero value of start")
+	}
+
+	return FriendsHopsArguments{
+		start: start,
+		max:   max,
+	}, nil
+}
+
+func NewFriendsHopsArgumentsFromBytes(b []byte) (FriendsHopsArguments, error) {
+	var args []friendsHopsArgumentsTransport
+
+	if err := jsoniter.Unmarshal(b, &args); err != nil {
+		return FriendsHopsArguments{}, errors.Wrap(err, "json unmarshal failed")
+	}
+
+	if len(args) == 0 {
+		return NewFriendsHopsArguments(nil, nil)
+	}
+
+	if len(args) != 1 {
+		return FriendsHopsArguments{}, errors.New("expected at most one argument")
+	}
+
+	var start *refs.Identity
+	if args[0].Start != nil {
+		tmp, err := refs.NewIdentity(*args[0].Start)
+		if err != nil {
+			return FriendsHopsArguments{}, errors.Wrap(err, "could not create a ref")
+		}
+		start = &tmp
+	}
+
+	var max *graph.Hops
+	if args[0].Max != nil {
+		tmp, err := graph.NewHops(*args[0].Max)
+		if err != nil {
+			return FriendsHopsArguments{}, errors.Wrap(err, "could not create hops")
+		}
+		max = &tmp
+	}
+
+	return NewFriendsHopsArguments(start, max)
+}
+
+func (a FriendsHopsArguments) Start() *refs.Identity {
+	return a.start
+}
+
+func (a FriendsHopsArguments) Max() *graph.Hops {
+	return a.max
+}
+
+func (a FriendsHopsArguments) MarshalJSON() ([]byte, error) {
+	var transport friendsHopsArgumentsTransport
+
+	if a.start != nil {
+		tmp := a.start.String()
+		transport.Start = &tmp
+	}
+
+	if a.max != nil {
+		tmp := a.max.Int()
+		transport.Max = &tmp
+	}
+
+	return jsoniter.Marshal([]friendsHopsArgumentsTransport{transport})
+}
+
+type friendsHopsArgumentsTransport struct {
+	Start *string `json:"start,omitempty"`
+	Max   *int    `json:"max,omitempty"`
+}
+
+type FriendsHopsResponse struct {
+	contacts []graph.Contact
+}
+
+func NewFriendsHopsResponse(contacts []graph.Contact) FriendsHopsResponse {
+	return FriendsHopsResponse{
+		contacts: contacts,
+	}
+}
+
+func (r FriendsHopsResponse) MarshalJSON() ([]byte, error) {
+	transport := make(map[string]int, len(r.contacts))
+	for _, contact := range r.contacts {
+		transport[contact.Id.String()] = contact.Hops.Int()
+	}
+	return jsoniter.Marshal(transport)
+}
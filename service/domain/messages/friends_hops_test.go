@@ -0,0 +1,89 @@
+package messages_test
+
+import (
+	"testing"
+
+	"github.com/planetary-social/scuttlego/internal"
+	"github.com/planetary-social/scuttlego/service/domain/graph"
+	"github.com/planetary-social/scuttlego/service/domain/messages"
+	"github.com/planetary-social/scuttlego/service/domain/refs"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewFriendsHopsArgumentsFromBytes(t *testing.T) {
+	start := refs.MustNewIdentity("@qFtLJ6P5Eh9vKxnj7Rsh8SkE6B6Z36DVLP7ZOKNeQ/Y=.ed25519")
+
+	testCases := []struct {
+		Name          string
+		Data          string
+		ExpectedStart *refs.Identity
+		ExpectedMax   *graph.Hops
+		ExpectedError bool
+	}{
+		{
+			Name: "no_arguments",
+			Data: `[]`,
+		},
+		{
+			Name: "empty_object",
+			Data: `[{}]`,
+		},
+		{
+			Name:          "start_and_max",
+			Data:          `[{"start":"@qFtLJ6P5Eh9vKxnj7Rsh8SkE6B6Z36DVLP7ZOKNeQ/Y=.ed25519","max":2}]`,
+			ExpectedStart: internal.Ptr(start),
+			ExpectedMax:   internal.Ptr(graph.MustNewHops(2)),
+		},
+		{
+			Name:          "invalid_start",
+			Data:          `[{"start":"invalid"}]`,
+			ExpectedError: true,
+		},
+		{
+			Name:          "negative_max",
+			Data:          `[{"max":-1}]`,
+			ExpectedError: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			args, err := messages.NewFriendsHopsArgumentsFromBytes([]byte(testCase.Data))
+			if testCase.ExpectedError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, testCase.ExpectedStart, args.Start())
+			require.Equal(t, testCase.ExpectedMax, args.Max())
+		})
+	}
+}
+
+func TestFriendsHopsArguments_MarshalJSON(t *testing.T) {
+	start := refs.MustNewIdentity("@qFtLJ6P5Eh9vKxnj7Rsh8SkE6B6Z36DVLP7ZOKNeQ/Y=.ed25519")
+
+	args, err := messages.NewFriendsHopsArguments(internal.Ptr(start), internal.Ptr(graph.MustNewHops(2)))
+	require.NoError(t, err)
+
+	j, err := args.MarshalJSON()
+	require.NoError(t, err)
+	require.Equal(t, `[{"start":"@qFtLJ6P5Eh9vKxnj7Rsh8SkE6B6Z36DVLP7ZOKNeQ/Y=.ed25519","max":2}]`, string(j))
+}
+
+func TestFriendsHopsResponse_MarshalJSON(t *testing.T) {
+	response := messages.NewFriendsHopsResponse([]graph.Contact{
+		{
+			Id:   refs.MustNewIdentity("@qFtLJ6P5Eh9vKxnj7Rsh8SkE6B6Z36DVLP7ZOKNeQ/Y=.ed25519"),
+			Hops: graph.MustNewHops(0),
+		},
+		{
+			Id:   refs.MustNewIdentity("@iL6NzQoOLFP18pCpprkbY80DMtiG4JFFtVSVUaoGsOQ=.ed25519"),
+			Hops: graph.MustNewHops(1),
+		},
+	})
+
+	j, err := response.MarshalJSON()
+	require.NoError(t, err)
+	require.JSONEq(t, `{"@qFtLJ6P5Eh9vKxnj7Rsh8SkE6B6Z36DVLP7ZOKNeQ/Y=.ed25519":0,"@iL6NzQoOLFP18pCpprkbY80DMtiG4JFFtVSVUaoGsOQ=.ed25519":1}`, string(j))
+}
@@ -0,0 +1,26 @@
+package messages
+
+import (
+	"github.com/boreq/errors"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc"
+)
+
+var (
+	FriendsIsBlockingProcedure = rpc.MustNewProcedure(
+		rpc.MustNewProcedureName([]string{"friends", "isBlocking"}),
+		rpc.ProcedureTypeAsync,
+	)
+)
+
+func NewFriendsIsBlocking(arguments FriendsRelationArguments) (*rpc.Request, error) {
+	j, err := arguments.MarshalJSON()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal arguments")
+	}
+
+	return rpc.NewRequest(
+		FriendsIsBlockingProcedure.Name(),
+		FriendsIsBlockingProcedure.Typ(),
+		j,
+	)
+}
@@ -0,0 +1,96 @@
+package messages
+
+import (
+	"github.com/boreq/errors"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/planetary-social/scuttlego/service/domain/refs"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc"
+)
+
+var (
+	FriendsIsFollowingProcedure = rpc.MustNewProcedure(
+		rpc.MustNewProcedureName([]string{"friends", "isFollowing"}),
+		rpc.ProcedureTypeAsync,
+	)
+)
+
+func NewFriendsIsFollowing(arguments FriendsRelationArguments) (*rpc.Request, error) {
+	j, err := arguments.MarshalJSON()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal arguments")
+	}
+
+	return rpc.NewRequest(
+		FriendsIsFollowingProcedure.Name(),
+		FriendsIsFollowingProcedure.Typ(),
+		j,
+	)
+}
+
+// FriendsRelationArguments are used by procedures which check the relation
+// between two identities.
+type FriendsRelationArguments struct {
+	source refs.Identity
+	dest   refs.Identity
+}
+
+func NewFriendsRelationArguments(source, dest refs.Identity) (FriendsRelationArguments, error) {
+	if source.IsZero() {
+		return FriendsRelationArguments{}, errors.New("zero value of source")
+	}
+
+	if dest.IsZero() {
+		return FriendsRelationArguments{}, errors.New("zero value of dest")
+	}
+
+	return FriendsRelationArguments{
+		source: source,
+		dest:   dest,
+	}, nil
+}
+
+func NewFriendsRelationArgumentsFromBytes(b []byte) (FriendsRelationArguments, error) {
+	var args []friendsRelationArgumentsTransport
+
+	if err := jsoniter.Unmarshal(b, &args); err != nil {
+		return FriendsRelationArguments{}, errors.Wrap(err, "json unmarshal failed")
+	}
+
+	if len(args) != 1 {
+		return FriendsRelationArguments{}, errors.New("expected exactly one argument")
+	}
+
+	source, err := refs.NewIdentity(args[0].Source)
+	if err != nil {
+		return FriendsRelationArguments{}, errors.Wrap(err, "could not create the source ref")
+	}
+
+	dest, err := refs.NewIdentity(args[0].Dest)
+	if err != nil {
+		return FriendsRelationArguments{}, errors.Wrap(err, "could not create the dest ref")
+	}
+
+	return NewFriendsRelationArguments(source, dest)
+}
+
+func (a FriendsRelationArguments) Source() refs.Identity {
+	return a.source
+}
+
+func (a FriendsRelationArguments) Dest() refs.Identity {
+	return a.dest
+}
+
+func (a FriendsRelationArguments) MarshalJSON() ([]byte, error) {
+	return jsoniter.Marshal([]friendsRelationArgumentsTransport{
+		{
+			Source: a.source.String(),
+			Dest:   a.dest.String(),
+		},
+	})
+}
+
+type friendsRelationArgumentsTransport struct {
+	Source string `json:"source"`
+	Dest   string `json:"dest"`
+}
@@ -0,0 +1,37 @@
+package messages_test
+
+import (
+	"testing"
+
+	"github.com/planetary-social/scuttlego/service/domain/messages"
+	"github.com/planetary-social/scuttlego/service/domain/refs"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewFriendsRelationArgumentsFromBytes(t *testing.T) {
+	source := refs.MustNewIdentity("@qFtLJ6P5Eh9vKxnj7Rsh8SkE6B6Z36DVLP7ZOKNeQ/Y=.ed25519")
+	dest := refs.MustNewIdentity("@iL6NzQoOLFP18pCpprkbY80DMtiG4JFFtVSVUaoGsOQ=.ed25519")
+
+	args, err := messages.NewFriendsRelationArgumentsFromBytes([]byte(`[{"source":"@qFtLJ6P5Eh9vKxnj7Rsh8SkE6B6Z36DVLP7ZOKNeQ/Y=.ed25519","dest":"@iL6NzQoOLFP18pCpprkbY80DMtiG4JFFtVSVUaoGsOQ=.ed25519"}]`))
+	require.NoError(t, err)
+	require.Equal(t, source, args.Source())
+	require.Equal(t, dest, args.Dest())
+
+	_, err = messages.NewFriendsRelationArgumentsFromBytes([]byte(`[{"source":"@qFtLJ6P5Eh9vKxnj7Rsh8SkE6B6Z36DVLP7ZOKNeQ/Y=.ed25519"}]`))
+	require.Error(t, err)
+
+	_, err = messages.NewFriendsRelationArgumentsFromBytes([]byte(`[]`))
+	require.Error(t, err)
+}
+
+func TestFriendsRelationArguments_MarshalJSON(t *testing.T) {
+	args, err := messages.NewFriendsRelationArguments(
+		refs.MustNewIdentity("@qFtLJ6P5Eh9vKxnj7Rsh8SkE6B6Z36DVLP7ZOKNeQ/Y=.ed25519"),
+		refs.MustNewIdentity("@iL6NzQoOLFP18pCpprkbY80DMtiG4JFFtVSVUaoGsOQ=.ed25519"),
+	)
+	require.NoError(t, err)
+
+	j, err := args.MarshalJSON()
+	require.NoError(t, err)
+	require.Equal(t, `[{"source":"@qFtLJ6P5Eh9vKxnj7Rsh8SkE6B6Z36DVLP7ZOKNeQ/Y=.ed25519","dest":"@iL6NzQoOLFP18pCpprkbY80DMtiG4JFFtVSVUaoGsOQ=.ed25519"}]`, string(j))
+}
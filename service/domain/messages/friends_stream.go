@@ -0,0 +1,142 @@
+package messages
+
+import (
+	"github.com/boreq/errors"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/planetary-social/scuttlego/service/domain/feeds"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc"
+)
+
+var (
+	FriendsStreamProcedure = rpc.MustNewProcedure(
+		rpc.MustNewProcedureName([]string{"friends", "stream"}),
+		rpc.ProcedureTypeSource,
+	)
+)
+
+const (
+	friendsStreamValueFollowing = 1
+	friendsStreamValueBlocking  = -1
+	friendsStreamValueNone      = -2
+)
+
+func NewFriendsStream(arguments FriendsStreamArguments) (*rpc.Request, error) {
+	j, err := arguments.MarshalJSON()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal arguments")
+	}
+
+	return rpc.NewRequest(
+		FriendsStreamProcedure.Name(),
+		FriendsStreamProcedure.Typ(),
+		j,
+	)
+}
+
+type FriendsStreamArguments struct {
+	live bool
+}
+
+func NewFriendsStreamArguments(live bool) FriendsStreamArguments {
+	return FriendsStreamArguments{
+		live: live,
+	}
+}
+
+func NewFriendsStreamArgumentsFromBytes(b []byte) (FriendsStreamArguments, error) {
+	var args []friendsStreamArgumentsTransport
+
+	if err := jsoniter.Unmarshal(b, &args); err != nil {
+		return FriendsStreamArguments{}, errors.Wrap(err, "json unmarshal failed")
+	}
+
+	if len(args) == 0 {
+		return NewFriendsStreamArguments(false), nil
+	}
+
+	if len(args) != 1 {
+		return FriendsStreamArguments{}, errors.New("expected at most one argument")
+	}
+
+	return NewFriendsStreamArguments(args[0].Live != nil && *args[0].Live), nil
+}
+
+func (a FriendsStreamArguments) Live() bool {
+	return a.live
+}
+
+func (a FriendsStreamArguments) MarshalJSON() ([]byte, error) {
+	return jsoniter.Marshal([]friendsStreamArgumentsTransport{
+		{
+			Live: &a.live,
+		},
+	})
+}
+
+type friendsStreamArgumentsTransport struct {
+	Live *bool `json:"live,omitempty"`
+}
+
+// FriendsStreamGraphResponse is the first message sent by the stream and
+// contains the entire graph in the form of {"author": {"target": value}}.
+type FriendsStreamGraphResponse struct {
+	contacts []*feeds.Contact
+}
+
+func NewFriendsStreamGraphResponse(contacts []*feeds.Contact) FriendsStreamGraphResponse {
+	return FriendsStreamGraphResponse{
+		contacts: contacts,
+	}
+}
+
+func (r FriendsStreamGraphResponse) MarshalJSON() ([]byte, error) {
+	transport := make(map[string]map[string]int)
+	for _, contact := range r.contacts {
+		author := contact.Author().String()
+		if _, ok := transport[author]; !ok {
+			transport[author] = make(map[string]int)
+		}
+		transport[author][contact.Target().String()] = friendsStreamValue(contact)
+	}
+	return jsoniter.Marshal(transport)
+}
+
+// FriendsStreamChangeResponse is sent by live streams every time a contact
+// changes.
+type FriendsStreamChangeResponse struct {
+	contact *feeds.Contact
+}
+
+func NewFriendsStreamChangeResponse(contact *feeds.Contact) (FriendsStreamChangeResponse, error) {
+	if contact == nil {
+		return FriendsStreamChangeResponse{}, errors.New("nil contact")
+	}
+
+	return FriendsStreamChangeResponse{
+		contact: contact,
+	}, nil
+}
+
+func (r FriendsStreamChangeResponse) MarshalJSON() ([]byte, error) {
+	return jsoniter.Marshal(friendsStreamChangeResponseTransport{
+		From:  r.contact.Author().String(),
+		To:    r.contact.Target().String(),
+		Value: friendsStreamValue(r.contact),
+	})
+}
+
+type friendsStreamChangeResponseTransport struct {
+	From  string `json:"from"`
+	To    string `json:"to"`
+	Value int    `json:"value"`
+}
+
+func friendsStreamValue(contact *feeds.Contact) int {
+	if contact.Blocking() {
+		return friendsStreamValueBlocking
+	}
+	if contact.Following() {
+		return friendsStreamValueFollowing
+	}
+	return friendsStreamValueNone
+}
@@ -0,0 +1,81 @@
+package messages_test
+
+import (
+	"testing"
+
+	"github.com/planetary-social/scuttlego/service/domain/feeds"
+	"github.com/planetary-social/scuttlego/service/domain/messages"
+	"github.com/planetary-social/scuttlego/service/domain/refs"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewFriendsStreamArgumentsFromBytes(t *testing.T) {
+	testCases := []struct {
+		Name         string
+		Data         string
+		ExpectedLive bool
+	}{
+		{
+			Name:         "no_arguments",
+			Data:         `[]`,
+			ExpectedLive: false,
+		},
+		{
+			Name:         "empty_object",
+			Data:         `[{}]`,
+			ExpectedLive: false,
+		},
+		{
+			Name:         "live",
+			Data:         `[{"live":true}]`,
+			ExpectedLive: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			args, err := messages.NewFriendsStreamArgumentsFromBytes([]byte(testCase.Data))
+			require.NoError(t, err)
+			require.Equal(t, testCase.ExpectedLive, args.Live())
+		})
+	}
+}
+
+func TestFriendsStreamGraphResponse_MarshalJSON(t *testing.T) {
+	a := refs.MustNewIdentity("@qFtLJ6P5Eh9vKxnj7Rsh8SkE6B6Z36DVLP7ZOKNeQ/Y=.ed25519")
+	b := refs.MustNewIdentity("@iL6NzQoOLFP18pCpprkbY80DMtiG4JFFtVSVUaoGsOQ=.ed25519")
+	c := refs.MustNewIdentity("@Rb35fIxeeU4NbumTHE3r1R8BjS6vVIGGhOdX4jJ5qDc=.ed25519")
+
+	response := messages.NewFriendsStreamGraphResponse([]*feeds.Contact{
+		feeds.MustNewContactFromHistory(a, b, true, false),
+		feeds.MustNewContactFromHistory(a, c, true, true),
+		feeds.MustNewContactFromHistory(b, a, false, false),
+	})
+
+	j, err := response.MarshalJSON()
+	require.NoError(t, err)
+	require.JSONEq(t,
+		`{
+			"@qFtLJ6P5Eh9vKxnj7Rsh8SkE6B6Z36DVLP7ZOKNeQ/Y=.ed25519": {
+				"@iL6NzQoOLFP18pCpprkbY80DMtiG4JFFtVSVUaoGsOQ=.ed25519": 1,
+				"@Rb35fIxeeU4NbumTHE3r1R8BjS6vVIGGhOdX4jJ5qDc=.ed25519": -1
+			},
+			"@iL6NzQoOLFP18pCpprkbY80DMtiG4JFFtVSVUaoGsOQ=.ed25519": {
+				"@qFtLJ6P5Eh9vKxnj7Rsh8SkE6B6Z36DVLP7ZOKNeQ/Y=.ed25519": -2
+			}
+		}`,
+		string(j),
+	)
+}
+
+func TestFriendsStreamChangeResponse_MarshalJSON(t *testing.T) {
+	a := refs.MustNewIdentity("@qFtLJ6P5Eh9vKxnj7Rsh8SkE6B6Z36DVLP7ZOKNeQ/Y=.ed25519")
+	b := refs.MustNewIdentity("@iL6NzQoOLFP18pCpprkbY80DMtiG4JFFtVSVUaoGsOQ=.ed25519")
+
+	response, err := messages.NewFriendsStreamChangeResponse(feeds.MustNewContactFromHistory(a, b, true, false))
+	require.NoError(t, err)
+
+	j, err := response.MarshalJSON()
+	require.NoError(t, err)
+	require.Equal(t, `{"from":"@qFtLJ6P5Eh9vKxnj7Rsh8SkE6B6Z36DVLP7ZOKNeQ/Y=.ed25519","to":"@iL6NzQoOLFP18pCpprkbY80DMtiG4JFFtVSVUaoGsOQ=.ed25519","value":1}`, string(j))
+}
@@ -0,0 +1,161 @@
+package messages
+
+import (
+	"github.com/boreq/errors"
+	"github.com/hashicorp/go-multierror"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/planetary-social/scuttlego/service/domain/feeds/message"
+	"github.com/planetary-social/scuttlego/service/domain/refs"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc"
+)
+
+var (
+	GetProcedure = rpc.MustNewProcedure(
+		rpc.MustNewProcedureName([]string{"get"}),
+		rpc.ProcedureTypeAsync,
+	)
+)
+
+func NewGet(arguments GetArguments) (*rpc.Request, error) {
+	j, err := arguments.MarshalJSON()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal arguments")
+	}
+
+	return rpc.NewRequest(
+		GetProcedure.Name(),
+		GetProcedure.Typ(),
+		j,
+	)
+}
+
+type GetArguments struct {
+	id   refs.Message
+	meta bool
+}
+
+func NewGetArguments(id refs.Message) (GetArguments, error) {
+	if id.IsZero() {
+		return GetArguments{}, errors.New("zero value of id")
+	}
+
+	return GetArguments{
+		id: id,
+	}, nil
+}
+
+// NewGetArgumentsWithMeta creates arguments requesting the message to be
+// returned as a KeyValueTimestamp instead of just the raw message.
+func NewGetArgumentsWithMeta(id refs.Message) (GetArguments, error) {
+	args, err := NewGetArguments(id)
+	if err != nil {
+		return GetArguments{}, errors.Wrap(err, "error creating arguments")
+	}
+
+	args.meta = true
+	return args, nil
+}
+
+// NewGetArgumentsFromBytes accepts either a message id, e.g. ["%id.sha256"],
+// or an object, e.g. [{"id": "%id.sha256", "meta": true}].
+func NewGetArgumentsFromBytes(b []byte) (GetArguments, error) {
+	var err error
+
+	args, stringErr := newGetArgumentsFromBytesString(b)
+	err = multierror.Append(err, errors.Wrap(stringErr, "error unmarshaling arguments as string"))
+	if stringErr == nil {
+		return args, nil
+	}
+
+	args, objectErr := newGetArgumentsFromBytesObject(b)
+	err = multierror.Append(err, errors.Wrap(objectErr, "error unmarshaling arguments as object"))
+	if objectErr == nil {
+		return args, nil
+	}
+
+	return GetArguments{}, err
+}
+
+func newGetArgumentsFromBytesString(b []byte) (GetArguments, error) {
+	var args []string
+
+	if err := jsoniter.Unmarshal(b, &args); err != nil {
+		return GetArguments{}, errors.Wrap(err, "json unmarshal failed")
+	}
+
+	if len(args) != 1 {
+		return GetArguments{}, errors.New("expected exactly one argument")
+	}
+
+	id, err := refs.NewMessage(args[0])
+	if err != nil {
+		return GetArguments{}, errors.Wrap(err, "could not create a message ref")
+	}
+
+	return NewGetArguments(id)
+}
+
+func newGetArgumentsFromBytesObject(b []byte) (GetArguments, error) {
+	var args []getArgumentsTransport
+
+	if err := jsoniter.Unmarshal(b, &args); err != nil {
+		return GetArguments{}, errors.Wrap(err, "json unmarshal failed")
+	}
+
+	if len(args) != 1 {
+		return GetArguments{}, errors.New("expected exactly one argument")
+	}
+
+	id, err := refs.NewMessage(args[0].Id)
+	if err != nil {
+		return GetArguments{}, errors.Wrap(err, "could not create a message ref")
+	}
+
+	if args[0].Meta {
+		return NewGetArgumentsWithMeta(id)
+	}
+
+	return NewGetArguments(id)
+}
+
+func (a GetArguments) Id() refs.Message {
+	return a.id
+}
+
+// Meta is true if the message should be returned as a KeyValueTimestamp.
+func (a GetArguments) Meta() bool {
+	return a.meta
+}
+
+func (a GetArguments) MarshalJSON() ([]byte, error) {
+	if a.meta {
+		return jsoniter.Marshal([]getArgumentsTransport{{Id: a.id.String(), Meta: true}})
+	}
+	return jsoniter.Marshal([]string{a.id.String()})
+}
+
+type getArgumentsTransport struct {
+	Id   string `json:"id"`
+	Meta bool   `json:"meta,omitempty"`
+}
+
+// GetResponse contains the value of the requested message which is the raw
+// message as it was signed by its author.
+type GetResponse struct {
+	raw message.RawMessage
+}
+
+func NewGetResponseFromBytes(b []byte) (GetResponse, error) {
+	raw, err := message.NewRawMessage(b)
+	if err != nil {
+		return GetResponse{}, errors.Wrap(err, "could not create a raw message")
+	}
+
+	return GetResponse{
+		raw: raw,
+	}, nil
+}
+
+func (r GetResponse) RawMessage() message.RawMessage {
+	return r.raw
+}
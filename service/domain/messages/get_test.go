@@ -0,0 +1,84 @@
+package messages_test
+
+import (
+	"testing"
+
+	"github.com/planetary-social/scuttlego/service/domain/messages"
+	"github.com/planetary-social/scuttlego/service/domain/refs"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewGetArgumentsFromBytes(t *testing.T) {
+	id := refs.MustNewMessage("%uaGieSQDJcHfUp6hjIcIq55GoZh4Ug7tNmgaohoxrpw=.sha256")
+
+	testCases := []struct {
+		Name          string
+		Data          string
+		ExpectedMeta  bool
+		ExpectedError bool
+	}{
+		{
+			Name: "string",
+			Data: `["%uaGieSQDJcHfUp6hjIcIq55GoZh4Ug7tNmgaohoxrpw=.sha256"]`,
+		},
+		{
+			Name: "object",
+			Data: `[{"id":"%uaGieSQDJcHfUp6hjIcIq55GoZh4Ug7tNmgaohoxrpw=.sha256","private":false}]`,
+		},
+		{
+			Name:         "object_with_meta",
+			Data:         `[{"id":"%uaGieSQDJcHfUp6hjIcIq55GoZh4Ug7tNmgaohoxrpw=.sha256","meta":true}]`,
+			ExpectedMeta: true,
+		},
+		{
+			Name:          "invalid_id",
+			Data:          `["invalid"]`,
+			ExpectedError: true,
+		},
+		{
+			Name:          "no_arguments",
+			Data:          `[]`,
+			ExpectedError: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			args, err := messages.NewGetArgumentsFromBytes([]byte(testCase.Data))
+			if testCase.ExpectedError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, id, args.Id())
+			require.Equal(t, testCase.ExpectedMeta, args.Meta())
+		})
+	}
+}
+
+func TestGetArguments_MarshalJSON(t *testing.T) {
+	args, err := messages.NewGetArguments(refs.MustNewMessage("%uaGieSQDJcHfUp6hjIcIq55GoZh4Ug7tNmgaohoxrpw=.sha256"))
+	require.NoError(t, err)
+
+	j, err := args.MarshalJSON()
+	require.NoError(t, err)
+	require.Equal(t, `["%uaGieSQDJcHfUp6hjIcIq55GoZh4Ug7tNmgaohoxrpw=.sha256"]`, string(j))
+}
+
+func TestGetArguments_MarshalJSONWithMeta(t *testing.T) {
+	args, err := messages.NewGetArgumentsWithMeta(refs.MustNewMessage("%uaGieSQDJcHfUp6hjIcIq55GoZh4Ug7tNmgaohoxrpw=.sha256"))
+	require.NoError(t, err)
+
+	j, err := args.MarshalJSON()
+	require.NoError(t, err)
+	require.Equal(t, `[{"id":"%uaGieSQDJcHfUp6hjIcIq55GoZh4Ug7tNmgaohoxrpw=.sha256","meta":true}]`, string(j))
+}
+
+func TestNewGetResponseFromBytes(t *testing.T) {
+	response, err := messages.NewGetResponseFromBytes([]byte(`{"author":"@x"}`))
+	require.NoError(t, err)
+	require.Equal(t, []byte(`{"author":"@x"}`), response.RawMessage().Bytes())
+
+	_, err = messages.NewGetResponseFromBytes(nil)
+	require.Error(t, err)
+}
@@ -0,0 +1,138 @@
+package messages
+
+import (
+	"time"
+
+	"github.com/boreq/errors"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc"
+)
+
+var (
+	GossipPingProcedure = rpc.MustNewProcedure(
+		rpc.MustNewProcedureName([]string{"gossip", "ping"}),
+		rpc.ProcedureTypeDuplex,
+	)
+)
+
+func NewGossipPing(arguments GossipPingArguments) (*rpc.Request, error) {
+	j, err := arguments.MarshalJSON()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal arguments")
+	}
+
+	return rpc.NewRequest(
+		GossipPingProcedure.Name(),
+		GossipPingProcedure.Typ(),
+		j,
+	)
+}
+
+type GossipPingArguments struct {
+	timeout time.Duration
+}
+
+// NewGossipPingArguments creates new arguments. Timeout tells the remote how
+// long it should wait for the next ping before giving up. Zero value of
+// timeout means that the remote should use its default value.
+func NewGossipPingArguments(timeout time.Duration) (GossipPingArguments, error) {
+	if timeout < 0 {
+		return GossipPingArguments{}, errors.New("negative timeout")
+	}
+
+	return GossipPingArguments{
+		timeout: timeout,
+	}, nil
+}
+
+func MustNewGossipPingArguments(timeout time.Duration) GossipPingArguments {
+	v, err := NewGossipPingArguments(timeout)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+func NewGossipPingArgumentsFromBytes(b []byte) (GossipPingArguments, error) {
+	var args []gossipPingArgumentsTransport
+
+	if err := jsoniter.Unmarshal(b, &args); err != nil {
+		return GossipPingArguments{}, errors.Wrap(err, "json unmarshal failed")
+	}
+
+	if len(args) > 1 {
+		return GossipPingArguments{}, errors.New("expected at most one argument")
+	}
+
+	if len(args) == 0 || args[0].Timeout == nil {
+		return NewGossipPingArguments(0)
+	}
+
+	return NewGossipPingArguments(time.Duration(*args[0].Timeout) * time.Millisecond)
+}
+
+// Timeout returns the timeout requested by the remote. Zero value means that
+// the timeout wasn't specified.
+func (a GossipPingArguments) Timeout() time.Duration {
+	return a.timeout
+}
+
+func (a GossipPingArguments) MarshalJSON() ([]byte, error) {
+	transport := []gossipPingArgumentsTransport{
+		{},
+	}
+
+	if a.timeout > 0 {
+		timeout := a.timeout.Milliseconds()
+		transport[0].Timeout = &timeout
+	}
+
+	return jsoniter.Marshal(transport)
+}
+
+type gossipPingArgumentsTransport struct {
+	Timeout *int64 `json:"timeout,omitempty"`
+}
+
+// GossipPingTimestamp is sent by both sides of a gossip.ping stream. The
+// client periodically sends its current time and the server replies to each
+// of those messages with its own current time.
+type GossipPingTimestamp struct {
+	t time.Time
+}
+
+func NewGossipPingTimestamp(t time.Time) (GossipPingTimestamp, error) {
+	if t.IsZero() {
+		return GossipPingTimestamp{}, errors.New("zero value of time")
+	}
+
+	return GossipPingTimestamp{
+		t: t,
+	}, nil
+}
+
+func MustNewGossipPingTimestamp(t time.Time) GossipPingTimestamp {
+	v, err := NewGossipPingTimestamp(t)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+func NewGossipPingTimestampFromBytes(b []byte) (GossipPingTimestamp, error) {
+	var milliseconds float64
+
+	if err := jsoniter.Unmarshal(b, &milliseconds); err != nil {
+		return GossipPingTimestamp{}, errors.Wrap(err, "json unmarshal failed")
+	}
+
+	return NewGossipPingTimestamp(time.UnixMilli(int64(milliseconds)))
+}
+
+func (t GossipPingTimestamp) Time() time.Time {
+	return t.t
+}
+
+func (t GossipPingTimestamp) MarshalJSON() ([]byte, error) {
+	return jsoniter.Marshal(t.t.UnixMilli())
+}
@@ -0,0 +1,104 @@
+package messages_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/planetary-social/scuttlego/service/domain/messages"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewGossipPing(t *testing.T) {
+	testCases := []struct {
+		Name              string
+		Timeout           time.Duration
+		ExpectedArguments string
+	}{
+		{
+			Name:              "with_timeout",
+			Timeout:           5 * time.Second,
+			ExpectedArguments: `[{"timeout":5000}]`,
+		},
+		{
+			Name:              "without_timeout",
+			Timeout:           0,
+			ExpectedArguments: `[{}]`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			req, err := messages.NewGossipPing(messages.MustNewGossipPingArguments(testCase.Timeout))
+			require.NoError(t, err)
+
+			require.Equal(t, rpc.MustNewProcedureName([]string{"gossip", "ping"}), req.Name())
+			require.Equal(t, rpc.ProcedureTypeDuplex, req.Type())
+			require.Equal(t, testCase.ExpectedArguments, string(req.Arguments()))
+		})
+	}
+}
+
+func TestNewGossipPingArgumentsFromBytes(t *testing.T) {
+	testCases := []struct {
+		Name            string
+		Arguments       string
+		ExpectedTimeout time.Duration
+		ExpectedError   bool
+	}{
+		{
+			Name:            "timeout",
+			Arguments:       `[{"timeout": 300000}]`,
+			ExpectedTimeout: 5 * time.Minute,
+		},
+		{
+			Name:            "empty_object",
+			Arguments:       `[{}]`,
+			ExpectedTimeout: 0,
+		},
+		{
+			Name:            "no_arguments",
+			Arguments:       `[]`,
+			ExpectedTimeout: 0,
+		},
+		{
+			Name:          "negative_timeout",
+			Arguments:     `[{"timeout": -1}]`,
+			ExpectedError: true,
+		},
+		{
+			Name:          "too_many_arguments",
+			Arguments:     `[{}, {}]`,
+			ExpectedError: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			args, err := messages.NewGossipPingArgumentsFromBytes([]byte(testCase.Arguments))
+			if testCase.ExpectedError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, testCase.ExpectedTimeout, args.Timeout())
+		})
+	}
+}
+
+func TestGossipPingTimestamp_MarshalAndUnmarshal(t *testing.T) {
+	now := time.UnixMilli(time.Now().UnixMilli())
+
+	b, err := messages.MustNewGossipPingTimestamp(now).MarshalJSON()
+	require.NoError(t, err)
+
+	timestamp, err := messages.NewGossipPingTimestampFromBytes(b)
+	require.NoError(t, err)
+	require.True(t, now.Equal(timestamp.Time()))
+}
+
+func TestNewGossipPingTimestampFromBytes_AcceptsFractionalValues(t *testing.T) {
+	timestamp, err := messages.NewGossipPingTimestampFromBytes([]byte(`1670000000000.123`))
+	require.NoError(t, err)
+	require.True(t, time.UnixMilli(1670000000000).Equal(timestamp.Time()))
+}
@@ -0,0 +1,86 @@
+package messages
+
+import (
+	"github.com/boreq/errors"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/planetary-social/scuttlego/service/domain/rooms/httpauth"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc"
+)
+
+var (
+	HttpAuthRequestSolutionProcedure = rpc.MustNewProcedure(
+		rpc.MustNewProcedureName([]string{"httpAuth", "requestSolution"}),
+		rpc.ProcedureTypeAsync,
+	)
+)
+
+func NewHttpAuthRequestSolution(arguments HttpAuthRequestSolutionArguments) (*rpc.Request, error) {
+	j, err := arguments.MarshalJSON()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal arguments")
+	}
+
+	return rpc.NewRequest(
+		HttpAuthRequestSolutionProcedure.Name(),
+		HttpAuthRequestSolutionProcedure.Typ(),
+		j,
+	)
+}
+
+type HttpAuthRequestSolutionArguments struct {
+	sc httpauth.Challenge
+	cc httpauth.Challenge
+}
+
+func NewHttpAuthRequestSolutionArguments(sc, cc httpauth.Challenge) (HttpAuthRequestSolutionArguments, error) {
+	if sc.IsZero() {
+		return HttpAuthRequestSolutionArguments{}, errors.New("zero value of sc")
+	}
+
+	if cc.IsZero() {
+		return HttpAuthRequestSolutionArguments{}, errors.New("zero value of cc")
+	}
+
+	return HttpAuthRequestSolutionArguments{
+		sc: sc,
+		cc: cc,
+	}, nil
+}
+
+func NewHttpAuthRequestSolutionArgumentsFromBytes(b []byte) (HttpAuthRequestSolutionArguments, error) {
+	var args []string
+	if err := jsoniter.Unmarshal(b, &args); err != nil {
+		return HttpAuthRequestSolutionArguments{}, errors.Wrap(err, "json unmarshal failed")
+	}
+
+	if len(args) != 2 {
+		return HttpAuthRequestSolutionArguments{}, errors.New("expected exactly two arguments")
+	}
+
+	sc, err := httpauth.NewChallengeFromString(args[0])
+	if err != nil {
+		return HttpAuthRequestSolutionArguments{}, errors.Wrap(err, "error creating sc")
+	}
+
+	cc, err := httpauth.NewChallengeFromString(args[1])
+	if err != nil {
+		return HttpAuthRequestSolutionArguments{}, errors.Wrap(err, "error creating cc")
+	}
+
+	return NewHttpAuthRequestSolutionArguments(sc, cc)
+}
+
+func (a HttpAuthRequestSolutionArguments) Sc() httpauth.Challenge {
+	return a.sc
+}
+
+func (a HttpAuthRequestSolutionArguments) Cc() httpauth.Challenge {
+	return a.cc
+}
+
+func (a HttpAuthRequestSolutionArguments) MarshalJSON() ([]byte, error) {
+	return jsoniter.Marshal([]string{
+		a.sc.String(),
+		a.cc.String(),
+	})
+}
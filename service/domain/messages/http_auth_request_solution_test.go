@@ -0,0 +1,29 @@
+package messages_test
+
+import (
+	"testing"
+
+	"github.com/planetary-social/scuttlego/service/domain/messages"
+	"github.com/planetary-social/scuttlego/service/domain/rooms/httpauth"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewHttpAuthRequestSolution(t *testing.T) {
+	sc := httpauth.MustNewChallenge()
+	cc := httpauth.MustNewChallenge()
+
+	args, err := messages.NewHttpAuthRequestSolutionArguments(sc, cc)
+	require.NoError(t, err)
+
+	req, err := messages.NewHttpAuthRequestSolution(args)
+	require.NoError(t, err)
+	require.Equal(t, rpc.ProcedureTypeAsync, req.Type())
+	require.Equal(t, rpc.MustNewProcedureName([]string{"httpAuth", "requestSolution"}), req.Name())
+	require.JSONEq(t, `["`+sc.String()+`", "`+cc.String()+`"]`, string(req.Arguments()))
+
+	argsFromBytes, err := messages.NewHttpAuthRequestSolutionArgumentsFromBytes(req.Arguments())
+	require.NoError(t, err)
+	require.Equal(t, sc, argsFromBytes.Sc())
+	require.Equal(t, cc, argsFromBytes.Cc())
+}
@@ -0,0 +1,62 @@
+package messages
+
+import (
+	"github.com/boreq/errors"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/planetary-social/scuttlego/service/domain/rooms/httpauth"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc"
+)
+
+var (
+	HttpAuthSendSolutionProcedure = rpc.MustNewProcedure(
+		rpc.MustNewProcedureName([]string{"httpAuth", "sendSolution"}),
+		rpc.ProcedureTypeAsync,
+	)
+)
+
+func NewHttpAuthSendSolution(arguments HttpAuthSendSolutionArguments) (*rpc.Request, error) {
+	j, err := arguments.MarshalJSON()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal arguments")
+	}
+
+	return rpc.NewRequest(
+		HttpAuthSendSolutionProcedure.Name(),
+		HttpAuthSendSolutionProcedure.Typ(),
+		j,
+	)
+}
+
+type HttpAuthSendSolutionArguments struct {
+	sc  httpauth.Challenge
+	cc  httpauth.Challenge
+	sol httpauth.Solution
+}
+
+func NewHttpAuthSendSolutionArguments(sc, cc httpauth.Challenge, sol httpauth.Solution) (HttpAuthSendSolutionArguments, error) {
+	if sc.IsZero() {
+		return HttpAuthSendSolutionArguments{}, errors.New("zero value of sc")
+	}
+
+	if cc.IsZero() {
+		return HttpAuthSendSolutionArguments{}, errors.New("zero value of cc")
+	}
+
+	if sol.IsZero() {
+		return HttpAuthSendSolutionArguments{}, errors.New("zero value of sol")
+	}
+
+	return HttpAuthSendSolutionArguments{
+		sc:  sc,
+		cc:  cc,
+		sol: sol,
+	}, nil
+}
+
+func (a HttpAuthSendSolutionArguments) MarshalJSON() ([]byte, error) {
+	return jsoniter.Marshal([]string{
+		a.sc.String(),
+		a.cc.String(),
+		a.sol.String(),
+	})
+}
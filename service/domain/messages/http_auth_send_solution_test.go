@@ -0,0 +1,33 @@
+package messages_test
+
+import (
+	"testing"
+
+	"github.com/planetary-social/scuttlego/internal/fixtures"
+	"github.com/planetary-social/scuttlego/service/domain/messages"
+	"github.com/planetary-social/scuttlego/service/domain/refs"
+	"github.com/planetary-social/scuttlego/service/domain/rooms/httpauth"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewHttpAuthSendSolution(t *testing.T) {
+	client := fixtures.SomePrivateIdentity()
+	sc := httpauth.MustNewChallenge()
+	cc := httpauth.MustNewChallenge()
+
+	msg, err := httpauth.NewSignInMessage(fixtures.SomeRefIdentity(), refs.MustNewIdentityFromPublic(client.Public()), sc, cc)
+	require.NoError(t, err)
+
+	sol, err := httpauth.NewSolution(msg, client)
+	require.NoError(t, err)
+
+	args, err := messages.NewHttpAuthSendSolutionArguments(sc, cc, sol)
+	require.NoError(t, err)
+
+	req, err := messages.NewHttpAuthSendSolution(args)
+	require.NoError(t, err)
+	require.Equal(t, rpc.ProcedureTypeAsync, req.Type())
+	require.Equal(t, rpc.MustNewProcedureName([]string{"httpAuth", "sendSolution"}), req.Name())
+	require.JSONEq(t, `["`+sc.String()+`", "`+cc.String()+`", "`+sol.String()+`"]`, string(req.Arguments()))
+}
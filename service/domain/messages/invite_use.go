@@ -57,6 +57,10 @@ func NewInviteUseArgumentsFromBytes(b []byte) (InviteUseArguments, error) {
 	return NewInviteUseArguments(feed)
 }
 
+func (i InviteUseArguments) Feed() refs.Identity {
+	return i.feed
+}
+
 func (i InviteUseArguments) MarshalJSON() ([]byte, error) {
 	return jsoniter.Marshal([]inviteUseArgumentsTransport{
 		{
@@ -0,0 +1,35 @@
+package messages
+
+import (
+	"encoding/json"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/planetary-social/scuttlego/service/domain/feeds/message"
+)
+
+// KeyValueTimestamp is the format in which ssb-server returns messages e.g.
+// {"key": "%id.sha256", "value": {...}, "timestamp": 1514517067954}. In
+// ssb-server the timestamp is the time at which the message was received.
+// Scuttlego doesn't record it so the timestamp claimed by the author of the
+// message is used instead.
+type KeyValueTimestamp struct {
+	msg message.Message
+}
+
+func NewKeyValueTimestamp(msg message.Message) KeyValueTimestamp {
+	return KeyValueTimestamp{msg: msg}
+}
+
+func (k KeyValueTimestamp) MarshalJSON() ([]byte, error) {
+	return jsoniter.Marshal(keyValueTimestampTransport{
+		Key:       k.msg.Id().String(),
+		Value:     k.msg.Raw().Bytes(),
+		Timestamp: k.msg.Timestamp().UnixMilli(),
+	})
+}
+
+type keyValueTimestampTransport struct {
+	Key       string          `json:"key"`
+	Value     json.RawMessage `json:"value"`
+	Timestamp int64           `json:"timestamp"`
+}
@@ -0,0 +1,109 @@
+package messages
+
+import (
+	"github.com/boreq/errors"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/planetary-social/scuttlego/service/domain/feeds/message"
+	"github.com/planetary-social/scuttlego/service/domain/refs"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc"
+)
+
+var (
+	LatestSequenceProcedure = rpc.MustNewProcedure(
+		rpc.MustNewProcedureName([]string{"latestSequence"}),
+		rpc.ProcedureTypeAsync,
+	)
+)
+
+func NewLatestSequence(arguments LatestSequenceArguments) (*rpc.Request, error) {
+	j, err := arguments.MarshalJSON()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal arguments")
+	}
+
+	return rpc.NewRequest(
+		LatestSequenceProcedure.Name(),
+		LatestSequenceProcedure.Typ(),
+		j,
+	)
+}
+
+type LatestSequenceArguments struct {
+	id refs.Feed
+}
+
+func NewLatestSequenceArguments(id refs.Feed) (LatestSequenceArguments, error) {
+	if id.IsZero() {
+		return LatestSequenceArguments{}, errors.New("zero value of id")
+	}
+
+	return LatestSequenceArguments{
+		id: id,
+	}, nil
+}
+
+func NewLatestSequenceArgumentsFromBytes(b []byte) (LatestSequenceArguments, error) {
+	var args []string
+
+	if err := jsoniter.Unmarshal(b, &args); err != nil {
+		return LatestSequenceArguments{}, errors.Wrap(err, "json unmarshal failed")
+	}
+
+	if len(args) != 1 {
+		return LatestSequenceArguments{}, errors.New("expected exactly one argument")
+	}
+
+	id, err := refs.NewFeed(args[0])
+	if err != nil {
+		return LatestSequenceArguments{}, errors.Wrap(err, "could not create a feed ref")
+	}
+
+	return NewLatestSequenceArguments(id)
+}
+
+func (a LatestSequenceArguments) Id() refs.Feed {
+	return a.id
+}
+
+func (a LatestSequenceArguments) MarshalJSON() ([]byte, error) {
+	return jsoniter.Marshal([]string{a.id.String()})
+}
+
+// LatestSequenceResponse contains the sequence of the latest message of the
+// requested feed.
+type LatestSequenceResponse struct {
+	sequence message.Sequence
+}
+
+func NewLatestSequenceResponse(sequence message.Sequence) (LatestSequenceResponse, error) {
+	if sequence.IsZero() {
+		return LatestSequenceResponse{}, errors.New("zero value of sequence")
+	}
+
+	return LatestSequenceResponse{
+		sequence: sequence,
+	}, nil
+}
+
+func NewLatestSequenceResponseFromBytes(b []byte) (LatestSequenceResponse, error) {
+	var v int
+
+	if err := jsoniter.Unmarshal(b, &v); err != nil {
+		return LatestSequenceResponse{}, errors.Wrap(err, "json unmarshal failed")
+	}
+
+	sequence, err := message.NewSequence(v)
+	if err != nil {
+		return LatestSequenceResponse{}, errors.Wrap(err, "could not create a sequence")
+	}
+
+	return NewLatestSequenceResponse(sequence)
+}
+
+func (r LatestSequenceResponse) Sequence() message.Sequence {
+	return r.sequence
+}
+
+func (r LatestSequenceResponse) MarshalJSON() ([]byte, error) {
+	return jsoniter.Marshal(r.sequence.Int())
+}
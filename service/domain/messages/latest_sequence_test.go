@@ -0,0 +1,38 @@
+package messages_test
+
+import (
+	"testing"
+
+	"github.com/planetary-social/scuttlego/service/domain/feeds/message"
+	"github.com/planetary-social/scuttlego/service/domain/messages"
+	"github.com/planetary-social/scuttlego/service/domain/refs"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLatestSequenceArguments(t *testing.T) {
+	id := refs.MustNewFeed("@qFtLJ6P5Eh9vKxnj7Rsh8SkE6B6Z36DVLP7ZOKNeQ/Y=.ed25519")
+
+	args, err := messages.NewLatestSequenceArguments(id)
+	require.NoError(t, err)
+
+	j, err := args.MarshalJSON()
+	require.NoError(t, err)
+	require.Equal(t, `["@qFtLJ6P5Eh9vKxnj7Rsh8SkE6B6Z36DVLP7ZOKNeQ/Y=.ed25519"]`, string(j))
+
+	unmarshaled, err := messages.NewLatestSequenceArgumentsFromBytes(j)
+	require.NoError(t, err)
+	require.Equal(t, id, unmarshaled.Id())
+}
+
+func TestLatestSequenceResponse_MarshalJSON(t *testing.T) {
+	response, err := messages.NewLatestSequenceResponse(message.MustNewSequence(123))
+	require.NoError(t, err)
+
+	j, err := response.MarshalJSON()
+	require.NoError(t, err)
+	require.Equal(t, `123`, string(j))
+
+	unmarshaled, err := messages.NewLatestSequenceResponseFromBytes(j)
+	require.NoError(t, err)
+	require.Equal(t, response, unmarshaled)
+}
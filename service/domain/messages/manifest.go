@@ -0,0 +1,200 @@
+package messages
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/boreq/errors"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc"
+)
+
+var (
+	ManifestProcedure = rpc.MustNewProcedure(
+		rpc.MustNewProcedureName([]string{"manifest"}),
+		rpc.ProcedureTypeAsync,
+	)
+)
+
+func NewManifest() (*rpc.Request, error) {
+	return rpc.NewRequest(
+		ManifestProcedure.Name(),
+		ManifestProcedure.Typ(),
+		[]byte("[]"),
+	)
+}
+
+// ManifestResponse describes procedures supported by a peer. Procedure names
+// are encoded as nested objects e.g. procedure "blobs.get" of type "source" is
+// encoded as {"blobs": {"get": "source"}}.
+type ManifestResponse struct {
+	procedures []rpc.Procedure
+}
+
+func NewManifestResponse(procedures []rpc.Procedure) (ManifestResponse, error) {
+	for _, procedure := range procedures {
+		if procedure.Name().IsZero() {
+			return ManifestResponse{}, errors.New("zero value of procedure")
+		}
+	}
+
+	tmp := make([]rpc.Procedure, len(procedures))
+	copy(tmp, procedures)
+
+	sort.Slice(tmp, func(i, j int) bool {
+		return tmp[i].Name().String() < tmp[j].Name().String()
+	})
+
+	response := ManifestResponse{
+		procedures: tmp,
+	}
+
+	if _, err := response.toTransport(); err != nil {
+		return ManifestResponse{}, errors.Wrap(err, "procedures can't be represented as a manifest")
+	}
+
+	return response, nil
+}
+
+func MustNewManifestResponse(procedures []rpc.Procedure) ManifestResponse {
+	v, err := NewManifestResponse(procedures)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// NewManifestResponseFromBytes parses a manifest. Procedures of types which
+// aren't supported by this implementation are skipped.
+func NewManifestResponseFromBytes(b []byte) (ManifestResponse, error) {
+	var transport map[string]any
+
+	if err := jsoniter.Unmarshal(b, &transport); err != nil {
+		return ManifestResponse{}, errors.Wrap(err, "json unmarshal failed")
+	}
+
+	var procedures []rpc.Procedure
+	if err := fromManifestTransport(nil, transport, &procedures); err != nil {
+		return ManifestResponse{}, errors.Wrap(err, "error decoding the manifest")
+	}
+
+	return NewManifestResponse(procedures)
+}
+
+func (r ManifestResponse) Procedures() []rpc.Procedure {
+	tmp := make([]rpc.Procedure, len(r.procedures))
+	copy(tmp, r.procedures)
+	return tmp
+}
+
+func (r ManifestResponse) MarshalJSON() ([]byte, error) {
+	transport, err := r.toTransport()
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating the transport")
+	}
+	return jsoniter.Marshal(transport)
+}
+
+func (r ManifestResponse) toTransport() (map[string]any, error) {
+	transport := make(map[string]any)
+
+	for _, procedure := range r.procedures {
+		typ, err := encodeManifestProcedureType(procedure.Typ())
+		if err != nil {
+			return nil, errors.Wrapf(err, "error encoding type of procedure '%s'", procedure.Name().String())
+		}
+
+		components := procedure.Name().Components()
+
+		current := transport
+		for _, component := range components[:len(components)-1] {
+			v, ok := current[component]
+			if !ok {
+				next := make(map[string]any)
+				current[component] = next
+				current = next
+				continue
+			}
+
+			next, ok := v.(map[string]any)
+			if !ok {
+				return nil, fmt.Errorf("procedure '%s' conflicts with another procedure", procedure.Name().String())
+			}
+			current = next
+		}
+
+		last := components[len(components)-1]
+		if _, ok := current[last]; ok {
+			return nil, fmt.Errorf("procedure '%s' conflicts with another procedure", procedure.Name().String())
+		}
+		current[last] = typ
+	}
+
+	return transport, nil
+}
+
+func fromManifestTransport(prefix []string, transport map[string]any, procedures *[]rpc.Procedure) error {
+	for key, value := range transport {
+		components := make([]string, len(prefix), len(prefix)+1)
+		copy(components, prefix)
+		components = append(components, key)
+
+		switch v := value.(type) {
+		case map[string]any:
+			if err := fromManifestTransport(components, v, procedures); err != nil {
+				return errors.Wrap(err, "error decoding a nested object")
+			}
+		case string:
+			typ, ok := decodeManifestProcedureType(v)
+			if !ok {
+				continue
+			}
+
+			name, err := rpc.NewProcedureName(components)
+			if err != nil {
+				return errors.Wrap(err, "error creating the procedure name")
+			}
+
+			procedure, err := rpc.NewProcedure(name, typ)
+			if err != nil {
+				return errors.Wrap(err, "error creating the procedure")
+			}
+
+			*procedures = append(*procedures, procedure)
+		default:
+			return fmt.Errorf("unexpected value for key '%s'", key)
+		}
+	}
+
+	return nil
+}
+
+func encodeManifestProcedureType(typ rpc.ProcedureType) (string, error) {
+	switch typ {
+	case rpc.ProcedureTypeAsync:
+		return "async", nil
+	case rpc.ProcedureTypeSource:
+		return "source", nil
+	case rpc.ProcedureTypeDuplex:
+		return "duplex", nil
+	case rpc.ProcedureTypeSink:
+		return "sink", nil
+	default:
+		return "", errors.New("unsupported procedure type")
+	}
+}
+
+func decodeManifestProcedureType(s string) (rpc.ProcedureType, bool) {
+	switch s {
+	case "async", "sync":
+		return rpc.ProcedureTypeAsync, true
+	case "source":
+		return rpc.ProcedureTypeSource, true
+	case "duplex":
+		return rpc.ProcedureTypeDuplex, true
+	case "sink":
+		return rpc.ProcedureTypeSink, true
+	default:
+		return rpc.ProcedureType{}, false
+	}
+}
@@ -0,0 +1,105 @@
+package messages_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/planetary-social/scuttlego/service/domain/messages"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewManifest(t *testing.T) {
+	req, err := messages.NewManifest()
+	require.NoError(t, err)
+	require.Equal(t, rpc.ProcedureTypeAsync, req.Type())
+	require.Equal(t, rpc.MustNewProcedureName([]string{"manifest"}), req.Name())
+	require.Equal(t, json.RawMessage(`[]`), req.Arguments())
+}
+
+func TestManifestResponse_MarshalJSON(t *testing.T) {
+	response, err := messages.NewManifestResponse([]rpc.Procedure{
+		messages.BlobsGetProcedure,
+		messages.BlobsCreateWantsProcedure,
+		messages.EbtReplicateProcedure,
+		messages.CreateHistoryStreamProcedure,
+		messages.ManifestProcedure,
+	})
+	require.NoError(t, err)
+
+	j, err := response.MarshalJSON()
+	require.NoError(t, err)
+
+	require.JSONEq(t,
+		`{
+			"blobs": {
+				"get": "source",
+				"createWants": "source"
+			},
+			"ebt": {
+				"replicate": "duplex"
+			},
+			"createHistoryStream": "source",
+			"manifest": "async"
+		}`,
+		string(j),
+	)
+}
+
+func TestNewManifestResponse_ReturnsAnErrorIfProceduresConflict(t *testing.T) {
+	testCases := []struct {
+		Name       string
+		Procedures []rpc.Procedure
+	}{
+		{
+			Name: "duplicate",
+			Procedures: []rpc.Procedure{
+				messages.BlobsGetProcedure,
+				messages.BlobsGetProcedure,
+			},
+		},
+		{
+			Name: "procedure_and_namespace",
+			Procedures: []rpc.Procedure{
+				rpc.MustNewProcedure(rpc.MustNewProcedureName([]string{"blobs"}), rpc.ProcedureTypeAsync),
+				messages.BlobsGetProcedure,
+			},
+		},
+		{
+			Name: "unsupported_type",
+			Procedures: []rpc.Procedure{
+				rpc.MustNewProcedure(rpc.MustNewProcedureName([]string{"something"}), rpc.ProcedureTypeUnknown),
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			_, err := messages.NewManifestResponse(testCase.Procedures)
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestNewManifestResponseFromBytes(t *testing.T) {
+	response, err := messages.NewManifestResponseFromBytes([]byte(`{
+		"whoami": "sync",
+		"blobs": {
+			"get": "source"
+		},
+		"ebt": {
+			"replicate": "duplex"
+		},
+		"somethingElse": "unknowntype"
+	}`))
+	require.NoError(t, err)
+
+	require.Equal(t,
+		[]rpc.Procedure{
+			messages.BlobsGetProcedure,
+			messages.EbtReplicateProcedure,
+			messages.WhoamiProcedure,
+		},
+		response.Procedures(),
+	)
+}
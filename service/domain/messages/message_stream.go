@@ -0,0 +1,158 @@
+package messages
+
+import (
+	"time"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/planetary-social/scuttlego/service/domain/feeds/message"
+)
+
+// MessageStreamOptions control what is returned for each message by streams
+// such as createLogStream. Both keys and values are returned by default.
+type MessageStreamOptions struct {
+	keys   bool
+	values bool
+}
+
+func NewMessageStreamOptions(keys, values bool) MessageStreamOptions {
+	return MessageStreamOptions{
+		keys:   keys,
+		values: values,
+	}
+}
+
+func (o MessageStreamOptions) Keys() bool {
+	return o.keys
+}
+
+func (o MessageStreamOptions) Values() bool {
+	return o.values
+}
+
+// MessageStreamResponse is a single message returned by a stream. Depending
+// on the options it is a KeyValueTimestamp, just the key or just the value.
+type MessageStreamResponse struct {
+	msg     message.Message
+	options MessageStreamOptions
+}
+
+func NewMessageStreamResponse(msg message.Message, options MessageStreamOptions) MessageStreamResponse {
+	return MessageStreamResponse{
+		msg:     msg,
+		options: options,
+	}
+}
+
+func (r MessageStreamResponse) MarshalJSON() ([]byte, error) {
+	switch {
+	case r.options.keys && r.options.values:
+		return NewKeyValueTimestamp(r.msg).MarshalJSON()
+	case r.options.keys:
+		return jsoniter.Marshal(r.msg.Id().String())
+	default:
+		return r.msg.Raw().Bytes(), nil
+	}
+}
+
+// MessageStreamMode controls which messages are returned by streams such as
+// createLogStream. By default only the messages which are already stored are
+// returned, oldest first.
+type MessageStreamMode struct {
+	live    bool
+	old     bool
+	reverse bool
+}
+
+func NewMessageStreamMode(live, old, reverse bool) MessageStreamMode {
+	return MessageStreamMode{
+		live:    live,
+		old:     old,
+		reverse: reverse,
+	}
+}
+
+func NewDefaultMessageStreamMode() MessageStreamMode {
+	return NewMessageStreamMode(defaultLive, defaultOld, defaultReverse)
+}
+
+// Live is true if the stream should stay open and return new messages as they
+// are stored.
+func (m MessageStreamMode) Live() bool {
+	return m.live
+}
+
+// Old is true if the messages which are already stored should be returned.
+func (m MessageStreamMode) Old() bool {
+	return m.old
+}
+
+// Reverse is true if the messages which are already stored should be returned
+// newest first. Live messages are always returned as they arrive.
+func (m MessageStreamMode) Reverse() bool {
+	return m.reverse
+}
+
+type messageStreamModeTransport struct {
+	Live    *bool `json:"live,omitempty"`
+	Old     *bool `json:"old,omitempty"`
+	Reverse *bool `json:"reverse,omitempty"`
+}
+
+func (t messageStreamModeTransport) mode() MessageStreamMode {
+	return NewMessageStreamMode(
+		valueOrDefault(t.Live, defaultLive),
+		valueOrDefault(t.Old, defaultOld),
+		valueOrDefault(t.Reverse, defaultReverse),
+	)
+}
+
+func newMessageStreamModeTransport(m MessageStreamMode) messageStreamModeTransport {
+	return messageStreamModeTransport{
+		Live:    nilIfDefault(m.live, defaultLive),
+		Old:     nilIfDefault(m.old, defaultOld),
+		Reverse: nilIfDefault(m.reverse, defaultReverse),
+	}
+}
+
+type messageStreamOptionsTransport struct {
+	Keys   *bool `json:"keys,omitempty"`
+	Values *bool `json:"values,omitempty"`
+}
+
+func (t messageStreamOptionsTransport) options() MessageStreamOptions {
+	return NewMessageStreamOptions(
+		valueOrDefault(t.Keys, true),
+		valueOrDefault(t.Values, true),
+	)
+}
+
+func newMessageStreamOptionsTransport(o MessageStreamOptions) messageStreamOptionsTransport {
+	return messageStreamOptionsTransport{
+		Keys:   nilIfDefault(o.keys, true),
+		Values: nilIfDefault(o.values, true),
+	}
+}
+
+// limitFromTransport converts limits used by ssb-server where negative values
+// mean that the number of returned messages is unlimited.
+func limitFromTransport(limit *int) *int {
+	if limit == nil || *limit < 0 {
+		return nil
+	}
+	return limit
+}
+
+// timestampFromTransport converts timestamps in milliseconds used by
+// ssb-server.
+func timestampFromTransport(v float64) *time.Time {
+	t := time.UnixMilli(int64(v))
+	return &t
+}
+
+func timestampToTransport(t *time.Time) *float64 {
+	if t == nil {
+		return nil
+	}
+	v := float64(t.UnixMilli())
+	return &v
+}
@@ -0,0 +1,74 @@
+package messages_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/planetary-social/scuttlego/service/domain/feeds/message"
+	"github.com/planetary-social/scuttlego/service/domain/messages"
+	"github.com/planetary-social/scuttlego/service/domain/refs"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMessageStreamResponse_MarshalJSON(t *testing.T) {
+	msg := message.MustNewMessage(
+		refs.MustNewMessage("%uaGieSQDJcHfUp6hjIcIq55GoZh4Ug7tNmgaohoxrpw=.sha256"),
+		nil,
+		message.NewFirstSequence(),
+		refs.MustNewIdentity("@qFtLJ6P5Eh9vKxnj7Rsh8SkE6B6Z36DVLP7ZOKNeQ/Y=.ed25519"),
+		refs.MustNewFeed("@qFtLJ6P5Eh9vKxnj7Rsh8SkE6B6Z36DVLP7ZOKNeQ/Y=.ed25519"),
+		time.UnixMilli(1514517067954),
+		message.MustNewContent(message.MustNewRawContent([]byte(`{"type":"post"}`)), nil, nil),
+		message.MustNewRawMessage([]byte(`{"content":{"type":"post"}}`)),
+	)
+
+	testCases := []struct {
+		Name     string
+		Options  messages.MessageStreamOptions
+		Expected string
+	}{
+		{
+			Name:     "keys_and_values",
+			Options:  messages.NewMessageStreamOptions(true, true),
+			Expected: `{"key":"%uaGieSQDJcHfUp6hjIcIq55GoZh4Ug7tNmgaohoxrpw=.sha256","value":{"content":{"type":"post"}},"timestamp":1514517067954}`,
+		},
+		{
+			Name:     "keys",
+			Options:  messages.NewMessageStreamOptions(true, false),
+			Expected: `"%uaGieSQDJcHfUp6hjIcIq55GoZh4Ug7tNmgaohoxrpw=.sha256"`,
+		},
+		{
+			Name:     "values",
+			Options:  messages.NewMessageStreamOptions(false, true),
+			Expected: `{"content":{"type":"post"}}`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			j, err := messages.NewMessageStreamResponse(msg, testCase.Options).MarshalJSON()
+			require.NoError(t, err)
+			require.Equal(t, testCase.Expected, string(j))
+		})
+	}
+}
+
+func TestKeyValueTimestamp_MarshalJSON(t *testing.T) {
+	msg := message.MustNewMessage(
+		refs.MustNewMessage("%uaGieSQDJcHfUp6hjIcIq55GoZh4Ug7tNmgaohoxrpw=.sha256"),
+		nil,
+		message.NewFirstSequence(),
+		refs.MustNewIdentity("@qFtLJ6P5Eh9vKxnj7Rsh8SkE6B6Z36DVLP7ZOKNeQ/Y=.ed25519"),
+		refs.MustNewFeed("@qFtLJ6P5Eh9vKxnj7Rsh8SkE6B6Z36DVLP7ZOKNeQ/Y=.ed25519"),
+		time.UnixMilli(1514517067954),
+		message.MustNewContent(message.MustNewRawContent([]byte(`{"type":"post"}`)), nil, nil),
+		message.MustNewRawMessage([]byte(`{"content":{"type":"post"}}`)),
+	)
+
+	j, err := messages.NewKeyValueTimestamp(msg).MarshalJSON()
+	require.NoError(t, err)
+	require.Equal(t,
+		`{"key":"%uaGieSQDJcHfUp6hjIcIq55GoZh4Ug7tNmgaohoxrpw=.sha256","value":{"content":{"type":"post"}},"timestamp":1514517067954}`,
+		string(j),
+	)
+}
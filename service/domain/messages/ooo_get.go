@@ -0,0 +1,30 @@
+package messages
+
+import (
+	"github.com/boreq/errors"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc"
+)
+
+var (
+	// OooGetProcedure is used to retrieve a single message from a peer by
+	// its id without replicating the feed that the message belongs to.
+	// Unlike GetProcedure it is served to all peers. Arguments and the
+	// response are the same as for GetProcedure.
+	OooGetProcedure = rpc.MustNewProcedure(
+		rpc.MustNewProcedureName([]string{"ooo", "get"}),
+		rpc.ProcedureTypeAsync,
+	)
+)
+
+func NewOooGet(arguments GetArguments) (*rpc.Request, error) {
+	j, err := arguments.MarshalJSON()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal arguments")
+	}
+
+	return rpc.NewRequest(
+		OooGetProcedure.Name(),
+		OooGetProcedure.Typ(),
+		j,
+	)
+}
@@ -0,0 +1,20 @@
+package messages_test
+
+import (
+	"testing"
+
+	"github.com/planetary-social/scuttlego/service/domain/messages"
+	"github.com/planetary-social/scuttlego/service/domain/refs"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewOooGet(t *testing.T) {
+	args, err := messages.NewGetArguments(refs.MustNewMessage("%uaGieSQDJcHfUp6hjIcIq55GoZh4Ug7tNmgaohoxrpw=.sha256"))
+	require.NoError(t, err)
+
+	req, err := messages.NewOooGet(args)
+	require.NoError(t, err)
+	require.Equal(t, messages.OooGetProcedure.Name(), req.Name())
+	require.Equal(t, messages.OooGetProcedure.Typ(), req.Type())
+	require.Equal(t, `["%uaGieSQDJcHfUp6hjIcIq55GoZh4Ug7tNmgaohoxrpw=.sha256"]`, string(req.Arguments()))
+}
@@ -0,0 +1,19 @@
+package messages
+
+import (
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc"
+)
+
+var (
+	// PublishProcedure publishes a message using the identity of this node
+	// and returns it as a KeyValueTimestamp. Its arguments are the same as
+	// the ones of ScuttlegoPublishProcedure.
+	PublishProcedure = rpc.MustNewProcedure(
+		rpc.MustNewProcedureName([]string{"publish"}),
+		rpc.ProcedureTypeAsync,
+	)
+)
+
+func NewPublish(arguments ScuttlegoPublishArguments) (*rpc.Request, error) {
+	return newScuttlegoRequest(PublishProcedure, arguments)
+}
@@ -0,0 +1,119 @@
+package messages
+
+import (
+	"github.com/boreq/errors"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/planetary-social/scuttlego/service/domain/feeds/message"
+	"github.com/planetary-social/scuttlego/service/domain/refs"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc"
+)
+
+var (
+	ReplicateUptoProcedure = rpc.MustNewProcedure(
+		rpc.MustNewProcedureName([]string{"replicate", "upto"}),
+		rpc.ProcedureTypeSource,
+	)
+)
+
+func NewReplicateUpto(arguments ReplicateUptoArguments) (*rpc.Request, error) {
+	j, err := arguments.MarshalJSON()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal arguments")
+	}
+
+	return rpc.NewRequest(
+		ReplicateUptoProcedure.Name(),
+		ReplicateUptoProcedure.Typ(),
+		j,
+	)
+}
+
+// ReplicateUptoArguments represents the options passed to replicate.upto.
+// The options are currently ignored but other implementations send an empty
+// object or no arguments at all.
+type ReplicateUptoArguments struct {
+}
+
+func NewReplicateUptoArguments() ReplicateUptoArguments {
+	return ReplicateUptoArguments{}
+}
+
+func NewReplicateUptoArgumentsFromBytes(b []byte) (ReplicateUptoArguments, error) {
+	var args []jsoniter.RawMessage
+
+	if err := jsoniter.Unmarshal(b, &args); err != nil {
+		return ReplicateUptoArguments{}, errors.Wrap(err, "json unmarshal failed")
+	}
+
+	if len(args) > 1 {
+		return ReplicateUptoArguments{}, errors.New("expected at most one argument")
+	}
+
+	return NewReplicateUptoArguments(), nil
+}
+
+func (a ReplicateUptoArguments) MarshalJSON() ([]byte, error) {
+	return jsoniter.Marshal([]struct{}{{}})
+}
+
+// ReplicateUptoResponse is sent for each replicated feed and contains the
+// sequence of the latest message of that feed.
+type ReplicateUptoResponse struct {
+	id       refs.Feed
+	sequence message.Sequence
+}
+
+func NewReplicateUptoResponse(id refs.Feed, sequence message.Sequence) (ReplicateUptoResponse, error) {
+	if id.IsZero() {
+		return ReplicateUptoResponse{}, errors.New("zero value of id")
+	}
+
+	if sequence.IsZero() {
+		return ReplicateUptoResponse{}, errors.New("zero value of sequence")
+	}
+
+	return ReplicateUptoResponse{
+		id:       id,
+		sequence: sequence,
+	}, nil
+}
+
+func NewReplicateUptoResponseFromBytes(b []byte) (ReplicateUptoResponse, error) {
+	var transport replicateUptoResponseTransport
+
+	if err := jsoniter.Unmarshal(b, &transport); err != nil {
+		return ReplicateUptoResponse{}, errors.Wrap(err, "json unmarshal failed")
+	}
+
+	id, err := refs.NewFeed(transport.Id)
+	if err != nil {
+		return ReplicateUptoResponse{}, errors.Wrap(err, "could not create a feed ref")
+	}
+
+	sequence, err := message.NewSequence(transport.Sequence)
+	if err != nil {
+		return ReplicateUptoResponse{}, errors.Wrap(err, "could not create a sequence")
+	}
+
+	return NewReplicateUptoResponse(id, sequence)
+}
+
+func (r ReplicateUptoResponse) Id() refs.Feed {
+	return r.id
+}
+
+func (r ReplicateUptoResponse) Sequence() message.Sequence {
+	return r.sequence
+}
+
+func (r ReplicateUptoResponse) MarshalJSON() ([]byte, error) {
+	return jsoniter.Marshal(replicateUptoResponseTransport{
+		Id:       r.id.String(),
+		Sequence: r.sequence.Int(),
+	})
+}
+
+type replicateUptoResponseTransport struct {
+	Id       string `json:"id"`
+	Sequence int    `json:"sequence"`
+}
@@ -0,0 +1,64 @@
+package messages_test
+
+import (
+	"testing"
+
+	"github.com/planetary-social/scuttlego/service/domain/feeds/message"
+	"github.com/planetary-social/scuttlego/service/domain/messages"
+	"github.com/planetary-social/scuttlego/service/domain/refs"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewReplicateUptoArgumentsFromBytes(t *testing.T) {
+	testCases := []struct {
+		Name          string
+		Data          string
+		ExpectedError bool
+	}{
+		{
+			Name: "no_arguments",
+			Data: `[]`,
+		},
+		{
+			Name: "empty_object",
+			Data: `[{}]`,
+		},
+		{
+			Name:          "too_many_arguments",
+			Data:          `[{}, {}]`,
+			ExpectedError: true,
+		},
+		{
+			Name:          "not_an_array",
+			Data:          `{}`,
+			ExpectedError: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			_, err := messages.NewReplicateUptoArgumentsFromBytes([]byte(testCase.Data))
+			if testCase.ExpectedError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestReplicateUptoResponse_MarshalJSON(t *testing.T) {
+	response, err := messages.NewReplicateUptoResponse(
+		refs.MustNewFeed("@qFtLJ6P5Eh9vKxnj7Rsh8SkE6B6Z36DVLP7ZOKNeQ/Y=.ed25519"),
+		message.MustNewSequence(123),
+	)
+	require.NoError(t, err)
+
+	j, err := response.MarshalJSON()
+	require.NoError(t, err)
+	require.Equal(t, `{"id":"@qFtLJ6P5Eh9vKxnj7Rsh8SkE6B6Z36DVLP7ZOKNeQ/Y=.ed25519","sequence":123}`, string(j))
+
+	unmarshaled, err := messages.NewReplicateUptoResponseFromBytes(j)
+	require.NoError(t, err)
+	require.Equal(t, response, unmarshaled)
+}
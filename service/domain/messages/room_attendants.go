@@ -28,6 +28,25 @@ type RoomAttendantsResponseState struct {
 	ids []refs.Identity
 }
 
+func NewRoomAttendantsResponseState(ids []refs.Identity) (RoomAttendantsResponseState, error) {
+	for _, id := range ids {
+		if id.IsZero() {
+			return RoomAttendantsResponseState{}, errors.New("zero value of id")
+		}
+	}
+	return RoomAttendantsResponseState{
+		ids: ids,
+	}, nil
+}
+
+func MustNewRoomAttendantsResponseState(ids []refs.Identity) RoomAttendantsResponseState {
+	v, err := NewRoomAttendantsResponseState(ids)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
 func NewRoomAttendantsResponseStateFromBytes(b []byte) (RoomAttendantsResponseState, error) {
 	var transport roomAttendantsResponseStateTransport
 	if err := jsoniter.Unmarshal(b, &transport); err != nil {
@@ -58,11 +77,44 @@ func (r RoomAttendantsResponseState) Ids() []refs.Identity {
 	return r.ids
 }
 
+func (r RoomAttendantsResponseState) MarshalJSON() ([]byte, error) {
+	ids := make([]string, 0, len(r.ids))
+	for _, id := range r.ids {
+		ids = append(ids, id.String())
+	}
+
+	return jsoniter.Marshal(roomAttendantsResponseStateTransport{
+		Type: "state",
+		Ids:  ids,
+	})
+}
+
 type RoomAttendantsResponseJoinedOrLeft struct {
 	typ RoomAttendantsResponseType
 	id  refs.Identity
 }
 
+func NewRoomAttendantsResponseJoinedOrLeft(typ RoomAttendantsResponseType, id refs.Identity) (RoomAttendantsResponseJoinedOrLeft, error) {
+	if typ.IsZero() {
+		return RoomAttendantsResponseJoinedOrLeft{}, errors.New("zero value of type")
+	}
+	if id.IsZero() {
+		return RoomAttendantsResponseJoinedOrLeft{}, errors.New("zero value of id")
+	}
+	return RoomAttendantsResponseJoinedOrLeft{
+		typ: typ,
+		id:  id,
+	}, nil
+}
+
+func MustNewRoomAttendantsResponseJoinedOrLeft(typ RoomAttendantsResponseType, id refs.Identity) RoomAttendantsResponseJoinedOrLeft {
+	v, err := NewRoomAttendantsResponseJoinedOrLeft(typ, id)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
 func NewRoomAttendantsResponseJoinedOrLeftFromBytes(b []byte) (RoomAttendantsResponseJoinedOrLeft, error) {
 	var transport roomAttendantsResponseJoinedOrLeftTransport
 	if err := jsoniter.Unmarshal(b, &transport); err != nil {
@@ -93,6 +145,13 @@ func (r RoomAttendantsResponseJoinedOrLeft) Id() refs.Identity {
 	return r.id
 }
 
+func (r RoomAttendantsResponseJoinedOrLeft) MarshalJSON() ([]byte, error) {
+	return jsoniter.Marshal(roomAttendantsResponseJoinedOrLeftTransport{
+		Type: r.typ.s,
+		Id:   r.id.String(),
+	})
+}
+
 type roomAttendantsResponseStateTransport struct {
 	Type string   `json:"type"`
 	Ids  []string `json:"ids"`
@@ -107,6 +166,10 @@ type RoomAttendantsResponseType struct {
 	s string
 }
 
+func (t RoomAttendantsResponseType) IsZero() bool {
+	return t == RoomAttendantsResponseType{}
+}
+
 var (
 	RoomAttendantsResponseTypeJoined = RoomAttendantsResponseType{"joined"}
 	RoomAttendantsResponseTypeLeft   = RoomAttendantsResponseType{"left"}
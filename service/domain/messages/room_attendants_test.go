@@ -80,3 +80,49 @@ func TestNewRoomAttendantsResponseJoinedOrLeftFromBytes(t *testing.T) {
 		})
 	}
 }
+
+func TestRoomAttendantsResponseState_MarshalJSON(t *testing.T) {
+	ids := []refs.Identity{
+		refs.MustNewIdentity("@Uv38ByGCZU8WP18PmmIdcpVmx00QA3xNe7sEB9Hixkk=.ed25519"),
+		refs.MustNewIdentity("@gYVa2GgdDYbR6R4AFnk5y2aU0sQirNIIoAcpOUh/aZk=.ed25519"),
+	}
+
+	j, err := messages.MustNewRoomAttendantsResponseState(ids).MarshalJSON()
+	require.NoError(t, err)
+	require.JSONEq(t, `{"type": "state", "ids": ["@Uv38ByGCZU8WP18PmmIdcpVmx00QA3xNe7sEB9Hixkk=.ed25519", "@gYVa2GgdDYbR6R4AFnk5y2aU0sQirNIIoAcpOUh/aZk=.ed25519"]}`, string(j))
+
+	response, err := messages.NewRoomAttendantsResponseStateFromBytes(j)
+	require.NoError(t, err)
+	require.Equal(t, ids, response.Ids())
+}
+
+func TestRoomAttendantsResponseJoinedOrLeft_MarshalJSON(t *testing.T) {
+	id := refs.MustNewIdentity("@Uv38ByGCZU8WP18PmmIdcpVmx00QA3xNe7sEB9Hixkk=.ed25519")
+
+	testCases := []struct {
+		Typ          messages.RoomAttendantsResponseType
+		ExpectedJSON string
+	}{
+		{
+			Typ:          messages.RoomAttendantsResponseTypeJoined,
+			ExpectedJSON: `{"type": "joined", "id": "@Uv38ByGCZU8WP18PmmIdcpVmx00QA3xNe7sEB9Hixkk=.ed25519"}`,
+		},
+		{
+			Typ:          messages.RoomAttendantsResponseTypeLeft,
+			ExpectedJSON: `{"type": "left", "id": "@Uv38ByGCZU8WP18PmmIdcpVmx00QA3xNe7sEB9Hixkk=.ed25519"}`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.ExpectedJSON, func(t *testing.T) {
+			j, err := messages.MustNewRoomAttendantsResponseJoinedOrLeft(testCase.Typ, id).MarshalJSON()
+			require.NoError(t, err)
+			require.JSONEq(t, testCase.ExpectedJSON, string(j))
+
+			response, err := messages.NewRoomAttendantsResponseJoinedOrLeftFromBytes(j)
+			require.NoError(t, err)
+			require.Equal(t, testCase.Typ, response.Typ())
+			require.Equal(t, id, response.Id())
+		})
+	}
+}
@@ -44,6 +44,28 @@ func NewRoomListAliasesArguments(
 	}, nil
 }
 
+func NewRoomListAliasesArgumentsFromBytes(b []byte) (RoomListAliasesArguments, error) {
+	var args []string
+	if err := jsoniter.Unmarshal(b, &args); err != nil {
+		return RoomListAliasesArguments{}, errors.Wrap(err, "json unmarshal failed")
+	}
+
+	if len(args) != 1 {
+		return RoomListAliasesArguments{}, errors.New("expected exactly one argument")
+	}
+
+	identity, err := refs.NewIdentity(args[0])
+	if err != nil {
+		return RoomListAliasesArguments{}, errors.Wrap(err, "error creating the identity")
+	}
+
+	return NewRoomListAliasesArguments(identity)
+}
+
+func (i RoomListAliasesArguments) Identity() refs.Identity {
+	return i.identity
+}
+
 func (i RoomListAliasesArguments) MarshalJSON() ([]byte, error) {
 	return jsoniter.Marshal([]string{
 		i.identity.String(),
@@ -54,6 +76,10 @@ type RoomListAliasesResponse struct {
 	aliases []aliases.Alias
 }
 
+func NewRoomListAliasesResponse(aliasesSlice []aliases.Alias) RoomListAliasesResponse {
+	return RoomListAliasesResponse{aliases: aliasesSlice}
+}
+
 func NewRoomListAliasesResponseFromBytes(b []byte) (RoomListAliasesResponse, error) {
 	var aliasesAsStrings []string
 	if err := jsoniter.Unmarshal(b, &aliasesAsStrings); err != nil {
@@ -75,3 +101,11 @@ func NewRoomListAliasesResponseFromBytes(b []byte) (RoomListAliasesResponse, err
 func (r RoomListAliasesResponse) Aliases() []aliases.Alias {
 	return r.aliases
 }
+
+func (r RoomListAliasesResponse) MarshalJSON() ([]byte, error) {
+	aliasesAsStrings := make([]string, 0, len(r.aliases))
+	for _, alias := range r.aliases {
+		aliasesAsStrings = append(aliasesAsStrings, alias.String())
+	}
+	return jsoniter.Marshal(aliasesAsStrings)
+}
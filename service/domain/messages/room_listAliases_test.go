@@ -36,3 +36,22 @@ func TestNewRoomListAliasesResponseFromBytes(t *testing.T) {
 		resp.Aliases(),
 	)
 }
+
+func TestNewRoomListAliasesArgumentsFromBytes(t *testing.T) {
+	args, err := messages.NewRoomListAliasesArgumentsFromBytes([]byte(`["@Uv38ByGCZU8WP18PmmIdcpVmx00QA3xNe7sEB9Hixkk=.ed25519"]`))
+	require.NoError(t, err)
+	require.Equal(t, refs.MustNewIdentity("@Uv38ByGCZU8WP18PmmIdcpVmx00QA3xNe7sEB9Hixkk=.ed25519"), args.Identity())
+}
+
+func TestRoomListAliasesResponse_MarshalJSON(t *testing.T) {
+	j, err := messages.NewRoomListAliasesResponse(nil).MarshalJSON()
+	require.NoError(t, err)
+	require.JSONEq(t, `[]`, string(j))
+
+	j, err = messages.NewRoomListAliasesResponse([]aliases.Alias{
+		aliases.MustNewAlias("alias1"),
+		aliases.MustNewAlias("alias2"),
+	}).MarshalJSON()
+	require.NoError(t, err)
+	require.JSONEq(t, `["alias1", "alias2"]`, string(j))
+}
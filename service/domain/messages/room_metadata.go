@@ -1,6 +1,8 @@
 package messages
 
 import (
+	"fmt"
+
 	"github.com/boreq/errors"
 	jsoniter "github.com/json-iterator/go"
 	"github.com/planetary-social/scuttlego/service/domain/rooms/features"
@@ -68,15 +70,57 @@ func (r RoomMetadataResponse) Features() features.Features {
 	return r.features
 }
 
+func (r RoomMetadataResponse) MarshalJSON() ([]byte, error) {
+	featuresSlice := make([]string, 0)
+	for _, feature := range knownRoomFeatures {
+		if r.features.Contains(feature) {
+			featuresSlice = append(featuresSlice, encodeRoomFeature(feature))
+		}
+	}
+
+	return jsoniter.Marshal(roomMetadataTransport{
+		Membership: r.membership,
+		Features:   featuresSlice,
+	})
+}
+
 type roomMetadataTransport struct {
 	Membership bool     `json:"membership"`
 	Features   []string `json:"features"`
 }
 
+var knownRoomFeatures = []features.Feature{
+	features.FeatureTunnel,
+	features.FeatureRoom1,
+	features.FeatureRoom2,
+	features.FeatureAlias,
+}
+
+func encodeRoomFeature(feature features.Feature) string {
+	switch feature {
+	case features.FeatureTunnel:
+		return "tunnel"
+	case features.FeatureRoom1:
+		return "room1"
+	case features.FeatureRoom2:
+		return "room2"
+	case features.FeatureAlias:
+		return "alias"
+	default:
+		panic(fmt.Sprintf("unknown feature: %+v", feature))
+	}
+}
+
 func decodeRoomFeature(s string) (features.Feature, bool) {
 	switch s {
 	case "tunnel":
 		return features.FeatureTunnel, true
+	case "room1":
+		return features.FeatureRoom1, true
+	case "room2":
+		return features.FeatureRoom2, true
+	case "alias":
+		return features.FeatureAlias, true
 	default:
 		return features.Feature{}, false
 	}
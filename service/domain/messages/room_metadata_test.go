@@ -56,3 +56,53 @@ func TestNewRoomMetadataResponseFromBytes(t *testing.T) {
 		})
 	}
 }
+
+func TestRoomMetadataResponse_MarshalJSON(t *testing.T) {
+	testCases := []struct {
+		Name         string
+		Response     messages.RoomMetadataResponse
+		ExpectedJSON string
+	}{
+		{
+			Name: "member_and_tunnel",
+			Response: messages.NewRoomMetadataResponse(
+				true,
+				features.MustNewFeatures([]features.Feature{features.FeatureTunnel}),
+			),
+			ExpectedJSON: `{"membership": true, "features": ["tunnel"]}`,
+		},
+		{
+			Name: "all_features",
+			Response: messages.NewRoomMetadataResponse(
+				false,
+				features.MustNewFeatures([]features.Feature{
+					features.FeatureAlias,
+					features.FeatureRoom2,
+					features.FeatureRoom1,
+					features.FeatureTunnel,
+				}),
+			),
+			ExpectedJSON: `{"membership": false, "features": ["tunnel", "room1", "room2", "alias"]}`,
+		},
+		{
+			Name: "no_features",
+			Response: messages.NewRoomMetadataResponse(
+				false,
+				features.MustNewFeatures(nil),
+			),
+			ExpectedJSON: `{"membership": false, "features": []}`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			j, err := testCase.Response.MarshalJSON()
+			require.NoError(t, err)
+			require.JSONEq(t, testCase.ExpectedJSON, string(j))
+
+			response, err := messages.NewRoomMetadataResponseFromBytes(j)
+			require.NoError(t, err)
+			require.Equal(t, testCase.Response, response)
+		})
+	}
+}
@@ -2,6 +2,7 @@ package messages
 
 import (
 	"encoding/base64"
+	"strings"
 
 	"github.com/boreq/errors"
 	jsoniter "github.com/json-iterator/go"
@@ -52,17 +53,66 @@ func NewRoomRegisterAliasArguments(
 	}, nil
 }
 
+func NewRoomRegisterAliasArgumentsFromBytes(b []byte) (RoomRegisterAliasArguments, error) {
+	var args []string
+	if err := jsoniter.Unmarshal(b, &args); err != nil {
+		return RoomRegisterAliasArguments{}, errors.Wrap(err, "json unmarshal failed")
+	}
+
+	if len(args) != 2 {
+		return RoomRegisterAliasArguments{}, errors.New("expected exactly two arguments")
+	}
+
+	alias, err := aliases.NewAlias(args[0])
+	if err != nil {
+		return RoomRegisterAliasArguments{}, errors.Wrap(err, "error creating the alias")
+	}
+
+	if !strings.HasSuffix(args[1], registrationSignatureSuffix) {
+		return RoomRegisterAliasArguments{}, errors.New("invalid signature suffix")
+	}
+
+	signatureBytes, err := base64.StdEncoding.DecodeString(strings.TrimSuffix(args[1], registrationSignatureSuffix))
+	if err != nil {
+		return RoomRegisterAliasArguments{}, errors.Wrap(err, "error decoding the signature")
+	}
+
+	signature, err := aliases.NewRegistrationSignatureFromBytes(signatureBytes)
+	if err != nil {
+		return RoomRegisterAliasArguments{}, errors.Wrap(err, "error creating the signature")
+	}
+
+	return NewRoomRegisterAliasArguments(alias, signature)
+}
+
+func (i RoomRegisterAliasArguments) Alias() aliases.Alias {
+	return i.alias
+}
+
+func (i RoomRegisterAliasArguments) Signature() aliases.RegistrationSignature {
+	return i.signature
+}
+
 func (i RoomRegisterAliasArguments) MarshalJSON() ([]byte, error) {
 	return jsoniter.Marshal([]string{
 		i.alias.String(),
-		base64.StdEncoding.EncodeToString(i.signature.Bytes()) + ".sig.ed25519",
+		base64.StdEncoding.EncodeToString(i.signature.Bytes()) + registrationSignatureSuffix,
 	})
 }
 
+const registrationSignatureSuffix = ".sig.ed25519"
+
 type RoomRegisterAliasResponse struct {
 	url aliases.AliasEndpointURL
 }
 
+func NewRoomRegisterAliasResponse(url aliases.AliasEndpointURL) (RoomRegisterAliasResponse, error) {
+	if url.IsZero() {
+		return RoomRegisterAliasResponse{}, errors.New("zero value of url")
+	}
+	return RoomRegisterAliasResponse{url: url}, nil
+}
+
 func NewRoomRegisterAliasResponseFromBytes(b []byte) (RoomRegisterAliasResponse, error) {
 	url, err := aliases.NewAliasEndpointURL(string(b))
 	if err != nil {
@@ -74,3 +124,8 @@ func NewRoomRegisterAliasResponseFromBytes(b []byte) (RoomRegisterAliasResponse,
 func (r RoomRegisterAliasResponse) AliasEndpointURL() aliases.AliasEndpointURL {
 	return r.url
 }
+
+// Bytes returns the response body which is sent as a string.
+func (r RoomRegisterAliasResponse) Bytes() []byte {
+	return []byte(r.url.String())
+}
@@ -53,3 +53,38 @@ func TestNewRoomRegisterAliasResponseFromBytes(t *testing.T) {
 		resp.AliasEndpointURL(),
 	)
 }
+
+func TestNewRoomRegisterAliasArgumentsFromBytes(t *testing.T) {
+	alias := aliases.MustNewAlias("somealias")
+	userIdentity := fixtures.SomePrivateIdentity()
+
+	message, err := aliases.NewRegistrationMessage(alias, refs.MustNewIdentityFromPublic(userIdentity.Public()), fixtures.SomeRefIdentity())
+	require.NoError(t, err)
+
+	signature, err := aliases.NewRegistrationSignature(message, userIdentity)
+	require.NoError(t, err)
+
+	args, err := messages.NewRoomRegisterAliasArguments(alias, signature)
+	require.NoError(t, err)
+
+	j, err := args.MarshalJSON()
+	require.NoError(t, err)
+
+	args2, err := messages.NewRoomRegisterAliasArgumentsFromBytes(j)
+	require.NoError(t, err)
+	require.Equal(t, alias, args2.Alias())
+	require.Equal(t, signature.Bytes(), args2.Signature().Bytes())
+}
+
+func TestNewRoomRegisterAliasArgumentsFromBytes_RequiresSignatureSuffix(t *testing.T) {
+	signature := base64.StdEncoding.EncodeToString(fixtures.SomeBytesOfLength(64))
+
+	_, err := messages.NewRoomRegisterAliasArgumentsFromBytes([]byte(`["somealias", "` + signature + `"]`))
+	require.EqualError(t, err, "invalid signature suffix")
+}
+
+func TestRoomRegisterAliasResponse_Bytes(t *testing.T) {
+	resp, err := messages.NewRoomRegisterAliasResponse(aliases.MustNewAliasEndpointURL("https://somealias.example.com"))
+	require.NoError(t, err)
+	require.Equal(t, []byte("https://somealias.example.com"), resp.Bytes())
+}
@@ -43,6 +43,28 @@ func NewRoomRevokeAliasArguments(
 	}, nil
 }
 
+func NewRoomRevokeAliasArgumentsFromBytes(b []byte) (RoomRevokeAliasArguments, error) {
+	var args []string
+	if err := jsoniter.Unmarshal(b, &args); err != nil {
+		return RoomRevokeAliasArguments{}, errors.Wrap(err, "json unmarshal failed")
+	}
+
+	if len(args) != 1 {
+		return RoomRevokeAliasArguments{}, errors.New("expected exactly one argument")
+	}
+
+	alias, err := aliases.NewAlias(args[0])
+	if err != nil {
+		return RoomRevokeAliasArguments{}, errors.Wrap(err, "error creating the alias")
+	}
+
+	return NewRoomRevokeAliasArguments(alias)
+}
+
+func (i RoomRevokeAliasArguments) Alias() aliases.Alias {
+	return i.alias
+}
+
 func (i RoomRevokeAliasArguments) MarshalJSON() ([]byte, error) {
 	return jsoniter.Marshal([]string{
 		i.alias.String(),
@@ -22,3 +22,12 @@ func TestNewRoomRevokeAlias(t *testing.T) {
 	require.Equal(t, rpc.MustNewProcedureName([]string{"room", "revokeAlias"}), req.Name())
 	require.Equal(t, json.RawMessage(`["somealias"]`), req.Arguments())
 }
+
+func TestNewRoomRevokeAliasArgumentsFromBytes(t *testing.T) {
+	args, err := messages.NewRoomRevokeAliasArgumentsFromBytes([]byte(`["somealias"]`))
+	require.NoError(t, err)
+	require.Equal(t, aliases.MustNewAlias("somealias"), args.Alias())
+
+	_, err = messages.NewRoomRevokeAliasArgumentsFromBytes([]byte(`["invalid-alias"]`))
+	require.Error(t, err)
+}
@@ -0,0 +1,73 @@
+package messages
+
+import (
+	"encoding/hex"
+
+	"github.com/boreq/errors"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/planetary-social/scuttlego/service/domain/bans"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc"
+)
+
+var (
+	ScuttlegoAddToBanListProcedure = rpc.MustNewProcedure(
+		rpc.MustNewProcedureName([]string{"scuttlego", "banList", "add"}),
+		rpc.ProcedureTypeAsync,
+	)
+
+	ScuttlegoRemoveFromBanListProcedure = rpc.MustNewProcedure(
+		rpc.MustNewProcedureName([]string{"scuttlego", "banList", "remove"}),
+		rpc.ProcedureTypeAsync,
+	)
+)
+
+func NewScuttlegoAddToBanList(arguments ScuttlegoBanListArguments) (*rpc.Request, error) {
+	return newScuttlegoRequest(ScuttlegoAddToBanListProcedure, arguments)
+}
+
+func NewScuttlegoRemoveFromBanList(arguments ScuttlegoBanListArguments) (*rpc.Request, error) {
+	return newScuttlegoRequest(ScuttlegoRemoveFromBanListProcedure, arguments)
+}
+
+// ScuttlegoBanListArguments contain a hex encoded ban list hash e.g.
+// ["<hex>"].
+type ScuttlegoBanListArguments struct {
+	hash bans.Hash
+}
+
+func NewScuttlegoBanListArguments(hash bans.Hash) (ScuttlegoBanListArguments, error) {
+	if hash.IsZero() {
+		return ScuttlegoBanListArguments{}, errors.New("zero value of hash")
+	}
+
+	return ScuttlegoBanListArguments{
+		hash: hash,
+	}, nil
+}
+
+func NewScuttlegoBanListArgumentsFromBytes(b []byte) (ScuttlegoBanListArguments, error) {
+	s, err := unmarshalSingleStringArgument(b)
+	if err != nil {
+		return ScuttlegoBanListArguments{}, errors.Wrap(err, "error unmarshaling the argument")
+	}
+
+	decoded, err := hex.DecodeString(s)
+	if err != nil {
+		return ScuttlegoBanListArguments{}, errors.Wrap(err, "error decoding hex")
+	}
+
+	hash, err := bans.NewHash(decoded)
+	if err != nil {
+		return ScuttlegoBanListArguments{}, errors.Wrap(err, "error creating the hash")
+	}
+
+	return NewScuttlegoBanListArguments(hash)
+}
+
+func (a ScuttlegoBanListArguments) Hash() bans.Hash {
+	return a.hash
+}
+
+func (a ScuttlegoBanListArguments) MarshalJSON() ([]byte, error) {
+	return jsoniter.Marshal([]string{hex.EncodeToString(a.hash.Bytes())})
+}
@@ -0,0 +1,69 @@
+package messages
+
+import (
+	"encoding/base64"
+
+	"github.com/boreq/errors"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/planetary-social/scuttlego/service/domain/blobs"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc"
+)
+
+var (
+	// ScuttlegoAddBlobProcedure is an async procedure as duplex streams can't
+	// be used to send a response after the client closes its side of the
+	// stream. Blobs are small enough to fit in a single request.
+	ScuttlegoAddBlobProcedure = rpc.MustNewProcedure(
+		rpc.MustNewProcedureName([]string{"scuttlego", "addBlob"}),
+		rpc.ProcedureTypeAsync,
+	)
+)
+
+func NewScuttlegoAddBlob(arguments ScuttlegoAddBlobArguments) (*rpc.Request, error) {
+	return newScuttlegoRequest(ScuttlegoAddBlobProcedure, arguments)
+}
+
+// ScuttlegoAddBlobArguments contain base64 encoded blob data e.g.
+// ["<base64>"].
+type ScuttlegoAddBlobArguments struct {
+	data []byte
+}
+
+func NewScuttlegoAddBlobArguments(data []byte) (ScuttlegoAddBlobArguments, error) {
+	if len(data) == 0 {
+		return ScuttlegoAddBlobArguments{}, errors.New("empty blob")
+	}
+
+	if blobs.MustNewSize(int64(len(data))).Above(blobs.MaxBlobSize()) {
+		return ScuttlegoAddBlobArguments{}, errors.New("blob is too large")
+	}
+
+	tmp := make([]byte, len(data))
+	copy(tmp, data)
+
+	return ScuttlegoAddBlobArguments{
+		data: tmp,
+	}, nil
+}
+
+func NewScuttlegoAddBlobArgumentsFromBytes(b []byte) (ScuttlegoAddBlobArguments, error) {
+	s, err := unmarshalSingleStringArgument(b)
+	if err != nil {
+		return ScuttlegoAddBlobArguments{}, errors.Wrap(err, "error unmarshaling the argument")
+	}
+
+	data, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		return ScuttlegoAddBlobArguments{}, errors.Wrap(err, "error decoding base64")
+	}
+
+	return NewScuttlegoAddBlobArguments(data)
+}
+
+func (a ScuttlegoAddBlobArguments) Data() []byte {
+	return a.data
+}
+
+func (a ScuttlegoAddBlobArguments) MarshalJSON() ([]byte, error) {
+	return jsoniter.Marshal([]string{base64.StdEncoding.EncodeToString(a.data)})
+}
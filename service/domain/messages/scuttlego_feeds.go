@@ -0,0 +1,332 @@
+package messages
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/boreq/errors"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/planetary-social/scuttlego/service/domain/feeds/message"
+	"github.com/planetary-social/scuttlego/service/domain/refs"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc"
+)
+
+// Procedures in the scuttlego namespace expose the application layer to
+// local clients such as scuttlego-cli.
+var (
+	ScuttlegoPublishProcedure = rpc.MustNewProcedure(
+		rpc.MustNewProcedureName([]string{"scuttlego", "publish"}),
+		rpc.ProcedureTypeAsync,
+	)
+
+	ScuttlegoFollowProcedure = rpc.MustNewProcedure(
+		rpc.MustNewProcedureName([]string{"scuttlego", "follow"}),
+		rpc.ProcedureTypeAsync,
+	)
+
+	ScuttlegoGetMessageProcedure = rpc.MustNewProcedure(
+		rpc.MustNewProcedureName([]string{"scuttlego", "getMessage"}),
+		rpc.ProcedureTypeAsync,
+	)
+
+	ScuttlegoReceiveLogProcedure = rpc.MustNewProcedure(
+		rpc.MustNewProcedureName([]string{"scuttlego", "receiveLog"}),
+		rpc.ProcedureTypeSource,
+	)
+)
+
+func NewScuttlegoPublish(arguments ScuttlegoPublishArguments) (*rpc.Request, error) {
+	return newScuttlegoRequest(ScuttlegoPublishProcedure, arguments)
+}
+
+type ScuttlegoPublishArguments struct {
+	content []byte
+}
+
+// NewScuttlegoPublishArguments creates arguments containing the content of
+// the message which will be published. Content must be a JSON object.
+func NewScuttlegoPublishArguments(content []byte) (ScuttlegoPublishArguments, error) {
+	var object map[string]jsoniter.RawMessage
+	if err := jsoniter.Unmarshal(content, &object); err != nil {
+		return ScuttlegoPublishArguments{}, errors.Wrap(err, "content must be a json object")
+	}
+
+	tmp := make([]byte, len(content))
+	copy(tmp, content)
+
+	return ScuttlegoPublishArguments{
+		content: tmp,
+	}, nil
+}
+
+func NewScuttlegoPublishArgumentsFromBytes(b []byte) (ScuttlegoPublishArguments, error) {
+	var args []jsoniter.RawMessage
+
+	if err := jsoniter.Unmarshal(b, &args); err != nil {
+		return ScuttlegoPublishArguments{}, errors.Wrap(err, "json unmarshal failed")
+	}
+
+	if len(args) != 1 {
+		return ScuttlegoPublishArguments{}, errors.New("expected exactly one argument")
+	}
+
+	return NewScuttlegoPublishArguments(args[0])
+}
+
+func (a ScuttlegoPublishArguments) Content() []byte {
+	return a.content
+}
+
+func (a ScuttlegoPublishArguments) MarshalJSON() ([]byte, error) {
+	return jsoniter.Marshal([]jsoniter.RawMessage{a.content})
+}
+
+func NewScuttlegoFollow(arguments ScuttlegoIdentityArguments) (*rpc.Request, error) {
+	return newScuttlegoRequest(ScuttlegoFollowProcedure, arguments)
+}
+
+// ScuttlegoIdentityArguments are used by procedures which accept a single
+// identity e.g. ["@id.ed25519"].
+type ScuttlegoIdentityArguments struct {
+	id refs.Identity
+}
+
+func NewScuttlegoIdentityArguments(id refs.Identity) (ScuttlegoIdentityArguments, error) {
+	if id.IsZero() {
+		return ScuttlegoIdentityArguments{}, errors.New("zero value of id")
+	}
+
+	return ScuttlegoIdentityArguments{
+		id: id,
+	}, nil
+}
+
+func NewScuttlegoIdentityArgumentsFromBytes(b []byte) (ScuttlegoIdentityArguments, error) {
+	s, err := unmarshalSingleStringArgument(b)
+	if err != nil {
+		return ScuttlegoIdentityArguments{}, errors.Wrap(err, "error unmarshaling the argument")
+	}
+
+	id, err := refs.NewIdentity(s)
+	if err != nil {
+		return ScuttlegoIdentityArguments{}, errors.Wrap(err, "could not create an identity ref")
+	}
+
+	return NewScuttlegoIdentityArguments(id)
+}
+
+func (a ScuttlegoIdentityArguments) Id() refs.Identity {
+	return a.id
+}
+
+func (a ScuttlegoIdentityArguments) MarshalJSON() ([]byte, error) {
+	return jsoniter.Marshal([]string{a.id.String()})
+}
+
+func NewScuttlegoGetMessage(arguments GetArguments) (*rpc.Request, error) {
+	return newScuttlegoRequest(ScuttlegoGetMessageProcedure, arguments)
+}
+
+func NewScuttlegoReceiveLog(arguments ScuttlegoReceiveLogArguments) (*rpc.Request, error) {
+	return newScuttlegoRequest(ScuttlegoReceiveLogProcedure, arguments)
+}
+
+type ScuttlegoReceiveLogArguments struct {
+	start int
+	limit int
+}
+
+// NewScuttlegoReceiveLogArguments creates arguments for reading messages
+// starting at the provided receive log sequence.
+func NewScuttlegoReceiveLogArguments(start int, limit int) (ScuttlegoReceiveLogArguments, error) {
+	if start < 0 {
+		return ScuttlegoReceiveLogArguments{}, errors.New("start can't be negative")
+	}
+
+	if limit <= 0 {
+		return ScuttlegoReceiveLogArguments{}, errors.New("limit must be positive")
+	}
+
+	return ScuttlegoReceiveLogArguments{
+		start: start,
+		limit: limit,
+	}, nil
+}
+
+func NewScuttlegoReceiveLogArgumentsFromBytes(b []byte) (ScuttlegoReceiveLogArguments, error) {
+	var args []scuttlegoReceiveLogArgumentsTransport
+
+	if err := jsoniter.Unmarshal(b, &args); err != nil {
+		return ScuttlegoReceiveLogArguments{}, errors.Wrap(err, "json unmarshal failed")
+	}
+
+	if len(args) != 1 {
+		return ScuttlegoReceiveLogArguments{}, errors.New("expected exactly one argument")
+	}
+
+	return NewScuttlegoReceiveLogArguments(args[0].Start, args[0].Limit)
+}
+
+func (a ScuttlegoReceiveLogArguments) Start() int {
+	return a.start
+}
+
+func (a ScuttlegoReceiveLogArguments) Limit() int {
+	return a.limit
+}
+
+func (a ScuttlegoReceiveLogArguments) MarshalJSON() ([]byte, error) {
+	return jsoniter.Marshal([]scuttlegoReceiveLogArgumentsTransport{
+		{
+			Start: a.start,
+			Limit: a.limit,
+		},
+	})
+}
+
+type scuttlegoReceiveLogArgumentsTransport struct {
+	Start int `json:"start"`
+	Limit int `json:"limit"`
+}
+
+// ScuttlegoMessageResponse describes a single message.
+type ScuttlegoMessageResponse struct {
+	msg message.Message
+}
+
+func NewScuttlegoMessageResponse(msg message.Message) (ScuttlegoMessageResponse, error) {
+	if msg.IsZero() {
+		return ScuttlegoMessageResponse{}, errors.New("zero value of message")
+	}
+
+	return ScuttlegoMessageResponse{
+		msg: msg,
+	}, nil
+}
+
+func (r ScuttlegoMessageResponse) MarshalJSON() ([]byte, error) {
+	transport, err := newScuttlegoMessageTransport(r.msg)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating the transport")
+	}
+
+	return jsoniter.Marshal(transport)
+}
+
+// ScuttlegoReceiveLogResponse describes a single message and its position in
+// the receive log.
+type ScuttlegoReceiveLogResponse struct {
+	msg      message.Message
+	sequence int
+}
+
+func NewScuttlegoReceiveLogResponse(msg message.Message, sequence int) (ScuttlegoReceiveLogResponse, error) {
+	if msg.IsZero() {
+		return ScuttlegoReceiveLogResponse{}, errors.New("zero value of message")
+	}
+
+	return ScuttlegoReceiveLogResponse{
+		msg:      msg,
+		sequence: sequence,
+	}, nil
+}
+
+func (r ScuttlegoReceiveLogResponse) MarshalJSON() ([]byte, error) {
+	transport, err := newScuttlegoMessageTransport(r.msg)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating the transport")
+	}
+
+	return jsoniter.Marshal(scuttlegoReceiveLogResponseTransport{
+		ReceiveLogSequence: r.sequence,
+		Message:            transport,
+	})
+}
+
+type scuttlegoReceiveLogResponseTransport struct {
+	ReceiveLogSequence int                       `json:"receiveLogSequence"`
+	Message            scuttlegoMessageTransport `json:"message"`
+}
+
+type scuttlegoMessageTransport struct {
+	Id        string              `json:"id"`
+	Feed      string              `json:"feed"`
+	Sequence  int                 `json:"sequence"`
+	Timestamp int64               `json:"timestamp"`
+	Content   jsoniter.RawMessage `json:"content"`
+
+	// Raw contains the signed message exactly as it was received so that
+	// clients can verify it.
+	Raw string `json:"raw"`
+}
+
+func newScuttlegoMessageTransport(msg message.Message) (scuttlegoMessageTransport, error) {
+	// Content is compacted so that each response fits on a single line.
+	content := &bytes.Buffer{}
+	if err := json.Compact(content, msg.Content().Raw().Bytes()); err != nil {
+		return scuttlegoMessageTransport{}, errors.Wrap(err, "error compacting content")
+	}
+
+	return scuttlegoMessageTransport{
+		Id:        msg.Id().String(),
+		Feed:      msg.Feed().String(),
+		Sequence:  msg.Sequence().Int(),
+		Timestamp: msg.Timestamp().UnixMilli(),
+		Content:   content.Bytes(),
+		Raw:       string(msg.Raw().Bytes()),
+	}, nil
+}
+
+// ScuttlegoIdResponse is returned by procedures which create a new message or
+// blob.
+type ScuttlegoIdResponse struct {
+	id string
+}
+
+func NewScuttlegoIdResponse(id fmt.Stringer) (ScuttlegoIdResponse, error) {
+	if id == nil {
+		return ScuttlegoIdResponse{}, errors.New("nil id")
+	}
+
+	return ScuttlegoIdResponse{
+		id: id.String(),
+	}, nil
+}
+
+func (r ScuttlegoIdResponse) MarshalJSON() ([]byte, error) {
+	return jsoniter.Marshal(scuttlegoIdResponseTransport{
+		Id: r.id,
+	})
+}
+
+type scuttlegoIdResponseTransport struct {
+	Id string `json:"id"`
+}
+
+func newScuttlegoRequest(procedure rpc.Procedure, arguments interface{ MarshalJSON() ([]byte, error) }) (*rpc.Request, error) {
+	j, err := arguments.MarshalJSON()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal arguments")
+	}
+
+	return rpc.NewRequest(
+		procedure.Name(),
+		procedure.Typ(),
+		j,
+	)
+}
+
+func unmarshalSingleStringArgument(b []byte) (string, error) {
+	var args []string
+
+	if err := jsoniter.Unmarshal(b, &args); err != nil {
+		return "", errors.Wrap(err, "json unmarshal failed")
+	}
+
+	if len(args) != 1 {
+		return "", errors.New("expected exactly one argument")
+	}
+
+	return args[0], nil
+}
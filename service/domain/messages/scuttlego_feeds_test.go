@@ -0,0 +1,126 @@
+package messages_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/planetary-social/scuttlego/internal/fixtures"
+	"github.com/planetary-social/scuttlego/service/domain/feeds/message"
+	"github.com/planetary-social/scuttlego/service/domain/messages"
+	"github.com/planetary-social/scuttlego/service/domain/refs"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScuttlegoPublishArguments(t *testing.T) {
+	testCases := []struct {
+		Name          string
+		Content       string
+		ExpectedError bool
+	}{
+		{
+			Name:          "object",
+			Content:       `{"type":"post","text":"hello"}`,
+			ExpectedError: false,
+		},
+		{
+			Name:          "array",
+			Content:       `[1, 2]`,
+			ExpectedError: true,
+		},
+		{
+			Name:          "string",
+			Content:       `"string"`,
+			ExpectedError: true,
+		},
+		{
+			Name:          "invalid_json",
+			Content:       `{`,
+			ExpectedError: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			args, err := messages.NewScuttlegoPublishArguments([]byte(testCase.Content))
+			if testCase.ExpectedError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			j, err := args.MarshalJSON()
+			require.NoError(t, err)
+			require.Equal(t, `[{"type":"post","text":"hello"}]`, string(j))
+
+			unmarshaled, err := messages.NewScuttlegoPublishArgumentsFromBytes(j)
+			require.NoError(t, err)
+			require.Equal(t, testCase.Content, string(unmarshaled.Content()))
+		})
+	}
+}
+
+func TestScuttlegoReceiveLogArguments(t *testing.T) {
+	args, err := messages.NewScuttlegoReceiveLogArguments(10, 20)
+	require.NoError(t, err)
+
+	j, err := args.MarshalJSON()
+	require.NoError(t, err)
+	require.Equal(t, `[{"start":10,"limit":20}]`, string(j))
+
+	unmarshaled, err := messages.NewScuttlegoReceiveLogArgumentsFromBytes(j)
+	require.NoError(t, err)
+	require.Equal(t, args, unmarshaled)
+
+	_, err = messages.NewScuttlegoReceiveLogArguments(-1, 20)
+	require.Error(t, err)
+
+	_, err = messages.NewScuttlegoReceiveLogArguments(10, 0)
+	require.Error(t, err)
+}
+
+func TestScuttlegoReceiveLogResponse_MarshalJSON(t *testing.T) {
+	raw := "{\n  \"previous\": null,\n  \"content\": {\n    \"type\": \"post\"\n  }\n}"
+
+	msg := message.MustNewMessage(
+		refs.MustNewMessage("%uRECWB4KIeKoNMis2UYWyB2aQPvWmS3OePQvBj2zClg=.sha256"),
+		nil,
+		message.NewFirstSequence(),
+		refs.MustNewIdentity("@qFtLJ6P5Eh9vKxnj7Rsh8SkE6B6Z36DVLP7ZOKNeQ/Y=.ed25519"),
+		refs.MustNewFeed("@qFtLJ6P5Eh9vKxnj7Rsh8SkE6B6Z36DVLP7ZOKNeQ/Y=.ed25519"),
+		time.UnixMilli(1660000000123),
+		message.MustNewContent(message.MustNewRawContent([]byte("{\n    \"type\": \"post\"\n  }")), nil, nil),
+		message.MustNewRawMessage([]byte(raw)),
+	)
+
+	response, err := messages.NewScuttlegoReceiveLogResponse(msg, 5)
+	require.NoError(t, err)
+
+	j, err := response.MarshalJSON()
+	require.NoError(t, err)
+	require.JSONEq(t,
+		`{
+	"receiveLogSequence": 5,
+	"message": {
+		"id": "%uRECWB4KIeKoNMis2UYWyB2aQPvWmS3OePQvBj2zClg=.sha256",
+		"feed": "@qFtLJ6P5Eh9vKxnj7Rsh8SkE6B6Z36DVLP7ZOKNeQ/Y=.ed25519",
+		"sequence": 1,
+		"timestamp": 1660000000123,
+		"content": {"type": "post"},
+		"raw": "{\n  \"previous\": null,\n  \"content\": {\n    \"type\": \"post\"\n  }\n}"
+	}
+}`,
+		string(j),
+	)
+	require.NotContains(t, string(j), "\n", "responses must fit on a single line")
+}
+
+func TestScuttlegoIdResponse_MarshalJSON(t *testing.T) {
+	id := fixtures.SomeRefMessage()
+
+	response, err := messages.NewScuttlegoIdResponse(id)
+	require.NoError(t, err)
+
+	j, err := response.MarshalJSON()
+	require.NoError(t, err)
+	require.JSONEq(t, `{"id":"`+id.String()+`"}`, string(j))
+}
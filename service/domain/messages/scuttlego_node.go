@@ -0,0 +1,157 @@
+package messages
+
+import (
+	"github.com/boreq/errors"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/planetary-social/scuttlego/service/domain/identity"
+	"github.com/planetary-social/scuttlego/service/domain/network"
+	"github.com/planetary-social/scuttlego/service/domain/refs"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc"
+)
+
+var (
+	ScuttlegoStatusProcedure = rpc.MustNewProcedure(
+		rpc.MustNewProcedureName([]string{"scuttlego", "status"}),
+		rpc.ProcedureTypeAsync,
+	)
+
+	ScuttlegoConnectProcedure = rpc.MustNewProcedure(
+		rpc.MustNewProcedureName([]string{"scuttlego", "connect"}),
+		rpc.ProcedureTypeAsync,
+	)
+
+	ScuttlegoRedeemInviteProcedure = rpc.MustNewProcedure(
+		rpc.MustNewProcedureName([]string{"scuttlego", "redeemInvite"}),
+		rpc.ProcedureTypeAsync,
+	)
+)
+
+func NewScuttlegoStatus() (*rpc.Request, error) {
+	return rpc.NewRequest(
+		ScuttlegoStatusProcedure.Name(),
+		ScuttlegoStatusProcedure.Typ(),
+		[]byte("[]"),
+	)
+}
+
+type ScuttlegoStatusResponse struct {
+	numberOfMessages int
+	numberOfFeeds    int
+	peers            []identity.Public
+}
+
+func NewScuttlegoStatusResponse(numberOfMessages, numberOfFeeds int, peers []identity.Public) ScuttlegoStatusResponse {
+	return ScuttlegoStatusResponse{
+		numberOfMessages: numberOfMessages,
+		numberOfFeeds:    numberOfFeeds,
+		peers:            peers,
+	}
+}
+
+func (r ScuttlegoStatusResponse) MarshalJSON() ([]byte, error) {
+	transport := scuttlegoStatusResponseTransport{
+		NumberOfMessages: r.numberOfMessages,
+		NumberOfFeeds:    r.numberOfFeeds,
+		Peers:            make([]scuttlegoStatusResponsePeerTransport, 0, len(r.peers)),
+	}
+
+	for _, peer := range r.peers {
+		ref, err := refs.NewIdentityFromPublic(peer)
+		if err != nil {
+			return nil, errors.Wrap(err, "error creating an identity ref")
+		}
+
+		transport.Peers = append(transport.Peers, scuttlegoStatusResponsePeerTransport{
+			Id: ref.String(),
+		})
+	}
+
+	return jsoniter.Marshal(transport)
+}
+
+type scuttlegoStatusResponseTransport struct {
+	NumberOfMessages int                                    `json:"numberOfMessages"`
+	NumberOfFeeds    int                                    `json:"numberOfFeeds"`
+	Peers            []scuttlegoStatusResponsePeerTransport `json:"peers"`
+}
+
+type scuttlegoStatusResponsePeerTransport struct {
+	Id string `json:"id"`
+}
+
+func NewScuttlegoConnect(arguments ScuttlegoConnectArguments) (*rpc.Request, error) {
+	return newScuttlegoRequest(ScuttlegoConnectProcedure, arguments)
+}
+
+// ScuttlegoConnectArguments contain a multiserver address of the peer e.g.
+// ["net:example.com:8008~shs:<base64 key>"].
+type ScuttlegoConnectArguments struct {
+	address string
+	parsed  network.MultiserverAddress
+}
+
+func NewScuttlegoConnectArguments(address string) (ScuttlegoConnectArguments, error) {
+	parsed, err := network.NewMultiserverAddress(address)
+	if err != nil {
+		return ScuttlegoConnectArguments{}, errors.Wrap(err, "invalid address")
+	}
+
+	return ScuttlegoConnectArguments{
+		address: address,
+		parsed:  parsed,
+	}, nil
+}
+
+func NewScuttlegoConnectArgumentsFromBytes(b []byte) (ScuttlegoConnectArguments, error) {
+	s, err := unmarshalSingleStringArgument(b)
+	if err != nil {
+		return ScuttlegoConnectArguments{}, errors.Wrap(err, "error unmarshaling the argument")
+	}
+
+	return NewScuttlegoConnectArguments(s)
+}
+
+func (a ScuttlegoConnectArguments) Address() network.MultiserverAddress {
+	return a.parsed
+}
+
+func (a ScuttlegoConnectArguments) MarshalJSON() ([]byte, error) {
+	return jsoniter.Marshal([]string{a.address})
+}
+
+func NewScuttlegoRedeemInvite(arguments ScuttlegoRedeemInviteArguments) (*rpc.Request, error) {
+	return newScuttlegoRequest(ScuttlegoRedeemInviteProcedure, arguments)
+}
+
+// ScuttlegoRedeemInviteArguments contain an invite in the format produced by
+// rooms and pubs. The invite is parsed by the handler.
+type ScuttlegoRedeemInviteArguments struct {
+	invite string
+}
+
+func NewScuttlegoRedeemInviteArguments(invite string) (ScuttlegoRedeemInviteArguments, error) {
+	if invite == "" {
+		return ScuttlegoRedeemInviteArguments{}, errors.New("empty invite")
+	}
+
+	return ScuttlegoRedeemInviteArguments{
+		invite: invite,
+	}, nil
+}
+
+func NewScuttlegoRedeemInviteArgumentsFromBytes(b []byte) (ScuttlegoRedeemInviteArguments, error) {
+	s, err := unmarshalSingleStringArgument(b)
+	if err != nil {
+		return ScuttlegoRedeemInviteArguments{}, errors.Wrap(err, "error unmarshaling the argument")
+	}
+
+	return NewScuttlegoRedeemInviteArguments(s)
+}
+
+func (a ScuttlegoRedeemInviteArguments) Invite() string {
+	return a.invite
+}
+
+func (a ScuttlegoRedeemInviteArguments) MarshalJSON() ([]byte, error) {
+	return jsoniter.Marshal([]string{a.invite})
+}
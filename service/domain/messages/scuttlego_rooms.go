@@ -0,0 +1,169 @@
+package messages
+
+import (
+	"github.com/boreq/errors"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/planetary-social/scuttlego/service/domain/network"
+	"github.com/planetary-social/scuttlego/service/domain/rooms/aliases"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc"
+)
+
+var (
+	ScuttlegoRegisterAliasProcedure = rpc.MustNewProcedure(
+		rpc.MustNewProcedureName([]string{"scuttlego", "room", "registerAlias"}),
+		rpc.ProcedureTypeAsync,
+	)
+
+	ScuttlegoRevokeAliasProcedure = rpc.MustNewProcedure(
+		rpc.MustNewProcedureName([]string{"scuttlego", "room", "revokeAlias"}),
+		rpc.ProcedureTypeAsync,
+	)
+
+	ScuttlegoListAliasesProcedure = rpc.MustNewProcedure(
+		rpc.MustNewProcedureName([]string{"scuttlego", "room", "listAliases"}),
+		rpc.ProcedureTypeAsync,
+	)
+)
+
+func NewScuttlegoRegisterAlias(arguments ScuttlegoAliasArguments) (*rpc.Request, error) {
+	return newScuttlegoRequest(ScuttlegoRegisterAliasProcedure, arguments)
+}
+
+func NewScuttlegoRevokeAlias(arguments ScuttlegoAliasArguments) (*rpc.Request, error) {
+	return newScuttlegoRequest(ScuttlegoRevokeAliasProcedure, arguments)
+}
+
+func NewScuttlegoListAliases(arguments ScuttlegoRoomArguments) (*rpc.Request, error) {
+	return newScuttlegoRequest(ScuttlegoListAliasesProcedure, arguments)
+}
+
+// ScuttlegoRoomArguments contain a multiserver address of a room e.g.
+// [{"room":"net:example.com:8008~shs:<base64 key>"}].
+type ScuttlegoRoomArguments struct {
+	room    string
+	address network.MultiserverAddress
+}
+
+func NewScuttlegoRoomArguments(room string) (ScuttlegoRoomArguments, error) {
+	address, err := network.NewMultiserverAddress(room)
+	if err != nil {
+		return ScuttlegoRoomArguments{}, errors.Wrap(err, "invalid room address")
+	}
+
+	return ScuttlegoRoomArguments{
+		room:    room,
+		address: address,
+	}, nil
+}
+
+func NewScuttlegoRoomArgumentsFromBytes(b []byte) (ScuttlegoRoomArguments, error) {
+	var args []scuttlegoAliasArgumentsTransport
+	if err := jsoniter.Unmarshal(b, &args); err != nil {
+		return ScuttlegoRoomArguments{}, errors.Wrap(err, "json unmarshal failed")
+	}
+
+	if len(args) != 1 {
+		return ScuttlegoRoomArguments{}, errors.New("expected exactly one argument")
+	}
+
+	return NewScuttlegoRoomArguments(args[0].Room)
+}
+
+func (a ScuttlegoRoomArguments) Room() network.MultiserverAddress {
+	return a.address
+}
+
+func (a ScuttlegoRoomArguments) MarshalJSON() ([]byte, error) {
+	return jsoniter.Marshal([]scuttlegoAliasArgumentsTransport{
+		{
+			Room: a.room,
+		},
+	})
+}
+
+// ScuttlegoAliasArguments contain a multiserver address of a room and an
+// alias e.g. [{"room":"net:example.com:8008~shs:<base64 key>","alias":"name"}].
+type ScuttlegoAliasArguments struct {
+	room  ScuttlegoRoomArguments
+	alias aliases.Alias
+}
+
+func NewScuttlegoAliasArguments(room ScuttlegoRoomArguments, alias aliases.Alias) (ScuttlegoAliasArguments, error) {
+	if alias.IsZero() {
+		return ScuttlegoAliasArguments{}, errors.New("zero value of alias")
+	}
+
+	return ScuttlegoAliasArguments{
+		room:  room,
+		alias: alias,
+	}, nil
+}
+
+func NewScuttlegoAliasArgumentsFromBytes(b []byte) (ScuttlegoAliasArguments, error) {
+	var args []scuttlegoAliasArgumentsTransport
+	if err := jsoniter.Unmarshal(b, &args); err != nil {
+		return ScuttlegoAliasArguments{}, errors.Wrap(err, "json unmarshal failed")
+	}
+
+	if len(args) != 1 {
+		return ScuttlegoAliasArguments{}, errors.New("expected exactly one argument")
+	}
+
+	room, err := NewScuttlegoRoomArguments(args[0].Room)
+	if err != nil {
+		return ScuttlegoAliasArguments{}, errors.Wrap(err, "error creating room arguments")
+	}
+
+	alias, err := aliases.NewAlias(args[0].Alias)
+	if err != nil {
+		return ScuttlegoAliasArguments{}, errors.Wrap(err, "invalid alias")
+	}
+
+	return NewScuttlegoAliasArguments(room, alias)
+}
+
+func (a ScuttlegoAliasArguments) Room() network.MultiserverAddress {
+	return a.room.Room()
+}
+
+func (a ScuttlegoAliasArguments) Alias() aliases.Alias {
+	return a.alias
+}
+
+func (a ScuttlegoAliasArguments) MarshalJSON() ([]byte, error) {
+	return jsoniter.Marshal([]scuttlegoAliasArgumentsTransport{
+		{
+			Room:  a.room.room,
+			Alias: a.alias.String(),
+		},
+	})
+}
+
+type scuttlegoAliasArgumentsTransport struct {
+	Room  string `json:"room"`
+	Alias string `json:"alias,omitempty"`
+}
+
+type ScuttlegoRegisterAliasResponse struct {
+	url aliases.AliasEndpointURL
+}
+
+func NewScuttlegoRegisterAliasResponse(url aliases.AliasEndpointURL) (ScuttlegoRegisterAliasResponse, error) {
+	if url.IsZero() {
+		return ScuttlegoRegisterAliasResponse{}, errors.New("zero value of url")
+	}
+
+	return ScuttlegoRegisterAliasResponse{
+		url: url,
+	}, nil
+}
+
+func (r ScuttlegoRegisterAliasResponse) MarshalJSON() ([]byte, error) {
+	return jsoniter.Marshal(scuttlegoRegisterAliasResponseTransport{
+		URL: r.url.String(),
+	})
+}
+
+type scuttlegoRegisterAliasResponseTransport struct {
+	URL string `json:"url"`
+}
@@ -0,0 +1,47 @@
+package messages_test
+
+import (
+	"testing"
+
+	"github.com/planetary-social/scuttlego/service/domain/messages"
+	"github.com/planetary-social/scuttlego/service/domain/rooms/aliases"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScuttlegoAliasArguments(t *testing.T) {
+	const room = "net:example.com:8008~shs:qFtLJ6P5Eh9vKxnj7Rsh8SkE6B6Z36DVLP7ZOKNeQ/Y="
+
+	roomArgs, err := messages.NewScuttlegoRoomArguments(room)
+	require.NoError(t, err)
+
+	args, err := messages.NewScuttlegoAliasArguments(roomArgs, aliases.MustNewAlias("somealias"))
+	require.NoError(t, err)
+
+	j, err := args.MarshalJSON()
+	require.NoError(t, err)
+	require.Equal(t, `[{"room":"`+room+`","alias":"somealias"}]`, string(j))
+
+	unmarshaled, err := messages.NewScuttlegoAliasArgumentsFromBytes(j)
+	require.NoError(t, err)
+	require.Equal(t, args, unmarshaled)
+	require.Equal(t, "example.com:8008", unmarshaled.Room().Address().String())
+	require.Equal(t, "somealias", unmarshaled.Alias().String())
+}
+
+func TestScuttlegoRoomArguments(t *testing.T) {
+	const room = "net:example.com:8008~shs:qFtLJ6P5Eh9vKxnj7Rsh8SkE6B6Z36DVLP7ZOKNeQ/Y="
+
+	args, err := messages.NewScuttlegoRoomArguments(room)
+	require.NoError(t, err)
+
+	j, err := args.MarshalJSON()
+	require.NoError(t, err)
+	require.Equal(t, `[{"room":"`+room+`"}]`, string(j))
+
+	unmarshaled, err := messages.NewScuttlegoRoomArgumentsFromBytes(j)
+	require.NoError(t, err)
+	require.Equal(t, args, unmarshaled)
+
+	_, err = messages.NewScuttlegoRoomArguments("invalid")
+	require.Error(t, err)
+}
@@ -0,0 +1,98 @@
+package messages
+
+import (
+	"github.com/boreq/errors"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/planetary-social/scuttlego/service/domain/identity"
+	"github.com/planetary-social/scuttlego/service/domain/refs"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc"
+)
+
+var (
+	StatusProcedure = rpc.MustNewProcedure(
+		rpc.MustNewProcedureName([]string{"status"}),
+		rpc.ProcedureTypeAsync,
+	)
+)
+
+func NewStatus() (*rpc.Request, error) {
+	return rpc.NewRequest(
+		StatusProcedure.Name(),
+		StatusProcedure.Typ(),
+		[]byte("[]"),
+	)
+}
+
+// StatusResponse mimics the parts of the status returned by ssb-server which
+// are used by clients to display progress. Scuttlego indexes messages when
+// they are saved so the indexes are always reported as up to date.
+type StatusResponse struct {
+	numberOfMessages int
+	peers            []identity.Public
+}
+
+func NewStatusResponse(numberOfMessages int, peers []identity.Public) StatusResponse {
+	return StatusResponse{
+		numberOfMessages: numberOfMessages,
+		peers:            peers,
+	}
+}
+
+func (r StatusResponse) MarshalJSON() ([]byte, error) {
+	transport := statusResponseTransport{
+		Progress: statusResponseProgressTransport{
+			Indexes: statusResponseIndexesTransport{
+				Start:   r.numberOfMessages,
+				Current: r.numberOfMessages,
+				Target:  r.numberOfMessages,
+			},
+		},
+		Sync: statusResponseSyncTransport{
+			Since:   r.numberOfMessages,
+			Plugins: make(map[string]int),
+			Sync:    true,
+		},
+		Peers: make([]statusResponsePeerTransport, 0, len(r.peers)),
+	}
+
+	for _, peer := range r.peers {
+		ref, err := refs.NewIdentityFromPublic(peer)
+		if err != nil {
+			return nil, errors.Wrap(err, "error creating an identity ref")
+		}
+
+		transport.Peers = append(transport.Peers, statusResponsePeerTransport{
+			Key:   ref.String(),
+			State: "connected",
+		})
+	}
+
+	return jsoniter.Marshal(transport)
+}
+
+type statusResponseTransport struct {
+	Progress statusResponseProgressTransport `json:"progress"`
+	Sync     statusResponseSyncTransport     `json:"sync"`
+	Peers    []statusResponsePeerTransport   `json:"peers"`
+}
+
+type statusResponseProgressTransport struct {
+	Indexes statusResponseIndexesTransport `json:"indexes"`
+}
+
+type statusResponseIndexesTransport struct {
+	Start   int `json:"start"`
+	Current int `json:"current"`
+	Target  int `json:"target"`
+}
+
+type statusResponseSyncTransport struct {
+	Since   int            `json:"since"`
+	Plugins map[string]int `json:"plugins"`
+	Sync    bool           `json:"sync"`
+}
+
+type statusResponsePeerTransport struct {
+	Key   string `json:"key"`
+	State string `json:"state"`
+}
@@ -0,0 +1,27 @@
+package messages_test
+
+import (
+	"testing"
+
+	"github.com/planetary-social/scuttlego/service/domain/identity"
+	"github.com/planetary-social/scuttlego/service/domain/messages"
+	"github.com/planetary-social/scuttlego/service/domain/refs"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatusResponse_MarshalJSON(t *testing.T) {
+	peer := refs.MustNewIdentity("@qFtLJ6P5Eh9vKxnj7Rsh8SkE6B6Z36DVLP7ZOKNeQ/Y=.ed25519")
+
+	response := messages.NewStatusResponse(10, []identity.Public{peer.Identity()})
+
+	j, err := response.MarshalJSON()
+	require.NoError(t, err)
+	require.JSONEq(t,
+		`{
+			"progress":{"indexes":{"start":10,"current":10,"target":10}},
+			"sync":{"since":10,"plugins":{},"sync":true},
+			"peers":[{"key":"@qFtLJ6P5Eh9vKxnj7Rsh8SkE6B6Z36DVLP7ZOKNeQ/Y=.ed25519","state":"connected"}]
+		}`,
+		string(j),
+	)
+}
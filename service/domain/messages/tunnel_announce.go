@@ -0,0 +1,20 @@
+package messages
+
+import (
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc"
+)
+
+var (
+	TunnelAnnounceProcedure = rpc.MustNewProcedure(
+		rpc.MustNewProcedureName([]string{"tunnel", "announce"}),
+		rpc.ProcedureTypeAsync,
+	)
+)
+
+func NewTunnelAnnounce() (*rpc.Request, error) {
+	return rpc.NewRequest(
+		TunnelAnnounceProcedure.Name(),
+		TunnelAnnounceProcedure.Typ(),
+		[]byte("[]"),
+	)
+}
@@ -63,6 +63,37 @@ func NewTunnelConnectToPortalArguments(
 	}, nil
 }
 
+func NewTunnelConnectToPortalArgumentsFromBytes(b []byte) (TunnelConnectToPortalArguments, error) {
+	var args []tunnelConnectToPortalArgumentsTransport
+	if err := jsoniter.Unmarshal(b, &args); err != nil {
+		return TunnelConnectToPortalArguments{}, errors.Wrap(err, "json unmarshal failed")
+	}
+
+	if len(args) != 1 {
+		return TunnelConnectToPortalArguments{}, errors.New("expected exactly one argument")
+	}
+
+	portal, err := refs.NewIdentity(args[0].Portal)
+	if err != nil {
+		return TunnelConnectToPortalArguments{}, errors.New("error creating portal ref")
+	}
+
+	target, err := refs.NewIdentity(args[0].Target)
+	if err != nil {
+		return TunnelConnectToPortalArguments{}, errors.New("error creating target ref")
+	}
+
+	return NewTunnelConnectToPortalArguments(portal, target)
+}
+
+func (i TunnelConnectToPortalArguments) Portal() refs.Identity {
+	return i.portal
+}
+
+func (i TunnelConnectToPortalArguments) Target() refs.Identity {
+	return i.target
+}
+
 func (i TunnelConnectToPortalArguments) MarshalJSON() ([]byte, error) {
 	return jsoniter.Marshal([]tunnelConnectToPortalArgumentsTransport{
 		{
@@ -156,6 +187,26 @@ func (t TunnelConnectToTargetArguments) MarshalJSON() ([]byte, error) {
 	})
 }
 
+// IsTunnelConnectToTarget returns true if the arguments of a tunnel.connect
+// request contain the origin which means that they were sent by a room to the
+// target. Otherwise they were sent by the origin to the room.
+func IsTunnelConnectToTarget(b []byte) (bool, error) {
+	var args []tunnelConnectOriginTransport
+	if err := jsoniter.Unmarshal(b, &args); err != nil {
+		return false, errors.Wrap(err, "json unmarshal failed")
+	}
+
+	if len(args) != 1 {
+		return false, errors.New("expected exactly one argument")
+	}
+
+	return args[0].Origin != nil, nil
+}
+
+type tunnelConnectOriginTransport struct {
+	Origin *string `json:"origin"`
+}
+
 type tunnelConnectToPortalArgumentsTransport struct {
 	Portal string `json:"portal"`
 	Target string `json:"target"`
@@ -59,3 +59,57 @@ func TestNewTunnelConnectToTargetArgumentsFromBytes(t *testing.T) {
 	require.Equal(t, args.Target(), target)
 	require.Equal(t, args.Origin(), origin)
 }
+
+func TestNewTunnelConnectToPortalArgumentsFromBytes(t *testing.T) {
+	portal := refs.MustNewIdentity("@650YpEeEBF2H88Z88idG6ZWvWiU2eVG6ov9s1HHEg/E=.ed25519")
+	target := refs.MustNewIdentity("@gYVa2GgdDYbR6R4AFnk5y2aU0sQirNIIoAcpOUh/aZk=.ed25519")
+
+	j := fmt.Sprintf(`[{"target": "%s", "portal":"%s"}]`, target, portal)
+
+	args, err := messages.NewTunnelConnectToPortalArgumentsFromBytes([]byte(j))
+	require.NoError(t, err)
+	require.Equal(t, args.Portal(), portal)
+	require.Equal(t, args.Target(), target)
+}
+
+func TestIsTunnelConnectToTarget(t *testing.T) {
+	testCases := []struct {
+		Name          string
+		Arguments     string
+		Expected      bool
+		ExpectedError bool
+	}{
+		{
+			Name:      "with_origin",
+			Arguments: `[{"origin": "invalid", "target": "invalid", "portal": "invalid"}]`,
+			Expected:  true,
+		},
+		{
+			Name:      "without_origin",
+			Arguments: `[{"target": "invalid", "portal": "invalid"}]`,
+			Expected:  false,
+		},
+		{
+			Name:          "no_arguments",
+			Arguments:     `[]`,
+			ExpectedError: true,
+		},
+		{
+			Name:          "invalid_json",
+			Arguments:     `{`,
+			ExpectedError: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			toTarget, err := messages.IsTunnelConnectToTarget([]byte(testCase.Arguments))
+			if testCase.ExpectedError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, testCase.Expected, toTarget)
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package messages
+
+import (
+	"github.com/boreq/errors"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/planetary-social/scuttlego/service/domain/refs"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc"
+)
+
+var (
+	TunnelEndpointsProcedure = rpc.MustNewProcedure(
+		rpc.MustNewProcedureName([]string{"tunnel", "endpoints"}),
+		rpc.ProcedureTypeSource,
+	)
+)
+
+func NewTunnelEndpoints() (*rpc.Request, error) {
+	return rpc.NewRequest(
+		TunnelEndpointsProcedure.Name(),
+		TunnelEndpointsProcedure.Typ(),
+		[]byte("[]"),
+	)
+}
+
+// TunnelEndpointsResponse lists all attendants of a room which can be reached
+// using tunnel.connect. A new response is sent every time the list changes.
+type TunnelEndpointsResponse struct {
+	ids []refs.Identity
+}
+
+func NewTunnelEndpointsResponse(ids []refs.Identity) (TunnelEndpointsResponse, error) {
+	for _, id := range ids {
+		if id.IsZero() {
+			return TunnelEndpointsResponse{}, errors.New("zero value of id")
+		}
+	}
+	return TunnelEndpointsResponse{
+		ids: ids,
+	}, nil
+}
+
+func MustNewTunnelEndpointsResponse(ids []refs.Identity) TunnelEndpointsResponse {
+	v, err := NewTunnelEndpointsResponse(ids)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+func NewTunnelEndpointsResponseFromBytes(b []byte) (TunnelEndpointsResponse, error) {
+	var transport []string
+	if err := jsoniter.Unmarshal(b, &transport); err != nil {
+		return TunnelEndpointsResponse{}, errors.Wrap(err, "json unmarshal failed")
+	}
+
+	var ids []refs.Identity
+	for _, s := range transport {
+		id, err := refs.NewIdentity(s)
+		if err != nil {
+			return TunnelEndpointsResponse{}, errors.Wrap(err, "error creating a ref")
+		}
+		ids = append(ids, id)
+	}
+
+	return NewTunnelEndpointsResponse(ids)
+}
+
+func (r TunnelEndpointsResponse) Ids() []refs.Identity {
+	return r.ids
+}
+
+func (r TunnelEndpointsResponse) MarshalJSON() ([]byte, error) {
+	transport := make([]string, 0, len(r.ids))
+	for _, id := range r.ids {
+		transport = append(transport, id.String())
+	}
+	return jsoniter.Marshal(transport)
+}
@@ -0,0 +1,41 @@
+package messages_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/planetary-social/scuttlego/internal/fixtures"
+	"github.com/planetary-social/scuttlego/service/domain/messages"
+	"github.com/planetary-social/scuttlego/service/domain/refs"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewTunnelEndpoints(t *testing.T) {
+	req, err := messages.NewTunnelEndpoints()
+	require.NoError(t, err)
+	require.Equal(t, rpc.ProcedureTypeSource, req.Type())
+	require.Equal(t, rpc.MustNewProcedureName([]string{"tunnel", "endpoints"}), req.Name())
+	require.Equal(t, json.RawMessage("[]"), req.Arguments())
+}
+
+func TestTunnelEndpointsResponse_MarshalAndUnmarshal(t *testing.T) {
+	ids := []refs.Identity{
+		fixtures.SomeRefIdentity(),
+		fixtures.SomeRefIdentity(),
+	}
+
+	j, err := messages.MustNewTunnelEndpointsResponse(ids).MarshalJSON()
+	require.NoError(t, err)
+	require.JSONEq(t, `["`+ids[0].String()+`", "`+ids[1].String()+`"]`, string(j))
+
+	response, err := messages.NewTunnelEndpointsResponseFromBytes(j)
+	require.NoError(t, err)
+	require.Equal(t, ids, response.Ids())
+}
+
+func TestTunnelEndpointsResponse_EmptyListIsMarshaledAsAnEmptyArray(t *testing.T) {
+	j, err := messages.MustNewTunnelEndpointsResponse(nil).MarshalJSON()
+	require.NoError(t, err)
+	require.Equal(t, `[]`, string(j))
+}
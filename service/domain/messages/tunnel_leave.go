@@ -0,0 +1,20 @@
+package messages
+
+import (
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc"
+)
+
+var (
+	TunnelLeaveProcedure = rpc.MustNewProcedure(
+		rpc.MustNewProcedureName([]string{"tunnel", "leave"}),
+		rpc.ProcedureTypeAsync,
+	)
+)
+
+func NewTunnelLeave() (*rpc.Request, error) {
+	return rpc.NewRequest(
+		TunnelLeaveProcedure.Name(),
+		TunnelLeaveProcedure.Typ(),
+		[]byte("[]"),
+	)
+}
@@ -0,0 +1,74 @@
+package messages
+
+import (
+	"github.com/boreq/errors"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/planetary-social/scuttlego/service/domain/refs"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc"
+)
+
+var (
+	WhoamiProcedure = rpc.MustNewProcedure(
+		rpc.MustNewProcedureName([]string{"whoami"}),
+		rpc.ProcedureTypeAsync,
+	)
+)
+
+func NewWhoami() (*rpc.Request, error) {
+	return rpc.NewRequest(
+		WhoamiProcedure.Name(),
+		WhoamiProcedure.Typ(),
+		[]byte("[]"),
+	)
+}
+
+type WhoamiResponse struct {
+	id refs.Identity
+}
+
+func NewWhoamiResponse(id refs.Identity) (WhoamiResponse, error) {
+	if id.IsZero() {
+		return WhoamiResponse{}, errors.New("zero value of id")
+	}
+
+	return WhoamiResponse{
+		id: id,
+	}, nil
+}
+
+func MustNewWhoamiResponse(id refs.Identity) WhoamiResponse {
+	v, err := NewWhoamiResponse(id)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+func NewWhoamiResponseFromBytes(b []byte) (WhoamiResponse, error) {
+	var transport whoamiResponseTransport
+
+	if err := jsoniter.Unmarshal(b, &transport); err != nil {
+		return WhoamiResponse{}, errors.Wrap(err, "json unmarshal failed")
+	}
+
+	id, err := refs.NewIdentity(transport.Id)
+	if err != nil {
+		return WhoamiResponse{}, errors.Wrap(err, "invalid id")
+	}
+
+	return NewWhoamiResponse(id)
+}
+
+func (r WhoamiResponse) Id() refs.Identity {
+	return r.id
+}
+
+func (r WhoamiResponse) MarshalJSON() ([]byte, error) {
+	return jsoniter.Marshal(whoamiResponseTransport{
+		Id: r.id.String(),
+	})
+}
+
+type whoamiResponseTransport struct {
+	Id string `json:"id"`
+}
@@ -0,0 +1,31 @@
+package messages_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/planetary-social/scuttlego/internal/fixtures"
+	"github.com/planetary-social/scuttlego/service/domain/messages"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewWhoami(t *testing.T) {
+	req, err := messages.NewWhoami()
+	require.NoError(t, err)
+	require.Equal(t, rpc.ProcedureTypeAsync, req.Type())
+	require.Equal(t, rpc.MustNewProcedureName([]string{"whoami"}), req.Name())
+	require.Equal(t, json.RawMessage(`[]`), req.Arguments())
+}
+
+func TestWhoamiResponse_MarshalAndUnmarshal(t *testing.T) {
+	id := fixtures.SomeRefIdentity()
+
+	j, err := messages.MustNewWhoamiResponse(id).MarshalJSON()
+	require.NoError(t, err)
+	require.JSONEq(t, `{"id":"`+id.String()+`"}`, string(j))
+
+	response, err := messages.NewWhoamiResponseFromBytes(j)
+	require.NoError(t, err)
+	require.Equal(t, id, response.Id())
+}
@@ -1,5 +1,15 @@
 package network
 
+import "strings"
+
+const (
+	webSocketScheme       = "ws://"
+	secureWebSocketScheme = "wss://"
+)
+
+// Address is either a host and port pair in the format accepted by the net
+// package e.g. "example.com:8008" or a WebSocket URL e.g.
+// "wss://example.com:8989".
 type Address struct {
 	s string
 }
@@ -8,6 +18,12 @@ func NewAddress(s string) Address {
 	return Address{s}
 }
 
+// IsWebSocket returns true if this address is a WebSocket URL and should be
+// dialed using the WebSocket transport.
+func (a Address) IsWebSocket() bool {
+	return strings.HasPrefix(a.s, webSocketScheme) || strings.HasPrefix(a.s, secureWebSocketScheme)
+}
+
 func (a Address) String() string {
 	return a.s
 }
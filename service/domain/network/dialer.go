@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/boreq/errors"
+	"github.com/gorilla/websocket"
 	"github.com/planetary-social/scuttlego/logging"
 	"github.com/planetary-social/scuttlego/service/domain/identity"
 	"github.com/planetary-social/scuttlego/service/domain/transport"
@@ -35,11 +36,7 @@ func NewDialer(initializer ClientPeerInitializer, logger logging.Logger) (*Diale
 }
 
 func (d Dialer) DialWithInitializer(ctx context.Context, initializer ClientPeerInitializer, remote identity.Public, addr Address) (transport.Peer, error) {
-	dialer := net.Dialer{
-		Timeout: dialTimeout,
-	}
-
-	conn, err := dialer.Dial("tcp", addr.String())
+	conn, err := d.dial(ctx, addr)
 	if err != nil {
 		return transport.Peer{}, errors.Wrap(err, "could not dial")
 	}
@@ -55,3 +52,29 @@ func (d Dialer) DialWithInitializer(ctx context.Context, initializer ClientPeerI
 func (d Dialer) Dial(ctx context.Context, remote identity.Public, address Address) (transport.Peer, error) {
 	return d.DialWithInitializer(ctx, d.initializer, remote, address)
 }
+
+func (d Dialer) dial(ctx context.Context, addr Address) (io.ReadWriteCloser, error) {
+	if addr.IsWebSocket() {
+		return d.dialWebSocket(ctx, addr)
+	}
+
+	dialer := net.Dialer{
+		Timeout: dialTimeout,
+	}
+
+	return dialer.DialContext(ctx, "tcp", addr.String())
+}
+
+func (d Dialer) dialWebSocket(ctx context.Context, addr Address) (io.ReadWriteCloser, error) {
+	dialer := websocket.Dialer{
+		HandshakeTimeout: dialTimeout,
+	}
+
+	conn, response, err := dialer.DialContext(ctx, addr.String(), nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "websocket dial failed")
+	}
+	defer response.Body.Close()
+
+	return NewWebSocketConn(conn), nil
+}
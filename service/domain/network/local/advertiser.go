@@ -4,85 +4,171 @@ import (
 	"context"
 	"net"
 	"strconv"
+	"time"
 
 	"github.com/boreq/errors"
+	"github.com/planetary-social/scuttlego/logging"
 	"github.com/planetary-social/scuttlego/service/domain/identity"
-	"github.com/planetary-social/scuttlego/service/domain/refs"
-	ssbrefs "github.com/ssbc/go-ssb-refs"
-	ssbnetwork "github.com/ssbc/go-ssb/network"
-	"golang.org/x/crypto/ed25519"
 )
 
+const advertiseEvery = 5 * time.Second
+
+var ipv6LinkLocalAllNodes = net.ParseIP("ff02::1")
+
+// Advertiser periodically sends announcements to other nodes in the local
+// network. Announcements are broadcast on all IPv4 networks and multicast to
+// all nodes on all IPv6 links which the machine is connected to.
 type Advertiser struct {
-	advertiser *ssbnetwork.Advertiser
+	local identity.Public
+
+	// ip limits the addresses which are announced, if this is nil then all
+	// local addresses are announced.
+	ip   net.IP
+	port int
+
+	logger logging.Logger
 }
 
-func NewAdvertiser(local identity.Public, address string) (*Advertiser, error) {
-	kp, err := newKeyPair(local)
-	if err != nil {
-		return nil, errors.Wrap(err, "could not create a new key pair")
+// NewAdvertiser creates an advertiser which announces the provided listen
+// address. The address should be formatted in the way which can be handled by
+// the net package e.g. ":8008". If the host is not specified then all
+// addresses in local networks are announced.
+func NewAdvertiser(local identity.Public, address string, logger logging.Logger) (*Advertiser, error) {
+	if local.IsZero() {
+		return nil, errors.New("zero value of local identity")
 	}
 
-	host, portStr, err := net.SplitHostPort(address)
+	host, portString, err := net.SplitHostPort(address)
 	if err != nil {
 		return nil, errors.Wrap(err, "could not split host port")
 	}
 
-	port, err := strconv.Atoi(portStr)
+	port, err := strconv.Atoi(portString)
 	if err != nil {
 		return nil, errors.Wrap(err, "could not convert the port to int")
 	}
 
-	ip := net.ParseIP(host)
+	var ip net.IP
+	if host != "" {
+		ip = net.ParseIP(host)
+		if ip == nil {
+			return nil, errors.New("host must be an ip address")
+		}
 
-	advertiser, err := ssbnetwork.NewAdvertiser(
-		&net.TCPAddr{
-			IP:   ip,
-			Port: port,
-		},
-		kp,
-	)
-	if err != nil {
-		return nil, errors.Wrap(err, "could not create a go-ssb advertiser")
+		if ip.IsUnspecified() {
+			ip = nil
+		}
 	}
 
 	return &Advertiser{
-		advertiser: advertiser,
+		local:  local,
+		ip:     ip,
+		port:   port,
+		logger: logger.New("advertiser"),
 	}, nil
-
 }
 
+// Run periodically sends announcements until the context is closed.
 func (a *Advertiser) Run(ctx context.Context) error {
-	// todo there is no way to see if the advertiser is running correctly or not, the errors will not even be printed
-	a.advertiser.Start()
-	<-ctx.Done()
-	a.advertiser.Stop()
+	for {
+		if err := a.advertise(); err != nil {
+			a.logger.Debug().WithError(err).Message("failed to advertise")
+		}
+
+		select {
+		case <-time.After(advertiseEvery):
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+func (a *Advertiser) advertise() error {
+	interfaces, err := net.Interfaces()
+	if err != nil {
+		return errors.Wrap(err, "error listing interfaces")
+	}
+
+	for _, iface := range interfaces {
+		if iface.Flags&net.FlagUp == 0 || iface.Flags&net.FlagLoopback != 0 {
+			continue
+		}
+
+		addrs, err := iface.Addrs()
+		if err != nil {
+			a.logger.Debug().WithError(err).WithField("interface", iface.Name).Message("error listing addresses")
+			continue
+		}
+
+		for _, addr := range addrs {
+			ipNet, ok := addr.(*net.IPNet)
+			if !ok || !a.shouldAnnounce(ipNet.IP) {
+				continue
+			}
+
+			if err := a.announce(iface, ipNet); err != nil {
+				a.logger.Trace().
+					WithError(err).
+					WithField("interface", iface.Name).
+					WithField("address", ipNet.String()).
+					Message("error sending an announcement")
+			}
+		}
+	}
+
 	return nil
 }
 
-type keyPair struct {
-	id refs.Feed
+func (a *Advertiser) shouldAnnounce(ip net.IP) bool {
+	if a.ip != nil {
+		return a.ip.Equal(ip)
+	}
+	return ip.IsPrivate() || ip.IsLinkLocalUnicast()
 }
 
-func newKeyPair(local identity.Public) (*keyPair, error) {
-	public, err := refs.NewIdentityFromPublic(local)
+func (a *Advertiser) announce(iface net.Interface, ipNet *net.IPNet) error {
+	announcement, err := NewAnnouncement(a.local, ipNet.IP, a.port)
 	if err != nil {
-		return nil, errors.Wrap(err, "could not create a new ref")
+		return errors.Wrap(err, "error creating the announcement")
 	}
 
-	return &keyPair{
-		id: public.MainFeed(),
-	}, nil
-}
+	var source, destination *net.UDPAddr
+
+	if ip4 := ipNet.IP.To4(); ip4 != nil {
+		if iface.Flags&net.FlagBroadcast == 0 {
+			return nil
+		}
+		source = &net.UDPAddr{IP: ip4}
+		destination = &net.UDPAddr{IP: broadcastAddress(ip4, ipNet.Mask), Port: DefaultPort}
+	} else {
+		if iface.Flags&net.FlagMulticast == 0 {
+			return nil
+		}
+		source = &net.UDPAddr{IP: ipNet.IP, Zone: iface.Name}
+		destination = &net.UDPAddr{IP: ipv6LinkLocalAllNodes, Port: DefaultPort, Zone: iface.Name}
+	}
 
-func (k keyPair) ID() ssbrefs.FeedRef {
-	ref, err := ssbrefs.ParseFeedRef(k.id.String())
+	conn, err := net.DialUDP("udp", source, destination)
 	if err != nil {
-		panic(err) // as programmers like to say: this should never happen
+		return errors.Wrap(err, "error dialing")
 	}
-	return ref
+	defer conn.Close()
+
+	if _, err := conn.Write(announcement.Bytes()); err != nil {
+		return errors.Wrap(err, "error writing")
+	}
+
+	return nil
 }
 
-func (k keyPair) Secret() ed25519.PrivateKey {
-	return nil // ssb doesn't actually need the secret
+func broadcastAddress(ip net.IP, mask net.IPMask) net.IP {
+	if len(mask) == net.IPv6len {
+		mask = mask[12:]
+	}
+
+	broadcast := make(net.IP, net.IPv4len)
+	for i := range broadcast {
+		broadcast[i] = ip[i] | ^mask[i]
+	}
+	return broadcast
 }
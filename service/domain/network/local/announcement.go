@@ -0,0 +1,147 @@
+// Package local implements discovery of other Secure Scuttlebutt nodes in the
+// local network using UDP broadcasts.
+package local
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/boreq/errors"
+	"github.com/planetary-social/scuttlego/service/domain/identity"
+	"github.com/planetary-social/scuttlego/service/domain/network"
+)
+
+const (
+	// DefaultPort is the UDP port on which the announcements are sent and
+	// received.
+	DefaultPort = 8008
+
+	// maxAnnouncementLength limits the size of the received packets which are
+	// processed, announcements are normally much shorter than this.
+	maxAnnouncementLength = 1024
+
+	netPrefix = "net:"
+)
+
+// Announcement is broadcast by nodes to inform other nodes in the local
+// network about their presence. Announcements use the multiserver address
+// format e.g. "net:192.168.1.10:8008~shs:<base64 key>". Multiple addresses can
+// be included in a single announcement, they are separated by semicolons.
+type Announcement struct {
+	remote identity.Public
+	ip     net.IP
+	port   int
+}
+
+func NewAnnouncement(remote identity.Public, ip net.IP, port int) (Announcement, error) {
+	if remote.IsZero() {
+		return Announcement{}, errors.New("zero value of remote")
+	}
+
+	if ip == nil {
+		return Announcement{}, errors.New("nil ip")
+	}
+
+	if port <= 0 || port > 65535 {
+		return Announcement{}, errors.New("invalid port")
+	}
+
+	return Announcement{
+		remote: remote,
+		ip:     ip,
+		port:   port,
+	}, nil
+}
+
+func MustNewAnnouncement(remote identity.Public, ip net.IP, port int) Announcement {
+	v, err := NewAnnouncement(remote, ip, port)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// NewAnnouncementFromBytes parses an announcement. The first address which
+// uses the "net" transport is selected and its host must be an IP address.
+func NewAnnouncementFromBytes(b []byte) (Announcement, error) {
+	if len(b) > maxAnnouncementLength {
+		return Announcement{}, errors.New("announcement too long")
+	}
+
+	var parseErr error
+
+	for _, candidate := range strings.Split(string(b), ";") {
+		if !strings.HasPrefix(candidate, netPrefix) {
+			continue
+		}
+
+		address, err := network.NewMultiserverAddress(candidate)
+		if err != nil {
+			parseErr = errors.Wrapf(err, "error parsing '%s'", candidate)
+			continue
+		}
+
+		ip, port, err := splitHostPort(address.Address().String())
+		if err != nil {
+			parseErr = errors.Wrapf(err, "error parsing address of '%s'", candidate)
+			continue
+		}
+
+		return NewAnnouncement(address.Remote(), ip, port)
+	}
+
+	if parseErr == nil {
+		parseErr = errors.New("no net addresses found")
+	}
+
+	return Announcement{}, parseErr
+}
+
+func (a Announcement) Remote() identity.Public {
+	return a.remote
+}
+
+func (a Announcement) IP() net.IP {
+	return a.ip
+}
+
+func (a Announcement) Port() int {
+	return a.port
+}
+
+// Bytes returns the announcement in the multiserver address format.
+func (a Announcement) Bytes() []byte {
+	hostPort := net.JoinHostPort(a.ip.String(), strconv.Itoa(a.port))
+	return []byte(fmt.Sprintf("%s%s~shs:%s", netPrefix, hostPort, a.remote.String()))
+}
+
+func (a Announcement) IsZero() bool {
+	return a.remote.IsZero()
+}
+
+// splitHostPort accepts both the bracketed and the unbracketed IPv6 addresses
+// as some implementations don't put IPv6 addresses in brackets.
+func splitHostPort(s string) (net.IP, int, error) {
+	host, portString, err := net.SplitHostPort(s)
+	if err != nil {
+		index := strings.LastIndex(s, ":")
+		if index < 0 {
+			return nil, 0, errors.New("missing port")
+		}
+		host, portString = s[:index], s[index+1:]
+	}
+
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return nil, 0, fmt.Errorf("host '%s' is not an ip address", host)
+	}
+
+	port, err := strconv.Atoi(portString)
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "invalid port")
+	}
+
+	return ip, port, nil
+}
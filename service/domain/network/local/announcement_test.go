@@ -0,0 +1,116 @@
+package local_test
+
+import (
+	"fmt"
+	"net"
+	"testing"
+
+	"github.com/planetary-social/scuttlego/internal/fixtures"
+	"github.com/planetary-social/scuttlego/service/domain/network/local"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnnouncement_BytesCanBeParsed(t *testing.T) {
+	testCases := []struct {
+		Name string
+		IP   net.IP
+	}{
+		{
+			Name: "ipv4",
+			IP:   net.ParseIP("192.168.1.10"),
+		},
+		{
+			Name: "ipv6",
+			IP:   net.ParseIP("fe80::1"),
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			remote := fixtures.SomePublicIdentity()
+			port := 8008
+
+			announcement := local.MustNewAnnouncement(remote, testCase.IP, port)
+
+			parsed, err := local.NewAnnouncementFromBytes(announcement.Bytes())
+			require.NoError(t, err)
+
+			require.True(t, remote.Equal(parsed.Remote()))
+			require.True(t, testCase.IP.Equal(parsed.IP()))
+			require.Equal(t, port, parsed.Port())
+		})
+	}
+}
+
+func TestNewAnnouncementFromBytes(t *testing.T) {
+	remote := fixtures.SomePublicIdentity()
+	key := remote.String()
+
+	testCases := []struct {
+		Name          string
+		Announcement  string
+		ExpectedIP    net.IP
+		ExpectedPort  int
+		ExpectedError bool
+	}{
+		{
+			Name:         "ipv4",
+			Announcement: fmt.Sprintf("net:192.168.1.10:8008~shs:%s", key),
+			ExpectedIP:   net.ParseIP("192.168.1.10"),
+			ExpectedPort: 8008,
+		},
+		{
+			Name:         "bracketed_ipv6",
+			Announcement: fmt.Sprintf("net:[fe80::1]:8008~shs:%s", key),
+			ExpectedIP:   net.ParseIP("fe80::1"),
+			ExpectedPort: 8008,
+		},
+		{
+			Name:         "unbracketed_ipv6",
+			Announcement: fmt.Sprintf("net:fe80::1:8008~shs:%s", key),
+			ExpectedIP:   net.ParseIP("fe80::1"),
+			ExpectedPort: 8008,
+		},
+		{
+			Name:         "multiple_addresses",
+			Announcement: fmt.Sprintf("ws://192.168.1.10:8989~shs:%s;net:192.168.1.10:8008~shs:%s", key, key),
+			ExpectedIP:   net.ParseIP("192.168.1.10"),
+			ExpectedPort: 8008,
+		},
+		{
+			Name:          "hostname",
+			Announcement:  fmt.Sprintf("net:example.com:8008~shs:%s", key),
+			ExpectedError: true,
+		},
+		{
+			Name:          "only_ws",
+			Announcement:  fmt.Sprintf("ws://192.168.1.10:8989~shs:%s", key),
+			ExpectedError: true,
+		},
+		{
+			Name:          "invalid_key",
+			Announcement:  "net:192.168.1.10:8008~shs:invalid",
+			ExpectedError: true,
+		},
+		{
+			Name:          "garbage",
+			Announcement:  "garbage",
+			ExpectedError: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			announcement, err := local.NewAnnouncementFromBytes([]byte(testCase.Announcement))
+			if testCase.ExpectedError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			require.True(t, remote.Equal(announcement.Remote()))
+			require.True(t, testCase.ExpectedIP.Equal(announcement.IP()))
+			require.Equal(t, testCase.ExpectedPort, announcement.Port())
+		})
+	}
+}
@@ -3,14 +3,30 @@ package local
 import (
 	"context"
 	"net"
+	"strconv"
+	"sync"
+	"time"
 
 	"github.com/boreq/errors"
 	"github.com/planetary-social/scuttlego/logging"
 	"github.com/planetary-social/scuttlego/service/domain/identity"
 	"github.com/planetary-social/scuttlego/service/domain/network"
-	"github.com/planetary-social/scuttlego/service/domain/refs"
-	"github.com/ssbc/go-netwrap"
-	ssbnetwork "github.com/ssbc/go-ssb/network"
+)
+
+const (
+	// announcementRateLimitPeriod is the period within which at most one
+	// announcement sent by each remote is processed.
+	announcementRateLimitPeriod = 15 * time.Second
+
+	// maxAnnouncementsPerRateLimitPeriod limits the total number of
+	// processed announcements regardless of their origin.
+	maxAnnouncementsPerRateLimitPeriod = 50
+
+	// readErrorInitialBackoff and readErrorMaxBackoff bound the delay
+	// between reads performed after a read error. The delay doubles with
+	// each consecutive error and is reset after a successful read.
+	readErrorInitialBackoff = 100 * time.Millisecond
+	readErrorMaxBackoff     = 10 * time.Second
 )
 
 type IdentityWithAddress struct {
@@ -18,73 +34,189 @@ type IdentityWithAddress struct {
 	Address network.Address
 }
 
+// Discoverer receives announcements sent by other nodes in the local network
+// over IPv4 and IPv6.
 type Discoverer struct {
-	discoverer *ssbnetwork.Discoverer
-	logger     logging.Logger
+	local identity.Public
+	port  int
+
+	rateLimiter     *announcementRateLimiter
+	rateLimiterLock sync.Mutex
+
+	logger logging.Logger
 }
 
 func NewDiscoverer(local identity.Public, logger logging.Logger) (*Discoverer, error) {
-	kp, err := newKeyPair(local)
-	if err != nil {
-		return nil, errors.Wrap(err, "could not create a new key pair")
-	}
-
-	discoverer, err := ssbnetwork.NewDiscoverer(
-		kp,
-	)
-	if err != nil {
-		return nil, errors.Wrap(err, "could not create a go-ssb discoverer")
+	if local.IsZero() {
+		return nil, errors.New("zero value of local identity")
 	}
 
 	return &Discoverer{
-		discoverer: discoverer,
-		logger:     logger.New("discoverer"),
+		local:       local,
+		port:        DefaultPort,
+		rateLimiter: newAnnouncementRateLimiter(announcementRateLimitPeriod, maxAnnouncementsPerRateLimitPeriod),
+		logger:      logger.New("discoverer"),
 	}, nil
-
 }
 
+// Run receives announcements until the context is closed. Announcements sent
+// by the local identity are ignored. The returned channel is closed when the
+// context is closed.
 func (d *Discoverer) Run(ctx context.Context) <-chan IdentityWithAddress {
 	ch := make(chan IdentityWithAddress)
 
-	ssbCh, cancel := d.discoverer.Notify()
+	go func() {
+		defer close(ch)
+		d.run(ctx, ch)
+	}()
+
+	return ch
+}
+
+func (d *Discoverer) run(ctx context.Context, ch chan<- IdentityWithAddress) {
+	var conns []net.PacketConn
+
+	for _, udpNetwork := range []string{"udp4", "udp6"} {
+		conn, err := d.listen(ctx, udpNetwork)
+		if err != nil {
+			d.logger.Debug().
+				WithError(err).
+				WithField("network", udpNetwork).
+				Message("failed to listen for announcements")
+			continue
+		}
+		conns = append(conns, conn)
+	}
+
+	if len(conns) == 0 {
+		d.logger.Error().Message("failed to listen for announcements on all networks")
+		<-ctx.Done()
+		return
+	}
 
 	go func() {
 		<-ctx.Done()
-		cancel()
+		for _, conn := range conns {
+			if err := conn.Close(); err != nil {
+				d.logger.Debug().WithError(err).Message("error closing the connection")
+			}
+		}
 	}()
 
-	go func() {
-		defer close(ch)
-		for update := range ssbCh {
-			v, err := d.convert(update)
-			if err != nil {
-				d.logger.Error().WithError(err).Message("conversion failed")
-				continue
+	wg := &sync.WaitGroup{}
+	for _, conn := range conns {
+		conn := conn
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			d.readLoop(ctx, conn, ch)
+		}()
+	}
+	wg.Wait()
+}
+
+func (d *Discoverer) listen(ctx context.Context, udpNetwork string) (net.PacketConn, error) {
+	lc := net.ListenConfig{
+		Control: reuseAddressAndPort,
+	}
+
+	conn, err := lc.ListenPacket(ctx, udpNetwork, net.JoinHostPort("", strconv.Itoa(d.port)))
+	if err != nil {
+		return nil, errors.Wrap(err, "error listening")
+	}
+
+	return conn, nil
+}
+
+func (d *Discoverer) readLoop(ctx context.Context, conn net.PacketConn, ch chan<- IdentityWithAddress) {
+	buf := make([]byte, maxAnnouncementLength+1)
+	backoff := readErrorInitialBackoff
+
+	for {
+		n, addr, err := conn.ReadFrom(buf)
+		if err != nil {
+			if ctx.Err() != nil || errors.Is(err, net.ErrClosed) {
+				return
 			}
 
+			d.logger.Debug().
+				WithError(err).
+				WithField("backoff", backoff).
+				Message("error reading an announcement")
+
 			select {
-			case ch <- v:
-				continue
+			case <-time.After(backoff):
 			case <-ctx.Done():
 				return
 			}
+
+			backoff = nextReadErrorBackoff(backoff)
+			continue
 		}
-	}()
 
-	return ch
+		backoff = readErrorInitialBackoff
+
+		source, ok := addr.(*net.UDPAddr)
+		if !ok {
+			continue
+		}
+
+		v, err := d.handleAnnouncement(buf[:n], source, time.Now())
+		if err != nil {
+			d.logger.Trace().
+				WithError(err).
+				WithField("source", source.String()).
+				Message("ignoring an announcement")
+			continue
+		}
+
+		select {
+		case ch <- v:
+		case <-ctx.Done():
+			return
+		}
+	}
 }
 
-func (d *Discoverer) convert(update net.Addr) (IdentityWithAddress, error) {
-	refString := update.(netwrap.Addr).Head().String()
-	addrString := update.(netwrap.Addr).Inner().String()
+func nextReadErrorBackoff(backoff time.Duration) time.Duration {
+	backoff *= 2
+	if backoff > readErrorMaxBackoff {
+		return readErrorMaxBackoff
+	}
+	return backoff
+}
 
-	ref, err := refs.NewIdentity(refString)
+func (d *Discoverer) handleAnnouncement(b []byte, source *net.UDPAddr, now time.Time) (IdentityWithAddress, error) {
+	announcement, err := NewAnnouncementFromBytes(b)
 	if err != nil {
-		return IdentityWithAddress{}, errors.Wrapf(err, "could not create an identity ref from '%s'", refString)
+		return IdentityWithAddress{}, errors.Wrap(err, "error parsing the announcement")
+	}
+
+	if announcement.Remote().Equal(d.local) {
+		return IdentityWithAddress{}, errors.New("announcement sent by the local identity")
+	}
+
+	// This prevents others from making us connect to arbitrary hosts.
+	if !source.IP.Equal(announcement.IP()) {
+		return IdentityWithAddress{}, errors.New("announcement wasn't sent from the announced address")
+	}
+
+	// Using the source address preserves the zone of link-local IPv6
+	// addresses which is required to connect to them.
+	sourceWithoutPort := net.IPAddr{IP: source.IP, Zone: source.Zone}
+	address := network.NewAddress(net.JoinHostPort(sourceWithoutPort.String(), strconv.Itoa(announcement.Port())))
+
+	d.rateLimiterLock.Lock()
+	allowed := d.rateLimiter.Allow(announcement.Remote().String(), now)
+	d.rateLimiterLock.Unlock()
+
+	if !allowed {
+		return IdentityWithAddress{}, errors.New("rate limited")
 	}
 
 	return IdentityWithAddress{
-		Remote:  ref.Identity(),
-		Address: network.NewAddress(addrString),
+		Remote:  announcement.Remote(),
+		Address: address,
 	}, nil
 }
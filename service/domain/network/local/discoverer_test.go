@@ -0,0 +1,132 @@
+package local
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/planetary-social/scuttlego/internal/fixtures"
+	"github.com/planetary-social/scuttlego/service/domain/network"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiscoverer_HandleAnnouncement(t *testing.T) {
+	localIdentity := fixtures.SomePublicIdentity()
+	remote := fixtures.SomePublicIdentity()
+
+	testCases := []struct {
+		Name            string
+		Announcement    Announcement
+		Source          *net.UDPAddr
+		ExpectedAddress network.Address
+		ExpectedError   bool
+	}{
+		{
+			Name:            "ipv4",
+			Announcement:    MustNewAnnouncement(remote, net.ParseIP("192.168.1.10"), 8008),
+			Source:          &net.UDPAddr{IP: net.ParseIP("192.168.1.10"), Port: 12345},
+			ExpectedAddress: network.NewAddress("192.168.1.10:8008"),
+		},
+		{
+			Name:            "ipv6_zone_is_preserved",
+			Announcement:    MustNewAnnouncement(remote, net.ParseIP("fe80::1"), 8008),
+			Source:          &net.UDPAddr{IP: net.ParseIP("fe80::1"), Port: 12345, Zone: "eth0"},
+			ExpectedAddress: network.NewAddress("[fe80::1%eth0]:8008"),
+		},
+		{
+			Name:          "announced_address_differs_from_source",
+			Announcement:  MustNewAnnouncement(remote, net.ParseIP("192.168.1.10"), 8008),
+			Source:        &net.UDPAddr{IP: net.ParseIP("192.168.1.11"), Port: 12345},
+			ExpectedError: true,
+		},
+		{
+			Name:          "local_identity",
+			Announcement:  MustNewAnnouncement(localIdentity, net.ParseIP("192.168.1.10"), 8008),
+			Source:        &net.UDPAddr{IP: net.ParseIP("192.168.1.10"), Port: 12345},
+			ExpectedError: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			discoverer, err := NewDiscoverer(localIdentity, fixtures.TestLogger(t))
+			require.NoError(t, err)
+
+			v, err := discoverer.handleAnnouncement(testCase.Announcement.Bytes(), testCase.Source, time.Now())
+			if testCase.ExpectedError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			require.True(t, remote.Equal(v.Remote))
+			require.Equal(t, testCase.ExpectedAddress, v.Address)
+		})
+	}
+}
+
+func TestDiscoverer_HandleAnnouncementIsRateLimited(t *testing.T) {
+	discoverer, err := NewDiscoverer(fixtures.SomePublicIdentity(), fixtures.TestLogger(t))
+	require.NoError(t, err)
+
+	ip := net.ParseIP("192.168.1.10")
+	announcement := MustNewAnnouncement(fixtures.SomePublicIdentity(), ip, 8008)
+	source := &net.UDPAddr{IP: ip, Port: 12345}
+	now := time.Now()
+
+	_, err = discoverer.handleAnnouncement(announcement.Bytes(), source, now)
+	require.NoError(t, err)
+
+	_, err = discoverer.handleAnnouncement(announcement.Bytes(), source, now)
+	require.Error(t, err)
+
+	_, err = discoverer.handleAnnouncement(announcement.Bytes(), source, now.Add(announcementRateLimitPeriod))
+	require.NoError(t, err)
+}
+
+func TestDiscoverer_ReadLoopBacksOffAfterReadErrors(t *testing.T) {
+	discoverer, err := NewDiscoverer(fixtures.SomePublicIdentity(), fixtures.TestLogger(t))
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(fixtures.TestContext(t), 250*time.Millisecond)
+	defer cancel()
+
+	conn := newFailingPacketConnMock()
+	discoverer.readLoop(ctx, conn, make(chan IdentityWithAddress))
+
+	// Reads are performed after 0, 100 and 300 milliseconds.
+	require.LessOrEqual(t, conn.Reads(), 3)
+}
+
+func TestNextReadErrorBackoff(t *testing.T) {
+	require.Equal(t, 2*readErrorInitialBackoff, nextReadErrorBackoff(readErrorInitialBackoff))
+	require.Equal(t, readErrorMaxBackoff, nextReadErrorBackoff(readErrorMaxBackoff))
+	require.Equal(t, readErrorMaxBackoff, nextReadErrorBackoff(readErrorMaxBackoff-time.Millisecond))
+}
+
+type failingPacketConnMock struct {
+	net.PacketConn
+
+	reads     int
+	readsLock sync.Mutex
+}
+
+func newFailingPacketConnMock() *failingPacketConnMock {
+	return &failingPacketConnMock{}
+}
+
+func (f *failingPacketConnMock) ReadFrom(p []byte) (int, net.Addr, error) {
+	f.readsLock.Lock()
+	defer f.readsLock.Unlock()
+	f.reads++
+	return 0, nil, errors.New("some error")
+}
+
+func (f *failingPacketConnMock) Reads() int {
+	f.readsLock.Lock()
+	defer f.readsLock.Unlock()
+	return f.reads
+}
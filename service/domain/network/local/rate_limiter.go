@@ -0,0 +1,56 @@
+package local
+
+import (
+	"time"
+)
+
+// announcementRateLimiter decides which announcements should be processed.
+// Each remote is processed at most once per the configured period and no more
+// than the configured number of announcements are processed in total per
+// period. The second limit prevents nodes sending announcements with random
+// identities from triggering an unbounded number of connection attempts.
+type announcementRateLimiter struct {
+	period            time.Duration
+	maxPerPeriod      int
+	lastProcessed     map[string]time.Time
+	periodStart       time.Time
+	processedInPeriod int
+}
+
+func newAnnouncementRateLimiter(period time.Duration, maxPerPeriod int) *announcementRateLimiter {
+	return &announcementRateLimiter{
+		period:        period,
+		maxPerPeriod:  maxPerPeriod,
+		lastProcessed: make(map[string]time.Time),
+	}
+}
+
+// Allow returns true if the announcement of the provided remote should be
+// processed. The key should uniquely identify the remote.
+func (r *announcementRateLimiter) Allow(key string, now time.Time) bool {
+	if now.Sub(r.periodStart) >= r.period {
+		r.periodStart = now
+		r.processedInPeriod = 0
+		r.cleanup(now)
+	}
+
+	if last, ok := r.lastProcessed[key]; ok && now.Sub(last) < r.period {
+		return false
+	}
+
+	if r.processedInPeriod >= r.maxPerPeriod {
+		return false
+	}
+
+	r.lastProcessed[key] = now
+	r.processedInPeriod++
+	return true
+}
+
+func (r *announcementRateLimiter) cleanup(now time.Time) {
+	for key, last := range r.lastProcessed {
+		if now.Sub(last) >= r.period {
+			delete(r.lastProcessed, key)
+		}
+	}
+}
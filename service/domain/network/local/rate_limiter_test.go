@@ -0,0 +1,32 @@
+package local
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnnouncementRateLimiter_EachKeyIsAllowedOncePerPeriod(t *testing.T) {
+	period := 10 * time.Second
+	limiter := newAnnouncementRateLimiter(period, 10)
+
+	now := time.Now()
+
+	require.True(t, limiter.Allow("a", now))
+	require.True(t, limiter.Allow("b", now))
+	require.False(t, limiter.Allow("a", now.Add(period/2)))
+	require.True(t, limiter.Allow("a", now.Add(period)))
+}
+
+func TestAnnouncementRateLimiter_TotalNumberOfAllowedKeysIsLimitedPerPeriod(t *testing.T) {
+	period := 10 * time.Second
+	limiter := newAnnouncementRateLimiter(period, 2)
+
+	now := time.Now()
+
+	require.True(t, limiter.Allow("a", now))
+	require.True(t, limiter.Allow("b", now))
+	require.False(t, limiter.Allow("c", now))
+	require.True(t, limiter.Allow("c", now.Add(period)))
+}
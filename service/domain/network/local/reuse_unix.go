@@ -0,0 +1,30 @@
+//go:build !windows
+
+package local
+
+import (
+	"syscall"
+
+	"github.com/boreq/errors"
+	"golang.org/x/sys/unix"
+)
+
+// reuseAddressAndPort makes it possible for multiple Secure Scuttlebutt
+// clients running on the same machine to receive the announcements.
+func reuseAddressAndPort(network, address string, c syscall.RawConn) error {
+	var sockoptErr error
+	if err := c.Control(func(fd uintptr) {
+		if err := unix.SetsockoptInt(int(fd), unix.SOL_SOCKET, unix.SO_REUSEADDR, 1); err != nil {
+			sockoptErr = errors.Wrap(err, "error setting SO_REUSEADDR")
+			return
+		}
+
+		if err := unix.SetsockoptInt(int(fd), unix.SOL_SOCKET, unix.SO_REUSEPORT, 1); err != nil {
+			sockoptErr = errors.Wrap(err, "error setting SO_REUSEPORT")
+			return
+		}
+	}); err != nil {
+		return errors.Wrap(err, "control failed")
+	}
+	return sockoptErr
+}
@@ -0,0 +1,23 @@
+//go:build windows
+
+package local
+
+import (
+	"syscall"
+
+	"github.com/boreq/errors"
+)
+
+// reuseAddressAndPort makes it possible for multiple Secure Scuttlebutt
+// clients running on the same machine to receive the announcements.
+func reuseAddressAndPort(network, address string, c syscall.RawConn) error {
+	var sockoptErr error
+	if err := c.Control(func(fd uintptr) {
+		if err := syscall.SetsockoptInt(syscall.Handle(fd), syscall.SOL_SOCKET, syscall.SO_REUSEADDR, 1); err != nil {
+			sockoptErr = errors.Wrap(err, "error setting SO_REUSEADDR")
+		}
+	}); err != nil {
+		return errors.Wrap(err, "control failed")
+	}
+	return sockoptErr
+}
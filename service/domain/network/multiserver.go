@@ -0,0 +1,120 @@
+package network
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/boreq/errors"
+	"github.com/planetary-social/scuttlego/service/domain/identity"
+	"github.com/planetary-social/scuttlego/service/domain/refs"
+)
+
+const (
+	multiserverAddressSeparator   = ";"
+	multiserverTransformSeparator = "~"
+	multiserverNetPrefix          = "net:"
+	multiserverShsPrefix          = "shs:"
+)
+
+// MultiserverAddress is a single address in the multiserver format e.g.
+// "net:example.com:8008~shs:<base64 key>" or
+// "wss://example.com:8989~shs:<base64 key>".
+type MultiserverAddress struct {
+	remote  identity.Public
+	address Address
+}
+
+// NewMultiserverAddress parses a multiserver address. If the string contains
+// multiple addresses separated by semicolons the first one using a supported
+// transport is selected. Only the "net" and "ws" transports with the "shs"
+// transform are supported.
+func NewMultiserverAddress(s string) (MultiserverAddress, error) {
+	var parseErr error
+
+	for _, candidate := range strings.Split(s, multiserverAddressSeparator) {
+		address, err := newMultiserverAddress(candidate)
+		if err != nil {
+			parseErr = errors.Wrapf(err, "error parsing '%s'", candidate)
+			continue
+		}
+		return address, nil
+	}
+
+	return MultiserverAddress{}, errors.Wrap(parseErr, "no supported addresses found")
+}
+
+func MustNewMultiserverAddress(s string) MultiserverAddress {
+	v, err := NewMultiserverAddress(s)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+func newMultiserverAddress(s string) (MultiserverAddress, error) {
+	transport, transform, ok := strings.Cut(s, multiserverTransformSeparator)
+	if !ok {
+		return MultiserverAddress{}, errors.New("missing transform")
+	}
+
+	address, err := newAddressFromMultiserverTransport(transport)
+	if err != nil {
+		return MultiserverAddress{}, errors.Wrap(err, "error parsing the transport")
+	}
+
+	remote, err := newIdentityFromMultiserverTransform(transform)
+	if err != nil {
+		return MultiserverAddress{}, errors.Wrap(err, "error parsing the transform")
+	}
+
+	return MultiserverAddress{
+		remote:  remote,
+		address: address,
+	}, nil
+}
+
+func newAddressFromMultiserverTransport(s string) (Address, error) {
+	if strings.HasPrefix(s, multiserverNetPrefix) {
+		hostPort := strings.TrimPrefix(s, multiserverNetPrefix)
+		if hostPort == "" {
+			return Address{}, errors.New("empty host and port")
+		}
+		return NewAddress(hostPort), nil
+	}
+
+	address := NewAddress(s)
+	if address.IsWebSocket() {
+		return address, nil
+	}
+
+	return Address{}, errors.New("unsupported transport")
+}
+
+func newIdentityFromMultiserverTransform(s string) (identity.Public, error) {
+	if !strings.HasPrefix(s, multiserverShsPrefix) {
+		return identity.Public{}, errors.New("unsupported transform")
+	}
+
+	key := strings.TrimPrefix(s, multiserverShsPrefix)
+
+	ref, err := refs.NewIdentity(fmt.Sprintf("@%s.ed25519", key))
+	if err != nil {
+		return identity.Public{}, errors.Wrap(err, "invalid key")
+	}
+
+	return ref.Identity(), nil
+}
+
+// Remote returns the identity of the node listening on this address.
+func (m MultiserverAddress) Remote() identity.Public {
+	return m.remote
+}
+
+// Address returns the address which can be passed to the Dialer.
+func (m MultiserverAddress) Address() Address {
+	return m.address
+}
+
+func (m MultiserverAddress) IsZero() bool {
+	return m.address.IsZero()
+}
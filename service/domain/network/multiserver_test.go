@@ -0,0 +1,77 @@
+package network_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/planetary-social/scuttlego/internal/fixtures"
+	"github.com/planetary-social/scuttlego/service/domain/network"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewMultiserverAddress(t *testing.T) {
+	remote := fixtures.SomeRefIdentity()
+	key := strings.TrimSuffix(strings.TrimPrefix(remote.String(), "@"), ".ed25519")
+
+	testCases := []struct {
+		Name            string
+		Address         string
+		ExpectedAddress network.Address
+		ExpectedError   bool
+	}{
+		{
+			Name:            "net",
+			Address:         "net:example.com:8008~shs:" + key,
+			ExpectedAddress: network.NewAddress("example.com:8008"),
+		},
+		{
+			Name:            "ws",
+			Address:         "ws://example.com:8989~shs:" + key,
+			ExpectedAddress: network.NewAddress("ws://example.com:8989"),
+		},
+		{
+			Name:            "wss",
+			Address:         "wss://example.com~shs:" + key,
+			ExpectedAddress: network.NewAddress("wss://example.com"),
+		},
+		{
+			Name:            "first_supported_address_is_selected",
+			Address:         "onion:example.onion:8008~shs:" + key + ";ws://example.com:8989~shs:" + key,
+			ExpectedAddress: network.NewAddress("ws://example.com:8989"),
+		},
+		{
+			Name:          "unsupported_transport",
+			Address:       "onion:example.onion:8008~shs:" + key,
+			ExpectedError: true,
+		},
+		{
+			Name:          "unsupported_transform",
+			Address:       "net:example.com:8008~noauth",
+			ExpectedError: true,
+		},
+		{
+			Name:          "missing_transform",
+			Address:       "net:example.com:8008",
+			ExpectedError: true,
+		},
+		{
+			Name:          "invalid_key",
+			Address:       "net:example.com:8008~shs:invalid",
+			ExpectedError: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			address, err := network.NewMultiserverAddress(testCase.Address)
+			if testCase.ExpectedError {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, testCase.ExpectedAddress, address.Address())
+			require.True(t, remote.Identity().Equal(address.Remote()))
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package network
+
+import (
+	"io"
+	"sync"
+	"time"
+
+	"github.com/boreq/errors"
+	"github.com/gorilla/websocket"
+)
+
+// WebSocketConn adapts a WebSocket connection to io.ReadWriteCloser so that
+// the rest of the protocol stack can be used on top of it. Data is always
+// written as binary messages. Message boundaries are not preserved when
+// reading, the contents of consecutive messages are treated as a continuous
+// byte stream.
+type WebSocketConn struct {
+	conn *websocket.Conn
+
+	readLock sync.Mutex // guards reader and calls to NextReader
+	reader   io.Reader
+
+	writeLock sync.Mutex // gorilla supports only one concurrent writer
+}
+
+func NewWebSocketConn(conn *websocket.Conn) *WebSocketConn {
+	return &WebSocketConn{
+		conn: conn,
+	}
+}
+
+func (w *WebSocketConn) Read(p []byte) (int, error) {
+	w.readLock.Lock()
+	defer w.readLock.Unlock()
+
+	for {
+		if w.reader == nil {
+			_, reader, err := w.conn.NextReader()
+			if err != nil {
+				if websocket.IsCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway) {
+					return 0, io.EOF
+				}
+				return 0, errors.Wrap(err, "error getting the next reader")
+			}
+			w.reader = reader
+		}
+
+		n, err := w.reader.Read(p)
+		if errors.Is(err, io.EOF) {
+			w.reader = nil
+			if n == 0 {
+				continue
+			}
+			return n, nil
+		}
+		return n, err
+	}
+}
+
+func (w *WebSocketConn) Write(p []byte) (int, error) {
+	w.writeLock.Lock()
+	defer w.writeLock.Unlock()
+
+	if err := w.conn.WriteMessage(websocket.BinaryMessage, p); err != nil {
+		return 0, errors.Wrap(err, "error writing the message")
+	}
+
+	return len(p), nil
+}
+
+func (w *WebSocketConn) Close() error {
+	return w.conn.Close()
+}
+
+// SetDeadline makes it possible for the handshake timeouts to apply to
+// WebSocket connections.
+func (w *WebSocketConn) SetDeadline(t time.Time) error {
+	if err := w.conn.SetReadDeadline(t); err != nil {
+		return errors.Wrap(err, "error setting the read deadline")
+	}
+
+	if err := w.conn.SetWriteDeadline(t); err != nil {
+		return errors.Wrap(err, "error setting the write deadline")
+	}
+
+	return nil
+}
@@ -0,0 +1,102 @@
+package network_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/websocket"
+	"github.com/planetary-social/scuttlego/internal/fixtures"
+	"github.com/planetary-social/scuttlego/internal/mocks"
+	"github.com/planetary-social/scuttlego/service/domain/identity"
+	"github.com/planetary-social/scuttlego/service/domain/network"
+	"github.com/planetary-social/scuttlego/service/domain/transport"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDialer_WebSocketAddressesAreDialedUsingWebSockets(t *testing.T) {
+	ctx := fixtures.TestContext(t)
+
+	server := httptest.NewServer(newEchoWebSocketHandler(t))
+	t.Cleanup(server.Close)
+
+	initializer := newEchoingClientPeerInitializer()
+
+	dialer, err := network.NewDialer(initializer, fixtures.TestLogger(t))
+	require.NoError(t, err)
+
+	remote := fixtures.SomePublicIdentity()
+	address := network.NewAddress(strings.Replace(server.URL, "http://", "ws://", 1))
+	require.True(t, address.IsWebSocket())
+
+	_, err = dialer.Dial(ctx, remote, address)
+	require.NoError(t, err)
+
+	require.Equal(t, []echoingClientPeerInitializerCall{
+		{
+			Remote: remote,
+			Echo:   []byte("some data which spans multiple messages"),
+		},
+	}, initializer.calls)
+}
+
+type echoingClientPeerInitializer struct {
+	calls []echoingClientPeerInitializerCall
+}
+
+func newEchoingClientPeerInitializer() *echoingClientPeerInitializer {
+	return &echoingClientPeerInitializer{}
+}
+
+func (e *echoingClientPeerInitializer) InitializeClientPeer(ctx context.Context, rwc io.ReadWriteCloser, remote identity.Public) (transport.Peer, error) {
+	defer rwc.Close()
+
+	chunks := []string{"some data ", "which spans ", "multiple messages"}
+
+	var length int
+	for _, chunk := range chunks {
+		if _, err := rwc.Write([]byte(chunk)); err != nil {
+			return transport.Peer{}, err
+		}
+		length += len(chunk)
+	}
+
+	buf := make([]byte, length)
+	if _, err := io.ReadFull(rwc, buf); err != nil {
+		return transport.Peer{}, err
+	}
+
+	e.calls = append(e.calls, echoingClientPeerInitializerCall{
+		Remote: remote,
+		Echo:   buf,
+	})
+
+	return transport.MustNewPeer(remote, mocks.NewConnectionMock(ctx)), nil
+}
+
+type echoingClientPeerInitializerCall struct {
+	Remote identity.Public
+	Echo   []byte
+}
+
+func newEchoWebSocketHandler(t *testing.T) http.Handler {
+	var upgrader websocket.Upgrader
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			t.Log("error upgrading", err)
+			return
+		}
+
+		rwc := network.NewWebSocketConn(conn)
+		defer rwc.Close()
+
+		if _, err := io.Copy(rwc, rwc); err != nil {
+			t.Log("error copying", err)
+		}
+	})
+}
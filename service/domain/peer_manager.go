@@ -125,20 +125,21 @@ func (p PeerManager) Connect(ctx context.Context, remote identity.Public, addres
 }
 
 // ConnectViaRoom attempts to establish communications with the specified peer
-// using a room as a relay. Behaves like Connect.
-func (p PeerManager) ConnectViaRoom(ctx context.Context, portal transport.Peer, target identity.Public) error {
+// using a room as a relay. Behaves like Connect. Returns the tunnelled peer or
+// a zero value if a connection to the specified peer already exists.
+func (p PeerManager) ConnectViaRoom(ctx context.Context, portal transport.Peer, target identity.Public) (transport.Peer, error) {
 	if p.alreadyConnected(target) { // early check
-		return nil
+		return transport.Peer{}, nil
 	}
 
 	p.logger.Debug().WithField("target", target).WithField("portal", portal).Message("dialing via room")
 
-	_, err := p.roomDialer.DialViaRoom(ctx, portal, target)
+	peer, err := p.roomDialer.DialViaRoom(ctx, portal, target)
 	if err != nil {
-		return errors.Wrap(err, "dial via room failed")
+		return transport.Peer{}, errors.Wrap(err, "dial via room failed")
 	}
 
-	return nil
+	return peer, nil
 }
 
 // ProcessNewLocalDiscovery handles incoming local peer announcements.
@@ -0,0 +1,200 @@
+// Package ping implements keep-alive pings used to measure round-trip times
+// and detect dead connections.
+package ping
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/boreq/errors"
+	"github.com/planetary-social/scuttlego/logging"
+	"github.com/planetary-social/scuttlego/service/domain/messages"
+	"github.com/planetary-social/scuttlego/service/domain/transport"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc"
+	rpctransport "github.com/planetary-social/scuttlego/service/domain/transport/rpc/transport"
+)
+
+type Config struct {
+	// Interval specifies how often peers are pinged.
+	Interval time.Duration
+
+	// MaxMissedPings specifies after how many consecutive unanswered pings
+	// the connection is considered dead and closed.
+	MaxMissedPings int
+}
+
+func (c Config) validate() error {
+	if c.Interval <= 0 {
+		return errors.New("interval must be positive")
+	}
+	if c.MaxMissedPings <= 0 {
+		return errors.New("max missed pings must be positive")
+	}
+	return nil
+}
+
+// Pinger periodically pings peers using gossip.ping. Only one ping is in
+// flight at any given time. If the remote doesn't reply to a ping before the
+// next ping is due then the ping is considered missed.
+type Pinger struct {
+	config Config
+
+	roundTripTimes     map[transport.Connection]time.Duration
+	roundTripTimesLock sync.Mutex
+
+	logger logging.Logger
+}
+
+func NewPinger(config Config, logger logging.Logger) (*Pinger, error) {
+	if err := config.validate(); err != nil {
+		return nil, errors.Wrap(err, "invalid config")
+	}
+
+	return &Pinger{
+		config:         config,
+		roundTripTimes: make(map[transport.Connection]time.Duration),
+		logger:         logger.New("pinger"),
+	}, nil
+}
+
+// Run pings the peer until the context is cancelled or the connection is
+// considered dead in which case it is closed. If the remote doesn't support
+// gossip.ping then Run returns an error without closing the connection.
+func (p *Pinger) Run(ctx context.Context, peer transport.Peer) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	defer p.forgetRoundTripTime(peer)
+
+	args, err := messages.NewGossipPingArguments(p.remoteTimeout())
+	if err != nil {
+		return errors.Wrap(err, "error creating arguments")
+	}
+
+	req, err := messages.NewGossipPing(args)
+	if err != nil {
+		return errors.Wrap(err, "error creating the request")
+	}
+
+	stream, err := peer.Conn().PerformRequest(ctx, req)
+	if err != nil {
+		return errors.Wrap(err, "error performing the request")
+	}
+
+	ticker := time.NewTicker(p.config.Interval)
+	defer ticker.Stop()
+
+	sentAt, err := p.sendPing(stream)
+	if err != nil {
+		return errors.Wrap(err, "error sending the first ping")
+	}
+
+	missedPings := 0
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case resp, ok := <-stream.Channel():
+			if !ok {
+				return errors.New("stream closed")
+			}
+
+			if err := resp.Err; err != nil {
+				return errors.Wrap(err, "received an error")
+			}
+
+			if _, err := messages.NewGossipPingTimestampFromBytes(resp.Value.Bytes()); err != nil {
+				return errors.Wrap(err, "error parsing the timestamp")
+			}
+
+			if sentAt.IsZero() {
+				continue
+			}
+
+			p.recordRoundTripTime(peer, time.Since(sentAt))
+			sentAt = time.Time{}
+			missedPings = 0
+		case <-ticker.C:
+			if !sentAt.IsZero() {
+				missedPings++
+
+				p.logger.Debug().
+					WithField("peer", peer.Identity().String()).
+					WithField("missed_pings", missedPings).
+					Message("missed a ping")
+
+				if missedPings >= p.config.MaxMissedPings {
+					if err := peer.Conn().Close(); err != nil {
+						return errors.Wrap(err, "error closing the connection")
+					}
+					return errors.New("too many missed pings, closed the connection")
+				}
+
+				continue
+			}
+
+			sentAt, err = p.sendPing(stream)
+			if err != nil {
+				return errors.Wrap(err, "error sending a ping")
+			}
+		}
+	}
+}
+
+// RoundTripTime returns the most recently measured round-trip time for the
+// given peer. Round-trip times are tracked separately for each connection.
+// Returns false if no measurements are available.
+func (p *Pinger) RoundTripTime(peer transport.Peer) (time.Duration, bool) {
+	p.roundTripTimesLock.Lock()
+	defer p.roundTripTimesLock.Unlock()
+
+	rtt, ok := p.roundTripTimes[peer.Conn()]
+	return rtt, ok
+}
+
+func (p *Pinger) sendPing(stream rpc.ResponseStream) (time.Time, error) {
+	now := time.Now()
+
+	timestamp, err := messages.NewGossipPingTimestamp(now)
+	if err != nil {
+		return time.Time{}, errors.Wrap(err, "error creating the timestamp")
+	}
+
+	j, err := timestamp.MarshalJSON()
+	if err != nil {
+		return time.Time{}, errors.Wrap(err, "error marshaling the timestamp")
+	}
+
+	if err := stream.WriteMessage(j, rpctransport.MessageBodyTypeJSON); err != nil {
+		return time.Time{}, errors.Wrap(err, "error writing the message")
+	}
+
+	return now, nil
+}
+
+func (p *Pinger) recordRoundTripTime(peer transport.Peer, rtt time.Duration) {
+	p.logger.Trace().
+		WithField("peer", peer.Identity().String()).
+		WithField("rtt", rtt).
+		Message("received a pong")
+
+	p.roundTripTimesLock.Lock()
+	defer p.roundTripTimesLock.Unlock()
+
+	p.roundTripTimes[peer.Conn()] = rtt
+}
+
+func (p *Pinger) forgetRoundTripTime(peer transport.Peer) {
+	p.roundTripTimesLock.Lock()
+	defer p.roundTripTimesLock.Unlock()
+
+	delete(p.roundTripTimes, peer.Conn())
+}
+
+// remoteTimeout is sent to the remote to tell it how long it should wait for
+// our pings before giving up.
+func (p *Pinger) remoteTimeout() time.Duration {
+	return p.config.Interval * time.Duration(p.config.MaxMissedPings+1)
+}
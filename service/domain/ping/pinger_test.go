@@ -0,0 +1,323 @@
+package ping_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/boreq/errors"
+	"github.com/planetary-social/scuttlego/internal/fixtures"
+	"github.com/planetary-social/scuttlego/service/domain/messages"
+	"github.com/planetary-social/scuttlego/service/domain/ping"
+	"github.com/planetary-social/scuttlego/service/domain/transport"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc"
+	rpctransport "github.com/planetary-social/scuttlego/service/domain/transport/rpc/transport"
+	"github.com/stretchr/testify/require"
+)
+
+const testInterval = 10 * time.Millisecond
+
+func TestNewPinger_ValidatesConfig(t *testing.T) {
+	testCases := []struct {
+		Name          string
+		Config        ping.Config
+		ExpectedError bool
+	}{
+		{
+			Name: "valid",
+			Config: ping.Config{
+				Interval:       time.Second,
+				MaxMissedPings: 1,
+			},
+			ExpectedError: false,
+		},
+		{
+			Name: "zero_interval",
+			Config: ping.Config{
+				Interval:       0,
+				MaxMissedPings: 1,
+			},
+			ExpectedError: true,
+		},
+		{
+			Name: "zero_max_missed_pings",
+			Config: ping.Config{
+				Interval:       time.Second,
+				MaxMissedPings: 0,
+			},
+			ExpectedError: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			_, err := ping.NewPinger(testCase.Config, fixtures.TestLogger(t))
+			if testCase.ExpectedError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestPinger_RecordsRoundTripTimeIfRemoteReplies(t *testing.T) {
+	pinger := newTestPinger(t, 3)
+
+	ctx, cancel := context.WithCancel(fixtures.TestContext(t))
+	defer cancel()
+
+	conn := newConnectionMock(true)
+	peer := transport.MustNewPeer(fixtures.SomePublicIdentity(), conn)
+
+	errCh := make(chan error)
+	go func() {
+		errCh <- pinger.Run(ctx, peer)
+	}()
+
+	require.Eventually(t,
+		func() bool {
+			_, ok := pinger.RoundTripTime(peer)
+			return ok
+		},
+		1*time.Second, 10*time.Millisecond,
+	)
+
+	require.Eventually(t,
+		func() bool {
+			return conn.ReceivedPings() > 5
+		},
+		1*time.Second, 10*time.Millisecond,
+	)
+
+	require.False(t, conn.IsClosed())
+	require.Equal(t, []*rpc.Request{expectedRequest(t, 3)}, conn.Requests())
+
+	cancel()
+
+	select {
+	case err := <-errCh:
+		require.ErrorIs(t, err, context.Canceled)
+	case <-time.After(1 * time.Second):
+		t.Fatal("timeout")
+	}
+
+	_, ok := pinger.RoundTripTime(peer)
+	require.False(t, ok, "round-trip time should be forgotten once the pinger exits")
+}
+
+func TestPinger_RoundTripTimesAreTrackedPerConnection(t *testing.T) {
+	pinger := newTestPinger(t, 3)
+
+	ctx := fixtures.TestContext(t)
+	remote := fixtures.SomePublicIdentity()
+
+	ctx1, cancel1 := context.WithCancel(ctx)
+	defer cancel1()
+
+	peer1 := transport.MustNewPeer(remote, newConnectionMock(true))
+	peer2 := transport.MustNewPeer(remote, newConnectionMock(true))
+
+	errCh := make(chan error)
+	go func() {
+		errCh <- pinger.Run(ctx1, peer1)
+	}()
+
+	go func() {
+		_ = pinger.Run(ctx, peer2)
+	}()
+
+	require.Eventually(t,
+		func() bool {
+			_, ok1 := pinger.RoundTripTime(peer1)
+			_, ok2 := pinger.RoundTripTime(peer2)
+			return ok1 && ok2
+		},
+		1*time.Second, 10*time.Millisecond,
+	)
+
+	cancel1()
+
+	select {
+	case err := <-errCh:
+		require.ErrorIs(t, err, context.Canceled)
+	case <-time.After(1 * time.Second):
+		t.Fatal("timeout")
+	}
+
+	_, ok := pinger.RoundTripTime(peer1)
+	require.False(t, ok)
+
+	_, ok = pinger.RoundTripTime(peer2)
+	require.True(t, ok, "round-trip time of the other connection should be kept")
+}
+
+func TestPinger_ClosesConnectionAfterTooManyMissedPings(t *testing.T) {
+	const maxMissedPings = 3
+
+	pinger := newTestPinger(t, maxMissedPings)
+
+	ctx := fixtures.TestContext(t)
+
+	conn := newConnectionMock(false)
+	peer := transport.MustNewPeer(fixtures.SomePublicIdentity(), conn)
+
+	err := pinger.Run(ctx, peer)
+	require.EqualError(t, err, "too many missed pings, closed the connection")
+	require.True(t, conn.IsClosed())
+	require.Equal(t, 1, conn.ReceivedPings(), "only one ping should be in flight")
+
+	_, ok := pinger.RoundTripTime(peer)
+	require.False(t, ok)
+}
+
+func TestPinger_ReturnsErrorWithoutClosingConnectionIfRemoteReturnsAnError(t *testing.T) {
+	pinger := newTestPinger(t, 3)
+
+	ctx := fixtures.TestContext(t)
+
+	conn := newConnectionMock(true)
+	conn.MockError(rpc.NewRemoteError([]byte("no such procedure")))
+	peer := transport.MustNewPeer(fixtures.SomePublicIdentity(), conn)
+
+	err := pinger.Run(ctx, peer)
+	require.ErrorIs(t, err, rpc.RemoteError{})
+	require.False(t, conn.IsClosed())
+}
+
+func newTestPinger(t *testing.T, maxMissedPings int) *ping.Pinger {
+	pinger, err := ping.NewPinger(
+		ping.Config{
+			Interval:       testInterval,
+			MaxMissedPings: maxMissedPings,
+		},
+		fixtures.TestLogger(t),
+	)
+	require.NoError(t, err)
+	return pinger
+}
+
+func expectedRequest(t *testing.T, maxMissedPings int) *rpc.Request {
+	args, err := messages.NewGossipPingArguments(testInterval * time.Duration(maxMissedPings+1))
+	require.NoError(t, err)
+
+	req, err := messages.NewGossipPing(args)
+	require.NoError(t, err)
+
+	return req
+}
+
+type connectionMock struct {
+	reply bool
+
+	lock          sync.Mutex
+	requests      []*rpc.Request
+	receivedPings int
+	closed        bool
+	err           error
+}
+
+func newConnectionMock(reply bool) *connectionMock {
+	return &connectionMock{reply: reply}
+}
+
+func (c *connectionMock) PerformRequest(ctx context.Context, req *rpc.Request) (rpc.ResponseStream, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.requests = append(c.requests, req)
+
+	s := &responseStreamMock{
+		ctx:  ctx,
+		conn: c,
+		ch:   make(chan rpc.ResponseWithError, 1),
+	}
+
+	if c.err != nil {
+		s.ch <- rpc.ResponseWithError{Err: c.err}
+	}
+
+	return s, nil
+}
+
+func (c *connectionMock) WasInitiatedByRemote() bool {
+	return false
+}
+
+func (c *connectionMock) Close() error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.closed = true
+	return nil
+}
+
+func (c *connectionMock) MockError(err error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.err = err
+}
+
+func (c *connectionMock) Requests() []*rpc.Request {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	tmp := make([]*rpc.Request, len(c.requests))
+	copy(tmp, c.requests)
+	return tmp
+}
+
+func (c *connectionMock) ReceivedPings() int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.receivedPings
+}
+
+func (c *connectionMock) IsClosed() bool {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.closed
+}
+
+type responseStreamMock struct {
+	ctx  context.Context
+	conn *connectionMock
+	ch   chan rpc.ResponseWithError
+}
+
+func (r *responseStreamMock) WriteMessage(body []byte, bodyType rpctransport.MessageBodyType) error {
+	if bodyType != rpctransport.MessageBodyTypeJSON {
+		return errors.New("invalid body type")
+	}
+
+	if _, err := messages.NewGossipPingTimestampFromBytes(body); err != nil {
+		return errors.Wrap(err, "invalid timestamp")
+	}
+
+	r.conn.lock.Lock()
+	r.conn.receivedPings++
+	r.conn.lock.Unlock()
+
+	if r.conn.reply {
+		go func() {
+			select {
+			case r.ch <- rpc.ResponseWithError{Value: rpc.NewResponse([]byte(`1670000000000`))}:
+			case <-r.ctx.Done():
+			}
+		}()
+	}
+
+	return nil
+}
+
+func (r *responseStreamMock) Channel() <-chan rpc.ResponseWithError {
+	return r.ch
+}
+
+func (r *responseStreamMock) Ctx() context.Context {
+	return r.ctx
+}
@@ -3,6 +3,7 @@ package replication
 import (
 	"github.com/planetary-social/scuttlego/service/domain/feeds/message"
 	"github.com/planetary-social/scuttlego/service/domain/identity"
+	"github.com/planetary-social/scuttlego/service/domain/refs"
 )
 
 type RawMessageHandler interface {
@@ -14,3 +15,17 @@ type ContactsStorage interface {
 	// ascending. Contacts include the local feed.
 	GetContacts(peer identity.Public) ([]Contact, error)
 }
+
+type Metrics interface {
+	// ReportReplicationLag is called with the number of messages by which
+	// the local copy of a feed is behind the copy held by a peer.
+	ReportReplicationLag(feed refs.Feed, lag int)
+
+	// ForgetReplicationLag is called when a feed is no longer replicated.
+	ForgetReplicationLag(feed refs.Feed)
+}
+
+type EventPublisher interface {
+	PublishReplicationStarted(peer identity.Public, feed refs.Feed)
+	PublishReplicationFinished(peer identity.Public, feed refs.Feed, receivedMessages int, err error)
+}
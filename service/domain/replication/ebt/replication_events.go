@@ -0,0 +1,91 @@
+package ebt
+
+import (
+	"sync"
+
+	"github.com/planetary-social/scuttlego/service/domain/identity"
+	"github.com/planetary-social/scuttlego/service/domain/messages"
+	"github.com/planetary-social/scuttlego/service/domain/refs"
+	"github.com/planetary-social/scuttlego/service/domain/replication"
+)
+
+// replicationEvents publishes replication events for feeds which were
+// requested from the peer in a single session. Replication of a feed starts
+// when a note asking the peer for that feed is sent and finishes when the
+// feed is cancelled or when the session ends.
+type replicationEvents struct {
+	peer      identity.Public
+	publisher replication.EventPublisher
+
+	feeds    map[string]*replicatedFeed
+	finished bool
+	lock     sync.Mutex // locks feeds and finished
+}
+
+type replicatedFeed struct {
+	feed             refs.Feed
+	receivedMessages int
+}
+
+func newReplicationEvents(peer identity.Public, publisher replication.EventPublisher) *replicationEvents {
+	return &replicationEvents{
+		peer:      peer,
+		publisher: publisher,
+		feeds:     make(map[string]*replicatedFeed),
+	}
+}
+
+// NotesSent should be called after notes are sent to the peer.
+func (r *replicationEvents) NotesSent(notes messages.EbtReplicateNotes) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if r.finished {
+		return
+	}
+
+	for _, note := range notes.Notes() {
+		key := note.Ref().String()
+		v, ok := r.feeds[key]
+
+		if note.Replicate() && note.Receive() {
+			if !ok {
+				r.feeds[key] = &replicatedFeed{feed: note.Ref()}
+				r.publisher.PublishReplicationStarted(r.peer, note.Ref())
+			}
+			continue
+		}
+
+		if ok {
+			delete(r.feeds, key)
+			r.publisher.PublishReplicationFinished(r.peer, v.feed, v.receivedMessages, nil)
+		}
+	}
+}
+
+// MessageReceived should be called after a message is received from the peer.
+func (r *replicationEvents) MessageReceived(feed refs.Feed) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if v, ok := r.feeds[feed.String()]; ok {
+		v.receivedMessages++
+	}
+}
+
+// SessionEnded publishes replication finished events for all feeds which are
+// still being replicated. No events are published afterwards.
+func (r *replicationEvents) SessionEnded(err error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if r.finished {
+		return
+	}
+	r.finished = true
+
+	for key, v := range r.feeds {
+		delete(r.feeds, key)
+		r.publisher.PublishReplicationFinished(r.peer, v.feed, v.receivedMessages, err)
+	}
+}
@@ -1,7 +1,10 @@
 package ebt
 
 import (
+	"sync"
+
 	"github.com/boreq/errors"
+	"github.com/planetary-social/scuttlego/service/domain/feeds/message"
 	"github.com/planetary-social/scuttlego/service/domain/messages"
 	"github.com/planetary-social/scuttlego/service/domain/refs"
 	"github.com/planetary-social/scuttlego/service/domain/replication"
@@ -9,15 +12,19 @@ import (
 
 type SentNotes struct {
 	prevNotes map[string]messages.EbtReplicateNote
+	lock      sync.Mutex // locks prevNotes
 }
 
 func NewSentNotes() *SentNotes {
 	return &SentNotes{
-		make(map[string]messages.EbtReplicateNote),
+		prevNotes: make(map[string]messages.EbtReplicateNote),
 	}
 }
 
 func (w *SentNotes) Update(contacts []replication.Contact) (messages.EbtReplicateNotes, error) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
 	var notesToSend []messages.EbtReplicateNote
 
 	missing := w.getMissing(contacts)
@@ -48,6 +55,35 @@ func (w *SentNotes) Update(contacts []replication.Contact) (messages.EbtReplicat
 	return messages.NewEbtReplicateNotes(notesToSend)
 }
 
+// FeedState returns the state of the feed described by the last note which
+// was sent for that feed. It returns false if no note was sent or if the feed
+// was cancelled.
+func (w *SentNotes) FeedState(ref refs.Feed) (replication.FeedState, bool) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	note, ok := w.prevNotes[ref.String()]
+	if !ok {
+		return replication.FeedState{}, false
+	}
+
+	if note.Sequence() <= 0 {
+		return replication.NewEmptyFeedState(), true
+	}
+
+	sequence, err := message.NewSequence(note.Sequence())
+	if err != nil {
+		return replication.FeedState{}, false
+	}
+
+	feedState, err := replication.NewFeedState(sequence)
+	if err != nil {
+		return replication.FeedState{}, false
+	}
+
+	return feedState, true
+}
+
 func (w *SentNotes) getMissing(newContacts []replication.Contact) map[string]messages.EbtReplicateNote {
 	missing := make(map[string]messages.EbtReplicateNote)
 
@@ -6,13 +6,19 @@ import (
 
 	"github.com/boreq/errors"
 	"github.com/planetary-social/scuttlego/logging"
+	"github.com/planetary-social/scuttlego/service/domain/feeds"
 	"github.com/planetary-social/scuttlego/service/domain/feeds/message"
 	"github.com/planetary-social/scuttlego/service/domain/identity"
 	"github.com/planetary-social/scuttlego/service/domain/messages"
 	"github.com/planetary-social/scuttlego/service/domain/refs"
 	"github.com/planetary-social/scuttlego/service/domain/replication"
+	"github.com/planetary-social/scuttlego/tracing"
 )
 
+type RawMessagePeeker interface {
+	PeekRawMessage(raw message.RawMessage) (feeds.PeekedMessage, error)
+}
+
 type MessageWriter interface {
 	WriteMessage(msg message.Message) error
 }
@@ -71,6 +77,10 @@ type SessionRunner struct {
 	rawMessageHandler replication.RawMessageHandler
 	contactsStorage   replication.ContactsStorage
 	streamer          MessageStreamer
+	metrics           replication.Metrics
+	peeker            RawMessagePeeker
+	publisher         replication.EventPublisher
+	tracer            *tracing.Tracer
 }
 
 func NewSessionRunner(
@@ -78,20 +88,36 @@ func NewSessionRunner(
 	rawMessageHandler replication.RawMessageHandler,
 	contactsStorage replication.ContactsStorage,
 	streamer MessageStreamer,
+	metrics replication.Metrics,
+	peeker RawMessagePeeker,
+	publisher replication.EventPublisher,
+	tracer *tracing.Tracer,
 ) *SessionRunner {
 	return &SessionRunner{
 		logger:            logger,
 		rawMessageHandler: rawMessageHandler,
 		contactsStorage:   contactsStorage,
 		streamer:          streamer,
+		metrics:           metrics,
+		peeker:            peeker,
+		publisher:         publisher,
+		tracer:            tracer,
 	}
 }
 
 func (s *SessionRunner) HandleStream(ctx context.Context, stream Stream) error {
+	ctx, span := s.tracer.Start(ctx, "ebt.session")
+	defer span.End()
+
+	span.SetAttribute("remote", stream.RemoteIdentity().String())
+
 	rf := NewRequestedFeeds(s.streamer, stream)
-	session := NewSession(ctx, stream, s.logger, s.rawMessageHandler, s.contactsStorage, rf)
+	session := NewSession(ctx, stream, s.logger, s.rawMessageHandler, s.contactsStorage, rf, s.metrics, s.peeker, s.publisher)
 	go session.SendNotesLoop()
-	return session.HandleIncomingMessagesLoop()
+
+	err := session.HandleIncomingMessagesLoop()
+	span.SetError(err)
+	return err
 }
 
 type FeedRequester interface {
@@ -112,6 +138,9 @@ type Session struct {
 	logger            logging.Logger
 	rawMessageHandler replication.RawMessageHandler
 	contactsStorage   replication.ContactsStorage
+	metrics           replication.Metrics
+	peeker            RawMessagePeeker
+	events            *replicationEvents
 }
 
 func NewSession(
@@ -121,6 +150,9 @@ func NewSession(
 	rawMessageHandler replication.RawMessageHandler,
 	contactsStorage replication.ContactsStorage,
 	feedRequester FeedRequester,
+	metrics replication.Metrics,
+	peeker RawMessagePeeker,
+	publisher replication.EventPublisher,
 ) *Session {
 	ctx, cancel := context.WithCancel(ctx)
 
@@ -136,11 +168,17 @@ func NewSession(
 		logger:            logger.New("session").WithCtx(ctx),
 		rawMessageHandler: rawMessageHandler,
 		contactsStorage:   contactsStorage,
+		metrics:           metrics,
+		peeker:            peeker,
+		events:            newReplicationEvents(stream.RemoteIdentity(), publisher),
 	}
 }
 
-func (s *Session) HandleIncomingMessagesLoop() error {
+func (s *Session) HandleIncomingMessagesLoop() (err error) {
 	defer s.cancel()
+	defer func() {
+		s.events.SessionEnded(err)
+	}()
 
 	for incoming := range s.stream.IncomingMessages(s.ctx) {
 		if err := s.handleIncomingMessage(s.ctx, incoming); err != nil {
@@ -188,7 +226,12 @@ func (s *Session) SendNotes() error {
 		WithField("number_of_notes", len(notesToSend.Notes())).
 		Message("sending notes")
 
-	return s.stream.SendNotes(notesToSend)
+	if err := s.stream.SendNotes(notesToSend); err != nil {
+		return errors.Wrap(err, "error sending notes")
+	}
+
+	s.events.NotesSent(notesToSend)
+	return nil
 }
 
 func (s *Session) handleIncomingMessage(ctx context.Context, incoming IncomingMessage) error {
@@ -209,6 +252,10 @@ func (s *Session) handleIncomingMessage(ctx context.Context, incoming IncomingMe
 			s.logger.Debug().WithError(err).Message("error handling a raw message")
 			return nil
 		}
+
+		if peeked, err := s.peeker.PeekRawMessage(msg); err == nil {
+			s.events.MessageReceived(peeked.Feed())
+		}
 		return nil
 	}
 
@@ -224,6 +271,7 @@ func (s *Session) handleIncomingNotes(ctx context.Context, notes messages.EbtRep
 			if err != nil {
 				return errors.Wrap(err, "error parsing sequence")
 			}
+			s.reportLag(note.Ref(), seq)
 			s.feedRequester.Request(ctx, note.Ref(), seq)
 		}
 	}
@@ -231,6 +279,22 @@ func (s *Session) handleIncomingNotes(ctx context.Context, notes messages.EbtRep
 	return nil
 }
 
+// reportLag compares the sequence announced by the peer with the sequence
+// which was most recently announced to the peer. Feeds which weren't announced
+// to the peer aren't replicated in this session and are therefore skipped.
+func (s *Session) reportLag(ref refs.Feed, remote *message.Sequence) {
+	if remote == nil {
+		return
+	}
+
+	local, ok := s.sentNotes.FeedState(ref)
+	if !ok {
+		return
+	}
+
+	s.metrics.ReportReplicationLag(ref, local.Lag(*remote))
+}
+
 func (s *Session) parseSeq(seq int) (*message.Sequence, error) {
 	if seq <= 0 {
 		return nil, nil
@@ -120,6 +120,61 @@ func TestSession_NotesWithReceiveAndReplicateSetToTrueCallRequestedFeedsRequest(
 	require.Equal(t, ref, s.FeedRequester.RequestCalls()[0].Ref)
 }
 
+func TestSession_IncomingNotesReportReplicationLagOfFeedsAnnouncedToThePeer(t *testing.T) {
+	s := newTestSession(t)
+
+	announcedContact := replication.MustNewContact(
+		fixtures.SomeRefFeed(),
+		graph.MustNewHops(1),
+		replication.MustNewFeedState(message.MustNewSequence(3)),
+	)
+	notAnnouncedRef := fixtures.SomeRefFeed()
+
+	s.ContactsStorage.Contacts = []replication.Contact{announcedContact}
+
+	err := s.Session.SendNotes()
+	require.NoError(t, err)
+
+	go func() {
+		s.Stream.ReceiveIncomingMessage(s.Ctx, ebt.NewIncomingMessageWithNotes(
+			messages.MustNewEbtReplicateNotes(
+				[]messages.EbtReplicateNote{
+					messages.MustNewEbtReplicateNote(
+						announcedContact.Who(),
+						true,
+						true,
+						10,
+					),
+					messages.MustNewEbtReplicateNote(
+						notAnnouncedRef,
+						true,
+						true,
+						10,
+					),
+				}),
+		))
+	}()
+
+	go func() {
+		err := s.Session.HandleIncomingMessagesLoop()
+		t.Log(err)
+	}()
+
+	require.Eventually(t,
+		func() bool {
+			return len(s.FeedRequester.RequestCalls()) == 2
+		},
+		time.Second, 10*time.Millisecond,
+	)
+
+	lag, ok := s.Metrics.ReplicationLag(announcedContact.Who())
+	require.True(t, ok)
+	require.Equal(t, 7, lag)
+
+	_, ok = s.Metrics.ReplicationLag(notAnnouncedRef)
+	require.False(t, ok)
+}
+
 func TestSession_NotesWithReceiveOrReplicateSetToFalseCallRequestedFeedsCancel(t *testing.T) {
 	ref := fixtures.SomeRefFeed()
 
@@ -220,6 +275,91 @@ func TestSession_ErrorsWhenProcessingRawMessagesDontTerminateTheSession(t *testi
 	}
 }
 
+func TestSession_ReplicationEventsArePublishedUntilTheSessionEnds(t *testing.T) {
+	s := newTestSession(t)
+
+	contact := replication.MustNewContact(
+		fixtures.SomeRefFeed(),
+		graph.MustNewHops(1),
+		replication.NewEmptyFeedState(),
+	)
+
+	s.ContactsStorage.Contacts = []replication.Contact{contact}
+
+	err := s.Session.SendNotes()
+	require.NoError(t, err)
+
+	err = s.Session.SendNotes()
+	require.NoError(t, err)
+
+	require.Equal(t,
+		[]mocks.ReplicationEventPublisherMockEvent{
+			{
+				Peer: s.Stream.RemoteIdentity(),
+				Feed: contact.Who(),
+			},
+		},
+		s.EventPublisher.Started(),
+	)
+	require.Empty(t, s.EventPublisher.Finished())
+
+	msg := fixtures.SomeMessage(message.NewFirstSequence(), contact.Who())
+	s.Peeker.Mock(msg)
+
+	sessionErr := fixtures.SomeError()
+
+	go func() {
+		s.Stream.ReceiveIncomingMessage(s.Ctx, ebt.NewIncomingMessageWithMessage(msg.Raw()))
+		s.Stream.ReceiveIncomingMessage(s.Ctx, ebt.NewIncomingMessageWithErr(sessionErr))
+	}()
+
+	err = s.Session.HandleIncomingMessagesLoop()
+	require.ErrorIs(t, err, sessionErr)
+
+	finished := s.EventPublisher.Finished()
+	require.Len(t, finished, 1)
+	require.Equal(t, s.Stream.RemoteIdentity(), finished[0].Peer)
+	require.Equal(t, contact.Who(), finished[0].Feed)
+	require.Equal(t, 1, finished[0].ReceivedMessages)
+	require.ErrorIs(t, finished[0].Err, sessionErr)
+
+	err = s.Session.SendNotes()
+	require.NoError(t, err)
+	require.Len(t, s.EventPublisher.Started(), 1, "no events should be published after the session ends")
+}
+
+func TestSession_ReplicationFinishedEventIsPublishedWhenFeedIsNoLongerRequested(t *testing.T) {
+	s := newTestSession(t)
+
+	contact := replication.MustNewContact(
+		fixtures.SomeRefFeed(),
+		graph.MustNewHops(1),
+		replication.NewEmptyFeedState(),
+	)
+
+	s.ContactsStorage.Contacts = []replication.Contact{contact}
+
+	err := s.Session.SendNotes()
+	require.NoError(t, err)
+
+	s.ContactsStorage.Contacts = nil
+
+	err = s.Session.SendNotes()
+	require.NoError(t, err)
+
+	require.Equal(t,
+		[]mocks.ReplicationEventPublisherMockFinishedEvent{
+			{
+				Peer:             s.Stream.RemoteIdentity(),
+				Feed:             contact.Who(),
+				ReceivedMessages: 0,
+				Err:              nil,
+			},
+		},
+		s.EventPublisher.Finished(),
+	)
+}
+
 type testSession struct {
 	Session           *ebt.Session
 	ContactsStorage   *mocks.ContactsStorageMock
@@ -228,6 +368,9 @@ type testSession struct {
 	Ctx               context.Context
 	FeedRequester     *feedRequesterMock
 	RawMessageHandler *rawMessageHandlerMock
+	Metrics           *mocks.ReplicationMetricsMock
+	Peeker            *mocks.RawMessageIdentifierMock
+	EventPublisher    *mocks.ReplicationEventPublisherMock
 }
 
 func newTestSession(t *testing.T) testSession {
@@ -237,6 +380,9 @@ func newTestSession(t *testing.T) testSession {
 	contactsStorage := mocks.NewContactsStorageMock()
 	fr := newFeedRequesterMock()
 	handler := newRawMessageHandlerMock()
+	metrics := mocks.NewReplicationMetricsMock()
+	peeker := mocks.NewRawMessageIdentifierMock()
+	eventPublisher := mocks.NewReplicationEventPublisherMock()
 	session := ebt.NewSession(
 		ctx,
 		stream,
@@ -244,6 +390,9 @@ func newTestSession(t *testing.T) testSession {
 		handler,
 		contactsStorage,
 		fr,
+		metrics,
+		peeker,
+		eventPublisher,
 	)
 
 	return testSession{
@@ -252,23 +401,28 @@ func newTestSession(t *testing.T) testSession {
 		Stream:            stream,
 		FeedRequester:     fr,
 		RawMessageHandler: handler,
+		Metrics:           metrics,
+		Peeker:            peeker,
+		EventPublisher:    eventPublisher,
 		Ctx:               ctx,
 	}
 }
 
 type mockStream struct {
+	remote    identity.Public
 	sentNotes []messages.EbtReplicateNotes
 	in        chan ebt.IncomingMessage
 }
 
 func newMockStream() *mockStream {
 	return &mockStream{
-		in: make(chan ebt.IncomingMessage),
+		remote: fixtures.SomePublicIdentity(),
+		in:     make(chan ebt.IncomingMessage),
 	}
 }
 
 func (m *mockStream) RemoteIdentity() identity.Public {
-	return fixtures.SomePublicIdentity()
+	return m.remote
 }
 
 func (m *mockStream) IncomingMessages(ctx context.Context) <-chan ebt.IncomingMessage {
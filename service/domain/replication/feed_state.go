@@ -49,6 +49,19 @@ func (s FeedState) Sequence() (message.Sequence, bool) {
 	return message.Sequence{}, false
 }
 
+// Lag returns the number of messages which are missing from this feed
+// compared to a copy of the feed which ends with a message with the provided
+// sequence.
+func (s FeedState) Lag(remote message.Sequence) int {
+	if s.sequence == nil {
+		return remote.Int()
+	}
+	if remote.ComesAfter(*s.sequence) {
+		return remote.Int() - s.sequence.Int()
+	}
+	return 0
+}
+
 // String is useful for printing this value when logging or debugging. Do not
 // use it for other purposes.
 func (s FeedState) String() string {
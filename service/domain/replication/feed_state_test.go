@@ -12,3 +12,43 @@ func TestFeedState_ZeroValue(t *testing.T) {
 	_, err := replication.NewFeedState(message.Sequence{})
 	require.EqualError(t, err, "zero value of sequence", "zero value of sequence is not accepted, the other constructor should be used instead")
 }
+
+func TestFeedState_Lag(t *testing.T) {
+	testCases := []struct {
+		Name        string
+		FeedState   replication.FeedState
+		Remote      message.Sequence
+		ExpectedLag int
+	}{
+		{
+			Name:        "empty",
+			FeedState:   replication.NewEmptyFeedState(),
+			Remote:      message.MustNewSequence(5),
+			ExpectedLag: 5,
+		},
+		{
+			Name:        "behind",
+			FeedState:   replication.MustNewFeedState(message.MustNewSequence(3)),
+			Remote:      message.MustNewSequence(5),
+			ExpectedLag: 2,
+		},
+		{
+			Name:        "up_to_date",
+			FeedState:   replication.MustNewFeedState(message.MustNewSequence(5)),
+			Remote:      message.MustNewSequence(5),
+			ExpectedLag: 0,
+		},
+		{
+			Name:        "ahead",
+			FeedState:   replication.MustNewFeedState(message.MustNewSequence(7)),
+			Remote:      message.MustNewSequence(5),
+			ExpectedLag: 0,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			require.Equal(t, testCase.ExpectedLag, testCase.FeedState.Lag(testCase.Remote))
+		})
+	}
+}
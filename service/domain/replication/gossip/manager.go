@@ -39,6 +39,11 @@ const (
 	// before giving up and making that feed available to be replicated by other
 	// peers.
 	waitForTaskToBePickedUp = 10 * time.Millisecond
+
+	// For how long the feeds reported by a peer are used to decide which
+	// feeds should be replicated from that peer. Afterwards the manager falls
+	// back to asking the peer for all feeds until it reports its feeds again.
+	remoteFeedsValidFor = 10 * time.Minute
 )
 
 type TaskResult struct {
@@ -55,6 +60,10 @@ var (
 	TaskResultDidNotStart = TaskResult{"did_not_start"}
 )
 
+func (r TaskResult) String() string {
+	return r.s
+}
+
 type TaskCompletedFn func(result TaskResult)
 
 type ReplicateFeedTask struct {
@@ -78,6 +87,14 @@ type ReplicationManager interface {
 	// received tasks. The caller must call the completion function for each
 	// task.
 	GetFeedsToReplicateSelf(ctx context.Context, remote identity.Public) <-chan ReplicateFeedTask
+
+	// SetRemoteFeeds informs the manager which feeds the peer can provide.
+	// Feeds which the peer can't provide will not be replicated from it.
+	SetRemoteFeeds(remote identity.Public, feeds RemoteFeeds)
+
+	// ForgetRemoteFeeds informs the manager that the peer disconnected and
+	// it no longer needs to remember which feeds it can provide.
+	ForgetRemoteFeeds(remote identity.Public)
 }
 
 // Manager distributes replication tasks to replicators. Replicators consume
@@ -91,21 +108,27 @@ type ReplicationManager interface {
 // particular feed at any given time. Manager backs off if a peer doesn't have
 // any new messages for a feed before attempting to ask it for messages from
 // that feed again. Backoff time is increased for feeds which are further away.
+// If a peer reported which feeds it has then it will not be asked for feeds
+// which it can't provide.
 type Manager struct {
 	storage replication.ContactsStorage
+	metrics replication.Metrics
 	logger  logging.Logger
 
 	activeTasks *activeTasksSet
-	peerState   peerMap    // todo clean up periodically
-	lock        sync.Mutex // locks activeTasks and peerState
+	peerState   peerMap // todo clean up periodically
+	remoteFeeds remoteFeedsMap
+	lock        sync.Mutex // locks activeTasks, peerState and remoteFeeds
 }
 
-func NewManager(logger logging.Logger, storage replication.ContactsStorage) *Manager {
+func NewManager(logger logging.Logger, storage replication.ContactsStorage, metrics replication.Metrics) *Manager {
 	return &Manager{
 		storage:     storage,
+		metrics:     metrics,
 		logger:      logger.New("manager"),
 		activeTasks: newActiveTasksSet(),
 		peerState:   make(peerMap),
+		remoteFeeds: make(remoteFeedsMap),
 	}
 }
 
@@ -125,6 +148,23 @@ func (m *Manager) GetFeedsToReplicateSelf(ctx context.Context, remote identity.P
 	return ch
 }
 
+func (m *Manager) SetRemoteFeeds(remote identity.Public, feeds RemoteFeeds) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.remoteFeeds[remote.String()] = remoteFeedsEntry{
+		Feeds:    feeds,
+		Received: time.Now(),
+	}
+}
+
+func (m *Manager) ForgetRemoteFeeds(remote identity.Public) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	delete(m.remoteFeeds, remote.String())
+}
+
 func (m *Manager) sendFeedsToReplicateLoop(ctx context.Context, ch chan ReplicateFeedTask, remote identity.Public, localOnly bool) {
 	defer close(ch)
 
@@ -237,6 +277,10 @@ func (m *Manager) shouldStartReplication(remote identity.Public, contact replica
 		return false, nil
 	}
 
+	if !m.remoteCanProvide(remote, contact) {
+		return false, nil
+	}
+
 	peerState, ok := m.peerState[remote.String()]
 	if !ok {
 		return true, nil
@@ -263,6 +307,17 @@ func (m *Manager) shouldStartReplication(remote identity.Public, contact replica
 	}
 }
 
+func (m *Manager) remoteCanProvide(remote identity.Public, contact replication.Contact) bool {
+	entry, ok := m.remoteFeeds[remote.String()]
+	if !ok || time.Since(entry.Received) > remoteFeedsValidFor {
+		return true
+	}
+	if lag, ok := entry.Feeds.Lag(contact.Who(), contact.FeedState()); ok {
+		m.metrics.ReportReplicationLag(contact.Who(), lag)
+	}
+	return entry.Feeds.CanProvide(contact.Who(), contact.FeedState())
+}
+
 type peerMap map[string]peerState
 
 type peerState map[string]peerFeedState
@@ -272,6 +327,13 @@ type peerFeedState struct {
 	Result         TaskResult
 }
 
+type remoteFeedsMap map[string]remoteFeedsEntry
+
+type remoteFeedsEntry struct {
+	Feeds    RemoteFeeds
+	Received time.Time
+}
+
 type activeTasksSet struct {
 	m map[string]struct{}
 }
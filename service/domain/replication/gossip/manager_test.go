@@ -7,6 +7,7 @@ import (
 	"github.com/planetary-social/scuttlego/internal/fixtures"
 	"github.com/planetary-social/scuttlego/internal/mocks"
 	"github.com/planetary-social/scuttlego/logging"
+	"github.com/planetary-social/scuttlego/service/domain/feeds/message"
 	"github.com/planetary-social/scuttlego/service/domain/graph"
 	"github.com/planetary-social/scuttlego/service/domain/replication"
 	"github.com/planetary-social/scuttlego/service/domain/replication/gossip"
@@ -188,18 +189,119 @@ func TestManager_OnlyLocalFeedWillBeSentFromGetFeedsToReplicateSelf(t *testing.T
 	}
 }
 
+func TestManager_FeedsWhichThePeerCanNotProvideAreNotReplicated(t *testing.T) {
+	t.Parallel()
+
+	m := newTestManager()
+
+	missingContact := replication.MustNewContact(
+		fixtures.SomeRefFeed(),
+		graph.MustNewHops(0),
+		replication.NewEmptyFeedState(),
+	)
+
+	upToDateContact := replication.MustNewContact(
+		fixtures.SomeRefFeed(),
+		graph.MustNewHops(0),
+		replication.MustNewFeedState(message.MustNewSequence(5)),
+	)
+
+	outdatedContact := replication.MustNewContact(
+		fixtures.SomeRefFeed(),
+		graph.MustNewHops(0),
+		replication.MustNewFeedState(message.MustNewSequence(5)),
+	)
+
+	m.Storage.Contacts = []replication.Contact{
+		missingContact,
+		upToDateContact,
+		outdatedContact,
+	}
+
+	peer := fixtures.SomePublicIdentity()
+
+	remoteFeeds := gossip.NewRemoteFeeds()
+	remoteFeeds.Add(upToDateContact.Who(), message.MustNewSequence(5))
+	remoteFeeds.Add(outdatedContact.Who(), message.MustNewSequence(6))
+	m.Manager.SetRemoteFeeds(peer, remoteFeeds)
+
+	ctx := fixtures.TestContext(t)
+
+	feedsCh := m.Manager.GetFeedsToReplicate(ctx, peer)
+
+	select {
+	case task := <-feedsCh:
+		require.Equal(t, outdatedContact.Who(), task.Id)
+	case <-time.After(1 * time.Second):
+		t.Fatal("peer should have been asked to replicate the feed")
+	}
+
+	_, ok := m.Metrics.ReplicationLag(missingContact.Who())
+	require.False(t, ok)
+
+	lag, ok := m.Metrics.ReplicationLag(upToDateContact.Who())
+	require.True(t, ok)
+	require.Equal(t, 0, lag)
+
+	lag, ok = m.Metrics.ReplicationLag(outdatedContact.Who())
+	require.True(t, ok)
+	require.Equal(t, 1, lag)
+
+	select {
+	case <-feedsCh:
+		t.Fatal("peer should not replicate feeds which it can't provide")
+	case <-time.After(1 * time.Second):
+		// correct, nothing received
+	}
+}
+
+func TestManager_FeedsAreNoLongerFilteredAfterRemoteFeedsAreForgotten(t *testing.T) {
+	t.Parallel()
+
+	m := newTestManager()
+
+	contact := replication.MustNewContact(
+		fixtures.SomeRefFeed(),
+		graph.MustNewHops(0),
+		replication.NewEmptyFeedState(),
+	)
+
+	m.Storage.Contacts = []replication.Contact{
+		contact,
+	}
+
+	peer := fixtures.SomePublicIdentity()
+
+	m.Manager.SetRemoteFeeds(peer, gossip.NewRemoteFeeds())
+	m.Manager.ForgetRemoteFeeds(peer)
+
+	ctx := fixtures.TestContext(t)
+
+	feedsCh := m.Manager.GetFeedsToReplicate(ctx, peer)
+
+	select {
+	case task := <-feedsCh:
+		require.Equal(t, contact.Who(), task.Id)
+	case <-time.After(1 * time.Second):
+		t.Fatal("peer should have been asked to replicate the feed")
+	}
+}
+
 type testManager struct {
 	Manager *gossip.Manager
 	Storage *mocks.ContactsStorageMock
+	Metrics *mocks.ReplicationMetricsMock
 }
 
 func newTestManager() testManager {
 	logger := logging.NewDevNullLogger()
 	storage := mocks.NewContactsStorageMock()
-	manager := gossip.NewManager(logger, storage)
+	metrics := mocks.NewReplicationMetricsMock()
+	manager := gossip.NewManager(logger, storage, metrics)
 
 	return testManager{
 		Manager: manager,
 		Storage: storage,
+		Metrics: metrics,
 	}
 }
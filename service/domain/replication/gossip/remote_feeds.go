@@ -0,0 +1,59 @@
+package gossip
+
+import (
+	"github.com/planetary-social/scuttlego/service/domain/feeds/message"
+	"github.com/planetary-social/scuttlego/service/domain/refs"
+	"github.com/planetary-social/scuttlego/service/domain/replication"
+)
+
+// RemoteFeeds describes which feeds a peer can provide and the sequence of
+// the latest message it has in each of them. It is populated using the
+// replicate.upto procedure.
+type RemoteFeeds struct {
+	sequences map[string]message.Sequence
+}
+
+func NewRemoteFeeds() RemoteFeeds {
+	return RemoteFeeds{
+		sequences: make(map[string]message.Sequence),
+	}
+}
+
+func (r RemoteFeeds) Add(feed refs.Feed, sequence message.Sequence) {
+	r.sequences[feed.String()] = sequence
+}
+
+func (r RemoteFeeds) Len() int {
+	return len(r.sequences)
+}
+
+// CanProvide returns true if the peer has messages which are newer than the
+// ones described by the provided feed state.
+func (r RemoteFeeds) CanProvide(feed refs.Feed, state replication.FeedState) bool {
+	remoteSequence, ok := r.sequences[feed.String()]
+	if !ok {
+		return false
+	}
+
+	localSequence, ok := state.Sequence()
+	if !ok {
+		return true
+	}
+
+	return remoteSequence.ComesAfter(localSequence)
+}
+
+// Lag returns the number of messages which the peer has and which are missing
+// from the feed described by the provided feed state. It returns false if the
+// peer didn't report that it has the feed.
+func (r RemoteFeeds) Lag(feed refs.Feed, state replication.FeedState) (int, bool) {
+	remoteSequence, ok := r.sequences[feed.String()]
+	if !ok {
+		return 0, false
+	}
+	return state.Lag(remoteSequence), true
+}
+
+func (r RemoteFeeds) IsZero() bool {
+	return r.sequences == nil
+}
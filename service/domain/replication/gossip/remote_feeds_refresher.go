@@ -0,0 +1,182 @@
+package gossip
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/boreq/errors"
+	"github.com/planetary-social/scuttlego/logging"
+	"github.com/planetary-social/scuttlego/service/domain/identity"
+	"github.com/planetary-social/scuttlego/service/domain/messages"
+	"github.com/planetary-social/scuttlego/service/domain/transport"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc"
+)
+
+type RemoteFeedsStorage interface {
+	// SetRemoteFeeds informs the manager which feeds the peer can provide.
+	SetRemoteFeeds(remote identity.Public, feeds RemoteFeeds)
+
+	// ForgetRemoteFeeds informs the manager that the peer disconnected and
+	// it no longer needs to remember which feeds it can provide.
+	ForgetRemoteFeeds(remote identity.Public)
+}
+
+// remoteFeedsRefresher periodically asks peers which feeds they can provide
+// using replicate.upto. A single loop is run per connection no matter how
+// many replication processes use that connection. Feeds reported by a peer
+// are forgotten once all of its connections end.
+type remoteFeedsRefresher struct {
+	storage RemoteFeedsStorage
+	every   time.Duration
+	timeout time.Duration
+	logger  logging.Logger
+
+	loops map[transport.Connection]*remoteFeedsRefresherLoop
+	lock  sync.Mutex // locks loops
+}
+
+func newRemoteFeedsRefresher(
+	storage RemoteFeedsStorage,
+	every time.Duration,
+	timeout time.Duration,
+	logger logging.Logger,
+) *remoteFeedsRefresher {
+	return &remoteFeedsRefresher{
+		storage: storage,
+		every:   every,
+		timeout: timeout,
+		logger:  logger.New("remote_feeds_refresher"),
+		loops:   make(map[transport.Connection]*remoteFeedsRefresherLoop),
+	}
+}
+
+// Run makes sure that the loop refreshing feeds of the given peer is running
+// at least until the context is cancelled.
+func (r *remoteFeedsRefresher) Run(ctx context.Context, peer transport.Peer) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	loop, ok := r.loops[peer.Conn()]
+	if !ok {
+		// The loop outlives the context of the caller if other replication
+		// processes still use the connection therefore it can't be used as
+		// the parent context.
+		loopCtx, cancel := context.WithCancel(context.Background())
+		loop = &remoteFeedsRefresherLoop{
+			remote: peer.Identity(),
+			cancel: cancel,
+		}
+		r.loops[peer.Conn()] = loop
+		go r.loop(loopCtx, peer)
+	}
+
+	loop.users++
+	go r.release(ctx, peer)
+}
+
+func (r *remoteFeedsRefresher) release(ctx context.Context, peer transport.Peer) {
+	<-ctx.Done()
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	loop := r.loops[peer.Conn()]
+	loop.users--
+	if loop.users == 0 {
+		loop.cancel()
+		delete(r.loops, peer.Conn())
+	}
+}
+
+func (r *remoteFeedsRefresher) loop(ctx context.Context, peer transport.Peer) {
+	r.refresh(ctx, peer)
+	<-ctx.Done()
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if !r.hasLoopsFor(peer.Identity()) {
+		r.storage.ForgetRemoteFeeds(peer.Identity())
+	}
+}
+
+// refresh stops early if the peer doesn't support replicate.upto in which
+// case the manager keeps asking it for all feeds.
+func (r *remoteFeedsRefresher) refresh(ctx context.Context, peer transport.Peer) {
+	logger := r.logger.WithField("peer", peer.Identity().String())
+
+	for {
+		feeds, err := r.getRemoteFeeds(ctx, peer)
+		if err != nil {
+			if errors.Is(err, rpc.RemoteError{}) {
+				logger.Debug().WithError(err).Message("peer doesn't support listing its feeds")
+				return
+			}
+			logger.Debug().WithError(err).Message("peer didn't list its feeds")
+		} else {
+			logger.Trace().WithField("feeds", feeds.Len()).Message("peer listed its feeds")
+			r.storage.SetRemoteFeeds(peer.Identity(), feeds)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(r.every):
+			continue
+		}
+	}
+}
+
+func (r *remoteFeedsRefresher) getRemoteFeeds(ctx context.Context, peer transport.Peer) (RemoteFeeds, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	request, err := messages.NewReplicateUpto(messages.NewReplicateUptoArguments())
+	if err != nil {
+		return RemoteFeeds{}, errors.Wrap(err, "could not create a request")
+	}
+
+	rs, err := peer.Conn().PerformRequest(ctx, request)
+	if err != nil {
+		return RemoteFeeds{}, errors.Wrap(err, "could not perform a request")
+	}
+
+	feeds := NewRemoteFeeds()
+	for response := range rs.Channel() {
+		if err := response.Err; err != nil {
+			if errors.Is(err, rpc.ErrRemoteEnd) {
+				break
+			}
+			return RemoteFeeds{}, errors.Wrap(err, "response stream error")
+		}
+
+		upto, err := messages.NewReplicateUptoResponseFromBytes(response.Value.Bytes())
+		if err != nil {
+			return RemoteFeeds{}, errors.Wrap(err, "could not parse the response")
+		}
+
+		feeds.Add(upto.Id(), upto.Sequence())
+	}
+
+	if err := ctx.Err(); err != nil {
+		return RemoteFeeds{}, errors.Wrap(err, "context error")
+	}
+
+	return feeds, nil
+}
+
+func (r *remoteFeedsRefresher) hasLoopsFor(remote identity.Public) bool {
+	for _, loop := range r.loops {
+		if loop.remote.Equal(remote) {
+			return true
+		}
+	}
+	return false
+}
+
+type remoteFeedsRefresherLoop struct {
+	remote identity.Public
+	users  int
+	cancel context.CancelFunc
+}
@@ -0,0 +1,197 @@
+package gossip
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/boreq/errors"
+	"github.com/planetary-social/scuttlego/internal/fixtures"
+	"github.com/planetary-social/scuttlego/internal/mocks"
+	"github.com/planetary-social/scuttlego/logging"
+	"github.com/planetary-social/scuttlego/service/domain/identity"
+	"github.com/planetary-social/scuttlego/service/domain/messages"
+	"github.com/planetary-social/scuttlego/service/domain/transport"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testRefreshEvery   = 10 * time.Millisecond
+	testRefreshTimeout = time.Second
+)
+
+func TestRemoteFeedsRefresher_OnlyOneLoopIsStartedPerConnection(t *testing.T) {
+	ctx := fixtures.TestContext(t)
+	storage := newRemoteFeedsStorageMock()
+	refresher := newRemoteFeedsRefresher(storage, time.Hour, testRefreshTimeout, logging.NewDevNullLogger())
+
+	peer, requests := newReplicateUptoPeer(ctx, func(n int) []rpc.ResponseWithError {
+		return someReplicateUptoResponses(t)
+	})
+
+	refresher.Run(ctx, peer)
+	refresher.Run(ctx, peer)
+
+	require.Eventually(t, func() bool {
+		return storage.SetCalls() == 1
+	}, time.Second, 10*time.Millisecond)
+
+	<-time.After(100 * time.Millisecond)
+	require.Equal(t, 1, requests())
+}
+
+func TestRemoteFeedsRefresher_RetriesAfterTransientErrors(t *testing.T) {
+	ctx := fixtures.TestContext(t)
+	storage := newRemoteFeedsStorageMock()
+	refresher := newRemoteFeedsRefresher(storage, testRefreshEvery, testRefreshTimeout, logging.NewDevNullLogger())
+
+	peer, _ := newReplicateUptoPeer(ctx, func(n int) []rpc.ResponseWithError {
+		if n == 1 {
+			return []rpc.ResponseWithError{
+				{
+					Err: errors.New("transient error"),
+				},
+			}
+		}
+		return someReplicateUptoResponses(t)
+	})
+
+	refresher.Run(ctx, peer)
+
+	require.Eventually(t, func() bool {
+		return storage.SetCalls() > 0
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestRemoteFeedsRefresher_StopsIfPeerDoesNotSupportReplicateUpto(t *testing.T) {
+	ctx := fixtures.TestContext(t)
+	storage := newRemoteFeedsStorageMock()
+	refresher := newRemoteFeedsRefresher(storage, testRefreshEvery, testRefreshTimeout, logging.NewDevNullLogger())
+
+	peer, requests := newReplicateUptoPeer(ctx, func(n int) []rpc.ResponseWithError {
+		return []rpc.ResponseWithError{
+			{
+				Err: rpc.NewRemoteError(nil),
+			},
+		}
+	})
+
+	refresher.Run(ctx, peer)
+
+	<-time.After(10 * testRefreshEvery)
+	require.Equal(t, 1, requests())
+	require.Equal(t, 0, storage.SetCalls())
+}
+
+func TestRemoteFeedsRefresher_FeedsAreForgottenOnlyOnceAllUsersOfTheConnectionFinish(t *testing.T) {
+	ctx := fixtures.TestContext(t)
+	storage := newRemoteFeedsStorageMock()
+	refresher := newRemoteFeedsRefresher(storage, testRefreshEvery, testRefreshTimeout, logging.NewDevNullLogger())
+
+	peer, _ := newReplicateUptoPeer(ctx, func(n int) []rpc.ResponseWithError {
+		return someReplicateUptoResponses(t)
+	})
+
+	ctx1, cancel1 := context.WithCancel(ctx)
+	defer cancel1()
+
+	ctx2, cancel2 := context.WithCancel(ctx)
+	defer cancel2()
+
+	refresher.Run(ctx1, peer)
+	refresher.Run(ctx2, peer)
+
+	require.Eventually(t, func() bool {
+		return storage.SetCalls() > 0
+	}, time.Second, 10*time.Millisecond)
+
+	cancel1()
+
+	<-time.After(10 * testRefreshEvery)
+	require.Empty(t, storage.ForgetCalls())
+
+	cancel2()
+
+	require.Eventually(t, func() bool {
+		forgetCalls := storage.ForgetCalls()
+		return len(forgetCalls) == 1 && forgetCalls[0].Equal(peer.Identity())
+	}, time.Second, 10*time.Millisecond)
+}
+
+func newReplicateUptoPeer(ctx context.Context, fn func(n int) []rpc.ResponseWithError) (transport.Peer, func() int) {
+	var requests int
+	var lock sync.Mutex
+
+	conn := mocks.NewConnectionMock(ctx)
+	conn.Mock(func(req *rpc.Request) []rpc.ResponseWithError {
+		if !req.Name().Equal(messages.ReplicateUptoProcedure.Name()) {
+			return nil
+		}
+
+		lock.Lock()
+		requests++
+		n := requests
+		lock.Unlock()
+
+		return fn(n)
+	})
+
+	return transport.MustNewPeer(fixtures.SomePublicIdentity(), conn), func() int {
+		lock.Lock()
+		defer lock.Unlock()
+		return requests
+	}
+}
+
+func someReplicateUptoResponses(t *testing.T) []rpc.ResponseWithError {
+	response, err := messages.NewReplicateUptoResponse(fixtures.SomeRefFeed(), fixtures.SomeSequence())
+	require.NoError(t, err)
+
+	j, err := response.MarshalJSON()
+	require.NoError(t, err)
+
+	return []rpc.ResponseWithError{
+		{
+			Value: rpc.NewResponse(j),
+		},
+		{
+			Err: rpc.ErrRemoteEnd,
+		},
+	}
+}
+
+type remoteFeedsStorageMock struct {
+	setCalls    int
+	forgetCalls []identity.Public
+	lock        sync.Mutex
+}
+
+func newRemoteFeedsStorageMock() *remoteFeedsStorageMock {
+	return &remoteFeedsStorageMock{}
+}
+
+func (m *remoteFeedsStorageMock) SetRemoteFeeds(remote identity.Public, feeds RemoteFeeds) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.setCalls++
+}
+
+func (m *remoteFeedsStorageMock) ForgetRemoteFeeds(remote identity.Public) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.forgetCalls = append(m.forgetCalls, remote)
+}
+
+func (m *remoteFeedsStorageMock) SetCalls() int {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return m.setCalls
+}
+
+func (m *remoteFeedsStorageMock) ForgetCalls() []identity.Public {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return append([]identity.Public(nil), m.forgetCalls...)
+}
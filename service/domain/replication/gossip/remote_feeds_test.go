@@ -0,0 +1,59 @@
+package gossip_test
+
+import (
+	"testing"
+
+	"github.com/planetary-social/scuttlego/internal/fixtures"
+	"github.com/planetary-social/scuttlego/service/domain/feeds/message"
+	"github.com/planetary-social/scuttlego/service/domain/replication"
+	"github.com/planetary-social/scuttlego/service/domain/replication/gossip"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRemoteFeeds_CanProvide(t *testing.T) {
+	feed := fixtures.SomeRefFeed()
+
+	remoteFeeds := gossip.NewRemoteFeeds()
+	remoteFeeds.Add(feed, message.MustNewSequence(5))
+
+	testCases := []struct {
+		Name     string
+		State    func() replication.FeedState
+		Expected bool
+	}{
+		{
+			Name:     "empty_feed",
+			State:    replication.NewEmptyFeedState,
+			Expected: true,
+		},
+		{
+			Name: "older_local_sequence",
+			State: func() replication.FeedState {
+				return replication.MustNewFeedState(message.MustNewSequence(4))
+			},
+			Expected: true,
+		},
+		{
+			Name: "equal_local_sequence",
+			State: func() replication.FeedState {
+				return replication.MustNewFeedState(message.MustNewSequence(5))
+			},
+			Expected: false,
+		},
+		{
+			Name: "newer_local_sequence",
+			State: func() replication.FeedState {
+				return replication.MustNewFeedState(message.MustNewSequence(6))
+			},
+			Expected: false,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			require.Equal(t, testCase.Expected, remoteFeeds.CanProvide(feed, testCase.State()))
+		})
+	}
+
+	require.False(t, remoteFeeds.CanProvide(fixtures.SomeRefFeed(), replication.NewEmptyFeedState()))
+}
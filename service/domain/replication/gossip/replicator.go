@@ -2,6 +2,7 @@ package gossip
 
 import (
 	"context"
+	"time"
 
 	"github.com/boreq/errors"
 	"github.com/planetary-social/scuttlego/logging"
@@ -11,6 +12,7 @@ import (
 	"github.com/planetary-social/scuttlego/service/domain/replication"
 	"github.com/planetary-social/scuttlego/service/domain/transport"
 	"github.com/planetary-social/scuttlego/service/domain/transport/rpc"
+	"github.com/planetary-social/scuttlego/tracing"
 )
 
 const (
@@ -27,23 +29,44 @@ const (
 	// and not to small to reduce the overhead related to sending new RPC
 	// requests too often.
 	limit = 1000
+
+	// How often to ask the peer which feeds it has using replicate.upto.
+	refreshRemoteFeedsEvery = 5 * time.Minute
+
+	// For how long to wait for the peer to list its feeds.
+	refreshRemoteFeedsTimeout = 30 * time.Second
 )
 
 type GossipReplicator struct {
-	manager ReplicationManager
-	handler replication.RawMessageHandler
-	logger  logging.Logger
+	manager     ReplicationManager
+	handler     replication.RawMessageHandler
+	tracer      *tracing.Tracer
+	publisher   replication.EventPublisher
+	remoteFeeds *remoteFeedsRefresher
+	logger      logging.Logger
 }
 
-func NewGossipReplicator(manager ReplicationManager, handler replication.RawMessageHandler, logger logging.Logger) (*GossipReplicator, error) {
+func NewGossipReplicator(
+	manager ReplicationManager,
+	handler replication.RawMessageHandler,
+	tracer *tracing.Tracer,
+	publisher replication.EventPublisher,
+	logger logging.Logger,
+) (*GossipReplicator, error) {
+	logger = logger.New("gossip_replicator")
 	return &GossipReplicator{
-		manager: manager,
-		handler: handler,
-		logger:  logger.New("gossip_replicator"),
+		manager:     manager,
+		handler:     handler,
+		tracer:      tracer,
+		publisher:   publisher,
+		remoteFeeds: newRemoteFeedsRefresher(manager, refreshRemoteFeedsEvery, refreshRemoteFeedsTimeout, logger),
+		logger:      logger,
 	}, nil
 }
 
 func (r GossipReplicator) Replicate(ctx context.Context, peer transport.Peer) error {
+	r.remoteFeeds.Run(ctx, peer)
+
 	feedsToReplicateCh := r.manager.GetFeedsToReplicate(ctx, peer.Identity())
 	r.startWorkers(ctx, peer, feedsToReplicateCh, numWorkers)
 	<-ctx.Done()
@@ -52,6 +75,8 @@ func (r GossipReplicator) Replicate(ctx context.Context, peer transport.Peer) er
 }
 
 func (r GossipReplicator) ReplicateSelf(ctx context.Context, peer transport.Peer) error {
+	r.remoteFeeds.Run(ctx, peer)
+
 	feedsToReplicateCh := r.manager.GetFeedsToReplicateSelf(ctx, peer.Identity())
 	r.startWorkers(ctx, peer, feedsToReplicateCh, numWorkersSelf)
 	<-ctx.Done()
@@ -80,20 +105,38 @@ func (r GossipReplicator) replicateFeedTask(ctx context.Context, peer transport.
 
 	logger.Trace().Message("starting")
 
+	ctx, span := r.tracer.Start(ctx, "gossip.create_history_stream")
+	defer span.End()
+
+	span.SetAttribute("peer", peer.Identity().String())
+	span.SetAttribute("feed", task.Id.String())
+	span.SetAttribute("state", task.State)
+
+	result := r.replicateFeedTaskResult(ctx, peer, task, logger, span)
+	span.SetAttribute("result", result)
+	task.OnComplete(result)
+}
+
+func (r GossipReplicator) replicateFeedTaskResult(ctx context.Context, peer transport.Peer, task ReplicateFeedTask, logger logging.Logger, span *tracing.Span) TaskResult {
+	r.publisher.PublishReplicationStarted(peer.Identity(), task.Id)
+
 	n, err := r.replicateFeed(ctx, peer, task)
+	span.SetAttribute("received_messages", n)
+
 	if err != nil && !errors.Is(err, rpc.ErrRemoteEnd) {
+		span.SetError(err)
+		r.publisher.PublishReplicationFinished(peer.Identity(), task.Id, n, err)
 		logger.Error().WithField("received_messages", n).WithError(err).Message("failed")
-		task.OnComplete(TaskResultFailed)
-		return
+		return TaskResultFailed
 	}
 
 	logger.Trace().WithField("received_messages", n).Message("finished")
+	r.publisher.PublishReplicationFinished(peer.Identity(), task.Id, n, nil)
 
 	if n < limit {
-		task.OnComplete(TaskResultDoesNotHaveMoreMessages)
-	} else {
-		task.OnComplete(TaskResultHasMoreMessages)
+		return TaskResultDoesNotHaveMoreMessages
 	}
+	return TaskResultHasMoreMessages
 }
 
 func (r GossipReplicator) replicateFeed(ctx context.Context, peer transport.Peer, feed ReplicateFeedTask) (int, error) {
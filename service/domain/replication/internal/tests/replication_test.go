@@ -29,9 +29,11 @@ func TestReplicationFallsBackToCreateHistoryStream(t *testing.T) {
 	conn.SetWasInitiatedByRemote(false)
 	peer := transport.MustNewPeer(fixtures.SomePublicIdentity(), conn)
 
+	feed := fixtures.SomeRefFeed()
+
 	tr.ContactsRepository.GetWantedFeedsReturnValue = replication.MustNewWantedFeeds([]replication.Contact{
 		replication.MustNewContact(
-			fixtures.SomeRefFeed(),
+			feed,
 			graph.MustNewHops(fixtures.SomePositiveInt()),
 			replication.NewEmptyFeedState(),
 		),
@@ -41,6 +43,10 @@ func TestReplicationFallsBackToCreateHistoryStream(t *testing.T) {
 	var requestsLock sync.Mutex
 
 	conn.Mock(func(req *rpc.Request) []rpc.ResponseWithError {
+		if req.Name().Equal(messages.ReplicateUptoProcedure.Name()) {
+			return replicateUptoNotSupported()
+		}
+
 		requestsLock.Lock()
 		defer requestsLock.Unlock()
 
@@ -82,6 +88,17 @@ func TestReplicationFallsBackToCreateHistoryStream(t *testing.T) {
 		return true
 	}, 1*time.Second, 10*time.Millisecond)
 
+	require.Eventually(t, func() bool {
+		return len(tr.EventPublisher.Finished()) > 0
+	}, 1*time.Second, 10*time.Millisecond)
+
+	require.Equal(t,
+		mocks.ReplicationEventPublisherMockEvent{Peer: peer.Identity(), Feed: feed},
+		tr.EventPublisher.Started()[0],
+	)
+	require.Equal(t, peer.Identity(), tr.EventPublisher.Finished()[0].Peer)
+	require.Equal(t, feed, tr.EventPublisher.Finished()[0].Feed)
+
 	replicateCancel()
 
 	select {
@@ -125,6 +142,10 @@ func TestReplicationPullsOwnStreamUsingCreateHistoryStreamEvenIfEpidemicBroadcas
 	defer replicateCancel()
 
 	conn.Mock(func(req *rpc.Request) []rpc.ResponseWithError {
+		if req.Name().Equal(messages.ReplicateUptoProcedure.Name()) {
+			return replicateUptoNotSupported()
+		}
+
 		requestsLock.Lock()
 		requests = append(requests, req)
 		requestsLock.Unlock()
@@ -184,3 +205,14 @@ func TestReplicationPullsOwnStreamUsingCreateHistoryStreamEvenIfEpidemicBroadcas
 		t.Fatal("timeout")
 	}
 }
+
+// replicateUptoNotSupported simulates a peer which doesn't support
+// replicate.upto so that only the replication requests are recorded.
+func replicateUptoNotSupported() []rpc.ResponseWithError {
+	return []rpc.ResponseWithError{
+		{
+			Value: nil,
+			Err:   rpc.NewRemoteError(nil),
+		},
+	}
+}
@@ -7,10 +7,12 @@ import (
 	"testing"
 
 	"github.com/google/wire"
+	"github.com/planetary-social/scuttlego/internal/mocks"
 	"github.com/planetary-social/scuttlego/logging"
 	"github.com/planetary-social/scuttlego/service/domain/replication"
 	"github.com/planetary-social/scuttlego/service/domain/replication/ebt"
 	"github.com/planetary-social/scuttlego/service/domain/replication/gossip"
+	"github.com/planetary-social/scuttlego/tracing"
 )
 
 type TestReplication struct {
@@ -19,6 +21,7 @@ type TestReplication struct {
 	RawMessageHandler  *RawMessageHandlerMock
 	ContactsRepository *WantedFeedsProviderMock
 	MessageStreamer    *MessageStreamerMock
+	EventPublisher     *mocks.ReplicationEventPublisherMock
 }
 
 func BuildTestReplication(t *testing.T) (TestReplication, error) {
@@ -55,6 +58,17 @@ func BuildTestReplication(t *testing.T) (TestReplication, error) {
 		NewMessageStreamerMock,
 		wire.Bind(new(ebt.MessageStreamer), new(*MessageStreamerMock)),
 
+		mocks.NewReplicationMetricsMock,
+		wire.Bind(new(replication.Metrics), new(*mocks.ReplicationMetricsMock)),
+
+		mocks.NewRawMessageIdentifierMock,
+		wire.Bind(new(ebt.RawMessagePeeker), new(*mocks.RawMessageIdentifierMock)),
+
+		mocks.NewReplicationEventPublisherMock,
+		wire.Bind(new(replication.EventPublisher), new(*mocks.ReplicationEventPublisherMock)),
+
+		tracing.NewDevNullTracer,
+
 		logging.NewDevNullLogger,
 		wire.Bind(new(logging.Logger), new(logging.DevNullLogger)),
 	)
@@ -9,10 +9,12 @@ package tests
 import (
 	"testing"
 
+	"github.com/planetary-social/scuttlego/internal/mocks"
 	"github.com/planetary-social/scuttlego/logging"
 	"github.com/planetary-social/scuttlego/service/domain/replication"
 	"github.com/planetary-social/scuttlego/service/domain/replication/ebt"
 	"github.com/planetary-social/scuttlego/service/domain/replication/gossip"
+	"github.com/planetary-social/scuttlego/tracing"
 )
 
 // Injectors from wire.go:
@@ -22,11 +24,15 @@ func BuildTestReplication(t *testing.T) (TestReplication, error) {
 	sessionTracker := ebt.NewSessionTracker()
 	rawMessageHandlerMock := NewRawMessageHandlerMock()
 	wantedFeedsProviderMock := NewWantedFeedsProviderMock()
-	wantedFeedsCache := replication.NewWantedFeedsCache(wantedFeedsProviderMock)
+	replicationMetricsMock := mocks.NewReplicationMetricsMock()
+	wantedFeedsCache := replication.NewWantedFeedsCache(wantedFeedsProviderMock, replicationMetricsMock)
 	messageStreamerMock := NewMessageStreamerMock()
-	sessionRunner := ebt.NewSessionRunner(devNullLogger, rawMessageHandlerMock, wantedFeedsCache, messageStreamerMock)
-	manager := gossip.NewManager(devNullLogger, wantedFeedsCache)
-	gossipReplicator, err := gossip.NewGossipReplicator(manager, rawMessageHandlerMock, devNullLogger)
+	rawMessageIdentifierMock := mocks.NewRawMessageIdentifierMock()
+	replicationEventPublisherMock := mocks.NewReplicationEventPublisherMock()
+	tracer := tracing.NewDevNullTracer()
+	sessionRunner := ebt.NewSessionRunner(devNullLogger, rawMessageHandlerMock, wantedFeedsCache, messageStreamerMock, replicationMetricsMock, rawMessageIdentifierMock, replicationEventPublisherMock, tracer)
+	manager := gossip.NewManager(devNullLogger, wantedFeedsCache, replicationMetricsMock)
+	gossipReplicator, err := gossip.NewGossipReplicator(manager, rawMessageHandlerMock, tracer, replicationEventPublisherMock, devNullLogger)
 	if err != nil {
 		return TestReplication{}, err
 	}
@@ -37,6 +43,7 @@ func BuildTestReplication(t *testing.T) (TestReplication, error) {
 		RawMessageHandler:  rawMessageHandlerMock,
 		ContactsRepository: wantedFeedsProviderMock,
 		MessageStreamer:    messageStreamerMock,
+		EventPublisher:     replicationEventPublisherMock,
 	}
 	return testReplication, nil
 }
@@ -49,4 +56,5 @@ type TestReplication struct {
 	RawMessageHandler  *RawMessageHandlerMock
 	ContactsRepository *WantedFeedsProviderMock
 	MessageStreamer    *MessageStreamerMock
+	EventPublisher     *mocks.ReplicationEventPublisherMock
 }
@@ -137,6 +137,7 @@ type WantedFeedsProvider interface {
 
 type WantedFeedsCache struct {
 	provider WantedFeedsProvider
+	metrics  Metrics
 
 	feedsWhichShouldNotBeReplicatedWithPeer map[string]*internal.Set[string]
 	cache                                   []Contact
@@ -144,9 +145,10 @@ type WantedFeedsCache struct {
 	lock                                    sync.Mutex // locks cache, cacheTimestamp, feedsWhichShouldNotBeReplicatedWithPeer
 }
 
-func NewWantedFeedsCache(provider WantedFeedsProvider) *WantedFeedsCache {
+func NewWantedFeedsCache(provider WantedFeedsProvider, metrics Metrics) *WantedFeedsCache {
 	return &WantedFeedsCache{
 		provider: provider,
+		metrics:  metrics,
 
 		feedsWhichShouldNotBeReplicatedWithPeer: make(map[string]*internal.Set[string]),
 	}
@@ -213,7 +215,24 @@ func (c *WantedFeedsCache) refreshCache() error {
 	contacts = append(contacts, v.Contacts()...)
 	// todo contacts can contain dupes?
 
+	c.forgetFeedsWhichAreNoLongerReplicated(contacts)
+
 	c.cache = contacts
 	c.cacheTimestamp = time.Now()
 	return nil
 }
+
+// forgetFeedsWhichAreNoLongerReplicated makes sure that metrics aren't
+// reported for feeds which disappeared from the cache.
+func (c *WantedFeedsCache) forgetFeedsWhichAreNoLongerReplicated(newContacts []Contact) {
+	newFeeds := internal.NewSet[string]()
+	for _, contact := range newContacts {
+		newFeeds.Put(contact.Who().String())
+	}
+
+	for _, contact := range c.cache {
+		if !newFeeds.Contains(contact.Who().String()) {
+			c.metrics.ForgetReplicationLag(contact.Who())
+		}
+	}
+}
@@ -1,12 +1,51 @@
 package replication_test
 
 import (
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/planetary-social/scuttlego/internal/fixtures"
+	"github.com/planetary-social/scuttlego/internal/mocks"
 	"github.com/planetary-social/scuttlego/service/domain/replication"
+	"github.com/stretchr/testify/require"
 )
 
+func TestWantedFeedsCache_ReplicationLagIsForgottenForFeedsWhichAreNoLongerWanted(t *testing.T) {
+	t.Parallel()
+
+	wantedFeedsProvider := newWantedFeedsProviderMock()
+	metrics := mocks.NewReplicationMetricsMock()
+	cache := replication.NewWantedFeedsCache(wantedFeedsProvider, metrics)
+
+	peer := fixtures.SomePublicIdentity()
+	remainingContact := replication.MustNewContact(fixtures.SomeRefFeed(), fixtures.SomeHops(), replication.NewEmptyFeedState())
+	removedContact := replication.MustNewContact(fixtures.SomeRefFeed(), fixtures.SomeHops(), replication.NewEmptyFeedState())
+
+	wantedFeedsProvider.SetWantedFeeds(replication.MustNewWantedFeeds([]replication.Contact{remainingContact, removedContact}, nil))
+
+	_, err := cache.GetContacts(peer)
+	require.NoError(t, err)
+
+	metrics.ReportReplicationLag(remainingContact.Who(), 1)
+	metrics.ReportReplicationLag(removedContact.Who(), 2)
+
+	wantedFeedsProvider.SetWantedFeeds(replication.MustNewWantedFeeds([]replication.Contact{remainingContact}, nil))
+
+	require.Eventually(t, func() bool {
+		contacts, err := cache.GetContacts(peer)
+		require.NoError(t, err)
+		return len(contacts) == 1
+	}, 10*time.Second, 100*time.Millisecond)
+
+	_, ok := metrics.ReplicationLag(removedContact.Who())
+	require.False(t, ok)
+
+	lag, ok := metrics.ReplicationLag(remainingContact.Who())
+	require.True(t, ok)
+	require.Equal(t, 1, lag)
+}
+
 func BenchmarkWantedFeedsCache_GetContacts(b *testing.B) {
 	wantedFeedsProvider := newWantedFeedsProviderMock()
 
@@ -21,10 +60,10 @@ func BenchmarkWantedFeedsCache_GetContacts(b *testing.B) {
 		)
 	}
 
-	wantedFeedsProvider.GetWantedFeedsReturnValue = replication.MustNewWantedFeeds(contacts, nil)
+	wantedFeedsProvider.SetWantedFeeds(replication.MustNewWantedFeeds(contacts, nil))
 
 	peer := fixtures.SomePublicIdentity()
-	cache := replication.NewWantedFeedsCache(wantedFeedsProvider)
+	cache := replication.NewWantedFeedsCache(wantedFeedsProvider, mocks.NewReplicationMetricsMock())
 
 	b.ResetTimer()
 	b.ReportAllocs()
@@ -38,13 +77,22 @@ func BenchmarkWantedFeedsCache_GetContacts(b *testing.B) {
 }
 
 type wantedFeedsProviderMock struct {
-	GetWantedFeedsReturnValue replication.WantedFeeds
+	wantedFeeds replication.WantedFeeds
+	lock        sync.Mutex
 }
 
 func newWantedFeedsProviderMock() *wantedFeedsProviderMock {
 	return &wantedFeedsProviderMock{}
 }
 
-func (w wantedFeedsProviderMock) GetWantedFeeds() (replication.WantedFeeds, error) {
-	return w.GetWantedFeedsReturnValue, nil
+func (w *wantedFeedsProviderMock) SetWantedFeeds(wantedFeeds replication.WantedFeeds) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	w.wantedFeeds = wantedFeeds
+}
+
+func (w *wantedFeedsProviderMock) GetWantedFeeds() (replication.WantedFeeds, error) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	return w.wantedFeeds, nil
 }
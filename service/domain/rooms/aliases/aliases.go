@@ -2,6 +2,7 @@ package aliases
 
 import (
 	"crypto/ed25519"
+	"encoding/base64"
 	"fmt"
 	"strings"
 
@@ -10,6 +11,10 @@ import (
 	"github.com/planetary-social/scuttlego/service/domain/refs"
 )
 
+var ErrInvalidSignature = errors.New("invalid signature")
+
+const registrationSignatureSuffix = ".sig.ed25519"
+
 type RegistrationMessage struct {
 	alias Alias
 	user  refs.Identity
@@ -36,6 +41,18 @@ func NewRegistrationMessage(alias Alias, user refs.Identity, room refs.Identity)
 	}, nil
 }
 
+func (r RegistrationMessage) Alias() Alias {
+	return r.alias
+}
+
+func (r RegistrationMessage) User() refs.Identity {
+	return r.user
+}
+
+func (r RegistrationMessage) Room() refs.Identity {
+	return r.room
+}
+
 func (r RegistrationMessage) String() string {
 	var message strings.Builder
 	message.WriteString("=room-alias-registration:")
@@ -79,16 +96,108 @@ func NewRegistrationSignature(msg RegistrationMessage, private identity.Private)
 	}, nil
 }
 
+func NewRegistrationSignatureFromBytes(b []byte) (RegistrationSignature, error) {
+	if len(b) != ed25519.SignatureSize {
+		return RegistrationSignature{}, errors.New("invalid signature length")
+	}
+
+	tmp := make([]byte, len(b))
+	copy(tmp, b)
+
+	return RegistrationSignature{
+		signature: tmp,
+	}, nil
+}
+
+// NewRegistrationSignatureFromString parses signatures in the format used by
+// rooms e.g. "base64signature.sig.ed25519".
+func NewRegistrationSignatureFromString(s string) (RegistrationSignature, error) {
+	if !strings.HasSuffix(s, registrationSignatureSuffix) {
+		return RegistrationSignature{}, errors.New("invalid signature suffix")
+	}
+
+	b, err := base64.StdEncoding.DecodeString(strings.TrimSuffix(s, registrationSignatureSuffix))
+	if err != nil {
+		return RegistrationSignature{}, errors.Wrap(err, "error decoding the signature")
+	}
+
+	return NewRegistrationSignatureFromBytes(b)
+}
+
+// Verify checks if the signature was created by the user from the provided
+// message.
+func (s RegistrationSignature) Verify(msg RegistrationMessage) bool {
+	return ed25519.Verify(msg.user.Identity().PublicKey(), []byte(msg.String()), s.signature)
+}
+
 func (s RegistrationSignature) Bytes() []byte {
 	tmp := make([]byte, len(s.signature))
 	copy(tmp, s.signature)
 	return tmp
 }
 
+func (s RegistrationSignature) String() string {
+	return base64.StdEncoding.EncodeToString(s.signature) + registrationSignatureSuffix
+}
+
 func (s RegistrationSignature) IsZero() bool {
 	return len(s.signature) == 0
 }
 
+// Registration is an alias registered by a user in a room. Creating a
+// registration ensures that the signature is valid.
+type Registration struct {
+	msg       RegistrationMessage
+	signature RegistrationSignature
+}
+
+func NewRegistration(msg RegistrationMessage, signature RegistrationSignature) (Registration, error) {
+	if msg.IsZero() {
+		return Registration{}, errors.New("zero value of registration message")
+	}
+
+	if signature.IsZero() {
+		return Registration{}, errors.New("zero value of signature")
+	}
+
+	if !signature.Verify(msg) {
+		return Registration{}, ErrInvalidSignature
+	}
+
+	return Registration{
+		msg:       msg,
+		signature: signature,
+	}, nil
+}
+
+func MustNewRegistration(msg RegistrationMessage, signature RegistrationSignature) Registration {
+	v, err := NewRegistration(msg, signature)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+func (r Registration) Alias() Alias {
+	return r.msg.alias
+}
+
+func (r Registration) User() refs.Identity {
+	return r.msg.user
+}
+
+func (r Registration) Room() refs.Identity {
+	return r.msg.room
+}
+
+func (r Registration) Signature() RegistrationSignature {
+	return r.signature
+}
+
+func (r Registration) IsZero() bool {
+	return r.msg.IsZero()
+}
+
 type Alias struct {
 	s string
 }
@@ -156,6 +265,10 @@ func MustNewAliasEndpointURL(s string) AliasEndpointURL {
 	return v
 }
 
+func (a AliasEndpointURL) IsZero() bool {
+	return a == AliasEndpointURL{}
+}
+
 func (a AliasEndpointURL) String() string {
 	return a.s
 }
@@ -128,3 +128,39 @@ func TestNewAliasEndpointURL(t *testing.T) {
 		})
 	}
 }
+
+func TestNewRegistration_VerifiesTheSignature(t *testing.T) {
+	identity := fixtures.SomePrivateIdentity()
+	user := refs.MustNewIdentityFromPublic(identity.Public())
+	room := fixtures.SomeRefIdentity()
+
+	message, err := aliases.NewRegistrationMessage(fixtures.SomeAlias(), user, room)
+	require.NoError(t, err)
+
+	signature, err := aliases.NewRegistrationSignature(message, identity)
+	require.NoError(t, err)
+
+	signatureFromBytes, err := aliases.NewRegistrationSignatureFromBytes(signature.Bytes())
+	require.NoError(t, err)
+
+	signatureFromString, err := aliases.NewRegistrationSignatureFromString(signature.String())
+	require.NoError(t, err)
+	require.Equal(t, signature.Bytes(), signatureFromString.Bytes())
+
+	registration, err := aliases.NewRegistration(message, signatureFromBytes)
+	require.NoError(t, err)
+	require.Equal(t, message.Alias(), registration.Alias())
+	require.Equal(t, user, registration.User())
+	require.Equal(t, room, registration.Room())
+
+	otherMessage, err := aliases.NewRegistrationMessage(fixtures.SomeAlias(), user, room)
+	require.NoError(t, err)
+
+	_, err = aliases.NewRegistration(otherMessage, signature)
+	require.ErrorIs(t, err, aliases.ErrInvalidSignature)
+}
+
+func TestNewRegistrationSignatureFromBytes_ChecksLength(t *testing.T) {
+	_, err := aliases.NewRegistrationSignatureFromBytes(fixtures.SomeBytesOfLength(10))
+	require.EqualError(t, err, "invalid signature length")
+}
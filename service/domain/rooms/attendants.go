@@ -0,0 +1,110 @@
+package rooms
+
+import (
+	"time"
+
+	"github.com/boreq/errors"
+	"github.com/planetary-social/scuttlego/service/domain/refs"
+	"github.com/planetary-social/scuttlego/service/domain/transport"
+)
+
+// PortalAttendants describes attendants of a room that we are connected to.
+type PortalAttendants struct {
+	portal     refs.Identity
+	attendants []Attendant
+}
+
+func NewPortalAttendants(portal refs.Identity, attendants []Attendant) (PortalAttendants, error) {
+	if portal.IsZero() {
+		return PortalAttendants{}, errors.New("zero value of portal")
+	}
+
+	for _, attendant := range attendants {
+		if attendant.IsZero() {
+			return PortalAttendants{}, errors.New("zero value of attendant")
+		}
+	}
+
+	return PortalAttendants{
+		portal:     portal,
+		attendants: attendants,
+	}, nil
+}
+
+func MustNewPortalAttendants(portal refs.Identity, attendants []Attendant) PortalAttendants {
+	v, err := NewPortalAttendants(portal, attendants)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+func (p PortalAttendants) Portal() refs.Identity {
+	return p.portal
+}
+
+func (p PortalAttendants) Attendants() []Attendant {
+	return p.attendants
+}
+
+func (p PortalAttendants) IsZero() bool {
+	return p.portal.IsZero()
+}
+
+// Attendant is a peer present in a room.
+type Attendant struct {
+	id     refs.Identity
+	joined time.Time
+
+	// tunnel is the connection which we established with this attendant
+	// via the room or nil if we never successfully opened a tunnel. The
+	// connection may already be closed, compare it with the connections
+	// which are currently open to find out if the tunnel is still in use.
+	tunnel transport.Connection
+}
+
+// NewAttendant creates a new attendant. Tunnel is nil if a tunnel to this
+// attendant wasn't established.
+func NewAttendant(id refs.Identity, joined time.Time, tunnel transport.Connection) (Attendant, error) {
+	if id.IsZero() {
+		return Attendant{}, errors.New("zero value of id")
+	}
+
+	if joined.IsZero() {
+		return Attendant{}, errors.New("zero value of joined")
+	}
+
+	return Attendant{
+		id:     id,
+		joined: joined,
+		tunnel: tunnel,
+	}, nil
+}
+
+func MustNewAttendant(id refs.Identity, joined time.Time, tunnel transport.Connection) Attendant {
+	v, err := NewAttendant(id, joined, tunnel)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+func (a Attendant) Id() refs.Identity {
+	return a.id
+}
+
+// Joined returns the time at which we learned that this attendant joined the
+// room.
+func (a Attendant) Joined() time.Time {
+	return a.joined
+}
+
+// Tunnel returns the connection established with this attendant via the
+// room. It doesn't mean that the connection is still open.
+func (a Attendant) Tunnel() (transport.Connection, bool) {
+	return a.tunnel, a.tunnel != nil
+}
+
+func (a Attendant) IsZero() bool {
+	return a.id.IsZero()
+}
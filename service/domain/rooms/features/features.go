@@ -44,7 +44,19 @@ func (f Features) Contains(feature Feature) bool {
 }
 
 var (
+	// FeatureTunnel means that the room can relay tunnels between attendants.
 	FeatureTunnel = Feature{"tunnel"}
+
+	// FeatureRoom1 means that the room is compatible with rooms 1.0 clients
+	// which use tunnel.announce, tunnel.leave and tunnel.endpoints.
+	FeatureRoom1 = Feature{"room1"}
+
+	// FeatureRoom2 means that the room implements rooms 2.0 e.g.
+	// room.attendants and privacy modes.
+	FeatureRoom2 = Feature{"room2"}
+
+	// FeatureAlias means that the room supports registering aliases.
+	FeatureAlias = Feature{"alias"}
 )
 
 type Feature struct {
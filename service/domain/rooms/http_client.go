@@ -0,0 +1,281 @@
+package rooms
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/boreq/errors"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/planetary-social/scuttlego/service/domain/network"
+	"github.com/planetary-social/scuttlego/service/domain/refs"
+	"github.com/planetary-social/scuttlego/service/domain/rooms/aliases"
+)
+
+const (
+	httpClientTimeout = 30 * time.Second
+
+	// maxHTTPResponseSize limits the size of responses read from rooms.
+	maxHTTPResponseSize = 1024 * 1024
+
+	httpResponseStatusSuccessful = "successful"
+)
+
+// HTTPClient implements the client side of HTTP endpoints defined by the
+// Rooms 2.0 specification.
+type HTTPClient struct {
+	client *http.Client
+}
+
+func NewHTTPClient() *HTTPClient {
+	return &HTTPClient{
+		client: &http.Client{
+			Timeout: httpClientTimeout,
+		},
+	}
+}
+
+// ResolveAlias fetches information about an alias from its endpoint URL e.g.
+// "https://somealias.room.example.com". The registration signature returned
+// by the room is verified.
+func (c *HTTPClient) ResolveAlias(ctx context.Context, endpoint aliases.AliasEndpointURL) (ResolvedAlias, error) {
+	u, err := url.Parse(endpoint.String())
+	if err != nil {
+		return ResolvedAlias{}, errors.Wrap(err, "error parsing the url")
+	}
+
+	query := u.Query()
+	query.Set("encoding", "json")
+	u.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return ResolvedAlias{}, errors.Wrap(err, "error creating the request")
+	}
+
+	var response resolveAliasResponse
+	if err := c.do(req, &response); err != nil {
+		return ResolvedAlias{}, errors.Wrap(err, "request failed")
+	}
+
+	if err := response.err(); err != nil {
+		return ResolvedAlias{}, errors.Wrap(err, "room returned an error")
+	}
+
+	return newResolvedAliasFromResponse(response)
+}
+
+// ConsumeInvite uses an invite to become a member of a room and returns the
+// address of that room.
+func (c *HTTPClient) ConsumeInvite(ctx context.Context, invite HTTPInvite, user refs.Identity) (network.MultiserverAddress, error) {
+	body, err := jsoniter.Marshal(consumeInviteRequest{
+		Invite: invite.token,
+		Id:     user.String(),
+	})
+	if err != nil {
+		return network.MultiserverAddress{}, errors.Wrap(err, "error marshaling the request")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, invite.consumeURL, bytes.NewReader(body))
+	if err != nil {
+		return network.MultiserverAddress{}, errors.Wrap(err, "error creating the request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	var response consumeInviteResponse
+	if err := c.do(req, &response); err != nil {
+		return network.MultiserverAddress{}, errors.Wrap(err, "request failed")
+	}
+
+	if err := response.err(); err != nil {
+		return network.MultiserverAddress{}, errors.Wrap(err, "room returned an error")
+	}
+
+	address, err := network.NewMultiserverAddress(response.MultiserverAddress)
+	if err != nil {
+		return network.MultiserverAddress{}, errors.Wrap(err, "error parsing the address")
+	}
+
+	return address, nil
+}
+
+func (c *HTTPClient) do(req *http.Request, v any) error {
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "error performing the request")
+	}
+	defer resp.Body.Close()
+
+	b, err := io.ReadAll(io.LimitReader(resp.Body, maxHTTPResponseSize))
+	if err != nil {
+		return errors.Wrap(err, "error reading the body")
+	}
+
+	// rooms return error details in JSON with non-2xx status codes
+	if err := jsoniter.Unmarshal(b, v); err != nil {
+		return errors.Wrapf(err, "json unmarshal failed (status code: %d)", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// ResolvedAlias contains information needed to connect to a user using an
+// alias registered in a room.
+type ResolvedAlias struct {
+	registration aliases.Registration
+	address      network.MultiserverAddress
+}
+
+func newResolvedAliasFromResponse(response resolveAliasResponse) (ResolvedAlias, error) {
+	alias, err := aliases.NewAlias(response.Alias)
+	if err != nil {
+		return ResolvedAlias{}, errors.Wrap(err, "invalid alias")
+	}
+
+	user, err := refs.NewIdentity(response.UserId)
+	if err != nil {
+		return ResolvedAlias{}, errors.Wrap(err, "invalid user id")
+	}
+
+	room, err := refs.NewIdentity(response.RoomId)
+	if err != nil {
+		return ResolvedAlias{}, errors.Wrap(err, "invalid room id")
+	}
+
+	signature, err := aliases.NewRegistrationSignatureFromString(response.Signature)
+	if err != nil {
+		return ResolvedAlias{}, errors.Wrap(err, "invalid signature")
+	}
+
+	address, err := network.NewMultiserverAddress(response.MultiserverAddress)
+	if err != nil {
+		return ResolvedAlias{}, errors.Wrap(err, "invalid multiserver address")
+	}
+
+	if !address.Remote().Equal(room.Identity()) {
+		return ResolvedAlias{}, errors.New("multiserver address doesn't point to the room")
+	}
+
+	msg, err := aliases.NewRegistrationMessage(alias, user, room)
+	if err != nil {
+		return ResolvedAlias{}, errors.Wrap(err, "error creating the registration message")
+	}
+
+	registration, err := aliases.NewRegistration(msg, signature)
+	if err != nil {
+		return ResolvedAlias{}, errors.Wrap(err, "error creating the registration")
+	}
+
+	return ResolvedAlias{
+		registration: registration,
+		address:      address,
+	}, nil
+}
+
+func (r ResolvedAlias) Alias() aliases.Alias {
+	return r.registration.Alias()
+}
+
+func (r ResolvedAlias) User() refs.Identity {
+	return r.registration.User()
+}
+
+func (r ResolvedAlias) Room() refs.Identity {
+	return r.registration.Room()
+}
+
+// Address is the address of the room which can be used to open a tunnel to
+// the user.
+func (r ResolvedAlias) Address() network.MultiserverAddress {
+	return r.address
+}
+
+// HTTPInvite is a room invite in the form of a URL e.g.
+// "https://room.example.com/join?invite=sometoken".
+type HTTPInvite struct {
+	token      string
+	consumeURL string
+}
+
+func NewHTTPInvite(s string) (HTTPInvite, error) {
+	u, err := url.Parse(s)
+	if err != nil {
+		return HTTPInvite{}, errors.Wrap(err, "error parsing the url")
+	}
+
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return HTTPInvite{}, fmt.Errorf("invalid scheme '%s'", u.Scheme)
+	}
+
+	token := u.Query().Get("invite")
+	if token == "" {
+		return HTTPInvite{}, errors.New("missing invite token")
+	}
+
+	consumeURL := url.URL{
+		Scheme: u.Scheme,
+		Host:   u.Host,
+		Path:   "/invite/consume",
+	}
+
+	return HTTPInvite{
+		token:      token,
+		consumeURL: consumeURL.String(),
+	}, nil
+}
+
+func MustNewHTTPInvite(s string) HTTPInvite {
+	v, err := NewHTTPInvite(s)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+func (i HTTPInvite) Token() string {
+	return i.token
+}
+
+func (i HTTPInvite) IsZero() bool {
+	return i.token == ""
+}
+
+type httpResponse struct {
+	Status string `json:"status"`
+	Error  string `json:"error"`
+}
+
+func (r httpResponse) err() error {
+	if r.Status == httpResponseStatusSuccessful {
+		return nil
+	}
+	if r.Error != "" {
+		return errors.New(r.Error)
+	}
+	return fmt.Errorf("unexpected status '%s'", r.Status)
+}
+
+type resolveAliasResponse struct {
+	httpResponse
+	MultiserverAddress string `json:"multiserverAddress"`
+	RoomId             string `json:"roomId"`
+	UserId             string `json:"userId"`
+	Alias              string `json:"alias"`
+	Signature          string `json:"signature"`
+}
+
+type consumeInviteRequest struct {
+	Invite string `json:"invite"`
+	Id     string `json:"id"`
+}
+
+type consumeInviteResponse struct {
+	httpResponse
+	MultiserverAddress string `json:"multiserverAddress"`
+}
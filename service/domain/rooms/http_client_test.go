@@ -0,0 +1,210 @@
+package rooms_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/planetary-social/scuttlego/internal/fixtures"
+	"github.com/planetary-social/scuttlego/service/domain/network"
+	"github.com/planetary-social/scuttlego/service/domain/refs"
+	"github.com/planetary-social/scuttlego/service/domain/rooms"
+	"github.com/planetary-social/scuttlego/service/domain/rooms/aliases"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTPClient_ResolveAlias(t *testing.T) {
+	room := fixtures.SomeRefIdentity()
+	alias := fixtures.SomeAlias()
+	userPrivate := fixtures.SomePrivateIdentity()
+	user := refs.MustNewIdentityFromPublic(userPrivate.Public())
+	address := someMultiserverAddress(room)
+
+	msg, err := aliases.NewRegistrationMessage(alias, user, room)
+	require.NoError(t, err)
+
+	validSignature, err := aliases.NewRegistrationSignature(msg, userPrivate)
+	require.NoError(t, err)
+
+	otherMsg, err := aliases.NewRegistrationMessage(fixtures.SomeAlias(), user, room)
+	require.NoError(t, err)
+
+	invalidSignature, err := aliases.NewRegistrationSignature(otherMsg, userPrivate)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		Name          string
+		Response      map[string]string
+		ExpectedError string
+	}{
+		{
+			Name: "valid",
+			Response: map[string]string{
+				"status":             "successful",
+				"multiserverAddress": address,
+				"roomId":             room.String(),
+				"userId":             user.String(),
+				"alias":              alias.String(),
+				"signature":          validSignature.String(),
+			},
+		},
+		{
+			Name: "invalid_signature",
+			Response: map[string]string{
+				"status":             "successful",
+				"multiserverAddress": address,
+				"roomId":             room.String(),
+				"userId":             user.String(),
+				"alias":              alias.String(),
+				"signature":          invalidSignature.String(),
+			},
+			ExpectedError: "invalid signature",
+		},
+		{
+			Name: "address_of_a_different_room",
+			Response: map[string]string{
+				"status":             "successful",
+				"multiserverAddress": someMultiserverAddress(fixtures.SomeRefIdentity()),
+				"roomId":             room.String(),
+				"userId":             user.String(),
+				"alias":              alias.String(),
+				"signature":          validSignature.String(),
+			},
+			ExpectedError: "multiserver address doesn't point to the room",
+		},
+		{
+			Name: "failed",
+			Response: map[string]string{
+				"status": "failed",
+				"error":  "alias not found",
+			},
+			ExpectedError: "alias not found",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				require.Equal(t, http.MethodGet, r.Method)
+				require.Equal(t, "json", r.URL.Query().Get("encoding"))
+				writeJSON(t, w, testCase.Response)
+			}))
+			defer server.Close()
+
+			client := rooms.NewHTTPClient()
+
+			resolved, err := client.ResolveAlias(context.Background(), aliases.MustNewAliasEndpointURL(server.URL))
+			if testCase.ExpectedError != "" {
+				require.ErrorContains(t, err, testCase.ExpectedError)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, alias, resolved.Alias())
+			require.Equal(t, user, resolved.User())
+			require.Equal(t, room, resolved.Room())
+			require.Equal(t, network.MustNewMultiserverAddress(address), resolved.Address())
+		})
+	}
+}
+
+func TestHTTPClient_ConsumeInvite(t *testing.T) {
+	room := fixtures.SomeRefIdentity()
+	user := fixtures.SomeRefIdentity()
+	token := fixtures.SomeString()
+	address := someMultiserverAddress(room)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPost, r.Method)
+		require.Equal(t, "/invite/consume", r.URL.Path)
+
+		var request map[string]string
+		err := jsoniter.NewDecoder(r.Body).Decode(&request)
+		require.NoError(t, err)
+
+		if request["invite"] != token {
+			writeJSON(t, w, map[string]string{
+				"status": "failed",
+				"error":  "invalid invite",
+			})
+			return
+		}
+
+		require.Equal(t, user.String(), request["id"])
+
+		writeJSON(t, w, map[string]string{
+			"status":             "successful",
+			"multiserverAddress": address,
+		})
+	}))
+	defer server.Close()
+
+	client := rooms.NewHTTPClient()
+
+	t.Run("valid", func(t *testing.T) {
+		invite := rooms.MustNewHTTPInvite(server.URL + "/join?invite=" + token)
+
+		result, err := client.ConsumeInvite(context.Background(), invite, user)
+		require.NoError(t, err)
+		require.Equal(t, network.MustNewMultiserverAddress(address), result)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		invite := rooms.MustNewHTTPInvite(server.URL + "/join?invite=" + fixtures.SomeString())
+
+		_, err := client.ConsumeInvite(context.Background(), invite, user)
+		require.ErrorContains(t, err, "invalid invite")
+	})
+}
+
+func TestNewHTTPInvite(t *testing.T) {
+	testCases := []struct {
+		Name          string
+		URL           string
+		ExpectedToken string
+		ExpectedError bool
+	}{
+		{
+			Name:          "valid",
+			URL:           "https://room.example.com/join?invite=sometoken",
+			ExpectedToken: "sometoken",
+		},
+		{
+			Name:          "missing_token",
+			URL:           "https://room.example.com/join",
+			ExpectedError: true,
+		},
+		{
+			Name:          "invalid_scheme",
+			URL:           "ssb:experimental?action=claim-http-invite&invite=sometoken",
+			ExpectedError: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			invite, err := rooms.NewHTTPInvite(testCase.URL)
+			if testCase.ExpectedError {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, testCase.ExpectedToken, invite.Token())
+		})
+	}
+}
+
+func someMultiserverAddress(room refs.Identity) string {
+	key := strings.TrimSuffix(strings.TrimPrefix(room.String(), "@"), ".ed25519")
+	return "net:room.example.com:8008~shs:" + key
+}
+
+func writeJSON(t *testing.T, w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	err := jsoniter.NewEncoder(w).Encode(v)
+	require.NoError(t, err)
+}
@@ -0,0 +1,89 @@
+package httpauth
+
+import (
+	"time"
+
+	"github.com/boreq/errors"
+	"github.com/planetary-social/scuttlego/service/domain/refs"
+)
+
+// ClientChallengeTTL specifies for how long a client challenge can be used
+// after it was created.
+const ClientChallengeTTL = 5 * time.Minute
+
+var (
+	ErrClientChallengeExpired        = errors.New("client challenge expired")
+	ErrClientChallengeForAnotherRoom = errors.New("client challenge was created for another room")
+)
+
+// ClientChallenge is a client challenge (cc) created by this node when the
+// user initiates signing in to the website of a room. Rooms requesting
+// solutions for challenges which weren't created this way are refused.
+type ClientChallenge struct {
+	room      refs.Identity
+	challenge Challenge
+	createdAt time.Time
+}
+
+func NewClientChallenge(room refs.Identity, challenge Challenge, createdAt time.Time) (ClientChallenge, error) {
+	if room.IsZero() {
+		return ClientChallenge{}, errors.New("zero value of room")
+	}
+
+	if challenge.IsZero() {
+		return ClientChallenge{}, errors.New("zero value of challenge")
+	}
+
+	if createdAt.IsZero() {
+		return ClientChallenge{}, errors.New("zero value of created at")
+	}
+
+	return ClientChallenge{
+		room:      room,
+		challenge: challenge,
+		createdAt: createdAt,
+	}, nil
+}
+
+func MustNewClientChallenge(room refs.Identity, challenge Challenge, createdAt time.Time) ClientChallenge {
+	v, err := NewClientChallenge(room, challenge, createdAt)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+func (c ClientChallenge) Room() refs.Identity {
+	return c.room
+}
+
+func (c ClientChallenge) Challenge() Challenge {
+	return c.challenge
+}
+
+func (c ClientChallenge) CreatedAt() time.Time {
+	return c.createdAt
+}
+
+// Expired returns true if the challenge can no longer be used.
+func (c ClientChallenge) Expired(now time.Time) bool {
+	return now.Sub(c.createdAt) > ClientChallengeTTL
+}
+
+// CheckCanBeUsed returns an error if the challenge can't be used to answer a
+// request sent by the provided room.
+func (c ClientChallenge) CheckCanBeUsed(room refs.Identity, now time.Time) error {
+	if !c.room.Equal(room) {
+		return ErrClientChallengeForAnotherRoom
+	}
+
+	if c.Expired(now) {
+		return ErrClientChallengeExpired
+	}
+
+	return nil
+}
+
+func (c ClientChallenge) IsZero() bool {
+	return c.room.IsZero()
+}
@@ -0,0 +1,55 @@
+package httpauth_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/planetary-social/scuttlego/internal/fixtures"
+	"github.com/planetary-social/scuttlego/service/domain/refs"
+	"github.com/planetary-social/scuttlego/service/domain/rooms/httpauth"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientChallenge_CheckCanBeUsed(t *testing.T) {
+	room := fixtures.SomeRefIdentity()
+	createdAt := fixtures.SomeTime()
+
+	testCases := []struct {
+		Name          string
+		Room          refs.Identity
+		Now           time.Time
+		ExpectedError error
+	}{
+		{
+			Name:          "valid",
+			Room:          room,
+			Now:           createdAt.Add(httpauth.ClientChallengeTTL),
+			ExpectedError: nil,
+		},
+		{
+			Name:          "expired",
+			Room:          room,
+			Now:           createdAt.Add(httpauth.ClientChallengeTTL + time.Second),
+			ExpectedError: httpauth.ErrClientChallengeExpired,
+		},
+		{
+			Name:          "another_room",
+			Room:          fixtures.SomeRefIdentity(),
+			Now:           createdAt,
+			ExpectedError: httpauth.ErrClientChallengeForAnotherRoom,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			cc := httpauth.MustNewClientChallenge(room, httpauth.MustNewChallenge(), createdAt)
+
+			err := cc.CheckCanBeUsed(testCase.Room, testCase.Now)
+			if testCase.ExpectedError == nil {
+				require.NoError(t, err)
+			} else {
+				require.ErrorIs(t, err, testCase.ExpectedError)
+			}
+		})
+	}
+}
@@ -0,0 +1,171 @@
+// Package httpauth implements the "Sign-in with SSB" protocol used by rooms to
+// let users log into their web interfaces using their SSB identities.
+package httpauth
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"strings"
+
+	"github.com/boreq/errors"
+	"github.com/planetary-social/scuttlego/service/domain/identity"
+	"github.com/planetary-social/scuttlego/service/domain/refs"
+)
+
+const (
+	challengeLength = 32
+	solutionSuffix  = ".sig.ed25519"
+)
+
+// Challenge is a random value generated by either the client (cc) or the
+// server (sc).
+type Challenge struct {
+	b []byte
+}
+
+func NewChallenge() (Challenge, error) {
+	b := make([]byte, challengeLength)
+	if _, err := rand.Read(b); err != nil {
+		return Challenge{}, errors.Wrap(err, "error reading random bytes")
+	}
+	return Challenge{b: b}, nil
+}
+
+func MustNewChallenge() Challenge {
+	v, err := NewChallenge()
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+func NewChallengeFromString(s string) (Challenge, error) {
+	b, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		return Challenge{}, errors.Wrap(err, "error decoding the challenge")
+	}
+
+	if len(b) != challengeLength {
+		return Challenge{}, errors.New("invalid challenge length")
+	}
+
+	return Challenge{b: b}, nil
+}
+
+func (c Challenge) String() string {
+	return base64.StdEncoding.EncodeToString(c.b)
+}
+
+func (c Challenge) IsZero() bool {
+	return len(c.b) == 0
+}
+
+// SignInMessage is the message signed by the client to prove its identity to
+// the server.
+type SignInMessage struct {
+	sid refs.Identity
+	cid refs.Identity
+	sc  Challenge
+	cc  Challenge
+}
+
+// NewSignInMessage creates a new message. Sid is the identity of the server,
+// cid is the identity of the client, sc is the challenge generated by the
+// server and cc is the challenge generated by the client.
+func NewSignInMessage(sid, cid refs.Identity, sc, cc Challenge) (SignInMessage, error) {
+	if sid.IsZero() {
+		return SignInMessage{}, errors.New("zero value of sid")
+	}
+
+	if cid.IsZero() {
+		return SignInMessage{}, errors.New("zero value of cid")
+	}
+
+	if sc.IsZero() {
+		return SignInMessage{}, errors.New("zero value of sc")
+	}
+
+	if cc.IsZero() {
+		return SignInMessage{}, errors.New("zero value of cc")
+	}
+
+	return SignInMessage{
+		sid: sid,
+		cid: cid,
+		sc:  sc,
+		cc:  cc,
+	}, nil
+}
+
+func (m SignInMessage) String() string {
+	var message strings.Builder
+	message.WriteString("=http-auth-sign-in:")
+	message.WriteString(m.sid.String())
+	message.WriteString(":")
+	message.WriteString(m.cid.String())
+	message.WriteString(":")
+	message.WriteString(m.sc.String())
+	message.WriteString(":")
+	message.WriteString(m.cc.String())
+	return message.String()
+}
+
+func (m SignInMessage) IsZero() bool {
+	return m.sid.IsZero()
+}
+
+// Solution is a signature of the sign-in message created by the client.
+type Solution struct {
+	signature []byte
+}
+
+func NewSolution(msg SignInMessage, private identity.Private) (Solution, error) {
+	if msg.IsZero() {
+		return Solution{}, errors.New("zero value of message")
+	}
+
+	if private.IsZero() {
+		return Solution{}, errors.New("zero value of identity")
+	}
+
+	public, err := refs.NewIdentityFromPublic(private.Public())
+	if err != nil {
+		return Solution{}, errors.Wrap(err, "failed to create a public identity")
+	}
+
+	if !public.Equal(msg.cid) {
+		return Solution{}, errors.New("private identity doesn't match client identity from the message")
+	}
+
+	return Solution{
+		signature: ed25519.Sign(private.PrivateKey(), []byte(msg.String())),
+	}, nil
+}
+
+func NewSolutionFromString(s string) (Solution, error) {
+	b, err := base64.StdEncoding.DecodeString(strings.TrimSuffix(s, solutionSuffix))
+	if err != nil {
+		return Solution{}, errors.Wrap(err, "error decoding the solution")
+	}
+
+	if len(b) != ed25519.SignatureSize {
+		return Solution{}, errors.New("invalid solution length")
+	}
+
+	return Solution{signature: b}, nil
+}
+
+// Verify checks if the solution was created by the client from the provided
+// message.
+func (s Solution) Verify(msg SignInMessage) bool {
+	return ed25519.Verify(msg.cid.Identity().PublicKey(), []byte(msg.String()), s.signature)
+}
+
+func (s Solution) String() string {
+	return base64.StdEncoding.EncodeToString(s.signature) + solutionSuffix
+}
+
+func (s Solution) IsZero() bool {
+	return len(s.signature) == 0
+}
@@ -0,0 +1,82 @@
+package httpauth_test
+
+import (
+	"testing"
+
+	"github.com/planetary-social/scuttlego/internal/fixtures"
+	"github.com/planetary-social/scuttlego/service/domain/refs"
+	"github.com/planetary-social/scuttlego/service/domain/rooms/httpauth"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignInMessage_ProducesExpectedMessageString(t *testing.T) {
+	sid := refs.MustNewIdentity("@Uv38ByGCZU8WP18PmmIdcpVmx00QA3xNe7sEB9Hixkk=.ed25519")
+	cid := refs.MustNewIdentity("@gYVa2GgdDYbR6R4AFnk5y2aU0sQirNIIoAcpOUh/aZk=.ed25519")
+
+	sc, err := httpauth.NewChallengeFromString("AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA=")
+	require.NoError(t, err)
+
+	cc, err := httpauth.NewChallengeFromString("AQEBAQEBAQEBAQEBAQEBAQEBAQEBAQEBAQEBAQEBAQE=")
+	require.NoError(t, err)
+
+	msg, err := httpauth.NewSignInMessage(sid, cid, sc, cc)
+	require.NoError(t, err)
+
+	require.Equal(t,
+		"=http-auth-sign-in:@Uv38ByGCZU8WP18PmmIdcpVmx00QA3xNe7sEB9Hixkk=.ed25519:@gYVa2GgdDYbR6R4AFnk5y2aU0sQirNIIoAcpOUh/aZk=.ed25519:AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA=:AQEBAQEBAQEBAQEBAQEBAQEBAQEBAQEBAQEBAQEBAQE=",
+		msg.String(),
+	)
+}
+
+func TestNewChallengeFromString_ChecksLength(t *testing.T) {
+	_, err := httpauth.NewChallengeFromString("AAAA")
+	require.EqualError(t, err, "invalid challenge length")
+
+	challenge := httpauth.MustNewChallenge()
+
+	challengeFromString, err := httpauth.NewChallengeFromString(challenge.String())
+	require.NoError(t, err)
+	require.Equal(t, challenge, challengeFromString)
+}
+
+func TestSolution_CanBeVerified(t *testing.T) {
+	client := fixtures.SomePrivateIdentity()
+
+	msg, err := httpauth.NewSignInMessage(
+		fixtures.SomeRefIdentity(),
+		refs.MustNewIdentityFromPublic(client.Public()),
+		httpauth.MustNewChallenge(),
+		httpauth.MustNewChallenge(),
+	)
+	require.NoError(t, err)
+
+	solution, err := httpauth.NewSolution(msg, client)
+	require.NoError(t, err)
+	require.True(t, solution.Verify(msg))
+
+	solutionFromString, err := httpauth.NewSolutionFromString(solution.String())
+	require.NoError(t, err)
+	require.True(t, solutionFromString.Verify(msg))
+
+	otherMsg, err := httpauth.NewSignInMessage(
+		fixtures.SomeRefIdentity(),
+		refs.MustNewIdentityFromPublic(client.Public()),
+		httpauth.MustNewChallenge(),
+		httpauth.MustNewChallenge(),
+	)
+	require.NoError(t, err)
+	require.False(t, solution.Verify(otherMsg))
+}
+
+func TestNewSolution_PrivateIdentityMustMatchClientIdentity(t *testing.T) {
+	msg, err := httpauth.NewSignInMessage(
+		fixtures.SomeRefIdentity(),
+		fixtures.SomeRefIdentity(),
+		httpauth.MustNewChallenge(),
+		httpauth.MustNewChallenge(),
+	)
+	require.NoError(t, err)
+
+	_, err = httpauth.NewSolution(msg, fixtures.SomePrivateIdentity())
+	require.EqualError(t, err, "private identity doesn't match client identity from the message")
+}
@@ -0,0 +1,58 @@
+package server
+
+import (
+	"fmt"
+)
+
+// PrivacyMode controls who can use a room. In the open mode anyone can become
+// an attendant and open tunnels. In the community and restricted modes only
+// members can do so. The restricted mode additionally disables features which
+// expose members to the outside world such as aliases.
+type PrivacyMode struct {
+	s string
+}
+
+var (
+	PrivacyModeOpen       = PrivacyMode{"open"}
+	PrivacyModeCommunity  = PrivacyMode{"community"}
+	PrivacyModeRestricted = PrivacyMode{"restricted"}
+)
+
+func NewPrivacyModeFromString(s string) (PrivacyMode, error) {
+	switch s {
+	case PrivacyModeOpen.s:
+		return PrivacyModeOpen, nil
+	case PrivacyModeCommunity.s:
+		return PrivacyModeCommunity, nil
+	case PrivacyModeRestricted.s:
+		return PrivacyModeRestricted, nil
+	default:
+		return PrivacyMode{}, fmt.Errorf("unknown privacy mode '%s'", s)
+	}
+}
+
+func MustNewPrivacyModeFromString(s string) PrivacyMode {
+	v, err := NewPrivacyModeFromString(s)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// RequiresMembership returns true if only members can use the room.
+func (m PrivacyMode) RequiresMembership() bool {
+	return m == PrivacyModeCommunity || m == PrivacyModeRestricted
+}
+
+// AllowsAliases returns true if members can register aliases.
+func (m PrivacyMode) AllowsAliases() bool {
+	return m == PrivacyModeOpen || m == PrivacyModeCommunity
+}
+
+func (m PrivacyMode) String() string {
+	return m.s
+}
+
+func (m PrivacyMode) IsZero() bool {
+	return m == PrivacyMode{}
+}
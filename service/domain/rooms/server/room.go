@@ -0,0 +1,483 @@
+// Package server implements the server side of rooms which lets this node act
+// as a room relaying connections between its attendants.
+package server
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/boreq/errors"
+	"github.com/hashicorp/go-multierror"
+	"github.com/planetary-social/scuttlego/logging"
+	"github.com/planetary-social/scuttlego/service/domain/identity"
+	"github.com/planetary-social/scuttlego/service/domain/messages"
+	"github.com/planetary-social/scuttlego/service/domain/refs"
+	"github.com/planetary-social/scuttlego/service/domain/rooms"
+	"github.com/planetary-social/scuttlego/service/domain/rooms/aliases"
+	"github.com/planetary-social/scuttlego/service/domain/rooms/features"
+	"github.com/planetary-social/scuttlego/service/domain/rooms/tunnel"
+	"github.com/planetary-social/scuttlego/service/domain/transport"
+)
+
+var (
+	ErrRoomDisabled        = errors.New("this node is not a room")
+	ErrAliasesNotSupported = errors.New("this room doesn't support aliases")
+	ErrNotAMember          = errors.New("only members can use this room")
+	ErrNotConnected        = errors.New("peer is not connected to the room")
+	ErrTargetNotPresent    = errors.New("target is not an attendant of this room")
+)
+
+type Config struct {
+	// PrivacyMode controls who can use the room. Zero value disables the
+	// room.
+	PrivacyMode PrivacyMode
+
+	// AliasDomain is the domain under which aliases are available e.g.
+	// aliases registered in a room with the domain set to "room.example.com"
+	// are resolved using "https://somealias.room.example.com". Aliases are not
+	// supported if this is not set.
+	AliasDomain string
+}
+
+// subscriptionBufferSize is the number of events which can be queued for a
+// subscriber. Subscribers which fall behind are disconnected so that they
+// don't block the room.
+const subscriptionBufferSize = 100
+
+// Room keeps track of peers connected to this node and of which of them
+// announced themselves as attendants. Attendants can be reached by other
+// clients using tunnel.connect which is relayed by the room.
+//
+// Membership is stored outside of the room therefore methods accept a flag
+// which indicates whether the calling peer is a member.
+type Room struct {
+	local       identity.Public
+	mode        PrivacyMode
+	aliasDomain string
+	logger      logging.Logger
+
+	lock                    sync.Mutex
+	peers                   map[string]transport.Peer
+	attendants              map[string]refs.Identity
+	attendantsSubscriptions subscriptions[rooms.RoomAttendantsEvent]
+	endpointsSubscriptions  subscriptions[[]refs.Identity]
+}
+
+// NewRoom creates a new room. Zero value of privacy mode disables the room in
+// which case all methods return ErrRoomDisabled.
+func NewRoom(local identity.Public, config Config, logger logging.Logger) *Room {
+	return &Room{
+		local:       local,
+		mode:        config.PrivacyMode,
+		aliasDomain: config.AliasDomain,
+		logger:      logger.New("room_server"),
+
+		peers:                   make(map[string]transport.Peer),
+		attendants:              make(map[string]refs.Identity),
+		attendantsSubscriptions: newSubscriptions[rooms.RoomAttendantsEvent](),
+		endpointsSubscriptions:  newSubscriptions[[]refs.Identity](),
+	}
+}
+
+// Run keeps track of the peer until the context is cancelled. It should be
+// called for every connected peer. If the room is disabled this function
+// returns immediately.
+func (r *Room) Run(ctx context.Context, peer transport.Peer) error {
+	if r.mode.IsZero() {
+		return nil
+	}
+
+	r.addPeer(peer)
+	defer r.removePeer(peer)
+
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func (r *Room) Metadata(member bool) (messages.RoomMetadataResponse, error) {
+	if r.mode.IsZero() {
+		return messages.RoomMetadataResponse{}, ErrRoomDisabled
+	}
+
+	ftrs, err := features.NewFeatures(r.features())
+	if err != nil {
+		return messages.RoomMetadataResponse{}, errors.Wrap(err, "error creating features")
+	}
+
+	return messages.NewRoomMetadataResponse(member, ftrs), nil
+}
+
+func (r *Room) features() []features.Feature {
+	ftrs := []features.Feature{features.FeatureTunnel, features.FeatureRoom2}
+
+	// Rooms 1.0 clients are not aware of membership.
+	if !r.mode.RequiresMembership() {
+		ftrs = append(ftrs, features.FeatureRoom1)
+	}
+
+	if r.checkAliasesSupported() == nil {
+		ftrs = append(ftrs, features.FeatureAlias)
+	}
+
+	return ftrs
+}
+
+// Announce makes the peer an attendant of the room.
+func (r *Room) Announce(remote identity.Public, member bool) error {
+	if err := r.checkAccess(member); err != nil {
+		return errors.Wrap(err, "access denied")
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	key := remote.String()
+
+	if _, ok := r.peers[key]; !ok {
+		return ErrNotConnected
+	}
+
+	if _, ok := r.attendants[key]; ok {
+		return nil
+	}
+
+	ref, err := refs.NewIdentityFromPublic(remote)
+	if err != nil {
+		return errors.Wrap(err, "error creating a ref")
+	}
+
+	r.attendants[key] = ref
+	r.publish(rooms.RoomAttendantsEventTypeJoined, ref)
+	return nil
+}
+
+// Leave removes the peer from the list of attendants. Leaving when not being
+// an attendant is not an error.
+func (r *Room) Leave(remote identity.Public) error {
+	if r.mode.IsZero() {
+		return ErrRoomDisabled
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.removeAttendant(remote.String())
+	return nil
+}
+
+// Attendants returns the current list of attendants and a channel which
+// receives events when attendants join or leave the room. The channel is
+// closed when the context is cancelled or when the caller doesn't receive
+// events quickly enough.
+func (r *Room) Attendants(ctx context.Context, member bool) ([]refs.Identity, <-chan rooms.RoomAttendantsEvent, error) {
+	if err := r.checkAccess(member); err != nil {
+		return nil, nil, errors.Wrap(err, "access denied")
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	ch := make(chan rooms.RoomAttendantsEvent, subscriptionBufferSize)
+	r.attendantsSubscriptions.Add(ch)
+	go r.unsubscribeWhenDone(ctx, func() { r.attendantsSubscriptions.Remove(ch) })
+
+	return r.attendantsList(), ch, nil
+}
+
+// Endpoints returns a channel which receives the list of attendants every
+// time that list changes. The current list is sent immediately. The channel
+// is closed when the context is cancelled or when the caller doesn't receive
+// values quickly enough.
+func (r *Room) Endpoints(ctx context.Context, member bool) (<-chan []refs.Identity, error) {
+	if err := r.checkAccess(member); err != nil {
+		return nil, errors.Wrap(err, "access denied")
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	ch := make(chan []refs.Identity, subscriptionBufferSize)
+	ch <- r.attendantsList()
+	r.endpointsSubscriptions.Add(ch)
+	go r.unsubscribeWhenDone(ctx, func() { r.endpointsSubscriptions.Remove(ch) })
+
+	return ch, nil
+}
+
+// Connect relays a tunnel.connect request from origin to target. Data read
+// from the provided stream is forwarded to the target and data received from
+// the target is written to the provided stream. This function blocks until
+// either side closes the tunnel or the context is cancelled.
+func (r *Room) Connect(ctx context.Context, origin identity.Public, member bool, target refs.Identity, stream io.ReadWriteCloser) error {
+	if err := r.checkAccess(member); err != nil {
+		return errors.Wrap(err, "access denied")
+	}
+
+	targetPeer, err := r.getAttendant(target)
+	if err != nil {
+		return errors.Wrap(err, "error getting the target")
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	portalRef, err := refs.NewIdentityFromPublic(r.local)
+	if err != nil {
+		return errors.Wrap(err, "error creating the portal ref")
+	}
+
+	originRef, err := refs.NewIdentityFromPublic(origin)
+	if err != nil {
+		return errors.Wrap(err, "error creating the origin ref")
+	}
+
+	args, err := messages.NewTunnelConnectToTargetArguments(portalRef, target, originRef)
+	if err != nil {
+		return errors.Wrap(err, "error creating arguments")
+	}
+
+	req, err := messages.NewTunnelConnectToTarget(args)
+	if err != nil {
+		return errors.Wrap(err, "error creating the request")
+	}
+
+	rs, err := targetPeer.Conn().PerformRequest(ctx, req)
+	if err != nil {
+		return errors.Wrap(err, "error performing the request")
+	}
+
+	targetStream := tunnel.NewResponseStreamReadWriteCloserAdapter(rs, cancel)
+
+	if err := pipe(stream, targetStream); err != nil {
+		r.logger.Debug().WithError(err).Message("tunnel closed")
+	}
+
+	return nil
+}
+
+// RegisterAlias validates an alias registration sent by a user. Only members
+// can register aliases. The returned URL can be used to resolve the alias.
+func (r *Room) RegisterAlias(member bool, user refs.Identity, alias aliases.Alias, signature aliases.RegistrationSignature) (aliases.Registration, aliases.AliasEndpointURL, error) {
+	if err := r.CanManageAliases(member); err != nil {
+		return aliases.Registration{}, aliases.AliasEndpointURL{}, errors.Wrap(err, "access denied")
+	}
+
+	room, err := refs.NewIdentityFromPublic(r.local)
+	if err != nil {
+		return aliases.Registration{}, aliases.AliasEndpointURL{}, errors.Wrap(err, "error creating the room ref")
+	}
+
+	msg, err := aliases.NewRegistrationMessage(alias, user, room)
+	if err != nil {
+		return aliases.Registration{}, aliases.AliasEndpointURL{}, errors.Wrap(err, "error creating the registration message")
+	}
+
+	registration, err := aliases.NewRegistration(msg, signature)
+	if err != nil {
+		return aliases.Registration{}, aliases.AliasEndpointURL{}, errors.Wrap(err, "error creating the registration")
+	}
+
+	url, err := r.AliasEndpointURL(alias)
+	if err != nil {
+		return aliases.Registration{}, aliases.AliasEndpointURL{}, errors.Wrap(err, "error creating the url")
+	}
+
+	return registration, url, nil
+}
+
+// CanManageAliases returns an error if the peer isn't allowed to register or
+// revoke aliases.
+func (r *Room) CanManageAliases(member bool) error {
+	if err := r.checkAliasesSupported(); err != nil {
+		return errors.Wrap(err, "aliases not supported")
+	}
+
+	if !member {
+		return ErrNotAMember
+	}
+
+	return nil
+}
+
+// CanUse returns an error if the peer isn't allowed to use the room.
+func (r *Room) CanUse(member bool) error {
+	return r.checkAccess(member)
+}
+
+// AliasEndpointURL returns the URL under which the alias can be resolved.
+func (r *Room) AliasEndpointURL(alias aliases.Alias) (aliases.AliasEndpointURL, error) {
+	if err := r.checkAliasesSupported(); err != nil {
+		return aliases.AliasEndpointURL{}, errors.Wrap(err, "aliases not supported")
+	}
+
+	return aliases.NewAliasEndpointURL(fmt.Sprintf("https://%s.%s", alias.String(), r.aliasDomain))
+}
+
+func (r *Room) checkAliasesSupported() error {
+	if r.mode.IsZero() {
+		return ErrRoomDisabled
+	}
+
+	if !r.mode.AllowsAliases() || r.aliasDomain == "" {
+		return ErrAliasesNotSupported
+	}
+
+	return nil
+}
+
+func (r *Room) checkAccess(member bool) error {
+	if r.mode.IsZero() {
+		return ErrRoomDisabled
+	}
+
+	if r.mode.RequiresMembership() && !member {
+		return ErrNotAMember
+	}
+
+	return nil
+}
+
+func (r *Room) getAttendant(target refs.Identity) (transport.Peer, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	key := target.Identity().String()
+
+	if _, ok := r.attendants[key]; !ok {
+		return transport.Peer{}, ErrTargetNotPresent
+	}
+
+	peer, ok := r.peers[key]
+	if !ok {
+		return transport.Peer{}, ErrTargetNotPresent
+	}
+
+	return peer, nil
+}
+
+func (r *Room) addPeer(peer transport.Peer) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.peers[peer.Identity().String()] = peer
+}
+
+func (r *Room) removePeer(peer transport.Peer) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	key := peer.Identity().String()
+
+	// a newer connection from the same identity may have replaced this one
+	if current, ok := r.peers[key]; !ok || current.Conn() != peer.Conn() {
+		return
+	}
+
+	delete(r.peers, key)
+	r.removeAttendant(key)
+}
+
+func (r *Room) removeAttendant(key string) {
+	ref, ok := r.attendants[key]
+	if !ok {
+		return
+	}
+
+	delete(r.attendants, key)
+	r.publish(rooms.RoomAttendantsEventTypeLeft, ref)
+}
+
+func (r *Room) publish(typ rooms.RoomAttendantsEventType, ref refs.Identity) {
+	event, err := rooms.NewRoomAttendantsEvent(typ, ref)
+	if err != nil {
+		r.logger.Error().WithError(err).Message("error creating an event")
+		return
+	}
+
+	r.attendantsSubscriptions.Publish(event)
+	r.endpointsSubscriptions.Publish(r.attendantsList())
+}
+
+func (r *Room) attendantsList() []refs.Identity {
+	result := make([]refs.Identity, 0, len(r.attendants))
+	for _, ref := range r.attendants {
+		result = append(result, ref)
+	}
+	return result
+}
+
+func (r *Room) unsubscribeWhenDone(ctx context.Context, unsubscribe func()) {
+	<-ctx.Done()
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	unsubscribe()
+}
+
+// subscriptions must be protected by the room lock.
+type subscriptions[T any] struct {
+	chs map[chan T]struct{}
+}
+
+func newSubscriptions[T any]() subscriptions[T] {
+	return subscriptions[T]{chs: make(map[chan T]struct{})}
+}
+
+func (s subscriptions[T]) Add(ch chan T) {
+	s.chs[ch] = struct{}{}
+}
+
+// Remove closes the channel if it wasn't already removed.
+func (s subscriptions[T]) Remove(ch chan T) {
+	if _, ok := s.chs[ch]; ok {
+		delete(s.chs, ch)
+		close(ch)
+	}
+}
+
+// Publish sends the value to all subscribers removing those whose buffers are
+// full.
+func (s subscriptions[T]) Publish(v T) {
+	for ch := range s.chs {
+		select {
+		case ch <- v:
+		default:
+			s.Remove(ch)
+		}
+	}
+}
+
+// pipe copies data in both directions until one of the directions fails and
+// then closes both streams.
+func pipe(a, b io.ReadWriteCloser) error {
+	errCh := make(chan error, 2)
+
+	go func() {
+		_, err := io.Copy(a, b)
+		errCh <- err
+	}()
+
+	go func() {
+		_, err := io.Copy(b, a)
+		errCh <- err
+	}()
+
+	var result error
+
+	if err := <-errCh; err != nil {
+		result = multierror.Append(result, errors.Wrap(err, "copying failed"))
+	}
+
+	if err := a.Close(); err != nil {
+		result = multierror.Append(result, errors.Wrap(err, "error closing the first stream"))
+	}
+
+	if err := b.Close(); err != nil {
+		result = multierror.Append(result, errors.Wrap(err, "error closing the second stream"))
+	}
+
+	return result
+}
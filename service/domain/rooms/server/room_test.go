@@ -0,0 +1,432 @@
+package server_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/planetary-social/scuttlego/internal/fixtures"
+	"github.com/planetary-social/scuttlego/internal/mocks"
+	"github.com/planetary-social/scuttlego/service/domain/identity"
+	"github.com/planetary-social/scuttlego/service/domain/messages"
+	"github.com/planetary-social/scuttlego/service/domain/refs"
+	"github.com/planetary-social/scuttlego/service/domain/rooms"
+	"github.com/planetary-social/scuttlego/service/domain/rooms/aliases"
+	"github.com/planetary-social/scuttlego/service/domain/rooms/features"
+	"github.com/planetary-social/scuttlego/service/domain/rooms/server"
+	"github.com/planetary-social/scuttlego/service/domain/transport"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoom_DisabledRoomReturnsErrors(t *testing.T) {
+	ctx := fixtures.TestContext(t)
+	room := server.NewRoom(fixtures.SomePublicIdentity(), server.Config{}, fixtures.TestLogger(t))
+
+	peer := transport.MustNewPeer(fixtures.SomePublicIdentity(), mocks.NewConnectionMock(ctx))
+
+	err := room.Run(ctx, peer)
+	require.NoError(t, err)
+
+	_, err = room.Metadata(true)
+	require.ErrorIs(t, err, server.ErrRoomDisabled)
+
+	err = room.Announce(peer.Identity(), true)
+	require.ErrorIs(t, err, server.ErrRoomDisabled)
+
+	_, _, err = room.Attendants(ctx, true)
+	require.ErrorIs(t, err, server.ErrRoomDisabled)
+}
+
+func TestRoom_MembershipIsRequiredDependingOnPrivacyMode(t *testing.T) {
+	testCases := []struct {
+		Mode                server.PrivacyMode
+		NonMembersCanAttend bool
+	}{
+		{
+			Mode:                server.PrivacyModeOpen,
+			NonMembersCanAttend: true,
+		},
+		{
+			Mode:                server.PrivacyModeCommunity,
+			NonMembersCanAttend: false,
+		},
+		{
+			Mode:                server.PrivacyModeRestricted,
+			NonMembersCanAttend: false,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Mode.String(), func(t *testing.T) {
+			ctx := fixtures.TestContext(t)
+			room := server.NewRoom(fixtures.SomePublicIdentity(), server.Config{PrivacyMode: testCase.Mode}, fixtures.TestLogger(t))
+
+			peer := runPeer(t, ctx, room)
+
+			metadata, err := room.Metadata(false)
+			require.NoError(t, err)
+			require.False(t, metadata.Membership())
+			require.True(t, metadata.Features().Contains(features.FeatureTunnel))
+
+			err = room.Announce(peer.Identity(), false)
+			if testCase.NonMembersCanAttend {
+				require.NoError(t, err)
+			} else {
+				require.ErrorIs(t, err, server.ErrNotAMember)
+			}
+
+			err = room.Announce(peer.Identity(), true)
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestRoom_MetadataAdvertisesFeaturesDependingOnConfig(t *testing.T) {
+	testCases := []struct {
+		Name             string
+		Config           server.Config
+		ExpectedFeatures []features.Feature
+	}{
+		{
+			Name: "open",
+			Config: server.Config{
+				PrivacyMode: server.PrivacyModeOpen,
+			},
+			ExpectedFeatures: []features.Feature{
+				features.FeatureTunnel,
+				features.FeatureRoom1,
+				features.FeatureRoom2,
+			},
+		},
+		{
+			Name: "open_with_alias_domain",
+			Config: server.Config{
+				PrivacyMode: server.PrivacyModeOpen,
+				AliasDomain: "room.example.com",
+			},
+			ExpectedFeatures: []features.Feature{
+				features.FeatureTunnel,
+				features.FeatureRoom1,
+				features.FeatureRoom2,
+				features.FeatureAlias,
+			},
+		},
+		{
+			Name: "community_with_alias_domain",
+			Config: server.Config{
+				PrivacyMode: server.PrivacyModeCommunity,
+				AliasDomain: "room.example.com",
+			},
+			ExpectedFeatures: []features.Feature{
+				features.FeatureTunnel,
+				features.FeatureRoom2,
+				features.FeatureAlias,
+			},
+		},
+		{
+			Name: "restricted_with_alias_domain",
+			Config: server.Config{
+				PrivacyMode: server.PrivacyModeRestricted,
+				AliasDomain: "room.example.com",
+			},
+			ExpectedFeatures: []features.Feature{
+				features.FeatureTunnel,
+				features.FeatureRoom2,
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			room := server.NewRoom(fixtures.SomePublicIdentity(), testCase.Config, fixtures.TestLogger(t))
+
+			metadata, err := room.Metadata(true)
+			require.NoError(t, err)
+			require.Equal(t, features.MustNewFeatures(testCase.ExpectedFeatures), metadata.Features())
+		})
+	}
+}
+
+func TestRoom_AnnounceRequiresTheClientToBeConnected(t *testing.T) {
+	room := server.NewRoom(fixtures.SomePublicIdentity(), server.Config{PrivacyMode: server.PrivacyModeOpen}, fixtures.TestLogger(t))
+
+	err := room.Announce(fixtures.SomePublicIdentity(), true)
+	require.ErrorIs(t, err, server.ErrNotConnected)
+}
+
+func TestRoom_AttendantsReceiveEventsWhenPeersJoinAndLeave(t *testing.T) {
+	ctx := fixtures.TestContext(t)
+	room := server.NewRoom(fixtures.SomePublicIdentity(), server.Config{PrivacyMode: server.PrivacyModeOpen}, fixtures.TestLogger(t))
+
+	peer1 := runPeer(t, ctx, room)
+	err := room.Announce(peer1.Identity(), true)
+	require.NoError(t, err)
+
+	peer2Ctx, peer2Cancel := context.WithCancel(ctx)
+	peer2 := runPeer(t, peer2Ctx, room)
+
+	state, events, err := room.Attendants(ctx, true)
+	require.NoError(t, err)
+	require.Equal(t, []refs.Identity{refs.MustNewIdentityFromPublic(peer1.Identity())}, state)
+
+	endpoints, err := room.Endpoints(ctx, true)
+	require.NoError(t, err)
+	require.Equal(t, []refs.Identity{refs.MustNewIdentityFromPublic(peer1.Identity())}, <-endpoints)
+
+	err = room.Announce(peer2.Identity(), true)
+	require.NoError(t, err)
+
+	require.Equal(t,
+		rooms.MustNewRoomAttendantsEvent(rooms.RoomAttendantsEventTypeJoined, refs.MustNewIdentityFromPublic(peer2.Identity())),
+		<-events,
+	)
+	require.Len(t, <-endpoints, 2)
+
+	err = room.Leave(peer1.Identity())
+	require.NoError(t, err)
+
+	require.Equal(t,
+		rooms.MustNewRoomAttendantsEvent(rooms.RoomAttendantsEventTypeLeft, refs.MustNewIdentityFromPublic(peer1.Identity())),
+		<-events,
+	)
+	require.Equal(t, []refs.Identity{refs.MustNewIdentityFromPublic(peer2.Identity())}, <-endpoints)
+
+	peer2Cancel()
+
+	require.Equal(t,
+		rooms.MustNewRoomAttendantsEvent(rooms.RoomAttendantsEventTypeLeft, refs.MustNewIdentityFromPublic(peer2.Identity())),
+		<-events,
+	)
+	require.Empty(t, <-endpoints)
+}
+
+func TestRoom_SubscriptionsAreClosedWhenContextIsCancelled(t *testing.T) {
+	ctx := fixtures.TestContext(t)
+	room := server.NewRoom(fixtures.SomePublicIdentity(), server.Config{PrivacyMode: server.PrivacyModeOpen}, fixtures.TestLogger(t))
+
+	subscriptionCtx, subscriptionCancel := context.WithCancel(ctx)
+
+	_, events, err := room.Attendants(subscriptionCtx, true)
+	require.NoError(t, err)
+
+	subscriptionCancel()
+
+	select {
+	case _, ok := <-events:
+		require.False(t, ok)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timeout")
+	}
+}
+
+func TestRoom_ConnectRelaysDataBetweenOriginAndTarget(t *testing.T) {
+	ctx := fixtures.TestContext(t)
+	local := fixtures.SomePublicIdentity()
+	room := server.NewRoom(local, server.Config{PrivacyMode: server.PrivacyModeOpen}, fixtures.TestLogger(t))
+
+	origin := fixtures.SomePublicIdentity()
+	target := fixtures.SomePublicIdentity()
+	dataFromTarget := fixtures.SomeBytes()
+
+	var (
+		requests     []*rpc.Request
+		requestsLock sync.Mutex
+	)
+
+	targetConn := mocks.NewConnectionMock(ctx)
+	targetConn.Mock(func(req *rpc.Request) []rpc.ResponseWithError {
+		requestsLock.Lock()
+		defer requestsLock.Unlock()
+		requests = append(requests, req)
+
+		return []rpc.ResponseWithError{
+			{
+				Value: rpc.NewResponse(dataFromTarget),
+			},
+		}
+	})
+
+	targetPeer := transport.MustNewPeer(target, targetConn)
+	go func() {
+		_ = room.Run(ctx, targetPeer)
+	}()
+
+	require.Eventually(t, func() bool {
+		return room.Announce(target, true) == nil
+	}, 5*time.Second, 10*time.Millisecond)
+
+	originStream := newReadWriteCloserMock()
+
+	err := room.Connect(ctx, origin, true, refs.MustNewIdentityFromPublic(target), originStream)
+	require.NoError(t, err)
+
+	require.Equal(t, dataFromTarget, originStream.Written())
+
+	requestsLock.Lock()
+	defer requestsLock.Unlock()
+
+	require.Len(t, requests, 1)
+	require.Equal(t, messages.TunnelConnectProcedure.Name(), requests[0].Name())
+
+	args, err := messages.NewTunnelConnectToTargetArgumentsFromBytes(requests[0].Arguments())
+	require.NoError(t, err)
+	require.Equal(t, refs.MustNewIdentityFromPublic(local), args.Portal())
+	require.Equal(t, refs.MustNewIdentityFromPublic(target), args.Target())
+	require.Equal(t, refs.MustNewIdentityFromPublic(origin), args.Origin())
+}
+
+func TestRoom_ConnectReturnsAnErrorIfTargetIsNotAnAttendant(t *testing.T) {
+	ctx := fixtures.TestContext(t)
+	room := server.NewRoom(fixtures.SomePublicIdentity(), server.Config{PrivacyMode: server.PrivacyModeOpen}, fixtures.TestLogger(t))
+
+	target := runPeer(t, ctx, room)
+
+	err := room.Connect(ctx, fixtures.SomePublicIdentity(), true, refs.MustNewIdentityFromPublic(target.Identity()), newReadWriteCloserMock())
+	require.ErrorIs(t, err, server.ErrTargetNotPresent)
+}
+
+func TestRoom_RegisterAlias(t *testing.T) {
+	local := fixtures.SomePublicIdentity()
+	userIdentity := fixtures.SomePrivateIdentity()
+	user := refs.MustNewIdentityFromPublic(userIdentity.Public())
+	alias := aliases.MustNewAlias("somealias")
+
+	signature := someRegistrationSignature(t, userIdentity, alias, refs.MustNewIdentityFromPublic(local))
+
+	testCases := []struct {
+		Name          string
+		Config        server.Config
+		Member        bool
+		Signature     aliases.RegistrationSignature
+		ExpectedError error
+	}{
+		{
+			Name:      "valid",
+			Config:    server.Config{PrivacyMode: server.PrivacyModeOpen, AliasDomain: "room.example.com"},
+			Member:    true,
+			Signature: signature,
+		},
+		{
+			Name:          "not_a_member",
+			Config:        server.Config{PrivacyMode: server.PrivacyModeOpen, AliasDomain: "room.example.com"},
+			Member:        false,
+			Signature:     signature,
+			ExpectedError: server.ErrNotAMember,
+		},
+		{
+			Name:          "no_domain",
+			Config:        server.Config{PrivacyMode: server.PrivacyModeCommunity},
+			Member:        true,
+			Signature:     signature,
+			ExpectedError: server.ErrAliasesNotSupported,
+		},
+		{
+			Name:          "restricted",
+			Config:        server.Config{PrivacyMode: server.PrivacyModeRestricted, AliasDomain: "room.example.com"},
+			Member:        true,
+			Signature:     signature,
+			ExpectedError: server.ErrAliasesNotSupported,
+		},
+		{
+			Name:          "disabled",
+			Config:        server.Config{AliasDomain: "room.example.com"},
+			Member:        true,
+			Signature:     signature,
+			ExpectedError: server.ErrRoomDisabled,
+		},
+		{
+			Name:          "signature_for_a_different_room",
+			Config:        server.Config{PrivacyMode: server.PrivacyModeOpen, AliasDomain: "room.example.com"},
+			Member:        true,
+			Signature:     someRegistrationSignature(t, userIdentity, alias, fixtures.SomeRefIdentity()),
+			ExpectedError: aliases.ErrInvalidSignature,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			room := server.NewRoom(local, testCase.Config, fixtures.TestLogger(t))
+
+			registration, url, err := room.RegisterAlias(testCase.Member, user, alias, testCase.Signature)
+			if testCase.ExpectedError != nil {
+				require.ErrorIs(t, err, testCase.ExpectedError)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, alias, registration.Alias())
+			require.Equal(t, user, registration.User())
+			require.Equal(t, "https://somealias.room.example.com", url.String())
+		})
+	}
+}
+
+func someRegistrationSignature(t *testing.T, user identity.Private, alias aliases.Alias, room refs.Identity) aliases.RegistrationSignature {
+	msg, err := aliases.NewRegistrationMessage(alias, refs.MustNewIdentityFromPublic(user.Public()), room)
+	require.NoError(t, err)
+
+	signature, err := aliases.NewRegistrationSignature(msg, user)
+	require.NoError(t, err)
+
+	return signature
+}
+
+func runPeer(t *testing.T, ctx context.Context, room *server.Room) transport.Peer {
+	peer := transport.MustNewPeer(fixtures.SomePublicIdentity(), mocks.NewConnectionMock(ctx))
+
+	go func() {
+		_ = room.Run(ctx, peer)
+	}()
+
+	// there is no way to wait for the peer to be registered other than trying
+	// to use it
+	require.Eventually(t, func() bool {
+		err := room.Announce(peer.Identity(), true)
+		if err != nil {
+			return false
+		}
+		return room.Leave(peer.Identity()) == nil
+	}, 5*time.Second, 10*time.Millisecond)
+
+	return peer
+}
+
+type readWriteCloserMock struct {
+	closed    chan struct{}
+	closeOnce sync.Once
+
+	lock    sync.Mutex
+	written bytes.Buffer
+}
+
+func newReadWriteCloserMock() *readWriteCloserMock {
+	return &readWriteCloserMock{
+		closed: make(chan struct{}),
+	}
+}
+
+func (r *readWriteCloserMock) Read(p []byte) (int, error) {
+	<-r.closed
+	return 0, io.EOF
+}
+
+func (r *readWriteCloserMock) Write(p []byte) (int, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return r.written.Write(p)
+}
+
+func (r *readWriteCloserMock) Close() error {
+	r.closeOnce.Do(func() { close(r.closed) })
+	return nil
+}
+
+func (r *readWriteCloserMock) Written() []byte {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return r.written.Bytes()
+}
@@ -3,6 +3,9 @@ package tunnel
 import (
 	"bytes"
 	"context"
+	"os"
+	"sync"
+	"time"
 
 	"github.com/boreq/errors"
 	"github.com/planetary-social/scuttlego/service/domain/transport/rpc"
@@ -10,57 +13,82 @@ import (
 	"github.com/planetary-social/scuttlego/service/domain/transport/rpc/transport"
 )
 
+// ResponseStreamReadWriteCloserAdapter implements boxstream.SetDeadliner so
+// that handshakes performed over tunnelled streams time out. Reads and writes
+// which exceed the deadline return os.ErrDeadlineExceeded.
 type ResponseStreamReadWriteCloserAdapter struct {
-	cancel context.CancelFunc
-	stream rpc.ResponseStream
-	buf    *bytes.Buffer
+	cancel   context.CancelFunc
+	stream   rpc.ResponseStream
+	buf      *bytes.Buffer
+	deadline *deadline
 }
 
 func NewResponseStreamReadWriteCloserAdapter(stream rpc.ResponseStream, cancel context.CancelFunc) *ResponseStreamReadWriteCloserAdapter {
 	return &ResponseStreamReadWriteCloserAdapter{
-		stream: stream,
-		cancel: cancel,
-		buf:    &bytes.Buffer{},
+		stream:   stream,
+		cancel:   cancel,
+		buf:      &bytes.Buffer{},
+		deadline: newDeadline(),
 	}
 }
 
 func (s ResponseStreamReadWriteCloserAdapter) Read(p []byte) (int, error) {
 	if s.buf.Len() == 0 {
-		resp, ok := <-s.stream.Channel()
-		if !ok {
-			return 0, errors.New("channel closed")
+		timeout, stop := s.deadline.Channel()
+		defer stop()
+
+		select {
+		case resp, ok := <-s.stream.Channel():
+			if !ok {
+				return 0, errors.New("channel closed")
+			}
+
+			if err := resp.Err; err != nil {
+				return 0, errors.Wrap(err, "stream returned an error")
+			}
+
+			s.buf.Write(resp.Value.Bytes())
+		case <-timeout:
+			return 0, os.ErrDeadlineExceeded
 		}
-
-		if err := resp.Err; err != nil {
-			return 0, errors.Wrap(err, "stream returned an error")
-		}
-
-		s.buf.Write(resp.Value.Bytes())
 	}
 
 	return s.buf.Read(p)
 }
 
 func (s ResponseStreamReadWriteCloserAdapter) Write(p []byte) (n int, err error) {
+	if s.deadline.Exceeded() {
+		return 0, os.ErrDeadlineExceeded
+	}
 	return len(p), s.stream.WriteMessage(p, transport.MessageBodyTypeBinary)
 }
 
+func (s ResponseStreamReadWriteCloserAdapter) SetDeadline(t time.Time) error {
+	s.deadline.Set(t)
+	return nil
+}
+
 func (s ResponseStreamReadWriteCloserAdapter) Close() error {
 	s.cancel()
 	return nil
 }
 
+// StreamReadWriteCloserAdapter implements boxstream.SetDeadliner so that
+// handshakes performed over tunnelled streams time out. Reads and writes which
+// exceed the deadline return os.ErrDeadlineExceeded.
 type StreamReadWriteCloserAdapter struct {
-	stream mux.Stream
-	cancel context.CancelFunc
-	buf    *bytes.Buffer
+	stream   mux.Stream
+	cancel   context.CancelFunc
+	buf      *bytes.Buffer
+	deadline *deadline
 }
 
 func NewStreamReadWriteCloserAdapter(stream mux.Stream, cancel context.CancelFunc) *StreamReadWriteCloserAdapter {
 	return &StreamReadWriteCloserAdapter{
-		stream: stream,
-		cancel: cancel,
-		buf:    &bytes.Buffer{},
+		stream:   stream,
+		cancel:   cancel,
+		buf:      &bytes.Buffer{},
+		deadline: newDeadline(),
 	}
 }
 
@@ -71,22 +99,77 @@ func (s StreamReadWriteCloserAdapter) Read(p []byte) (n int, err error) {
 			return 0, errors.Wrap(err, "failed to get incoming message channel")
 		}
 
-		resp, ok := <-ch
-		if !ok {
-			return 0, errors.New("channel closed")
-		}
+		timeout, stop := s.deadline.Channel()
+		defer stop()
+
+		select {
+		case resp, ok := <-ch:
+			if !ok {
+				return 0, errors.New("channel closed")
+			}
 
-		s.buf.Write(resp.Body)
+			s.buf.Write(resp.Body)
+		case <-timeout:
+			return 0, os.ErrDeadlineExceeded
+		}
 	}
 
 	return s.buf.Read(p)
 }
 
 func (s StreamReadWriteCloserAdapter) Write(p []byte) (n int, err error) {
+	if s.deadline.Exceeded() {
+		return 0, os.ErrDeadlineExceeded
+	}
 	return len(p), s.stream.WriteMessage(p, transport.MessageBodyTypeBinary)
 }
 
+func (s StreamReadWriteCloserAdapter) SetDeadline(t time.Time) error {
+	s.deadline.Set(t)
+	return nil
+}
+
 func (s StreamReadWriteCloserAdapter) Close() error {
 	s.cancel()
 	return nil
 }
+
+// deadline stores a deadline set using SetDeadline. Zero value of time means
+// that there is no deadline. Changing the deadline doesn't affect reads which
+// are already blocked.
+type deadline struct {
+	t    time.Time
+	lock sync.Mutex
+}
+
+func newDeadline() *deadline {
+	return &deadline{}
+}
+
+func (d *deadline) Set(t time.Time) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	d.t = t
+}
+
+func (d *deadline) Exceeded() bool {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	return !d.t.IsZero() && !time.Now().Before(d.t)
+}
+
+// Channel returns a channel which receives a value once the deadline is
+// exceeded. If there is no deadline then the returned channel is nil and
+// therefore blocks forever. The returned function must be called to release
+// resources.
+func (d *deadline) Channel() (<-chan time.Time, func()) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	if d.t.IsZero() {
+		return nil, func() {}
+	}
+
+	timer := time.NewTimer(time.Until(d.t))
+	return timer.C, func() { timer.Stop() }
+}
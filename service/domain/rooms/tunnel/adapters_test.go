@@ -3,6 +3,7 @@ package tunnel_test
 import (
 	"bytes"
 	"context"
+	"os"
 	"testing"
 	"time"
 
@@ -283,6 +284,52 @@ func TestStreamReadWriterCloserAdapter_WriteCallsWriteMessage(t *testing.T) {
 	)
 }
 
+func TestResponseStreamReadWriteCloserAdapter_ReadAndWriteReturnErrorsIfDeadlineIsExceeded(t *testing.T) {
+	ctx := fixtures.TestContext(t)
+	cancel := newCancelFuncMock()
+	stream := newResponseStreamMock(ctx, nil)
+	adapter := tunnel.NewResponseStreamReadWriteCloserAdapter(stream, cancel.Cancel)
+
+	err := adapter.SetDeadline(time.Now().Add(100 * time.Millisecond))
+	require.NoError(t, err)
+
+	_, err = adapter.Read(nil)
+	require.ErrorIs(t, err, os.ErrDeadlineExceeded)
+
+	_, err = adapter.Write(fixtures.SomeBytes())
+	require.ErrorIs(t, err, os.ErrDeadlineExceeded)
+	require.Empty(t, stream.WriteMessageCalls)
+
+	err = adapter.SetDeadline(time.Time{})
+	require.NoError(t, err)
+
+	_, err = adapter.Write(fixtures.SomeBytes())
+	require.NoError(t, err)
+}
+
+func TestStreamReadWriterCloserAdapter_ReadAndWriteReturnErrorsIfDeadlineIsExceeded(t *testing.T) {
+	ctx := fixtures.TestContext(t)
+	cancel := newCancelFuncMock()
+	stream := newStreamMock(ctx, nil)
+	adapter := tunnel.NewStreamReadWriteCloserAdapter(stream, cancel.Cancel)
+
+	err := adapter.SetDeadline(time.Now().Add(100 * time.Millisecond))
+	require.NoError(t, err)
+
+	_, err = adapter.Read(nil)
+	require.ErrorIs(t, err, os.ErrDeadlineExceeded)
+
+	_, err = adapter.Write(fixtures.SomeBytes())
+	require.ErrorIs(t, err, os.ErrDeadlineExceeded)
+	require.Empty(t, stream.WriteMessageCalls)
+
+	err = adapter.SetDeadline(time.Time{})
+	require.NoError(t, err)
+
+	_, err = adapter.Write(fixtures.SomeBytes())
+	require.NoError(t, err)
+}
+
 type cancelFuncMock struct {
 	Called bool
 }
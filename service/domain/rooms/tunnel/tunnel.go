@@ -3,12 +3,14 @@ package tunnel
 import (
 	"context"
 	"io"
+	"time"
 
 	"github.com/boreq/errors"
 	"github.com/planetary-social/scuttlego/service/domain/identity"
 	"github.com/planetary-social/scuttlego/service/domain/messages"
 	"github.com/planetary-social/scuttlego/service/domain/refs"
 	"github.com/planetary-social/scuttlego/service/domain/transport"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc"
 )
 
 type ClientPeerInitializer interface {
@@ -18,16 +20,25 @@ type ClientPeerInitializer interface {
 	InitializeClientPeer(ctx context.Context, rwc io.ReadWriteCloser, remote identity.Public) (transport.Peer, error)
 }
 
+// ErrDialTimeout is returned if establishing a tunnelled connection takes
+// longer than the timeout passed to NewDialer.
+var ErrDialTimeout = errors.New("dialing via room timed out")
+
 type Dialer struct {
 	initializer ClientPeerInitializer
+	timeout     time.Duration
 }
 
-func NewDialer(initializer ClientPeerInitializer) *Dialer {
-	return &Dialer{initializer: initializer}
+// NewDialer creates a new dialer. Timeout limits how long opening the tunnel
+// and initializing the peer can take. Zero value of timeout disables it.
+func NewDialer(initializer ClientPeerInitializer, timeout time.Duration) *Dialer {
+	return &Dialer{
+		initializer: initializer,
+		timeout:     timeout,
+	}
 }
 
 func (d *Dialer) DialViaRoom(ctx context.Context, portal transport.Peer, target identity.Public) (transport.Peer, error) {
-	// todo timeout?
 	portalRef, err := refs.NewIdentityFromPublic(portal.Identity())
 	if err != nil {
 		return transport.Peer{}, errors.Wrap(err, "error creating portal identity ref")
@@ -48,19 +59,50 @@ func (d *Dialer) DialViaRoom(ctx context.Context, portal transport.Peer, target
 		return transport.Peer{}, errors.Wrap(err, "error creating a request")
 	}
 
+	// The context outlives this function as it is used by the established
+	// connection therefore the timeout can't be implemented using
+	// context.WithTimeout.
 	ctx, cancel := context.WithCancel(ctx)
 
-	stream, err := portal.Conn().PerformRequest(ctx, request)
+	stopTimeout := d.startTimeout(cancel)
+
+	peer, err := d.dial(ctx, cancel, portal, request, target)
+	if timedOut := stopTimeout(); timedOut {
+		cancel()
+		return transport.Peer{}, ErrDialTimeout
+	}
+
 	if err != nil {
 		cancel()
+		return transport.Peer{}, errors.Wrap(err, "dial failed")
+	}
+
+	return peer, nil
+}
+
+// startTimeout calls cancel once the timeout is exceeded. The returned function
+// stops the timer and reports whether the timeout was exceeded.
+func (d *Dialer) startTimeout(cancel context.CancelFunc) func() bool {
+	if d.timeout <= 0 {
+		return func() bool { return false }
+	}
+
+	timer := time.AfterFunc(d.timeout, cancel)
+	return func() bool {
+		return !timer.Stop()
+	}
+}
+
+func (d *Dialer) dial(ctx context.Context, cancel context.CancelFunc, portal transport.Peer, request *rpc.Request, target identity.Public) (transport.Peer, error) {
+	stream, err := portal.Conn().PerformRequest(ctx, request)
+	if err != nil {
 		return transport.Peer{}, errors.Wrap(err, "error performing the request")
 	}
 
 	rwc := NewResponseStreamReadWriteCloserAdapter(stream, cancel)
 	peer, err := d.initializer.InitializeClientPeer(ctx, rwc, target)
 	if err != nil {
-		cancel()
-		return transport.Peer{}, errors.Wrap(err, "error performing the request")
+		return transport.Peer{}, errors.Wrap(err, "error initializing the peer")
 	}
 
 	return peer, nil
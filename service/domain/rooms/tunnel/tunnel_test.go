@@ -20,7 +20,7 @@ import (
 
 func TestDialer_DialViaRoomPerformsCorrectRequestsAndCallsInitializerWithCorrectArguments(t *testing.T) {
 	clientPeerInitializer := newClientPeerInitializerMock()
-	dialer := tunnel.NewDialer(clientPeerInitializer)
+	dialer := tunnel.NewDialer(clientPeerInitializer, 0)
 
 	ctx := fixtures.TestContext(t)
 	portalConn := mocks.NewConnectionMock(ctx)
@@ -68,6 +68,20 @@ func TestDialer_DialViaRoomPerformsCorrectRequestsAndCallsInitializerWithCorrect
 		}, 1*time.Second, 10*time.Millisecond)
 }
 
+func TestDialer_DialViaRoomReturnsAnErrorIfDialingTakesTooLong(t *testing.T) {
+	dialer := tunnel.NewDialer(newBlockingClientPeerInitializerMock(), 100*time.Millisecond)
+
+	ctx := fixtures.TestContext(t)
+	portalConn := mocks.NewConnectionMock(ctx)
+	portalConn.Mock(func(req *rpc.Request) []rpc.ResponseWithError {
+		return nil
+	})
+	portalPeer := transport.MustNewPeer(fixtures.SomePublicIdentity(), portalConn)
+
+	_, err := dialer.DialViaRoom(ctx, portalPeer, fixtures.SomePublicIdentity())
+	require.ErrorIs(t, err, tunnel.ErrDialTimeout)
+}
+
 type clientPeerInitializerMock struct {
 	calls []clientPeerInitializerCall
 }
@@ -95,3 +109,15 @@ type clientPeerInitializerCall struct {
 	Remote          identity.Public
 	ReceivedMessage []byte
 }
+
+type blockingClientPeerInitializerMock struct {
+}
+
+func newBlockingClientPeerInitializerMock() *blockingClientPeerInitializerMock {
+	return &blockingClientPeerInitializerMock{}
+}
+
+func (c *blockingClientPeerInitializerMock) InitializeClientPeer(ctx context.Context, rwc io.ReadWriteCloser, remote identity.Public) (transport.Peer, error) {
+	<-ctx.Done()
+	return transport.Peer{}, ctx.Err()
+}
@@ -54,10 +54,8 @@ func NewHandshaker(
 // remote peer and the provided ReadWriteCloser. This should be used when
 // initiating a connection with a remote peer.
 func (h Handshaker) OpenClientStream(rw io.ReadWriteCloser, remote identity.Public) (*Stream, error) {
-	if v, ok := rw.(SetDeadliner); ok {
-		if err := v.SetDeadline(h.currentTimeProvider.Get().Add(handshakeTimeout)); err != nil {
-			return nil, errors.Wrap(err, "failed to set a deadline")
-		}
+	if err := h.setDeadline(rw); err != nil {
+		return nil, errors.Wrap(err, "failed to set a deadline")
 	}
 
 	if remote.IsZero() {
@@ -74,10 +72,8 @@ func (h Handshaker) OpenClientStream(rw io.ReadWriteCloser, remote identity.Publ
 		return nil, errors.Wrap(err, "could not perform the client handshake")
 	}
 
-	if v, ok := rw.(SetDeadliner); ok {
-		if err := v.SetDeadline(time.Time{}); err != nil {
-			return nil, errors.Wrap(err, "failed to reset a deadline")
-		}
+	if err := h.resetDeadline(rw); err != nil {
+		return nil, errors.Wrap(err, "failed to reset a deadline")
 	}
 
 	return h.createStream(rw, state)
@@ -87,6 +83,10 @@ func (h Handshaker) OpenClientStream(rw io.ReadWriteCloser, remote identity.Publ
 // This should be used when handling incoming connections which were initiated
 // by the other party.
 func (h Handshaker) OpenServerStream(rw io.ReadWriteCloser) (*Stream, error) {
+	if err := h.setDeadline(rw); err != nil {
+		return nil, errors.Wrap(err, "failed to set a deadline")
+	}
+
 	state, err := secrethandshake.NewServerState(h.networkKey.Bytes(), h.localKeypair())
 	if err != nil {
 		return nil, errors.Wrap(err, "could not create client state")
@@ -97,9 +97,29 @@ func (h Handshaker) OpenServerStream(rw io.ReadWriteCloser) (*Stream, error) {
 		return nil, errors.Wrap(err, "could not perform the client handshake")
 	}
 
+	if err := h.resetDeadline(rw); err != nil {
+		return nil, errors.Wrap(err, "failed to reset a deadline")
+	}
+
 	return h.createStream(rw, state)
 }
 
+// setDeadline limits the time the handshake can take if the provided
+// ReadWriteCloser supports deadlines.
+func (h Handshaker) setDeadline(rw io.ReadWriteCloser) error {
+	if v, ok := rw.(SetDeadliner); ok {
+		return v.SetDeadline(h.currentTimeProvider.Get().Add(handshakeTimeout))
+	}
+	return nil
+}
+
+func (h Handshaker) resetDeadline(rw io.ReadWriteCloser) error {
+	if v, ok := rw.(SetDeadliner); ok {
+		return v.SetDeadline(time.Time{})
+	}
+	return nil
+}
+
 func (h Handshaker) createStream(rw io.ReadWriteCloser, state *secrethandshake.State) (*Stream, error) {
 	result, err := h.toResult(state)
 	if err != nil {
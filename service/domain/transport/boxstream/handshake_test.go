@@ -48,15 +48,13 @@ func TestHandshaker_HandshakerSetsDeadlinesIfConnectionImplementsSetDeadliner(t
 
 	runTest(t, networkKey, currentTimeProvider, conn1, conn2)
 
-	require.Equal(t,
-		[]time.Time{
-			currentTimeProvider.CurrentTime.Add(15 * time.Second),
-			{},
-		},
-		conn1.SetDeadlineCalls,
-	)
-
-	require.Empty(t, conn2.SetDeadlineCalls)
+	expectedSetDeadlineCalls := []time.Time{
+		currentTimeProvider.CurrentTime.Add(15 * time.Second),
+		{},
+	}
+
+	require.Equal(t, expectedSetDeadlineCalls, conn1.SetDeadlineCalls)
+	require.Equal(t, expectedSetDeadlineCalls, conn2.SetDeadlineCalls)
 }
 
 func runTest(t *testing.T, networkKey boxstream.NetworkKey, currentTimeProvider *mocks.CurrentTimeProviderMock, conn1 io.ReadWriteCloser, conn2 io.ReadWriteCloser) {
@@ -0,0 +1,37 @@
+package transport
+
+import (
+	"io"
+)
+
+// meteredReadWriteCloser reports the number of bytes passing through a box
+// stream. Box streams always report that zero bytes were written so the
+// length of the written data is used instead.
+type meteredReadWriteCloser struct {
+	rwc     io.ReadWriteCloser
+	metrics Metrics
+}
+
+func newMeteredReadWriteCloser(rwc io.ReadWriteCloser, metrics Metrics) *meteredReadWriteCloser {
+	return &meteredReadWriteCloser{rwc: rwc, metrics: metrics}
+}
+
+func (m *meteredReadWriteCloser) Read(p []byte) (int, error) {
+	n, err := m.rwc.Read(p)
+	if n > 0 {
+		m.metrics.ReportBytesReceived(n)
+	}
+	return n, err
+}
+
+func (m *meteredReadWriteCloser) Write(p []byte) (int, error) {
+	n, err := m.rwc.Write(p)
+	if err == nil {
+		m.metrics.ReportBytesSent(len(p))
+	}
+	return n, err
+}
+
+func (m *meteredReadWriteCloser) Close() error {
+	return m.rwc.Close()
+}
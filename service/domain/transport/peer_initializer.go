@@ -10,17 +10,35 @@ import (
 	"github.com/planetary-social/scuttlego/service/domain/transport/boxstream"
 	"github.com/planetary-social/scuttlego/service/domain/transport/rpc"
 	"github.com/planetary-social/scuttlego/service/domain/transport/rpc/transport"
+	"github.com/planetary-social/scuttlego/tracing"
 )
 
 type NewPeerHandler interface {
 	HandleNewPeer(ctx context.Context, peer Peer)
 }
 
+type Metrics interface {
+	ReportPeerConnected()
+	ReportPeerDisconnected()
+	ReportBytesReceived(n int)
+	ReportBytesSent(n int)
+}
+
+type EventPublisher interface {
+	PublishPeerConnected(remote identity.Public, connectionId rpc.ConnectionId, initiatedByRemote bool)
+	PublishPeerDisconnected(remote identity.Public, connectionId rpc.ConnectionId)
+	PublishHandshakeFailed(connectionId rpc.ConnectionId, initiatedByRemote bool, remote identity.Public, err error)
+}
+
 type PeerInitializer struct {
 	handshaker            boxstream.Handshaker
 	requestHandler        rpc.RequestHandler
 	connectionIdGenerator *rpc.ConnectionIdGenerator
 	newPeerHandler        NewPeerHandler
+	timeouts              rpc.ResponseStreamTimeouts
+	metrics               Metrics
+	tracer                *tracing.Tracer
+	eventPublisher        EventPublisher
 	logger                logging.Logger
 }
 
@@ -29,6 +47,10 @@ func NewPeerInitializer(
 	requestHandler rpc.RequestHandler,
 	connectionIdGenerator *rpc.ConnectionIdGenerator,
 	newPeerHandler NewPeerHandler,
+	timeouts rpc.ResponseStreamTimeouts,
+	metrics Metrics,
+	tracer *tracing.Tracer,
+	eventPublisher EventPublisher,
 	logger logging.Logger,
 ) *PeerInitializer {
 	return &PeerInitializer{
@@ -36,32 +58,66 @@ func NewPeerInitializer(
 		requestHandler:        requestHandler,
 		connectionIdGenerator: connectionIdGenerator,
 		newPeerHandler:        newPeerHandler,
+		timeouts:              timeouts,
+		metrics:               metrics,
+		tracer:                tracer,
+		eventPublisher:        eventPublisher,
 		logger:                logger,
 	}
 }
 
 func (i PeerInitializer) InitializeServerPeer(ctx context.Context, rwc io.ReadWriteCloser) (Peer, error) {
-	boxStream, err := i.handshaker.OpenServerStream(rwc)
+	connectionId := i.connectionIdGenerator.Generate()
+	ctx = logging.AddToLoggingContext(ctx, logging.ConnectionIdContextLabel, connectionId)
+
+	boxStream, err := i.handshake(ctx, connectionId, true, identity.Public{}, func() (*boxstream.Stream, error) {
+		return i.handshaker.OpenServerStream(rwc)
+	})
 	if err != nil {
 		return Peer{}, errors.Wrap(err, "failed to open a server stream")
 	}
 
-	return i.initializePeer(ctx, boxStream, true)
+	return i.initializePeer(ctx, connectionId, boxStream, true)
 }
 
 func (i PeerInitializer) InitializeClientPeer(ctx context.Context, rwc io.ReadWriteCloser, remote identity.Public) (Peer, error) {
-	boxStream, err := i.handshaker.OpenClientStream(rwc, remote)
+	connectionId := i.connectionIdGenerator.Generate()
+	ctx = logging.AddToLoggingContext(ctx, logging.ConnectionIdContextLabel, connectionId)
+
+	boxStream, err := i.handshake(ctx, connectionId, false, remote, func() (*boxstream.Stream, error) {
+		return i.handshaker.OpenClientStream(rwc, remote)
+	})
 	if err != nil {
 		return Peer{}, errors.Wrap(err, "failed to open a client stream")
 	}
 
-	return i.initializePeer(ctx, boxStream, false)
+	return i.initializePeer(ctx, connectionId, boxStream, false)
 }
 
-func (i PeerInitializer) initializePeer(ctx context.Context, boxStream *boxstream.Stream, wasInitiatedByRemote bool) (Peer, error) {
-	connectionId := i.connectionIdGenerator.Generate()
+func (i PeerInitializer) handshake(
+	ctx context.Context,
+	connectionId rpc.ConnectionId,
+	wasInitiatedByRemote bool,
+	remote identity.Public,
+	fn func() (*boxstream.Stream, error),
+) (*boxstream.Stream, error) {
+	_, span := i.tracer.Start(ctx, "transport.handshake")
+	defer span.End()
 
-	ctx = logging.AddToLoggingContext(ctx, logging.ConnectionIdContextLabel, connectionId)
+	span.SetAttribute("initiated_by_remote", wasInitiatedByRemote)
+
+	boxStream, err := fn()
+	if err != nil {
+		span.SetError(err)
+		i.eventPublisher.PublishHandshakeFailed(connectionId, wasInitiatedByRemote, remote, err)
+		return nil, err
+	}
+
+	span.SetAttribute(logging.PeerIdContextLabel, boxStream.Remote().String())
+	return boxStream, nil
+}
+
+func (i PeerInitializer) initializePeer(ctx context.Context, connectionId rpc.ConnectionId, boxStream *boxstream.Stream, wasInitiatedByRemote bool) (Peer, error) {
 	ctx = logging.AddToLoggingContext(ctx, logging.PeerIdContextLabel, boxStream.Remote().String())
 
 	logger := i.logger.WithCtx(ctx)
@@ -69,9 +125,10 @@ func (i PeerInitializer) initializePeer(ctx context.Context, boxStream *boxstrea
 	ctx = rpc.PutRemoteIdentityInContext(ctx, boxStream.Remote())
 	ctx = rpc.PutConnectionIdInContext(ctx, connectionId)
 
-	raw := transport.NewRawConnection(boxStream, logger)
+	raw := transport.NewRawConnection(newMeteredReadWriteCloser(boxStream, i.metrics), logger)
+	requestHandler := newTracedRequestHandler(i.requestHandler, i.tracer)
 
-	rpcConn, err := rpc.NewConnection(connectionId, wasInitiatedByRemote, raw, i.requestHandler, logger)
+	rpcConn, err := rpc.NewConnection(connectionId, wasInitiatedByRemote, raw, requestHandler, i.timeouts, logger)
 	if err != nil {
 		return Peer{}, errors.Wrap(err, "failed to establish an RPC connection")
 	}
@@ -81,13 +138,25 @@ func (i PeerInitializer) initializePeer(ctx context.Context, boxStream *boxstrea
 		return Peer{}, errors.Wrap(err, "error creating a peer")
 	}
 
+	i.metrics.ReportPeerConnected()
+	i.eventPublisher.PublishPeerConnected(peer.Identity(), connectionId, wasInitiatedByRemote)
+
 	go func() {
+		defer i.eventPublisher.PublishPeerDisconnected(peer.Identity(), connectionId)
+		defer i.metrics.ReportPeerDisconnected()
+
+		ctx, span := i.tracer.Start(ctx, "transport.connection")
+		defer span.End()
+
+		span.SetAttribute("initiated_by_remote", wasInitiatedByRemote)
+
 		ctx, cancel := context.WithCancel(ctx)
 		defer cancel()
 
 		i.newPeerHandler.HandleNewPeer(ctx, peer)
 
 		if err := rpcConn.Loop(ctx); err != nil {
+			span.SetError(err)
 			logger.Debug().WithError(err).Message("connection loop exited")
 		}
 	}()
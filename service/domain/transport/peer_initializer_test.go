@@ -0,0 +1,244 @@
+package transport_test
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/planetary-social/scuttlego/internal/fixtures"
+	"github.com/planetary-social/scuttlego/internal/mocks"
+	"github.com/planetary-social/scuttlego/service/domain/identity"
+	"github.com/planetary-social/scuttlego/service/domain/transport"
+	"github.com/planetary-social/scuttlego/service/domain/transport/boxstream"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc"
+	"github.com/planetary-social/scuttlego/tracing"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPeerInitializer_ConnectionEventsArePublished(t *testing.T) {
+	t.Parallel()
+
+	ctx := fixtures.TestContext(t)
+	client := newTestPeerInitializer(t)
+	server := newTestPeerInitializer(t)
+
+	clientConn, serverConn := net.Pipe()
+
+	serverPeerCh := make(chan transport.Peer)
+	go func() {
+		peer, err := server.Initializer.InitializeServerPeer(ctx, serverConn)
+		require.NoError(t, err)
+		serverPeerCh <- peer
+	}()
+
+	clientPeer, err := client.Initializer.InitializeClientPeer(ctx, clientConn, server.Identity.Public())
+	require.NoError(t, err)
+
+	<-serverPeerCh
+
+	require.Equal(t,
+		[]peerInitializerEvent{
+			{
+				Name:              "connected",
+				Remote:            server.Identity.Public(),
+				InitiatedByRemote: false,
+			},
+		},
+		client.EventPublisher.Events(),
+	)
+
+	require.Equal(t,
+		[]peerInitializerEvent{
+			{
+				Name:              "connected",
+				Remote:            client.Identity.Public(),
+				InitiatedByRemote: true,
+			},
+		},
+		server.EventPublisher.Events(),
+	)
+
+	_ = clientPeer.Conn().Close()
+
+	require.Eventually(t, func() bool {
+		return len(client.EventPublisher.Events()) == 2 && len(server.EventPublisher.Events()) == 2
+	}, 1*time.Second, 10*time.Millisecond)
+
+	require.Equal(t,
+		peerInitializerEvent{
+			Name:   "disconnected",
+			Remote: server.Identity.Public(),
+		},
+		client.EventPublisher.Events()[1],
+	)
+
+	require.Equal(t,
+		peerInitializerEvent{
+			Name:   "disconnected",
+			Remote: client.Identity.Public(),
+		},
+		server.EventPublisher.Events()[1],
+	)
+
+}
+
+func TestPeerInitializer_HandshakeFailuresArePublished(t *testing.T) {
+	t.Parallel()
+
+	ctx := fixtures.TestContext(t)
+	client := newTestPeerInitializer(t)
+	server := newTestPeerInitializer(t)
+
+	clientConn, serverConn := net.Pipe()
+
+	serverErrCh := make(chan error)
+	go func() {
+		_, err := server.Initializer.InitializeServerPeer(ctx, serverConn)
+		_ = serverConn.Close()
+		serverErrCh <- err
+	}()
+
+	wrongRemote := fixtures.SomePublicIdentity()
+
+	_, err := client.Initializer.InitializeClientPeer(ctx, clientConn, wrongRemote)
+	require.Error(t, err)
+
+	select {
+	case err := <-serverErrCh:
+		require.Error(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timeout")
+	}
+
+	clientEvents := client.EventPublisher.Events()
+	require.Len(t, clientEvents, 1)
+	require.Equal(t, "handshake_failed", clientEvents[0].Name)
+	require.Equal(t, wrongRemote, clientEvents[0].Remote)
+	require.False(t, clientEvents[0].InitiatedByRemote)
+	require.Error(t, clientEvents[0].Err)
+
+	serverEvents := server.EventPublisher.Events()
+	require.Len(t, serverEvents, 1)
+	require.Equal(t, "handshake_failed", serverEvents[0].Name)
+	require.True(t, serverEvents[0].Remote.IsZero())
+	require.True(t, serverEvents[0].InitiatedByRemote)
+	require.Error(t, serverEvents[0].Err)
+}
+
+type testPeerInitializer struct {
+	Initializer    *transport.PeerInitializer
+	Identity       identity.Private
+	EventPublisher *peerInitializerEventPublisherMock
+}
+
+func newTestPeerInitializer(t *testing.T) testPeerInitializer {
+	local := fixtures.SomePrivateIdentity()
+
+	currentTimeProvider := mocks.NewCurrentTimeProviderMock()
+	currentTimeProvider.CurrentTime = time.Now()
+
+	handshaker, err := boxstream.NewHandshaker(local, boxstream.NewDefaultNetworkKey(), currentTimeProvider)
+	require.NoError(t, err)
+
+	eventPublisher := newPeerInitializerEventPublisherMock()
+
+	initializer := transport.NewPeerInitializer(
+		handshaker,
+		newRequestHandlerMock(),
+		rpc.NewConnectionIdGenerator(),
+		newNewPeerHandlerMock(),
+		rpc.ResponseStreamTimeouts{},
+		newTransportMetricsMock(),
+		tracing.NewDevNullTracer(),
+		eventPublisher,
+		fixtures.TestLogger(t),
+	)
+
+	return testPeerInitializer{
+		Initializer:    initializer,
+		Identity:       local,
+		EventPublisher: eventPublisher,
+	}
+}
+
+type peerInitializerEvent struct {
+	Name              string
+	Remote            identity.Public
+	InitiatedByRemote bool
+	Err               error
+}
+
+type peerInitializerEventPublisherMock struct {
+	events []peerInitializerEvent
+	lock   sync.Mutex
+}
+
+func newPeerInitializerEventPublisherMock() *peerInitializerEventPublisherMock {
+	return &peerInitializerEventPublisherMock{}
+}
+
+func (p *peerInitializerEventPublisherMock) PublishPeerConnected(remote identity.Public, connectionId rpc.ConnectionId, initiatedByRemote bool) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.events = append(p.events, peerInitializerEvent{Name: "connected", Remote: remote, InitiatedByRemote: initiatedByRemote})
+}
+
+func (p *peerInitializerEventPublisherMock) PublishPeerDisconnected(remote identity.Public, connectionId rpc.ConnectionId) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.events = append(p.events, peerInitializerEvent{Name: "disconnected", Remote: remote})
+}
+
+func (p *peerInitializerEventPublisherMock) PublishHandshakeFailed(connectionId rpc.ConnectionId, initiatedByRemote bool, remote identity.Public, err error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.events = append(p.events, peerInitializerEvent{Name: "handshake_failed", Remote: remote, InitiatedByRemote: initiatedByRemote, Err: err})
+}
+
+func (p *peerInitializerEventPublisherMock) Events() []peerInitializerEvent {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	return append([]peerInitializerEvent(nil), p.events...)
+}
+
+type requestHandlerMock struct {
+}
+
+func newRequestHandlerMock() *requestHandlerMock {
+	return &requestHandlerMock{}
+}
+
+func (r requestHandlerMock) HandleRequest(ctx context.Context, s rpc.Stream, req *rpc.Request) {
+	_ = s.CloseWithError(nil)
+}
+
+type newPeerHandlerMock struct {
+}
+
+func newNewPeerHandlerMock() *newPeerHandlerMock {
+	return &newPeerHandlerMock{}
+}
+
+func (n newPeerHandlerMock) HandleNewPeer(ctx context.Context, peer transport.Peer) {
+}
+
+type transportMetricsMock struct {
+}
+
+func newTransportMetricsMock() *transportMetricsMock {
+	return &transportMetricsMock{}
+}
+
+func (t transportMetricsMock) ReportPeerConnected() {
+}
+
+func (t transportMetricsMock) ReportPeerDisconnected() {
+}
+
+func (t transportMetricsMock) ReportBytesReceived(n int) {
+}
+
+func (t transportMetricsMock) ReportBytesSent(n int) {
+}
@@ -29,18 +29,20 @@ type Connection struct {
 
 // NewConnection is the only way of creating a new Connection, zero value is invalid. Terminating the provided context
 // is equivalent to calling Close. The provided context is used as a base context for the contexts passed to the
-// request handler. Connection takes over managing RawConnection which must not be used further.
+// request handler. Connection takes over managing RawConnection which must not be used further. Timeouts apply to
+// requests performed using this connection.
 func NewConnection(
 	id ConnectionId,
 	wasInitiatedByRemote bool,
 	raw RawConnection,
 	handler RequestHandler,
+	timeouts ResponseStreamTimeouts,
 	logger logging.Logger,
 ) (*Connection, error) {
 	conn := &Connection{
 		wasInitiatedByRemote: wasInitiatedByRemote,
 		raw:                  raw,
-		responseStreams:      NewResponseStreams(raw, logger),
+		responseStreams:      NewResponseStreams(raw, timeouts, logger),
 		requestStreams:       NewRequestStreams(raw, handler, logger),
 		logger:               logger.New("connection"),
 		id:                   id,
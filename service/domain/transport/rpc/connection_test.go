@@ -349,7 +349,7 @@ func TestIncomingRequests(t *testing.T) {
 				require.NoError(t, err)
 			})
 
-			conn, err := rpc.NewConnection(fixtures.SomeConnectionId(), fixtures.SomeBool(), raw, handler, logger)
+			conn, err := rpc.NewConnection(fixtures.SomeConnectionId(), fixtures.SomeBool(), raw, handler, rpc.ResponseStreamTimeouts{}, logger)
 			require.NoError(t, err)
 
 			connectionLoopClosed := make(chan struct{})
@@ -443,7 +443,7 @@ func TestCloseWithErrorAndReceivingInStreamMessagesDoNotDeadlock(t *testing.T) {
 		require.NoError(t, err)
 	})
 
-	conn, err := rpc.NewConnection(fixtures.SomeConnectionId(), fixtures.SomeBool(), raw, handler, logger)
+	conn, err := rpc.NewConnection(fixtures.SomeConnectionId(), fixtures.SomeBool(), raw, handler, rpc.ResponseStreamTimeouts{}, logger)
 	require.NoError(t, err)
 
 	ctx, cancel := context.WithCancel(ctx)
@@ -543,7 +543,7 @@ func TestPrematureTerminationByRemote(t *testing.T) {
 				requestHandlerTerminatedCorrectly.Store(true)
 			})
 
-			conn, err := rpc.NewConnection(fixtures.SomeConnectionId(), fixtures.SomeBool(), raw, handler, logger)
+			conn, err := rpc.NewConnection(fixtures.SomeConnectionId(), fixtures.SomeBool(), raw, handler, rpc.ResponseStreamTimeouts{}, logger)
 			require.NoError(t, err)
 
 			go func() {
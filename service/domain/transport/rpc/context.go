@@ -37,3 +37,24 @@ func GetRemoteIdentityFromContext(ctx context.Context) (identity.Public, bool) {
 	}
 	return v.(identity.Public), true
 }
+
+type privilegedKeyType string
+
+const privilegedKey privilegedKeyType = "privileged"
+
+// PutPrivilegedInContext marks the connection as privileged. Privileged
+// procedures can only be called over privileged connections. This should only
+// be done for connections established by local clients.
+func PutPrivilegedInContext(ctx context.Context) context.Context {
+	return context.WithValue(ctx, privilegedKey, true)
+}
+
+// IsPrivilegedConnection returns true if the context was marked using
+// PutPrivilegedInContext.
+func IsPrivilegedConnection(ctx context.Context) bool {
+	v := ctx.Value(privilegedKey)
+	if v == nil {
+		return false
+	}
+	return v.(bool)
+}
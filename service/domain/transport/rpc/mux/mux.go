@@ -53,20 +53,44 @@ type SynchronousHandler interface {
 	Handle(ctx context.Context, s CloserStream, req *rpc.Request)
 }
 
+// PrivilegedHandlers are handlers which can only be called over privileged
+// connections e.g. by local clients, see rpc.PutPrivilegedInContext. Requests
+// arriving over other connections are rejected without calling the handler.
+// This is enforced at runtime by the mux, the handlers themselves are regular
+// handlers.
+type PrivilegedHandlers []Handler
+
+type Metrics interface {
+	// ReportRPCRequest is called with the name of the procedure when a
+	// request is passed to a handler.
+	ReportRPCRequest(procedure rpc.ProcedureName)
+
+	// ReportUnknownRPCRequest is called when a request can't be passed to
+	// any handler. The procedure name isn't reported as it is controlled by
+	// the remote.
+	ReportUnknownRPCRequest()
+}
+
 type Mux struct {
 	handlers            map[string]Handler
 	synchronousHandlers map[string]SynchronousHandler
+	privilegedHandlers  map[string]Handler
+	metrics             Metrics
 	logger              logging.Logger
 }
 
 func NewMux(
 	logger logging.Logger,
+	metrics Metrics,
 	handlers []Handler,
 	synchronousHandlers []SynchronousHandler,
+	privilegedHandlers PrivilegedHandlers,
 ) (*Mux, error) {
 	m := &Mux{
 		handlers:            make(map[string]Handler),
 		synchronousHandlers: make(map[string]SynchronousHandler),
+		privilegedHandlers:  make(map[string]Handler),
+		metrics:             metrics,
 		logger:              logger.New("mux"),
 	}
 
@@ -82,14 +106,21 @@ func NewMux(
 		}
 	}
 
+	for _, handler := range privilegedHandlers {
+		if err := m.addPrivilegedHandler(handler); err != nil {
+			return nil, errors.Wrap(err, "could not add a privileged handler")
+		}
+	}
+
 	return m, nil
 }
 
 func (m Mux) HandleRequest(ctx context.Context, s CloserStream, req *rpc.Request) {
 	var findHandlerErr error
 
-	handler, err := m.getHandler(req)
+	handler, err := m.getHandler(ctx, req)
 	if err == nil {
+		m.metrics.ReportRPCRequest(req.Name())
 		go func() {
 			err := handler.Handle(ctx, s, req)
 			if err != nil {
@@ -106,12 +137,14 @@ func (m Mux) HandleRequest(ctx context.Context, s CloserStream, req *rpc.Request
 
 	closingHandler, err := m.getSynchronousHandler(req)
 	if err == nil {
+		m.metrics.ReportRPCRequest(req.Name())
 		closingHandler.Handle(ctx, s, req)
 		return
 	} else {
 		findHandlerErr = multierror.Append(findHandlerErr, err)
 	}
 
+	m.metrics.ReportUnknownRPCRequest()
 	m.logger.Debug().WithError(findHandlerErr).Message("handler not found")
 
 	if err := s.CloseWithError(findHandlerErr); err != nil {
@@ -143,6 +176,18 @@ func (m Mux) addSynchronousHandler(handler SynchronousHandler) error {
 	return nil
 }
 
+func (m Mux) addPrivilegedHandler(handler Handler) error {
+	key := m.procedureNameToKey(handler.Procedure().Name())
+
+	if err := m.checkKeyUnique(key); err != nil {
+		return errors.Wrap(err, "handler is not unique")
+	}
+
+	m.logger.Trace().WithField("key", key).Message("adding privileged handler")
+	m.privilegedHandlers[key] = handler
+	return nil
+}
+
 func (m Mux) checkKeyUnique(key string) error {
 	if _, ok := m.handlers[key]; ok {
 		return fmt.Errorf("handler for method '%s' was already added", key)
@@ -152,15 +197,28 @@ func (m Mux) checkKeyUnique(key string) error {
 		return fmt.Errorf("synchronous handler for method '%s' was already added", key)
 	}
 
+	if _, ok := m.privilegedHandlers[key]; ok {
+		return fmt.Errorf("privileged handler for method '%s' was already added", key)
+	}
+
 	return nil
 }
 
-func (m Mux) getHandler(req *rpc.Request) (Handler, error) {
+func (m Mux) getHandler(ctx context.Context, req *rpc.Request) (Handler, error) {
 	key := m.procedureNameToKey(req.Name())
 
 	handler, ok := m.handlers[key]
 	if !ok {
-		return nil, errors.New("handler not found")
+		privilegedHandler, ok := m.privilegedHandlers[key]
+		if !ok {
+			return nil, errors.New("handler not found")
+		}
+
+		if !rpc.IsPrivilegedConnection(ctx) {
+			return nil, errors.New("procedure can only be called over a privileged connection")
+		}
+
+		handler = privilegedHandler
 	}
 
 	if handler.Procedure().Typ() != req.Type() {
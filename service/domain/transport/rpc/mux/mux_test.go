@@ -53,7 +53,7 @@ func TestNewMux(t *testing.T) {
 		),
 	}
 
-	_, err := mux.NewMux(logger, handlers, synchronousHandlers)
+	_, err := mux.NewMux(logger, mocks.NewMuxMetricsMock(), handlers, synchronousHandlers, nil)
 	require.NoError(t, err)
 }
 
@@ -81,7 +81,7 @@ func TestNewMux_ProcedureNamesMustBeUniqueForHandlers(t *testing.T) {
 		),
 	}
 
-	_, err := mux.NewMux(logger, handlers, nil)
+	_, err := mux.NewMux(logger, mocks.NewMuxMetricsMock(), handlers, nil, nil)
 	require.EqualError(t, err, "could not add a handler: handler is not unique: handler for method 'someProcedure' was already added")
 }
 
@@ -109,7 +109,7 @@ func TestNewMux_ProcedureNamesMustBeUniqueForSynchronousHandlers(t *testing.T) {
 		),
 	}
 
-	_, err := mux.NewMux(logger, nil, synchronousHandlers)
+	_, err := mux.NewMux(logger, mocks.NewMuxMetricsMock(), nil, synchronousHandlers, nil)
 	require.EqualError(t, err, "could not add a synchronous handler: handler is not unique: synchronous handler for method 'someProcedure' was already added")
 }
 
@@ -140,7 +140,7 @@ func TestNewMux_ProcedureNamesMustBeUniqueForSynchronousHandlersAndHandlers(t *t
 		),
 	}
 
-	_, err := mux.NewMux(logger, handlers, synchronousHandlers)
+	_, err := mux.NewMux(logger, mocks.NewMuxMetricsMock(), handlers, synchronousHandlers, nil)
 	require.EqualError(t, err, "could not add a synchronous handler: handler is not unique: handler for method 'someProcedure' was already added")
 }
 
@@ -165,7 +165,7 @@ func TestNewMux_ReturningNonNilErrorFromSynchroniousHandlerCallsCloseWithError(t
 		),
 	}
 
-	m, err := mux.NewMux(logger, handlers, nil)
+	m, err := mux.NewMux(logger, mocks.NewMuxMetricsMock(), handlers, nil, nil)
 	require.NoError(t, err)
 
 	ctx := fixtures.TestContext(t)
@@ -206,7 +206,7 @@ func TestNewMux_ReturningNilErrorFromSynchrioniousHandlerCallsCloseWithError(t *
 		),
 	}
 
-	m, err := mux.NewMux(logger, handlers, nil)
+	m, err := mux.NewMux(logger, mocks.NewMuxMetricsMock(), handlers, nil, nil)
 	require.NoError(t, err)
 
 	ctx := fixtures.TestContext(t)
@@ -253,7 +253,7 @@ func TestNewMux_HandlerDoesNotBlock(t *testing.T) {
 		),
 	}
 
-	m, err := mux.NewMux(logger, handlers, nil)
+	m, err := mux.NewMux(logger, mocks.NewMuxMetricsMock(), handlers, nil, nil)
 	require.NoError(t, err)
 
 	ctx := fixtures.TestContext(t)
@@ -296,7 +296,7 @@ func TestNewMux_SynchronousHandlerBlocks(t *testing.T) {
 		),
 	}
 
-	m, err := mux.NewMux(logger, nil, synchronousHandlers)
+	m, err := mux.NewMux(logger, mocks.NewMuxMetricsMock(), nil, synchronousHandlers, nil)
 	require.NoError(t, err)
 
 	ctx := fixtures.TestContext(t)
@@ -315,6 +315,165 @@ func TestNewMux_SynchronousHandlerBlocks(t *testing.T) {
 	require.Greater(t, time.Since(start), delay)
 }
 
+func TestNewMux_PrivilegedHandlersAreCalledOnlyForPrivilegedConnections(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		Name                 string
+		Privileged           bool
+		ShouldCallTheHandler bool
+	}{
+		{
+			Name:                 "privileged",
+			Privileged:           true,
+			ShouldCallTheHandler: true,
+		},
+		{
+			Name:                 "not_privileged",
+			Privileged:           false,
+			ShouldCallTheHandler: false,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.Name, func(t *testing.T) {
+			t.Parallel()
+
+			logger := fixtures.TestLogger(t)
+
+			procedure := rpc.MustNewProcedure(
+				rpc.MustNewProcedureName([]string{"someProcedure"}),
+				rpc.ProcedureTypeAsync,
+			)
+
+			handlerCalled := make(chan struct{})
+
+			privilegedHandlers := mux.PrivilegedHandlers{
+				newMockHandler(
+					procedure,
+					func(ctx context.Context, s mux.Stream, req *rpc.Request) error {
+						close(handlerCalled)
+						return nil
+					},
+				),
+			}
+
+			m, err := mux.NewMux(logger, mocks.NewMuxMetricsMock(), nil, nil, privilegedHandlers)
+			require.NoError(t, err)
+
+			ctx := fixtures.TestContext(t)
+			if testCase.Privileged {
+				ctx = rpc.PutPrivilegedInContext(ctx)
+			}
+			s := mocks.NewMockCloserStream()
+			req := rpc.MustNewRequest(procedure.Name(), procedure.Typ(), nil)
+
+			m.HandleRequest(ctx, s, req)
+			require.Eventually(
+				t,
+				func() bool {
+					return len(s.WrittenErrors()) == 1
+				},
+				1*time.Second, 10*time.Millisecond,
+			)
+
+			if testCase.ShouldCallTheHandler {
+				require.NoError(t, s.WrittenErrors()[0])
+				<-handlerCalled
+			} else {
+				require.Error(t, s.WrittenErrors()[0])
+				select {
+				case <-handlerCalled:
+					t.Fatal("handler was called")
+				default:
+				}
+			}
+		})
+	}
+}
+
+func TestNewMux_ProcedureNamesMustBeUniqueForPrivilegedHandlersAndHandlers(t *testing.T) {
+	t.Parallel()
+
+	logger := fixtures.TestLogger(t)
+
+	procedure := rpc.MustNewProcedure(
+		rpc.MustNewProcedureName([]string{"someProcedure"}),
+		rpc.ProcedureTypeAsync,
+	)
+
+	handlers := []mux.Handler{
+		newMockHandler(procedure, nil),
+	}
+
+	privilegedHandlers := mux.PrivilegedHandlers{
+		newMockHandler(procedure, nil),
+	}
+
+	_, err := mux.NewMux(logger, mocks.NewMuxMetricsMock(), handlers, nil, privilegedHandlers)
+	require.EqualError(t, err, "could not add a privileged handler: handler is not unique: handler for method 'someProcedure' was already added")
+}
+
+func TestNewMux_RequestsAreReportedOnlyIfHandlerIsFound(t *testing.T) {
+	t.Parallel()
+
+	logger := fixtures.TestLogger(t)
+
+	procedure := rpc.MustNewProcedure(
+		rpc.MustNewProcedureName([]string{"someProcedure"}),
+		rpc.ProcedureTypeAsync,
+	)
+
+	synchronousProcedure := rpc.MustNewProcedure(
+		rpc.MustNewProcedureName([]string{"someSynchronousProcedure"}),
+		rpc.ProcedureTypeAsync,
+	)
+
+	handlers := []mux.Handler{
+		newMockHandler(
+			procedure,
+			func(ctx context.Context, s mux.Stream, req *rpc.Request) error {
+				return nil
+			},
+		),
+	}
+
+	synchronousHandlers := []mux.SynchronousHandler{
+		newMockSynchronousHandler(
+			synchronousProcedure,
+			func(ctx context.Context, s mux.Stream, req *rpc.Request) {
+			},
+		),
+	}
+
+	metrics := mocks.NewMuxMetricsMock()
+
+	m, err := mux.NewMux(logger, metrics, handlers, synchronousHandlers, nil)
+	require.NoError(t, err)
+
+	ctx := fixtures.TestContext(t)
+
+	for _, name := range []rpc.ProcedureName{
+		procedure.Name(),
+		synchronousProcedure.Name(),
+		fixtures.SomeProcedureName(),
+		fixtures.SomeProcedureName(),
+	} {
+		m.HandleRequest(ctx, mocks.NewMockCloserStream(), rpc.MustNewRequest(name, rpc.ProcedureTypeAsync, nil))
+	}
+
+	require.Equal(t,
+		[]rpc.ProcedureName{
+			procedure.Name(),
+			synchronousProcedure.Name(),
+		},
+		metrics.RPCRequests(),
+	)
+	require.Equal(t, 2, metrics.UnknownRPCRequests())
+}
+
 type handlerFn func(ctx context.Context, s mux.Stream, req *rpc.Request) error
 
 type mockHandler struct {
@@ -111,8 +111,8 @@ func (rs *RequestStream) CloseWithError(err error) error {
 }
 
 func (rs *RequestStream) IncomingMessages() (<-chan IncomingMessage, error) {
-	if rs.typ != ProcedureTypeDuplex {
-		return nil, errors.New("only duplex streams can receive messages")
+	if !rs.receivesMessages() {
+		return nil, errors.New("only duplex and sink streams can receive messages")
 	}
 	return rs.incomingMessages, nil
 }
@@ -122,8 +122,8 @@ func (rs *RequestStream) RequestNumber() int {
 }
 
 func (rs *RequestStream) HandleNewMessage(msg transport.Message) error {
-	if rs.typ != ProcedureTypeDuplex {
-		return errors.New("only duplex streams can receive messages")
+	if !rs.receivesMessages() {
+		return errors.New("only duplex and sink streams can receive messages")
 	}
 
 	select {
@@ -132,3 +132,7 @@ func (rs *RequestStream) HandleNewMessage(msg transport.Message) error {
 	}
 	return nil
 }
+
+func (rs *RequestStream) receivesMessages() bool {
+	return rs.typ == ProcedureTypeDuplex || rs.typ == ProcedureTypeSink
+}
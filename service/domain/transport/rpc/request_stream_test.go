@@ -171,6 +171,11 @@ func TestRequestStream_HandleNewMessageReturnsAnErrorForProceduresThatAreNotDupl
 			ProcedureType:           rpc.ProcedureTypeDuplex,
 			AcceptsFollowUpMessages: true,
 		},
+		{
+			Name:                    "sink",
+			ProcedureType:           rpc.ProcedureTypeSink,
+			AcceptsFollowUpMessages: true,
+		},
 	}
 
 	for _, testCase := range testCases {
@@ -200,7 +205,7 @@ func TestRequestStream_HandleNewMessageReturnsAnErrorForProceduresThatAreNotDupl
 			if testCase.AcceptsFollowUpMessages {
 				require.NoError(t, err)
 			} else {
-				require.EqualError(t, err, "only duplex streams can receive messages")
+				require.EqualError(t, err, "only duplex and sink streams can receive messages")
 			}
 		})
 	}
@@ -232,6 +237,11 @@ func TestRequestStream_IncomingMessagesReturnsAnErrorForProceduresThatAreNotDupl
 			ProcedureType: rpc.ProcedureTypeDuplex,
 			ExpectedError: false,
 		},
+		{
+			Name:          "sink",
+			ProcedureType: rpc.ProcedureTypeSink,
+			ExpectedError: false,
+		},
 	}
 
 	for _, testCase := range testCases {
@@ -245,7 +255,7 @@ func TestRequestStream_IncomingMessagesReturnsAnErrorForProceduresThatAreNotDupl
 
 			_, err = stream.IncomingMessages()
 			if testCase.ExpectedError {
-				require.EqualError(t, err, "only duplex streams can receive messages")
+				require.EqualError(t, err, "only duplex and sink streams can receive messages")
 			} else {
 				require.NoError(t, err)
 			}
@@ -444,7 +454,7 @@ func newTestConnection(tb testing.TB, handler rpc.RequestHandler) testConnection
 	logger := fixtures.SomeLogger()
 	raw := newRawConnectionMock()
 
-	conn, err := rpc.NewConnection(fixtures.SomeConnectionId(), fixtures.SomeBool(), raw, handler, logger)
+	conn, err := rpc.NewConnection(fixtures.SomeConnectionId(), fixtures.SomeBool(), raw, handler, rpc.ResponseStreamTimeouts{}, logger)
 	require.NoError(tb, err)
 
 	loopCtx, cancelLoopCtx := context.WithCancel(ctx)
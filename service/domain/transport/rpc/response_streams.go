@@ -6,6 +6,7 @@ import (
 	"net"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/boreq/errors"
 	"github.com/planetary-social/scuttlego/logging"
@@ -27,8 +28,43 @@ var (
 	// ErrRemoteEnd signals that the remote closed the stream but didn't signal
 	// that an error occurred.
 	ErrRemoteEnd = errors.New("remote end")
+
+	// ErrFirstResponseTimeout signals that the stream was closed because the
+	// remote didn't send the first response in time.
+	ErrFirstResponseTimeout = errors.New("timed out waiting for the first response")
+
+	// ErrIdleStreamTimeout signals that the stream was closed because the
+	// remote didn't send any messages for too long.
+	ErrIdleStreamTimeout = errors.New("stream was idle for too long")
 )
 
+// ResponseStreamTimeouts limit how long streams initiated by us wait for the
+// remote. Zero value of any of the timeouts disables it.
+type ResponseStreamTimeouts struct {
+	// FirstResponse limits how long async and duplex streams wait for the
+	// first response. Source streams are not affected as live source streams
+	// can legitimately stay quiet for a long time.
+	FirstResponse time.Duration
+
+	// IdleDuplexStream limits how long duplex streams can go without
+	// receiving any messages after the first response was received.
+	IdleDuplexStream time.Duration
+}
+
+func (t ResponseStreamTimeouts) firstResponse(typ ProcedureType) time.Duration {
+	if typ == ProcedureTypeAsync || typ == ProcedureTypeDuplex {
+		return t.FirstResponse
+	}
+	return 0
+}
+
+func (t ResponseStreamTimeouts) idle(typ ProcedureType) time.Duration {
+	if typ == ProcedureTypeDuplex {
+		return t.IdleDuplexStream
+	}
+	return 0
+}
+
 type RemoteError struct {
 	response []byte
 }
@@ -78,15 +114,17 @@ type ResponseStreams struct {
 
 	outgoingRequestNumber uint32
 
-	raw    MessageSender
-	logger logging.Logger
+	timeouts ResponseStreamTimeouts
+	raw      MessageSender
+	logger   logging.Logger
 }
 
-func NewResponseStreams(raw MessageSender, logger logging.Logger) *ResponseStreams {
+func NewResponseStreams(raw MessageSender, timeouts ResponseStreamTimeouts, logger logging.Logger) *ResponseStreams {
 	return &ResponseStreams{
-		raw:     raw,
-		streams: make(map[int]*responseStream),
-		logger:  logger.New("response_streams"),
+		raw:      raw,
+		timeouts: timeouts,
+		streams:  make(map[int]*responseStream),
+		logger:   logger.New("response_streams"),
 	}
 }
 
@@ -117,6 +155,7 @@ func (s *ResponseStreams) Open(ctx context.Context, req *Request) (ResponseStrea
 	s.streams[requestNumber] = rs
 
 	go s.waitAndCloseResponseStream(rs)
+	go s.closeResponseStreamOnTimeout(rs)
 
 	if err := s.raw.Send(msg); err != nil {
 		return nil, errors.Wrap(err, "could not send a message")
@@ -150,6 +189,64 @@ func (s *ResponseStreams) HandleIncomingResponse(msg *transport.Message) error {
 	return nil
 }
 
+// closeResponseStreamOnTimeout closes the stream with an error if the remote
+// doesn't send the first response or goes idle for longer than the configured
+// timeouts.
+func (s *ResponseStreams) closeResponseStreamOnTimeout(rs *responseStream) {
+	firstResponseTimeout := s.timeouts.firstResponse(rs.typ)
+	idleTimeout := s.timeouts.idle(rs.typ)
+
+	if firstResponseTimeout == 0 && idleTimeout == 0 {
+		return
+	}
+
+	var timer *time.Timer
+	var timeoutErr error
+
+	if firstResponseTimeout > 0 {
+		timer = time.NewTimer(firstResponseTimeout)
+		timeoutErr = ErrFirstResponseTimeout
+	} else {
+		timer = time.NewTimer(idleTimeout) // we are not waiting for the first response so it doesn't really matter
+		timeoutErr = ErrIdleStreamTimeout
+	}
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-rs.ctx.Done():
+			return
+		case <-rs.activity:
+			if idleTimeout == 0 {
+				return
+			}
+
+			if !timer.Stop() {
+				<-timer.C
+			}
+			timer.Reset(idleTimeout)
+			timeoutErr = ErrIdleStreamTimeout
+		case <-timer.C:
+			s.closeResponseStreamWithError(rs, timeoutErr)
+			return
+		}
+	}
+}
+
+func (s *ResponseStreams) closeResponseStreamWithError(rs *responseStream, err error) {
+	s.streamsLock.Lock()
+	defer s.streamsLock.Unlock()
+
+	if existing, ok := s.streams[rs.number]; !ok || existing != rs {
+		return
+	}
+
+	s.logger.Debug().WithError(err).WithField("number", rs.number).Message("closing the stream")
+
+	defer rs.cancel()
+	rs.handleError(err)
+}
+
 func (s *ResponseStreams) marshalRequest(req *Request) (*transport.Message, error) {
 	requestNumber := s.newOutgoingRequestNumber()
 	return marshalRequest(req, requestNumber)
@@ -197,7 +294,8 @@ type responseStream struct {
 	cancel context.CancelFunc
 	raw    MessageSender
 
-	ch chan ResponseWithError
+	ch       chan ResponseWithError
+	activity chan struct{}
 }
 
 func newResponseStream(ctx context.Context, number int, typ ProcedureType, raw MessageSender) (*responseStream, error) {
@@ -209,12 +307,13 @@ func newResponseStream(ctx context.Context, number int, typ ProcedureType, raw M
 	ctx, cancel := context.WithCancel(ctx)
 
 	return &responseStream{
-		number: number,
-		typ:    typ,
-		ctx:    ctx,
-		cancel: cancel,
-		raw:    raw,
-		ch:     make(chan ResponseWithError),
+		number:   number,
+		typ:      typ,
+		ctx:      ctx,
+		cancel:   cancel,
+		raw:      raw,
+		ch:       make(chan ResponseWithError),
+		activity: make(chan struct{}, 1),
 	}, nil
 }
 
@@ -265,13 +364,22 @@ func (rs responseStream) Channel() <-chan ResponseWithError {
 }
 
 func (rs responseStream) handleRemoteErr(body []byte) {
+	rs.handleError(rs.guessError(body))
+}
+
+func (rs responseStream) handleError(err error) {
 	select {
-	case rs.ch <- ResponseWithError{Err: rs.guessError(body)}:
+	case rs.ch <- ResponseWithError{Err: err}:
 	case <-rs.ctx.Done():
 	}
 }
 
 func (rs responseStream) handleRemoteResponse(resp *Response) {
+	select {
+	case rs.activity <- struct{}{}:
+	default:
+	}
+
 	select {
 	case rs.ch <- ResponseWithError{Value: resp}:
 	case <-rs.ctx.Done():
@@ -19,7 +19,7 @@ func TestResponseStreams_RemoteTerminationReturnsErrRemoteEnd(t *testing.T) {
 	ctx := fixtures.TestContext(t)
 	req := someRequest()
 
-	streams := rpc.NewResponseStreams(sender, logger)
+	streams := rpc.NewResponseStreams(sender, rpc.ResponseStreamTimeouts{}, logger)
 
 	stream, err := streams.Open(ctx, req)
 	require.NoError(t, err)
@@ -59,7 +59,7 @@ func TestResponseStreams_RemoteTerminationWithAnErrorReturnsErrRemoteError(t *te
 	ctx := fixtures.TestContext(t)
 	req := someRequest()
 
-	streams := rpc.NewResponseStreams(sender, logger)
+	streams := rpc.NewResponseStreams(sender, rpc.ResponseStreamTimeouts{}, logger)
 
 	stream, err := streams.Open(ctx, req)
 	require.NoError(t, err)
@@ -99,7 +99,7 @@ func TestResponseStreams_ContextTerminationClosesTheChannel(t *testing.T) {
 	ctx, cancel := context.WithCancel(fixtures.TestContext(t))
 	req := someRequest()
 
-	streams := rpc.NewResponseStreams(sender, logger)
+	streams := rpc.NewResponseStreams(sender, rpc.ResponseStreamTimeouts{}, logger)
 
 	stream, err := streams.Open(ctx, req)
 	require.NoError(t, err)
@@ -131,7 +131,7 @@ func TestResponseStreams_RequestsAndInStreamMessagesAreCorrectlyMarshaledInDuple
 	inStreamMessageBodyType := fixtures.SomeMessageBodyType()
 	inStreamMessageData := fixtures.SomeBytes()
 
-	streams := rpc.NewResponseStreams(sender, logger)
+	streams := rpc.NewResponseStreams(sender, rpc.ResponseStreamTimeouts{}, logger)
 
 	stream, err := streams.Open(ctx, req)
 	require.NoError(t, err)
@@ -174,6 +174,123 @@ func TestResponseStreams_RequestsAndInStreamMessagesAreCorrectlyMarshaledInDuple
 	}
 }
 
+func TestResponseStreams_FirstResponseTimeoutClosesTheStreamWithAnError(t *testing.T) {
+	testCases := []struct {
+		Name                string
+		ProcedureType       rpc.ProcedureType
+		ShouldTimeOut       bool
+		SendFirstResponse   bool
+		IdleDuplexStream    time.Duration
+		ExpectedTimeoutErr  error
+		ExpectedTermination bool
+	}{
+		{
+			Name:                "async",
+			ProcedureType:       rpc.ProcedureTypeAsync,
+			ShouldTimeOut:       true,
+			ExpectedTimeoutErr:  rpc.ErrFirstResponseTimeout,
+			ExpectedTermination: false,
+		},
+		{
+			Name:                "duplex",
+			ProcedureType:       rpc.ProcedureTypeDuplex,
+			ShouldTimeOut:       true,
+			ExpectedTimeoutErr:  rpc.ErrFirstResponseTimeout,
+			ExpectedTermination: true,
+		},
+		{
+			Name:          "source",
+			ProcedureType: rpc.ProcedureTypeSource,
+			ShouldTimeOut: false,
+		},
+		{
+			Name:              "duplex_which_received_the_first_response",
+			ProcedureType:     rpc.ProcedureTypeDuplex,
+			SendFirstResponse: true,
+			ShouldTimeOut:     false,
+		},
+		{
+			Name:                "idle_duplex",
+			ProcedureType:       rpc.ProcedureTypeDuplex,
+			SendFirstResponse:   true,
+			IdleDuplexStream:    100 * time.Millisecond,
+			ShouldTimeOut:       true,
+			ExpectedTimeoutErr:  rpc.ErrIdleStreamTimeout,
+			ExpectedTermination: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			sender := NewSenderMock()
+			logger := fixtures.TestLogger(t)
+			ctx := fixtures.TestContext(t)
+
+			req := rpc.MustNewRequest(
+				fixtures.SomeProcedureName(),
+				testCase.ProcedureType,
+				fixtures.SomeJSON(),
+			)
+
+			timeouts := rpc.ResponseStreamTimeouts{
+				FirstResponse:    100 * time.Millisecond,
+				IdleDuplexStream: testCase.IdleDuplexStream,
+			}
+
+			streams := rpc.NewResponseStreams(sender, timeouts, logger)
+
+			stream, err := streams.Open(ctx, req)
+			require.NoError(t, err)
+
+			if testCase.SendFirstResponse {
+				go func() {
+					err := streams.HandleIncomingResponse(createResponse(sender.SendCalls()[0]))
+					require.NoError(t, err)
+				}()
+
+				select {
+				case resp := <-stream.Channel():
+					require.NoError(t, resp.Err)
+				case <-time.After(5 * time.Second):
+					t.Fatal("first response was not received")
+				}
+			}
+
+			if !testCase.ShouldTimeOut {
+				select {
+				case resp := <-stream.Channel():
+					t.Fatalf("unexpected response: %+v", resp)
+				case <-time.After(500 * time.Millisecond):
+					t.Log("ok, stream didn't time out")
+				}
+				return
+			}
+
+			select {
+			case resp, ok := <-stream.Channel():
+				require.True(t, ok)
+				require.ErrorIs(t, resp.Err, testCase.ExpectedTimeoutErr)
+			case <-time.After(5 * time.Second):
+				t.Fatal("stream didn't time out")
+			}
+
+			select {
+			case _, ok := <-stream.Channel():
+				require.False(t, ok)
+			case <-time.After(5 * time.Second):
+				t.Fatal("channel was not closed")
+			}
+
+			if testCase.ExpectedTermination {
+				require.Eventually(t, func() bool {
+					calls := sender.SendCalls()
+					return len(calls) == 2 && calls[1].Header.Flags().EndOrError()
+				}, time.Second, 10*time.Millisecond)
+			}
+		})
+	}
+}
+
 type expectedFlagsAndRequestNumber struct {
 	Flags         transport.MessageHeaderFlags
 	RequestNumber int
@@ -213,7 +330,7 @@ func TestResponseStreams_DoesNotAcceptProcedureOfTypeUnknown(t *testing.T) {
 			sender := NewSenderMock()
 			logger := fixtures.TestLogger(t)
 
-			streams := rpc.NewResponseStreams(sender, logger)
+			streams := rpc.NewResponseStreams(sender, rpc.ResponseStreamTimeouts{}, logger)
 
 			_, err := streams.Open(ctx,
 				rpc.MustNewRequest(
@@ -259,7 +376,7 @@ func TestResponseStreams_ReturnsImplementationForWhichWriteMessageWorksOnlyForDu
 			sender := NewSenderMock()
 			logger := fixtures.TestLogger(t)
 
-			streams := rpc.NewResponseStreams(sender, logger)
+			streams := rpc.NewResponseStreams(sender, rpc.ResponseStreamTimeouts{}, logger)
 
 			stream, err := streams.Open(ctx,
 				rpc.MustNewRequest(
@@ -318,7 +435,7 @@ func TestResponseStreams_StreamsShouldSendTerminationOnlyForCertainProcedureType
 				fixtures.SomeJSON(),
 			)
 
-			streams := rpc.NewResponseStreams(sender, logger)
+			streams := rpc.NewResponseStreams(sender, rpc.ResponseStreamTimeouts{}, logger)
 
 			stream, err := streams.Open(ctx, req)
 			require.NoError(t, err)
@@ -47,6 +47,7 @@ const (
 	transportStringForProcedureTypeSource = "source"
 	transportStringForProcedureTypeDuplex = "duplex"
 	transportStringForProcedureTypeAsync  = "async"
+	transportStringForProcedureTypeSink   = "sink"
 )
 
 func decodeProcedureType(str string) ProcedureType {
@@ -57,6 +58,8 @@ func decodeProcedureType(str string) ProcedureType {
 		return ProcedureTypeDuplex
 	case transportStringForProcedureTypeAsync:
 		return ProcedureTypeAsync
+	case transportStringForProcedureTypeSink:
+		return ProcedureTypeSink
 	default:
 		return ProcedureTypeUnknown
 	}
@@ -70,6 +73,8 @@ func encodeProcedureType(t ProcedureType) (string, error) {
 		return transportStringForProcedureTypeDuplex, nil
 	case ProcedureTypeAsync:
 		return transportStringForProcedureTypeAsync, nil
+	case ProcedureTypeSink:
+		return transportStringForProcedureTypeSink, nil
 	default:
 		return "", fmt.Errorf("unknown procedure type %+v", t)
 	}
@@ -134,6 +139,8 @@ func guessStream(procedureType ProcedureType) bool {
 		return true
 	case ProcedureTypeSource:
 		return true
+	case ProcedureTypeSink:
+		return true
 	default:
 		return false
 	}
@@ -131,6 +131,11 @@ var (
 	ProcedureTypeSource  = ProcedureType{"source"}
 	ProcedureTypeDuplex  = ProcedureType{"duplex"}
 	ProcedureTypeAsync   = ProcedureType{"async"}
+
+	// ProcedureTypeSink streams are only used by local clients e.g. to upload
+	// blobs. The caller sends a stream of messages and the callee responds
+	// only by closing the stream.
+	ProcedureTypeSink = ProcedureType{"sink"}
 )
 
 type Procedure struct {
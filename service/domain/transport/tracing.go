@@ -0,0 +1,31 @@
+package transport
+
+import (
+	"context"
+
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc"
+	"github.com/planetary-social/scuttlego/tracing"
+)
+
+// tracedRequestHandler records a span for each request stream initiated by
+// the remote. The span ends when the stream is closed.
+type tracedRequestHandler struct {
+	handler rpc.RequestHandler
+	tracer  *tracing.Tracer
+}
+
+func newTracedRequestHandler(handler rpc.RequestHandler, tracer *tracing.Tracer) *tracedRequestHandler {
+	return &tracedRequestHandler{handler: handler, tracer: tracer}
+}
+
+func (t *tracedRequestHandler) HandleRequest(ctx context.Context, s rpc.Stream, req *rpc.Request) {
+	ctx, span := t.tracer.Start(ctx, "rpc.request")
+	span.SetAttribute("procedure", req.Name().String())
+
+	go func() {
+		<-ctx.Done()
+		span.End()
+	}()
+
+	t.handler.HandleRequest(ctx, s, req)
+}
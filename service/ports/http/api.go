@@ -0,0 +1,195 @@
+package http
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/boreq/errors"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/planetary-social/scuttlego/service/app/common"
+	"github.com/planetary-social/scuttlego/service/domain/blobs/replication"
+)
+
+const (
+	maxJSONBodySizeInBytes = 1024 * 1024
+
+	// accessTokenQueryParameter can be used to authenticate requests made by
+	// clients which can't set headers e.g. EventSource in browsers.
+	accessTokenQueryParameter = "access_token"
+)
+
+// router matches requests using escaped paths. http.ServeMux can't be used as
+// it cleans decoded paths and refs can contain sequences such as "//".
+type router struct {
+	routes   map[string]http.Handler
+	prefixes []prefixRoute
+}
+
+type prefixRoute struct {
+	prefix  string
+	handler http.Handler
+}
+
+func newRouter() *router {
+	return &router{
+		routes: make(map[string]http.Handler),
+	}
+}
+
+// Handle registers a handler for the provided path.
+func (r *router) Handle(path string, handler http.Handler) {
+	r.routes[path] = handler
+}
+
+// HandlePrefix registers a handler for paths starting with the provided
+// prefix. The rest of the path can be retrieved using pathParameter.
+func (r *router) HandlePrefix(prefix string, handler http.Handler) {
+	r.prefixes = append(r.prefixes, prefixRoute{prefix: prefix, handler: handler})
+}
+
+func (r *router) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	path := req.URL.EscapedPath()
+
+	if handler, ok := r.routes[path]; ok {
+		handler.ServeHTTP(w, req)
+		return
+	}
+
+	for _, route := range r.prefixes {
+		if strings.HasPrefix(path, route.prefix) {
+			route.handler.ServeHTTP(w, req)
+			return
+		}
+	}
+
+	writeError(w, http.StatusNotFound, errors.New("not found"))
+}
+
+// handlerFunc is an HTTP handler which can return an error. Errors are
+// converted to JSON responses with an appropriate status code.
+type handlerFunc func(w http.ResponseWriter, r *http.Request) error
+
+// methods routes requests to handlers based on the HTTP method.
+type methods map[string]handlerFunc
+
+func (m methods) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	handler, ok := m[r.Method]
+	if !ok {
+		writeError(w, http.StatusMethodNotAllowed, errors.New("method not allowed"))
+		return
+	}
+
+	if err := handler(w, r); err != nil {
+		writeError(w, statusCodeForError(err), err)
+	}
+}
+
+func (s *Server) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !s.validToken(tokenFromRequest(r)) {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			writeError(w, http.StatusUnauthorized, errors.New("invalid token"))
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (s *Server) validToken(token string) bool {
+	return subtle.ConstantTimeCompare([]byte(token), []byte(s.token)) == 1
+}
+
+func tokenFromRequest(r *http.Request) string {
+	if header := r.Header.Get("Authorization"); header != "" {
+		const prefix = "Bearer "
+		if len(header) > len(prefix) && strings.EqualFold(header[:len(prefix)], prefix) {
+			return header[len(prefix):]
+		}
+		return ""
+	}
+	return r.URL.Query().Get(accessTokenQueryParameter)
+}
+
+type badRequestError struct {
+	err error
+}
+
+func newBadRequestError(err error) error {
+	return badRequestError{err: err}
+}
+
+func (e badRequestError) Error() string {
+	return e.err.Error()
+}
+
+func (e badRequestError) Unwrap() error {
+	return e.err
+}
+
+func statusCodeForError(err error) int {
+	if errors.As(err, &badRequestError{}) {
+		return http.StatusBadRequest
+	}
+
+	if errors.Is(err, common.ErrMessageNotFound) || errors.Is(err, replication.ErrBlobNotFound) {
+		return http.StatusNotFound
+	}
+
+	return http.StatusInternalServerError
+}
+
+func readJSON(r *http.Request, v any) error {
+	decoder := jsoniter.NewDecoder(http.MaxBytesReader(nil, r.Body, maxJSONBodySizeInBytes))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(v); err != nil {
+		return newBadRequestError(errors.Wrap(err, "invalid request body"))
+	}
+	return nil
+}
+
+func writeJSON(w http.ResponseWriter, statusCode int, v any) error {
+	j, err := jsoniter.Marshal(v)
+	if err != nil {
+		return errors.Wrap(err, "json marshalling failed")
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	if _, err := w.Write(j); err != nil {
+		return errors.Wrap(err, "error writing the response")
+	}
+
+	return nil
+}
+
+func writeNoContent(w http.ResponseWriter) error {
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
+func writeError(w http.ResponseWriter, statusCode int, err error) {
+	_ = writeJSON(w, statusCode, errorTransport{Error: err.Error()})
+}
+
+// pathParameter returns the unescaped path segment following the prefix.
+// Escaped paths are used as identifiers such as message refs can contain
+// slashes which have to be escaped by the client.
+func pathParameter(r *http.Request, prefix string) (string, error) {
+	escaped := strings.TrimPrefix(r.URL.EscapedPath(), prefix)
+	if escaped == "" || strings.Contains(escaped, "/") {
+		return "", newBadRequestError(errors.New("invalid path"))
+	}
+
+	v, err := url.PathUnescape(escaped)
+	if err != nil {
+		return "", newBadRequestError(errors.Wrap(err, "error unescaping the path"))
+	}
+
+	return v, nil
+}
+
+type errorTransport struct {
+	Error string `json:"error"`
+}
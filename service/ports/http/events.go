@@ -0,0 +1,199 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/boreq/errors"
+	jsoniter "github.com/json-iterator/go"
+)
+
+const (
+	eventTypeMessage        = "message"
+	eventTypeBlobDownloaded = "blobDownloaded"
+	eventTypeStatus         = "status"
+
+	// eventTypesQueryParameter can be used to limit the stream to a
+	// comma-separated list of event types.
+	eventTypesQueryParameter = "types"
+
+	// eventBufferSize is the number of events which can be queued for a
+	// client. Clients which fall behind are disconnected as otherwise they
+	// would block the publishers.
+	eventBufferSize = 100
+
+	statusEventInterval = 10 * time.Second
+	keepAliveInterval   = 30 * time.Second
+)
+
+var eventTypes = []string{
+	eventTypeMessage,
+	eventTypeBlobDownloaded,
+	eventTypeStatus,
+}
+
+type event struct {
+	Type string
+	Data any
+}
+
+// events streams live data using server-sent events. A status event is sent
+// immediately and after that only when the status changes.
+func (s *Server) events(w http.ResponseWriter, r *http.Request) error {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		return errors.New("streaming is not supported")
+	}
+
+	types, err := requestedEventTypes(r)
+	if err != nil {
+		return errors.Wrap(err, "error getting event types")
+	}
+
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+
+	events := make(chan event, eventBufferSize)
+
+	if _, ok := types[eventTypeMessage]; ok {
+		go s.forwardMessages(ctx, cancel, events)
+	}
+
+	if _, ok := types[eventTypeBlobDownloaded]; ok {
+		go s.forwardBlobDownloaded(ctx, cancel, events)
+	}
+
+	if _, ok := types[eventTypeStatus]; ok {
+		go s.forwardStatus(ctx, cancel, events)
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	keepAlive := time.NewTicker(keepAliveInterval)
+	defer keepAlive.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-keepAlive.C:
+			if _, err := fmt.Fprint(w, ": keepalive\n\n"); err != nil {
+				return nil
+			}
+		case e := <-events:
+			if err := writeEvent(w, e); err != nil {
+				s.logger.Debug().WithError(err).Message("error writing an event")
+				return nil
+			}
+		}
+		flusher.Flush()
+	}
+}
+
+func (s *Server) forwardMessages(ctx context.Context, cancel context.CancelFunc, events chan<- event) {
+	for msg := range s.app.MessageSavedEvents.Handle(ctx) {
+		transport, err := newMessageTransport(msg)
+		if err != nil {
+			s.logger.Error().WithError(err).Message("error creating the transport")
+			continue
+		}
+
+		sendEvent(cancel, events, event{Type: eventTypeMessage, Data: transport})
+	}
+}
+
+func (s *Server) forwardBlobDownloaded(ctx context.Context, cancel context.CancelFunc, events chan<- event) {
+	for blob := range s.app.BlobDownloadedEvents.Handle(ctx) {
+		transport := blobDownloadedTransport{
+			Id:   blob.Id.String(),
+			Size: blob.Size.InBytes(),
+		}
+
+		sendEvent(cancel, events, event{Type: eventTypeBlobDownloaded, Data: transport})
+	}
+}
+
+func (s *Server) forwardStatus(ctx context.Context, cancel context.CancelFunc, events chan<- event) {
+	var previous *statusTransport
+
+	for {
+		result, err := s.app.Status.Handle()
+		if err != nil {
+			s.logger.Error().WithError(err).Message("error getting the status")
+		} else {
+			transport, err := newStatusTransport(result)
+			if err != nil {
+				s.logger.Error().WithError(err).Message("error creating the transport")
+			} else if previous == nil || !reflect.DeepEqual(*previous, transport) {
+				previous = &transport
+				sendEvent(cancel, events, event{Type: eventTypeStatus, Data: transport})
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(statusEventInterval):
+		}
+	}
+}
+
+// sendEvent never blocks. If the client can't keep up the stream is closed.
+func sendEvent(cancel context.CancelFunc, events chan<- event, e event) {
+	select {
+	case events <- e:
+	default:
+		cancel()
+	}
+}
+
+func writeEvent(w http.ResponseWriter, e event) error {
+	data, err := jsoniter.Marshal(e.Data)
+	if err != nil {
+		return errors.Wrap(err, "error marshaling the data")
+	}
+
+	if _, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", e.Type, data); err != nil {
+		return errors.Wrap(err, "error writing the event")
+	}
+
+	return nil
+}
+
+func requestedEventTypes(r *http.Request) (map[string]struct{}, error) {
+	result := make(map[string]struct{})
+
+	param := r.URL.Query().Get(eventTypesQueryParameter)
+	if param == "" {
+		for _, eventType := range eventTypes {
+			result[eventType] = struct{}{}
+		}
+		return result, nil
+	}
+
+	for _, eventType := range strings.Split(param, ",") {
+		if !isKnownEventType(eventType) {
+			return nil, newBadRequestError(fmt.Errorf("unknown event type '%s'", eventType))
+		}
+		result[eventType] = struct{}{}
+	}
+
+	return result, nil
+}
+
+func isKnownEventType(eventType string) bool {
+	for _, known := range eventTypes {
+		if known == eventType {
+			return true
+		}
+	}
+	return false
+}
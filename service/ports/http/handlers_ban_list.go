@@ -0,0 +1,72 @@
+package http
+
+import (
+	"encoding/hex"
+	"net/http"
+
+	"github.com/boreq/errors"
+	"github.com/planetary-social/scuttlego/service/app/commands"
+	"github.com/planetary-social/scuttlego/service/domain/bans"
+)
+
+const banListPrefix = "/api/ban-list/"
+
+func (s *Server) addToBanList(w http.ResponseWriter, r *http.Request) error {
+	var req banListRequestTransport
+	if err := readJSON(r, &req); err != nil {
+		return errors.Wrap(err, "error reading the request")
+	}
+
+	hash, err := newBanListHash(req.Hash)
+	if err != nil {
+		return errors.Wrap(err, "invalid hash")
+	}
+
+	cmd, err := commands.NewAddToBanList(hash)
+	if err != nil {
+		return errors.Wrap(err, "error creating the command")
+	}
+
+	if err := s.app.AddToBanList.Handle(cmd); err != nil {
+		return errors.Wrap(err, "error executing the command")
+	}
+
+	return writeNoContent(w)
+}
+
+func (s *Server) removeFromBanList(w http.ResponseWriter, r *http.Request) error {
+	param, err := pathParameter(r, banListPrefix)
+	if err != nil {
+		return errors.Wrap(err, "error getting the hash")
+	}
+
+	hash, err := newBanListHash(param)
+	if err != nil {
+		return errors.Wrap(err, "invalid hash")
+	}
+
+	cmd, err := commands.NewRemoveFromBanList(hash)
+	if err != nil {
+		return errors.Wrap(err, "error creating the command")
+	}
+
+	if err := s.app.RemoveFromBanList.Handle(cmd); err != nil {
+		return errors.Wrap(err, "error executing the command")
+	}
+
+	return writeNoContent(w)
+}
+
+func newBanListHash(s string) (bans.Hash, error) {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		return bans.Hash{}, newBadRequestError(errors.Wrap(err, "error decoding hex"))
+	}
+
+	hash, err := bans.NewHash(b)
+	if err != nil {
+		return bans.Hash{}, newBadRequestError(errors.Wrap(err, "error creating the hash"))
+	}
+
+	return hash, nil
+}
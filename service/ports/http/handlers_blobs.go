@@ -0,0 +1,76 @@
+package http
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/boreq/errors"
+	"github.com/planetary-social/scuttlego/service/app/commands"
+	"github.com/planetary-social/scuttlego/service/app/queries"
+	"github.com/planetary-social/scuttlego/service/domain/blobs"
+	"github.com/planetary-social/scuttlego/service/domain/refs"
+)
+
+const blobsPrefix = "/api/blobs/"
+
+// addBlob stores the request body as a blob.
+func (s *Server) addBlob(w http.ResponseWriter, r *http.Request) error {
+	maxSize := blobs.MaxBlobSize().InBytes()
+
+	data, err := io.ReadAll(io.LimitReader(r.Body, maxSize+1))
+	if err != nil {
+		return newBadRequestError(errors.Wrap(err, "error reading the body"))
+	}
+
+	if len(data) == 0 {
+		return newBadRequestError(errors.New("empty blob"))
+	}
+
+	if int64(len(data)) > maxSize {
+		return newBadRequestError(errors.New("blob is too large"))
+	}
+
+	id, err := s.app.CreateBlob.Handle(commands.CreateBlob{Reader: bytes.NewReader(data)})
+	if err != nil {
+		return errors.Wrap(err, "error executing the command")
+	}
+
+	return writeJSON(w, http.StatusCreated, idTransport{Id: id.String()})
+}
+
+func (s *Server) getBlob(w http.ResponseWriter, r *http.Request) error {
+	param, err := pathParameter(r, blobsPrefix)
+	if err != nil {
+		return errors.Wrap(err, "error getting the id")
+	}
+
+	id, err := refs.NewBlob(param)
+	if err != nil {
+		return newBadRequestError(errors.Wrap(err, "invalid blob id"))
+	}
+
+	// Setting max forces the handler to check if the blob exists before
+	// opening it.
+	max := blobs.MaxBlobSize()
+
+	rc, err := s.app.GetBlob.Handle(queries.GetBlob{Id: id, Max: &max})
+	if err != nil {
+		return errors.Wrap(err, "error executing the query")
+	}
+	defer rc.Close()
+
+	data, err := io.ReadAll(rc)
+	if err != nil {
+		return errors.Wrap(err, "error reading the blob")
+	}
+
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.Header().Set("Content-Length", strconv.Itoa(len(data)))
+	if _, err := w.Write(data); err != nil {
+		return errors.Wrap(err, "error writing the blob")
+	}
+
+	return nil
+}
@@ -0,0 +1,192 @@
+package http
+
+import (
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/boreq/errors"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/planetary-social/scuttlego/service/app/commands"
+	"github.com/planetary-social/scuttlego/service/app/common"
+	"github.com/planetary-social/scuttlego/service/app/queries"
+	"github.com/planetary-social/scuttlego/service/domain/refs"
+)
+
+const (
+	messagesPrefix = "/api/messages/"
+
+	defaultReceiveLogLimit = 100
+	maxReceiveLogLimit     = 1000
+)
+
+// publishMessage publishes a message with the content provided in the request
+// body. The content must be a JSON object.
+func (s *Server) publishMessage(w http.ResponseWriter, r *http.Request) error {
+	content, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxJSONBodySizeInBytes))
+	if err != nil {
+		return newBadRequestError(errors.Wrap(err, "error reading the body"))
+	}
+
+	var object map[string]jsoniter.RawMessage
+	if err := jsoniter.Unmarshal(content, &object); err != nil {
+		return newBadRequestError(errors.Wrap(err, "content must be a json object"))
+	}
+
+	cmd, err := commands.NewPublishRaw(content)
+	if err != nil {
+		return newBadRequestError(errors.Wrap(err, "error creating the command"))
+	}
+
+	id, err := s.app.PublishRaw.Handle(cmd)
+	if err != nil {
+		return errors.Wrap(err, "error executing the command")
+	}
+
+	return writeJSON(w, http.StatusCreated, idTransport{Id: id.String()})
+}
+
+func (s *Server) getMessage(w http.ResponseWriter, r *http.Request) error {
+	param, err := pathParameter(r, messagesPrefix)
+	if err != nil {
+		return errors.Wrap(err, "error getting the id")
+	}
+
+	id, err := refs.NewMessage(param)
+	if err != nil {
+		return newBadRequestError(errors.Wrap(err, "invalid message id"))
+	}
+
+	query, err := queries.NewGetMessage(id)
+	if err != nil {
+		return errors.Wrap(err, "error creating the query")
+	}
+
+	msg, err := s.app.GetMessage.Handle(query)
+	if err != nil {
+		return errors.Wrap(err, "error executing the query")
+	}
+
+	transport, err := newMessageTransport(msg)
+	if err != nil {
+		return errors.Wrap(err, "error creating the transport")
+	}
+
+	return writeJSON(w, http.StatusOK, transport)
+}
+
+// getReceiveLog returns a page of the receive log. Clients page through the
+// log by passing the last received sequence plus one as the start of the next
+// request. If reverse is set the newest messages are returned first and the
+// last received sequence should be passed as the end of the next request
+// instead.
+func (s *Server) getReceiveLog(w http.ResponseWriter, r *http.Request) error {
+	start, err := intQueryParameter(r, "start", 0)
+	if err != nil {
+		return errors.Wrap(err, "invalid start")
+	}
+
+	limit, err := intQueryParameter(r, "limit", defaultReceiveLogLimit)
+	if err != nil {
+		return errors.Wrap(err, "invalid limit")
+	}
+
+	if limit > maxReceiveLogLimit {
+		return newBadRequestError(errors.New("limit is too large"))
+	}
+
+	reverse, err := boolQueryParameter(r, "reverse", false)
+	if err != nil {
+		return errors.Wrap(err, "invalid reverse")
+	}
+
+	startSeq, err := common.NewReceiveLogSequence(start)
+	if err != nil {
+		return newBadRequestError(errors.Wrap(err, "invalid start"))
+	}
+
+	var endSeq *common.ReceiveLogSequence
+	if r.URL.Query().Get("end") != "" {
+		end, err := intQueryParameter(r, "end", 0)
+		if err != nil {
+			return errors.Wrap(err, "invalid end")
+		}
+
+		tmp, err := common.NewReceiveLogSequence(end)
+		if err != nil {
+			return newBadRequestError(errors.Wrap(err, "invalid end"))
+		}
+		endSeq = &tmp
+	}
+
+	query, err := queries.NewReceiveLogInRange(startSeq, endSeq, reverse, queries.TimestampRange{}, limit)
+	if err != nil {
+		return newBadRequestError(errors.Wrap(err, "error creating the query"))
+	}
+
+	logMessages, err := s.app.ReceiveLog.Handle(query)
+	if err != nil {
+		return errors.Wrap(err, "error executing the query")
+	}
+
+	result := make([]receiveLogEntryTransport, 0, len(logMessages))
+	for _, logMessage := range logMessages {
+		transport, err := newMessageTransport(logMessage.Message)
+		if err != nil {
+			return errors.Wrap(err, "error creating the transport")
+		}
+
+		result = append(result, receiveLogEntryTransport{
+			ReceiveLogSequence: logMessage.Sequence.Int(),
+			Message:            transport,
+		})
+	}
+
+	return writeJSON(w, http.StatusOK, result)
+}
+
+func (s *Server) follow(w http.ResponseWriter, r *http.Request) error {
+	var req followRequestTransport
+	if err := readJSON(r, &req); err != nil {
+		return errors.Wrap(err, "error reading the request")
+	}
+
+	target, err := refs.NewIdentity(req.Feed)
+	if err != nil {
+		return newBadRequestError(errors.Wrap(err, "invalid feed"))
+	}
+
+	if err := s.app.Follow.Handle(commands.Follow{Target: target}); err != nil {
+		return errors.Wrap(err, "error executing the command")
+	}
+
+	return writeNoContent(w)
+}
+
+func boolQueryParameter(r *http.Request, name string, defaultValue bool) (bool, error) {
+	s := r.URL.Query().Get(name)
+	if s == "" {
+		return defaultValue, nil
+	}
+
+	v, err := strconv.ParseBool(s)
+	if err != nil {
+		return false, newBadRequestError(errors.Wrapf(err, "parameter '%s' must be a boolean", name))
+	}
+
+	return v, nil
+}
+
+func intQueryParameter(r *http.Request, name string, defaultValue int) (int, error) {
+	s := r.URL.Query().Get(name)
+	if s == "" {
+		return defaultValue, nil
+	}
+
+	v, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, newBadRequestError(errors.Wrapf(err, "parameter '%s' must be an integer", name))
+	}
+
+	return v, nil
+}
@@ -0,0 +1,66 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/boreq/errors"
+	"github.com/planetary-social/scuttlego/service/app/commands"
+	"github.com/planetary-social/scuttlego/service/domain/invites"
+	"github.com/planetary-social/scuttlego/service/domain/network"
+)
+
+func (s *Server) getStatus(w http.ResponseWriter, r *http.Request) error {
+	result, err := s.app.Status.Handle()
+	if err != nil {
+		return errors.Wrap(err, "error executing the query")
+	}
+
+	transport, err := newStatusTransport(result)
+	if err != nil {
+		return errors.Wrap(err, "error creating the transport")
+	}
+
+	return writeJSON(w, http.StatusOK, transport)
+}
+
+// connect connects to a peer with the provided multiserver address.
+func (s *Server) connect(w http.ResponseWriter, r *http.Request) error {
+	var req connectRequestTransport
+	if err := readJSON(r, &req); err != nil {
+		return errors.Wrap(err, "error reading the request")
+	}
+
+	address, err := network.NewMultiserverAddress(req.Address)
+	if err != nil {
+		return newBadRequestError(errors.Wrap(err, "invalid address"))
+	}
+
+	cmd := commands.Connect{
+		Remote:  address.Remote(),
+		Address: address.Address(),
+	}
+
+	if err := s.app.Connect.Handle(r.Context(), cmd); err != nil {
+		return errors.Wrap(err, "error executing the command")
+	}
+
+	return writeNoContent(w)
+}
+
+func (s *Server) redeemInvite(w http.ResponseWriter, r *http.Request) error {
+	var req redeemInviteRequestTransport
+	if err := readJSON(r, &req); err != nil {
+		return errors.Wrap(err, "error reading the request")
+	}
+
+	invite, err := invites.NewInviteFromString(req.Invite)
+	if err != nil {
+		return newBadRequestError(errors.Wrap(err, "invalid invite"))
+	}
+
+	if err := s.app.RedeemInvite.Handle(r.Context(), commands.RedeemInvite{Invite: invite}); err != nil {
+		return errors.Wrap(err, "error executing the command")
+	}
+
+	return writeNoContent(w)
+}
@@ -0,0 +1,114 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/boreq/errors"
+	"github.com/planetary-social/scuttlego/service/app/commands"
+	"github.com/planetary-social/scuttlego/service/app/queries"
+	"github.com/planetary-social/scuttlego/service/domain/network"
+	"github.com/planetary-social/scuttlego/service/domain/refs"
+	"github.com/planetary-social/scuttlego/service/domain/rooms/aliases"
+)
+
+const (
+	roomAliasesPrefix = "/api/rooms/aliases/"
+
+	// roomQueryParameter contains the multiserver address of a room.
+	roomQueryParameter = "room"
+)
+
+func (s *Server) listAliases(w http.ResponseWriter, r *http.Request) error {
+	room, address, err := newRoom(r.URL.Query().Get(roomQueryParameter))
+	if err != nil {
+		return errors.Wrap(err, "invalid room")
+	}
+
+	query, err := queries.NewRoomsListAliases(room, address)
+	if err != nil {
+		return errors.Wrap(err, "error creating the query")
+	}
+
+	result, err := s.app.RoomsListAliases.Handle(r.Context(), query)
+	if err != nil {
+		return errors.Wrap(err, "error executing the query")
+	}
+
+	aliasesAsStrings := make([]string, 0, len(result))
+	for _, alias := range result {
+		aliasesAsStrings = append(aliasesAsStrings, alias.String())
+	}
+
+	return writeJSON(w, http.StatusOK, aliasesAsStrings)
+}
+
+func (s *Server) registerAlias(w http.ResponseWriter, r *http.Request) error {
+	var req registerAliasRequestTransport
+	if err := readJSON(r, &req); err != nil {
+		return errors.Wrap(err, "error reading the request")
+	}
+
+	room, address, err := newRoom(req.Room)
+	if err != nil {
+		return errors.Wrap(err, "invalid room")
+	}
+
+	alias, err := aliases.NewAlias(req.Alias)
+	if err != nil {
+		return newBadRequestError(errors.Wrap(err, "invalid alias"))
+	}
+
+	cmd, err := commands.NewRoomsAliasRegister(room, address, alias)
+	if err != nil {
+		return errors.Wrap(err, "error creating the command")
+	}
+
+	url, err := s.app.RoomsAliasRegister.Handle(r.Context(), cmd)
+	if err != nil {
+		return errors.Wrap(err, "error executing the command")
+	}
+
+	return writeJSON(w, http.StatusCreated, registerAliasResponseTransport{URL: url.String()})
+}
+
+func (s *Server) revokeAlias(w http.ResponseWriter, r *http.Request) error {
+	param, err := pathParameter(r, roomAliasesPrefix)
+	if err != nil {
+		return errors.Wrap(err, "error getting the alias")
+	}
+
+	alias, err := aliases.NewAlias(param)
+	if err != nil {
+		return newBadRequestError(errors.Wrap(err, "invalid alias"))
+	}
+
+	room, address, err := newRoom(r.URL.Query().Get(roomQueryParameter))
+	if err != nil {
+		return errors.Wrap(err, "invalid room")
+	}
+
+	cmd, err := commands.NewRoomsAliasRevoke(room, address, alias)
+	if err != nil {
+		return errors.Wrap(err, "error creating the command")
+	}
+
+	if err := s.app.RoomsAliasRevoke.Handle(r.Context(), cmd); err != nil {
+		return errors.Wrap(err, "error executing the command")
+	}
+
+	return writeNoContent(w)
+}
+
+func newRoom(s string) (refs.Identity, network.Address, error) {
+	multiserverAddress, err := network.NewMultiserverAddress(s)
+	if err != nil {
+		return refs.Identity{}, network.Address{}, newBadRequestError(errors.Wrap(err, "invalid multiserver address"))
+	}
+
+	room, err := refs.NewIdentityFromPublic(multiserverAddress.Remote())
+	if err != nil {
+		return refs.Identity{}, network.Address{}, errors.Wrap(err, "error creating the room ref")
+	}
+
+	return room, multiserverAddress.Address(), nil
+}
@@ -0,0 +1,75 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+
+	"github.com/boreq/errors"
+	"github.com/planetary-social/scuttlego/logging"
+)
+
+// metricsContentType is the content type of the Prometheus text exposition
+// format.
+const metricsContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+type Metrics interface {
+	// WriteTo writes metrics in the Prometheus text exposition format.
+	WriteTo(w io.Writer) (int64, error)
+}
+
+// MetricsServer exposes metrics which can be scraped by Prometheus. Unlike
+// Server it doesn't require authentication so that it can be scraped without
+// additional configuration. It should not be exposed publicly.
+type MetricsServer struct {
+	address string
+	metrics Metrics
+	logger  logging.Logger
+}
+
+// NewMetricsServer creates a new server which listens on the provided address.
+// The address should be formatted in the way which can be handled by the net
+// package e.g. ":9090".
+func NewMetricsServer(
+	address string,
+	metrics Metrics,
+	logger logging.Logger,
+) *MetricsServer {
+	return &MetricsServer{
+		address: address,
+		metrics: metrics,
+		logger:  logger.New("metrics_server"),
+	}
+}
+
+// ListenAndServe starts listening and keeps serving requests until the
+// context is closed.
+func (s *MetricsServer) ListenAndServe(ctx context.Context) error {
+	return listenAndServe(ctx, s.address, s.Handler(), s.logger)
+}
+
+// Handler returns an HTTP handler serving the metrics.
+func (s *MetricsServer) Handler() http.Handler {
+	r := newRouter()
+
+	r.Handle("/metrics", methods{
+		http.MethodGet: s.getMetrics,
+	})
+
+	return r
+}
+
+func (s *MetricsServer) getMetrics(w http.ResponseWriter, r *http.Request) error {
+	buf := &bytes.Buffer{}
+	if _, err := s.metrics.WriteTo(buf); err != nil {
+		return errors.Wrap(err, "error writing metrics")
+	}
+
+	w.Header().Set("Content-Type", metricsContentType)
+	if _, err := buf.WriteTo(w); err != nil {
+		s.logger.Debug().WithError(err).Message("error writing the response")
+	}
+
+	return nil
+}
@@ -0,0 +1,58 @@
+package http_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/boreq/errors"
+	"github.com/planetary-social/scuttlego/logging"
+	httpport "github.com/planetary-social/scuttlego/service/ports/http"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetricsServer_GetMetrics(t *testing.T) {
+	metrics := newMetricsMock()
+	metrics.Data = "some_metric 1\n"
+
+	s := httpport.NewMetricsServer(":9090", metrics, logging.NewDevNullLogger())
+
+	r := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+	w := httptest.NewRecorder()
+	s.Handler().ServeHTTP(w, r)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, "text/plain; version=0.0.4; charset=utf-8", w.Header().Get("Content-Type"))
+	require.Equal(t, metrics.Data, w.Body.String())
+}
+
+func TestMetricsServer_GetMetricsReturnsAnErrorIfMetricsCanNotBeWritten(t *testing.T) {
+	metrics := newMetricsMock()
+	metrics.Err = errors.New("some error")
+
+	s := httpport.NewMetricsServer(":9090", metrics, logging.NewDevNullLogger())
+
+	r := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+	w := httptest.NewRecorder()
+	s.Handler().ServeHTTP(w, r)
+
+	require.Equal(t, http.StatusInternalServerError, w.Code)
+}
+
+type metricsMock struct {
+	Data string
+	Err  error
+}
+
+func newMetricsMock() *metricsMock {
+	return &metricsMock{}
+}
+
+func (m *metricsMock) WriteTo(w io.Writer) (int64, error) {
+	if m.Err != nil {
+		return 0, m.Err
+	}
+	n, err := io.WriteString(w, m.Data)
+	return int64(n), err
+}
@@ -0,0 +1,224 @@
+// Package http exposes the application layer over a JSON HTTP API which can be
+// used by clients which can't speak muxrpc e.g. web frontends.
+package http
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/boreq/errors"
+	"github.com/planetary-social/scuttlego/logging"
+	"github.com/planetary-social/scuttlego/service/app/commands"
+	"github.com/planetary-social/scuttlego/service/app/queries"
+	"github.com/planetary-social/scuttlego/service/domain/feeds/message"
+	"github.com/planetary-social/scuttlego/service/domain/refs"
+	"github.com/planetary-social/scuttlego/service/domain/rooms/aliases"
+)
+
+const readHeaderTimeout = 15 * time.Second
+
+type PublishRawCommandHandler interface {
+	Handle(cmd commands.PublishRaw) (refs.Message, error)
+}
+
+type FollowCommandHandler interface {
+	Handle(cmd commands.Follow) error
+}
+
+type CreateBlobCommandHandler interface {
+	Handle(cmd commands.CreateBlob) (refs.Blob, error)
+}
+
+type AddToBanListCommandHandler interface {
+	Handle(cmd commands.AddToBanList) error
+}
+
+type RemoveFromBanListCommandHandler interface {
+	Handle(cmd commands.RemoveFromBanList) error
+}
+
+type RedeemInviteCommandHandler interface {
+	Handle(ctx context.Context, cmd commands.RedeemInvite) error
+}
+
+type ConnectCommandHandler interface {
+	Handle(ctx context.Context, cmd commands.Connect) error
+}
+
+type RoomsAliasRegisterCommandHandler interface {
+	Handle(ctx context.Context, cmd commands.RoomsAliasRegister) (aliases.AliasEndpointURL, error)
+}
+
+type RoomsAliasRevokeCommandHandler interface {
+	Handle(ctx context.Context, cmd commands.RoomsAliasRevoke) error
+}
+
+type StatusQueryHandler interface {
+	Handle() (queries.StatusResult, error)
+}
+
+type GetMessageQueryHandler interface {
+	Handle(query queries.GetMessage) (message.Message, error)
+}
+
+type ReceiveLogQueryHandler interface {
+	Handle(query queries.ReceiveLog) ([]queries.LogMessage, error)
+}
+
+type GetBlobQueryHandler interface {
+	Handle(query queries.GetBlob) (io.ReadCloser, error)
+}
+
+type RoomsListAliasesQueryHandler interface {
+	Handle(ctx context.Context, query queries.RoomsListAliases) ([]aliases.Alias, error)
+}
+
+type MessageSavedEventsQueryHandler interface {
+	Handle(ctx context.Context) <-chan message.Message
+}
+
+type BlobDownloadedEventsQueryHandler interface {
+	Handle(ctx context.Context) <-chan queries.BlobDownloaded
+}
+
+// Application contains the parts of the application layer which are exposed
+// by the server.
+type Application struct {
+	PublishRaw         PublishRawCommandHandler
+	Follow             FollowCommandHandler
+	CreateBlob         CreateBlobCommandHandler
+	AddToBanList       AddToBanListCommandHandler
+	RemoveFromBanList  RemoveFromBanListCommandHandler
+	RedeemInvite       RedeemInviteCommandHandler
+	Connect            ConnectCommandHandler
+	RoomsAliasRegister RoomsAliasRegisterCommandHandler
+	RoomsAliasRevoke   RoomsAliasRevokeCommandHandler
+
+	Status               StatusQueryHandler
+	GetMessage           GetMessageQueryHandler
+	ReceiveLog           ReceiveLogQueryHandler
+	GetBlob              GetBlobQueryHandler
+	RoomsListAliases     RoomsListAliasesQueryHandler
+	MessageSavedEvents   MessageSavedEventsQueryHandler
+	BlobDownloadedEvents BlobDownloadedEventsQueryHandler
+}
+
+// Server serves the HTTP API. All requests have to be authenticated using a
+// bearer token.
+type Server struct {
+	address string
+	token   string
+	app     Application
+	logger  logging.Logger
+}
+
+// NewServer creates a new server which listens on the provided address. The
+// address should be formatted in the way which can be handled by the net
+// package e.g. ":8080".
+func NewServer(
+	address string,
+	token string,
+	app Application,
+	logger logging.Logger,
+) (*Server, error) {
+	if token == "" {
+		return nil, errors.New("token can't be empty")
+	}
+
+	return &Server{
+		address: address,
+		token:   token,
+		app:     app,
+		logger:  logger.New("http_server"),
+	}, nil
+}
+
+// ListenAndServe starts listening and keeps serving requests until the
+// context is closed.
+func (s *Server) ListenAndServe(ctx context.Context) error {
+	return listenAndServe(ctx, s.address, s.Handler(), s.logger)
+}
+
+// Handler returns an HTTP handler serving the API.
+func (s *Server) Handler() http.Handler {
+	r := newRouter()
+
+	r.Handle("/api/status", methods{
+		http.MethodGet: s.getStatus,
+	})
+	r.Handle("/api/connect", methods{
+		http.MethodPost: s.connect,
+	})
+	r.Handle("/api/invites/redeem", methods{
+		http.MethodPost: s.redeemInvite,
+	})
+	r.Handle("/api/messages", methods{
+		http.MethodPost: s.publishMessage,
+	})
+	r.HandlePrefix(messagesPrefix, methods{
+		http.MethodGet: s.getMessage,
+	})
+	r.Handle("/api/receive-log", methods{
+		http.MethodGet: s.getReceiveLog,
+	})
+	r.Handle("/api/follow", methods{
+		http.MethodPost: s.follow,
+	})
+	r.Handle("/api/blobs", methods{
+		http.MethodPost: s.addBlob,
+	})
+	r.HandlePrefix(blobsPrefix, methods{
+		http.MethodGet: s.getBlob,
+	})
+	r.Handle("/api/ban-list", methods{
+		http.MethodPost: s.addToBanList,
+	})
+	r.HandlePrefix(banListPrefix, methods{
+		http.MethodDelete: s.removeFromBanList,
+	})
+	r.Handle("/api/rooms/aliases", methods{
+		http.MethodGet:  s.listAliases,
+		http.MethodPost: s.registerAlias,
+	})
+	r.HandlePrefix(roomAliasesPrefix, methods{
+		http.MethodDelete: s.revokeAlias,
+	})
+	r.Handle("/api/events", methods{
+		http.MethodGet: s.events,
+	})
+
+	return s.authenticate(r)
+}
+
+func listenAndServe(ctx context.Context, address string, handler http.Handler, logger logging.Logger) error {
+	var lc net.ListenConfig
+	listener, err := lc.Listen(ctx, "tcp", address)
+	if err != nil {
+		return errors.Wrap(err, "could not start a listener")
+	}
+
+	// Write timeouts can't be set as event streams stay open indefinitely.
+	server := &http.Server{
+		Handler:           handler,
+		ReadHeaderTimeout: readHeaderTimeout,
+		BaseContext: func(net.Listener) context.Context {
+			return ctx
+		},
+	}
+
+	go func() {
+		<-ctx.Done()
+		if err := server.Close(); err != nil {
+			logger.Error().WithError(err).Message("error closing the server")
+		}
+	}()
+
+	if err := server.Serve(listener); err != nil {
+		return errors.Wrap(err, "could not serve")
+	}
+
+	return nil
+}
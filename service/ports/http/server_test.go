@@ -0,0 +1,336 @@
+package http_test
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/planetary-social/scuttlego/internal/fixtures"
+	"github.com/planetary-social/scuttlego/logging"
+	"github.com/planetary-social/scuttlego/service/app/commands"
+	"github.com/planetary-social/scuttlego/service/app/common"
+	"github.com/planetary-social/scuttlego/service/app/queries"
+	"github.com/planetary-social/scuttlego/service/domain/feeds/message"
+	"github.com/planetary-social/scuttlego/service/domain/refs"
+	httpport "github.com/planetary-social/scuttlego/service/ports/http"
+	"github.com/stretchr/testify/require"
+)
+
+const testToken = "some-token"
+
+func TestNewServer_TokenIsRequired(t *testing.T) {
+	_, err := httpport.NewServer(":8080", "", httpport.Application{}, logging.NewDevNullLogger())
+	require.EqualError(t, err, "token can't be empty")
+}
+
+func TestServer_Authentication(t *testing.T) {
+	ts := newTestServer(t)
+
+	testCases := []struct {
+		Name               string
+		Modify             func(r *http.Request)
+		ExpectedStatusCode int
+	}{
+		{
+			Name:               "no_token",
+			Modify:             func(r *http.Request) {},
+			ExpectedStatusCode: http.StatusUnauthorized,
+		},
+		{
+			Name: "invalid_token",
+			Modify: func(r *http.Request) {
+				r.Header.Set("Authorization", "Bearer invalid")
+			},
+			ExpectedStatusCode: http.StatusUnauthorized,
+		},
+		{
+			Name: "header",
+			Modify: func(r *http.Request) {
+				r.Header.Set("Authorization", "Bearer "+testToken)
+			},
+			ExpectedStatusCode: http.StatusOK,
+		},
+		{
+			Name: "query_parameter",
+			Modify: func(r *http.Request) {
+				r.URL.RawQuery = url.Values{"access_token": []string{testToken}}.Encode()
+			},
+			ExpectedStatusCode: http.StatusOK,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/api/status", nil)
+			testCase.Modify(r)
+
+			w := httptest.NewRecorder()
+			ts.Server.Handler().ServeHTTP(w, r)
+			require.Equal(t, testCase.ExpectedStatusCode, w.Code)
+		})
+	}
+}
+
+func TestServer_PublishMessage(t *testing.T) {
+	testCases := []struct {
+		Name               string
+		Body               string
+		ExpectedStatusCode int
+	}{
+		{
+			Name:               "object",
+			Body:               `{"type":"post","text":"hello"}`,
+			ExpectedStatusCode: http.StatusCreated,
+		},
+		{
+			Name:               "not_an_object",
+			Body:               `"hello"`,
+			ExpectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			Name:               "invalid_json",
+			Body:               `{`,
+			ExpectedStatusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			ts := newTestServer(t)
+
+			w := ts.Do(http.MethodPost, "/api/messages", testCase.Body)
+			require.Equal(t, testCase.ExpectedStatusCode, w.Code)
+
+			if testCase.ExpectedStatusCode == http.StatusCreated {
+				require.JSONEq(t, `{"id":"`+ts.PublishRaw.id.String()+`"}`, w.Body.String())
+				expectedCmd, err := commands.NewPublishRaw([]byte(testCase.Body))
+				require.NoError(t, err)
+				require.Equal(t, []commands.PublishRaw{expectedCmd}, ts.PublishRaw.calls)
+			} else {
+				require.Empty(t, ts.PublishRaw.calls)
+			}
+		})
+	}
+}
+
+func TestServer_GetMessage(t *testing.T) {
+	ts := newTestServer(t)
+
+	msg := fixtures.SomeMessage(message.NewFirstSequence(), fixtures.SomeRefFeed())
+	ts.GetMessage.messages[msg.Id().String()] = msg
+
+	w := ts.Do(http.MethodGet, "/api/messages/"+url.PathEscape(msg.Id().String()), "")
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Contains(t, w.Body.String(), `"sequence":1`)
+	require.Contains(t, w.Body.String(), msg.Feed().String())
+
+	w = ts.Do(http.MethodGet, "/api/messages/"+url.PathEscape(fixtures.SomeRefMessage().String()), "")
+	require.Equal(t, http.StatusNotFound, w.Code)
+
+	w = ts.Do(http.MethodGet, "/api/messages/invalid", "")
+	require.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestServer_GetReceiveLog(t *testing.T) {
+	testCases := []struct {
+		Name               string
+		Target             string
+		ExpectedStatusCode int
+		ExpectedQuery      func() (queries.ReceiveLog, error)
+	}{
+		{
+			Name:               "defaults",
+			Target:             "/api/receive-log",
+			ExpectedStatusCode: http.StatusOK,
+			ExpectedQuery: func() (queries.ReceiveLog, error) {
+				return queries.NewReceiveLog(common.MustNewReceiveLogSequence(0), 100)
+			},
+		},
+		{
+			Name:               "reverse_with_end",
+			Target:             "/api/receive-log?reverse=true&end=10&limit=5",
+			ExpectedStatusCode: http.StatusOK,
+			ExpectedQuery: func() (queries.ReceiveLog, error) {
+				end := common.MustNewReceiveLogSequence(10)
+				return queries.NewReceiveLogInRange(common.MustNewReceiveLogSequence(0), &end, true, queries.TimestampRange{}, 5)
+			},
+		},
+		{
+			Name:               "invalid_reverse",
+			Target:             "/api/receive-log?reverse=maybe",
+			ExpectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			Name:               "invalid_end",
+			Target:             "/api/receive-log?end=-1",
+			ExpectedStatusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			ts := newTestServer(t)
+
+			w := ts.Do(http.MethodGet, testCase.Target, "")
+			require.Equal(t, testCase.ExpectedStatusCode, w.Code)
+
+			if testCase.ExpectedQuery != nil {
+				expectedQuery, err := testCase.ExpectedQuery()
+				require.NoError(t, err)
+				require.Equal(t, []queries.ReceiveLog{expectedQuery}, ts.ReceiveLog.calls)
+			} else {
+				require.Empty(t, ts.ReceiveLog.calls)
+			}
+		})
+	}
+}
+
+func TestServer_UnknownRoutesAndMethods(t *testing.T) {
+	ts := newTestServer(t)
+
+	w := ts.Do(http.MethodGet, "/api/unknown", "")
+	require.Equal(t, http.StatusNotFound, w.Code)
+
+	w = ts.Do(http.MethodDelete, "/api/status", "")
+	require.Equal(t, http.StatusMethodNotAllowed, w.Code)
+}
+
+func TestServer_EventsStreamsSavedMessages(t *testing.T) {
+	ts := newTestServer(t)
+
+	server := httptest.NewServer(ts.Server.Handler())
+	t.Cleanup(server.Close)
+
+	ctx, cancel := context.WithCancel(fixtures.TestContext(t))
+	defer cancel()
+
+	r, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/api/events?types=message", nil)
+	require.NoError(t, err)
+	r.Header.Set("Authorization", "Bearer "+testToken)
+
+	resp, err := http.DefaultClient.Do(r)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	msg := fixtures.SomeMessage(message.NewFirstSequence(), fixtures.SomeRefFeed())
+
+	select {
+	case ts.MessageSavedEvents.ch <- msg:
+	case <-ctx.Done():
+		t.Fatal("timeout")
+	}
+
+	reader := bufio.NewReader(resp.Body)
+
+	line, err := reader.ReadString('\n')
+	require.NoError(t, err)
+	require.Equal(t, "event: message\n", line)
+
+	line, err = reader.ReadString('\n')
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(line, "data: "))
+	require.Contains(t, line, msg.Id().String())
+}
+
+type testServer struct {
+	Server *httpport.Server
+
+	PublishRaw         *publishRawCommandHandlerMock
+	GetMessage         *getMessageQueryHandlerMock
+	ReceiveLog         *receiveLogQueryHandlerMock
+	MessageSavedEvents *messageSavedEventsQueryHandlerMock
+}
+
+func newTestServer(t *testing.T) testServer {
+	publishRaw := &publishRawCommandHandlerMock{id: fixtures.SomeRefMessage()}
+	getMessage := &getMessageQueryHandlerMock{messages: make(map[string]message.Message)}
+	receiveLog := &receiveLogQueryHandlerMock{}
+	messageSavedEvents := &messageSavedEventsQueryHandlerMock{ch: make(chan message.Message)}
+
+	app := httpport.Application{
+		PublishRaw:         publishRaw,
+		Status:             statusQueryHandlerMock{},
+		GetMessage:         getMessage,
+		ReceiveLog:         receiveLog,
+		MessageSavedEvents: messageSavedEvents,
+	}
+
+	server, err := httpport.NewServer(":0", testToken, app, logging.NewDevNullLogger())
+	require.NoError(t, err)
+
+	return testServer{
+		Server:             server,
+		PublishRaw:         publishRaw,
+		GetMessage:         getMessage,
+		ReceiveLog:         receiveLog,
+		MessageSavedEvents: messageSavedEvents,
+	}
+}
+
+func (ts testServer) Do(method, target, body string) *httptest.ResponseRecorder {
+	var reader io.Reader
+	if body != "" {
+		reader = strings.NewReader(body)
+	}
+
+	r := httptest.NewRequest(method, target, reader)
+	r.Header.Set("Authorization", "Bearer "+testToken)
+
+	w := httptest.NewRecorder()
+	ts.Server.Handler().ServeHTTP(w, r)
+	return w
+}
+
+type publishRawCommandHandlerMock struct {
+	id    refs.Message
+	calls []commands.PublishRaw
+}
+
+func (p *publishRawCommandHandlerMock) Handle(cmd commands.PublishRaw) (refs.Message, error) {
+	p.calls = append(p.calls, cmd)
+	return p.id, nil
+}
+
+type statusQueryHandlerMock struct {
+}
+
+func (s statusQueryHandlerMock) Handle() (queries.StatusResult, error) {
+	return queries.StatusResult{}, nil
+}
+
+type getMessageQueryHandlerMock struct {
+	messages map[string]message.Message
+}
+
+func (g *getMessageQueryHandlerMock) Handle(query queries.GetMessage) (message.Message, error) {
+	msg, ok := g.messages[query.Id().String()]
+	if !ok {
+		return message.Message{}, common.ErrMessageNotFound
+	}
+	return msg, nil
+}
+
+type receiveLogQueryHandlerMock struct {
+	calls []queries.ReceiveLog
+}
+
+func (r *receiveLogQueryHandlerMock) Handle(query queries.ReceiveLog) ([]queries.LogMessage, error) {
+	r.calls = append(r.calls, query)
+	return nil, nil
+}
+
+type messageSavedEventsQueryHandlerMock struct {
+	ch chan message.Message
+}
+
+func (m *messageSavedEventsQueryHandlerMock) Handle(ctx context.Context) <-chan message.Message {
+	return m.ch
+}
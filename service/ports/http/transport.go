@@ -0,0 +1,118 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/boreq/errors"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/planetary-social/scuttlego/service/app/queries"
+	"github.com/planetary-social/scuttlego/service/domain/feeds/message"
+	"github.com/planetary-social/scuttlego/service/domain/refs"
+)
+
+type idTransport struct {
+	Id string `json:"id"`
+}
+
+type messageTransport struct {
+	Id        string              `json:"id"`
+	Feed      string              `json:"feed"`
+	Sequence  int                 `json:"sequence"`
+	Timestamp int64               `json:"timestamp"`
+	Content   jsoniter.RawMessage `json:"content"`
+
+	// Raw contains the signed message exactly as it was received so that
+	// clients can verify it.
+	Raw string `json:"raw"`
+}
+
+func newMessageTransport(msg message.Message) (messageTransport, error) {
+	content := &bytes.Buffer{}
+	if err := json.Compact(content, msg.Content().Raw().Bytes()); err != nil {
+		return messageTransport{}, errors.Wrap(err, "error compacting content")
+	}
+
+	return messageTransport{
+		Id:        msg.Id().String(),
+		Feed:      msg.Feed().String(),
+		Sequence:  msg.Sequence().Int(),
+		Timestamp: msg.Timestamp().UnixMilli(),
+		Content:   content.Bytes(),
+		Raw:       string(msg.Raw().Bytes()),
+	}, nil
+}
+
+type receiveLogEntryTransport struct {
+	ReceiveLogSequence int              `json:"receiveLogSequence"`
+	Message            messageTransport `json:"message"`
+}
+
+type statusTransport struct {
+	NumberOfMessages int             `json:"numberOfMessages"`
+	NumberOfFeeds    int             `json:"numberOfFeeds"`
+	Peers            []peerTransport `json:"peers"`
+}
+
+type peerTransport struct {
+	Id string `json:"id"`
+
+	// RoundTripTime is expressed in milliseconds and omitted if it hasn't
+	// been measured yet.
+	RoundTripTime *int64 `json:"roundTripTime,omitempty"`
+}
+
+func newStatusTransport(result queries.StatusResult) (statusTransport, error) {
+	transport := statusTransport{
+		NumberOfMessages: result.NumberOfMessages,
+		NumberOfFeeds:    result.NumberOfFeeds,
+		Peers:            make([]peerTransport, 0, len(result.Peers)),
+	}
+
+	for _, peer := range result.Peers {
+		ref, err := refs.NewIdentityFromPublic(peer.Identity)
+		if err != nil {
+			return statusTransport{}, errors.Wrap(err, "error creating an identity ref")
+		}
+
+		p := peerTransport{Id: ref.String()}
+		if peer.RoundTripTime > 0 {
+			rtt := peer.RoundTripTime.Milliseconds()
+			p.RoundTripTime = &rtt
+		}
+
+		transport.Peers = append(transport.Peers, p)
+	}
+
+	return transport, nil
+}
+
+type blobDownloadedTransport struct {
+	Id   string `json:"id"`
+	Size int64  `json:"size"`
+}
+
+type followRequestTransport struct {
+	Feed string `json:"feed"`
+}
+
+type banListRequestTransport struct {
+	Hash string `json:"hash"`
+}
+
+type redeemInviteRequestTransport struct {
+	Invite string `json:"invite"`
+}
+
+type connectRequestTransport struct {
+	Address string `json:"address"`
+}
+
+type registerAliasRequestTransport struct {
+	Room  string `json:"room"`
+	Alias string `json:"alias"`
+}
+
+type registerAliasResponseTransport struct {
+	URL string `json:"url"`
+}
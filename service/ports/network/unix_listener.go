@@ -0,0 +1,192 @@
+package network
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+
+	"github.com/boreq/errors"
+	"github.com/planetary-social/scuttlego/logging"
+	"github.com/planetary-social/scuttlego/service/domain/identity"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc"
+	rpctransport "github.com/planetary-social/scuttlego/service/domain/transport/rpc/transport"
+)
+
+const unixSocketPermissions = 0600
+
+// UnixListener handles incoming connections from local clients over a Unix
+// domain socket. Those connections skip the secret handshake and the boxstream
+// protocol, the clients are treated as if they authenticated as the local
+// identity and can call privileged procedures. This is equivalent to the
+// "unix:...~noauth" multiserver address supported by other implementations.
+// Access to the socket is restricted by setting its file permissions.
+//
+// Local clients are not passed to the AcceptNewPeer command as they aren't
+// other Secure Scuttlebutt nodes.
+type UnixListener struct {
+	local                 identity.Public
+	requestHandler        rpc.RequestHandler
+	connectionIdGenerator *rpc.ConnectionIdGenerator
+	path                  string
+	logger                logging.Logger
+}
+
+// NewUnixListener creates a new listener which listens on a Unix domain socket
+// created at the provided path.
+func NewUnixListener(
+	local identity.Public,
+	requestHandler rpc.RequestHandler,
+	connectionIdGenerator *rpc.ConnectionIdGenerator,
+	path string,
+	logger logging.Logger,
+) (*UnixListener, error) {
+	if local.IsZero() {
+		return nil, errors.New("zero value of local identity")
+	}
+
+	if path == "" {
+		return nil, errors.New("path can't be empty")
+	}
+
+	return &UnixListener{
+		local:                 local,
+		requestHandler:        requestHandler,
+		connectionIdGenerator: connectionIdGenerator,
+		path:                  path,
+		logger:                logger.New("unix_listener"),
+	}, nil
+}
+
+// ListenAndServe starts listening and keeps accepting connections and
+// processing them until the context is closed. A stale socket left at the
+// configured path e.g. after a crash is replaced but other files are never
+// removed. The context passed to this function is used as the base context for
+// the RPC connections.
+func (l *UnixListener) ListenAndServe(ctx context.Context) error {
+	listener, err := l.listen(ctx)
+	if err != nil {
+		return errors.Wrap(err, "could not start a listener")
+	}
+
+	go func() {
+		<-ctx.Done()
+		if err := listener.Close(); err != nil {
+			l.logger.Error().WithError(err).Message("error closing the listener")
+		}
+		if err := removeSocket(l.path); err != nil {
+			l.logger.Error().WithError(err).Message("error removing the socket")
+		}
+	}()
+
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			return errors.Wrap(err, "could not accept a connection")
+		}
+
+		go l.handleNewConnection(ctx, conn)
+	}
+}
+
+// listen creates the socket in a temporary directory which only the current
+// user can access and moves it to the configured path once its permissions are
+// restricted so that other users can never connect to it.
+func (l *UnixListener) listen(ctx context.Context) (net.Listener, error) {
+	if err := checkNotAnotherFile(l.path); err != nil {
+		return nil, errors.Wrap(err, "refusing to replace the file")
+	}
+
+	dir, err := os.MkdirTemp(filepath.Dir(l.path), ".scuttlego-socket-")
+	if err != nil {
+		return nil, errors.Wrap(err, "could not create a temporary directory")
+	}
+	defer func() {
+		if err := os.RemoveAll(dir); err != nil {
+			l.logger.Error().WithError(err).Message("error removing the temporary directory")
+		}
+	}()
+
+	tmpPath := filepath.Join(dir, "socket")
+
+	var lc net.ListenConfig
+	listener, err := lc.Listen(ctx, "unix", tmpPath)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not listen")
+	}
+
+	// The socket is moved so it has to be removed manually.
+	listener.(*net.UnixListener).SetUnlinkOnClose(false)
+
+	if err := os.Chmod(tmpPath, unixSocketPermissions); err != nil {
+		listener.Close()
+		return nil, errors.Wrap(err, "could not change socket permissions")
+	}
+
+	if err := os.Rename(tmpPath, l.path); err != nil {
+		listener.Close()
+		return nil, errors.Wrap(err, "could not move the socket")
+	}
+
+	return listener, nil
+}
+
+func (l *UnixListener) handleNewConnection(ctx context.Context, conn net.Conn) {
+	connectionId := l.connectionIdGenerator.Generate()
+
+	ctx = logging.AddToLoggingContext(ctx, logging.ConnectionIdContextLabel, connectionId)
+	ctx = logging.AddToLoggingContext(ctx, logging.PeerIdContextLabel, l.local.String())
+
+	logger := l.logger.WithCtx(ctx)
+
+	ctx = rpc.PutRemoteIdentityInContext(ctx, l.local)
+	ctx = rpc.PutConnectionIdInContext(ctx, connectionId)
+	ctx = rpc.PutPrivilegedInContext(ctx)
+
+	raw := rpctransport.NewRawConnection(conn, logger)
+
+	// Requests are never performed over connections with local clients so
+	// timeouts can be left unset.
+	rpcConn, err := rpc.NewConnection(connectionId, true, raw, l.requestHandler, rpc.ResponseStreamTimeouts{}, logger)
+	if err != nil {
+		conn.Close()
+		logger.Debug().WithError(err).Message("could not establish an RPC connection")
+		return
+	}
+
+	if err := rpcConn.Loop(ctx); err != nil {
+		logger.Debug().WithError(err).Message("connection loop exited")
+	}
+}
+
+// checkNotAnotherFile returns an error if something other than a socket exists
+// at the provided path.
+func checkNotAnotherFile(path string) error {
+	fi, err := os.Lstat(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return errors.Wrap(err, "stat failed")
+	}
+
+	if fi.Mode()&os.ModeSocket == 0 {
+		return fmt.Errorf("'%s' exists and is not a socket", path)
+	}
+
+	return nil
+}
+
+// removeSocket removes the file at the provided path only if it is a socket.
+func removeSocket(path string) error {
+	if err := checkNotAnotherFile(path); err != nil {
+		return errors.Wrap(err, "refusing to remove the file")
+	}
+
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return errors.Wrap(err, "remove failed")
+	}
+
+	return nil
+}
@@ -0,0 +1,196 @@
+package network_test
+
+import (
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/planetary-social/scuttlego/internal/fixtures"
+	"github.com/planetary-social/scuttlego/service/domain/identity"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc"
+	rpctransport "github.com/planetary-social/scuttlego/service/domain/transport/rpc/transport"
+	"github.com/planetary-social/scuttlego/service/ports/network"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnixListener_ClientsAreTreatedAsLocalIdentityAndArePrivileged(t *testing.T) {
+	ctx := fixtures.TestContext(t)
+	logger := fixtures.TestLogger(t)
+
+	local := fixtures.SomePublicIdentity()
+	path := filepath.Join(fixtures.Directory(t), "socket")
+	requestHandler := newRequestHandlerMock()
+
+	listener, err := network.NewUnixListener(local, requestHandler, rpc.NewConnectionIdGenerator(), path, logger)
+	require.NoError(t, err)
+
+	go func() {
+		_ = listener.ListenAndServe(ctx)
+	}()
+
+	var conn net.Conn
+	require.Eventually(t, func() bool {
+		conn, err = net.Dial("unix", path)
+		return err == nil
+	}, 1*time.Second, 10*time.Millisecond)
+
+	client, err := rpc.NewConnection(
+		fixtures.SomeConnectionId(),
+		false,
+		rpctransport.NewRawConnection(conn, logger),
+		newRequestHandlerMock(),
+		rpc.ResponseStreamTimeouts{},
+		logger,
+	)
+	require.NoError(t, err)
+
+	go func() {
+		_ = client.Loop(ctx)
+	}()
+
+	request := rpc.MustNewRequest(
+		fixtures.SomeProcedureName(),
+		rpc.ProcedureTypeAsync,
+		fixtures.SomeJSON(),
+	)
+
+	stream, err := client.PerformRequest(ctx, request)
+	require.NoError(t, err)
+
+	for range stream.Channel() {
+	}
+
+	require.Eventually(t, func() bool {
+		return assert.ObjectsAreEqual(
+			[]requestHandlerMockCall{
+				{
+					Remote:     local,
+					Privileged: true,
+					Name:       request.Name(),
+				},
+			},
+			requestHandler.Calls(),
+		)
+	}, 1*time.Second, 10*time.Millisecond)
+}
+
+func TestUnixListener_SocketCanOnlyBeAccessedByTheCurrentUser(t *testing.T) {
+	ctx := fixtures.TestContext(t)
+	path := filepath.Join(fixtures.Directory(t), "socket")
+
+	runUnixListener(t, ctx, path)
+
+	fi, err := os.Lstat(path)
+	require.NoError(t, err)
+	require.NotZero(t, fi.Mode()&os.ModeSocket)
+	require.Equal(t, os.FileMode(0600), fi.Mode().Perm())
+}
+
+func TestUnixListener_StaleSocketIsReplaced(t *testing.T) {
+	ctx := fixtures.TestContext(t)
+	path := filepath.Join(fixtures.Directory(t), "socket")
+
+	stale, err := net.Listen("unix", path)
+	require.NoError(t, err)
+	stale.(*net.UnixListener).SetUnlinkOnClose(false)
+	require.NoError(t, stale.Close())
+
+	runUnixListener(t, ctx, path)
+}
+
+func TestUnixListener_OtherFilesAreNotRemoved(t *testing.T) {
+	ctx := fixtures.TestContext(t)
+	path := filepath.Join(fixtures.Directory(t), "socket")
+
+	err := os.WriteFile(path, []byte("some data"), 0600)
+	require.NoError(t, err)
+
+	listener, err := network.NewUnixListener(fixtures.SomePublicIdentity(), newRequestHandlerMock(), rpc.NewConnectionIdGenerator(), path, fixtures.TestLogger(t))
+	require.NoError(t, err)
+
+	err = listener.ListenAndServe(ctx)
+	require.ErrorContains(t, err, "is not a socket")
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, []byte("some data"), data)
+}
+
+func TestUnixListener_SocketIsRemovedWhenContextIsClosed(t *testing.T) {
+	ctx, cancel := context.WithCancel(fixtures.TestContext(t))
+	defer cancel()
+
+	path := filepath.Join(fixtures.Directory(t), "socket")
+
+	runUnixListener(t, ctx, path)
+	cancel()
+
+	require.Eventually(t, func() bool {
+		_, err := os.Lstat(path)
+		return os.IsNotExist(err)
+	}, 1*time.Second, 10*time.Millisecond)
+}
+
+// runUnixListener starts a listener and waits until it is possible to connect
+// to it.
+func runUnixListener(t *testing.T, ctx context.Context, path string) {
+	listener, err := network.NewUnixListener(fixtures.SomePublicIdentity(), newRequestHandlerMock(), rpc.NewConnectionIdGenerator(), path, fixtures.TestLogger(t))
+	require.NoError(t, err)
+
+	go func() {
+		_ = listener.ListenAndServe(ctx)
+	}()
+
+	require.Eventually(t, func() bool {
+		conn, err := net.Dial("unix", path)
+		if err != nil {
+			return false
+		}
+		_ = conn.Close()
+		return true
+	}, 1*time.Second, 10*time.Millisecond)
+}
+
+type requestHandlerMock struct {
+	calls     []requestHandlerMockCall
+	callsLock sync.Mutex
+}
+
+func newRequestHandlerMock() *requestHandlerMock {
+	return &requestHandlerMock{}
+}
+
+func (r *requestHandlerMock) HandleRequest(ctx context.Context, s rpc.Stream, req *rpc.Request) {
+	r.callsLock.Lock()
+	defer r.callsLock.Unlock()
+
+	remote, _ := rpc.GetRemoteIdentityFromContext(ctx)
+
+	r.calls = append(r.calls, requestHandlerMockCall{
+		Remote:     remote,
+		Privileged: rpc.IsPrivilegedConnection(ctx),
+		Name:       req.Name(),
+	})
+
+	_ = s.CloseWithError(nil)
+}
+
+func (r *requestHandlerMock) Calls() []requestHandlerMockCall {
+	r.callsLock.Lock()
+	defer r.callsLock.Unlock()
+
+	tmp := make([]requestHandlerMockCall, len(r.calls))
+	copy(tmp, r.calls)
+	return tmp
+}
+
+type requestHandlerMockCall struct {
+	Remote     identity.Public
+	Privileged bool
+	Name       rpc.ProcedureName
+}
@@ -0,0 +1,102 @@
+package network
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/boreq/errors"
+	"github.com/gorilla/websocket"
+	"github.com/planetary-social/scuttlego/logging"
+	"github.com/planetary-social/scuttlego/service/domain/network"
+)
+
+const webSocketReadHeaderTimeout = 15 * time.Second
+
+// WebSocketListener handles incoming WebSocket connections initiated by other
+// peers e.g. browser-based clients, initializes them and passes them to the
+// AcceptNewPeer command. Apart from the transport, those connections are
+// handled in the same way as connections accepted by Listener.
+type WebSocketListener struct {
+	initializer ServerPeerInitializer
+	address     string
+	upgrader    websocket.Upgrader
+	logger      logging.Logger
+}
+
+// NewWebSocketListener creates a new listener which listens on the provided
+// address. The address should be formatted in the way which can be handled by
+// the net package e.g. ":8989".
+func NewWebSocketListener(
+	initializer ServerPeerInitializer,
+	address string,
+	logger logging.Logger,
+) (*WebSocketListener, error) {
+	return &WebSocketListener{
+		initializer: initializer,
+		address:     address,
+		upgrader: websocket.Upgrader{
+			// Browsers always send the origin header and peers can connect
+			// from any origin. Connections are authenticated using the
+			// secret handshake which is performed after upgrading.
+			CheckOrigin: func(r *http.Request) bool {
+				return true
+			},
+		},
+		logger: logger.New("websocket_listener"),
+	}, nil
+}
+
+// ListenAndServe starts listening and keeps accepting connections and
+// processing them until the context is closed. The context passed to this
+// function is not used to initiate RPC connections.
+func (l *WebSocketListener) ListenAndServe(ctx context.Context) error {
+	var lc net.ListenConfig
+	listener, err := lc.Listen(ctx, "tcp", l.address)
+	if err != nil {
+		return errors.Wrap(err, "could not start a listener")
+	}
+
+	server := &http.Server{
+		Handler:           l.Handler(ctx),
+		ReadHeaderTimeout: webSocketReadHeaderTimeout,
+	}
+
+	go func() {
+		<-ctx.Done()
+		if err := server.Close(); err != nil {
+			l.logger.Error().WithError(err).Message("error closing the server")
+		}
+	}()
+
+	if err := server.Serve(listener); err != nil {
+		return errors.Wrap(err, "could not serve")
+	}
+
+	return nil
+}
+
+// Handler returns an HTTP handler which upgrades incoming requests to
+// WebSocket connections and initializes them. The provided context is used as
+// the base context for the RPC connections.
+func (l *WebSocketListener) Handler(ctx context.Context) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := l.upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			l.logger.Debug().WithError(err).Message("could not upgrade the connection")
+			return
+		}
+
+		go l.handleNewConnection(ctx, network.NewWebSocketConn(conn))
+	})
+}
+
+func (l *WebSocketListener) handleNewConnection(ctx context.Context, conn *network.WebSocketConn) {
+	_, err := l.initializer.InitializeServerPeer(ctx, conn)
+	if err != nil {
+		conn.Close()
+		l.logger.Debug().WithError(err).Message("could not init a peer")
+		return
+	}
+}
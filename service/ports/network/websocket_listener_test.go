@@ -0,0 +1,92 @@
+package network_test
+
+import (
+	"context"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/planetary-social/scuttlego/internal/fixtures"
+	"github.com/planetary-social/scuttlego/internal/mocks"
+	"github.com/planetary-social/scuttlego/service/domain/identity"
+	domainnetwork "github.com/planetary-social/scuttlego/service/domain/network"
+	"github.com/planetary-social/scuttlego/service/domain/transport"
+	"github.com/planetary-social/scuttlego/service/domain/transport/boxstream"
+	"github.com/planetary-social/scuttlego/service/ports/network"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebSocketListener_ClientsCanCompleteTheHandshake(t *testing.T) {
+	ctx := fixtures.TestContext(t)
+	logger := fixtures.TestLogger(t)
+
+	networkKey := fixtures.SomeNetworkKey()
+	serverIdentity := fixtures.SomePrivateIdentity()
+	clientIdentity := fixtures.SomePrivateIdentity()
+
+	serverHandshaker, err := boxstream.NewHandshaker(serverIdentity, networkKey, mocks.NewCurrentTimeProviderMock())
+	require.NoError(t, err)
+
+	clientHandshaker, err := boxstream.NewHandshaker(clientIdentity, networkKey, mocks.NewCurrentTimeProviderMock())
+	require.NoError(t, err)
+
+	initializer := newHandshakingServerPeerInitializer(serverHandshaker)
+
+	listener, err := network.NewWebSocketListener(initializer, ":0", logger)
+	require.NoError(t, err)
+
+	server := httptest.NewServer(listener.Handler(ctx))
+	t.Cleanup(server.Close)
+
+	conn, _, err := websocket.DefaultDialer.DialContext(ctx, strings.Replace(server.URL, "http://", "ws://", 1), nil)
+	require.NoError(t, err)
+
+	wsConn := domainnetwork.NewWebSocketConn(conn)
+	defer wsConn.Close()
+
+	stream, err := clientHandshaker.OpenClientStream(wsConn, serverIdentity.Public())
+	require.NoError(t, err)
+	require.Equal(t, serverIdentity.Public(), stream.Remote())
+
+	require.Eventually(t, func() bool {
+		remotes := initializer.Remotes()
+		return len(remotes) == 1 && remotes[0].Equal(clientIdentity.Public())
+	}, 1*time.Second, 10*time.Millisecond)
+}
+
+type handshakingServerPeerInitializer struct {
+	handshaker boxstream.Handshaker
+
+	remotes     []identity.Public
+	remotesLock sync.Mutex
+}
+
+func newHandshakingServerPeerInitializer(handshaker boxstream.Handshaker) *handshakingServerPeerInitializer {
+	return &handshakingServerPeerInitializer{handshaker: handshaker}
+}
+
+func (h *handshakingServerPeerInitializer) InitializeServerPeer(ctx context.Context, rwc io.ReadWriteCloser) (transport.Peer, error) {
+	stream, err := h.handshaker.OpenServerStream(rwc)
+	if err != nil {
+		return transport.Peer{}, err
+	}
+
+	h.remotesLock.Lock()
+	defer h.remotesLock.Unlock()
+	h.remotes = append(h.remotes, stream.Remote())
+
+	return transport.MustNewPeer(stream.Remote(), mocks.NewConnectionMock(ctx)), nil
+}
+
+func (h *handshakingServerPeerInitializer) Remotes() []identity.Public {
+	h.remotesLock.Lock()
+	defer h.remotesLock.Unlock()
+
+	tmp := make([]identity.Public, len(h.remotes))
+	copy(tmp, h.remotes)
+	return tmp
+}
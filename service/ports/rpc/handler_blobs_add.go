@@ -0,0 +1,67 @@
+package rpc
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/boreq/errors"
+	"github.com/planetary-social/scuttlego/service/app/commands"
+	"github.com/planetary-social/scuttlego/service/domain/blobs"
+	"github.com/planetary-social/scuttlego/service/domain/messages"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc/mux"
+)
+
+// HandlerBlobsAdd receives a blob streamed by the client and stores it once
+// the client closes the stream.
+type HandlerBlobsAdd struct {
+	handler CreateBlobCommandHandler
+}
+
+func NewHandlerBlobsAdd(handler CreateBlobCommandHandler) *HandlerBlobsAdd {
+	return &HandlerBlobsAdd{handler: handler}
+}
+
+func (h HandlerBlobsAdd) Procedure() rpc.Procedure {
+	return messages.BlobsAddProcedure
+}
+
+func (h HandlerBlobsAdd) Handle(ctx context.Context, s mux.Stream, req *rpc.Request) error {
+	args, err := messages.NewBlobsAddArgumentsFromBytes(req.Arguments())
+	if err != nil {
+		return errors.Wrap(err, "error parsing arguments")
+	}
+
+	incoming, err := s.IncomingMessages()
+	if err != nil {
+		return errors.Wrap(err, "error getting incoming messages")
+	}
+
+	maxSize := blobs.MaxBlobSize().InBytes()
+
+	buf := &bytes.Buffer{}
+	for msg := range incoming {
+		if int64(buf.Len()+len(msg.Body)) > maxSize {
+			return errors.New("blob is too large")
+		}
+		buf.Write(msg.Body)
+	}
+
+	if buf.Len() == 0 {
+		return errors.New("empty blob")
+	}
+
+	if expected, ok := args.Id(); ok {
+		hasher := blobs.NewHasher()
+		hasher.Write(buf.Bytes()) //nolint:errcheck
+		if err := blobs.Verify(expected, hasher); err != nil {
+			return errors.Wrap(err, "blob doesn't match the provided id")
+		}
+	}
+
+	if _, err := h.handler.Handle(commands.CreateBlob{Reader: buf}); err != nil {
+		return errors.Wrap(err, "error executing the command")
+	}
+
+	return nil
+}
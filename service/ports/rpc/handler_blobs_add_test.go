@@ -0,0 +1,90 @@
+package rpc_test
+
+import (
+	"testing"
+
+	"github.com/planetary-social/scuttlego/internal"
+	"github.com/planetary-social/scuttlego/internal/fixtures"
+	"github.com/planetary-social/scuttlego/internal/mocks"
+	"github.com/planetary-social/scuttlego/service/domain/blobs"
+	"github.com/planetary-social/scuttlego/service/domain/messages"
+	"github.com/planetary-social/scuttlego/service/domain/refs"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc"
+	portsrpc "github.com/planetary-social/scuttlego/service/ports/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandlerBlobsAdd(t *testing.T) {
+	chunks := [][]byte{
+		[]byte("some "),
+		[]byte("blob"),
+	}
+
+	hasher := blobs.NewHasher()
+	_, err := hasher.Write([]byte("some blob"))
+	require.NoError(t, err)
+
+	id, err := hasher.SumRef()
+	require.NoError(t, err)
+
+	testCases := []struct {
+		Name           string
+		Id             *refs.Blob
+		ExpectedError  bool
+		ExpectedStored bool
+	}{
+		{
+			Name:           "without_id",
+			Id:             nil,
+			ExpectedStored: true,
+		},
+		{
+			Name:           "matching_id",
+			Id:             internal.Ptr(id),
+			ExpectedStored: true,
+		},
+		{
+			Name:           "mismatched_id",
+			Id:             internal.Ptr(fixtures.SomeRefBlob()),
+			ExpectedError:  true,
+			ExpectedStored: false,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			commandHandler := newCreateBlobCommandHandlerMock(fixtures.SomeRefBlob(), nil)
+			h := portsrpc.NewHandlerBlobsAdd(commandHandler)
+
+			require.Equal(t, messages.BlobsAddProcedure, h.Procedure())
+
+			args, err := messages.NewBlobsAddArguments(testCase.Id)
+			require.NoError(t, err)
+
+			req, err := messages.NewBlobsAdd(args)
+			require.NoError(t, err)
+
+			incoming := make(chan rpc.IncomingMessage, len(chunks))
+			for _, chunk := range chunks {
+				incoming <- rpc.IncomingMessage{Body: chunk}
+			}
+			close(incoming)
+
+			s := mocks.NewMockCloserStream()
+			s.MockIncomingMessages(incoming)
+
+			err = h.Handle(fixtures.TestContext(t), s, req)
+			if testCase.ExpectedError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+
+			if testCase.ExpectedStored {
+				require.Equal(t, [][]byte{[]byte("some blob")}, commandHandler.data)
+			} else {
+				require.Empty(t, commandHandler.data)
+			}
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package rpc
+
+import (
+	"context"
+
+	"github.com/boreq/errors"
+	"github.com/planetary-social/scuttlego/service/app/queries"
+	"github.com/planetary-social/scuttlego/service/domain/messages"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc/mux"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc/transport"
+)
+
+type BlobDownloadedEventsQueryHandler interface {
+	Handle(ctx context.Context) <-chan queries.BlobDownloaded
+}
+
+// HandlerBlobsChanges sends ids of blobs as they are downloaded. The stream
+// remains open until it is closed by the remote.
+type HandlerBlobsChanges struct {
+	handler BlobDownloadedEventsQueryHandler
+}
+
+func NewHandlerBlobsChanges(handler BlobDownloadedEventsQueryHandler) *HandlerBlobsChanges {
+	return &HandlerBlobsChanges{
+		handler: handler,
+	}
+}
+
+func (h HandlerBlobsChanges) Procedure() rpc.Procedure {
+	return messages.BlobsChangesProcedure
+}
+
+func (h HandlerBlobsChanges) Handle(ctx context.Context, s mux.Stream, req *rpc.Request) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	for event := range h.handler.Handle(ctx) {
+		response, err := messages.NewBlobsChangesResponse(event.Id)
+		if err != nil {
+			return errors.Wrap(err, "error creating the response")
+		}
+
+		j, err := response.MarshalJSON()
+		if err != nil {
+			return errors.Wrap(err, "json marshalling failed")
+		}
+
+		if err := s.WriteMessage(j, transport.MessageBodyTypeJSON); err != nil {
+			return errors.Wrap(err, "error writing the message")
+		}
+	}
+
+	return nil
+}
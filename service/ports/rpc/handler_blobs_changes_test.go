@@ -0,0 +1,64 @@
+package rpc_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/planetary-social/scuttlego/internal/fixtures"
+	"github.com/planetary-social/scuttlego/internal/mocks"
+	"github.com/planetary-social/scuttlego/service/app/queries"
+	"github.com/planetary-social/scuttlego/service/domain/messages"
+	"github.com/planetary-social/scuttlego/service/domain/refs"
+	"github.com/planetary-social/scuttlego/service/ports/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandlerBlobsChanges_SendsIdsOfDownloadedBlobs(t *testing.T) {
+	blob1 := fixtures.SomeRefBlob()
+	blob2 := fixtures.SomeRefBlob()
+
+	queryHandler := newBlobDownloadedEventsQueryHandlerMock([]queries.BlobDownloaded{
+		{Id: blob1, Size: fixtures.SomeSize()},
+		{Id: blob2, Size: fixtures.SomeSize()},
+	})
+	h := rpc.NewHandlerBlobsChanges(queryHandler)
+
+	require.Equal(t, messages.BlobsChangesProcedure, h.Procedure())
+
+	ctx := fixtures.TestContext(t)
+	s := mocks.NewMockCloserStream()
+
+	req, err := messages.NewBlobsChanges()
+	require.NoError(t, err)
+
+	err = h.Handle(ctx, s, req)
+	require.NoError(t, err)
+
+	written := s.WrittenMessages()
+	require.Len(t, written, 2)
+	requireBlobsChangesResponse(t, blob1, written[0].Body)
+	requireBlobsChangesResponse(t, blob2, written[1].Body)
+}
+
+func requireBlobsChangesResponse(t *testing.T, expected refs.Blob, body []byte) {
+	response, err := messages.NewBlobsChangesResponseFromBytes(body)
+	require.NoError(t, err)
+	require.Equal(t, expected, response.Id())
+}
+
+type blobDownloadedEventsQueryHandlerMock struct {
+	values []queries.BlobDownloaded
+}
+
+func newBlobDownloadedEventsQueryHandlerMock(values []queries.BlobDownloaded) *blobDownloadedEventsQueryHandlerMock {
+	return &blobDownloadedEventsQueryHandlerMock{values: values}
+}
+
+func (b *blobDownloadedEventsQueryHandlerMock) Handle(ctx context.Context) <-chan queries.BlobDownloaded {
+	ch := make(chan queries.BlobDownloaded, len(b.values))
+	for _, v := range b.values {
+		ch <- v
+	}
+	close(ch)
+	return ch
+}
@@ -0,0 +1,62 @@
+package rpc
+
+import (
+	"context"
+
+	"github.com/boreq/errors"
+	"github.com/planetary-social/scuttlego/service/app/queries"
+	"github.com/planetary-social/scuttlego/service/domain/messages"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc/mux"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc/transport"
+)
+
+type HasBlobQueryHandler interface {
+	Handle(query queries.HasBlob) (bool, error)
+}
+
+type HandlerBlobsHas struct {
+	handler HasBlobQueryHandler
+}
+
+func NewHandlerBlobsHas(handler HasBlobQueryHandler) *HandlerBlobsHas {
+	return &HandlerBlobsHas{
+		handler: handler,
+	}
+}
+
+func (h HandlerBlobsHas) Procedure() rpc.Procedure {
+	return messages.BlobsHasProcedure
+}
+
+func (h HandlerBlobsHas) Handle(ctx context.Context, s mux.Stream, req *rpc.Request) error {
+	args, err := messages.NewBlobIdsArgumentsFromBytes(req.Arguments())
+	if err != nil {
+		return errors.Wrap(err, "invalid arguments")
+	}
+
+	var values []bool
+	for _, id := range args.Ids() {
+		has, err := h.handler.Handle(queries.HasBlob{Id: id})
+		if err != nil {
+			return errors.Wrap(err, "error executing the query")
+		}
+		values = append(values, has)
+	}
+
+	response, err := messages.NewBlobsHasResponse(args, values)
+	if err != nil {
+		return errors.Wrap(err, "error creating the response")
+	}
+
+	j, err := response.MarshalJSON()
+	if err != nil {
+		return errors.Wrap(err, "json marshalling failed")
+	}
+
+	if err := s.WriteMessage(j, transport.MessageBodyTypeJSON); err != nil {
+		return errors.Wrap(err, "error writing the message")
+	}
+
+	return nil
+}
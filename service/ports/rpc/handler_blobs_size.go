@@ -0,0 +1,63 @@
+package rpc
+
+import (
+	"context"
+
+	"github.com/boreq/errors"
+	"github.com/planetary-social/scuttlego/service/app/queries"
+	"github.com/planetary-social/scuttlego/service/domain/blobs"
+	"github.com/planetary-social/scuttlego/service/domain/messages"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc/mux"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc/transport"
+)
+
+type GetBlobSizeQueryHandler interface {
+	Handle(query queries.GetBlobSize) (*blobs.Size, error)
+}
+
+type HandlerBlobsSize struct {
+	handler GetBlobSizeQueryHandler
+}
+
+func NewHandlerBlobsSize(handler GetBlobSizeQueryHandler) *HandlerBlobsSize {
+	return &HandlerBlobsSize{
+		handler: handler,
+	}
+}
+
+func (h HandlerBlobsSize) Procedure() rpc.Procedure {
+	return messages.BlobsSizeProcedure
+}
+
+func (h HandlerBlobsSize) Handle(ctx context.Context, s mux.Stream, req *rpc.Request) error {
+	args, err := messages.NewBlobIdsArgumentsFromBytes(req.Arguments())
+	if err != nil {
+		return errors.Wrap(err, "invalid arguments")
+	}
+
+	var values []*blobs.Size
+	for _, id := range args.Ids() {
+		size, err := h.handler.Handle(queries.GetBlobSize{Id: id})
+		if err != nil {
+			return errors.Wrap(err, "error executing the query")
+		}
+		values = append(values, size)
+	}
+
+	response, err := messages.NewBlobsSizeResponse(args, values)
+	if err != nil {
+		return errors.Wrap(err, "error creating the response")
+	}
+
+	j, err := response.MarshalJSON()
+	if err != nil {
+		return errors.Wrap(err, "json marshalling failed")
+	}
+
+	if err := s.WriteMessage(j, transport.MessageBodyTypeJSON); err != nil {
+		return errors.Wrap(err, "error writing the message")
+	}
+
+	return nil
+}
@@ -0,0 +1,74 @@
+package rpc_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/planetary-social/scuttlego/internal/fixtures"
+	"github.com/planetary-social/scuttlego/internal/mocks"
+	"github.com/planetary-social/scuttlego/service/app/queries"
+	"github.com/planetary-social/scuttlego/service/domain/messages"
+	"github.com/planetary-social/scuttlego/service/domain/refs"
+	"github.com/planetary-social/scuttlego/service/ports/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandlerBlobsSize(t *testing.T) {
+	storage := mocks.NewBlobStorageMock()
+	h := rpc.NewHandlerBlobsSize(queries.NewGetBlobSizeHandler(storage))
+
+	require.Equal(t, messages.BlobsSizeProcedure, h.Procedure())
+
+	existing := fixtures.SomeRefBlob()
+	missing := fixtures.SomeRefBlob()
+	data := fixtures.SomeBytes()
+	storage.MockBlob(existing, data)
+
+	testCases := []struct {
+		Name         string
+		Arguments    func() (messages.BlobIdsArguments, error)
+		ExpectedJSON string
+	}{
+		{
+			Name: "existing",
+			Arguments: func() (messages.BlobIdsArguments, error) {
+				return messages.NewBlobIdsArguments(existing)
+			},
+			ExpectedJSON: fmt.Sprintf(`%d`, len(data)),
+		},
+		{
+			Name: "missing",
+			Arguments: func() (messages.BlobIdsArguments, error) {
+				return messages.NewBlobIdsArguments(missing)
+			},
+			ExpectedJSON: `null`,
+		},
+		{
+			Name: "list",
+			Arguments: func() (messages.BlobIdsArguments, error) {
+				return messages.NewBlobIdsArgumentsList([]refs.Blob{existing, missing})
+			},
+			ExpectedJSON: fmt.Sprintf(`[%d, null]`, len(data)),
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			ctx := fixtures.TestContext(t)
+			s := mocks.NewMockCloserStream()
+
+			args, err := testCase.Arguments()
+			require.NoError(t, err)
+
+			req, err := messages.NewBlobsSize(args)
+			require.NoError(t, err)
+
+			err = h.Handle(ctx, s, req)
+			require.NoError(t, err)
+
+			written := s.WrittenMessages()
+			require.Len(t, written, 1)
+			require.JSONEq(t, testCase.ExpectedJSON, string(written[0].Body))
+		})
+	}
+}
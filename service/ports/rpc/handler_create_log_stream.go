@@ -0,0 +1,302 @@
+package rpc
+
+import (
+	"context"
+
+	"github.com/boreq/errors"
+	"github.com/planetary-social/scuttlego/service/app/common"
+	"github.com/planetary-social/scuttlego/service/app/queries"
+	"github.com/planetary-social/scuttlego/service/domain/feeds/message"
+	"github.com/planetary-social/scuttlego/service/domain/messages"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc/mux"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc/transport"
+)
+
+// createLogStreamPageSize is the number of messages which are loaded from the
+// receive log at once.
+const createLogStreamPageSize = 100
+
+type MessageSavedEventsQueryHandler interface {
+	Handle(ctx context.Context) <-chan message.Message
+}
+
+// HandlerCreateLogStream streams messages from the receive log in the format
+// used by ssb-server. The range specified by the client is compared with the
+// timestamps claimed by the authors of the messages as those are the
+// timestamps returned to the client.
+type HandlerCreateLogStream struct {
+	receiveLog         ReceiveLogQueryHandler
+	messageSavedEvents MessageSavedEventsQueryHandler
+}
+
+func NewHandlerCreateLogStream(
+	receiveLog ReceiveLogQueryHandler,
+	messageSavedEvents MessageSavedEventsQueryHandler,
+) *HandlerCreateLogStream {
+	return &HandlerCreateLogStream{
+		receiveLog:         receiveLog,
+		messageSavedEvents: messageSavedEvents,
+	}
+}
+
+func (h HandlerCreateLogStream) Procedure() rpc.Procedure {
+	return messages.CreateLogStreamProcedure
+}
+
+func (h HandlerCreateLogStream) Handle(ctx context.Context, s mux.Stream, req *rpc.Request) error {
+	args, err := messages.NewCreateLogStreamArgumentsFromBytes(req.Arguments())
+	if err != nil {
+		return errors.Wrap(err, "error parsing arguments")
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	w := newCreateLogStreamWriter(args, s)
+	if w.LimitReached() {
+		return nil
+	}
+
+	// Subscribing before reading the receive log ensures that no messages
+	// are missed when switching to live mode.
+	var newMessages <-chan struct{}
+	if args.Mode().Live() {
+		newMessages = subscribeToSavedMessages(ctx, h.messageSavedEvents, nil)
+	}
+
+	next, err := h.sendOld(args, w)
+	if err != nil {
+		return errors.Wrap(err, "error sending old messages")
+	}
+
+	if !args.Mode().Live() || w.LimitReached() {
+		return nil
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-newMessages:
+		}
+
+		next, err = h.readReceiveLog(next, w.Write)
+		if err != nil {
+			return errors.Wrap(err, "error sending new messages")
+		}
+
+		if w.LimitReached() {
+			return nil
+		}
+	}
+}
+
+// sendOld sends messages which are already in the receive log if the client
+// requested them and returns the sequence from which live messages should be
+// read.
+func (h HandlerCreateLogStream) sendOld(args messages.CreateLogStreamArguments, w *createLogStreamWriter) (common.ReceiveLogSequence, error) {
+	if args.Mode().Old() && !args.Mode().Reverse() {
+		return h.readReceiveLog(common.MustNewReceiveLogSequence(0), w.Write)
+	}
+
+	next, err := h.endOfReceiveLog()
+	if err != nil {
+		return common.ReceiveLogSequence{}, errors.Wrap(err, "error finding the end of the receive log")
+	}
+
+	if !args.Mode().Old() {
+		return next, nil
+	}
+
+	timestamps := queries.TimestampRange{
+		Gte: args.Gte(),
+		Lt:  args.Lt(),
+	}
+
+	if err := h.readReceiveLogInReverse(next, timestamps, w.Write); err != nil {
+		return common.ReceiveLogSequence{}, errors.Wrap(err, "error reading the receive log in reverse")
+	}
+
+	return next, nil
+}
+
+// endOfReceiveLog returns the sequence following the last message in the
+// receive log.
+func (h HandlerCreateLogStream) endOfReceiveLog() (common.ReceiveLogSequence, error) {
+	query, err := queries.NewReceiveLogInRange(
+		common.MustNewReceiveLogSequence(0),
+		nil,
+		true,
+		queries.TimestampRange{},
+		1,
+	)
+	if err != nil {
+		return common.ReceiveLogSequence{}, errors.Wrap(err, "error creating the query")
+	}
+
+	logMessages, err := h.receiveLog.Handle(query)
+	if err != nil {
+		return common.ReceiveLogSequence{}, errors.Wrap(err, "error executing the query")
+	}
+
+	if len(logMessages) == 0 {
+		return common.MustNewReceiveLogSequence(0), nil
+	}
+
+	return common.NewReceiveLogSequence(logMessages[0].Sequence.Int() + 1)
+}
+
+// readReceiveLogInReverse passes messages from the receive log with sequences
+// lower than the provided sequence to the provided function, newest first,
+// until the function returns false or the beginning of the receive log is
+// reached.
+func (h HandlerCreateLogStream) readReceiveLogInReverse(
+	end common.ReceiveLogSequence,
+	timestamps queries.TimestampRange,
+	fn func(msg message.Message) (bool, error),
+) error {
+	for {
+		query, err := queries.NewReceiveLogInRange(
+			common.MustNewReceiveLogSequence(0),
+			&end,
+			true,
+			timestamps,
+			createLogStreamPageSize,
+		)
+		if err != nil {
+			return errors.Wrap(err, "error creating the query")
+		}
+
+		logMessages, err := h.receiveLog.Handle(query)
+		if err != nil {
+			return errors.Wrap(err, "error executing the query")
+		}
+
+		for _, logMessage := range logMessages {
+			ok, err := fn(logMessage.Message)
+			if err != nil {
+				return errors.Wrap(err, "function returned an error")
+			}
+
+			if !ok {
+				return nil
+			}
+		}
+
+		if len(logMessages) < createLogStreamPageSize {
+			return nil
+		}
+
+		end = logMessages[len(logMessages)-1].Sequence
+	}
+}
+
+// readReceiveLog passes messages from the receive log starting at the
+// provided sequence to the provided function until the function returns false
+// or the end of the receive log is reached. It returns the sequence following
+// the last message which was read.
+func (h HandlerCreateLogStream) readReceiveLog(
+	start common.ReceiveLogSequence,
+	fn func(msg message.Message) (bool, error),
+) (common.ReceiveLogSequence, error) {
+	for {
+		query, err := queries.NewReceiveLog(start, createLogStreamPageSize)
+		if err != nil {
+			return common.ReceiveLogSequence{}, errors.Wrap(err, "error creating the query")
+		}
+
+		logMessages, err := h.receiveLog.Handle(query)
+		if err != nil {
+			return common.ReceiveLogSequence{}, errors.Wrap(err, "error executing the query")
+		}
+
+		for _, logMessage := range logMessages {
+			start, err = common.NewReceiveLogSequence(logMessage.Sequence.Int() + 1)
+			if err != nil {
+				return common.ReceiveLogSequence{}, errors.Wrap(err, "error creating the next start sequence")
+			}
+
+			ok, err := fn(logMessage.Message)
+			if err != nil {
+				return common.ReceiveLogSequence{}, errors.Wrap(err, "function returned an error")
+			}
+
+			if !ok {
+				return start, nil
+			}
+		}
+
+		if len(logMessages) < createLogStreamPageSize {
+			return start, nil
+		}
+	}
+}
+
+// subscribeToSavedMessages returns a channel which receives a value when
+// new messages matching the filter are saved. The subscription has to be
+// drained at all times as otherwise it would block publishing of new messages
+// so notifications are coalesced instead of being buffered. A nil filter
+// matches all messages.
+func subscribeToSavedMessages(
+	ctx context.Context,
+	messageSavedEvents MessageSavedEventsQueryHandler,
+	filter func(msg message.Message) bool,
+) <-chan struct{} {
+	ch := make(chan struct{}, 1)
+	subscription := messageSavedEvents.Handle(ctx)
+
+	go func() {
+		for msg := range subscription {
+			if filter != nil && !filter(msg) {
+				continue
+			}
+
+			select {
+			case ch <- struct{}{}:
+			default:
+			}
+		}
+	}()
+
+	return ch
+}
+
+type createLogStreamWriter struct {
+	args messages.CreateLogStreamArguments
+	s    mux.Stream
+	sent int
+}
+
+func newCreateLogStreamWriter(args messages.CreateLogStreamArguments, s mux.Stream) *createLogStreamWriter {
+	return &createLogStreamWriter{args: args, s: s}
+}
+
+// Write sends the message if it falls within the requested range and returns
+// false once no more messages should be sent.
+func (w *createLogStreamWriter) Write(msg message.Message) (bool, error) {
+	if w.LimitReached() {
+		return false, nil
+	}
+
+	if !w.args.InRange(msg.Timestamp()) {
+		return true, nil
+	}
+
+	j, err := messages.NewMessageStreamResponse(msg, w.args.Options()).MarshalJSON()
+	if err != nil {
+		return false, errors.Wrap(err, "json marshalling failed")
+	}
+
+	if err := w.s.WriteMessage(j, transport.MessageBodyTypeJSON); err != nil {
+		return false, errors.Wrap(err, "error writing the message")
+	}
+
+	w.sent++
+	return !w.LimitReached(), nil
+}
+
+func (w *createLogStreamWriter) LimitReached() bool {
+	limit := w.args.Limit()
+	return limit != nil && w.sent >= *limit
+}
@@ -0,0 +1,435 @@
+package rpc_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/planetary-social/scuttlego/internal"
+	"github.com/planetary-social/scuttlego/internal/fixtures"
+	"github.com/planetary-social/scuttlego/internal/mocks"
+	"github.com/planetary-social/scuttlego/service/app/common"
+	"github.com/planetary-social/scuttlego/service/app/queries"
+	"github.com/planetary-social/scuttlego/service/domain/feeds/message"
+	"github.com/planetary-social/scuttlego/service/domain/messages"
+	"github.com/planetary-social/scuttlego/service/ports/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandlerCreateLogStream(t *testing.T) {
+	const numberOfMessages = 250
+
+	var logMessages []queries.LogMessage
+	for i := 0; i < numberOfMessages; i++ {
+		logMessages = append(logMessages, queries.LogMessage{
+			Message:  fixtures.SomeMessage(message.NewFirstSequence(), fixtures.SomeRefFeed()),
+			Sequence: common.MustNewReceiveLogSequence(i),
+		})
+	}
+
+	testCases := []struct {
+		Name                     string
+		Limit                    *int
+		ExpectedNumberOfMessages int
+	}{
+		{
+			Name:                     "no_limit",
+			Limit:                    nil,
+			ExpectedNumberOfMessages: numberOfMessages,
+		},
+		{
+			Name:                     "limit_spanning_multiple_pages",
+			Limit:                    internal.Ptr(150),
+			ExpectedNumberOfMessages: 150,
+		},
+		{
+			Name:                     "limit_larger_than_log",
+			Limit:                    internal.Ptr(1000),
+			ExpectedNumberOfMessages: numberOfMessages,
+		},
+		{
+			Name:                     "zero_limit",
+			Limit:                    internal.Ptr(0),
+			ExpectedNumberOfMessages: 0,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			queryHandler := newPagingReceiveLogQueryHandlerMock(logMessages)
+			h := rpc.NewHandlerCreateLogStream(queryHandler, newMessageSavedEventsQueryHandlerMock())
+
+			require.Equal(t, messages.CreateLogStreamProcedure, h.Procedure())
+
+			options := messages.NewMessageStreamOptions(true, false)
+
+			args, err := messages.NewCreateLogStreamArguments(
+				nil,
+				nil,
+				testCase.Limit,
+				messages.NewDefaultMessageStreamMode(),
+				options,
+			)
+			require.NoError(t, err)
+
+			req, err := messages.NewCreateLogStream(args)
+			require.NoError(t, err)
+
+			s := mocks.NewMockCloserStream()
+			err = h.Handle(fixtures.TestContext(t), s, req)
+			require.NoError(t, err)
+
+			written := s.WrittenMessages()
+			require.Len(t, written, testCase.ExpectedNumberOfMessages)
+
+			for i, w := range written {
+				j, err := messages.NewMessageStreamResponse(logMessages[i].Message, options).MarshalJSON()
+				require.NoError(t, err)
+				require.Equal(t, j, w.Body)
+			}
+		})
+	}
+}
+
+func TestHandlerCreateLogStream_RangeAndReverse(t *testing.T) {
+	var logMessages []queries.LogMessage
+	for i := 0; i < 250; i++ {
+		logMessages = append(logMessages, queries.LogMessage{
+			Message:  someMessageWithTimestamp(time.UnixMilli(int64(i))),
+			Sequence: common.MustNewReceiveLogSequence(i),
+		})
+	}
+
+	testCases := []struct {
+		Name             string
+		Limit            *int
+		Reverse          bool
+		ExpectedMessages []int
+	}{
+		{
+			Name:             "forward",
+			ExpectedMessages: []int{100, 101, 102, 103, 104},
+		},
+		{
+			Name:             "reverse",
+			Reverse:          true,
+			ExpectedMessages: []int{104, 103, 102, 101, 100},
+		},
+		{
+			Name:             "reverse_with_limit",
+			Limit:            internal.Ptr(2),
+			Reverse:          true,
+			ExpectedMessages: []int{104, 103},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			queryHandler := newPagingReceiveLogQueryHandlerMock(logMessages)
+			h := rpc.NewHandlerCreateLogStream(queryHandler, newMessageSavedEventsQueryHandlerMock())
+
+			options := messages.NewMessageStreamOptions(true, true)
+
+			args, err := messages.NewCreateLogStreamArguments(
+				internal.Ptr(time.UnixMilli(100)),
+				internal.Ptr(time.UnixMilli(105)),
+				testCase.Limit,
+				messages.NewMessageStreamMode(false, true, testCase.Reverse),
+				options,
+			)
+			require.NoError(t, err)
+
+			req, err := messages.NewCreateLogStream(args)
+			require.NoError(t, err)
+
+			s := mocks.NewMockCloserStream()
+			err = h.Handle(fixtures.TestContext(t), s, req)
+			require.NoError(t, err)
+
+			var expected [][]byte
+			for _, i := range testCase.ExpectedMessages {
+				j, err := messages.NewMessageStreamResponse(logMessages[i].Message, options).MarshalJSON()
+				require.NoError(t, err)
+				expected = append(expected, j)
+			}
+
+			var written [][]byte
+			for _, w := range s.WrittenMessages() {
+				written = append(written, w.Body)
+			}
+
+			require.Equal(t, expected, written)
+		})
+	}
+}
+
+func TestHandlerCreateLogStream_Live(t *testing.T) {
+	testCases := []struct {
+		Name string
+		Old  bool
+	}{
+		{
+			Name: "old",
+			Old:  true,
+		},
+		{
+			Name: "only_live",
+			Old:  false,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(fixtures.TestContext(t))
+			defer cancel()
+
+			oldMessage := queries.LogMessage{
+				Message:  fixtures.SomeMessage(message.NewFirstSequence(), fixtures.SomeRefFeed()),
+				Sequence: common.MustNewReceiveLogSequence(0),
+			}
+
+			queryHandler := newPagingReceiveLogQueryHandlerMock([]queries.LogMessage{oldMessage})
+			messageSavedEvents := newMessageSavedEventsQueryHandlerMock()
+			h := rpc.NewHandlerCreateLogStream(queryHandler, messageSavedEvents)
+
+			options := messages.NewMessageStreamOptions(true, true)
+
+			limit := 1
+			expectedMessages := []queries.LogMessage{}
+			if testCase.Old {
+				limit = 2
+				expectedMessages = append(expectedMessages, oldMessage)
+			}
+
+			args, err := messages.NewCreateLogStreamArguments(
+				nil,
+				nil,
+				internal.Ptr(limit),
+				messages.NewMessageStreamMode(true, testCase.Old, false),
+				options,
+			)
+			require.NoError(t, err)
+
+			req, err := messages.NewCreateLogStream(args)
+			require.NoError(t, err)
+
+			s := mocks.NewMockCloserStream()
+
+			errCh := make(chan error)
+			go func() {
+				errCh <- h.Handle(ctx, s, req)
+			}()
+
+			require.Eventually(t, func() bool {
+				return queryHandler.NumberOfCalls() > 0 && len(s.WrittenMessages()) == len(expectedMessages)
+			}, 1*time.Second, 10*time.Millisecond)
+
+			newMessage := queries.LogMessage{
+				Message:  fixtures.SomeMessage(message.NewFirstSequence(), fixtures.SomeRefFeed()),
+				Sequence: common.MustNewReceiveLogSequence(1),
+			}
+			queryHandler.Append(newMessage)
+			messageSavedEvents.Publish(ctx, newMessage.Message)
+			expectedMessages = append(expectedMessages, newMessage)
+
+			select {
+			case err := <-errCh:
+				require.NoError(t, err)
+			case <-time.After(1 * time.Second):
+				t.Fatal("timeout")
+			}
+
+			var expected [][]byte
+			for _, logMessage := range expectedMessages {
+				j, err := messages.NewMessageStreamResponse(logMessage.Message, options).MarshalJSON()
+				require.NoError(t, err)
+				expected = append(expected, j)
+			}
+
+			var written [][]byte
+			for _, w := range s.WrittenMessages() {
+				written = append(written, w.Body)
+			}
+
+			require.Equal(t, expected, written)
+		})
+	}
+}
+
+func TestHandlerCreateLogStream_ReverseAndLiveOnlyRequestsDoNotReadTheEntireReceiveLog(t *testing.T) {
+	var logMessages []queries.LogMessage
+	for i := 0; i < 1000; i++ {
+		logMessages = append(logMessages, queries.LogMessage{
+			Message:  someMessageWithTimestamp(time.UnixMilli(int64(i))),
+			Sequence: common.MustNewReceiveLogSequence(i),
+		})
+	}
+
+	testCases := []struct {
+		Name                  string
+		Mode                  messages.MessageStreamMode
+		ExpectedNumberOfCalls int
+	}{
+		{
+			Name:                  "reverse",
+			Mode:                  messages.NewMessageStreamMode(false, true, true),
+			ExpectedNumberOfCalls: 2,
+		},
+		{
+			Name:                  "only_live",
+			Mode:                  messages.NewMessageStreamMode(true, false, false),
+			ExpectedNumberOfCalls: 1,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(fixtures.TestContext(t))
+			defer cancel()
+
+			queryHandler := newPagingReceiveLogQueryHandlerMock(logMessages)
+			h := rpc.NewHandlerCreateLogStream(queryHandler, newMessageSavedEventsQueryHandlerMock())
+
+			args, err := messages.NewCreateLogStreamArguments(
+				nil,
+				nil,
+				internal.Ptr(10),
+				testCase.Mode,
+				messages.NewMessageStreamOptions(false, false),
+			)
+			require.NoError(t, err)
+
+			req, err := messages.NewCreateLogStream(args)
+			require.NoError(t, err)
+
+			s := mocks.NewMockCloserStream()
+
+			errCh := make(chan error)
+			go func() {
+				errCh <- h.Handle(ctx, s, req)
+			}()
+
+			if testCase.Mode.Live() {
+				require.Eventually(t, func() bool {
+					return queryHandler.NumberOfCalls() >= testCase.ExpectedNumberOfCalls
+				}, 1*time.Second, 10*time.Millisecond)
+				cancel()
+			}
+
+			select {
+			case err := <-errCh:
+				require.NoError(t, err)
+			case <-time.After(1 * time.Second):
+				t.Fatal("timeout")
+			}
+
+			require.Equal(t, testCase.ExpectedNumberOfCalls, queryHandler.NumberOfCalls())
+			for _, query := range queryHandler.Queries() {
+				require.LessOrEqual(t, query.Limit(), 100)
+			}
+		})
+	}
+}
+
+func someMessageWithTimestamp(timestamp time.Time) message.Message {
+	return message.MustNewMessage(
+		fixtures.SomeRefMessage(),
+		nil,
+		message.NewFirstSequence(),
+		fixtures.SomeRefIdentity(),
+		fixtures.SomeRefFeed(),
+		timestamp,
+		fixtures.SomeContent(),
+		fixtures.SomeRawMessage(),
+	)
+}
+
+type pagingReceiveLogQueryHandlerMock struct {
+	logMessages []queries.LogMessage
+	queries     []queries.ReceiveLog
+	lock        sync.Mutex
+}
+
+func newPagingReceiveLogQueryHandlerMock(logMessages []queries.LogMessage) *pagingReceiveLogQueryHandlerMock {
+	return &pagingReceiveLogQueryHandlerMock{logMessages: logMessages}
+}
+
+func (p *pagingReceiveLogQueryHandlerMock) Append(logMessage queries.LogMessage) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.logMessages = append(p.logMessages, logMessage)
+}
+
+func (p *pagingReceiveLogQueryHandlerMock) NumberOfCalls() int {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	return len(p.queries)
+}
+
+func (p *pagingReceiveLogQueryHandlerMock) Queries() []queries.ReceiveLog {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	return p.queries
+}
+
+func (p *pagingReceiveLogQueryHandlerMock) Handle(query queries.ReceiveLog) ([]queries.LogMessage, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	p.queries = append(p.queries, query)
+
+	var matching []queries.LogMessage
+	for _, logMessage := range p.logMessages {
+		if logMessage.Sequence.Int() < query.StartSeq().Int() {
+			continue
+		}
+		if endSeq := query.EndSeq(); endSeq != nil && logMessage.Sequence.Int() >= endSeq.Int() {
+			continue
+		}
+		if !query.Timestamps().Includes(logMessage.Message.Timestamp()) {
+			continue
+		}
+		matching = append(matching, logMessage)
+	}
+
+	if query.Reverse() {
+		for i, j := 0, len(matching)-1; i < j; i, j = i+1, j-1 {
+			matching[i], matching[j] = matching[j], matching[i]
+		}
+	}
+
+	if len(matching) > query.Limit() {
+		matching = matching[:query.Limit()]
+	}
+
+	return matching, nil
+}
+
+type messageSavedEventsQueryHandlerMock struct {
+	subscriptions chan chan message.Message
+}
+
+func newMessageSavedEventsQueryHandlerMock() *messageSavedEventsQueryHandlerMock {
+	return &messageSavedEventsQueryHandlerMock{
+		subscriptions: make(chan chan message.Message, 1),
+	}
+}
+
+func (m *messageSavedEventsQueryHandlerMock) Handle(ctx context.Context) <-chan message.Message {
+	ch := make(chan message.Message)
+	m.subscriptions <- ch
+	go func() {
+		<-ctx.Done()
+		close(ch)
+	}()
+	return ch
+}
+
+func (m *messageSavedEventsQueryHandlerMock) Publish(ctx context.Context, msg message.Message) {
+	ch := <-m.subscriptions
+	select {
+	case ch <- msg:
+	case <-ctx.Done():
+	}
+}
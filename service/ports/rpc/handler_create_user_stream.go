@@ -0,0 +1,282 @@
+package rpc
+
+import (
+	"context"
+
+	"github.com/boreq/errors"
+	"github.com/planetary-social/scuttlego/internal"
+	"github.com/planetary-social/scuttlego/service/app/queries"
+	"github.com/planetary-social/scuttlego/service/domain/feeds/message"
+	"github.com/planetary-social/scuttlego/service/domain/messages"
+	"github.com/planetary-social/scuttlego/service/domain/refs"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc/mux"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc/transport"
+)
+
+// createUserStreamPageSize is the number of messages which are loaded from the
+// feed at once.
+const createUserStreamPageSize = 100
+
+type FeedMessagesQueryHandler interface {
+	Handle(query queries.FeedMessages) ([]message.Message, error)
+}
+
+// HandlerCreateUserStream streams messages from a single feed in the format
+// used by ssb-server. Messages are read from the feed page by page so that
+// the entire feed is never loaded at once.
+type HandlerCreateUserStream struct {
+	feedMessages       FeedMessagesQueryHandler
+	messageSavedEvents MessageSavedEventsQueryHandler
+}
+
+func NewHandlerCreateUserStream(
+	feedMessages FeedMessagesQueryHandler,
+	messageSavedEvents MessageSavedEventsQueryHandler,
+) *HandlerCreateUserStream {
+	return &HandlerCreateUserStream{
+		feedMessages:       feedMessages,
+		messageSavedEvents: messageSavedEvents,
+	}
+}
+
+func (h HandlerCreateUserStream) Procedure() rpc.Procedure {
+	return messages.CreateUserStreamProcedure
+}
+
+func (h HandlerCreateUserStream) Handle(ctx context.Context, s mux.Stream, req *rpc.Request) error {
+	args, err := messages.NewCreateUserStreamArgumentsFromBytes(req.Arguments())
+	if err != nil {
+		return errors.Wrap(err, "error parsing arguments")
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	w := newCreateUserStreamWriter(args, s)
+	if w.LimitReached() {
+		return nil
+	}
+
+	// Subscribing before reading the feed ensures that no messages are
+	// missed when switching to live mode.
+	var newMessages <-chan struct{}
+	if args.Mode().Live() {
+		newMessages = subscribeToSavedMessages(ctx, h.messageSavedEvents, func(msg message.Message) bool {
+			return msg.Feed().Equal(args.Id())
+		})
+	}
+
+	next, err := h.sendOld(args, w)
+	if err != nil {
+		return errors.Wrap(err, "error sending old messages")
+	}
+
+	if !args.Mode().Live() || w.LimitReached() {
+		return nil
+	}
+
+	for {
+		if lt := args.Lt(); lt != nil && next != nil && next.Int() >= lt.Int() {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-newMessages:
+		}
+
+		next, err = h.readFeed(args.Id(), next, args.Lt(), w.Write)
+		if err != nil {
+			return errors.Wrap(err, "error sending new messages")
+		}
+
+		if w.LimitReached() {
+			return nil
+		}
+	}
+}
+
+// sendOld sends messages which are already in the feed if the client
+// requested them and returns the sequence from which live messages should be
+// read.
+func (h HandlerCreateUserStream) sendOld(args messages.CreateUserStreamArguments, w *createUserStreamWriter) (*message.Sequence, error) {
+	if !args.Mode().Old() {
+		end, err := h.endOfFeed(args.Id())
+		if err != nil {
+			return nil, errors.Wrap(err, "error finding the end of the feed")
+		}
+
+		if gte := args.Gte(); end == nil || (gte != nil && gte.Int() > end.Int()) {
+			return gte, nil
+		}
+
+		return end, nil
+	}
+
+	if !args.Mode().Reverse() {
+		return h.readFeed(args.Id(), args.Gte(), args.Lt(), w.Write)
+	}
+
+	var next *message.Sequence
+	if err := h.readFeedInReverse(args.Id(), args.Gte(), args.Lt(), func(msg message.Message) (bool, error) {
+		if next == nil {
+			seq, err := message.NewSequence(msg.Sequence().Int() + 1)
+			if err != nil {
+				return false, errors.Wrap(err, "error creating the next sequence")
+			}
+			next = &seq
+		}
+		return w.Write(msg)
+	}); err != nil {
+		return nil, errors.Wrap(err, "error reading the feed in reverse")
+	}
+
+	if next == nil {
+		return args.Gte(), nil
+	}
+
+	return next, nil
+}
+
+// endOfFeed returns the sequence following the last message in the feed or
+// nil if the feed is empty.
+func (h HandlerCreateUserStream) endOfFeed(feed refs.Feed) (*message.Sequence, error) {
+	query, err := queries.NewFeedMessages(feed, nil, nil, true, 1)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating the query")
+	}
+
+	feedMessages, err := h.feedMessages.Handle(query)
+	if err != nil {
+		return nil, errors.Wrap(err, "error executing the query")
+	}
+
+	if len(feedMessages) == 0 {
+		return nil, nil
+	}
+
+	seq, err := message.NewSequence(feedMessages[0].Sequence().Int() + 1)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating the next sequence")
+	}
+
+	return &seq, nil
+}
+
+// readFeed passes messages from the feed with sequences greater or equal to
+// gte and lower than lt to the provided function until the function returns
+// false or the end of the range is reached. It returns the sequence following
+// the last message which was read.
+func (h HandlerCreateUserStream) readFeed(
+	feed refs.Feed,
+	gte, lt *message.Sequence,
+	fn func(msg message.Message) (bool, error),
+) (*message.Sequence, error) {
+	for {
+		query, err := queries.NewFeedMessages(feed, gte, lt, false, createUserStreamPageSize)
+		if err != nil {
+			return nil, errors.Wrap(err, "error creating the query")
+		}
+
+		feedMessages, err := h.feedMessages.Handle(query)
+		if err != nil {
+			return nil, errors.Wrap(err, "error executing the query")
+		}
+
+		for _, msg := range feedMessages {
+			seq, err := message.NewSequence(msg.Sequence().Int() + 1)
+			if err != nil {
+				return nil, errors.Wrap(err, "error creating the next sequence")
+			}
+			gte = &seq
+
+			ok, err := fn(msg)
+			if err != nil {
+				return nil, errors.Wrap(err, "function returned an error")
+			}
+
+			if !ok {
+				return gte, nil
+			}
+		}
+
+		if len(feedMessages) < createUserStreamPageSize {
+			return gte, nil
+		}
+	}
+}
+
+// readFeedInReverse passes messages from the feed with sequences greater or
+// equal to gte and lower than lt to the provided function, newest first,
+// until the function returns false or the beginning of the range is reached.
+func (h HandlerCreateUserStream) readFeedInReverse(
+	feed refs.Feed,
+	gte, lt *message.Sequence,
+	fn func(msg message.Message) (bool, error),
+) error {
+	for {
+		query, err := queries.NewFeedMessages(feed, gte, lt, true, createUserStreamPageSize)
+		if err != nil {
+			return errors.Wrap(err, "error creating the query")
+		}
+
+		feedMessages, err := h.feedMessages.Handle(query)
+		if err != nil {
+			return errors.Wrap(err, "error executing the query")
+		}
+
+		for _, msg := range feedMessages {
+			ok, err := fn(msg)
+			if err != nil {
+				return errors.Wrap(err, "function returned an error")
+			}
+
+			if !ok {
+				return nil
+			}
+		}
+
+		if len(feedMessages) < createUserStreamPageSize {
+			return nil
+		}
+
+		lt = internal.Ptr(feedMessages[len(feedMessages)-1].Sequence())
+	}
+}
+
+type createUserStreamWriter struct {
+	args messages.CreateUserStreamArguments
+	s    mux.Stream
+	sent int
+}
+
+func newCreateUserStreamWriter(args messages.CreateUserStreamArguments, s mux.Stream) *createUserStreamWriter {
+	return &createUserStreamWriter{args: args, s: s}
+}
+
+// Write sends the message and returns false once no more messages should be
+// sent.
+func (w *createUserStreamWriter) Write(msg message.Message) (bool, error) {
+	if w.LimitReached() {
+		return false, nil
+	}
+
+	j, err := messages.NewMessageStreamResponse(msg, w.args.Options()).MarshalJSON()
+	if err != nil {
+		return false, errors.Wrap(err, "json marshalling failed")
+	}
+
+	if err := w.s.WriteMessage(j, transport.MessageBodyTypeJSON); err != nil {
+		return false, errors.Wrap(err, "error writing the message")
+	}
+
+	w.sent++
+	return !w.LimitReached(), nil
+}
+
+func (w *createUserStreamWriter) LimitReached() bool {
+	limit := w.args.Limit()
+	return limit != nil && w.sent >= *limit
+}
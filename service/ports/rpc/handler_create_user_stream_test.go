@@ -0,0 +1,302 @@
+package rpc_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/planetary-social/scuttlego/internal"
+	"github.com/planetary-social/scuttlego/internal/fixtures"
+	"github.com/planetary-social/scuttlego/internal/mocks"
+	"github.com/planetary-social/scuttlego/service/app/queries"
+	"github.com/planetary-social/scuttlego/service/domain/feeds/message"
+	"github.com/planetary-social/scuttlego/service/domain/messages"
+	"github.com/planetary-social/scuttlego/service/ports/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandlerCreateUserStream(t *testing.T) {
+	feed := fixtures.SomeRefFeed()
+
+	var feedMessages []message.Message
+	for i := 1; i <= 10; i++ {
+		feedMessages = append(feedMessages, fixtures.SomeMessage(message.MustNewSequence(i), feed))
+	}
+
+	testCases := []struct {
+		Name             string
+		Gte              *message.Sequence
+		Lt               *message.Sequence
+		Limit            *int
+		Reverse          bool
+		ExpectedMessages []int
+	}{
+		{
+			Name:             "entire_feed",
+			ExpectedMessages: []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10},
+		},
+		{
+			Name:             "range",
+			Gte:              internal.Ptr(message.MustNewSequence(3)),
+			Lt:               internal.Ptr(message.MustNewSequence(6)),
+			ExpectedMessages: []int{3, 4, 5},
+		},
+		{
+			Name:             "range_with_limit",
+			Gte:              internal.Ptr(message.MustNewSequence(3)),
+			Lt:               internal.Ptr(message.MustNewSequence(6)),
+			Limit:            internal.Ptr(2),
+			ExpectedMessages: []int{3, 4},
+		},
+		{
+			Name:             "empty_range",
+			Gte:              internal.Ptr(message.MustNewSequence(6)),
+			Lt:               internal.Ptr(message.MustNewSequence(6)),
+			ExpectedMessages: nil,
+		},
+		{
+			Name:             "reverse",
+			Lt:               internal.Ptr(message.MustNewSequence(6)),
+			Reverse:          true,
+			ExpectedMessages: []int{5, 4, 3, 2, 1},
+		},
+		{
+			Name:             "reverse_with_limit",
+			Gte:              internal.Ptr(message.MustNewSequence(3)),
+			Limit:            internal.Ptr(3),
+			Reverse:          true,
+			ExpectedMessages: []int{10, 9, 8},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			feedMessagesHandler := newFeedMessagesQueryHandlerMock(feedMessages)
+			h := rpc.NewHandlerCreateUserStream(feedMessagesHandler, newMessageSavedEventsQueryHandlerMock())
+
+			require.Equal(t, messages.CreateUserStreamProcedure, h.Procedure())
+
+			options := messages.NewMessageStreamOptions(true, true)
+
+			args, err := messages.NewCreateUserStreamArguments(
+				feed,
+				testCase.Gte,
+				testCase.Lt,
+				testCase.Limit,
+				messages.NewMessageStreamMode(false, true, testCase.Reverse),
+				options,
+			)
+			require.NoError(t, err)
+
+			req, err := messages.NewCreateUserStream(args)
+			require.NoError(t, err)
+
+			s := mocks.NewMockCloserStream()
+			err = h.Handle(fixtures.TestContext(t), s, req)
+			require.NoError(t, err)
+
+			var expected [][]byte
+			for _, seq := range testCase.ExpectedMessages {
+				j, err := messages.NewMessageStreamResponse(feedMessages[seq-1], options).MarshalJSON()
+				require.NoError(t, err)
+				expected = append(expected, j)
+			}
+
+			var written [][]byte
+			for _, w := range s.WrittenMessages() {
+				written = append(written, w.Body)
+			}
+
+			require.Equal(t, expected, written)
+		})
+	}
+}
+
+func TestHandlerCreateUserStream_Live(t *testing.T) {
+	testCases := []struct {
+		Name    string
+		Old     bool
+		Reverse bool
+	}{
+		{
+			Name: "old",
+			Old:  true,
+		},
+		{
+			Name:    "old_reverse",
+			Old:     true,
+			Reverse: true,
+		},
+		{
+			Name: "only_live",
+			Old:  false,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(fixtures.TestContext(t))
+			defer cancel()
+
+			feed := fixtures.SomeRefFeed()
+			oldMessage := fixtures.SomeMessage(message.NewFirstSequence(), feed)
+
+			feedMessagesHandler := newFeedMessagesQueryHandlerMock([]message.Message{oldMessage})
+			messageSavedEvents := newMessageSavedEventsQueryHandlerMock()
+			h := rpc.NewHandlerCreateUserStream(feedMessagesHandler, messageSavedEvents)
+
+			options := messages.NewMessageStreamOptions(true, true)
+
+			limit := 1
+			var expectedMessages []message.Message
+			if testCase.Old {
+				limit = 2
+				expectedMessages = append(expectedMessages, oldMessage)
+			}
+
+			args, err := messages.NewCreateUserStreamArguments(
+				feed,
+				nil,
+				nil,
+				internal.Ptr(limit),
+				messages.NewMessageStreamMode(true, testCase.Old, testCase.Reverse),
+				options,
+			)
+			require.NoError(t, err)
+
+			req, err := messages.NewCreateUserStream(args)
+			require.NoError(t, err)
+
+			s := mocks.NewMockCloserStream()
+
+			errCh := make(chan error)
+			go func() {
+				errCh <- h.Handle(ctx, s, req)
+			}()
+
+			require.Eventually(t, func() bool {
+				return len(feedMessagesHandler.Queries()) > 0 && len(s.WrittenMessages()) == len(expectedMessages)
+			}, 1*time.Second, 10*time.Millisecond)
+
+			newMessage := fixtures.SomeMessage(message.MustNewSequence(2), feed)
+			feedMessagesHandler.Append(newMessage)
+			messageSavedEvents.Publish(ctx, newMessage)
+			expectedMessages = append(expectedMessages, newMessage)
+
+			select {
+			case err := <-errCh:
+				require.NoError(t, err)
+			case <-time.After(1 * time.Second):
+				t.Fatal("timeout")
+			}
+
+			var expected [][]byte
+			for _, msg := range expectedMessages {
+				j, err := messages.NewMessageStreamResponse(msg, options).MarshalJSON()
+				require.NoError(t, err)
+				expected = append(expected, j)
+			}
+
+			var written [][]byte
+			for _, w := range s.WrittenMessages() {
+				written = append(written, w.Body)
+			}
+
+			require.Equal(t, expected, written)
+		})
+	}
+}
+
+func TestHandlerCreateUserStream_ReverseReadsTheFeedInPages(t *testing.T) {
+	feed := fixtures.SomeRefFeed()
+
+	var feedMessages []message.Message
+	for i := 1; i <= 250; i++ {
+		feedMessages = append(feedMessages, fixtures.SomeMessage(message.MustNewSequence(i), feed))
+	}
+
+	feedMessagesHandler := newFeedMessagesQueryHandlerMock(feedMessages)
+	h := rpc.NewHandlerCreateUserStream(feedMessagesHandler, newMessageSavedEventsQueryHandlerMock())
+
+	args, err := messages.NewCreateUserStreamArguments(
+		feed,
+		nil,
+		nil,
+		nil,
+		messages.NewMessageStreamMode(false, true, true),
+		messages.NewMessageStreamOptions(false, false),
+	)
+	require.NoError(t, err)
+
+	req, err := messages.NewCreateUserStream(args)
+	require.NoError(t, err)
+
+	s := mocks.NewMockCloserStream()
+	err = h.Handle(fixtures.TestContext(t), s, req)
+	require.NoError(t, err)
+
+	require.Len(t, s.WrittenMessages(), len(feedMessages))
+	require.Len(t, feedMessagesHandler.Queries(), 3)
+	for _, query := range feedMessagesHandler.Queries() {
+		require.True(t, query.Reverse())
+		require.Equal(t, 100, query.Limit())
+	}
+}
+
+// feedMessagesQueryHandlerMock serves messages from a single feed.
+type feedMessagesQueryHandlerMock struct {
+	msgs    []message.Message
+	queries []queries.FeedMessages
+	lock    sync.Mutex
+}
+
+func newFeedMessagesQueryHandlerMock(msgs []message.Message) *feedMessagesQueryHandlerMock {
+	return &feedMessagesQueryHandlerMock{msgs: msgs}
+}
+
+func (m *feedMessagesQueryHandlerMock) Append(msg message.Message) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.msgs = append(m.msgs, msg)
+}
+
+func (m *feedMessagesQueryHandlerMock) Queries() []queries.FeedMessages {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return m.queries
+}
+
+func (m *feedMessagesQueryHandlerMock) Handle(query queries.FeedMessages) ([]message.Message, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.queries = append(m.queries, query)
+
+	var result []message.Message
+	for i := range m.msgs {
+		msg := m.msgs[i]
+		if query.Reverse() {
+			msg = m.msgs[len(m.msgs)-1-i]
+		}
+
+		if !msg.Feed().Equal(query.Feed()) {
+			continue
+		}
+
+		if query.Gte() != nil && msg.Sequence().Int() < query.Gte().Int() {
+			continue
+		}
+
+		if query.Lt() != nil && msg.Sequence().Int() >= query.Lt().Int() {
+			continue
+		}
+
+		result = append(result, msg)
+		if len(result) >= query.Limit() {
+			break
+		}
+	}
+
+	return result, nil
+}
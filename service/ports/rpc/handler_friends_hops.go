@@ -0,0 +1,63 @@
+package rpc
+
+import (
+	"context"
+
+	"github.com/boreq/errors"
+	"github.com/planetary-social/scuttlego/service/app/queries"
+	"github.com/planetary-social/scuttlego/service/domain/graph"
+	"github.com/planetary-social/scuttlego/service/domain/messages"
+	"github.com/planetary-social/scuttlego/service/domain/refs"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc/mux"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc/transport"
+)
+
+type FriendsHopsQueryHandler interface {
+	Handle(query queries.FriendsHops) (graph.SocialGraph, error)
+}
+
+type HandlerFriendsHops struct {
+	handler FriendsHopsQueryHandler
+}
+
+func NewHandlerFriendsHops(handler FriendsHopsQueryHandler) *HandlerFriendsHops {
+	return &HandlerFriendsHops{
+		handler: handler,
+	}
+}
+
+func (h HandlerFriendsHops) Procedure() rpc.Procedure {
+	return messages.FriendsHopsProcedure
+}
+
+func (h HandlerFriendsHops) Handle(ctx context.Context, s mux.Stream, req *rpc.Request) error {
+	args, err := messages.NewFriendsHopsArgumentsFromBytes(req.Arguments())
+	if err != nil {
+		return errors.Wrap(err, "invalid arguments")
+	}
+
+	var start refs.Identity
+	if args.Start() != nil {
+		start = *args.Start()
+	}
+
+	socialGraph, err := h.handler.Handle(queries.FriendsHops{
+		Start: start,
+		Max:   args.Max(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "error executing the query")
+	}
+
+	j, err := messages.NewFriendsHopsResponse(socialGraph.Contacts()).MarshalJSON()
+	if err != nil {
+		return errors.Wrap(err, "json marshalling failed")
+	}
+
+	if err := s.WriteMessage(j, transport.MessageBodyTypeJSON); err != nil {
+		return errors.Wrap(err, "error writing the message")
+	}
+
+	return nil
+}
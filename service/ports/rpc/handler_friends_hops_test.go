@@ -0,0 +1,67 @@
+package rpc_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/planetary-social/scuttlego/internal"
+	"github.com/planetary-social/scuttlego/internal/fixtures"
+	"github.com/planetary-social/scuttlego/internal/mocks"
+	"github.com/planetary-social/scuttlego/service/app/queries"
+	"github.com/planetary-social/scuttlego/service/domain/graph"
+	"github.com/planetary-social/scuttlego/service/domain/messages"
+	"github.com/planetary-social/scuttlego/service/ports/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandlerFriendsHops(t *testing.T) {
+	start := fixtures.SomeRefIdentity()
+	followee := fixtures.SomeRefIdentity()
+	max := graph.MustNewHops(2)
+
+	queryHandler := newFriendsHopsQueryHandlerMock(graph.NewSocialGraph(map[string]graph.Hops{
+		start.String():    graph.MustNewHops(0),
+		followee.String(): graph.MustNewHops(1),
+	}))
+	h := rpc.NewHandlerFriendsHops(queryHandler)
+
+	require.Equal(t, messages.FriendsHopsProcedure, h.Procedure())
+
+	args, err := messages.NewFriendsHopsArguments(internal.Ptr(start), internal.Ptr(max))
+	require.NoError(t, err)
+
+	req, err := messages.NewFriendsHops(args)
+	require.NoError(t, err)
+
+	s := mocks.NewMockCloserStream()
+	err = h.Handle(fixtures.TestContext(t), s, req)
+	require.NoError(t, err)
+
+	require.Equal(t,
+		[]queries.FriendsHops{
+			{
+				Start: start,
+				Max:   internal.Ptr(max),
+			},
+		},
+		queryHandler.calls,
+	)
+
+	written := s.WrittenMessages()
+	require.Len(t, written, 1)
+	require.JSONEq(t, fmt.Sprintf(`{"%s":0,"%s":1}`, start, followee), string(written[0].Body))
+}
+
+type friendsHopsQueryHandlerMock struct {
+	graph graph.SocialGraph
+	calls []queries.FriendsHops
+}
+
+func newFriendsHopsQueryHandlerMock(graph graph.SocialGraph) *friendsHopsQueryHandlerMock {
+	return &friendsHopsQueryHandlerMock{graph: graph}
+}
+
+func (f *friendsHopsQueryHandlerMock) Handle(query queries.FriendsHops) (graph.SocialGraph, error) {
+	f.calls = append(f.calls, query)
+	return f.graph, nil
+}
@@ -0,0 +1,28 @@
+package rpc
+
+import (
+	"context"
+
+	"github.com/planetary-social/scuttlego/service/domain/feeds"
+	"github.com/planetary-social/scuttlego/service/domain/messages"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc/mux"
+)
+
+type HandlerFriendsIsBlocking struct {
+	handler GetContactQueryHandler
+}
+
+func NewHandlerFriendsIsBlocking(handler GetContactQueryHandler) *HandlerFriendsIsBlocking {
+	return &HandlerFriendsIsBlocking{
+		handler: handler,
+	}
+}
+
+func (h HandlerFriendsIsBlocking) Procedure() rpc.Procedure {
+	return messages.FriendsIsBlockingProcedure
+}
+
+func (h HandlerFriendsIsBlocking) Handle(ctx context.Context, s mux.Stream, req *rpc.Request) error {
+	return handleFriendsRelation(s, req, h.handler, (*feeds.Contact).Blocking)
+}
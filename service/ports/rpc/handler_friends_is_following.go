@@ -0,0 +1,62 @@
+package rpc
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/boreq/errors"
+	"github.com/planetary-social/scuttlego/service/app/queries"
+	"github.com/planetary-social/scuttlego/service/domain/feeds"
+	"github.com/planetary-social/scuttlego/service/domain/messages"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc/mux"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc/transport"
+)
+
+type GetContactQueryHandler interface {
+	Handle(query queries.GetContact) (*feeds.Contact, error)
+}
+
+type HandlerFriendsIsFollowing struct {
+	handler GetContactQueryHandler
+}
+
+func NewHandlerFriendsIsFollowing(handler GetContactQueryHandler) *HandlerFriendsIsFollowing {
+	return &HandlerFriendsIsFollowing{
+		handler: handler,
+	}
+}
+
+func (h HandlerFriendsIsFollowing) Procedure() rpc.Procedure {
+	return messages.FriendsIsFollowingProcedure
+}
+
+func (h HandlerFriendsIsFollowing) Handle(ctx context.Context, s mux.Stream, req *rpc.Request) error {
+	return handleFriendsRelation(s, req, h.handler, (*feeds.Contact).Following)
+}
+
+func handleFriendsRelation(
+	s mux.Stream,
+	req *rpc.Request,
+	handler GetContactQueryHandler,
+	relation func(*feeds.Contact) bool,
+) error {
+	args, err := messages.NewFriendsRelationArgumentsFromBytes(req.Arguments())
+	if err != nil {
+		return errors.Wrap(err, "invalid arguments")
+	}
+
+	contact, err := handler.Handle(queries.GetContact{
+		Author: args.Source(),
+		Target: args.Dest(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "error executing the query")
+	}
+
+	if err := s.WriteMessage([]byte(strconv.FormatBool(relation(contact))), transport.MessageBodyTypeJSON); err != nil {
+		return errors.Wrap(err, "error writing the message")
+	}
+
+	return nil
+}
@@ -0,0 +1,90 @@
+package rpc_test
+
+import (
+	"testing"
+
+	"github.com/planetary-social/scuttlego/internal/fixtures"
+	"github.com/planetary-social/scuttlego/internal/mocks"
+	"github.com/planetary-social/scuttlego/service/app/queries"
+	"github.com/planetary-social/scuttlego/service/domain/feeds"
+	"github.com/planetary-social/scuttlego/service/domain/messages"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc/transport"
+	"github.com/planetary-social/scuttlego/service/ports/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandlerFriendsIsFollowingAndIsBlocking(t *testing.T) {
+	source := fixtures.SomeRefIdentity()
+	dest := fixtures.SomeRefIdentity()
+
+	queryHandler := newGetContactQueryHandlerMock(feeds.MustNewContactFromHistory(source, dest, true, false))
+
+	args, err := messages.NewFriendsRelationArguments(source, dest)
+	require.NoError(t, err)
+
+	t.Run("is_following", func(t *testing.T) {
+		h := rpc.NewHandlerFriendsIsFollowing(queryHandler)
+		require.Equal(t, messages.FriendsIsFollowingProcedure, h.Procedure())
+
+		req, err := messages.NewFriendsIsFollowing(args)
+		require.NoError(t, err)
+
+		s := mocks.NewMockCloserStream()
+		err = h.Handle(fixtures.TestContext(t), s, req)
+		require.NoError(t, err)
+
+		require.Equal(t,
+			[]mocks.MockCloserStreamWriteMessageCall{
+				{
+					Body:     []byte("true"),
+					BodyType: transport.MessageBodyTypeJSON,
+				},
+			},
+			s.WrittenMessages(),
+		)
+	})
+
+	t.Run("is_blocking", func(t *testing.T) {
+		h := rpc.NewHandlerFriendsIsBlocking(queryHandler)
+		require.Equal(t, messages.FriendsIsBlockingProcedure, h.Procedure())
+
+		req, err := messages.NewFriendsIsBlocking(args)
+		require.NoError(t, err)
+
+		s := mocks.NewMockCloserStream()
+		err = h.Handle(fixtures.TestContext(t), s, req)
+		require.NoError(t, err)
+
+		require.Equal(t,
+			[]mocks.MockCloserStreamWriteMessageCall{
+				{
+					Body:     []byte("false"),
+					BodyType: transport.MessageBodyTypeJSON,
+				},
+			},
+			s.WrittenMessages(),
+		)
+	})
+
+	require.Equal(t,
+		[]queries.GetContact{
+			{Author: source, Target: dest},
+			{Author: source, Target: dest},
+		},
+		queryHandler.calls,
+	)
+}
+
+type getContactQueryHandlerMock struct {
+	contact *feeds.Contact
+	calls   []queries.GetContact
+}
+
+func newGetContactQueryHandlerMock(contact *feeds.Contact) *getContactQueryHandlerMock {
+	return &getContactQueryHandlerMock{contact: contact}
+}
+
+func (g *getContactQueryHandlerMock) Handle(query queries.GetContact) (*feeds.Contact, error) {
+	g.calls = append(g.calls, query)
+	return g.contact, nil
+}
@@ -0,0 +1,83 @@
+package rpc
+
+import (
+	"context"
+
+	"github.com/boreq/errors"
+	"github.com/planetary-social/scuttlego/service/app/queries"
+	"github.com/planetary-social/scuttlego/service/domain/feeds"
+	"github.com/planetary-social/scuttlego/service/domain/messages"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc/mux"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc/transport"
+)
+
+type ContactsSubscribeQueryHandler interface {
+	Handle(ctx context.Context, fn queries.ContactsPageFn) (<-chan *feeds.Contact, error)
+}
+
+// HandlerFriendsStream sends the entire social graph and then, if the stream
+// is live, every change to it. Large graphs are sent in multiple graph
+// messages so that the entire graph doesn't have to be loaded at once. Live
+// streams remain open until they are closed by the remote.
+type HandlerFriendsStream struct {
+	handler ContactsSubscribeQueryHandler
+}
+
+func NewHandlerFriendsStream(handler ContactsSubscribeQueryHandler) *HandlerFriendsStream {
+	return &HandlerFriendsStream{
+		handler: handler,
+	}
+}
+
+func (h HandlerFriendsStream) Procedure() rpc.Procedure {
+	return messages.FriendsStreamProcedure
+}
+
+func (h HandlerFriendsStream) Handle(ctx context.Context, s mux.Stream, req *rpc.Request) error {
+	args, err := messages.NewFriendsStreamArgumentsFromBytes(req.Arguments())
+	if err != nil {
+		return errors.Wrap(err, "invalid arguments")
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	changes, err := h.handler.Handle(ctx, func(contacts []*feeds.Contact) error {
+		j, err := messages.NewFriendsStreamGraphResponse(contacts).MarshalJSON()
+		if err != nil {
+			return errors.Wrap(err, "json marshalling failed")
+		}
+
+		if err := s.WriteMessage(j, transport.MessageBodyTypeJSON); err != nil {
+			return errors.Wrap(err, "error writing the message")
+		}
+
+		return nil
+	})
+	if err != nil {
+		return errors.Wrap(err, "error executing the query")
+	}
+
+	if !args.Live() {
+		return nil
+	}
+
+	for contact := range changes {
+		response, err := messages.NewFriendsStreamChangeResponse(contact)
+		if err != nil {
+			return errors.Wrap(err, "error creating the response")
+		}
+
+		j, err := response.MarshalJSON()
+		if err != nil {
+			return errors.Wrap(err, "json marshalling failed")
+		}
+
+		if err := s.WriteMessage(j, transport.MessageBodyTypeJSON); err != nil {
+			return errors.Wrap(err, "error writing the message")
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,124 @@
+package rpc_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/planetary-social/scuttlego/internal/fixtures"
+	"github.com/planetary-social/scuttlego/internal/mocks"
+	"github.com/planetary-social/scuttlego/service/app/queries"
+	"github.com/planetary-social/scuttlego/service/domain/feeds"
+	"github.com/planetary-social/scuttlego/service/domain/messages"
+	"github.com/planetary-social/scuttlego/service/ports/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandlerFriendsStream(t *testing.T) {
+	a := fixtures.SomeRefIdentity()
+	b := fixtures.SomeRefIdentity()
+
+	stored := feeds.MustNewContactFromHistory(a, b, true, false)
+	change := feeds.MustNewContactFromHistory(a, b, true, true)
+
+	testCases := []struct {
+		Name             string
+		Live             bool
+		ExpectedMessages []string
+	}{
+		{
+			Name: "not_live",
+			Live: false,
+			ExpectedMessages: []string{
+				fmt.Sprintf(`{"%s":{"%s":1}}`, a, b),
+			},
+		},
+		{
+			Name: "live",
+			Live: true,
+			ExpectedMessages: []string{
+				fmt.Sprintf(`{"%s":{"%s":1}}`, a, b),
+				fmt.Sprintf(`{"from":"%s","to":"%s","value":-1}`, a, b),
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			queryHandler := newContactsSubscribeQueryHandlerMock(
+				[][]*feeds.Contact{{stored}},
+				[]*feeds.Contact{change},
+			)
+			h := rpc.NewHandlerFriendsStream(queryHandler)
+
+			require.Equal(t, messages.FriendsStreamProcedure, h.Procedure())
+
+			ctx := fixtures.TestContext(t)
+			s := mocks.NewMockCloserStream()
+
+			req, err := messages.NewFriendsStream(messages.NewFriendsStreamArguments(testCase.Live))
+			require.NoError(t, err)
+
+			err = h.Handle(ctx, s, req)
+			require.NoError(t, err)
+
+			written := s.WrittenMessages()
+			require.Len(t, written, len(testCase.ExpectedMessages))
+			for i, expected := range testCase.ExpectedMessages {
+				require.JSONEq(t, expected, string(written[i].Body))
+			}
+		})
+	}
+}
+
+func TestHandlerFriendsStream_SendsEveryPageOfContacts(t *testing.T) {
+	a := fixtures.SomeRefIdentity()
+	b := fixtures.SomeRefIdentity()
+	c := fixtures.SomeRefIdentity()
+
+	queryHandler := newContactsSubscribeQueryHandlerMock(
+		[][]*feeds.Contact{
+			{feeds.MustNewContactFromHistory(a, b, true, false)},
+			{feeds.MustNewContactFromHistory(a, c, false, true)},
+		},
+		nil,
+	)
+	h := rpc.NewHandlerFriendsStream(queryHandler)
+
+	s := mocks.NewMockCloserStream()
+
+	req, err := messages.NewFriendsStream(messages.NewFriendsStreamArguments(false))
+	require.NoError(t, err)
+
+	err = h.Handle(fixtures.TestContext(t), s, req)
+	require.NoError(t, err)
+
+	written := s.WrittenMessages()
+	require.Len(t, written, 2)
+	require.JSONEq(t, fmt.Sprintf(`{"%s":{"%s":1}}`, a, b), string(written[0].Body))
+	require.JSONEq(t, fmt.Sprintf(`{"%s":{"%s":-1}}`, a, c), string(written[1].Body))
+}
+
+type contactsSubscribeQueryHandlerMock struct {
+	pages   [][]*feeds.Contact
+	changes []*feeds.Contact
+}
+
+func newContactsSubscribeQueryHandlerMock(pages [][]*feeds.Contact, changes []*feeds.Contact) *contactsSubscribeQueryHandlerMock {
+	return &contactsSubscribeQueryHandlerMock{pages: pages, changes: changes}
+}
+
+func (c *contactsSubscribeQueryHandlerMock) Handle(ctx context.Context, fn queries.ContactsPageFn) (<-chan *feeds.Contact, error) {
+	for _, page := range c.pages {
+		if err := fn(page); err != nil {
+			return nil, err
+		}
+	}
+
+	ch := make(chan *feeds.Contact, len(c.changes))
+	for _, v := range c.changes {
+		ch <- v
+	}
+	close(ch)
+	return ch, nil
+}
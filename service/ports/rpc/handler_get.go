@@ -0,0 +1,57 @@
+package rpc
+
+import (
+	"context"
+
+	"github.com/boreq/errors"
+	"github.com/planetary-social/scuttlego/service/app/queries"
+	"github.com/planetary-social/scuttlego/service/domain/messages"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc/mux"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc/transport"
+)
+
+// HandlerGet returns a single message. Just like in ssb-server the raw
+// message is returned unless the client asks for metadata.
+type HandlerGet struct {
+	handler GetMessageQueryHandler
+}
+
+func NewHandlerGet(handler GetMessageQueryHandler) *HandlerGet {
+	return &HandlerGet{handler: handler}
+}
+
+func (h HandlerGet) Procedure() rpc.Procedure {
+	return messages.GetProcedure
+}
+
+func (h HandlerGet) Handle(ctx context.Context, s mux.Stream, req *rpc.Request) error {
+	args, err := messages.NewGetArgumentsFromBytes(req.Arguments())
+	if err != nil {
+		return errors.Wrap(err, "error parsing arguments")
+	}
+
+	query, err := queries.NewGetMessage(args.Id())
+	if err != nil {
+		return errors.Wrap(err, "error creating the query")
+	}
+
+	msg, err := h.handler.Handle(query)
+	if err != nil {
+		return errors.Wrap(err, "error executing the query")
+	}
+
+	j := msg.Raw().Bytes()
+	if args.Meta() {
+		j, err = messages.NewKeyValueTimestamp(msg).MarshalJSON()
+		if err != nil {
+			return errors.Wrap(err, "json marshalling failed")
+		}
+	}
+
+	if err := s.WriteMessage(j, transport.MessageBodyTypeJSON); err != nil {
+		return errors.Wrap(err, "error writing the message")
+	}
+
+	return nil
+}
@@ -0,0 +1,67 @@
+package rpc_test
+
+import (
+	"testing"
+
+	"github.com/planetary-social/scuttlego/internal/fixtures"
+	"github.com/planetary-social/scuttlego/internal/mocks"
+	"github.com/planetary-social/scuttlego/service/app/queries"
+	"github.com/planetary-social/scuttlego/service/domain/feeds/message"
+	"github.com/planetary-social/scuttlego/service/domain/messages"
+	"github.com/planetary-social/scuttlego/service/ports/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandlerGet(t *testing.T) {
+	msg := fixtures.SomeMessage(message.NewFirstSequence(), fixtures.SomeRefFeed())
+
+	kvt, err := messages.NewKeyValueTimestamp(msg).MarshalJSON()
+	require.NoError(t, err)
+
+	testCases := []struct {
+		Name             string
+		Meta             bool
+		ExpectedResponse []byte
+	}{
+		{
+			Name:             "raw",
+			Meta:             false,
+			ExpectedResponse: msg.Raw().Bytes(),
+		},
+		{
+			Name:             "meta",
+			Meta:             true,
+			ExpectedResponse: kvt,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			queryHandler := newGetMessageQueryHandlerMock(msg)
+			h := rpc.NewHandlerGet(queryHandler)
+
+			require.Equal(t, messages.GetProcedure, h.Procedure())
+
+			args, err := messages.NewGetArguments(msg.Id())
+			if testCase.Meta {
+				args, err = messages.NewGetArgumentsWithMeta(msg.Id())
+			}
+			require.NoError(t, err)
+
+			req, err := messages.NewGet(args)
+			require.NoError(t, err)
+
+			s := mocks.NewMockCloserStream()
+			err = h.Handle(fixtures.TestContext(t), s, req)
+			require.NoError(t, err)
+
+			expectedQuery, err := queries.NewGetMessage(msg.Id())
+			require.NoError(t, err)
+			require.Equal(t, []queries.GetMessage{expectedQuery}, queryHandler.calls)
+
+			written := s.WrittenMessages()
+			require.Len(t, written, 1)
+			require.Equal(t, testCase.ExpectedResponse, written[0].Body)
+		})
+	}
+}
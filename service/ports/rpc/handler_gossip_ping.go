@@ -0,0 +1,102 @@
+package rpc
+
+import (
+	"context"
+	"time"
+
+	"github.com/boreq/errors"
+	"github.com/planetary-social/scuttlego/service/domain/messages"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc/mux"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc/transport"
+)
+
+// defaultGossipPingTimeout is used if the remote didn't specify how long we
+// should wait for its pings.
+const defaultGossipPingTimeout = 5 * time.Minute
+
+type CurrentTimeProvider interface {
+	Get() time.Time
+}
+
+// HandlerGossipPing replies to each timestamp sent by the remote with the
+// current time so that the remote can measure the round-trip time and detect
+// dead connections. If the remote doesn't send a timestamp within the timeout
+// specified in the arguments then the stream is terminated.
+type HandlerGossipPing struct {
+	currentTimeProvider CurrentTimeProvider
+}
+
+func NewHandlerGossipPing(currentTimeProvider CurrentTimeProvider) *HandlerGossipPing {
+	return &HandlerGossipPing{
+		currentTimeProvider: currentTimeProvider,
+	}
+}
+
+func (h HandlerGossipPing) Procedure() rpc.Procedure {
+	return messages.GossipPingProcedure
+}
+
+func (h HandlerGossipPing) Handle(ctx context.Context, s mux.Stream, req *rpc.Request) error {
+	args, err := messages.NewGossipPingArgumentsFromBytes(req.Arguments())
+	if err != nil {
+		return errors.Wrap(err, "error parsing arguments")
+	}
+
+	timeout := args.Timeout()
+	if timeout == 0 {
+		timeout = defaultGossipPingTimeout
+	}
+
+	incomingMessages, err := s.IncomingMessages()
+	if err != nil {
+		return errors.Wrap(err, "error getting incoming messages")
+	}
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timer.C:
+			return errors.New("remote didn't send a ping before the timeout")
+		case msg, ok := <-incomingMessages:
+			if !ok {
+				return nil
+			}
+
+			if _, err := messages.NewGossipPingTimestampFromBytes(msg.Body); err != nil {
+				return errors.Wrap(err, "error parsing the timestamp")
+			}
+
+			if err := h.reply(s); err != nil {
+				return errors.Wrap(err, "error replying")
+			}
+
+			if !timer.Stop() {
+				<-timer.C
+			}
+			timer.Reset(timeout)
+		}
+	}
+}
+
+func (h HandlerGossipPing) reply(s mux.Stream) error {
+	timestamp, err := messages.NewGossipPingTimestamp(h.currentTimeProvider.Get())
+	if err != nil {
+		return errors.Wrap(err, "error creating the timestamp")
+	}
+
+	j, err := timestamp.MarshalJSON()
+	if err != nil {
+		return errors.Wrap(err, "error marshaling the timestamp")
+	}
+
+	if err := s.WriteMessage(j, transport.MessageBodyTypeJSON); err != nil {
+		return errors.Wrap(err, "error writing the message")
+	}
+
+	return nil
+}
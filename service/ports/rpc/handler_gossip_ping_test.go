@@ -0,0 +1,127 @@
+package rpc_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/planetary-social/scuttlego/internal/fixtures"
+	"github.com/planetary-social/scuttlego/internal/mocks"
+	"github.com/planetary-social/scuttlego/service/domain/messages"
+	transportrpc "github.com/planetary-social/scuttlego/service/domain/transport/rpc"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc/transport"
+	"github.com/planetary-social/scuttlego/service/ports/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandlerGossipPing_RepliesToEachTimestampWithCurrentTime(t *testing.T) {
+	currentTimeProvider := mocks.NewCurrentTimeProviderMock()
+	currentTimeProvider.CurrentTime = time.UnixMilli(1670000000000)
+
+	handler := rpc.NewHandlerGossipPing(currentTimeProvider)
+	require.Equal(t, messages.GossipPingProcedure, handler.Procedure())
+
+	ctx, cancel := context.WithCancel(fixtures.TestContext(t))
+	defer cancel()
+
+	incomingMessages := make(chan transportrpc.IncomingMessage)
+	s := mocks.NewMockCloserStream()
+	s.MockIncomingMessages(incomingMessages)
+
+	req, err := messages.NewGossipPing(messages.MustNewGossipPingArguments(5 * time.Second))
+	require.NoError(t, err)
+
+	errCh := make(chan error)
+	go func() {
+		errCh <- handler.Handle(ctx, s, req)
+	}()
+
+	const numberOfPings = 3
+
+	for i := 0; i < numberOfPings; i++ {
+		incomingMessages <- transportrpc.IncomingMessage{Body: []byte(`1660000000000`)}
+	}
+
+	require.Eventually(t,
+		func() bool {
+			return len(s.WrittenMessages()) == numberOfPings
+		},
+		1*time.Second, 10*time.Millisecond,
+	)
+
+	for _, msg := range s.WrittenMessages() {
+		require.Equal(t, transport.MessageBodyTypeJSON, msg.BodyType)
+		require.Equal(t, []byte(`1670000000000`), msg.Body)
+	}
+
+	cancel()
+
+	select {
+	case err := <-errCh:
+		require.EqualError(t, err, "context canceled")
+	case <-time.After(1 * time.Second):
+		t.Fatal("timeout, the handler is still blocking")
+	}
+}
+
+func TestHandlerGossipPing_ReturnsAnErrorIfTimestampIsInvalid(t *testing.T) {
+	handler := rpc.NewHandlerGossipPing(mocks.NewCurrentTimeProviderMock())
+
+	ctx := fixtures.TestContext(t)
+
+	incomingMessages := make(chan transportrpc.IncomingMessage)
+	s := mocks.NewMockCloserStream()
+	s.MockIncomingMessages(incomingMessages)
+
+	req, err := messages.NewGossipPing(messages.MustNewGossipPingArguments(0))
+	require.NoError(t, err)
+
+	errCh := make(chan error)
+	go func() {
+		errCh <- handler.Handle(ctx, s, req)
+	}()
+
+	incomingMessages <- transportrpc.IncomingMessage{Body: []byte(`"not a timestamp"`)}
+
+	select {
+	case err := <-errCh:
+		require.ErrorContains(t, err, "error parsing the timestamp")
+	case <-time.After(1 * time.Second):
+		t.Fatal("timeout, the handler is still blocking")
+	}
+	require.Empty(t, s.WrittenMessages())
+}
+
+func TestHandlerGossipPing_ReturnsAnErrorIfRemoteDoesNotPingBeforeTheTimeout(t *testing.T) {
+	handler := rpc.NewHandlerGossipPing(mocks.NewCurrentTimeProviderMock())
+
+	ctx := fixtures.TestContext(t)
+
+	incomingMessages := make(chan transportrpc.IncomingMessage)
+	s := mocks.NewMockCloserStream()
+	s.MockIncomingMessages(incomingMessages)
+
+	const timeout = 100 * time.Millisecond
+
+	req, err := messages.NewGossipPing(messages.MustNewGossipPingArguments(timeout))
+	require.NoError(t, err)
+
+	errCh := make(chan error)
+	go func() {
+		errCh <- handler.Handle(ctx, s, req)
+	}()
+
+	// pinging resets the timeout
+	for i := 0; i < 3; i++ {
+		<-time.After(timeout / 2)
+		incomingMessages <- transportrpc.IncomingMessage{Body: []byte(`1660000000000`)}
+	}
+
+	select {
+	case err := <-errCh:
+		require.EqualError(t, err, "remote didn't send a ping before the timeout")
+	case <-time.After(1 * time.Second):
+		t.Fatal("timeout, the handler is still blocking")
+	}
+	require.Len(t, s.WrittenMessages(), 3)
+}
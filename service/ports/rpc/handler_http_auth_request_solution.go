@@ -0,0 +1,63 @@
+package rpc
+
+import (
+	"context"
+
+	"github.com/boreq/errors"
+	"github.com/planetary-social/scuttlego/service/app/commands"
+	"github.com/planetary-social/scuttlego/service/domain/messages"
+	"github.com/planetary-social/scuttlego/service/domain/refs"
+	"github.com/planetary-social/scuttlego/service/domain/rooms/httpauth"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc/mux"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc/transport"
+)
+
+type RoomsHttpAuthSolveChallengeCommandHandler interface {
+	Handle(cmd commands.RoomsHttpAuthSolveChallenge) (httpauth.Solution, error)
+}
+
+type HandlerHttpAuthRequestSolution struct {
+	handler RoomsHttpAuthSolveChallengeCommandHandler
+}
+
+func NewHandlerHttpAuthRequestSolution(handler RoomsHttpAuthSolveChallengeCommandHandler) *HandlerHttpAuthRequestSolution {
+	return &HandlerHttpAuthRequestSolution{handler: handler}
+}
+
+func (h HandlerHttpAuthRequestSolution) Procedure() rpc.Procedure {
+	return messages.HttpAuthRequestSolutionProcedure
+}
+
+func (h HandlerHttpAuthRequestSolution) Handle(ctx context.Context, s mux.Stream, req *rpc.Request) error {
+	args, err := messages.NewHttpAuthRequestSolutionArgumentsFromBytes(req.Arguments())
+	if err != nil {
+		return errors.Wrap(err, "error parsing arguments")
+	}
+
+	remote, ok := rpc.GetRemoteIdentityFromContext(ctx)
+	if !ok {
+		return errors.New("remote identity not found in context")
+	}
+
+	room, err := refs.NewIdentityFromPublic(remote)
+	if err != nil {
+		return errors.Wrap(err, "error creating the room ref")
+	}
+
+	cmd, err := commands.NewRoomsHttpAuthSolveChallenge(room, args.Sc(), args.Cc())
+	if err != nil {
+		return errors.Wrap(err, "error creating the command")
+	}
+
+	solution, err := h.handler.Handle(cmd)
+	if err != nil {
+		return errors.Wrap(err, "error executing the command")
+	}
+
+	if err := s.WriteMessage([]byte(solution.String()), transport.MessageBodyTypeString); err != nil {
+		return errors.Wrap(err, "error writing the message")
+	}
+
+	return nil
+}
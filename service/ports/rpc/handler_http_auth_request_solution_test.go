@@ -0,0 +1,102 @@
+package rpc_test
+
+import (
+	"testing"
+
+	"github.com/boreq/errors"
+	"github.com/planetary-social/scuttlego/internal/fixtures"
+	"github.com/planetary-social/scuttlego/internal/mocks"
+	"github.com/planetary-social/scuttlego/service/app/commands"
+	"github.com/planetary-social/scuttlego/service/domain/messages"
+	"github.com/planetary-social/scuttlego/service/domain/refs"
+	"github.com/planetary-social/scuttlego/service/domain/rooms/httpauth"
+	transportrpc "github.com/planetary-social/scuttlego/service/domain/transport/rpc"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc/transport"
+	"github.com/planetary-social/scuttlego/service/ports/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandlerHttpAuthRequestSolution_WritesSolution(t *testing.T) {
+	commandHandler := newRoomsHttpAuthSolveChallengeCommandHandlerMock()
+	h := rpc.NewHandlerHttpAuthRequestSolution(commandHandler)
+
+	require.Equal(t, messages.HttpAuthRequestSolutionProcedure, h.Procedure())
+
+	remote := fixtures.SomePublicIdentity()
+	ctx := transportrpc.PutRemoteIdentityInContext(fixtures.TestContext(t), remote)
+	s := mocks.NewMockCloserStream()
+
+	sc := httpauth.MustNewChallenge()
+	cc := httpauth.MustNewChallenge()
+
+	local := fixtures.SomePrivateIdentity()
+
+	msg, err := httpauth.NewSignInMessage(
+		refs.MustNewIdentityFromPublic(remote),
+		refs.MustNewIdentityFromPublic(local.Public()),
+		sc,
+		cc,
+	)
+	require.NoError(t, err)
+
+	commandHandler.Solution, err = httpauth.NewSolution(msg, local)
+	require.NoError(t, err)
+
+	args, err := messages.NewHttpAuthRequestSolutionArguments(sc, cc)
+	require.NoError(t, err)
+
+	req, err := messages.NewHttpAuthRequestSolution(args)
+	require.NoError(t, err)
+
+	err = h.Handle(ctx, s, req)
+	require.NoError(t, err)
+
+	require.Equal(t,
+		[]commands.RoomsHttpAuthSolveChallenge{
+			commands.MustNewRoomsHttpAuthSolveChallenge(refs.MustNewIdentityFromPublic(remote), sc, cc),
+		},
+		commandHandler.Calls,
+	)
+
+	written := s.WrittenMessages()
+	require.Len(t, written, 1)
+	require.Equal(t, transport.MessageBodyTypeString, written[0].BodyType)
+	require.Equal(t, commandHandler.Solution.String(), string(written[0].Body))
+}
+
+func TestHandlerHttpAuthRequestSolution_ReturnsErrorsAndWritesNothingIfChallengeCanNotBeSolved(t *testing.T) {
+	commandHandler := newRoomsHttpAuthSolveChallengeCommandHandlerMock()
+	commandHandler.Error = commands.ErrClientChallengeNotFound
+	h := rpc.NewHandlerHttpAuthRequestSolution(commandHandler)
+
+	ctx := transportrpc.PutRemoteIdentityInContext(fixtures.TestContext(t), fixtures.SomePublicIdentity())
+	s := mocks.NewMockCloserStream()
+
+	args, err := messages.NewHttpAuthRequestSolutionArguments(httpauth.MustNewChallenge(), httpauth.MustNewChallenge())
+	require.NoError(t, err)
+
+	req, err := messages.NewHttpAuthRequestSolution(args)
+	require.NoError(t, err)
+
+	err = h.Handle(ctx, s, req)
+	require.ErrorIs(t, err, commands.ErrClientChallengeNotFound)
+	require.Empty(t, s.WrittenMessages())
+}
+
+type roomsHttpAuthSolveChallengeCommandHandlerMock struct {
+	Calls    []commands.RoomsHttpAuthSolveChallenge
+	Solution httpauth.Solution
+	Error    error
+}
+
+func newRoomsHttpAuthSolveChallengeCommandHandlerMock() *roomsHttpAuthSolveChallengeCommandHandlerMock {
+	return &roomsHttpAuthSolveChallengeCommandHandlerMock{}
+}
+
+func (m *roomsHttpAuthSolveChallengeCommandHandlerMock) Handle(cmd commands.RoomsHttpAuthSolveChallenge) (httpauth.Solution, error) {
+	m.Calls = append(m.Calls, cmd)
+	if m.Error != nil {
+		return httpauth.Solution{}, errors.Wrap(m.Error, "mock error")
+	}
+	return m.Solution, nil
+}
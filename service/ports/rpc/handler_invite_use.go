@@ -0,0 +1,93 @@
+package rpc
+
+import (
+	"context"
+
+	"github.com/boreq/errors"
+	"github.com/planetary-social/scuttlego/service/app/commands"
+	"github.com/planetary-social/scuttlego/service/app/queries"
+	"github.com/planetary-social/scuttlego/service/domain/feeds/message"
+	"github.com/planetary-social/scuttlego/service/domain/messages"
+	"github.com/planetary-social/scuttlego/service/domain/refs"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc/mux"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc/transport"
+)
+
+type GetMessageQueryHandler interface {
+	Handle(query queries.GetMessage) (message.Message, error)
+}
+
+type UseInviteCommandHandler interface {
+	Handle(cmd commands.UseInvite) (refs.Message, error)
+}
+
+// HandlerInviteUse lets peers which connected using an identity derived from
+// an invite seed use that invite. It responds with the published follow
+// message in the format used by ssb-server.
+type HandlerInviteUse struct {
+	handler    UseInviteCommandHandler
+	getMessage GetMessageQueryHandler
+}
+
+func NewHandlerInviteUse(
+	handler UseInviteCommandHandler,
+	getMessage GetMessageQueryHandler,
+) *HandlerInviteUse {
+	return &HandlerInviteUse{
+		handler:    handler,
+		getMessage: getMessage,
+	}
+}
+
+func (h HandlerInviteUse) Procedure() rpc.Procedure {
+	return messages.InviteUseProcedure
+}
+
+func (h HandlerInviteUse) Handle(ctx context.Context, s mux.Stream, req *rpc.Request) error {
+	args, err := messages.NewInviteUseArgumentsFromBytes(req.Arguments())
+	if err != nil {
+		return errors.Wrap(err, "error parsing arguments")
+	}
+
+	remote, ok := rpc.GetRemoteIdentityFromContext(ctx)
+	if !ok {
+		return errors.New("remote identity not found in context")
+	}
+
+	invite, err := refs.NewIdentityFromPublic(remote)
+	if err != nil {
+		return errors.Wrap(err, "error creating the invite ref")
+	}
+
+	cmd, err := commands.NewUseInvite(invite, args.Feed())
+	if err != nil {
+		return errors.Wrap(err, "error creating the command")
+	}
+
+	id, err := h.handler.Handle(cmd)
+	if err != nil {
+		return errors.Wrap(err, "error executing the command")
+	}
+
+	query, err := queries.NewGetMessage(id)
+	if err != nil {
+		return errors.Wrap(err, "error creating the query")
+	}
+
+	msg, err := h.getMessage.Handle(query)
+	if err != nil {
+		return errors.Wrap(err, "error getting the follow message")
+	}
+
+	j, err := messages.NewKeyValueTimestamp(msg).MarshalJSON()
+	if err != nil {
+		return errors.Wrap(err, "json marshalling failed")
+	}
+
+	if err := s.WriteMessage(j, transport.MessageBodyTypeJSON); err != nil {
+		return errors.Wrap(err, "error writing the message")
+	}
+
+	return nil
+}
@@ -0,0 +1,114 @@
+package rpc_test
+
+import (
+	"testing"
+
+	"github.com/boreq/errors"
+	"github.com/planetary-social/scuttlego/internal/fixtures"
+	"github.com/planetary-social/scuttlego/internal/mocks"
+	"github.com/planetary-social/scuttlego/service/app/commands"
+	"github.com/planetary-social/scuttlego/service/app/queries"
+	"github.com/planetary-social/scuttlego/service/domain/feeds/message"
+	"github.com/planetary-social/scuttlego/service/domain/messages"
+	"github.com/planetary-social/scuttlego/service/domain/refs"
+	transportrpc "github.com/planetary-social/scuttlego/service/domain/transport/rpc"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc/transport"
+	"github.com/planetary-social/scuttlego/service/ports/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandlerInviteUse_UsesTheInviteIdentifiedByTheRemoteIdentity(t *testing.T) {
+	followMessage := fixtures.SomeMessage(fixtures.SomeSequence(), fixtures.SomeRefFeed())
+
+	handler := newUseInviteCommandHandlerMock()
+	handler.id = followMessage.Id()
+	getMessage := newGetMessageQueryHandlerMock(followMessage)
+	h := rpc.NewHandlerInviteUse(handler, getMessage)
+
+	remote := fixtures.SomePublicIdentity()
+	feed := fixtures.SomeRefIdentity()
+
+	ctx := transportrpc.PutRemoteIdentityInContext(fixtures.TestContext(t), remote)
+	s := mocks.NewMockCloserStream()
+
+	req := someInviteUseRequest(t, feed)
+
+	err := h.Handle(ctx, s, req)
+	require.NoError(t, err)
+
+	require.Equal(t,
+		[]commands.UseInvite{
+			commands.MustNewUseInvite(refs.MustNewIdentityFromPublic(remote), feed),
+		},
+		handler.calls,
+	)
+	expectedQuery, err := queries.NewGetMessage(followMessage.Id())
+	require.NoError(t, err)
+	require.Equal(t, []queries.GetMessage{expectedQuery}, getMessage.calls)
+
+	expectedResponse, err := messages.NewKeyValueTimestamp(followMessage).MarshalJSON()
+	require.NoError(t, err)
+
+	writtenMessages := s.WrittenMessages()
+	require.Len(t, writtenMessages, 1)
+	require.Equal(t, transport.MessageBodyTypeJSON, writtenMessages[0].BodyType)
+	require.Equal(t, expectedResponse, writtenMessages[0].Body)
+}
+
+func TestHandlerInviteUse_ReturnsErrorsFromTheCommandHandler(t *testing.T) {
+	handler := newUseInviteCommandHandlerMock()
+	handler.err = errors.New("already following")
+	h := rpc.NewHandlerInviteUse(handler, newGetMessageQueryHandlerMock(message.Message{}))
+
+	ctx := transportrpc.PutRemoteIdentityInContext(fixtures.TestContext(t), fixtures.SomePublicIdentity())
+	s := mocks.NewMockCloserStream()
+
+	req := someInviteUseRequest(t, fixtures.SomeRefIdentity())
+
+	err := h.Handle(ctx, s, req)
+	require.ErrorContains(t, err, "already following")
+	require.Empty(t, s.WrittenMessages())
+}
+
+func someInviteUseRequest(t *testing.T, feed refs.Identity) *transportrpc.Request {
+	args, err := messages.NewInviteUseArguments(feed)
+	require.NoError(t, err)
+
+	req, err := messages.NewInviteUse(args)
+	require.NoError(t, err)
+
+	return req
+}
+
+type useInviteCommandHandlerMock struct {
+	calls []commands.UseInvite
+	id    refs.Message
+	err   error
+}
+
+func newUseInviteCommandHandlerMock() *useInviteCommandHandlerMock {
+	return &useInviteCommandHandlerMock{}
+}
+
+func (u *useInviteCommandHandlerMock) Handle(cmd commands.UseInvite) (refs.Message, error) {
+	u.calls = append(u.calls, cmd)
+	if u.err != nil {
+		return refs.Message{}, u.err
+	}
+	return u.id, nil
+}
+
+type getMessageQueryHandlerMock struct {
+	msg   message.Message
+	err   error
+	calls []queries.GetMessage
+}
+
+func newGetMessageQueryHandlerMock(msg message.Message) *getMessageQueryHandlerMock {
+	return &getMessageQueryHandlerMock{msg: msg}
+}
+
+func (g *getMessageQueryHandlerMock) Handle(query queries.GetMessage) (message.Message, error) {
+	g.calls = append(g.calls, query)
+	return g.msg, g.err
+}
@@ -0,0 +1,61 @@
+package rpc
+
+import (
+	"context"
+
+	"github.com/boreq/errors"
+	"github.com/planetary-social/scuttlego/service/app/queries"
+	"github.com/planetary-social/scuttlego/service/domain/feeds/message"
+	"github.com/planetary-social/scuttlego/service/domain/messages"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc/mux"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc/transport"
+)
+
+type LatestSequenceQueryHandler interface {
+	Handle(query queries.LatestSequence) (message.Sequence, error)
+}
+
+type HandlerLatestSequence struct {
+	handler LatestSequenceQueryHandler
+}
+
+func NewHandlerLatestSequence(handler LatestSequenceQueryHandler) *HandlerLatestSequence {
+	return &HandlerLatestSequence{
+		handler: handler,
+	}
+}
+
+func (h HandlerLatestSequence) Procedure() rpc.Procedure {
+	return messages.LatestSequenceProcedure
+}
+
+func (h HandlerLatestSequence) Handle(ctx context.Context, s mux.Stream, req *rpc.Request) error {
+	args, err := messages.NewLatestSequenceArgumentsFromBytes(req.Arguments())
+	if err != nil {
+		return errors.Wrap(err, "invalid arguments")
+	}
+
+	sequence, err := h.handler.Handle(queries.LatestSequence{
+		Feed: args.Id(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "error executing the query")
+	}
+
+	response, err := messages.NewLatestSequenceResponse(sequence)
+	if err != nil {
+		return errors.Wrap(err, "error creating the response")
+	}
+
+	j, err := response.MarshalJSON()
+	if err != nil {
+		return errors.Wrap(err, "json marshalling failed")
+	}
+
+	if err := s.WriteMessage(j, transport.MessageBodyTypeJSON); err != nil {
+		return errors.Wrap(err, "error writing the message")
+	}
+
+	return nil
+}
@@ -0,0 +1,52 @@
+package rpc_test
+
+import (
+	"testing"
+
+	"github.com/planetary-social/scuttlego/internal/fixtures"
+	"github.com/planetary-social/scuttlego/internal/mocks"
+	"github.com/planetary-social/scuttlego/service/app/queries"
+	"github.com/planetary-social/scuttlego/service/domain/feeds/message"
+	"github.com/planetary-social/scuttlego/service/domain/messages"
+	"github.com/planetary-social/scuttlego/service/ports/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandlerLatestSequence(t *testing.T) {
+	feed := fixtures.SomeRefFeed()
+
+	queryHandler := newLatestSequenceQueryHandlerMock(message.MustNewSequence(123))
+	h := rpc.NewHandlerLatestSequence(queryHandler)
+
+	require.Equal(t, messages.LatestSequenceProcedure, h.Procedure())
+
+	args, err := messages.NewLatestSequenceArguments(feed)
+	require.NoError(t, err)
+
+	req, err := messages.NewLatestSequence(args)
+	require.NoError(t, err)
+
+	s := mocks.NewMockCloserStream()
+	err = h.Handle(fixtures.TestContext(t), s, req)
+	require.NoError(t, err)
+
+	require.Equal(t, []queries.LatestSequence{{Feed: feed}}, queryHandler.calls)
+
+	written := s.WrittenMessages()
+	require.Len(t, written, 1)
+	require.Equal(t, `123`, string(written[0].Body))
+}
+
+type latestSequenceQueryHandlerMock struct {
+	sequence message.Sequence
+	calls    []queries.LatestSequence
+}
+
+func newLatestSequenceQueryHandlerMock(sequence message.Sequence) *latestSequenceQueryHandlerMock {
+	return &latestSequenceQueryHandlerMock{sequence: sequence}
+}
+
+func (l *latestSequenceQueryHandlerMock) Handle(query queries.LatestSequence) (message.Sequence, error) {
+	l.calls = append(l.calls, query)
+	return l.sequence, nil
+}
@@ -0,0 +1,76 @@
+package rpc
+
+import (
+	"context"
+
+	"github.com/boreq/errors"
+	"github.com/planetary-social/scuttlego/service/domain/messages"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc/mux"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc/transport"
+)
+
+// HandlerManifest describes the procedures implemented by the other handlers
+// so that the manifest never goes out of date. Privileged procedures are only
+// listed for privileged connections.
+type HandlerManifest struct {
+	manifest           messages.ManifestResponse
+	privilegedManifest messages.ManifestResponse
+}
+
+func NewHandlerManifest(
+	handlers []mux.Handler,
+	synchronousHandlers []mux.SynchronousHandler,
+	privilegedHandlers mux.PrivilegedHandlers,
+) (*HandlerManifest, error) {
+	procedures := []rpc.Procedure{messages.ManifestProcedure}
+
+	for _, handler := range handlers {
+		procedures = append(procedures, handler.Procedure())
+	}
+
+	for _, handler := range synchronousHandlers {
+		procedures = append(procedures, handler.Procedure())
+	}
+
+	manifest, err := messages.NewManifestResponse(procedures)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating the manifest")
+	}
+
+	for _, handler := range privilegedHandlers {
+		procedures = append(procedures, handler.Procedure())
+	}
+
+	privilegedManifest, err := messages.NewManifestResponse(procedures)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating the privileged manifest")
+	}
+
+	return &HandlerManifest{
+		manifest:           manifest,
+		privilegedManifest: privilegedManifest,
+	}, nil
+}
+
+func (h HandlerManifest) Procedure() rpc.Procedure {
+	return messages.ManifestProcedure
+}
+
+func (h HandlerManifest) Handle(ctx context.Context, s mux.Stream, req *rpc.Request) error {
+	manifest := h.manifest
+	if rpc.IsPrivilegedConnection(ctx) {
+		manifest = h.privilegedManifest
+	}
+
+	j, err := manifest.MarshalJSON()
+	if err != nil {
+		return errors.Wrap(err, "error marshaling the manifest")
+	}
+
+	if err := s.WriteMessage(j, transport.MessageBodyTypeJSON); err != nil {
+		return errors.Wrap(err, "error writing the message")
+	}
+
+	return nil
+}
@@ -0,0 +1,175 @@
+package rpc_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/planetary-social/scuttlego/internal/fixtures"
+	"github.com/planetary-social/scuttlego/internal/mocks"
+	"github.com/planetary-social/scuttlego/service/domain/messages"
+	transportrpc "github.com/planetary-social/scuttlego/service/domain/transport/rpc"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc/mux"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc/transport"
+	"github.com/planetary-social/scuttlego/service/ports/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandlerManifest_ListsProceduresOfHandlers(t *testing.T) {
+	t.Parallel()
+
+	handlerProcedure := transportrpc.MustNewProcedure(
+		transportrpc.MustNewProcedureName([]string{"some", "handler"}),
+		transportrpc.ProcedureTypeDuplex,
+	)
+
+	synchronousHandlerProcedure := transportrpc.MustNewProcedure(
+		transportrpc.MustNewProcedureName([]string{"some", "synchronousHandler"}),
+		transportrpc.ProcedureTypeSource,
+	)
+
+	privilegedHandlerProcedure := transportrpc.MustNewProcedure(
+		transportrpc.MustNewProcedureName([]string{"privilegedHandler"}),
+		transportrpc.ProcedureTypeAsync,
+	)
+
+	testCases := []struct {
+		Name             string
+		Privileged       bool
+		ExpectedManifest string
+	}{
+		{
+			Name:       "privileged",
+			Privileged: true,
+			ExpectedManifest: `{
+				"manifest": "async",
+				"some": {
+					"handler": "duplex",
+					"synchronousHandler": "source"
+				},
+				"privilegedHandler": "async"
+			}`,
+		},
+		{
+			Name:       "not_privileged",
+			Privileged: false,
+			ExpectedManifest: `{
+				"manifest": "async",
+				"some": {
+					"handler": "duplex",
+					"synchronousHandler": "source"
+				}
+			}`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.Name, func(t *testing.T) {
+			t.Parallel()
+
+			handler, err := rpc.NewHandlerManifest(
+				[]mux.Handler{newManifestTestHandler(handlerProcedure)},
+				[]mux.SynchronousHandler{newManifestTestSynchronousHandler(synchronousHandlerProcedure)},
+				mux.PrivilegedHandlers{newManifestTestHandler(privilegedHandlerProcedure)},
+			)
+			require.NoError(t, err)
+			require.Equal(t, messages.ManifestProcedure, handler.Procedure())
+
+			ctx := fixtures.TestContext(t)
+			if testCase.Privileged {
+				ctx = transportrpc.PutPrivilegedInContext(ctx)
+			}
+
+			s := mocks.NewMockCloserStream()
+
+			req, err := messages.NewManifest()
+			require.NoError(t, err)
+
+			err = handler.Handle(ctx, s, req)
+			require.NoError(t, err)
+
+			writtenMessages := s.WrittenMessages()
+			require.Len(t, writtenMessages, 1)
+			require.Equal(t, transport.MessageBodyTypeJSON, writtenMessages[0].BodyType)
+			require.JSONEq(t, testCase.ExpectedManifest, string(writtenMessages[0].Body))
+		})
+	}
+}
+
+func TestNewHandlerManifest_ReturnsAnErrorIfProceduresCanNotBeDescribedByTheManifest(t *testing.T) {
+	t.Parallel()
+
+	handlers := []mux.Handler{
+		newManifestTestHandler(
+			transportrpc.MustNewProcedure(
+				transportrpc.MustNewProcedureName([]string{"some"}),
+				transportrpc.ProcedureTypeAsync,
+			),
+		),
+		newManifestTestHandler(
+			transportrpc.MustNewProcedure(
+				transportrpc.MustNewProcedureName([]string{"some", "procedure"}),
+				transportrpc.ProcedureTypeAsync,
+			),
+		),
+	}
+
+	_, err := rpc.NewHandlerManifest(handlers, nil, nil)
+	require.Error(t, err)
+}
+
+func TestNewMux_RegistersTheManifestHandler(t *testing.T) {
+	t.Parallel()
+
+	m, err := rpc.NewMux(fixtures.TestLogger(t), mocks.NewMuxMetricsMock(), nil, nil, nil)
+	require.NoError(t, err)
+
+	s := mocks.NewMockCloserStream()
+
+	req, err := messages.NewManifest()
+	require.NoError(t, err)
+
+	m.HandleRequest(fixtures.TestContext(t), s, req)
+
+	require.Eventually(
+		t,
+		func() bool {
+			return len(s.WrittenMessages()) == 1
+		},
+		1*time.Second, 10*time.Millisecond,
+	)
+	require.JSONEq(t, `{"manifest": "async"}`, string(s.WrittenMessages()[0].Body))
+}
+
+type manifestTestHandler struct {
+	procedure transportrpc.Procedure
+}
+
+func newManifestTestHandler(procedure transportrpc.Procedure) *manifestTestHandler {
+	return &manifestTestHandler{procedure: procedure}
+}
+
+func (m manifestTestHandler) Procedure() transportrpc.Procedure {
+	return m.procedure
+}
+
+func (m manifestTestHandler) Handle(ctx context.Context, s mux.Stream, req *transportrpc.Request) error {
+	return nil
+}
+
+type manifestTestSynchronousHandler struct {
+	procedure transportrpc.Procedure
+}
+
+func newManifestTestSynchronousHandler(procedure transportrpc.Procedure) *manifestTestSynchronousHandler {
+	return &manifestTestSynchronousHandler{procedure: procedure}
+}
+
+func (m manifestTestSynchronousHandler) Procedure() transportrpc.Procedure {
+	return m.procedure
+}
+
+func (m manifestTestSynchronousHandler) Handle(ctx context.Context, s mux.CloserStream, req *transportrpc.Request) {
+}
@@ -0,0 +1,49 @@
+package rpc
+
+import (
+	"context"
+
+	"github.com/boreq/errors"
+	"github.com/planetary-social/scuttlego/service/app/queries"
+	"github.com/planetary-social/scuttlego/service/domain/messages"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc/mux"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc/transport"
+)
+
+// HandlerOooGet lets peers retrieve single messages by their ids. The raw
+// message is returned so that the peer can verify it.
+type HandlerOooGet struct {
+	handler GetMessageQueryHandler
+}
+
+func NewHandlerOooGet(handler GetMessageQueryHandler) *HandlerOooGet {
+	return &HandlerOooGet{handler: handler}
+}
+
+func (h HandlerOooGet) Procedure() rpc.Procedure {
+	return messages.OooGetProcedure
+}
+
+func (h HandlerOooGet) Handle(ctx context.Context, s mux.Stream, req *rpc.Request) error {
+	args, err := messages.NewGetArgumentsFromBytes(req.Arguments())
+	if err != nil {
+		return errors.Wrap(err, "error parsing arguments")
+	}
+
+	query, err := queries.NewGetMessage(args.Id())
+	if err != nil {
+		return errors.Wrap(err, "error creating the query")
+	}
+
+	msg, err := h.handler.Handle(query)
+	if err != nil {
+		return errors.Wrap(err, "error executing the query")
+	}
+
+	if err := s.WriteMessage(msg.Raw().Bytes(), transport.MessageBodyTypeJSON); err != nil {
+		return errors.Wrap(err, "error writing the message")
+	}
+
+	return nil
+}
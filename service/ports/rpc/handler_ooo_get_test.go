@@ -0,0 +1,58 @@
+package rpc_test
+
+import (
+	"testing"
+
+	"github.com/planetary-social/scuttlego/internal/fixtures"
+	"github.com/planetary-social/scuttlego/internal/mocks"
+	"github.com/planetary-social/scuttlego/service/domain/messages"
+	transportrpc "github.com/planetary-social/scuttlego/service/domain/transport/rpc"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc/transport"
+	"github.com/planetary-social/scuttlego/service/ports/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandlerOooGet_ReturnsRawMessage(t *testing.T) {
+	msg := fixtures.SomeMessage(fixtures.SomeSequence(), fixtures.SomeRefFeed())
+	queryHandler := newGetMessageQueryHandlerMock(msg)
+	h := rpc.NewHandlerOooGet(queryHandler)
+
+	require.Equal(t, messages.OooGetProcedure, h.Procedure())
+
+	args, err := messages.NewGetArguments(msg.Id())
+	require.NoError(t, err)
+
+	req, err := messages.NewOooGet(args)
+	require.NoError(t, err)
+
+	s := mocks.NewMockCloserStream()
+
+	err = h.Handle(fixtures.TestContext(t), s, req)
+	require.NoError(t, err)
+
+	require.Len(t, queryHandler.calls, 1)
+	require.Equal(t, msg.Id(), queryHandler.calls[0].Id())
+
+	require.Equal(t,
+		[]mocks.MockCloserStreamWriteMessageCall{
+			{
+				Body:     msg.Raw().Bytes(),
+				BodyType: transport.MessageBodyTypeJSON,
+			},
+		},
+		s.WrittenMessages(),
+	)
+}
+
+func TestHandlerOooGet_InvalidArgumentsReturnAnError(t *testing.T) {
+	h := rpc.NewHandlerOooGet(newGetMessageQueryHandlerMock(fixtures.SomeMessage(fixtures.SomeSequence(), fixtures.SomeRefFeed())))
+
+	req, err := transportrpc.NewRequest(messages.OooGetProcedure.Name(), messages.OooGetProcedure.Typ(), []byte(`["invalid"]`))
+	require.NoError(t, err)
+
+	s := mocks.NewMockCloserStream()
+
+	err = h.Handle(fixtures.TestContext(t), s, req)
+	require.Error(t, err)
+	require.Empty(t, s.WrittenMessages())
+}
@@ -0,0 +1,73 @@
+package rpc
+
+import (
+	"context"
+
+	"github.com/boreq/errors"
+	"github.com/planetary-social/scuttlego/service/app/commands"
+	"github.com/planetary-social/scuttlego/service/app/queries"
+	"github.com/planetary-social/scuttlego/service/domain/messages"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc/mux"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc/transport"
+)
+
+// HandlerPublish publishes a message using the identity of this node. Unlike
+// HandlerScuttlegoPublish it responds with the entire message in the format
+// used by ssb-server.
+type HandlerPublish struct {
+	publish    PublishRawCommandHandler
+	getMessage GetMessageQueryHandler
+}
+
+func NewHandlerPublish(
+	publish PublishRawCommandHandler,
+	getMessage GetMessageQueryHandler,
+) *HandlerPublish {
+	return &HandlerPublish{
+		publish:    publish,
+		getMessage: getMessage,
+	}
+}
+
+func (h HandlerPublish) Procedure() rpc.Procedure {
+	return messages.PublishProcedure
+}
+
+func (h HandlerPublish) Handle(ctx context.Context, s mux.Stream, req *rpc.Request) error {
+	args, err := messages.NewScuttlegoPublishArgumentsFromBytes(req.Arguments())
+	if err != nil {
+		return errors.Wrap(err, "error parsing arguments")
+	}
+
+	cmd, err := commands.NewPublishRaw(args.Content())
+	if err != nil {
+		return errors.Wrap(err, "error creating the command")
+	}
+
+	id, err := h.publish.Handle(cmd)
+	if err != nil {
+		return errors.Wrap(err, "error executing the command")
+	}
+
+	query, err := queries.NewGetMessage(id)
+	if err != nil {
+		return errors.Wrap(err, "error creating the query")
+	}
+
+	msg, err := h.getMessage.Handle(query)
+	if err != nil {
+		return errors.Wrap(err, "error getting the published message")
+	}
+
+	j, err := messages.NewKeyValueTimestamp(msg).MarshalJSON()
+	if err != nil {
+		return errors.Wrap(err, "json marshalling failed")
+	}
+
+	if err := s.WriteMessage(j, transport.MessageBodyTypeJSON); err != nil {
+		return errors.Wrap(err, "error writing the message")
+	}
+
+	return nil
+}
@@ -0,0 +1,137 @@
+package rpc_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/boreq/errors"
+	"github.com/planetary-social/scuttlego/internal/fixtures"
+	"github.com/planetary-social/scuttlego/internal/mocks"
+	"github.com/planetary-social/scuttlego/service/app/commands"
+	"github.com/planetary-social/scuttlego/service/domain/feeds/message"
+	"github.com/planetary-social/scuttlego/service/domain/messages"
+	"github.com/planetary-social/scuttlego/service/domain/refs"
+	transportrpc "github.com/planetary-social/scuttlego/service/domain/transport/rpc"
+	"github.com/planetary-social/scuttlego/service/ports/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandlerPublish_RespondsWithKeyValueTimestamp(t *testing.T) {
+	id := refs.MustNewMessage("%uaGieSQDJcHfUp6hjIcIq55GoZh4Ug7tNmgaohoxrpw=.sha256")
+	author := refs.MustNewIdentity("@FCX/tsDLpubCPKKfIrw4gc+SQkHcaD17s7GI6i/ziWY=.ed25519")
+	raw := fixtures.SomeRawMessage()
+
+	msg := message.MustNewMessage(
+		id,
+		nil,
+		message.MustNewSequence(1),
+		author,
+		author.MainFeed(),
+		time.UnixMilli(1514517067954),
+		fixtures.SomeContent(),
+		raw,
+	)
+
+	content := []byte(`{"type":"post","text":"hello"}`)
+
+	publishHandler := newPublishRawCommandHandlerMock(id)
+	getMessageHandler := newGetMessageQueryHandlerMock(msg)
+	h := rpc.NewHandlerPublish(publishHandler, getMessageHandler)
+
+	require.Equal(t, messages.PublishProcedure, h.Procedure())
+
+	s := mocks.NewMockCloserStream()
+	err := h.Handle(fixtures.TestContext(t), s, mustNewPublishRequest(t, content))
+	require.NoError(t, err)
+
+	expectedCmd, err := commands.NewPublishRaw(content)
+	require.NoError(t, err)
+	require.Equal(t, []commands.PublishRaw{expectedCmd}, publishHandler.calls)
+
+	require.Len(t, getMessageHandler.calls, 1)
+	require.Equal(t, id, getMessageHandler.calls[0].Id())
+
+	requireWrittenJSON(t, s, fmt.Sprintf(
+		`{"key":"%%uaGieSQDJcHfUp6hjIcIq55GoZh4Ug7tNmgaohoxrpw=.sha256","value":%s,"timestamp":1514517067954}`,
+		raw.Bytes(),
+	))
+}
+
+func TestHandlerPublish_InvalidArguments(t *testing.T) {
+	testCases := []struct {
+		Name      string
+		Arguments string
+	}{
+		{
+			Name:      "no_arguments",
+			Arguments: `[]`,
+		},
+		{
+			Name:      "not_json",
+			Arguments: `not json`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			publishHandler := newPublishRawCommandHandlerMock(fixtures.SomeRefMessage())
+			getMessageHandler := newGetMessageQueryHandlerMock(message.Message{})
+			h := rpc.NewHandlerPublish(publishHandler, getMessageHandler)
+
+			s := mocks.NewMockCloserStream()
+			err := h.Handle(fixtures.TestContext(t), s, mustNewScuttlegoRequest(t, messages.PublishProcedure, testCase.Arguments))
+			require.Error(t, err)
+
+			require.Empty(t, publishHandler.calls)
+			require.Empty(t, getMessageHandler.calls)
+			require.Empty(t, s.WrittenMessages())
+		})
+	}
+}
+
+func TestHandlerPublish_ErrorsAreReturned(t *testing.T) {
+	someErr := errors.New("some error")
+
+	testCases := []struct {
+		Name          string
+		PublishErr    error
+		GetMessageErr error
+	}{
+		{
+			Name:       "publishing_fails",
+			PublishErr: someErr,
+		},
+		{
+			Name:          "getting_the_published_message_fails",
+			GetMessageErr: someErr,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			publishHandler := newPublishRawCommandHandlerMock(fixtures.SomeRefMessage())
+			publishHandler.err = testCase.PublishErr
+
+			getMessageHandler := newGetMessageQueryHandlerMock(message.Message{})
+			getMessageHandler.err = testCase.GetMessageErr
+
+			h := rpc.NewHandlerPublish(publishHandler, getMessageHandler)
+
+			s := mocks.NewMockCloserStream()
+			err := h.Handle(fixtures.TestContext(t), s, mustNewPublishRequest(t, []byte(`{"type":"post"}`)))
+			require.ErrorIs(t, err, someErr)
+			require.Empty(t, s.WrittenMessages())
+		})
+	}
+}
+
+func mustNewPublishRequest(t *testing.T, content []byte) *transportrpc.Request {
+	args, err := messages.NewScuttlegoPublishArguments(content)
+	require.NoError(t, err)
+
+	req, err := messages.NewPublish(args)
+	require.NoError(t, err)
+
+	return req
+}
@@ -0,0 +1,86 @@
+package rpc
+
+import (
+	"context"
+
+	"github.com/boreq/errors"
+	"github.com/planetary-social/scuttlego/service/app/queries"
+	"github.com/planetary-social/scuttlego/service/domain/messages"
+	"github.com/planetary-social/scuttlego/service/domain/refs"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc/mux"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc/transport"
+)
+
+// replicateUptoPageSize is the number of feeds which are loaded at once.
+const replicateUptoPageSize = 100
+
+type ReplicateUptoQueryHandler interface {
+	Handle(query queries.ReplicateUpto) ([]queries.FeedSequence, error)
+}
+
+// HandlerReplicateUpto sends the latest sequence of every replicated feed
+// and then closes the stream. Feeds are loaded one page at a time and each
+// page is sent before the next one is loaded.
+type HandlerReplicateUpto struct {
+	handler ReplicateUptoQueryHandler
+}
+
+func NewHandlerReplicateUpto(handler ReplicateUptoQueryHandler) *HandlerReplicateUpto {
+	return &HandlerReplicateUpto{
+		handler: handler,
+	}
+}
+
+func (h HandlerReplicateUpto) Procedure() rpc.Procedure {
+	return messages.ReplicateUptoProcedure
+}
+
+func (h HandlerReplicateUpto) Handle(ctx context.Context, s mux.Stream, req *rpc.Request) error {
+	if _, err := messages.NewReplicateUptoArgumentsFromBytes(req.Arguments()); err != nil {
+		return errors.Wrap(err, "invalid arguments")
+	}
+
+	var after *refs.Feed
+	for {
+		query, err := queries.NewReplicateUpto(after, replicateUptoPageSize)
+		if err != nil {
+			return errors.Wrap(err, "error creating the query")
+		}
+
+		feedSequences, err := h.handler.Handle(query)
+		if err != nil {
+			return errors.Wrap(err, "error executing the query")
+		}
+
+		for _, feedSequence := range feedSequences {
+			if err := h.send(s, feedSequence); err != nil {
+				return errors.Wrap(err, "error sending the response")
+			}
+		}
+
+		if len(feedSequences) < replicateUptoPageSize {
+			return nil
+		}
+
+		after = &feedSequences[len(feedSequences)-1].Feed
+	}
+}
+
+func (h HandlerReplicateUpto) send(s mux.Stream, feedSequence queries.FeedSequence) error {
+	response, err := messages.NewReplicateUptoResponse(feedSequence.Feed, feedSequence.Sequence)
+	if err != nil {
+		return errors.Wrap(err, "error creating the response")
+	}
+
+	j, err := response.MarshalJSON()
+	if err != nil {
+		return errors.Wrap(err, "json marshalling failed")
+	}
+
+	if err := s.WriteMessage(j, transport.MessageBodyTypeJSON); err != nil {
+		return errors.Wrap(err, "error writing the message")
+	}
+
+	return nil
+}
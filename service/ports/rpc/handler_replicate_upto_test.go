@@ -0,0 +1,107 @@
+package rpc_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/planetary-social/scuttlego/internal/fixtures"
+	"github.com/planetary-social/scuttlego/internal/mocks"
+	"github.com/planetary-social/scuttlego/service/app/queries"
+	"github.com/planetary-social/scuttlego/service/domain/feeds/message"
+	"github.com/planetary-social/scuttlego/service/domain/messages"
+	"github.com/planetary-social/scuttlego/service/ports/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandlerReplicateUpto(t *testing.T) {
+	feed1 := fixtures.SomeRefFeed()
+	feed2 := fixtures.SomeRefFeed()
+
+	queryHandler := newReplicateUptoQueryHandlerMock([]queries.FeedSequence{
+		{
+			Feed:     feed1,
+			Sequence: message.MustNewSequence(1),
+		},
+		{
+			Feed:     feed2,
+			Sequence: message.MustNewSequence(123),
+		},
+	})
+	h := rpc.NewHandlerReplicateUpto(queryHandler)
+
+	require.Equal(t, messages.ReplicateUptoProcedure, h.Procedure())
+
+	req, err := messages.NewReplicateUpto(messages.NewReplicateUptoArguments())
+	require.NoError(t, err)
+
+	s := mocks.NewMockCloserStream()
+	err = h.Handle(fixtures.TestContext(t), s, req)
+	require.NoError(t, err)
+
+	written := s.WrittenMessages()
+	require.Len(t, written, 2)
+	require.JSONEq(t, fmt.Sprintf(`{"id":"%s","sequence":1}`, feed1), string(written[0].Body))
+	require.JSONEq(t, fmt.Sprintf(`{"id":"%s","sequence":123}`, feed2), string(written[1].Body))
+}
+
+func TestHandlerReplicateUpto_FeedsAreLoadedInPages(t *testing.T) {
+	const numberOfFeeds = 250
+
+	var feedSequences []queries.FeedSequence
+	for i := 0; i < numberOfFeeds; i++ {
+		feedSequences = append(feedSequences, queries.FeedSequence{
+			Feed:     fixtures.SomeRefFeed(),
+			Sequence: fixtures.SomeSequence(),
+		})
+	}
+
+	queryHandler := newReplicateUptoQueryHandlerMock(feedSequences)
+	h := rpc.NewHandlerReplicateUpto(queryHandler)
+
+	req, err := messages.NewReplicateUpto(messages.NewReplicateUptoArguments())
+	require.NoError(t, err)
+
+	s := mocks.NewMockCloserStream()
+	err = h.Handle(fixtures.TestContext(t), s, req)
+	require.NoError(t, err)
+
+	require.Len(t, s.WrittenMessages(), numberOfFeeds)
+
+	require.Len(t, queryHandler.calls, 3)
+	require.Nil(t, queryHandler.calls[0].After())
+	require.Equal(t, feedSequences[99].Feed, *queryHandler.calls[1].After())
+	require.Equal(t, feedSequences[199].Feed, *queryHandler.calls[2].After())
+	for _, call := range queryHandler.calls {
+		require.Equal(t, 100, call.Limit())
+	}
+}
+
+type replicateUptoQueryHandlerMock struct {
+	result []queries.FeedSequence
+	calls  []queries.ReplicateUpto
+}
+
+func newReplicateUptoQueryHandlerMock(result []queries.FeedSequence) *replicateUptoQueryHandlerMock {
+	return &replicateUptoQueryHandlerMock{result: result}
+}
+
+func (r *replicateUptoQueryHandlerMock) Handle(query queries.ReplicateUpto) ([]queries.FeedSequence, error) {
+	r.calls = append(r.calls, query)
+
+	start := 0
+	if after := query.After(); after != nil {
+		for i, v := range r.result {
+			if v.Feed.Equal(*after) {
+				start = i + 1
+				break
+			}
+		}
+	}
+
+	end := start + query.Limit()
+	if end > len(r.result) {
+		end = len(r.result)
+	}
+
+	return r.result[start:end], nil
+}
@@ -0,0 +1,99 @@
+package rpc
+
+import (
+	"context"
+
+	"github.com/boreq/errors"
+	"github.com/planetary-social/scuttlego/service/app/queries"
+	"github.com/planetary-social/scuttlego/service/domain/messages"
+	"github.com/planetary-social/scuttlego/service/domain/refs"
+	"github.com/planetary-social/scuttlego/service/domain/rooms"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc/mux"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc/transport"
+)
+
+type RoomServerAttendantsQueryHandler interface {
+	Handle(ctx context.Context, query queries.RoomServerAttendants) ([]refs.Identity, <-chan rooms.RoomAttendantsEvent, error)
+}
+
+// HandlerRoomAttendants sends the current list of attendants followed by
+// events describing attendants joining and leaving the room.
+type HandlerRoomAttendants struct {
+	handler RoomServerAttendantsQueryHandler
+}
+
+func NewHandlerRoomAttendants(handler RoomServerAttendantsQueryHandler) *HandlerRoomAttendants {
+	return &HandlerRoomAttendants{handler: handler}
+}
+
+func (h HandlerRoomAttendants) Procedure() rpc.Procedure {
+	return messages.RoomAttendantsProcedure
+}
+
+func (h HandlerRoomAttendants) Handle(ctx context.Context, s mux.Stream, req *rpc.Request) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	remote, ok := rpc.GetRemoteIdentityFromContext(ctx)
+	if !ok {
+		return errors.New("remote identity not found in context")
+	}
+
+	query, err := queries.NewRoomServerAttendants(remote)
+	if err != nil {
+		return errors.Wrap(err, "error creating the query")
+	}
+
+	state, events, err := h.handler.Handle(ctx, query)
+	if err != nil {
+		return errors.Wrap(err, "error executing the query")
+	}
+
+	stateResponse, err := messages.NewRoomAttendantsResponseState(state)
+	if err != nil {
+		return errors.Wrap(err, "error creating the state response")
+	}
+
+	if err := h.write(s, stateResponse); err != nil {
+		return errors.Wrap(err, "error sending the state")
+	}
+
+	for event := range events {
+		typ, err := roomAttendantsResponseType(event.Typ())
+		if err != nil {
+			return errors.Wrap(err, "error converting the event type")
+		}
+
+		eventResponse, err := messages.NewRoomAttendantsResponseJoinedOrLeft(typ, event.Id())
+		if err != nil {
+			return errors.Wrap(err, "error creating the event response")
+		}
+
+		if err := h.write(s, eventResponse); err != nil {
+			return errors.Wrap(err, "error sending the event")
+		}
+	}
+
+	return nil
+}
+
+func (h HandlerRoomAttendants) write(s mux.Stream, v interface{ MarshalJSON() ([]byte, error) }) error {
+	j, err := v.MarshalJSON()
+	if err != nil {
+		return errors.Wrap(err, "json marshalling failed")
+	}
+
+	return s.WriteMessage(j, transport.MessageBodyTypeJSON)
+}
+
+func roomAttendantsResponseType(typ rooms.RoomAttendantsEventType) (messages.RoomAttendantsResponseType, error) {
+	switch typ {
+	case rooms.RoomAttendantsEventTypeJoined:
+		return messages.RoomAttendantsResponseTypeJoined, nil
+	case rooms.RoomAttendantsEventTypeLeft:
+		return messages.RoomAttendantsResponseTypeLeft, nil
+	default:
+		return messages.RoomAttendantsResponseType{}, errors.New("unknown event type")
+	}
+}
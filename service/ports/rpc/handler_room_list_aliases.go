@@ -0,0 +1,62 @@
+package rpc
+
+import (
+	"context"
+
+	"github.com/boreq/errors"
+	"github.com/planetary-social/scuttlego/service/app/queries"
+	"github.com/planetary-social/scuttlego/service/domain/messages"
+	"github.com/planetary-social/scuttlego/service/domain/rooms/aliases"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc/mux"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc/transport"
+)
+
+type RoomServerListAliasesQueryHandler interface {
+	Handle(query queries.RoomServerListAliases) ([]aliases.Alias, error)
+}
+
+type HandlerRoomListAliases struct {
+	handler RoomServerListAliasesQueryHandler
+}
+
+func NewHandlerRoomListAliases(handler RoomServerListAliasesQueryHandler) *HandlerRoomListAliases {
+	return &HandlerRoomListAliases{handler: handler}
+}
+
+func (h HandlerRoomListAliases) Procedure() rpc.Procedure {
+	return messages.RoomListAliasesProcedure
+}
+
+func (h HandlerRoomListAliases) Handle(ctx context.Context, s mux.Stream, req *rpc.Request) error {
+	args, err := messages.NewRoomListAliasesArgumentsFromBytes(req.Arguments())
+	if err != nil {
+		return errors.Wrap(err, "error parsing arguments")
+	}
+
+	remote, ok := rpc.GetRemoteIdentityFromContext(ctx)
+	if !ok {
+		return errors.New("remote identity not found in context")
+	}
+
+	query, err := queries.NewRoomServerListAliases(remote, args.Identity())
+	if err != nil {
+		return errors.Wrap(err, "error creating the query")
+	}
+
+	result, err := h.handler.Handle(query)
+	if err != nil {
+		return errors.Wrap(err, "error executing the query")
+	}
+
+	j, err := messages.NewRoomListAliasesResponse(result).MarshalJSON()
+	if err != nil {
+		return errors.Wrap(err, "json marshalling failed")
+	}
+
+	if err := s.WriteMessage(j, transport.MessageBodyTypeJSON); err != nil {
+		return errors.Wrap(err, "error writing the message")
+	}
+
+	return nil
+}
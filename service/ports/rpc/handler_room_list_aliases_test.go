@@ -0,0 +1,143 @@
+package rpc_test
+
+import (
+	"testing"
+
+	"github.com/boreq/errors"
+	"github.com/planetary-social/scuttlego/internal/fixtures"
+	"github.com/planetary-social/scuttlego/internal/mocks"
+	"github.com/planetary-social/scuttlego/service/app/queries"
+	"github.com/planetary-social/scuttlego/service/domain/messages"
+	"github.com/planetary-social/scuttlego/service/domain/refs"
+	"github.com/planetary-social/scuttlego/service/domain/rooms/aliases"
+	transportrpc "github.com/planetary-social/scuttlego/service/domain/transport/rpc"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc/transport"
+	"github.com/planetary-social/scuttlego/service/ports/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandlerRoomListAliases_WritesAliases(t *testing.T) {
+	testCases := []struct {
+		Name         string
+		Aliases      []aliases.Alias
+		ExpectedBody string
+	}{
+		{
+			Name:         "no_aliases",
+			Aliases:      nil,
+			ExpectedBody: `[]`,
+		},
+		{
+			Name: "aliases",
+			Aliases: []aliases.Alias{
+				aliases.MustNewAlias("alias1"),
+				aliases.MustNewAlias("alias2"),
+			},
+			ExpectedBody: `["alias1","alias2"]`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			queryHandler := newRoomServerListAliasesQueryHandlerMock()
+			queryHandler.Aliases = testCase.Aliases
+			h := rpc.NewHandlerRoomListAliases(queryHandler)
+
+			require.Equal(t, messages.RoomListAliasesProcedure, h.Procedure())
+
+			remote := fixtures.SomePublicIdentity()
+			user := fixtures.SomeRefIdentity()
+
+			ctx := transportrpc.PutRemoteIdentityInContext(fixtures.TestContext(t), remote)
+			s := mocks.NewMockCloserStream()
+
+			err := h.Handle(ctx, s, newRoomListAliasesRequest(t, user))
+			require.NoError(t, err)
+
+			require.Equal(t,
+				[]queries.RoomServerListAliases{
+					queries.MustNewRoomServerListAliases(remote, user),
+				},
+				queryHandler.Calls,
+			)
+
+			written := s.WrittenMessages()
+			require.Len(t, written, 1)
+			require.Equal(t, transport.MessageBodyTypeJSON, written[0].BodyType)
+			require.JSONEq(t, testCase.ExpectedBody, string(written[0].Body))
+		})
+	}
+}
+
+func TestHandlerRoomListAliases_ReturnsErrors(t *testing.T) {
+	testCases := []struct {
+		Name          string
+		Request       *transportrpc.Request
+		HasRemote     bool
+		HandlerError  error
+		ExpectedError string
+	}{
+		{
+			Name:          "invalid_arguments",
+			Request:       transportrpc.MustNewRequest(messages.RoomListAliasesProcedure.Name(), messages.RoomListAliasesProcedure.Typ(), []byte(`["not-an-identity"]`)),
+			HasRemote:     true,
+			ExpectedError: "error parsing arguments: error creating the identity: invalid prefix",
+		},
+		{
+			Name:          "missing_remote_identity",
+			Request:       newRoomListAliasesRequest(t, fixtures.SomeRefIdentity()),
+			HasRemote:     false,
+			ExpectedError: "remote identity not found in context",
+		},
+		{
+			Name:          "handler_error",
+			Request:       newRoomListAliasesRequest(t, fixtures.SomeRefIdentity()),
+			HasRemote:     true,
+			HandlerError:  errors.New("some error"),
+			ExpectedError: "error executing the query: some error",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			queryHandler := newRoomServerListAliasesQueryHandlerMock()
+			queryHandler.Err = testCase.HandlerError
+			h := rpc.NewHandlerRoomListAliases(queryHandler)
+
+			ctx := fixtures.TestContext(t)
+			if testCase.HasRemote {
+				ctx = transportrpc.PutRemoteIdentityInContext(ctx, fixtures.SomePublicIdentity())
+			}
+			s := mocks.NewMockCloserStream()
+
+			err := h.Handle(ctx, s, testCase.Request)
+			require.EqualError(t, err, testCase.ExpectedError)
+			require.Empty(t, s.WrittenMessages())
+		})
+	}
+}
+
+func newRoomListAliasesRequest(t *testing.T, user refs.Identity) *transportrpc.Request {
+	args, err := messages.NewRoomListAliasesArguments(user)
+	require.NoError(t, err)
+
+	req, err := messages.NewRoomListAliases(args)
+	require.NoError(t, err)
+
+	return req
+}
+
+type roomServerListAliasesQueryHandlerMock struct {
+	Aliases []aliases.Alias
+	Err     error
+	Calls   []queries.RoomServerListAliases
+}
+
+func newRoomServerListAliasesQueryHandlerMock() *roomServerListAliasesQueryHandlerMock {
+	return &roomServerListAliasesQueryHandlerMock{}
+}
+
+func (r *roomServerListAliasesQueryHandlerMock) Handle(query queries.RoomServerListAliases) ([]aliases.Alias, error) {
+	r.Calls = append(r.Calls, query)
+	return r.Aliases, r.Err
+}
@@ -0,0 +1,56 @@
+package rpc
+
+import (
+	"context"
+
+	"github.com/boreq/errors"
+	"github.com/planetary-social/scuttlego/service/app/queries"
+	"github.com/planetary-social/scuttlego/service/domain/messages"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc/mux"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc/transport"
+)
+
+type RoomServerMetadataQueryHandler interface {
+	Handle(query queries.RoomServerMetadata) (messages.RoomMetadataResponse, error)
+}
+
+type HandlerRoomMetadata struct {
+	handler RoomServerMetadataQueryHandler
+}
+
+func NewHandlerRoomMetadata(handler RoomServerMetadataQueryHandler) *HandlerRoomMetadata {
+	return &HandlerRoomMetadata{handler: handler}
+}
+
+func (h HandlerRoomMetadata) Procedure() rpc.Procedure {
+	return messages.RoomMetadataProcedure
+}
+
+func (h HandlerRoomMetadata) Handle(ctx context.Context, s mux.Stream, req *rpc.Request) error {
+	remote, ok := rpc.GetRemoteIdentityFromContext(ctx)
+	if !ok {
+		return errors.New("remote identity not found in context")
+	}
+
+	query, err := queries.NewRoomServerMetadata(remote)
+	if err != nil {
+		return errors.Wrap(err, "error creating the query")
+	}
+
+	response, err := h.handler.Handle(query)
+	if err != nil {
+		return errors.Wrap(err, "error executing the query")
+	}
+
+	j, err := response.MarshalJSON()
+	if err != nil {
+		return errors.Wrap(err, "json marshalling failed")
+	}
+
+	if err := s.WriteMessage(j, transport.MessageBodyTypeJSON); err != nil {
+		return errors.Wrap(err, "error writing the message")
+	}
+
+	return nil
+}
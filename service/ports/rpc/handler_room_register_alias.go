@@ -0,0 +1,62 @@
+package rpc
+
+import (
+	"context"
+
+	"github.com/boreq/errors"
+	"github.com/planetary-social/scuttlego/service/app/commands"
+	"github.com/planetary-social/scuttlego/service/domain/messages"
+	"github.com/planetary-social/scuttlego/service/domain/rooms/aliases"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc/mux"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc/transport"
+)
+
+type RoomServerRegisterAliasCommandHandler interface {
+	Handle(cmd commands.RoomServerRegisterAlias) (aliases.AliasEndpointURL, error)
+}
+
+type HandlerRoomRegisterAlias struct {
+	handler RoomServerRegisterAliasCommandHandler
+}
+
+func NewHandlerRoomRegisterAlias(handler RoomServerRegisterAliasCommandHandler) *HandlerRoomRegisterAlias {
+	return &HandlerRoomRegisterAlias{handler: handler}
+}
+
+func (h HandlerRoomRegisterAlias) Procedure() rpc.Procedure {
+	return messages.RoomRegisterAliasProcedure
+}
+
+func (h HandlerRoomRegisterAlias) Handle(ctx context.Context, s mux.Stream, req *rpc.Request) error {
+	args, err := messages.NewRoomRegisterAliasArgumentsFromBytes(req.Arguments())
+	if err != nil {
+		return errors.Wrap(err, "error parsing arguments")
+	}
+
+	remote, ok := rpc.GetRemoteIdentityFromContext(ctx)
+	if !ok {
+		return errors.New("remote identity not found in context")
+	}
+
+	cmd, err := commands.NewRoomServerRegisterAlias(remote, args.Alias(), args.Signature())
+	if err != nil {
+		return errors.Wrap(err, "error creating the command")
+	}
+
+	url, err := h.handler.Handle(cmd)
+	if err != nil {
+		return errors.Wrap(err, "error executing the command")
+	}
+
+	response, err := messages.NewRoomRegisterAliasResponse(url)
+	if err != nil {
+		return errors.Wrap(err, "error creating the response")
+	}
+
+	if err := s.WriteMessage(response.Bytes(), transport.MessageBodyTypeString); err != nil {
+		return errors.Wrap(err, "error writing the message")
+	}
+
+	return nil
+}
@@ -0,0 +1,142 @@
+package rpc_test
+
+import (
+	"testing"
+
+	"github.com/boreq/errors"
+	"github.com/planetary-social/scuttlego/internal/fixtures"
+	"github.com/planetary-social/scuttlego/internal/mocks"
+	"github.com/planetary-social/scuttlego/service/app/commands"
+	"github.com/planetary-social/scuttlego/service/domain/identity"
+	"github.com/planetary-social/scuttlego/service/domain/messages"
+	"github.com/planetary-social/scuttlego/service/domain/refs"
+	"github.com/planetary-social/scuttlego/service/domain/rooms/aliases"
+	transportrpc "github.com/planetary-social/scuttlego/service/domain/transport/rpc"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc/transport"
+	"github.com/planetary-social/scuttlego/service/ports/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandlerRoomRegisterAlias_WritesTheAliasURL(t *testing.T) {
+	commandHandler := newRoomServerRegisterAliasCommandHandlerMock()
+	commandHandler.URL = aliases.MustNewAliasEndpointURL("https://somealias.room.example.com")
+	h := rpc.NewHandlerRoomRegisterAlias(commandHandler)
+
+	require.Equal(t, messages.RoomRegisterAliasProcedure, h.Procedure())
+
+	user := fixtures.SomePrivateIdentity()
+	alias := fixtures.SomeAlias()
+	signature := someRegistrationSignature(t, user, alias)
+
+	ctx := transportrpc.PutRemoteIdentityInContext(fixtures.TestContext(t), user.Public())
+	s := mocks.NewMockCloserStream()
+
+	err := h.Handle(ctx, s, newRoomRegisterAliasRequest(t, alias, signature))
+	require.NoError(t, err)
+
+	require.Equal(t,
+		[]commands.RoomServerRegisterAlias{
+			commands.MustNewRoomServerRegisterAlias(user.Public(), alias, signature),
+		},
+		commandHandler.Calls,
+	)
+
+	require.Equal(t,
+		[]mocks.MockCloserStreamWriteMessageCall{
+			{
+				Body:     []byte("https://somealias.room.example.com"),
+				BodyType: transport.MessageBodyTypeString,
+			},
+		},
+		s.WrittenMessages(),
+	)
+}
+
+func TestHandlerRoomRegisterAlias_ReturnsErrors(t *testing.T) {
+	user := fixtures.SomePrivateIdentity()
+	alias := fixtures.SomeAlias()
+
+	testCases := []struct {
+		Name          string
+		Request       *transportrpc.Request
+		HasRemote     bool
+		HandlerError  error
+		ExpectedError string
+	}{
+		{
+			Name:          "invalid_arguments",
+			Request:       transportrpc.MustNewRequest(messages.RoomRegisterAliasProcedure.Name(), messages.RoomRegisterAliasProcedure.Typ(), []byte(`["somealias"]`)),
+			HasRemote:     true,
+			ExpectedError: "error parsing arguments: expected exactly two arguments",
+		},
+		{
+			Name:          "missing_remote_identity",
+			Request:       newRoomRegisterAliasRequest(t, alias, someRegistrationSignature(t, user, alias)),
+			HasRemote:     false,
+			ExpectedError: "remote identity not found in context",
+		},
+		{
+			Name:          "handler_error",
+			Request:       newRoomRegisterAliasRequest(t, alias, someRegistrationSignature(t, user, alias)),
+			HasRemote:     true,
+			HandlerError:  errors.New("some error"),
+			ExpectedError: "error executing the command: some error",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			commandHandler := newRoomServerRegisterAliasCommandHandlerMock()
+			commandHandler.Err = testCase.HandlerError
+			h := rpc.NewHandlerRoomRegisterAlias(commandHandler)
+
+			ctx := fixtures.TestContext(t)
+			if testCase.HasRemote {
+				ctx = transportrpc.PutRemoteIdentityInContext(ctx, user.Public())
+			}
+			s := mocks.NewMockCloserStream()
+
+			err := h.Handle(ctx, s, testCase.Request)
+			require.EqualError(t, err, testCase.ExpectedError)
+			require.Empty(t, s.WrittenMessages())
+		})
+	}
+}
+
+func newRoomRegisterAliasRequest(t *testing.T, alias aliases.Alias, signature aliases.RegistrationSignature) *transportrpc.Request {
+	args, err := messages.NewRoomRegisterAliasArguments(alias, signature)
+	require.NoError(t, err)
+
+	req, err := messages.NewRoomRegisterAlias(args)
+	require.NoError(t, err)
+
+	return req
+}
+
+func someRegistrationSignature(t *testing.T, user identity.Private, alias aliases.Alias) aliases.RegistrationSignature {
+	msg, err := aliases.NewRegistrationMessage(alias, refs.MustNewIdentityFromPublic(user.Public()), fixtures.SomeRefIdentity())
+	require.NoError(t, err)
+
+	signature, err := aliases.NewRegistrationSignature(msg, user)
+	require.NoError(t, err)
+
+	return signature
+}
+
+type roomServerRegisterAliasCommandHandlerMock struct {
+	URL   aliases.AliasEndpointURL
+	Err   error
+	Calls []commands.RoomServerRegisterAlias
+}
+
+func newRoomServerRegisterAliasCommandHandlerMock() *roomServerRegisterAliasCommandHandlerMock {
+	return &roomServerRegisterAliasCommandHandlerMock{}
+}
+
+func (r *roomServerRegisterAliasCommandHandlerMock) Handle(cmd commands.RoomServerRegisterAlias) (aliases.AliasEndpointURL, error) {
+	r.Calls = append(r.Calls, cmd)
+	if r.Err != nil {
+		return aliases.AliasEndpointURL{}, r.Err
+	}
+	return r.URL, nil
+}
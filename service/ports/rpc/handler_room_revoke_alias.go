@@ -0,0 +1,55 @@
+package rpc
+
+import (
+	"context"
+
+	"github.com/boreq/errors"
+	"github.com/planetary-social/scuttlego/service/app/commands"
+	"github.com/planetary-social/scuttlego/service/domain/messages"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc/mux"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc/transport"
+)
+
+type RoomServerRevokeAliasCommandHandler interface {
+	Handle(cmd commands.RoomServerRevokeAlias) error
+}
+
+type HandlerRoomRevokeAlias struct {
+	handler RoomServerRevokeAliasCommandHandler
+}
+
+func NewHandlerRoomRevokeAlias(handler RoomServerRevokeAliasCommandHandler) *HandlerRoomRevokeAlias {
+	return &HandlerRoomRevokeAlias{handler: handler}
+}
+
+func (h HandlerRoomRevokeAlias) Procedure() rpc.Procedure {
+	return messages.RoomRevokeAliasProcedure
+}
+
+func (h HandlerRoomRevokeAlias) Handle(ctx context.Context, s mux.Stream, req *rpc.Request) error {
+	args, err := messages.NewRoomRevokeAliasArgumentsFromBytes(req.Arguments())
+	if err != nil {
+		return errors.Wrap(err, "error parsing arguments")
+	}
+
+	remote, ok := rpc.GetRemoteIdentityFromContext(ctx)
+	if !ok {
+		return errors.New("remote identity not found in context")
+	}
+
+	cmd, err := commands.NewRoomServerRevokeAlias(remote, args.Alias())
+	if err != nil {
+		return errors.Wrap(err, "error creating the command")
+	}
+
+	if err := h.handler.Handle(cmd); err != nil {
+		return errors.Wrap(err, "error executing the command")
+	}
+
+	if err := s.WriteMessage([]byte("true"), transport.MessageBodyTypeJSON); err != nil {
+		return errors.Wrap(err, "error writing the message")
+	}
+
+	return nil
+}
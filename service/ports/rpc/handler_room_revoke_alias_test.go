@@ -0,0 +1,130 @@
+package rpc_test
+
+import (
+	"testing"
+
+	"github.com/boreq/errors"
+	"github.com/planetary-social/scuttlego/internal/fixtures"
+	"github.com/planetary-social/scuttlego/internal/mocks"
+	"github.com/planetary-social/scuttlego/service/app/commands"
+	"github.com/planetary-social/scuttlego/service/domain/messages"
+	"github.com/planetary-social/scuttlego/service/domain/rooms/aliases"
+	transportrpc "github.com/planetary-social/scuttlego/service/domain/transport/rpc"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc/transport"
+	"github.com/planetary-social/scuttlego/service/ports/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandlerRoomRevokeAlias_WritesTrue(t *testing.T) {
+	commandHandler := newRoomServerRevokeAliasCommandHandlerMock()
+	h := rpc.NewHandlerRoomRevokeAlias(commandHandler)
+
+	require.Equal(t, messages.RoomRevokeAliasProcedure, h.Procedure())
+
+	remote := fixtures.SomePublicIdentity()
+	alias := fixtures.SomeAlias()
+
+	ctx := transportrpc.PutRemoteIdentityInContext(fixtures.TestContext(t), remote)
+	s := mocks.NewMockCloserStream()
+
+	err := h.Handle(ctx, s, newRoomRevokeAliasRequest(t, alias))
+	require.NoError(t, err)
+
+	require.Equal(t,
+		[]commands.RoomServerRevokeAlias{
+			commands.MustNewRoomServerRevokeAlias(remote, alias),
+		},
+		commandHandler.Calls,
+	)
+
+	require.Equal(t,
+		[]mocks.MockCloserStreamWriteMessageCall{
+			{
+				Body:     []byte("true"),
+				BodyType: transport.MessageBodyTypeJSON,
+			},
+		},
+		s.WrittenMessages(),
+	)
+}
+
+func TestHandlerRoomRevokeAlias_ReturnsErrors(t *testing.T) {
+	alias := fixtures.SomeAlias()
+
+	testCases := []struct {
+		Name          string
+		Request       *transportrpc.Request
+		HasRemote     bool
+		HandlerError  error
+		ExpectedError string
+	}{
+		{
+			Name:          "invalid_arguments",
+			Request:       transportrpc.MustNewRequest(messages.RoomRevokeAliasProcedure.Name(), messages.RoomRevokeAliasProcedure.Typ(), []byte(`[]`)),
+			HasRemote:     true,
+			ExpectedError: "error parsing arguments: expected exactly one argument",
+		},
+		{
+			Name:          "missing_remote_identity",
+			Request:       newRoomRevokeAliasRequest(t, alias),
+			HasRemote:     false,
+			ExpectedError: "remote identity not found in context",
+		},
+		{
+			Name:          "handler_error",
+			Request:       newRoomRevokeAliasRequest(t, alias),
+			HasRemote:     true,
+			HandlerError:  commands.ErrRoomAliasRegisteredByDifferentUser,
+			ExpectedError: "error executing the command: alias was registered by a different user",
+		},
+		{
+			Name:          "other_handler_error",
+			Request:       newRoomRevokeAliasRequest(t, alias),
+			HasRemote:     true,
+			HandlerError:  errors.New("some error"),
+			ExpectedError: "error executing the command: some error",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			commandHandler := newRoomServerRevokeAliasCommandHandlerMock()
+			commandHandler.Err = testCase.HandlerError
+			h := rpc.NewHandlerRoomRevokeAlias(commandHandler)
+
+			ctx := fixtures.TestContext(t)
+			if testCase.HasRemote {
+				ctx = transportrpc.PutRemoteIdentityInContext(ctx, fixtures.SomePublicIdentity())
+			}
+			s := mocks.NewMockCloserStream()
+
+			err := h.Handle(ctx, s, testCase.Request)
+			require.EqualError(t, err, testCase.ExpectedError)
+			require.Empty(t, s.WrittenMessages())
+		})
+	}
+}
+
+func newRoomRevokeAliasRequest(t *testing.T, alias aliases.Alias) *transportrpc.Request {
+	args, err := messages.NewRoomRevokeAliasArguments(alias)
+	require.NoError(t, err)
+
+	req, err := messages.NewRoomRevokeAlias(args)
+	require.NoError(t, err)
+
+	return req
+}
+
+type roomServerRevokeAliasCommandHandlerMock struct {
+	Err   error
+	Calls []commands.RoomServerRevokeAlias
+}
+
+func newRoomServerRevokeAliasCommandHandlerMock() *roomServerRevokeAliasCommandHandlerMock {
+	return &roomServerRevokeAliasCommandHandlerMock{}
+}
+
+func (r *roomServerRevokeAliasCommandHandlerMock) Handle(cmd commands.RoomServerRevokeAlias) error {
+	r.Calls = append(r.Calls, cmd)
+	return r.Err
+}
@@ -0,0 +1,58 @@
+package rpc
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/boreq/errors"
+	"github.com/planetary-social/scuttlego/service/app/commands"
+	"github.com/planetary-social/scuttlego/service/domain/messages"
+	"github.com/planetary-social/scuttlego/service/domain/refs"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc/mux"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc/transport"
+)
+
+type CreateBlobCommandHandler interface {
+	Handle(cmd commands.CreateBlob) (refs.Blob, error)
+}
+
+type HandlerScuttlegoAddBlob struct {
+	handler CreateBlobCommandHandler
+}
+
+func NewHandlerScuttlegoAddBlob(handler CreateBlobCommandHandler) *HandlerScuttlegoAddBlob {
+	return &HandlerScuttlegoAddBlob{handler: handler}
+}
+
+func (h HandlerScuttlegoAddBlob) Procedure() rpc.Procedure {
+	return messages.ScuttlegoAddBlobProcedure
+}
+
+func (h HandlerScuttlegoAddBlob) Handle(ctx context.Context, s mux.Stream, req *rpc.Request) error {
+	args, err := messages.NewScuttlegoAddBlobArgumentsFromBytes(req.Arguments())
+	if err != nil {
+		return errors.Wrap(err, "error parsing arguments")
+	}
+
+	id, err := h.handler.Handle(commands.CreateBlob{Reader: bytes.NewReader(args.Data())})
+	if err != nil {
+		return errors.Wrap(err, "error executing the command")
+	}
+
+	response, err := messages.NewScuttlegoIdResponse(id)
+	if err != nil {
+		return errors.Wrap(err, "error creating the response")
+	}
+
+	j, err := response.MarshalJSON()
+	if err != nil {
+		return errors.Wrap(err, "json marshalling failed")
+	}
+
+	if err := s.WriteMessage(j, transport.MessageBodyTypeJSON); err != nil {
+		return errors.Wrap(err, "error writing the message")
+	}
+
+	return nil
+}
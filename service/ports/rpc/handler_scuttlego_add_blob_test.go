@@ -0,0 +1,99 @@
+package rpc_test
+
+import (
+	"io"
+	"testing"
+
+	"github.com/boreq/errors"
+	"github.com/planetary-social/scuttlego/internal/fixtures"
+	"github.com/planetary-social/scuttlego/internal/mocks"
+	"github.com/planetary-social/scuttlego/service/app/commands"
+	"github.com/planetary-social/scuttlego/service/domain/messages"
+	"github.com/planetary-social/scuttlego/service/domain/refs"
+	"github.com/planetary-social/scuttlego/service/ports/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandlerScuttlegoAddBlob(t *testing.T) {
+	id := fixtures.SomeRefBlob()
+	data := []byte("some blob")
+
+	commandHandler := newCreateBlobCommandHandlerMock(id, nil)
+	h := rpc.NewHandlerScuttlegoAddBlob(commandHandler)
+
+	require.Equal(t, messages.ScuttlegoAddBlobProcedure, h.Procedure())
+
+	s := mocks.NewMockCloserStream()
+	err := h.Handle(fixtures.TestContext(t), s, mustNewScuttlegoRequest(t, messages.ScuttlegoAddBlobProcedure, `["c29tZSBibG9i"]`))
+	require.NoError(t, err)
+
+	require.Equal(t, [][]byte{data}, commandHandler.data)
+	requireWrittenJSON(t, s, `{"id":"`+id.String()+`"}`)
+}
+
+func TestHandlerScuttlegoAddBlob_InvalidArguments(t *testing.T) {
+	testCases := []struct {
+		Name      string
+		Arguments string
+	}{
+		{
+			Name:      "no_arguments",
+			Arguments: `[]`,
+		},
+		{
+			Name:      "too_many_arguments",
+			Arguments: `["c29tZSBibG9i", "c29tZSBibG9i"]`,
+		},
+		{
+			Name:      "not_base64",
+			Arguments: `["not base64!"]`,
+		},
+		{
+			Name:      "empty_blob",
+			Arguments: `[""]`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			commandHandler := newCreateBlobCommandHandlerMock(fixtures.SomeRefBlob(), nil)
+			h := rpc.NewHandlerScuttlegoAddBlob(commandHandler)
+
+			s := mocks.NewMockCloserStream()
+			err := h.Handle(fixtures.TestContext(t), s, mustNewScuttlegoRequest(t, messages.ScuttlegoAddBlobProcedure, testCase.Arguments))
+			require.Error(t, err)
+
+			require.Empty(t, commandHandler.data)
+			require.Empty(t, s.WrittenMessages())
+		})
+	}
+}
+
+func TestHandlerScuttlegoAddBlob_CommandErrorIsReturned(t *testing.T) {
+	commandErr := errors.New("some error")
+	h := rpc.NewHandlerScuttlegoAddBlob(newCreateBlobCommandHandlerMock(refs.Blob{}, commandErr))
+
+	s := mocks.NewMockCloserStream()
+	err := h.Handle(fixtures.TestContext(t), s, mustNewScuttlegoRequest(t, messages.ScuttlegoAddBlobProcedure, `["c29tZSBibG9i"]`))
+	require.ErrorIs(t, err, commandErr)
+	require.Empty(t, s.WrittenMessages())
+}
+
+type createBlobCommandHandlerMock struct {
+	id   refs.Blob
+	err  error
+	data [][]byte
+}
+
+func newCreateBlobCommandHandlerMock(id refs.Blob, err error) *createBlobCommandHandlerMock {
+	return &createBlobCommandHandlerMock{id: id, err: err}
+}
+
+func (c *createBlobCommandHandlerMock) Handle(cmd commands.CreateBlob) (refs.Blob, error) {
+	data, err := io.ReadAll(cmd.Reader)
+	if err != nil {
+		return refs.Blob{}, err
+	}
+	c.data = append(c.data, data)
+	return c.id, c.err
+}
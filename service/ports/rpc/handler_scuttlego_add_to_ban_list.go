@@ -0,0 +1,50 @@
+package rpc
+
+import (
+	"context"
+
+	"github.com/boreq/errors"
+	"github.com/planetary-social/scuttlego/service/app/commands"
+	"github.com/planetary-social/scuttlego/service/domain/messages"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc/mux"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc/transport"
+)
+
+type AddToBanListCommandHandler interface {
+	Handle(cmd commands.AddToBanList) error
+}
+
+type HandlerScuttlegoAddToBanList struct {
+	handler AddToBanListCommandHandler
+}
+
+func NewHandlerScuttlegoAddToBanList(handler AddToBanListCommandHandler) *HandlerScuttlegoAddToBanList {
+	return &HandlerScuttlegoAddToBanList{handler: handler}
+}
+
+func (h HandlerScuttlegoAddToBanList) Procedure() rpc.Procedure {
+	return messages.ScuttlegoAddToBanListProcedure
+}
+
+func (h HandlerScuttlegoAddToBanList) Handle(ctx context.Context, s mux.Stream, req *rpc.Request) error {
+	args, err := messages.NewScuttlegoBanListArgumentsFromBytes(req.Arguments())
+	if err != nil {
+		return errors.Wrap(err, "error parsing arguments")
+	}
+
+	cmd, err := commands.NewAddToBanList(args.Hash())
+	if err != nil {
+		return errors.Wrap(err, "error creating the command")
+	}
+
+	if err := h.handler.Handle(cmd); err != nil {
+		return errors.Wrap(err, "error executing the command")
+	}
+
+	if err := s.WriteMessage([]byte("true"), transport.MessageBodyTypeJSON); err != nil {
+		return errors.Wrap(err, "error writing the message")
+	}
+
+	return nil
+}
@@ -0,0 +1,95 @@
+package rpc_test
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/boreq/errors"
+	"github.com/planetary-social/scuttlego/internal/fixtures"
+	"github.com/planetary-social/scuttlego/internal/mocks"
+	"github.com/planetary-social/scuttlego/service/app/commands"
+	"github.com/planetary-social/scuttlego/service/domain/messages"
+	"github.com/planetary-social/scuttlego/service/ports/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandlerScuttlegoAddToBanList(t *testing.T) {
+	hash := fixtures.SomeBanListHash()
+
+	commandHandler := newAddToBanListCommandHandlerMock(nil)
+	h := rpc.NewHandlerScuttlegoAddToBanList(commandHandler)
+
+	require.Equal(t, messages.ScuttlegoAddToBanListProcedure, h.Procedure())
+
+	s := mocks.NewMockCloserStream()
+	err := h.Handle(fixtures.TestContext(t), s, mustNewScuttlegoRequest(t, messages.ScuttlegoAddToBanListProcedure, `["`+hex.EncodeToString(hash.Bytes())+`"]`))
+	require.NoError(t, err)
+
+	expectedCmd, err := commands.NewAddToBanList(hash)
+	require.NoError(t, err)
+	require.Equal(t, []commands.AddToBanList{expectedCmd}, commandHandler.calls)
+
+	requireWrittenJSON(t, s, `true`)
+}
+
+func TestHandlerScuttlegoAddToBanList_InvalidArguments(t *testing.T) {
+	for _, testCase := range scuttlegoBanListInvalidArgumentsTestCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			commandHandler := newAddToBanListCommandHandlerMock(nil)
+			h := rpc.NewHandlerScuttlegoAddToBanList(commandHandler)
+
+			s := mocks.NewMockCloserStream()
+			err := h.Handle(fixtures.TestContext(t), s, mustNewScuttlegoRequest(t, messages.ScuttlegoAddToBanListProcedure, testCase.Arguments))
+			require.Error(t, err)
+
+			require.Empty(t, commandHandler.calls)
+			require.Empty(t, s.WrittenMessages())
+		})
+	}
+}
+
+func TestHandlerScuttlegoAddToBanList_CommandErrorIsReturned(t *testing.T) {
+	commandErr := errors.New("some error")
+	h := rpc.NewHandlerScuttlegoAddToBanList(newAddToBanListCommandHandlerMock(commandErr))
+
+	s := mocks.NewMockCloserStream()
+	err := h.Handle(fixtures.TestContext(t), s, mustNewScuttlegoRequest(t, messages.ScuttlegoAddToBanListProcedure, `["`+hex.EncodeToString(fixtures.SomeBanListHash().Bytes())+`"]`))
+	require.ErrorIs(t, err, commandErr)
+	require.Empty(t, s.WrittenMessages())
+}
+
+var scuttlegoBanListInvalidArgumentsTestCases = []struct {
+	Name      string
+	Arguments string
+}{
+	{
+		Name:      "no_arguments",
+		Arguments: `[]`,
+	},
+	{
+		Name:      "not_a_string",
+		Arguments: `[123]`,
+	},
+	{
+		Name:      "not_hex",
+		Arguments: `["not hex"]`,
+	},
+	{
+		Name:      "hash_too_short",
+		Arguments: `["abcd"]`,
+	},
+}
+
+type addToBanListCommandHandlerMock struct {
+	err   error
+	calls []commands.AddToBanList
+}
+
+func newAddToBanListCommandHandlerMock(err error) *addToBanListCommandHandlerMock {
+	return &addToBanListCommandHandlerMock{err: err}
+}
+
+func (a *addToBanListCommandHandlerMock) Handle(cmd commands.AddToBanList) error {
+	a.calls = append(a.calls, cmd)
+	return a.err
+}
@@ -0,0 +1,50 @@
+package rpc
+
+import (
+	"context"
+
+	"github.com/boreq/errors"
+	"github.com/planetary-social/scuttlego/service/app/commands"
+	"github.com/planetary-social/scuttlego/service/domain/messages"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc/mux"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc/transport"
+)
+
+type ConnectCommandHandler interface {
+	Handle(ctx context.Context, cmd commands.Connect) error
+}
+
+type HandlerScuttlegoConnect struct {
+	handler ConnectCommandHandler
+}
+
+func NewHandlerScuttlegoConnect(handler ConnectCommandHandler) *HandlerScuttlegoConnect {
+	return &HandlerScuttlegoConnect{handler: handler}
+}
+
+func (h HandlerScuttlegoConnect) Procedure() rpc.Procedure {
+	return messages.ScuttlegoConnectProcedure
+}
+
+func (h HandlerScuttlegoConnect) Handle(ctx context.Context, s mux.Stream, req *rpc.Request) error {
+	args, err := messages.NewScuttlegoConnectArgumentsFromBytes(req.Arguments())
+	if err != nil {
+		return errors.Wrap(err, "error parsing arguments")
+	}
+
+	cmd := commands.Connect{
+		Remote:  args.Address().Remote(),
+		Address: args.Address().Address(),
+	}
+
+	if err := h.handler.Handle(ctx, cmd); err != nil {
+		return errors.Wrap(err, "error executing the command")
+	}
+
+	if err := s.WriteMessage([]byte("true"), transport.MessageBodyTypeJSON); err != nil {
+		return errors.Wrap(err, "error writing the message")
+	}
+
+	return nil
+}
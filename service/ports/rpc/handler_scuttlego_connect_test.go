@@ -0,0 +1,129 @@
+package rpc_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/boreq/errors"
+	"github.com/planetary-social/scuttlego/internal/fixtures"
+	"github.com/planetary-social/scuttlego/internal/mocks"
+	"github.com/planetary-social/scuttlego/service/app/commands"
+	"github.com/planetary-social/scuttlego/service/domain/messages"
+	"github.com/planetary-social/scuttlego/service/domain/network"
+	"github.com/planetary-social/scuttlego/service/domain/refs"
+	transportrpc "github.com/planetary-social/scuttlego/service/domain/transport/rpc"
+	"github.com/planetary-social/scuttlego/service/ports/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandlerScuttlegoConnect(t *testing.T) {
+	remote, address := someScuttlegoMultiserverAddress()
+
+	commandHandler := newConnectCommandHandlerMock(nil)
+	h := rpc.NewHandlerScuttlegoConnect(commandHandler)
+
+	require.Equal(t, messages.ScuttlegoConnectProcedure, h.Procedure())
+
+	args, err := messages.NewScuttlegoConnectArguments(address)
+	require.NoError(t, err)
+
+	req, err := messages.NewScuttlegoConnect(args)
+	require.NoError(t, err)
+
+	s := mocks.NewMockCloserStream()
+	err = h.Handle(fixtures.TestContext(t), s, req)
+	require.NoError(t, err)
+
+	require.Len(t, commandHandler.calls, 1)
+	require.Equal(t, remote, refs.MustNewIdentityFromPublic(commandHandler.calls[0].Remote))
+	require.Equal(t, network.NewAddress("example.com:8008"), commandHandler.calls[0].Address)
+
+	requireWrittenJSON(t, s, `true`)
+}
+
+func TestHandlerScuttlegoConnect_InvalidArguments(t *testing.T) {
+	testCases := []struct {
+		Name      string
+		Arguments string
+	}{
+		{
+			Name:      "no_arguments",
+			Arguments: `[]`,
+		},
+		{
+			Name:      "not_a_string",
+			Arguments: `[{}]`,
+		},
+		{
+			Name:      "not_a_multiserver_address",
+			Arguments: `["example.com:8008"]`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			commandHandler := newConnectCommandHandlerMock(nil)
+			h := rpc.NewHandlerScuttlegoConnect(commandHandler)
+
+			s := mocks.NewMockCloserStream()
+			err := h.Handle(fixtures.TestContext(t), s, mustNewScuttlegoRequest(t, messages.ScuttlegoConnectProcedure, testCase.Arguments))
+			require.Error(t, err)
+
+			require.Empty(t, commandHandler.calls)
+			require.Empty(t, s.WrittenMessages())
+		})
+	}
+}
+
+func TestHandlerScuttlegoConnect_CommandErrorIsReturned(t *testing.T) {
+	_, address := someScuttlegoMultiserverAddress()
+
+	commandErr := errors.New("some error")
+	h := rpc.NewHandlerScuttlegoConnect(newConnectCommandHandlerMock(commandErr))
+
+	args, err := messages.NewScuttlegoConnectArguments(address)
+	require.NoError(t, err)
+
+	req, err := messages.NewScuttlegoConnect(args)
+	require.NoError(t, err)
+
+	s := mocks.NewMockCloserStream()
+	err = h.Handle(fixtures.TestContext(t), s, req)
+	require.ErrorIs(t, err, commandErr)
+	require.Empty(t, s.WrittenMessages())
+}
+
+// someScuttlegoMultiserverAddress returns an address of a peer in the format
+// accepted by procedures in the scuttlego namespace.
+func someScuttlegoMultiserverAddress() (refs.Identity, string) {
+	remote := fixtures.SomeRefIdentity()
+	key := strings.TrimSuffix(strings.TrimPrefix(remote.String(), "@"), ".ed25519")
+	return remote, "net:example.com:8008~shs:" + key
+}
+
+func mustNewScuttlegoRequest(t *testing.T, procedure transportrpc.Procedure, arguments string) *transportrpc.Request {
+	req, err := transportrpc.NewRequest(procedure.Name(), procedure.Typ(), []byte(arguments))
+	require.NoError(t, err)
+	return req
+}
+
+func requireWrittenJSON(t *testing.T, s *mocks.MockCloserStream, expected string) {
+	written := s.WrittenMessages()
+	require.Len(t, written, 1)
+	require.JSONEq(t, expected, string(written[0].Body))
+}
+
+type connectCommandHandlerMock struct {
+	err   error
+	calls []commands.Connect
+}
+
+func newConnectCommandHandlerMock(err error) *connectCommandHandlerMock {
+	return &connectCommandHandlerMock{err: err}
+}
+
+func (c *connectCommandHandlerMock) Handle(ctx context.Context, cmd commands.Connect) error {
+	c.calls = append(c.calls, cmd)
+	return c.err
+}
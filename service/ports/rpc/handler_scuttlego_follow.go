@@ -0,0 +1,45 @@
+package rpc
+
+import (
+	"context"
+
+	"github.com/boreq/errors"
+	"github.com/planetary-social/scuttlego/service/app/commands"
+	"github.com/planetary-social/scuttlego/service/domain/messages"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc/mux"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc/transport"
+)
+
+type FollowCommandHandler interface {
+	Handle(cmd commands.Follow) error
+}
+
+type HandlerScuttlegoFollow struct {
+	handler FollowCommandHandler
+}
+
+func NewHandlerScuttlegoFollow(handler FollowCommandHandler) *HandlerScuttlegoFollow {
+	return &HandlerScuttlegoFollow{handler: handler}
+}
+
+func (h HandlerScuttlegoFollow) Procedure() rpc.Procedure {
+	return messages.ScuttlegoFollowProcedure
+}
+
+func (h HandlerScuttlegoFollow) Handle(ctx context.Context, s mux.Stream, req *rpc.Request) error {
+	args, err := messages.NewScuttlegoIdentityArgumentsFromBytes(req.Arguments())
+	if err != nil {
+		return errors.Wrap(err, "error parsing arguments")
+	}
+
+	if err := h.handler.Handle(commands.Follow{Target: args.Id()}); err != nil {
+		return errors.Wrap(err, "error executing the command")
+	}
+
+	if err := s.WriteMessage([]byte("true"), transport.MessageBodyTypeJSON); err != nil {
+		return errors.Wrap(err, "error writing the message")
+	}
+
+	return nil
+}
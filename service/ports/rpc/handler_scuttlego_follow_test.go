@@ -0,0 +1,95 @@
+package rpc_test
+
+import (
+	"testing"
+
+	"github.com/boreq/errors"
+	"github.com/planetary-social/scuttlego/internal/fixtures"
+	"github.com/planetary-social/scuttlego/internal/mocks"
+	"github.com/planetary-social/scuttlego/service/app/commands"
+	"github.com/planetary-social/scuttlego/service/domain/messages"
+	"github.com/planetary-social/scuttlego/service/ports/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandlerScuttlegoFollow(t *testing.T) {
+	target := fixtures.SomeRefIdentity()
+
+	commandHandler := newFollowCommandHandlerMock(nil)
+	h := rpc.NewHandlerScuttlegoFollow(commandHandler)
+
+	require.Equal(t, messages.ScuttlegoFollowProcedure, h.Procedure())
+
+	args, err := messages.NewScuttlegoIdentityArguments(target)
+	require.NoError(t, err)
+
+	req, err := messages.NewScuttlegoFollow(args)
+	require.NoError(t, err)
+
+	s := mocks.NewMockCloserStream()
+	err = h.Handle(fixtures.TestContext(t), s, req)
+	require.NoError(t, err)
+
+	require.Equal(t, []commands.Follow{{Target: target}}, commandHandler.calls)
+	requireWrittenJSON(t, s, `true`)
+}
+
+func TestHandlerScuttlegoFollow_InvalidArguments(t *testing.T) {
+	testCases := []struct {
+		Name      string
+		Arguments string
+	}{
+		{
+			Name:      "no_arguments",
+			Arguments: `[]`,
+		},
+		{
+			Name:      "not_an_identity",
+			Arguments: `["%uaGieSQDJcHfUp6hjIcIq55GoZh4Ug7tNmgaohoxrpw=.sha256"]`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			commandHandler := newFollowCommandHandlerMock(nil)
+			h := rpc.NewHandlerScuttlegoFollow(commandHandler)
+
+			s := mocks.NewMockCloserStream()
+			err := h.Handle(fixtures.TestContext(t), s, mustNewScuttlegoRequest(t, messages.ScuttlegoFollowProcedure, testCase.Arguments))
+			require.Error(t, err)
+
+			require.Empty(t, commandHandler.calls)
+			require.Empty(t, s.WrittenMessages())
+		})
+	}
+}
+
+func TestHandlerScuttlegoFollow_CommandErrorIsReturned(t *testing.T) {
+	commandErr := errors.New("some error")
+	h := rpc.NewHandlerScuttlegoFollow(newFollowCommandHandlerMock(commandErr))
+
+	args, err := messages.NewScuttlegoIdentityArguments(fixtures.SomeRefIdentity())
+	require.NoError(t, err)
+
+	req, err := messages.NewScuttlegoFollow(args)
+	require.NoError(t, err)
+
+	s := mocks.NewMockCloserStream()
+	err = h.Handle(fixtures.TestContext(t), s, req)
+	require.ErrorIs(t, err, commandErr)
+	require.Empty(t, s.WrittenMessages())
+}
+
+type followCommandHandlerMock struct {
+	err   error
+	calls []commands.Follow
+}
+
+func newFollowCommandHandlerMock(err error) *followCommandHandlerMock {
+	return &followCommandHandlerMock{err: err}
+}
+
+func (f *followCommandHandlerMock) Handle(cmd commands.Follow) error {
+	f.calls = append(f.calls, cmd)
+	return f.err
+}
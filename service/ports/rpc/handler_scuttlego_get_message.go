@@ -0,0 +1,57 @@
+package rpc
+
+import (
+	"context"
+
+	"github.com/boreq/errors"
+	"github.com/planetary-social/scuttlego/service/app/queries"
+	"github.com/planetary-social/scuttlego/service/domain/messages"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc/mux"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc/transport"
+)
+
+type HandlerScuttlegoGetMessage struct {
+	handler GetMessageQueryHandler
+}
+
+func NewHandlerScuttlegoGetMessage(handler GetMessageQueryHandler) *HandlerScuttlegoGetMessage {
+	return &HandlerScuttlegoGetMessage{handler: handler}
+}
+
+func (h HandlerScuttlegoGetMessage) Procedure() rpc.Procedure {
+	return messages.ScuttlegoGetMessageProcedure
+}
+
+func (h HandlerScuttlegoGetMessage) Handle(ctx context.Context, s mux.Stream, req *rpc.Request) error {
+	args, err := messages.NewGetArgumentsFromBytes(req.Arguments())
+	if err != nil {
+		return errors.Wrap(err, "error parsing arguments")
+	}
+
+	query, err := queries.NewGetMessage(args.Id())
+	if err != nil {
+		return errors.Wrap(err, "error creating the query")
+	}
+
+	msg, err := h.handler.Handle(query)
+	if err != nil {
+		return errors.Wrap(err, "error executing the query")
+	}
+
+	response, err := messages.NewScuttlegoMessageResponse(msg)
+	if err != nil {
+		return errors.Wrap(err, "error creating the response")
+	}
+
+	j, err := response.MarshalJSON()
+	if err != nil {
+		return errors.Wrap(err, "json marshalling failed")
+	}
+
+	if err := s.WriteMessage(j, transport.MessageBodyTypeJSON); err != nil {
+		return errors.Wrap(err, "error writing the message")
+	}
+
+	return nil
+}
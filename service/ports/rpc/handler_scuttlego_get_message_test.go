@@ -0,0 +1,97 @@
+package rpc_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/planetary-social/scuttlego/internal/fixtures"
+	"github.com/planetary-social/scuttlego/internal/mocks"
+	"github.com/planetary-social/scuttlego/service/app/common"
+	"github.com/planetary-social/scuttlego/service/domain/feeds/message"
+	"github.com/planetary-social/scuttlego/service/domain/messages"
+	"github.com/planetary-social/scuttlego/service/ports/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandlerScuttlegoGetMessage(t *testing.T) {
+	msg := fixtures.SomeMessage(fixtures.SomeSequence(), fixtures.SomeRefFeed())
+
+	queryHandler := newGetMessageQueryHandlerMock(msg)
+	h := rpc.NewHandlerScuttlegoGetMessage(queryHandler)
+
+	require.Equal(t, messages.ScuttlegoGetMessageProcedure, h.Procedure())
+
+	args, err := messages.NewGetArguments(msg.Id())
+	require.NoError(t, err)
+
+	req, err := messages.NewScuttlegoGetMessage(args)
+	require.NoError(t, err)
+
+	s := mocks.NewMockCloserStream()
+	err = h.Handle(fixtures.TestContext(t), s, req)
+	require.NoError(t, err)
+
+	require.Len(t, queryHandler.calls, 1)
+	require.Equal(t, msg.Id(), queryHandler.calls[0].Id())
+
+	raw, err := json.Marshal(string(msg.Raw().Bytes()))
+	require.NoError(t, err)
+
+	requireWrittenJSON(t, s, fmt.Sprintf(
+		`{"id":"%s","feed":"%s","sequence":%d,"timestamp":%d,"content":%s,"raw":%s}`,
+		msg.Id(),
+		msg.Feed(),
+		msg.Sequence().Int(),
+		msg.Timestamp().UnixMilli(),
+		msg.Content().Raw().Bytes(),
+		raw,
+	))
+}
+
+func TestHandlerScuttlegoGetMessage_InvalidArguments(t *testing.T) {
+	testCases := []struct {
+		Name      string
+		Arguments string
+	}{
+		{
+			Name:      "no_arguments",
+			Arguments: `[]`,
+		},
+		{
+			Name:      "not_a_message_ref",
+			Arguments: `["@qFtLJ6P5Eh9vKxnj7Rsh8SkE6B6Z36DVLP7ZOKNeQ/Y=.ed25519"]`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			queryHandler := newGetMessageQueryHandlerMock(message.Message{})
+			h := rpc.NewHandlerScuttlegoGetMessage(queryHandler)
+
+			s := mocks.NewMockCloserStream()
+			err := h.Handle(fixtures.TestContext(t), s, mustNewScuttlegoRequest(t, messages.ScuttlegoGetMessageProcedure, testCase.Arguments))
+			require.Error(t, err)
+
+			require.Empty(t, queryHandler.calls)
+			require.Empty(t, s.WrittenMessages())
+		})
+	}
+}
+
+func TestHandlerScuttlegoGetMessage_MessageNotFoundErrorIsReturned(t *testing.T) {
+	queryHandler := newGetMessageQueryHandlerMock(message.Message{})
+	queryHandler.err = common.ErrMessageNotFound
+	h := rpc.NewHandlerScuttlegoGetMessage(queryHandler)
+
+	args, err := messages.NewGetArguments(fixtures.SomeRefMessage())
+	require.NoError(t, err)
+
+	req, err := messages.NewScuttlegoGetMessage(args)
+	require.NoError(t, err)
+
+	s := mocks.NewMockCloserStream()
+	err = h.Handle(fixtures.TestContext(t), s, req)
+	require.ErrorIs(t, err, common.ErrMessageNotFound)
+	require.Empty(t, s.WrittenMessages())
+}
@@ -0,0 +1,63 @@
+package rpc
+
+import (
+	"context"
+
+	"github.com/boreq/errors"
+	"github.com/planetary-social/scuttlego/service/app/queries"
+	"github.com/planetary-social/scuttlego/service/domain/messages"
+	"github.com/planetary-social/scuttlego/service/domain/refs"
+	"github.com/planetary-social/scuttlego/service/domain/rooms/aliases"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc/mux"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc/transport"
+)
+
+type RoomsListAliasesQueryHandler interface {
+	Handle(ctx context.Context, query queries.RoomsListAliases) ([]aliases.Alias, error)
+}
+
+type HandlerScuttlegoListAliases struct {
+	handler RoomsListAliasesQueryHandler
+}
+
+func NewHandlerScuttlegoListAliases(handler RoomsListAliasesQueryHandler) *HandlerScuttlegoListAliases {
+	return &HandlerScuttlegoListAliases{handler: handler}
+}
+
+func (h HandlerScuttlegoListAliases) Procedure() rpc.Procedure {
+	return messages.ScuttlegoListAliasesProcedure
+}
+
+func (h HandlerScuttlegoListAliases) Handle(ctx context.Context, s mux.Stream, req *rpc.Request) error {
+	args, err := messages.NewScuttlegoRoomArgumentsFromBytes(req.Arguments())
+	if err != nil {
+		return errors.Wrap(err, "error parsing arguments")
+	}
+
+	room, err := refs.NewIdentityFromPublic(args.Room().Remote())
+	if err != nil {
+		return errors.Wrap(err, "error creating the room ref")
+	}
+
+	query, err := queries.NewRoomsListAliases(room, args.Room().Address())
+	if err != nil {
+		return errors.Wrap(err, "error creating the query")
+	}
+
+	result, err := h.handler.Handle(ctx, query)
+	if err != nil {
+		return errors.Wrap(err, "error executing the query")
+	}
+
+	j, err := messages.NewRoomListAliasesResponse(result).MarshalJSON()
+	if err != nil {
+		return errors.Wrap(err, "json marshalling failed")
+	}
+
+	if err := s.WriteMessage(j, transport.MessageBodyTypeJSON); err != nil {
+		return errors.Wrap(err, "error writing the message")
+	}
+
+	return nil
+}
@@ -0,0 +1,114 @@
+package rpc_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/boreq/errors"
+	"github.com/planetary-social/scuttlego/internal/fixtures"
+	"github.com/planetary-social/scuttlego/internal/mocks"
+	"github.com/planetary-social/scuttlego/service/app/queries"
+	"github.com/planetary-social/scuttlego/service/domain/messages"
+	"github.com/planetary-social/scuttlego/service/domain/network"
+	"github.com/planetary-social/scuttlego/service/domain/rooms/aliases"
+	"github.com/planetary-social/scuttlego/service/ports/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandlerScuttlegoListAliases(t *testing.T) {
+	room, address := someScuttlegoMultiserverAddress()
+
+	queryHandler := newRoomsListAliasesQueryHandlerMock(
+		[]aliases.Alias{
+			aliases.MustNewAlias("alias1"),
+			aliases.MustNewAlias("alias2"),
+		},
+		nil,
+	)
+	h := rpc.NewHandlerScuttlegoListAliases(queryHandler)
+
+	require.Equal(t, messages.ScuttlegoListAliasesProcedure, h.Procedure())
+
+	args, err := messages.NewScuttlegoRoomArguments(address)
+	require.NoError(t, err)
+
+	req, err := messages.NewScuttlegoListAliases(args)
+	require.NoError(t, err)
+
+	s := mocks.NewMockCloserStream()
+	err = h.Handle(fixtures.TestContext(t), s, req)
+	require.NoError(t, err)
+
+	expectedQuery, err := queries.NewRoomsListAliases(room, network.NewAddress("example.com:8008"))
+	require.NoError(t, err)
+	require.Equal(t, []queries.RoomsListAliases{expectedQuery}, queryHandler.calls)
+
+	requireWrittenJSON(t, s, `["alias1","alias2"]`)
+}
+
+func TestHandlerScuttlegoListAliases_InvalidArguments(t *testing.T) {
+	testCases := []struct {
+		Name      string
+		Arguments string
+	}{
+		{
+			Name:      "no_arguments",
+			Arguments: `[]`,
+		},
+		{
+			Name:      "not_an_object",
+			Arguments: `["net:example.com:8008"]`,
+		},
+		{
+			Name:      "invalid_room_address",
+			Arguments: `[{"room":"example.com:8008"}]`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			queryHandler := newRoomsListAliasesQueryHandlerMock(nil, nil)
+			h := rpc.NewHandlerScuttlegoListAliases(queryHandler)
+
+			s := mocks.NewMockCloserStream()
+			err := h.Handle(fixtures.TestContext(t), s, mustNewScuttlegoRequest(t, messages.ScuttlegoListAliasesProcedure, testCase.Arguments))
+			require.Error(t, err)
+
+			require.Empty(t, queryHandler.calls)
+			require.Empty(t, s.WrittenMessages())
+		})
+	}
+}
+
+func TestHandlerScuttlegoListAliases_QueryErrorIsReturned(t *testing.T) {
+	_, address := someScuttlegoMultiserverAddress()
+
+	queryErr := errors.New("some error")
+	h := rpc.NewHandlerScuttlegoListAliases(newRoomsListAliasesQueryHandlerMock(nil, queryErr))
+
+	args, err := messages.NewScuttlegoRoomArguments(address)
+	require.NoError(t, err)
+
+	req, err := messages.NewScuttlegoListAliases(args)
+	require.NoError(t, err)
+
+	s := mocks.NewMockCloserStream()
+	err = h.Handle(fixtures.TestContext(t), s, req)
+	require.ErrorIs(t, err, queryErr)
+	require.Empty(t, s.WrittenMessages())
+}
+
+type roomsListAliasesQueryHandlerMock struct {
+	result []aliases.Alias
+	err    error
+	calls  []queries.RoomsListAliases
+}
+
+func newRoomsListAliasesQueryHandlerMock(result []aliases.Alias, err error) *roomsListAliasesQueryHandlerMock {
+	return &roomsListAliasesQueryHandlerMock{result: result, err: err}
+}
+
+func (r *roomsListAliasesQueryHandlerMock) Handle(ctx context.Context, query queries.RoomsListAliases) ([]aliases.Alias, error) {
+	r.calls = append(r.calls, query)
+	return r.result, r.err
+}
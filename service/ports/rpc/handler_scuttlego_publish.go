@@ -0,0 +1,64 @@
+package rpc
+
+import (
+	"context"
+
+	"github.com/boreq/errors"
+	"github.com/planetary-social/scuttlego/service/app/commands"
+	"github.com/planetary-social/scuttlego/service/domain/messages"
+	"github.com/planetary-social/scuttlego/service/domain/refs"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc/mux"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc/transport"
+)
+
+type PublishRawCommandHandler interface {
+	Handle(cmd commands.PublishRaw) (refs.Message, error)
+}
+
+// HandlerScuttlegoPublish publishes a message using the identity of this
+// node.
+type HandlerScuttlegoPublish struct {
+	handler PublishRawCommandHandler
+}
+
+func NewHandlerScuttlegoPublish(handler PublishRawCommandHandler) *HandlerScuttlegoPublish {
+	return &HandlerScuttlegoPublish{handler: handler}
+}
+
+func (h HandlerScuttlegoPublish) Procedure() rpc.Procedure {
+	return messages.ScuttlegoPublishProcedure
+}
+
+func (h HandlerScuttlegoPublish) Handle(ctx context.Context, s mux.Stream, req *rpc.Request) error {
+	args, err := messages.NewScuttlegoPublishArgumentsFromBytes(req.Arguments())
+	if err != nil {
+		return errors.Wrap(err, "error parsing arguments")
+	}
+
+	cmd, err := commands.NewPublishRaw(args.Content())
+	if err != nil {
+		return errors.Wrap(err, "error creating the command")
+	}
+
+	id, err := h.handler.Handle(cmd)
+	if err != nil {
+		return errors.Wrap(err, "error executing the command")
+	}
+
+	response, err := messages.NewScuttlegoIdResponse(id)
+	if err != nil {
+		return errors.Wrap(err, "error creating the response")
+	}
+
+	j, err := response.MarshalJSON()
+	if err != nil {
+		return errors.Wrap(err, "json marshalling failed")
+	}
+
+	if err := s.WriteMessage(j, transport.MessageBodyTypeJSON); err != nil {
+		return errors.Wrap(err, "error writing the message")
+	}
+
+	return nil
+}
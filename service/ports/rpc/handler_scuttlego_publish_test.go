@@ -0,0 +1,56 @@
+package rpc_test
+
+import (
+	"testing"
+
+	"github.com/planetary-social/scuttlego/internal/fixtures"
+	"github.com/planetary-social/scuttlego/internal/mocks"
+	"github.com/planetary-social/scuttlego/service/app/commands"
+	"github.com/planetary-social/scuttlego/service/domain/messages"
+	"github.com/planetary-social/scuttlego/service/domain/refs"
+	"github.com/planetary-social/scuttlego/service/ports/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandlerScuttlegoPublish(t *testing.T) {
+	id := fixtures.SomeRefMessage()
+	content := []byte(`{"type":"post","text":"hello"}`)
+
+	commandHandler := newPublishRawCommandHandlerMock(id)
+	h := rpc.NewHandlerScuttlegoPublish(commandHandler)
+
+	require.Equal(t, messages.ScuttlegoPublishProcedure, h.Procedure())
+
+	args, err := messages.NewScuttlegoPublishArguments(content)
+	require.NoError(t, err)
+
+	req, err := messages.NewScuttlegoPublish(args)
+	require.NoError(t, err)
+
+	s := mocks.NewMockCloserStream()
+	err = h.Handle(fixtures.TestContext(t), s, req)
+	require.NoError(t, err)
+
+	expectedCmd, err := commands.NewPublishRaw(content)
+	require.NoError(t, err)
+	require.Equal(t, []commands.PublishRaw{expectedCmd}, commandHandler.calls)
+
+	written := s.WrittenMessages()
+	require.Len(t, written, 1)
+	require.JSONEq(t, `{"id":"`+id.String()+`"}`, string(written[0].Body))
+}
+
+type publishRawCommandHandlerMock struct {
+	id    refs.Message
+	err   error
+	calls []commands.PublishRaw
+}
+
+func newPublishRawCommandHandlerMock(id refs.Message) *publishRawCommandHandlerMock {
+	return &publishRawCommandHandlerMock{id: id}
+}
+
+func (p *publishRawCommandHandlerMock) Handle(cmd commands.PublishRaw) (refs.Message, error) {
+	p.calls = append(p.calls, cmd)
+	return p.id, p.err
+}
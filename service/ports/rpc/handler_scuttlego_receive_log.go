@@ -0,0 +1,72 @@
+package rpc
+
+import (
+	"context"
+
+	"github.com/boreq/errors"
+	"github.com/planetary-social/scuttlego/service/app/common"
+	"github.com/planetary-social/scuttlego/service/app/queries"
+	"github.com/planetary-social/scuttlego/service/domain/messages"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc/mux"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc/transport"
+)
+
+type ReceiveLogQueryHandler interface {
+	Handle(query queries.ReceiveLog) ([]queries.LogMessage, error)
+}
+
+// HandlerScuttlegoReceiveLog streams a page of the receive log. Clients page
+// through the log by passing the last received sequence plus one as the start
+// of the next request.
+type HandlerScuttlegoReceiveLog struct {
+	handler ReceiveLogQueryHandler
+}
+
+func NewHandlerScuttlegoReceiveLog(handler ReceiveLogQueryHandler) *HandlerScuttlegoReceiveLog {
+	return &HandlerScuttlegoReceiveLog{handler: handler}
+}
+
+func (h HandlerScuttlegoReceiveLog) Procedure() rpc.Procedure {
+	return messages.ScuttlegoReceiveLogProcedure
+}
+
+func (h HandlerScuttlegoReceiveLog) Handle(ctx context.Context, s mux.Stream, req *rpc.Request) error {
+	args, err := messages.NewScuttlegoReceiveLogArgumentsFromBytes(req.Arguments())
+	if err != nil {
+		return errors.Wrap(err, "error parsing arguments")
+	}
+
+	start, err := common.NewReceiveLogSequence(args.Start())
+	if err != nil {
+		return errors.Wrap(err, "error creating the start sequence")
+	}
+
+	query, err := queries.NewReceiveLog(start, args.Limit())
+	if err != nil {
+		return errors.Wrap(err, "error creating the query")
+	}
+
+	logMessages, err := h.handler.Handle(query)
+	if err != nil {
+		return errors.Wrap(err, "error executing the query")
+	}
+
+	for _, logMessage := range logMessages {
+		response, err := messages.NewScuttlegoReceiveLogResponse(logMessage.Message, logMessage.Sequence.Int())
+		if err != nil {
+			return errors.Wrap(err, "error creating the response")
+		}
+
+		j, err := response.MarshalJSON()
+		if err != nil {
+			return errors.Wrap(err, "json marshalling failed")
+		}
+
+		if err := s.WriteMessage(j, transport.MessageBodyTypeJSON); err != nil {
+			return errors.Wrap(err, "error writing the message")
+		}
+	}
+
+	return nil
+}
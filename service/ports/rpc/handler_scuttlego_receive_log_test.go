@@ -0,0 +1,73 @@
+package rpc_test
+
+import (
+	"testing"
+
+	"github.com/planetary-social/scuttlego/internal/fixtures"
+	"github.com/planetary-social/scuttlego/internal/mocks"
+	"github.com/planetary-social/scuttlego/service/app/common"
+	"github.com/planetary-social/scuttlego/service/app/queries"
+	"github.com/planetary-social/scuttlego/service/domain/feeds/message"
+	"github.com/planetary-social/scuttlego/service/domain/messages"
+	"github.com/planetary-social/scuttlego/service/ports/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandlerScuttlegoReceiveLog(t *testing.T) {
+	msg1 := fixtures.SomeMessage(message.NewFirstSequence(), fixtures.SomeRefFeed())
+	msg2 := fixtures.SomeMessage(message.NewFirstSequence(), fixtures.SomeRefFeed())
+
+	queryHandler := newReceiveLogQueryHandlerMock([]queries.LogMessage{
+		{
+			Message:  msg1,
+			Sequence: common.MustNewReceiveLogSequence(10),
+		},
+		{
+			Message:  msg2,
+			Sequence: common.MustNewReceiveLogSequence(11),
+		},
+	})
+	h := rpc.NewHandlerScuttlegoReceiveLog(queryHandler)
+
+	require.Equal(t, messages.ScuttlegoReceiveLogProcedure, h.Procedure())
+
+	args, err := messages.NewScuttlegoReceiveLogArguments(10, 2)
+	require.NoError(t, err)
+
+	req, err := messages.NewScuttlegoReceiveLog(args)
+	require.NoError(t, err)
+
+	s := mocks.NewMockCloserStream()
+	err = h.Handle(fixtures.TestContext(t), s, req)
+	require.NoError(t, err)
+
+	expectedQuery, err := queries.NewReceiveLog(common.MustNewReceiveLogSequence(10), 2)
+	require.NoError(t, err)
+	require.Equal(t, []queries.ReceiveLog{expectedQuery}, queryHandler.calls)
+
+	written := s.WrittenMessages()
+	require.Len(t, written, 2)
+
+	for i, msg := range []message.Message{msg1, msg2} {
+		response, err := messages.NewScuttlegoReceiveLogResponse(msg, 10+i)
+		require.NoError(t, err)
+
+		j, err := response.MarshalJSON()
+		require.NoError(t, err)
+		require.Equal(t, j, written[i].Body)
+	}
+}
+
+type receiveLogQueryHandlerMock struct {
+	result []queries.LogMessage
+	calls  []queries.ReceiveLog
+}
+
+func newReceiveLogQueryHandlerMock(result []queries.LogMessage) *receiveLogQueryHandlerMock {
+	return &receiveLogQueryHandlerMock{result: result}
+}
+
+func (r *receiveLogQueryHandlerMock) Handle(query queries.ReceiveLog) ([]queries.LogMessage, error) {
+	r.calls = append(r.calls, query)
+	return r.result, nil
+}
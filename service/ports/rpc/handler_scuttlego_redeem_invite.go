@@ -0,0 +1,51 @@
+package rpc
+
+import (
+	"context"
+
+	"github.com/boreq/errors"
+	"github.com/planetary-social/scuttlego/service/app/commands"
+	"github.com/planetary-social/scuttlego/service/domain/invites"
+	"github.com/planetary-social/scuttlego/service/domain/messages"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc/mux"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc/transport"
+)
+
+type RedeemInviteCommandHandler interface {
+	Handle(ctx context.Context, cmd commands.RedeemInvite) error
+}
+
+type HandlerScuttlegoRedeemInvite struct {
+	handler RedeemInviteCommandHandler
+}
+
+func NewHandlerScuttlegoRedeemInvite(handler RedeemInviteCommandHandler) *HandlerScuttlegoRedeemInvite {
+	return &HandlerScuttlegoRedeemInvite{handler: handler}
+}
+
+func (h HandlerScuttlegoRedeemInvite) Procedure() rpc.Procedure {
+	return messages.ScuttlegoRedeemInviteProcedure
+}
+
+func (h HandlerScuttlegoRedeemInvite) Handle(ctx context.Context, s mux.Stream, req *rpc.Request) error {
+	args, err := messages.NewScuttlegoRedeemInviteArgumentsFromBytes(req.Arguments())
+	if err != nil {
+		return errors.Wrap(err, "error parsing arguments")
+	}
+
+	invite, err := invites.NewInviteFromString(args.Invite())
+	if err != nil {
+		return errors.Wrap(err, "invalid invite")
+	}
+
+	if err := h.handler.Handle(ctx, commands.RedeemInvite{Invite: invite}); err != nil {
+		return errors.Wrap(err, "error executing the command")
+	}
+
+	if err := s.WriteMessage([]byte("true"), transport.MessageBodyTypeJSON); err != nil {
+		return errors.Wrap(err, "error writing the message")
+	}
+
+	return nil
+}
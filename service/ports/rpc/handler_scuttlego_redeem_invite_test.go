@@ -0,0 +1,100 @@
+package rpc_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/boreq/errors"
+	"github.com/planetary-social/scuttlego/internal/fixtures"
+	"github.com/planetary-social/scuttlego/internal/mocks"
+	"github.com/planetary-social/scuttlego/service/app/commands"
+	"github.com/planetary-social/scuttlego/service/domain/messages"
+	"github.com/planetary-social/scuttlego/service/ports/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandlerScuttlegoRedeemInvite(t *testing.T) {
+	invite := fixtures.SomeInvite()
+
+	commandHandler := newRedeemInviteCommandHandlerMock(nil)
+	h := rpc.NewHandlerScuttlegoRedeemInvite(commandHandler)
+
+	require.Equal(t, messages.ScuttlegoRedeemInviteProcedure, h.Procedure())
+
+	args, err := messages.NewScuttlegoRedeemInviteArguments(invite.String())
+	require.NoError(t, err)
+
+	req, err := messages.NewScuttlegoRedeemInvite(args)
+	require.NoError(t, err)
+
+	s := mocks.NewMockCloserStream()
+	err = h.Handle(fixtures.TestContext(t), s, req)
+	require.NoError(t, err)
+
+	require.Equal(t, []commands.RedeemInvite{{Invite: invite}}, commandHandler.calls)
+	requireWrittenJSON(t, s, `true`)
+}
+
+func TestHandlerScuttlegoRedeemInvite_InvalidArguments(t *testing.T) {
+	testCases := []struct {
+		Name      string
+		Arguments string
+	}{
+		{
+			Name:      "no_arguments",
+			Arguments: `[]`,
+		},
+		{
+			Name:      "empty_invite",
+			Arguments: `[""]`,
+		},
+		{
+			Name:      "invalid_invite",
+			Arguments: `["not an invite"]`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			commandHandler := newRedeemInviteCommandHandlerMock(nil)
+			h := rpc.NewHandlerScuttlegoRedeemInvite(commandHandler)
+
+			s := mocks.NewMockCloserStream()
+			err := h.Handle(fixtures.TestContext(t), s, mustNewScuttlegoRequest(t, messages.ScuttlegoRedeemInviteProcedure, testCase.Arguments))
+			require.Error(t, err)
+
+			require.Empty(t, commandHandler.calls)
+			require.Empty(t, s.WrittenMessages())
+		})
+	}
+}
+
+func TestHandlerScuttlegoRedeemInvite_CommandErrorIsReturned(t *testing.T) {
+	commandErr := errors.New("some error")
+	h := rpc.NewHandlerScuttlegoRedeemInvite(newRedeemInviteCommandHandlerMock(commandErr))
+
+	args, err := messages.NewScuttlegoRedeemInviteArguments(fixtures.SomeInvite().String())
+	require.NoError(t, err)
+
+	req, err := messages.NewScuttlegoRedeemInvite(args)
+	require.NoError(t, err)
+
+	s := mocks.NewMockCloserStream()
+	err = h.Handle(fixtures.TestContext(t), s, req)
+	require.ErrorIs(t, err, commandErr)
+	require.Empty(t, s.WrittenMessages())
+}
+
+type redeemInviteCommandHandlerMock struct {
+	err   error
+	calls []commands.RedeemInvite
+}
+
+func newRedeemInviteCommandHandlerMock(err error) *redeemInviteCommandHandlerMock {
+	return &redeemInviteCommandHandlerMock{err: err}
+}
+
+func (r *redeemInviteCommandHandlerMock) Handle(ctx context.Context, cmd commands.RedeemInvite) error {
+	r.calls = append(r.calls, cmd)
+	return r.err
+}
@@ -0,0 +1,71 @@
+package rpc
+
+import (
+	"context"
+
+	"github.com/boreq/errors"
+	"github.com/planetary-social/scuttlego/service/app/commands"
+	"github.com/planetary-social/scuttlego/service/domain/messages"
+	"github.com/planetary-social/scuttlego/service/domain/refs"
+	"github.com/planetary-social/scuttlego/service/domain/rooms/aliases"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc/mux"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc/transport"
+)
+
+type RoomsAliasRegisterCommandHandler interface {
+	Handle(ctx context.Context, cmd commands.RoomsAliasRegister) (aliases.AliasEndpointURL, error)
+}
+
+// HandlerScuttlegoRegisterAlias registers an alias in a room on behalf of
+// this node. It shouldn't be confused with HandlerRoomRegisterAlias which is
+// used when this node acts as a room.
+type HandlerScuttlegoRegisterAlias struct {
+	handler RoomsAliasRegisterCommandHandler
+}
+
+func NewHandlerScuttlegoRegisterAlias(handler RoomsAliasRegisterCommandHandler) *HandlerScuttlegoRegisterAlias {
+	return &HandlerScuttlegoRegisterAlias{handler: handler}
+}
+
+func (h HandlerScuttlegoRegisterAlias) Procedure() rpc.Procedure {
+	return messages.ScuttlegoRegisterAliasProcedure
+}
+
+func (h HandlerScuttlegoRegisterAlias) Handle(ctx context.Context, s mux.Stream, req *rpc.Request) error {
+	args, err := messages.NewScuttlegoAliasArgumentsFromBytes(req.Arguments())
+	if err != nil {
+		return errors.Wrap(err, "error parsing arguments")
+	}
+
+	room, err := refs.NewIdentityFromPublic(args.Room().Remote())
+	if err != nil {
+		return errors.Wrap(err, "error creating the room ref")
+	}
+
+	cmd, err := commands.NewRoomsAliasRegister(room, args.Room().Address(), args.Alias())
+	if err != nil {
+		return errors.Wrap(err, "error creating the command")
+	}
+
+	url, err := h.handler.Handle(ctx, cmd)
+	if err != nil {
+		return errors.Wrap(err, "error executing the command")
+	}
+
+	response, err := messages.NewScuttlegoRegisterAliasResponse(url)
+	if err != nil {
+		return errors.Wrap(err, "error creating the response")
+	}
+
+	j, err := response.MarshalJSON()
+	if err != nil {
+		return errors.Wrap(err, "json marshalling failed")
+	}
+
+	if err := s.WriteMessage(j, transport.MessageBodyTypeJSON); err != nil {
+		return errors.Wrap(err, "error writing the message")
+	}
+
+	return nil
+}
@@ -0,0 +1,121 @@
+package rpc_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/boreq/errors"
+	"github.com/planetary-social/scuttlego/internal/fixtures"
+	"github.com/planetary-social/scuttlego/internal/mocks"
+	"github.com/planetary-social/scuttlego/service/app/commands"
+	"github.com/planetary-social/scuttlego/service/domain/messages"
+	"github.com/planetary-social/scuttlego/service/domain/network"
+	"github.com/planetary-social/scuttlego/service/domain/rooms/aliases"
+	transportrpc "github.com/planetary-social/scuttlego/service/domain/transport/rpc"
+	"github.com/planetary-social/scuttlego/service/ports/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandlerScuttlegoRegisterAlias(t *testing.T) {
+	room, address := someScuttlegoMultiserverAddress()
+	alias := fixtures.SomeAlias()
+	url := aliases.MustNewAliasEndpointURL("https://" + alias.String() + ".example.com")
+
+	commandHandler := newRoomsAliasRegisterCommandHandlerMock(url, nil)
+	h := rpc.NewHandlerScuttlegoRegisterAlias(commandHandler)
+
+	require.Equal(t, messages.ScuttlegoRegisterAliasProcedure, h.Procedure())
+
+	s := mocks.NewMockCloserStream()
+	err := h.Handle(fixtures.TestContext(t), s, mustNewScuttlegoAliasRequest(t, messages.NewScuttlegoRegisterAlias, address, alias))
+	require.NoError(t, err)
+
+	expectedCmd, err := commands.NewRoomsAliasRegister(room, network.NewAddress("example.com:8008"), alias)
+	require.NoError(t, err)
+	require.Equal(t, []commands.RoomsAliasRegister{expectedCmd}, commandHandler.calls)
+
+	requireWrittenJSON(t, s, `{"url":"`+url.String()+`"}`)
+}
+
+func TestHandlerScuttlegoRegisterAlias_InvalidArguments(t *testing.T) {
+	for _, testCase := range scuttlegoAliasInvalidArgumentsTestCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			commandHandler := newRoomsAliasRegisterCommandHandlerMock(aliases.AliasEndpointURL{}, nil)
+			h := rpc.NewHandlerScuttlegoRegisterAlias(commandHandler)
+
+			s := mocks.NewMockCloserStream()
+			err := h.Handle(fixtures.TestContext(t), s, mustNewScuttlegoRequest(t, messages.ScuttlegoRegisterAliasProcedure, testCase.Arguments))
+			require.Error(t, err)
+
+			require.Empty(t, commandHandler.calls)
+			require.Empty(t, s.WrittenMessages())
+		})
+	}
+}
+
+func TestHandlerScuttlegoRegisterAlias_CommandErrorIsReturned(t *testing.T) {
+	_, address := someScuttlegoMultiserverAddress()
+
+	commandErr := errors.New("some error")
+	h := rpc.NewHandlerScuttlegoRegisterAlias(newRoomsAliasRegisterCommandHandlerMock(aliases.AliasEndpointURL{}, commandErr))
+
+	s := mocks.NewMockCloserStream()
+	err := h.Handle(fixtures.TestContext(t), s, mustNewScuttlegoAliasRequest(t, messages.NewScuttlegoRegisterAlias, address, fixtures.SomeAlias()))
+	require.ErrorIs(t, err, commandErr)
+	require.Empty(t, s.WrittenMessages())
+}
+
+var scuttlegoAliasInvalidArgumentsTestCases = []struct {
+	Name      string
+	Arguments string
+}{
+	{
+		Name:      "no_arguments",
+		Arguments: `[]`,
+	},
+	{
+		Name:      "missing_alias",
+		Arguments: `[{"room":"net:example.com:8008~shs:qFtLJ6P5Eh9vKxnj7Rsh8SkE6B6Z36DVLP7ZOKNeQ/Y="}]`,
+	},
+	{
+		Name:      "invalid_alias",
+		Arguments: `[{"room":"net:example.com:8008~shs:qFtLJ6P5Eh9vKxnj7Rsh8SkE6B6Z36DVLP7ZOKNeQ/Y=","alias":"Not An Alias!"}]`,
+	},
+	{
+		Name:      "invalid_room_address",
+		Arguments: `[{"room":"example.com:8008","alias":"alias"}]`,
+	},
+}
+
+func mustNewScuttlegoAliasRequest(
+	t *testing.T,
+	newRequest func(messages.ScuttlegoAliasArguments) (*transportrpc.Request, error),
+	address string,
+	alias aliases.Alias,
+) *transportrpc.Request {
+	roomArgs, err := messages.NewScuttlegoRoomArguments(address)
+	require.NoError(t, err)
+
+	args, err := messages.NewScuttlegoAliasArguments(roomArgs, alias)
+	require.NoError(t, err)
+
+	req, err := newRequest(args)
+	require.NoError(t, err)
+
+	return req
+}
+
+type roomsAliasRegisterCommandHandlerMock struct {
+	url   aliases.AliasEndpointURL
+	err   error
+	calls []commands.RoomsAliasRegister
+}
+
+func newRoomsAliasRegisterCommandHandlerMock(url aliases.AliasEndpointURL, err error) *roomsAliasRegisterCommandHandlerMock {
+	return &roomsAliasRegisterCommandHandlerMock{url: url, err: err}
+}
+
+func (r *roomsAliasRegisterCommandHandlerMock) Handle(ctx context.Context, cmd commands.RoomsAliasRegister) (aliases.AliasEndpointURL, error) {
+	r.calls = append(r.calls, cmd)
+	return r.url, r.err
+}
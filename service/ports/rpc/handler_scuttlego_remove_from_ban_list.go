@@ -0,0 +1,50 @@
+package rpc
+
+import (
+	"context"
+
+	"github.com/boreq/errors"
+	"github.com/planetary-social/scuttlego/service/app/commands"
+	"github.com/planetary-social/scuttlego/service/domain/messages"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc/mux"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc/transport"
+)
+
+type RemoveFromBanListCommandHandler interface {
+	Handle(cmd commands.RemoveFromBanList) error
+}
+
+type HandlerScuttlegoRemoveFromBanList struct {
+	handler RemoveFromBanListCommandHandler
+}
+
+func NewHandlerScuttlegoRemoveFromBanList(handler RemoveFromBanListCommandHandler) *HandlerScuttlegoRemoveFromBanList {
+	return &HandlerScuttlegoRemoveFromBanList{handler: handler}
+}
+
+func (h HandlerScuttlegoRemoveFromBanList) Procedure() rpc.Procedure {
+	return messages.ScuttlegoRemoveFromBanListProcedure
+}
+
+func (h HandlerScuttlegoRemoveFromBanList) Handle(ctx context.Context, s mux.Stream, req *rpc.Request) error {
+	args, err := messages.NewScuttlegoBanListArgumentsFromBytes(req.Arguments())
+	if err != nil {
+		return errors.Wrap(err, "error parsing arguments")
+	}
+
+	cmd, err := commands.NewRemoveFromBanList(args.Hash())
+	if err != nil {
+		return errors.Wrap(err, "error creating the command")
+	}
+
+	if err := h.handler.Handle(cmd); err != nil {
+		return errors.Wrap(err, "error executing the command")
+	}
+
+	if err := s.WriteMessage([]byte("true"), transport.MessageBodyTypeJSON); err != nil {
+		return errors.Wrap(err, "error writing the message")
+	}
+
+	return nil
+}
@@ -0,0 +1,73 @@
+package rpc_test
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/boreq/errors"
+	"github.com/planetary-social/scuttlego/internal/fixtures"
+	"github.com/planetary-social/scuttlego/internal/mocks"
+	"github.com/planetary-social/scuttlego/service/app/commands"
+	"github.com/planetary-social/scuttlego/service/domain/messages"
+	"github.com/planetary-social/scuttlego/service/ports/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandlerScuttlegoRemoveFromBanList(t *testing.T) {
+	hash := fixtures.SomeBanListHash()
+
+	commandHandler := newRemoveFromBanListCommandHandlerMock(nil)
+	h := rpc.NewHandlerScuttlegoRemoveFromBanList(commandHandler)
+
+	require.Equal(t, messages.ScuttlegoRemoveFromBanListProcedure, h.Procedure())
+
+	s := mocks.NewMockCloserStream()
+	err := h.Handle(fixtures.TestContext(t), s, mustNewScuttlegoRequest(t, messages.ScuttlegoRemoveFromBanListProcedure, `["`+hex.EncodeToString(hash.Bytes())+`"]`))
+	require.NoError(t, err)
+
+	expectedCmd, err := commands.NewRemoveFromBanList(hash)
+	require.NoError(t, err)
+	require.Equal(t, []commands.RemoveFromBanList{expectedCmd}, commandHandler.calls)
+
+	requireWrittenJSON(t, s, `true`)
+}
+
+func TestHandlerScuttlegoRemoveFromBanList_InvalidArguments(t *testing.T) {
+	for _, testCase := range scuttlegoBanListInvalidArgumentsTestCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			commandHandler := newRemoveFromBanListCommandHandlerMock(nil)
+			h := rpc.NewHandlerScuttlegoRemoveFromBanList(commandHandler)
+
+			s := mocks.NewMockCloserStream()
+			err := h.Handle(fixtures.TestContext(t), s, mustNewScuttlegoRequest(t, messages.ScuttlegoRemoveFromBanListProcedure, testCase.Arguments))
+			require.Error(t, err)
+
+			require.Empty(t, commandHandler.calls)
+			require.Empty(t, s.WrittenMessages())
+		})
+	}
+}
+
+func TestHandlerScuttlegoRemoveFromBanList_CommandErrorIsReturned(t *testing.T) {
+	commandErr := errors.New("some error")
+	h := rpc.NewHandlerScuttlegoRemoveFromBanList(newRemoveFromBanListCommandHandlerMock(commandErr))
+
+	s := mocks.NewMockCloserStream()
+	err := h.Handle(fixtures.TestContext(t), s, mustNewScuttlegoRequest(t, messages.ScuttlegoRemoveFromBanListProcedure, `["`+hex.EncodeToString(fixtures.SomeBanListHash().Bytes())+`"]`))
+	require.ErrorIs(t, err, commandErr)
+	require.Empty(t, s.WrittenMessages())
+}
+
+type removeFromBanListCommandHandlerMock struct {
+	err   error
+	calls []commands.RemoveFromBanList
+}
+
+func newRemoveFromBanListCommandHandlerMock(err error) *removeFromBanListCommandHandlerMock {
+	return &removeFromBanListCommandHandlerMock{err: err}
+}
+
+func (r *removeFromBanListCommandHandlerMock) Handle(cmd commands.RemoveFromBanList) error {
+	r.calls = append(r.calls, cmd)
+	return r.err
+}
@@ -0,0 +1,56 @@
+package rpc
+
+import (
+	"context"
+
+	"github.com/boreq/errors"
+	"github.com/planetary-social/scuttlego/service/app/commands"
+	"github.com/planetary-social/scuttlego/service/domain/messages"
+	"github.com/planetary-social/scuttlego/service/domain/refs"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc/mux"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc/transport"
+)
+
+type RoomsAliasRevokeCommandHandler interface {
+	Handle(ctx context.Context, cmd commands.RoomsAliasRevoke) error
+}
+
+type HandlerScuttlegoRevokeAlias struct {
+	handler RoomsAliasRevokeCommandHandler
+}
+
+func NewHandlerScuttlegoRevokeAlias(handler RoomsAliasRevokeCommandHandler) *HandlerScuttlegoRevokeAlias {
+	return &HandlerScuttlegoRevokeAlias{handler: handler}
+}
+
+func (h HandlerScuttlegoRevokeAlias) Procedure() rpc.Procedure {
+	return messages.ScuttlegoRevokeAliasProcedure
+}
+
+func (h HandlerScuttlegoRevokeAlias) Handle(ctx context.Context, s mux.Stream, req *rpc.Request) error {
+	args, err := messages.NewScuttlegoAliasArgumentsFromBytes(req.Arguments())
+	if err != nil {
+		return errors.Wrap(err, "error parsing arguments")
+	}
+
+	room, err := refs.NewIdentityFromPublic(args.Room().Remote())
+	if err != nil {
+		return errors.Wrap(err, "error creating the room ref")
+	}
+
+	cmd, err := commands.NewRoomsAliasRevoke(room, args.Room().Address(), args.Alias())
+	if err != nil {
+		return errors.Wrap(err, "error creating the command")
+	}
+
+	if err := h.handler.Handle(ctx, cmd); err != nil {
+		return errors.Wrap(err, "error executing the command")
+	}
+
+	if err := s.WriteMessage([]byte("true"), transport.MessageBodyTypeJSON); err != nil {
+		return errors.Wrap(err, "error writing the message")
+	}
+
+	return nil
+}
@@ -0,0 +1,77 @@
+package rpc_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/boreq/errors"
+	"github.com/planetary-social/scuttlego/internal/fixtures"
+	"github.com/planetary-social/scuttlego/internal/mocks"
+	"github.com/planetary-social/scuttlego/service/app/commands"
+	"github.com/planetary-social/scuttlego/service/domain/messages"
+	"github.com/planetary-social/scuttlego/service/domain/network"
+	"github.com/planetary-social/scuttlego/service/ports/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandlerScuttlegoRevokeAlias(t *testing.T) {
+	room, address := someScuttlegoMultiserverAddress()
+	alias := fixtures.SomeAlias()
+
+	commandHandler := newRoomsAliasRevokeCommandHandlerMock(nil)
+	h := rpc.NewHandlerScuttlegoRevokeAlias(commandHandler)
+
+	require.Equal(t, messages.ScuttlegoRevokeAliasProcedure, h.Procedure())
+
+	s := mocks.NewMockCloserStream()
+	err := h.Handle(fixtures.TestContext(t), s, mustNewScuttlegoAliasRequest(t, messages.NewScuttlegoRevokeAlias, address, alias))
+	require.NoError(t, err)
+
+	expectedCmd, err := commands.NewRoomsAliasRevoke(room, network.NewAddress("example.com:8008"), alias)
+	require.NoError(t, err)
+	require.Equal(t, []commands.RoomsAliasRevoke{expectedCmd}, commandHandler.calls)
+
+	requireWrittenJSON(t, s, `true`)
+}
+
+func TestHandlerScuttlegoRevokeAlias_InvalidArguments(t *testing.T) {
+	for _, testCase := range scuttlegoAliasInvalidArgumentsTestCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			commandHandler := newRoomsAliasRevokeCommandHandlerMock(nil)
+			h := rpc.NewHandlerScuttlegoRevokeAlias(commandHandler)
+
+			s := mocks.NewMockCloserStream()
+			err := h.Handle(fixtures.TestContext(t), s, mustNewScuttlegoRequest(t, messages.ScuttlegoRevokeAliasProcedure, testCase.Arguments))
+			require.Error(t, err)
+
+			require.Empty(t, commandHandler.calls)
+			require.Empty(t, s.WrittenMessages())
+		})
+	}
+}
+
+func TestHandlerScuttlegoRevokeAlias_CommandErrorIsReturned(t *testing.T) {
+	_, address := someScuttlegoMultiserverAddress()
+
+	commandErr := errors.New("some error")
+	h := rpc.NewHandlerScuttlegoRevokeAlias(newRoomsAliasRevokeCommandHandlerMock(commandErr))
+
+	s := mocks.NewMockCloserStream()
+	err := h.Handle(fixtures.TestContext(t), s, mustNewScuttlegoAliasRequest(t, messages.NewScuttlegoRevokeAlias, address, fixtures.SomeAlias()))
+	require.ErrorIs(t, err, commandErr)
+	require.Empty(t, s.WrittenMessages())
+}
+
+type roomsAliasRevokeCommandHandlerMock struct {
+	err   error
+	calls []commands.RoomsAliasRevoke
+}
+
+func newRoomsAliasRevokeCommandHandlerMock(err error) *roomsAliasRevokeCommandHandlerMock {
+	return &roomsAliasRevokeCommandHandlerMock{err: err}
+}
+
+func (r *roomsAliasRevokeCommandHandlerMock) Handle(ctx context.Context, cmd commands.RoomsAliasRevoke) error {
+	r.calls = append(r.calls, cmd)
+	return r.err
+}
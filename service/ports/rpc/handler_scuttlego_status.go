@@ -0,0 +1,52 @@
+package rpc
+
+import (
+	"context"
+
+	"github.com/boreq/errors"
+	"github.com/planetary-social/scuttlego/service/app/queries"
+	"github.com/planetary-social/scuttlego/service/domain/identity"
+	"github.com/planetary-social/scuttlego/service/domain/messages"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc/mux"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc/transport"
+)
+
+type StatusQueryHandler interface {
+	Handle() (queries.StatusResult, error)
+}
+
+type HandlerScuttlegoStatus struct {
+	handler StatusQueryHandler
+}
+
+func NewHandlerScuttlegoStatus(handler StatusQueryHandler) *HandlerScuttlegoStatus {
+	return &HandlerScuttlegoStatus{handler: handler}
+}
+
+func (h HandlerScuttlegoStatus) Procedure() rpc.Procedure {
+	return messages.ScuttlegoStatusProcedure
+}
+
+func (h HandlerScuttlegoStatus) Handle(ctx context.Context, s mux.Stream, req *rpc.Request) error {
+	result, err := h.handler.Handle()
+	if err != nil {
+		return errors.Wrap(err, "error executing the query")
+	}
+
+	var peers []identity.Public
+	for _, peer := range result.Peers {
+		peers = append(peers, peer.Identity)
+	}
+
+	j, err := messages.NewScuttlegoStatusResponse(result.NumberOfMessages, result.NumberOfFeeds, peers).MarshalJSON()
+	if err != nil {
+		return errors.Wrap(err, "json marshalling failed")
+	}
+
+	if err := s.WriteMessage(j, transport.MessageBodyTypeJSON); err != nil {
+		return errors.Wrap(err, "error writing the message")
+	}
+
+	return nil
+}
@@ -0,0 +1,55 @@
+package rpc_test
+
+import (
+	"testing"
+
+	"github.com/planetary-social/scuttlego/internal/fixtures"
+	"github.com/planetary-social/scuttlego/internal/mocks"
+	"github.com/planetary-social/scuttlego/service/app/queries"
+	"github.com/planetary-social/scuttlego/service/domain/messages"
+	"github.com/planetary-social/scuttlego/service/domain/refs"
+	"github.com/planetary-social/scuttlego/service/ports/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandlerScuttlegoStatus(t *testing.T) {
+	peer := fixtures.SomePublicIdentity()
+
+	h := rpc.NewHandlerScuttlegoStatus(newStatusQueryHandlerMock(queries.StatusResult{
+		NumberOfMessages: 10,
+		NumberOfFeeds:    2,
+		Peers: []queries.Peer{
+			{
+				Identity: peer,
+			},
+		},
+	}))
+
+	require.Equal(t, messages.ScuttlegoStatusProcedure, h.Procedure())
+
+	req, err := messages.NewScuttlegoStatus()
+	require.NoError(t, err)
+
+	s := mocks.NewMockCloserStream()
+	err = h.Handle(fixtures.TestContext(t), s, req)
+	require.NoError(t, err)
+
+	written := s.WrittenMessages()
+	require.Len(t, written, 1)
+	require.JSONEq(t,
+		`{"numberOfMessages":10,"numberOfFeeds":2,"peers":[{"id":"`+refs.MustNewIdentityFromPublic(peer).String()+`"}]}`,
+		string(written[0].Body),
+	)
+}
+
+type statusQueryHandlerMock struct {
+	result queries.StatusResult
+}
+
+func newStatusQueryHandlerMock(result queries.StatusResult) *statusQueryHandlerMock {
+	return &statusQueryHandlerMock{result: result}
+}
+
+func (s *statusQueryHandlerMock) Handle() (queries.StatusResult, error) {
+	return s.result, nil
+}
@@ -0,0 +1,49 @@
+package rpc
+
+import (
+	"context"
+
+	"github.com/boreq/errors"
+	"github.com/planetary-social/scuttlego/service/domain/identity"
+	"github.com/planetary-social/scuttlego/service/domain/messages"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc/mux"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc/transport"
+)
+
+// HandlerStatus returns the status in the format used by ssb-server, see
+// HandlerScuttlegoStatus for a more detailed status.
+type HandlerStatus struct {
+	handler StatusQueryHandler
+}
+
+func NewHandlerStatus(handler StatusQueryHandler) *HandlerStatus {
+	return &HandlerStatus{handler: handler}
+}
+
+func (h HandlerStatus) Procedure() rpc.Procedure {
+	return messages.StatusProcedure
+}
+
+func (h HandlerStatus) Handle(ctx context.Context, s mux.Stream, req *rpc.Request) error {
+	result, err := h.handler.Handle()
+	if err != nil {
+		return errors.Wrap(err, "error executing the query")
+	}
+
+	var peers []identity.Public
+	for _, peer := range result.Peers {
+		peers = append(peers, peer.Identity)
+	}
+
+	j, err := messages.NewStatusResponse(result.NumberOfMessages, peers).MarshalJSON()
+	if err != nil {
+		return errors.Wrap(err, "json marshalling failed")
+	}
+
+	if err := s.WriteMessage(j, transport.MessageBodyTypeJSON); err != nil {
+		return errors.Wrap(err, "error writing the message")
+	}
+
+	return nil
+}
@@ -0,0 +1,70 @@
+package rpc_test
+
+import (
+	"testing"
+
+	"github.com/planetary-social/scuttlego/internal/fixtures"
+	"github.com/planetary-social/scuttlego/internal/mocks"
+	"github.com/planetary-social/scuttlego/service/app/queries"
+	"github.com/planetary-social/scuttlego/service/domain/messages"
+	"github.com/planetary-social/scuttlego/service/domain/refs"
+	"github.com/planetary-social/scuttlego/service/ports/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandlerStatus(t *testing.T) {
+	peer := fixtures.SomePublicIdentity()
+
+	testCases := []struct {
+		Name         string
+		Result       queries.StatusResult
+		ExpectedJSON string
+	}{
+		{
+			Name: "no_peers",
+			Result: queries.StatusResult{
+				NumberOfMessages: 0,
+				NumberOfFeeds:    0,
+			},
+			ExpectedJSON: `{
+				"progress": {"indexes": {"start": 0, "current": 0, "target": 0}},
+				"sync": {"since": 0, "plugins": {}, "sync": true},
+				"peers": []
+			}`,
+		},
+		{
+			Name: "peers",
+			Result: queries.StatusResult{
+				NumberOfMessages: 10,
+				NumberOfFeeds:    2,
+				Peers: []queries.Peer{
+					{
+						Identity: peer,
+					},
+				},
+			},
+			ExpectedJSON: `{
+				"progress": {"indexes": {"start": 10, "current": 10, "target": 10}},
+				"sync": {"since": 10, "plugins": {}, "sync": true},
+				"peers": [{"key": "` + refs.MustNewIdentityFromPublic(peer).String() + `", "state": "connected"}]
+			}`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			h := rpc.NewHandlerStatus(newStatusQueryHandlerMock(testCase.Result))
+
+			require.Equal(t, messages.StatusProcedure, h.Procedure())
+
+			req, err := messages.NewStatus()
+			require.NoError(t, err)
+
+			s := mocks.NewMockCloserStream()
+			err = h.Handle(fixtures.TestContext(t), s, req)
+			require.NoError(t, err)
+
+			requireWrittenJSON(t, s, testCase.ExpectedJSON)
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package rpc
+
+import (
+	"context"
+
+	"github.com/boreq/errors"
+	"github.com/planetary-social/scuttlego/service/app/commands"
+	"github.com/planetary-social/scuttlego/service/domain/messages"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc/mux"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc/transport"
+)
+
+type RoomServerAnnounceCommandHandler interface {
+	Handle(cmd commands.RoomServerAnnounce) error
+}
+
+type HandlerTunnelAnnounce struct {
+	handler RoomServerAnnounceCommandHandler
+}
+
+func NewHandlerTunnelAnnounce(handler RoomServerAnnounceCommandHandler) *HandlerTunnelAnnounce {
+	return &HandlerTunnelAnnounce{handler: handler}
+}
+
+func (h HandlerTunnelAnnounce) Procedure() rpc.Procedure {
+	return messages.TunnelAnnounceProcedure
+}
+
+func (h HandlerTunnelAnnounce) Handle(ctx context.Context, s mux.Stream, req *rpc.Request) error {
+	remote, ok := rpc.GetRemoteIdentityFromContext(ctx)
+	if !ok {
+		return errors.New("remote identity not found in context")
+	}
+
+	cmd, err := commands.NewRoomServerAnnounce(remote)
+	if err != nil {
+		return errors.Wrap(err, "error creating the command")
+	}
+
+	if err := h.handler.Handle(cmd); err != nil {
+		return errors.Wrap(err, "error executing the command")
+	}
+
+	if err := s.WriteMessage([]byte("true"), transport.MessageBodyTypeJSON); err != nil {
+		return errors.Wrap(err, "error writing the message")
+	}
+
+	return nil
+}
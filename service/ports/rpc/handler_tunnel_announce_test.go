@@ -0,0 +1,162 @@
+package rpc_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/planetary-social/scuttlego/internal/fixtures"
+	"github.com/planetary-social/scuttlego/internal/mocks"
+	"github.com/planetary-social/scuttlego/service/app/commands"
+	"github.com/planetary-social/scuttlego/service/domain/identity"
+	"github.com/planetary-social/scuttlego/service/domain/messages"
+	"github.com/planetary-social/scuttlego/service/domain/refs"
+	"github.com/planetary-social/scuttlego/service/domain/rooms/server"
+	"github.com/planetary-social/scuttlego/service/domain/transport"
+	transportrpc "github.com/planetary-social/scuttlego/service/domain/transport/rpc"
+	"github.com/planetary-social/scuttlego/service/ports/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+// tunnelAccessTestCases describe who can use a room depending on its privacy
+// mode.
+var tunnelAccessTestCases = []struct {
+	Name          string
+	Mode          server.PrivacyMode
+	Member        bool
+	ExpectedError error
+}{
+	{
+		Name:          "disabled",
+		Mode:          server.PrivacyMode{},
+		Member:        true,
+		ExpectedError: server.ErrRoomDisabled,
+	},
+	{
+		Name:          "open_member",
+		Mode:          server.PrivacyModeOpen,
+		Member:        true,
+		ExpectedError: nil,
+	},
+	{
+		Name:          "open_non_member",
+		Mode:          server.PrivacyModeOpen,
+		Member:        false,
+		ExpectedError: nil,
+	},
+	{
+		Name:          "community_member",
+		Mode:          server.PrivacyModeCommunity,
+		Member:        true,
+		ExpectedError: nil,
+	},
+	{
+		Name:          "community_non_member",
+		Mode:          server.PrivacyModeCommunity,
+		Member:        false,
+		ExpectedError: server.ErrNotAMember,
+	},
+	{
+		Name:          "restricted_member",
+		Mode:          server.PrivacyModeRestricted,
+		Member:        true,
+		ExpectedError: nil,
+	},
+	{
+		Name:          "restricted_non_member",
+		Mode:          server.PrivacyModeRestricted,
+		Member:        false,
+		ExpectedError: server.ErrNotAMember,
+	},
+}
+
+func TestHandlerTunnelAnnounce(t *testing.T) {
+	for _, testCase := range tunnelAccessTestCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			ts := newTunnelTest(t, testCase.Mode)
+			ctx := ts.RunPeer(testCase.Member)
+
+			h := rpc.NewHandlerTunnelAnnounce(commands.NewRoomServerAnnounceHandler(ts.Transaction, ts.Room))
+			require.Equal(t, messages.TunnelAnnounceProcedure, h.Procedure())
+
+			req, err := messages.NewTunnelAnnounce()
+			require.NoError(t, err)
+
+			s := mocks.NewMockCloserStream()
+
+			err = h.Handle(ctx, s, req)
+			if testCase.ExpectedError != nil {
+				require.ErrorIs(t, err, testCase.ExpectedError)
+				require.Empty(t, s.WrittenMessages())
+				return
+			}
+			require.NoError(t, err)
+
+			written := s.WrittenMessages()
+			require.Len(t, written, 1)
+			require.JSONEq(t, `true`, string(written[0].Body))
+
+			attendants, _, err := ts.Room.Attendants(ctx, true)
+			require.NoError(t, err)
+			require.Equal(t, []refs.Identity{refs.MustNewIdentityFromPublic(ts.Remote)}, attendants)
+		})
+	}
+}
+
+func TestHandlerTunnelAnnounce_RemoteIdentityMustBeInContext(t *testing.T) {
+	ts := newTunnelTest(t, server.PrivacyModeOpen)
+
+	h := rpc.NewHandlerTunnelAnnounce(commands.NewRoomServerAnnounceHandler(ts.Transaction, ts.Room))
+
+	req, err := messages.NewTunnelAnnounce()
+	require.NoError(t, err)
+
+	err = h.Handle(fixtures.TestContext(t), mocks.NewMockCloserStream(), req)
+	require.EqualError(t, err, "remote identity not found in context")
+}
+
+type tunnelTest struct {
+	t           *testing.T
+	Remote      identity.Public
+	Room        *server.Room
+	RoomMember  *mocks.RoomMemberRepositoryMock
+	Transaction *mocks.MockCommandsTransactionProvider
+}
+
+func newTunnelTest(t *testing.T, mode server.PrivacyMode) tunnelTest {
+	roomMember := mocks.NewRoomMemberRepositoryMock()
+	return tunnelTest{
+		t:           t,
+		Remote:      fixtures.SomePublicIdentity(),
+		Room:        server.NewRoom(fixtures.SomePublicIdentity(), server.Config{PrivacyMode: mode}, fixtures.TestLogger(t)),
+		RoomMember:  roomMember,
+		Transaction: mocks.NewMockCommandsTransactionProvider(commands.Adapters{RoomMember: roomMember}),
+	}
+}
+
+// RunPeer connects the remote to the room, optionally makes it a member and
+// returns a context carrying its identity.
+func (ts tunnelTest) RunPeer(member bool) context.Context {
+	ctx := fixtures.TestContext(ts.t)
+	peer := transport.MustNewPeer(ts.Remote, mocks.NewConnectionMock(ctx))
+
+	if member {
+		ts.RoomMember.Mock(refs.MustNewIdentityFromPublic(ts.Remote))
+	}
+
+	go func() {
+		_ = ts.Room.Run(ctx, peer)
+	}()
+
+	// A disabled room doesn't keep track of peers.
+	if _, err := ts.Room.Metadata(true); err == nil {
+		require.Eventually(ts.t, func() bool {
+			if err := ts.Room.Announce(peer.Identity(), true); err != nil {
+				return false
+			}
+			return ts.Room.Leave(peer.Identity()) == nil
+		}, 5*time.Second, 10*time.Millisecond)
+	}
+
+	return transportrpc.PutRemoteIdentityInContext(ctx, ts.Remote)
+}
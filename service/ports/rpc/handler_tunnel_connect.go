@@ -2,9 +2,11 @@ package rpc
 
 import (
 	"context"
+	"io"
 
 	"github.com/boreq/errors"
 	"github.com/planetary-social/scuttlego/service/app/commands"
+	"github.com/planetary-social/scuttlego/service/domain/identity"
 	"github.com/planetary-social/scuttlego/service/domain/messages"
 	"github.com/planetary-social/scuttlego/service/domain/rooms/tunnel"
 	"github.com/planetary-social/scuttlego/service/domain/transport/rpc"
@@ -15,12 +17,29 @@ type AcceptTunnelConnectHandler interface {
 	Handle(ctx context.Context, cmd commands.AcceptTunnelConnect) error
 }
 
+type RoomServerConnectCommandHandler interface {
+	Handle(ctx context.Context, cmd commands.RoomServerConnect) error
+}
+
+// HandlerTunnelConnect accepts tunnels opened by rooms if origin is present in
+// the arguments. Otherwise the remote wants this node to act as a room and
+// relay the connection to the target.
 type HandlerTunnelConnect struct {
-	handler AcceptTunnelConnectHandler
+	local        identity.Public
+	handler      AcceptTunnelConnectHandler
+	relayHandler RoomServerConnectCommandHandler
 }
 
-func NewHandlerTunnelConnect(handler AcceptTunnelConnectHandler) *HandlerTunnelConnect {
-	return &HandlerTunnelConnect{handler: handler}
+func NewHandlerTunnelConnect(
+	local identity.Public,
+	handler AcceptTunnelConnectHandler,
+	relayHandler RoomServerConnectCommandHandler,
+) *HandlerTunnelConnect {
+	return &HandlerTunnelConnect{
+		local:        local,
+		handler:      handler,
+		relayHandler: relayHandler,
+	}
 }
 
 func (h HandlerTunnelConnect) Procedure() rpc.Procedure {
@@ -31,12 +50,21 @@ func (h HandlerTunnelConnect) Handle(ctx context.Context, s mux.Stream, req *rpc
 	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
 
-	args, err := messages.NewTunnelConnectToTargetArgumentsFromBytes(req.Arguments())
+	rwc := tunnel.NewStreamReadWriteCloserAdapter(s, cancel)
+
+	toTarget, err := messages.IsTunnelConnectToTarget(req.Arguments())
 	if err != nil {
 		return errors.Wrap(err, "error parsing arguments")
 	}
 
-	rwc := tunnel.NewStreamReadWriteCloserAdapter(s, cancel)
+	if !toTarget {
+		return h.relay(ctx, rwc, req)
+	}
+
+	args, err := messages.NewTunnelConnectToTargetArgumentsFromBytes(req.Arguments())
+	if err != nil {
+		return errors.Wrap(err, "error parsing arguments")
+	}
 
 	cmd, err := commands.NewAcceptTunnelConnect(args.Origin(), args.Target(), args.Portal(), rwc)
 	if err != nil {
@@ -51,3 +79,30 @@ func (h HandlerTunnelConnect) Handle(ctx context.Context, s mux.Stream, req *rpc
 	<-ctx.Done()
 	return ctx.Err()
 }
+
+func (h HandlerTunnelConnect) relay(ctx context.Context, rwc io.ReadWriteCloser, req *rpc.Request) error {
+	args, err := messages.NewTunnelConnectToPortalArgumentsFromBytes(req.Arguments())
+	if err != nil {
+		return errors.Wrap(err, "error parsing arguments")
+	}
+
+	if !args.Portal().Identity().Equal(h.local) {
+		return errors.New("portal isn't the local identity")
+	}
+
+	origin, ok := rpc.GetRemoteIdentityFromContext(ctx)
+	if !ok {
+		return errors.New("remote identity not found in context")
+	}
+
+	cmd, err := commands.NewRoomServerConnect(origin, args.Target(), rwc)
+	if err != nil {
+		return errors.Wrap(err, "error creating the command")
+	}
+
+	if err := h.relayHandler.Handle(ctx, cmd); err != nil {
+		return errors.Wrap(err, "error executing the command")
+	}
+
+	return nil
+}
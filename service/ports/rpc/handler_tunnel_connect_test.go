@@ -2,6 +2,7 @@ package rpc_test
 
 import (
 	"context"
+	"fmt"
 	"sync"
 	"testing"
 	"time"
@@ -10,13 +11,15 @@ import (
 	"github.com/planetary-social/scuttlego/internal/mocks"
 	"github.com/planetary-social/scuttlego/service/app/commands"
 	"github.com/planetary-social/scuttlego/service/domain/messages"
+	"github.com/planetary-social/scuttlego/service/domain/refs"
+	transportrpc "github.com/planetary-social/scuttlego/service/domain/transport/rpc"
 	"github.com/planetary-social/scuttlego/service/ports/rpc"
 	"github.com/stretchr/testify/require"
 )
 
 func TestHandlerTunnelConnect_CallsCommandHandler(t *testing.T) {
 	commandHandler := newAcceptTunnelConnectCommandHandlerMock()
-	handler := rpc.NewHandlerTunnelConnect(commandHandler)
+	handler := rpc.NewHandlerTunnelConnect(fixtures.SomePublicIdentity(), commandHandler, newRoomServerConnectCommandHandlerMock())
 
 	require.Equal(t, messages.TunnelConnectProcedure, handler.Procedure())
 
@@ -79,6 +82,80 @@ func TestHandlerTunnelConnect_CallsCommandHandler(t *testing.T) {
 	}
 }
 
+func TestHandlerTunnelConnect_RelaysConnectionsIfOriginIsMissing(t *testing.T) {
+	local := fixtures.SomePublicIdentity()
+	relayHandler := newRoomServerConnectCommandHandlerMock()
+	handler := rpc.NewHandlerTunnelConnect(local, newAcceptTunnelConnectCommandHandlerMock(), relayHandler)
+
+	origin := fixtures.SomePublicIdentity()
+	ctx := transportrpc.PutRemoteIdentityInContext(fixtures.TestContext(t), origin)
+	s := mocks.NewMockCloserStream()
+
+	portal := refs.MustNewIdentityFromPublic(local)
+	target := fixtures.SomeRefIdentity()
+
+	args, err := messages.NewTunnelConnectToPortalArguments(portal, target)
+	require.NoError(t, err)
+
+	req, err := messages.NewTunnelConnectToPortal(args)
+	require.NoError(t, err)
+
+	err = handler.Handle(ctx, s, req)
+	require.NoError(t, err)
+
+	calls := relayHandler.HandleCalls()
+	require.Len(t, calls, 1)
+	require.Equal(t, origin, calls[0].Origin())
+	require.Equal(t, target, calls[0].Target())
+	require.NotNil(t, calls[0].Stream())
+}
+
+func TestHandlerTunnelConnect_ReturnsAnErrorIfPortalIsNotTheLocalIdentity(t *testing.T) {
+	relayHandler := newRoomServerConnectCommandHandlerMock()
+	handler := rpc.NewHandlerTunnelConnect(fixtures.SomePublicIdentity(), newAcceptTunnelConnectCommandHandlerMock(), relayHandler)
+
+	ctx := transportrpc.PutRemoteIdentityInContext(fixtures.TestContext(t), fixtures.SomePublicIdentity())
+	s := mocks.NewMockCloserStream()
+
+	args, err := messages.NewTunnelConnectToPortalArguments(fixtures.SomeRefIdentity(), fixtures.SomeRefIdentity())
+	require.NoError(t, err)
+
+	req, err := messages.NewTunnelConnectToPortal(args)
+	require.NoError(t, err)
+
+	err = handler.Handle(ctx, s, req)
+	require.EqualError(t, err, "portal isn't the local identity")
+	require.Empty(t, relayHandler.HandleCalls())
+}
+
+func TestHandlerTunnelConnect_ReturnsAnErrorIfArgumentsWithOriginAreInvalid(t *testing.T) {
+	commandHandler := newAcceptTunnelConnectCommandHandlerMock()
+	relayHandler := newRoomServerConnectCommandHandlerMock()
+	local := fixtures.SomePublicIdentity()
+	handler := rpc.NewHandlerTunnelConnect(local, commandHandler, relayHandler)
+
+	ctx := transportrpc.PutRemoteIdentityInContext(fixtures.TestContext(t), fixtures.SomePublicIdentity())
+	s := mocks.NewMockCloserStream()
+
+	arguments := fmt.Sprintf(
+		`[{"portal": "%s", "target": "%s", "origin": "invalid"}]`,
+		refs.MustNewIdentityFromPublic(local).String(),
+		fixtures.SomeRefIdentity().String(),
+	)
+
+	req, err := transportrpc.NewRequest(
+		messages.TunnelConnectProcedure.Name(),
+		messages.TunnelConnectProcedure.Typ(),
+		[]byte(arguments),
+	)
+	require.NoError(t, err)
+
+	err = handler.Handle(ctx, s, req)
+	require.ErrorContains(t, err, "error parsing arguments")
+	require.Empty(t, commandHandler.HandleCalls())
+	require.Empty(t, relayHandler.HandleCalls())
+}
+
 type acceptTunnelConnectCommandHandlerMock struct {
 	handleCalls []commands.AcceptTunnelConnect
 	lock        sync.Mutex
@@ -102,3 +179,27 @@ func (a *acceptTunnelConnectCommandHandlerMock) HandleCalls() []commands.AcceptT
 	copy(tmp, a.handleCalls)
 	return tmp
 }
+
+type roomServerConnectCommandHandlerMock struct {
+	handleCalls []commands.RoomServerConnect
+	lock        sync.Mutex
+}
+
+func newRoomServerConnectCommandHandlerMock() *roomServerConnectCommandHandlerMock {
+	return &roomServerConnectCommandHandlerMock{}
+}
+
+func (r *roomServerConnectCommandHandlerMock) Handle(ctx context.Context, cmd commands.RoomServerConnect) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.handleCalls = append(r.handleCalls, cmd)
+	return nil
+}
+
+func (r *roomServerConnectCommandHandlerMock) HandleCalls() []commands.RoomServerConnect {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	tmp := make([]commands.RoomServerConnect, len(r.handleCalls))
+	copy(tmp, r.handleCalls)
+	return tmp
+}
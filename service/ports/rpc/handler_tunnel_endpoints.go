@@ -0,0 +1,68 @@
+package rpc
+
+import (
+	"context"
+
+	"github.com/boreq/errors"
+	"github.com/planetary-social/scuttlego/service/app/queries"
+	"github.com/planetary-social/scuttlego/service/domain/messages"
+	"github.com/planetary-social/scuttlego/service/domain/refs"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc/mux"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc/transport"
+)
+
+type RoomServerEndpointsQueryHandler interface {
+	Handle(ctx context.Context, query queries.RoomServerEndpoints) (<-chan []refs.Identity, error)
+}
+
+// HandlerTunnelEndpoints sends the list of attendants every time it changes.
+type HandlerTunnelEndpoints struct {
+	handler RoomServerEndpointsQueryHandler
+}
+
+func NewHandlerTunnelEndpoints(handler RoomServerEndpointsQueryHandler) *HandlerTunnelEndpoints {
+	return &HandlerTunnelEndpoints{handler: handler}
+}
+
+func (h HandlerTunnelEndpoints) Procedure() rpc.Procedure {
+	return messages.TunnelEndpointsProcedure
+}
+
+func (h HandlerTunnelEndpoints) Handle(ctx context.Context, s mux.Stream, req *rpc.Request) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	remote, ok := rpc.GetRemoteIdentityFromContext(ctx)
+	if !ok {
+		return errors.New("remote identity not found in context")
+	}
+
+	query, err := queries.NewRoomServerEndpoints(remote)
+	if err != nil {
+		return errors.Wrap(err, "error creating the query")
+	}
+
+	ch, err := h.handler.Handle(ctx, query)
+	if err != nil {
+		return errors.Wrap(err, "error executing the query")
+	}
+
+	for ids := range ch {
+		response, err := messages.NewTunnelEndpointsResponse(ids)
+		if err != nil {
+			return errors.Wrap(err, "error creating the response")
+		}
+
+		j, err := response.MarshalJSON()
+		if err != nil {
+			return errors.Wrap(err, "json marshalling failed")
+		}
+
+		if err := s.WriteMessage(j, transport.MessageBodyTypeJSON); err != nil {
+			return errors.Wrap(err, "error writing the message")
+		}
+	}
+
+	return nil
+}
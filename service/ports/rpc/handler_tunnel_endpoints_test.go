@@ -0,0 +1,61 @@
+package rpc_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/planetary-social/scuttlego/internal/fixtures"
+	"github.com/planetary-social/scuttlego/internal/mocks"
+	"github.com/planetary-social/scuttlego/service/app/queries"
+	"github.com/planetary-social/scuttlego/service/domain/messages"
+	"github.com/planetary-social/scuttlego/service/domain/refs"
+	transportrpc "github.com/planetary-social/scuttlego/service/domain/transport/rpc"
+	"github.com/planetary-social/scuttlego/service/ports/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandlerTunnelEndpoints_SendsEveryListOfEndpoints(t *testing.T) {
+	ids := []refs.Identity{fixtures.SomeRefIdentity()}
+
+	queryHandler := newRoomServerEndpointsQueryHandlerMock([][]refs.Identity{nil, ids})
+	h := rpc.NewHandlerTunnelEndpoints(queryHandler)
+
+	require.Equal(t, messages.TunnelEndpointsProcedure, h.Procedure())
+
+	remote := fixtures.SomePublicIdentity()
+	ctx := transportrpc.PutRemoteIdentityInContext(fixtures.TestContext(t), remote)
+	s := mocks.NewMockCloserStream()
+
+	req, err := messages.NewTunnelEndpoints()
+	require.NoError(t, err)
+
+	err = h.Handle(ctx, s, req)
+	require.NoError(t, err)
+
+	require.Equal(t, []queries.RoomServerEndpoints{queries.MustNewRoomServerEndpoints(remote)}, queryHandler.calls)
+
+	written := s.WrittenMessages()
+	require.Len(t, written, 2)
+	require.JSONEq(t, `[]`, string(written[0].Body))
+	require.JSONEq(t, `["`+ids[0].String()+`"]`, string(written[1].Body))
+}
+
+type roomServerEndpointsQueryHandlerMock struct {
+	values [][]refs.Identity
+	calls  []queries.RoomServerEndpoints
+}
+
+func newRoomServerEndpointsQueryHandlerMock(values [][]refs.Identity) *roomServerEndpointsQueryHandlerMock {
+	return &roomServerEndpointsQueryHandlerMock{values: values}
+}
+
+func (r *roomServerEndpointsQueryHandlerMock) Handle(ctx context.Context, query queries.RoomServerEndpoints) (<-chan []refs.Identity, error) {
+	r.calls = append(r.calls, query)
+
+	ch := make(chan []refs.Identity, len(r.values))
+	for _, v := range r.values {
+		ch <- v
+	}
+	close(ch)
+	return ch, nil
+}
@@ -0,0 +1,50 @@
+package rpc
+
+import (
+	"context"
+
+	"github.com/boreq/errors"
+	"github.com/planetary-social/scuttlego/service/app/commands"
+	"github.com/planetary-social/scuttlego/service/domain/messages"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc/mux"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc/transport"
+)
+
+type RoomServerLeaveCommandHandler interface {
+	Handle(cmd commands.RoomServerLeave) error
+}
+
+type HandlerTunnelLeave struct {
+	handler RoomServerLeaveCommandHandler
+}
+
+func NewHandlerTunnelLeave(handler RoomServerLeaveCommandHandler) *HandlerTunnelLeave {
+	return &HandlerTunnelLeave{handler: handler}
+}
+
+func (h HandlerTunnelLeave) Procedure() rpc.Procedure {
+	return messages.TunnelLeaveProcedure
+}
+
+func (h HandlerTunnelLeave) Handle(ctx context.Context, s mux.Stream, req *rpc.Request) error {
+	remote, ok := rpc.GetRemoteIdentityFromContext(ctx)
+	if !ok {
+		return errors.New("remote identity not found in context")
+	}
+
+	cmd, err := commands.NewRoomServerLeave(remote)
+	if err != nil {
+		return errors.Wrap(err, "error creating the command")
+	}
+
+	if err := h.handler.Handle(cmd); err != nil {
+		return errors.Wrap(err, "error executing the command")
+	}
+
+	if err := s.WriteMessage([]byte("true"), transport.MessageBodyTypeJSON); err != nil {
+		return errors.Wrap(err, "error writing the message")
+	}
+
+	return nil
+}
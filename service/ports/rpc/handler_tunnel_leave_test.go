@@ -0,0 +1,51 @@
+package rpc_test
+
+import (
+	"testing"
+
+	"github.com/planetary-social/scuttlego/internal/mocks"
+	"github.com/planetary-social/scuttlego/service/app/commands"
+	"github.com/planetary-social/scuttlego/service/domain/messages"
+	"github.com/planetary-social/scuttlego/service/domain/rooms/server"
+	"github.com/planetary-social/scuttlego/service/ports/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandlerTunnelLeave(t *testing.T) {
+	for _, testCase := range tunnelAccessTestCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			ts := newTunnelTest(t, testCase.Mode)
+			ctx := ts.RunPeer(testCase.Member)
+
+			if testCase.ExpectedError == nil {
+				err := ts.Room.Announce(ts.Remote, testCase.Member)
+				require.NoError(t, err)
+			}
+
+			h := rpc.NewHandlerTunnelLeave(commands.NewRoomServerLeaveHandler(ts.Room))
+			require.Equal(t, messages.TunnelLeaveProcedure, h.Procedure())
+
+			req, err := messages.NewTunnelLeave()
+			require.NoError(t, err)
+
+			s := mocks.NewMockCloserStream()
+
+			// Leaving is always possible unless the room is disabled.
+			err = h.Handle(ctx, s, req)
+			if testCase.Mode.IsZero() {
+				require.ErrorIs(t, err, server.ErrRoomDisabled)
+				require.Empty(t, s.WrittenMessages())
+				return
+			}
+			require.NoError(t, err)
+
+			written := s.WrittenMessages()
+			require.Len(t, written, 1)
+			require.JSONEq(t, `true`, string(written[0].Body))
+
+			attendants, _, err := ts.Room.Attendants(ctx, true)
+			require.NoError(t, err)
+			require.Empty(t, attendants)
+		})
+	}
+}
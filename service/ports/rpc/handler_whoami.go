@@ -0,0 +1,50 @@
+package rpc
+
+import (
+	"context"
+
+	"github.com/boreq/errors"
+	"github.com/planetary-social/scuttlego/service/domain/identity"
+	"github.com/planetary-social/scuttlego/service/domain/messages"
+	"github.com/planetary-social/scuttlego/service/domain/refs"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc/mux"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc/transport"
+)
+
+type HandlerWhoami struct {
+	local identity.Public
+}
+
+func NewHandlerWhoami(local identity.Public) *HandlerWhoami {
+	return &HandlerWhoami{
+		local: local,
+	}
+}
+
+func (h HandlerWhoami) Procedure() rpc.Procedure {
+	return messages.WhoamiProcedure
+}
+
+func (h HandlerWhoami) Handle(ctx context.Context, s mux.Stream, req *rpc.Request) error {
+	ref, err := refs.NewIdentityFromPublic(h.local)
+	if err != nil {
+		return errors.Wrap(err, "error creating the ref")
+	}
+
+	response, err := messages.NewWhoamiResponse(ref)
+	if err != nil {
+		return errors.Wrap(err, "error creating the response")
+	}
+
+	j, err := response.MarshalJSON()
+	if err != nil {
+		return errors.Wrap(err, "error marshaling the response")
+	}
+
+	if err := s.WriteMessage(j, transport.MessageBodyTypeJSON); err != nil {
+		return errors.Wrap(err, "error writing the message")
+	}
+
+	return nil
+}
@@ -0,0 +1,37 @@
+package rpc_test
+
+import (
+	"testing"
+
+	"github.com/planetary-social/scuttlego/internal/fixtures"
+	"github.com/planetary-social/scuttlego/internal/mocks"
+	"github.com/planetary-social/scuttlego/service/domain/messages"
+	"github.com/planetary-social/scuttlego/service/domain/refs"
+	"github.com/planetary-social/scuttlego/service/domain/transport/rpc/transport"
+	"github.com/planetary-social/scuttlego/service/ports/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandlerWhoami_ReturnsLocalIdentity(t *testing.T) {
+	local := fixtures.SomePublicIdentity()
+
+	handler := rpc.NewHandlerWhoami(local)
+	require.Equal(t, messages.WhoamiProcedure, handler.Procedure())
+
+	ctx := fixtures.TestContext(t)
+	s := mocks.NewMockCloserStream()
+
+	req, err := messages.NewWhoami()
+	require.NoError(t, err)
+
+	err = handler.Handle(ctx, s, req)
+	require.NoError(t, err)
+
+	writtenMessages := s.WrittenMessages()
+	require.Len(t, writtenMessages, 1)
+	require.Equal(t, transport.MessageBodyTypeJSON, writtenMessages[0].BodyType)
+
+	response, err := messages.NewWhoamiResponseFromBytes(writtenMessages[0].Body)
+	require.NoError(t, err)
+	require.Equal(t, refs.MustNewIdentityFromPublic(local), response.Id())
+}
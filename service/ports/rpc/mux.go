@@ -2,9 +2,32 @@
 package rpc
 
 import (
+	"github.com/boreq/errors"
+	"github.com/planetary-social/scuttlego/logging"
 	"github.com/planetary-social/scuttlego/service/domain/transport/rpc/mux"
 )
 
+// NewMux creates a mux which serves the provided handlers and the manifest
+// describing them.
+func NewMux(
+	logger logging.Logger,
+	metrics mux.Metrics,
+	handlers []mux.Handler,
+	synchronousHandlers []mux.SynchronousHandler,
+	privilegedHandlers mux.PrivilegedHandlers,
+) (*mux.Mux, error) {
+	manifest, err := NewHandlerManifest(handlers, synchronousHandlers, privilegedHandlers)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating the manifest handler")
+	}
+
+	handlersWithManifest := make([]mux.Handler, 0, len(handlers)+1)
+	handlersWithManifest = append(handlersWithManifest, handlers...)
+	handlersWithManifest = append(handlersWithManifest, manifest)
+
+	return mux.NewMux(logger, metrics, handlersWithManifest, synchronousHandlers, privilegedHandlers)
+}
+
 // NewMuxHandlers is a convenience function used to create a list of all
 // handlers implemented by this program.
 func NewMuxHandlers(
@@ -12,12 +35,48 @@ func NewMuxHandlers(
 	blobsCreateWants *HandlerBlobsCreateWants,
 	ebtReplicate *HandlerEbtReplicate,
 	tunnelConnect *HandlerTunnelConnect,
+	gossipPing *HandlerGossipPing,
+	whoami *HandlerWhoami,
+	inviteUse *HandlerInviteUse,
+	roomMetadata *HandlerRoomMetadata,
+	roomAttendants *HandlerRoomAttendants,
+	tunnelAnnounce *HandlerTunnelAnnounce,
+	tunnelLeave *HandlerTunnelLeave,
+	tunnelEndpoints *HandlerTunnelEndpoints,
+	roomRegisterAlias *HandlerRoomRegisterAlias,
+	roomRevokeAlias *HandlerRoomRevokeAlias,
+	roomListAliases *HandlerRoomListAliases,
+	httpAuthRequestSolution *HandlerHttpAuthRequestSolution,
+	blobsHas *HandlerBlobsHas,
+	blobsSize *HandlerBlobsSize,
+	blobsChanges *HandlerBlobsChanges,
+	oooGet *HandlerOooGet,
+	replicateUpto *HandlerReplicateUpto,
+	latestSequence *HandlerLatestSequence,
 ) []mux.Handler {
 	return []mux.Handler{
 		blobsGet,
 		blobsCreateWants,
 		ebtReplicate,
 		tunnelConnect,
+		gossipPing,
+		whoami,
+		inviteUse,
+		roomMetadata,
+		roomAttendants,
+		tunnelAnnounce,
+		tunnelLeave,
+		tunnelEndpoints,
+		roomRegisterAlias,
+		roomRevokeAlias,
+		roomListAliases,
+		httpAuthRequestSolution,
+		blobsHas,
+		blobsSize,
+		blobsChanges,
+		oooGet,
+		replicateUpto,
+		latestSequence,
 	}
 }
 
@@ -30,3 +89,58 @@ func NewMuxClosingHandlers(
 		createHistoryStream,
 	}
 }
+
+// NewMuxPrivilegedHandlers is a convenience function used to create a list of
+// all handlers implemented by this program which can only be called by local
+// clients.
+func NewMuxPrivilegedHandlers(
+	friendsHops *HandlerFriendsHops,
+	friendsIsFollowing *HandlerFriendsIsFollowing,
+	friendsIsBlocking *HandlerFriendsIsBlocking,
+	friendsStream *HandlerFriendsStream,
+	scuttlegoPublish *HandlerScuttlegoPublish,
+	scuttlegoFollow *HandlerScuttlegoFollow,
+	scuttlegoStatus *HandlerScuttlegoStatus,
+	scuttlegoGetMessage *HandlerScuttlegoGetMessage,
+	scuttlegoReceiveLog *HandlerScuttlegoReceiveLog,
+	scuttlegoAddBlob *HandlerScuttlegoAddBlob,
+	scuttlegoAddToBanList *HandlerScuttlegoAddToBanList,
+	scuttlegoRemoveFromBanList *HandlerScuttlegoRemoveFromBanList,
+	scuttlegoRedeemInvite *HandlerScuttlegoRedeemInvite,
+	scuttlegoRegisterAlias *HandlerScuttlegoRegisterAlias,
+	scuttlegoRevokeAlias *HandlerScuttlegoRevokeAlias,
+	scuttlegoListAliases *HandlerScuttlegoListAliases,
+	scuttlegoConnect *HandlerScuttlegoConnect,
+	publish *HandlerPublish,
+	createLogStream *HandlerCreateLogStream,
+	createUserStream *HandlerCreateUserStream,
+	get *HandlerGet,
+	blobsAdd *HandlerBlobsAdd,
+	status *HandlerStatus,
+) mux.PrivilegedHandlers {
+	return mux.PrivilegedHandlers{
+		friendsHops,
+		friendsIsFollowing,
+		friendsIsBlocking,
+		friendsStream,
+		scuttlegoPublish,
+		scuttlegoFollow,
+		scuttlegoStatus,
+		scuttlegoGetMessage,
+		scuttlegoReceiveLog,
+		scuttlegoAddBlob,
+		scuttlegoAddToBanList,
+		scuttlegoRemoveFromBanList,
+		scuttlegoRedeemInvite,
+		scuttlegoRegisterAlias,
+		scuttlegoRevokeAlias,
+		scuttlegoListAliases,
+		scuttlegoConnect,
+		publish,
+		createLogStream,
+		createUserStream,
+		get,
+		blobsAdd,
+		status,
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/planetary-social/scuttlego/service/app/commands"
 	"github.com/planetary-social/scuttlego/service/app/queries"
 	"github.com/planetary-social/scuttlego/service/domain/network/local"
+	httpport "github.com/planetary-social/scuttlego/service/ports/http"
 	networkport "github.com/planetary-social/scuttlego/service/ports/network"
 	pubsubport "github.com/planetary-social/scuttlego/service/ports/pubsub"
 )
@@ -19,6 +20,10 @@ type Service struct {
 	App app.Application
 
 	listener                     *networkport.Listener
+	webSocketListener            *networkport.WebSocketListener
+	unixListener                 *networkport.UnixListener
+	httpServer                   *httpport.Server
+	metricsServer                *httpport.MetricsServer
 	discoverer                   *networkport.Discoverer
 	connectionEstablisher        *networkport.ConnectionEstablisher
 	requestSubscriber            *pubsubport.RequestSubscriber
@@ -35,6 +40,10 @@ type Service struct {
 func NewService(
 	app app.Application,
 	listener *networkport.Listener,
+	webSocketListener *networkport.WebSocketListener,
+	unixListener *networkport.UnixListener,
+	httpServer *httpport.Server,
+	metricsServer *httpport.MetricsServer,
 	discoverer *networkport.Discoverer,
 	connectionEstablisher *networkport.ConnectionEstablisher,
 	requestSubscriber *pubsubport.RequestSubscriber,
@@ -51,6 +60,10 @@ func NewService(
 		App: app,
 
 		listener:                     listener,
+		webSocketListener:            webSocketListener,
+		unixListener:                 unixListener,
+		httpServer:                   httpServer,
+		metricsServer:                metricsServer,
 		discoverer:                   discoverer,
 		connectionEstablisher:        connectionEstablisher,
 		requestSubscriber:            requestSubscriber,
@@ -77,6 +90,34 @@ func (s Service) Run(ctx context.Context) error {
 		errCh <- s.listener.ListenAndServe(ctx)
 	}()
 
+	if s.webSocketListener != nil {
+		runners++
+		go func() {
+			errCh <- s.webSocketListener.ListenAndServe(ctx)
+		}()
+	}
+
+	if s.unixListener != nil {
+		runners++
+		go func() {
+			errCh <- s.unixListener.ListenAndServe(ctx)
+		}()
+	}
+
+	if s.httpServer != nil {
+		runners++
+		go func() {
+			errCh <- s.httpServer.ListenAndServe(ctx)
+		}()
+	}
+
+	if s.metricsServer != nil {
+		runners++
+		go func() {
+			errCh <- s.metricsServer.ListenAndServe(ctx)
+		}()
+	}
+
 	runners++
 	go func() {
 		errCh <- s.requestSubscriber.Run(ctx)
@@ -92,10 +133,12 @@ func (s Service) Run(ctx context.Context) error {
 		errCh <- s.newPeerSubscriber.Run(ctx)
 	}()
 
-	runners++
-	go func() {
-		errCh <- s.advertiser.Run(ctx)
-	}()
+	if s.advertiser != nil {
+		runners++
+		go func() {
+			errCh <- s.advertiser.Run(ctx)
+		}()
+	}
 
 	runners++
 	go func() {
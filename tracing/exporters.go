@@ -0,0 +1,132 @@
+package tracing
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/boreq/errors"
+)
+
+const (
+	jsonFilePermissions = 0600
+
+	maxJSONLineLength = 1 * 1024 * 1024
+)
+
+type DevNullExporter struct {
+}
+
+func NewDevNullExporter() DevNullExporter {
+	return DevNullExporter{}
+}
+
+func (d DevNullExporter) Export(span SpanData) error {
+	return nil
+}
+
+// InMemoryExporter stores exported spans so that they can be inspected e.g. in
+// tests.
+type InMemoryExporter struct {
+	spans []SpanData
+	lock  sync.Mutex
+}
+
+func NewInMemoryExporter() *InMemoryExporter {
+	return &InMemoryExporter{}
+}
+
+func (e *InMemoryExporter) Export(span SpanData) error {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	e.spans = append(e.spans, span)
+	return nil
+}
+
+// Spans returns exported spans in the order in which they ended.
+func (e *InMemoryExporter) Spans() []SpanData {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	tmp := make([]SpanData, len(e.spans))
+	copy(tmp, e.spans)
+	return tmp
+}
+
+// JSONExporter writes each span as a JSON object on a separate line. Spans
+// written by it can be read using ReadSpans.
+type JSONExporter struct {
+	w    io.Writer
+	lock sync.Mutex
+}
+
+func NewJSONExporter(w io.Writer) *JSONExporter {
+	return &JSONExporter{w: w}
+}
+
+// NewJSONFileExporter creates a JSONExporter which appends spans to the file
+// with the provided name. The file is created if it doesn't exist. Close
+// should be called to close the file.
+func NewJSONFileExporter(filename string) (*JSONExporter, error) {
+	f, err := os.OpenFile(filename, os.O_WRONLY|os.O_APPEND|os.O_CREATE, jsonFilePermissions)
+	if err != nil {
+		return nil, errors.Wrap(err, "error opening the file")
+	}
+	return NewJSONExporter(f), nil
+}
+
+func (e *JSONExporter) Export(span SpanData) error {
+	b, err := json.Marshal(span)
+	if err != nil {
+		return errors.Wrap(err, "error marshaling the span")
+	}
+
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	if _, err := e.w.Write(append(b, '\n')); err != nil {
+		return errors.Wrap(err, "error writing the span")
+	}
+
+	return nil
+}
+
+// Close closes the underlying writer if it is an io.Closer.
+func (e *JSONExporter) Close() error {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	if closer, ok := e.w.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
+
+// ReadSpans reads spans written by JSONExporter.
+func ReadSpans(r io.Reader) ([]SpanData, error) {
+	var spans []SpanData
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, maxJSONLineLength)
+
+	for scanner.Scan() {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+
+		var span SpanData
+		if err := json.Unmarshal(scanner.Bytes(), &span); err != nil {
+			return nil, errors.Wrapf(err, "error unmarshaling the line '%s'", scanner.Text())
+		}
+
+		spans = append(spans, span)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Wrap(err, "scanner error")
+	}
+
+	return spans, nil
+}
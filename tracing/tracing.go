@@ -0,0 +1,180 @@
+// Package tracing records spans describing how long certain operations took
+// and how they relate to each other. Spans are propagated using contexts in the
+// same way as the logging context and pick up labels stored in it such as
+// connection and stream ids. Finished spans are passed to an exporter.
+package tracing
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/planetary-social/scuttlego/logging"
+)
+
+const (
+	traceIdLength = 16
+	spanIdLength  = 8
+)
+
+type Exporter interface {
+	// Export is called once for every span when it ends.
+	Export(span SpanData) error
+}
+
+// SpanData describes a span. All timestamps are in UTC.
+type SpanData struct {
+	TraceId      string         `json:"traceId"`
+	SpanId       string         `json:"spanId"`
+	ParentSpanId string         `json:"parentSpanId,omitempty"`
+	Name         string         `json:"name"`
+	Start        time.Time      `json:"start"`
+	End          time.Time      `json:"end"`
+	Attributes   map[string]any `json:"attributes,omitempty"`
+	Error        string         `json:"error,omitempty"`
+}
+
+func (s SpanData) Duration() time.Duration {
+	return s.End.Sub(s.Start)
+}
+
+type Tracer struct {
+	exporter Exporter
+	logger   logging.Logger
+}
+
+func NewTracer(exporter Exporter, logger logging.Logger) *Tracer {
+	return &Tracer{
+		exporter: exporter,
+		logger:   logger.New("tracer"),
+	}
+}
+
+// NewDevNullTracer returns a tracer which discards all spans.
+func NewDevNullTracer() *Tracer {
+	return NewTracer(NewDevNullExporter(), logging.NewDevNullLogger())
+}
+
+// Start starts a new span which is a child of the span stored in the provided
+// context, if any. Labels from the logging context are added to the span as
+// attributes. The returned context contains the new span and its ids are added
+// to the logging context so that log entries can be matched with spans.
+func (t *Tracer) Start(ctx context.Context, name string) (context.Context, *Span) {
+	span := &Span{
+		tracer: t,
+		data: SpanData{
+			SpanId:     newId(spanIdLength),
+			Name:       name,
+			Start:      time.Now().UTC(),
+			Attributes: make(map[string]any),
+		},
+	}
+
+	if parent, ok := spanFromContext(ctx); ok {
+		span.data.TraceId = parent.data.TraceId
+		span.data.ParentSpanId = parent.data.SpanId
+	} else {
+		span.data.TraceId = newId(traceIdLength)
+	}
+
+	for label, value := range logging.GetLoggingContext(ctx) {
+		if label == logging.TraceIdContextLabel || label == logging.SpanIdContextLabel {
+			continue
+		}
+		span.SetAttribute(label, value)
+	}
+
+	ctx = context.WithValue(ctx, spanContextKey, span)
+	ctx = logging.AddToLoggingContext(ctx, logging.TraceIdContextLabel, span.data.TraceId)
+	ctx = logging.AddToLoggingContext(ctx, logging.SpanIdContextLabel, span.data.SpanId)
+	return ctx, span
+}
+
+func (t *Tracer) export(data SpanData) {
+	if err := t.exporter.Export(data); err != nil {
+		t.logger.Debug().WithError(err).WithField("span", data.Name).Message("error exporting a span")
+	}
+}
+
+// Span is safe for concurrent use.
+type Span struct {
+	tracer *Tracer
+
+	data  SpanData
+	ended bool
+	lock  sync.Mutex // locks data and ended
+}
+
+// SetAttribute sets an attribute of the span. Values which aren't strings,
+// numbers or booleans are converted to strings.
+func (s *Span) SetAttribute(key string, value any) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.data.Attributes[key] = normalizeAttributeValue(value)
+}
+
+// SetError marks the span as failed if the error isn't nil.
+func (s *Span) SetError(err error) {
+	if err == nil {
+		return
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.data.Error = err.Error()
+}
+
+// End ends the span and exports it. Calling End more than once has no effect.
+func (s *Span) End() {
+	s.lock.Lock()
+	if s.ended {
+		s.lock.Unlock()
+		return
+	}
+	s.ended = true
+	s.data.End = time.Now().UTC()
+	data := s.copyData()
+	s.lock.Unlock()
+
+	s.tracer.export(data)
+}
+
+func (s *Span) copyData() SpanData {
+	data := s.data
+	data.Attributes = make(map[string]any, len(s.data.Attributes))
+	for key, value := range s.data.Attributes {
+		data.Attributes[key] = value
+	}
+	return data
+}
+
+type spanContextKeyType string
+
+const spanContextKey spanContextKeyType = "span"
+
+func spanFromContext(ctx context.Context) (*Span, bool) {
+	span, ok := ctx.Value(spanContextKey).(*Span)
+	return span, ok
+}
+
+func normalizeAttributeValue(value any) any {
+	switch v := value.(type) {
+	case string, bool, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+		return v
+	case fmt.Stringer:
+		return v.String()
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+func newId(length int) string {
+	b := make([]byte, length)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,120 @@
+package tracing_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/boreq/errors"
+	"github.com/planetary-social/scuttlego/logging"
+	"github.com/planetary-social/scuttlego/tracing"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTracer_ChildSpansBelongToTheTraceOfTheirParent(t *testing.T) {
+	exporter := tracing.NewInMemoryExporter()
+	tracer := tracing.NewTracer(exporter, logging.NewDevNullLogger())
+
+	ctx, parent := tracer.Start(context.Background(), "parent")
+	_, child := tracer.Start(ctx, "child")
+	_, unrelated := tracer.Start(context.Background(), "unrelated")
+
+	child.End()
+	parent.End()
+	unrelated.End()
+
+	spans := exporter.Spans()
+	require.Len(t, spans, 3)
+
+	childData, parentData, unrelatedData := spans[0], spans[1], spans[2]
+
+	require.Equal(t, "child", childData.Name)
+	require.Equal(t, "parent", parentData.Name)
+	require.Equal(t, "unrelated", unrelatedData.Name)
+
+	require.Equal(t, parentData.TraceId, childData.TraceId)
+	require.Equal(t, parentData.SpanId, childData.ParentSpanId)
+	require.Empty(t, parentData.ParentSpanId)
+
+	require.NotEqual(t, parentData.TraceId, unrelatedData.TraceId)
+	require.Empty(t, unrelatedData.ParentSpanId)
+
+	require.False(t, childData.Start.After(childData.End))
+}
+
+func TestTracer_SpansPickUpLabelsFromTheLoggingContext(t *testing.T) {
+	exporter := tracing.NewInMemoryExporter()
+	tracer := tracing.NewTracer(exporter, logging.NewDevNullLogger())
+
+	ctx := context.Background()
+	ctx = logging.AddToLoggingContext(ctx, logging.PeerIdContextLabel, "some-peer")
+	ctx = logging.AddToLoggingContext(ctx, logging.StreamIdContextLabel, -5)
+
+	ctx, parent := tracer.Start(ctx, "parent")
+
+	loggingContext := logging.GetLoggingContext(ctx)
+	require.NotEmpty(t, loggingContext[logging.TraceIdContextLabel])
+	require.NotEmpty(t, loggingContext[logging.SpanIdContextLabel])
+
+	_, child := tracer.Start(ctx, "child")
+	child.SetAttribute("stringer", someStringer{})
+	child.SetAttribute("struct", struct{ A int }{A: 1})
+	child.End()
+	parent.End()
+
+	require.Equal(t,
+		map[string]any{
+			logging.PeerIdContextLabel:   "some-peer",
+			logging.StreamIdContextLabel: -5,
+			"stringer":                   "some stringer",
+			"struct":                     "{1}",
+		},
+		exporter.Spans()[0].Attributes,
+	)
+}
+
+func TestSpan_EndingSpanMoreThanOnceExportsItOnce(t *testing.T) {
+	exporter := tracing.NewInMemoryExporter()
+	tracer := tracing.NewTracer(exporter, logging.NewDevNullLogger())
+
+	_, span := tracer.Start(context.Background(), "span")
+	span.SetError(nil)
+	span.SetError(errors.New("some error"))
+	span.End()
+	span.End()
+
+	spans := exporter.Spans()
+	require.Len(t, spans, 1)
+	require.Equal(t, "some error", spans[0].Error)
+}
+
+func TestJSONExporter_SpansCanBeReadBack(t *testing.T) {
+	buf := &bytes.Buffer{}
+	exporter := tracing.NewJSONExporter(buf)
+	tracer := tracing.NewTracer(exporter, logging.NewDevNullLogger())
+
+	ctx, parent := tracer.Start(context.Background(), "parent")
+	parent.SetAttribute("number", 10)
+	_, child := tracer.Start(ctx, "child")
+	child.SetError(errors.New("some error"))
+	child.End()
+	parent.End()
+
+	spans, err := tracing.ReadSpans(buf)
+	require.NoError(t, err)
+	require.Len(t, spans, 2)
+
+	require.Equal(t, "child", spans[0].Name)
+	require.Equal(t, "some error", spans[0].Error)
+	require.Equal(t, spans[1].SpanId, spans[0].ParentSpanId)
+
+	require.Equal(t, "parent", spans[1].Name)
+	require.Equal(t, map[string]any{"number": float64(10)}, spans[1].Attributes)
+}
+
+type someStringer struct {
+}
+
+func (s someStringer) String() string {
+	return "some stringer"
+}